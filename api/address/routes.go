@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"strconv"
 
 	"github.com/ElrondNetwork/elrond-go/api/errors"
 	"github.com/ElrondNetwork/elrond-go/data/state"
@@ -14,7 +15,13 @@ import (
 // FacadeHandler interface defines methods that can be used from `elrondFacade` context variable
 type FacadeHandler interface {
 	GetBalance(address string) (*big.Int, error)
+	GetBalanceAtBlock(address string, blockQuery *state.BlockQuery) (*big.Int, error)
 	GetAccount(address string) (*state.Account, error)
+	GetAccountAtBlock(address string, blockQuery *state.BlockQuery) (*state.Account, error)
+	GetStorageValue(address string, key string) ([]byte, error)
+	GetStorageEntries(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error)
+	GetAccountProof(address string) (*state.AccountProof, error)
+	GetAccountKeyProof(address string, key string) (*state.AccountProof, error)
 }
 
 type accountResponse struct {
@@ -26,14 +33,43 @@ type accountResponse struct {
 	RootHash []byte `json:"rootHash"`
 }
 
+// defaultStorageEntries is the number of storage entries returned when no limit is requested
+const defaultStorageEntries = 100
+
+// maxStorageEntries is the maximum number of storage entries returned by a single request
+const maxStorageEntries = 1000
+
+type storageEntryResponse struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	ValueString string `json:"valueString"`
+	ValueBigInt string `json:"valueBigInt"`
+}
+
+type accountProofResponse struct {
+	Address      string   `json:"address"`
+	HeaderNonce  uint64   `json:"headerNonce"`
+	HeaderHash   string   `json:"headerHash"`
+	RootHash     string   `json:"rootHash"`
+	Proof        []string `json:"proof"`
+	Key          string   `json:"key,omitempty"`
+	DataRootHash string   `json:"dataRootHash,omitempty"`
+	DataProof    []string `json:"dataProof,omitempty"`
+}
+
 // Routes defines address related routes
 func Routes(router *gin.RouterGroup) {
 	router.GET("/:address", GetAccount)
 	router.GET("/:address/balance", GetBalance)
+	router.GET("/:address/storage", GetStorageEntries)
+	router.GET("/:address/storage/:key", GetStorageValue)
+	router.GET("/:address/proof", GetAccountProof)
+	router.GET("/:address/key/:key/proof", GetAccountKeyProof)
 }
 
 // GetAccount returns an accountResponse containing information
-//  about the account correlated with provided address
+//  about the account correlated with provided address. The optional blockNonce or blockHash
+//  query parameters select the block whose state is read
 func GetAccount(c *gin.Context) {
 	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
 	if !ok {
@@ -41,8 +77,19 @@ func GetAccount(c *gin.Context) {
 		return
 	}
 
+	blockQuery, err := blockQueryFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrCouldNotGetAccount.Error(), err.Error())})
+		return
+	}
+
 	addr := c.Param("address")
-	acc, err := ef.GetAccount(addr)
+	var acc *state.Account
+	if blockQuery == nil {
+		acc, err = ef.GetAccount(addr)
+	} else {
+		acc, err = ef.GetAccountAtBlock(addr, blockQuery)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrCouldNotGetAccount.Error(), err.Error())})
 		return
@@ -50,7 +97,8 @@ func GetAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"account": accountResponseFromBaseAccount(addr, acc)})
 }
 
-// GetBalance returns the balance for the address parameter
+// GetBalance returns the balance for the address parameter. The optional blockNonce or blockHash
+//  query parameters select the block whose state is read
 func GetBalance(c *gin.Context) {
 	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
 	if !ok {
@@ -64,7 +112,18 @@ func GetBalance(c *gin.Context) {
 		return
 	}
 
-	balance, err := ef.GetBalance(addr)
+	blockQuery, err := blockQueryFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetBalance.Error(), err.Error())})
+		return
+	}
+
+	var balance *big.Int
+	if blockQuery == nil {
+		balance, err = ef.GetBalance(addr)
+	} else {
+		balance, err = ef.GetBalanceAtBlock(addr, blockQuery)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetBalance.Error(), err.Error())})
 		return
@@ -73,6 +132,180 @@ func GetBalance(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"balance": balance})
 }
 
+// GetStorageValue returns the value stored at the hex encoded key in the data trie of the account
+//  correlated with provided address, both hex encoded and decoded as string and as big integer
+func GetStorageValue(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	addr := c.Param("address")
+	key := c.Param("key")
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetStorage.Error(), errors.ErrInvalidStorageKey.Error())})
+		return
+	}
+
+	value, err := ef.GetStorageValue(addr, key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetStorage.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"storage": storageEntryResponseFromKeyValue(keyBytes, value)})
+}
+
+// GetStorageEntries returns a page of entries from the data trie of the account correlated with provided
+//  address. The optional start query parameter holds the hex encoded key of the first entry and the
+//  optional limit query parameter the maximum number of entries. The nextKey field of the response is the
+//  start key of the following page, if any
+func GetStorageEntries(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	limit := defaultStorageEntries
+	limitParam, hasLimit := c.GetQuery("limit")
+	if hasLimit {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 || limit > maxStorageEntries {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetStorage.Error(), errors.ErrInvalidLimit.Error())})
+			return
+		}
+	}
+
+	startKey := c.Query("start")
+	_, err := hex.DecodeString(startKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetStorage.Error(), errors.ErrInvalidStorageKey.Error())})
+		return
+	}
+
+	addr := c.Param("address")
+	entries, nextKey, err := ef.GetStorageEntries(addr, startKey, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetStorage.Error(), err.Error())})
+		return
+	}
+
+	response := make([]storageEntryResponse, len(entries))
+	for i, entry := range entries {
+		response[i] = storageEntryResponseFromKeyValue(entry.Key, entry.Value)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"storage": response, "nextKey": hex.EncodeToString(nextKey)})
+}
+
+func storageEntryResponseFromKeyValue(key []byte, value []byte) storageEntryResponse {
+	return storageEntryResponse{
+		Key:         hex.EncodeToString(key),
+		Value:       hex.EncodeToString(value),
+		ValueString: string(value),
+		ValueBigInt: big.NewInt(0).SetBytes(value).String(),
+	}
+}
+
+// GetAccountProof returns the merkle proof of the account correlated with provided address,
+//  against the state root hash of the current block
+func GetAccountProof(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	addr := c.Param("address")
+	accountProof, err := ef.GetAccountProof(addr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetProof.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"proof": accountProofResponseFromAccountProof(accountProof)})
+}
+
+// GetAccountKeyProof returns the merkle proofs of the account correlated with provided address
+//  and of the hex encoded storage key from the account data trie
+func GetAccountKeyProof(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	addr := c.Param("address")
+	key := c.Param("key")
+	accountProof, err := ef.GetAccountKeyProof(addr, key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetProof.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"proof": accountProofResponseFromAccountProof(accountProof)})
+}
+
+func accountProofResponseFromAccountProof(accountProof *state.AccountProof) accountProofResponse {
+	return accountProofResponse{
+		Address:      hex.EncodeToString(accountProof.Address),
+		HeaderNonce:  accountProof.HeaderNonce,
+		HeaderHash:   hex.EncodeToString(accountProof.HeaderHash),
+		RootHash:     hex.EncodeToString(accountProof.RootHash),
+		Proof:        hexEncodeProof(accountProof.Proof),
+		Key:          hex.EncodeToString(accountProof.Key),
+		DataRootHash: hex.EncodeToString(accountProof.DataRootHash),
+		DataProof:    hexEncodeProof(accountProof.DataProof),
+	}
+}
+
+func hexEncodeProof(proof [][]byte) []string {
+	if len(proof) == 0 {
+		return nil
+	}
+
+	encodedProof := make([]string, len(proof))
+	for i, encNode := range proof {
+		encodedProof[i] = hex.EncodeToString(encNode)
+	}
+
+	return encodedProof
+}
+
+// blockQueryFromContext builds the block query from the blockNonce or the hex encoded blockHash query
+//  parameters. It returns nil if none of them was provided, meaning the current state should be read
+func blockQueryFromContext(c *gin.Context) (*state.BlockQuery, error) {
+	blockNonce, hasNonce := c.GetQuery("blockNonce")
+	blockHash, hasHash := c.GetQuery("blockHash")
+	if hasNonce && hasHash {
+		return nil, errors.ErrBlockNonceAndHash
+	}
+
+	if hasHash {
+		hash, err := hex.DecodeString(blockHash)
+		if err != nil || len(hash) == 0 {
+			return nil, errors.ErrInvalidBlockHash
+		}
+
+		return &state.BlockQuery{Hash: hash}, nil
+	}
+
+	if hasNonce {
+		nonce, err := strconv.ParseUint(blockNonce, 10, 64)
+		if err != nil {
+			return nil, errors.ErrInvalidBlockNonce
+		}
+
+		return &state.BlockQuery{Nonce: nonce}, nil
+	}
+
+	return nil, nil
+}
+
 func accountResponseFromBaseAccount(address string, account *state.Account) accountResponse {
 	return accountResponse{
 		Address:  address,
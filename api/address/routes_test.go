@@ -1,6 +1,7 @@
 package address_test
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -54,6 +55,38 @@ type AccountResponse struct {
 	} `json:"account"`
 }
 
+type AccountProofResponse struct {
+	GeneralResponse
+	Proof struct {
+		Address      string   `json:"address"`
+		HeaderNonce  uint64   `json:"headerNonce"`
+		HeaderHash   string   `json:"headerHash"`
+		RootHash     string   `json:"rootHash"`
+		Proof        []string `json:"proof"`
+		Key          string   `json:"key"`
+		DataRootHash string   `json:"dataRootHash"`
+		DataProof    []string `json:"dataProof"`
+	} `json:"proof"`
+}
+
+type StorageEntry struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	ValueString string `json:"valueString"`
+	ValueBigInt string `json:"valueBigInt"`
+}
+
+type StorageValueResponse struct {
+	GeneralResponse
+	Storage StorageEntry `json:"storage"`
+}
+
+type StorageEntriesResponse struct {
+	GeneralResponse
+	Storage []StorageEntry `json:"storage"`
+	NextKey string         `json:"nextKey"`
+}
+
 func TestAddressRoute_EmptyTrailReturns404(t *testing.T) {
 	t.Parallel()
 	facade := mock.Facade{}
@@ -132,6 +165,45 @@ func TestGetBalance_NodeGetBalanceReturnsError(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetBalance.Error(), balanceError.Error()), addressResponse.Error)
 }
 
+func TestGetBalance_WithBlockNonceShouldReturnTheBalanceAtThatBlock(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		BalanceHandler: func(s string) (*big.Int, error) {
+			return nil, errors.New("the current balance should not be read")
+		},
+		BalanceAtBlockHandler: func(address string, blockQuery *state.BlockQuery) (*big.Int, error) {
+			assert.Equal(t, &state.BlockQuery{Nonce: 12}, blockQuery)
+			return big.NewInt(42), nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/testAddress/balance?blockNonce=12", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := NewAddressResponse()
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, big.NewInt(42), response.Balance)
+	assert.Empty(t, response.Error)
+}
+
+func TestGetBalance_WithInvalidBlockNonceShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/testAddress/balance?blockNonce=abc", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := NewAddressResponse()
+	loadResponse(resp.Body, &response)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.True(t, strings.Contains(response.Error, errors2.ErrInvalidBlockNonce.Error()))
+}
+
 func TestGetBalance_WithEmptyAddressShouldReturnZeroAndError(t *testing.T) {
 	t.Parallel()
 	facade := mock.Facade{
@@ -233,6 +305,289 @@ func TestGetAccount_ReturnsSuccessfully(t *testing.T) {
 	assert.Empty(t, accountResponse.Error)
 }
 
+func TestGetStorageValue_ReturnsHexAndDecodedValues(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetStorageValueHandler: func(address string, key string) ([]byte, error) {
+			assert.Equal(t, "6b6579", key)
+			return []byte("AB"), nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage/6b6579", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageValueResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, storageResponse.Error)
+	assert.Equal(t, "6b6579", storageResponse.Storage.Key)
+	assert.Equal(t, "4142", storageResponse.Storage.Value)
+	assert.Equal(t, "AB", storageResponse.Storage.ValueString)
+	assert.Equal(t, "16706", storageResponse.Storage.ValueBigInt)
+}
+
+func TestGetStorageValue_FailWhenFacadeFails(t *testing.T) {
+	t.Parallel()
+	returnedError := "i am an error"
+	facade := mock.Facade{
+		GetStorageValueHandler: func(address string, key string) ([]byte, error) {
+			return nil, errors.New(returnedError)
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage/aa", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageValueResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.True(t, strings.Contains(storageResponse.Error, fmt.Sprintf("%s: %s", errors2.ErrGetStorage.Error(), returnedError)))
+}
+
+func TestGetStorageValue_InvalidHexKeyShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetStorageValueHandler: func(address string, key string) ([]byte, error) {
+			assert.Fail(t, "the facade should not be called with an invalid key")
+			return nil, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage/zz", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageValueResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.True(t, strings.Contains(storageResponse.Error, errors2.ErrInvalidStorageKey.Error()))
+}
+
+func TestGetStorageEntries_ReturnsThePageAndTheNextKey(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetStorageEntriesHandler: func(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error) {
+			assert.Equal(t, "aa", startKey)
+			assert.Equal(t, 2, maxEntries)
+			return []*state.StorageEntry{
+				{Key: []byte{0xaa}, Value: []byte{1}},
+				{Key: []byte{0xab}, Value: []byte{2}},
+			}, []byte{0xac}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage?start=aa&limit=2", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageEntriesResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, storageResponse.Error)
+	assert.Equal(t, 2, len(storageResponse.Storage))
+	assert.Equal(t, "aa", storageResponse.Storage[0].Key)
+	assert.Equal(t, "01", storageResponse.Storage[0].Value)
+	assert.Equal(t, "2", storageResponse.Storage[1].ValueBigInt)
+	assert.Equal(t, "ac", storageResponse.NextKey)
+}
+
+func TestGetStorageEntries_WithoutLimitShouldUseTheDefaultLimit(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetStorageEntriesHandler: func(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error) {
+			assert.Equal(t, "", startKey)
+			assert.Equal(t, 100, maxEntries)
+			return make([]*state.StorageEntry, 0), nil, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageEntriesResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 0, len(storageResponse.Storage))
+	assert.Empty(t, storageResponse.NextKey)
+}
+
+func TestGetStorageEntries_InvalidLimitShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage?limit=1001", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageEntriesResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.True(t, strings.Contains(storageResponse.Error, errors2.ErrInvalidLimit.Error()))
+}
+
+func TestGetStorageEntries_InvalidHexStartKeyShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage?start=abc", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageEntriesResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.True(t, strings.Contains(storageResponse.Error, errors2.ErrInvalidStorageKey.Error()))
+}
+
+func TestGetAccountProof_FailWhenFacadeGetAccountProofFails(t *testing.T) {
+	t.Parallel()
+	returnedError := "i am an error"
+	facade := mock.Facade{
+		GetAccountProofHandler: func(address string) (*state.AccountProof, error) {
+			return nil, errors.New(returnedError)
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/proof", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	proofResponse := AccountProofResponse{}
+	loadResponse(resp.Body, &proofResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Empty(t, proofResponse.Proof)
+	assert.True(t, strings.Contains(proofResponse.Error, fmt.Sprintf("%s: %s", errors2.ErrGetProof.Error(), returnedError)))
+}
+
+func TestGetAccountProof_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetAccountProofHandler: func(address string) (*state.AccountProof, error) {
+			return &state.AccountProof{
+				HeaderNonce: 4,
+				HeaderHash:  []byte("header hash"),
+				RootHash:    []byte("root hash"),
+				Address:     []byte(address),
+				Proof:       [][]byte{[]byte("node1"), []byte("node2")},
+			}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/proof", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	proofResponse := AccountProofResponse{}
+	loadResponse(resp.Body, &proofResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, proofResponse.Error)
+	assert.Equal(t, hex.EncodeToString([]byte("test")), proofResponse.Proof.Address)
+	assert.Equal(t, uint64(4), proofResponse.Proof.HeaderNonce)
+	assert.Equal(t, hex.EncodeToString([]byte("header hash")), proofResponse.Proof.HeaderHash)
+	assert.Equal(t, hex.EncodeToString([]byte("root hash")), proofResponse.Proof.RootHash)
+	assert.Equal(t, []string{hex.EncodeToString([]byte("node1")), hex.EncodeToString([]byte("node2"))}, proofResponse.Proof.Proof)
+	assert.Empty(t, proofResponse.Proof.DataProof)
+}
+
+func TestGetAccountKeyProof_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetAccountKeyProofHandler: func(address string, key string) (*state.AccountProof, error) {
+			keyBytes, _ := hex.DecodeString(key)
+			return &state.AccountProof{
+				Address:      []byte(address),
+				Proof:        [][]byte{[]byte("node1")},
+				Key:          keyBytes,
+				DataRootHash: []byte("data root hash"),
+				DataProof:    [][]byte{[]byte("data node")},
+			}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/key/aabb/proof", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	proofResponse := AccountProofResponse{}
+	loadResponse(resp.Body, &proofResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, proofResponse.Error)
+	assert.Equal(t, "aabb", proofResponse.Proof.Key)
+	assert.Equal(t, hex.EncodeToString([]byte("data root hash")), proofResponse.Proof.DataRootHash)
+	assert.Equal(t, []string{hex.EncodeToString([]byte("data node"))}, proofResponse.Proof.DataProof)
+}
+
+func TestGetAccountKeyProof_FailsWithWrongFacadeTypeConversion(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/address/test/key/aabb/proof", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	proofResponse := AccountProofResponse{}
+	loadResponse(resp.Body, &proofResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors2.ErrInvalidAppContext.Error(), proofResponse.Error)
+}
+
+func TestGetAccount_WithBlockHashShouldReturnTheAccountAtThatBlock(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetAccountHandler: func(address string) (*state.Account, error) {
+			return nil, errors.New("the current account should not be read")
+		},
+		GetAccountAtBlockHandler: func(address string, blockQuery *state.BlockQuery) (*state.Account, error) {
+			assert.Equal(t, &state.BlockQuery{Hash: []byte{0xab, 0xcd}}, blockQuery)
+			return &state.Account{
+				Nonce:   3,
+				Balance: big.NewInt(50),
+			}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test?blockHash=abcd", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	accountResponse := AccountResponse{}
+	loadResponse(resp.Body, &accountResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, uint64(3), accountResponse.Account.Nonce)
+	assert.Equal(t, "50", accountResponse.Account.Balance)
+	assert.Empty(t, accountResponse.Error)
+}
+
+func TestGetAccount_WithBlockNonceAndHashShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test?blockHash=abcd&blockNonce=2", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	accountResponse := AccountResponse{}
+	loadResponse(resp.Body, &accountResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.True(t, strings.Contains(accountResponse.Error, errors2.ErrBlockNonceAndHash.Error()))
+}
+
 func loadResponse(rsp io.Reader, destination interface{}) {
 	jsonParser := json.NewDecoder(rsp)
 	err := jsonParser.Decode(destination)
@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/ElrondNetwork/elrond-go/api/address"
+	"github.com/ElrondNetwork/elrond-go/api/events"
 	"github.com/ElrondNetwork/elrond-go/api/middleware"
 	"github.com/ElrondNetwork/elrond-go/api/node"
 	"github.com/ElrondNetwork/elrond-go/api/transaction"
@@ -113,6 +114,10 @@ func registerRoutes(ws *gin.Engine, elrondFacade middleware.ElrondHandler) {
 	vmValuesRoutes.Use(middleware.WithElrondFacade(elrondFacade))
 	vmValues.Routes(vmValuesRoutes)
 
+	eventsRoutes := ws.Group("/events")
+	eventsRoutes.Use(middleware.WithElrondFacade(elrondFacade))
+	events.Routes(eventsRoutes)
+
 	apiHandler, ok := elrondFacade.(MainApiHandler)
 	if ok && apiHandler.PrometheusMonitoring() {
 		nodeRoutes.GET("/metrics", gin.WrapH(promhttp.Handler()))
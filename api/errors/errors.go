@@ -51,3 +51,33 @@ var ErrGetTransaction = errors.New("transaction getting failed")
 
 // ErrTxNotFound signals an error happend trying to fetch a transaction
 var ErrTxNotFound = errors.New("transaction was not found")
+
+// ErrGetReceipt signals an error happend trying to fetch a transaction receipt
+var ErrGetReceipt = errors.New("receipt getting failed")
+
+// ErrReceiptNotFound signals that no receipt was saved for the requested transaction
+var ErrReceiptNotFound = errors.New("receipt was not found")
+
+// ErrSubscribeEvents signals an error in subscribing to the events stream
+var ErrSubscribeEvents = errors.New("events subscription failed")
+
+// ErrGetProof signals an error happend trying to build a merkle proof
+var ErrGetProof = errors.New("proof getting failed")
+
+// ErrInvalidBlockNonce signals that the queried block nonce is not a valid unsigned integer
+var ErrInvalidBlockNonce = errors.New("invalid block nonce")
+
+// ErrInvalidBlockHash signals that the queried block hash is not a valid hex string
+var ErrInvalidBlockHash = errors.New("invalid block hash, could not decode hex value")
+
+// ErrBlockNonceAndHash signals that both the block nonce and the block hash were queried
+var ErrBlockNonceAndHash = errors.New("only one of block nonce and block hash should be provided")
+
+// ErrGetStorage signals an error happend trying to read the storage of an account
+var ErrGetStorage = errors.New("storage getting failed")
+
+// ErrInvalidLimit signals that the requested number of entries is not between 1 and the allowed maximum
+var ErrInvalidLimit = errors.New("invalid limit")
+
+// ErrInvalidStorageKey signals that the storage key is not hex encoded
+var ErrInvalidStorageKey = errors.New("invalid storage key")
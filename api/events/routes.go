@@ -0,0 +1,71 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/core/events"
+	"github.com/gin-gonic/gin"
+)
+
+// EventsService interface defines methods that can be used from `elrondFacade` context variable
+type EventsService interface {
+	SubscribeEvents(eventTypes []events.EventType) (*events.Subscription, error)
+	UnsubscribeEvents(subscription *events.Subscription)
+}
+
+// Routes defines events related routes
+func Routes(router *gin.RouterGroup) {
+	router.GET("/stream", Stream)
+}
+
+// Stream pushes the events of the committed blocks as server-sent events until the client disconnects. The
+// optional types query parameter holds the comma separated event types to be streamed, all of them by default
+func Stream(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(EventsService)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	subscription, err := ef.SubscribeEvents(parseEventTypes(c.Query("types")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrSubscribeEvents.Error(), err.Error())})
+		return
+	}
+	defer ef.UnsubscribeEvents(subscription)
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, isOpen := <-subscription.Events():
+			if !isOpen {
+				return false
+			}
+			c.SSEvent(string(event.Type), event)
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func parseEventTypes(types string) []events.EventType {
+	if len(types) == 0 {
+		return events.AllEventTypes
+	}
+
+	eventTypes := make([]events.EventType, 0)
+	for _, eventType := range strings.Split(types, ",") {
+		eventTypes = append(eventTypes, events.EventType(strings.TrimSpace(eventType)))
+	}
+
+	return eventTypes
+}
@@ -0,0 +1,132 @@
+package events_test
+
+import (
+	"bufio"
+	"encoding/json"
+	errs "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/api/errors"
+	apiEvents "github.com/ElrondNetwork/elrond-go/api/events"
+	"github.com/ElrondNetwork/elrond-go/api/mock"
+	"github.com/ElrondNetwork/elrond-go/core/events"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type GeneralResponse struct {
+	Error string `json:"error"`
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestStream_FailsWithWrongFacadeTypeConversion(t *testing.T) {
+	t.Parallel()
+
+	ws := startEventsServer(mock.WrongFacade{})
+	req, _ := http.NewRequest("GET", "/events/stream", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := GeneralResponse{}
+	_ = json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors.ErrInvalidAppContext.Error(), response.Error)
+}
+
+func TestStream_SubscribeErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	facade := &mock.Facade{
+		SubscribeEventsHandler: func(eventTypes []events.EventType) (*events.Subscription, error) {
+			return nil, errs.New("subscribe error")
+		},
+	}
+	ws := startEventsServer(facade)
+	req, _ := http.NewRequest("GET", "/events/stream?types=unknown", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := GeneralResponse{}
+	_ = json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, response.Error, errors.ErrSubscribeEvents.Error())
+}
+
+func TestStream_ShouldSubscribeToAllEventTypesByDefault(t *testing.T) {
+	t.Parallel()
+
+	var subscribedTypes []events.EventType
+	facade := &mock.Facade{
+		SubscribeEventsHandler: func(eventTypes []events.EventType) (*events.Subscription, error) {
+			subscribedTypes = eventTypes
+			return nil, errs.New("subscribe error")
+		},
+	}
+	ws := startEventsServer(facade)
+	req, _ := http.NewRequest("GET", "/events/stream", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	assert.Equal(t, events.AllEventTypes, subscribedTypes)
+}
+
+func TestStream_ShouldPushTheEvents(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, _ := events.NewEventsDispatcher(10)
+	subscribed := make(chan []events.EventType, 1)
+	unsubscribed := make(chan struct{}, 1)
+	facade := &mock.Facade{
+		SubscribeEventsHandler: func(eventTypes []events.EventType) (*events.Subscription, error) {
+			subscription, err := dispatcher.Subscribe(eventTypes)
+			subscribed <- eventTypes
+			return subscription, err
+		},
+		UnsubscribeEventsHandler: func(subscription *events.Subscription) {
+			dispatcher.Unsubscribe(subscription)
+			unsubscribed <- struct{}{}
+		},
+	}
+	server := httptest.NewServer(startEventsServer(facade))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/events/stream?types=committedHeader")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []events.EventType{events.CommittedHeaderEvent}, <-subscribed)
+
+	dispatcher.NotifyCommittedBlock([]byte("hash"), &block.Header{Nonce: 3}, nil)
+
+	reader := bufio.NewReader(resp.Body)
+	eventLine, _ := reader.ReadString('\n')
+	dataLine, _ := reader.ReadString('\n')
+	assert.Equal(t, "event:committedHeader\n", eventLine)
+
+	event := events.Event{}
+	err = json.Unmarshal([]byte(strings.TrimPrefix(dataLine, "data:")), &event)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), event.BlockNonce)
+
+	_ = resp.Body.Close()
+	<-unsubscribed
+}
+
+func startEventsServer(facade interface{}) *gin.Engine {
+	ws := gin.New()
+	ws.Use(cors.Default())
+	ws.Use(func(c *gin.Context) {
+		c.Set("elrondFacade", facade)
+	})
+
+	eventsRoutes := ws.Group("/events")
+	apiEvents.Routes(eventsRoutes)
+	return ws
+}
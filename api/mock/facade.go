@@ -4,7 +4,9 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat"
@@ -19,13 +21,23 @@ type Facade struct {
 	TpsBenchmarkHandler                            func() *statistics.TpsBenchmark
 	GetHeartbeatsHandler                           func() ([]heartbeat.PubKeyHeartbeat, error)
 	BalanceHandler                                 func(string) (*big.Int, error)
+	BalanceAtBlockHandler                          func(address string, blockQuery *state.BlockQuery) (*big.Int, error)
 	GetAccountHandler                              func(address string) (*state.Account, error)
+	GetAccountAtBlockHandler                       func(address string, blockQuery *state.BlockQuery) (*state.Account, error)
+	GetStorageValueHandler                         func(address string, key string) ([]byte, error)
+	GetStorageEntriesHandler                       func(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error)
+	GetAccountProofHandler                         func(address string) (*state.AccountProof, error)
+	GetAccountKeyProofHandler                      func(address string, key string) (*state.AccountProof, error)
 	GenerateTransactionHandler                     func(sender string, receiver string, value *big.Int, code string) (*transaction.Transaction, error)
 	GetTransactionHandler                          func(hash string) (*transaction.Transaction, error)
-	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, code string, signature []byte) (string, error)
+	GetTransactionReceiptHandler                   func(hash string) (*receipt.Receipt, error)
+	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, code string, signature []byte, chainID string, version uint32) (string, string, error)
 	GenerateAndSendBulkTransactionsHandler         func(destination string, value *big.Int, nrTransactions uint64) error
 	GenerateAndSendBulkTransactionsOneByOneHandler func(destination string, value *big.Int, nrTransactions uint64) error
 	GetDataValueHandler                            func(address string, funcName string, argsBuff ...[]byte) ([]byte, error)
+	GetDataValueAtBlockHandler                     func(blockQuery *state.BlockQuery, address string, funcName string, argsBuff ...[]byte) ([]byte, error)
+	SubscribeEventsHandler                         func(eventTypes []events.EventType) (*events.Subscription, error)
+	UnsubscribeEventsHandler                       func(subscription *events.Subscription)
 }
 
 // IsNodeRunning is the mock implementation of a handler's IsNodeRunning method
@@ -72,11 +84,41 @@ func (f *Facade) GetBalance(address string) (*big.Int, error) {
 	return f.BalanceHandler(address)
 }
 
+// GetBalanceAtBlock is the mock implementation of a handler's GetBalanceAtBlock method
+func (f *Facade) GetBalanceAtBlock(address string, blockQuery *state.BlockQuery) (*big.Int, error) {
+	return f.BalanceAtBlockHandler(address, blockQuery)
+}
+
 // GetAccount is the mock implementation of a handler's GetAccount method
 func (f *Facade) GetAccount(address string) (*state.Account, error) {
 	return f.GetAccountHandler(address)
 }
 
+// GetAccountAtBlock is the mock implementation of a handler's GetAccountAtBlock method
+func (f *Facade) GetAccountAtBlock(address string, blockQuery *state.BlockQuery) (*state.Account, error) {
+	return f.GetAccountAtBlockHandler(address, blockQuery)
+}
+
+// GetStorageValue is the mock implementation of a handler's GetStorageValue method
+func (f *Facade) GetStorageValue(address string, key string) ([]byte, error) {
+	return f.GetStorageValueHandler(address, key)
+}
+
+// GetStorageEntries is the mock implementation of a handler's GetStorageEntries method
+func (f *Facade) GetStorageEntries(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error) {
+	return f.GetStorageEntriesHandler(address, startKey, maxEntries)
+}
+
+// GetAccountProof is the mock implementation of a handler's GetAccountProof method
+func (f *Facade) GetAccountProof(address string) (*state.AccountProof, error) {
+	return f.GetAccountProofHandler(address)
+}
+
+// GetAccountKeyProof is the mock implementation of a handler's GetAccountKeyProof method
+func (f *Facade) GetAccountKeyProof(address string, key string) (*state.AccountProof, error) {
+	return f.GetAccountKeyProofHandler(address, key)
+}
+
 // GenerateTransaction is the mock implementation of a handler's GenerateTransaction method
 func (f *Facade) GenerateTransaction(sender string, receiver string, value *big.Int,
 	code string) (*transaction.Transaction, error) {
@@ -88,9 +130,14 @@ func (f *Facade) GetTransaction(hash string) (*transaction.Transaction, error) {
 	return f.GetTransactionHandler(hash)
 }
 
+// GetTransactionReceipt is the mock implementation of a handler's GetTransactionReceipt method
+func (f *Facade) GetTransactionReceipt(hash string) (*receipt.Receipt, error) {
+	return f.GetTransactionReceiptHandler(hash)
+}
+
 // SendTransaction is the mock implementation of a handler's SendTransaction method
-func (f *Facade) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, code string, signature []byte) (string, error) {
-	return f.SendTransactionHandler(nonce, sender, receiver, value, gasPrice, gasLimit, code, signature)
+func (f *Facade) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, code string, signature []byte, chainID string, version uint32) (string, string, error) {
+	return f.SendTransactionHandler(nonce, sender, receiver, value, gasPrice, gasLimit, code, signature, chainID, version)
 }
 
 // GenerateAndSendBulkTransactions is the mock implementation of a handler's GenerateAndSendBulkTransactions method
@@ -107,6 +154,25 @@ func (f *Facade) GetVmValue(address string, funcName string, argsBuff ...[]byte)
 	return f.GetDataValueHandler(address, funcName, argsBuff...)
 }
 
+func (f *Facade) GetVmValueAtBlock(
+	blockQuery *state.BlockQuery,
+	address string,
+	funcName string,
+	argsBuff ...[]byte,
+) ([]byte, error) {
+	return f.GetDataValueAtBlockHandler(blockQuery, address, funcName, argsBuff...)
+}
+
+// SubscribeEvents is the mock implementation of a handler's SubscribeEvents method
+func (f *Facade) SubscribeEvents(eventTypes []events.EventType) (*events.Subscription, error) {
+	return f.SubscribeEventsHandler(eventTypes)
+}
+
+// UnsubscribeEvents is the mock implementation of a handler's UnsubscribeEvents method
+func (f *Facade) UnsubscribeEvents(subscription *events.Subscription) {
+	f.UnsubscribeEventsHandler(subscription)
+}
+
 // WrongFacade is a struct that can be used as a wrong implementation of the node router handler
 type WrongFacade struct {
 }
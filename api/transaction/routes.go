@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/gin-gonic/gin"
 )
@@ -14,8 +15,9 @@ import (
 // TxService interface defines methods that can be used from `elrondFacade` context variable
 type TxService interface {
 	GenerateTransaction(sender string, receiver string, value *big.Int, code string) (*transaction.Transaction, error)
-	SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, code string, signature []byte) (string, error)
+	SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, code string, signature []byte, chainID string, version uint32) (string, string, error)
 	GetTransaction(hash string) (*transaction.Transaction, error)
+	GetTransactionReceipt(hash string) (*receipt.Receipt, error)
 	GenerateAndSendBulkTransactions(string, *big.Int, uint64) error
 	GenerateAndSendBulkTransactionsOneByOne(string, *big.Int, uint64) error
 }
@@ -47,6 +49,8 @@ type SendTxRequest struct {
 	GasLimit  uint64   `form:"gasLimit" json:"gasLimit"`
 	Signature string   `form:"signature" json:"signature"`
 	Challenge string   `form:"challenge" json:"challenge"`
+	ChainID   string   `form:"chainID" json:"chainID"`
+	Version   uint32   `form:"version" json:"version"`
 }
 
 //TxResponse represents the structure on which the response will be validated against
@@ -59,6 +63,26 @@ type TxResponse struct {
 	Timestamp   uint64 `json:"timestamp"`
 }
 
+// LogEntryResponse represents a log entry of a receipt as it is returned by the api
+type LogEntryResponse struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// ReceiptResponse represents the structure on which the receipt response will be validated against
+type ReceiptResponse struct {
+	TxHash        string             `json:"txHash"`
+	Status        string             `json:"status"`
+	BlockHash     string             `json:"blockHash"`
+	BlockNonce    uint64             `json:"blockNonce"`
+	GasUsed       uint64             `json:"gasUsed"`
+	RefundedValue *big.Int           `json:"refundedValue"`
+	ReturnCode    string             `json:"returnCode"`
+	ReturnData    []string           `json:"returnData"`
+	Logs          []LogEntryResponse `json:"logs"`
+}
+
 // Routes defines transaction related routes
 func Routes(router *gin.RouterGroup) {
 	router.POST("/generate", GenerateTransaction)
@@ -66,6 +90,7 @@ func Routes(router *gin.RouterGroup) {
 	router.POST("/generate-and-send-multiple-one-by-one", GenerateAndSendBulkTransactionsOneByOne)
 	router.POST("/send", SendTransaction)
 	router.GET("/:txhash", GetTransaction)
+	router.GET("/:txhash/receipt", GetTransactionReceipt)
 }
 
 // GenerateTransaction generates a new transaction given a sender, receiver, value and data
@@ -113,13 +138,18 @@ func SendTransaction(c *gin.Context) {
 		return
 	}
 
-	txHash, err := ef.SendTransaction(gtx.Nonce, gtx.Sender, gtx.Receiver, gtx.Value, gtx.GasPrice, gtx.GasLimit, gtx.Data, signature)
+	txHash, replacedTxHash, err := ef.SendTransaction(gtx.Nonce, gtx.Sender, gtx.Receiver, gtx.Value, gtx.GasPrice, gtx.GasLimit, gtx.Data, signature, gtx.ChainID, gtx.Version)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrTxGenerationFailed.Error(), err.Error())})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"txHash": txHash})
+	if len(replacedTxHash) > 0 {
+		c.JSON(http.StatusOK, gin.H{"txHash": txHash, "replaced": true, "replacedTxHash": replacedTxHash})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"txHash": txHash, "replaced": false})
 }
 
 // GenerateAndSendBulkTransactions generates multipleTransactions
@@ -199,6 +229,66 @@ func GetTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"transaction": txResponseFromTransaction(tx)})
 }
 
+// GetTransactionReceipt returns the receipt of a committed transaction for a given txhash
+func GetTransactionReceipt(c *gin.Context) {
+	ef, ok := c.MustGet("elrondFacade").(TxService)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	txhash := c.Param("txhash")
+	if txhash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyTxHash.Error())})
+		return
+	}
+
+	rcpt, err := ef.GetTransactionReceipt(txhash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetReceipt.Error(), err.Error())})
+		return
+	}
+
+	if rcpt == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": errors.ErrReceiptNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"receipt": receiptResponseFromReceipt(rcpt)})
+}
+
+func receiptResponseFromReceipt(rcpt *receipt.Receipt) ReceiptResponse {
+	response := ReceiptResponse{}
+	response.TxHash = hex.EncodeToString(rcpt.TxHash)
+	response.Status = rcpt.Status.String()
+	response.BlockHash = hex.EncodeToString(rcpt.BlockHash)
+	response.BlockNonce = rcpt.BlockNonce
+	response.GasUsed = rcpt.GasUsed
+	response.RefundedValue = rcpt.RefundedValue
+	response.ReturnCode = rcpt.ReturnCode
+
+	response.ReturnData = make([]string, 0, len(rcpt.ReturnData))
+	for _, data := range rcpt.ReturnData {
+		response.ReturnData = append(response.ReturnData, hex.EncodeToString(data))
+	}
+
+	response.Logs = make([]LogEntryResponse, 0, len(rcpt.Logs))
+	for _, logEntry := range rcpt.Logs {
+		topics := make([]string, 0, len(logEntry.Topics))
+		for _, topic := range logEntry.Topics {
+			topics = append(topics, hex.EncodeToString(topic))
+		}
+
+		response.Logs = append(response.Logs, LogEntryResponse{
+			Address: hex.EncodeToString(logEntry.Address),
+			Topics:  topics,
+			Data:    hex.EncodeToString(logEntry.Data),
+		})
+	}
+
+	return response
+}
+
 func txResponseFromTransaction(tx *transaction.Transaction) TxResponse {
 	response := TxResponse{}
 	response.Nonce = tx.Nonce
@@ -210,6 +300,8 @@ func txResponseFromTransaction(tx *transaction.Transaction) TxResponse {
 	response.Value = tx.Value
 	response.GasLimit = tx.GasLimit
 	response.GasPrice = tx.GasPrice
+	response.ChainID = string(tx.ChainID)
+	response.Version = tx.Version
 
 	return response
 }
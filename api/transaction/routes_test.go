@@ -16,6 +16,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/api/middleware"
 	"github.com/ElrondNetwork/elrond-go/api/mock"
 	"github.com/ElrondNetwork/elrond-go/api/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	tr "github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -34,7 +35,14 @@ type TransactionResponse struct {
 
 type TransactionHashResponse struct {
 	GeneralResponse
-	TxHash string `json:"txHash,omitempty"`
+	TxHash         string `json:"txHash,omitempty"`
+	Replaced       bool   `json:"replaced"`
+	ReplacedTxHash string `json:"replacedTxHash,omitempty"`
+}
+
+type ReceiptResponse struct {
+	GeneralResponse
+	Receipt *transaction.ReceiptResponse `json:"receipt,omitempty"`
 }
 
 func init() {
@@ -55,6 +63,8 @@ func TestGenerateTransaction_WithParametersShouldReturnTransaction(t *testing.T)
 				RcvAddr: []byte(receiver),
 				Data:    data,
 				Value:   value,
+				ChainID: []byte("chain ID"),
+				Version: 1,
 			}, nil
 		},
 	}
@@ -84,6 +94,8 @@ func TestGenerateTransaction_WithParametersShouldReturnTransaction(t *testing.T)
 	assert.Equal(t, hex.EncodeToString([]byte(receiver)), txResp.Receiver)
 	assert.Equal(t, value, txResp.Value)
 	assert.Equal(t, data, txResp.Data)
+	assert.Equal(t, "chain ID", txResp.ChainID)
+	assert.Equal(t, uint32(1), txResp.Version)
 }
 
 func TestGenerateAndSendMultipleTransaction_WithParametersShouldReturnNoError(t *testing.T) {
@@ -213,6 +225,86 @@ func TestGetTransaction_WithUnknownHashShouldReturnNil(t *testing.T) {
 	assert.Nil(t, transactionResponse.TxResp)
 }
 
+func TestGetTransactionReceipt_ShouldReturnReceipt(t *testing.T) {
+	txHash := []byte("tx hash")
+	facade := mock.Facade{
+		GetTransactionReceiptHandler: func(hash string) (*receipt.Receipt, error) {
+			return &receipt.Receipt{
+				TxHash:        txHash,
+				Status:        receipt.Failed,
+				BlockNonce:    3,
+				GasUsed:       100,
+				RefundedValue: big.NewInt(20),
+				ReturnCode:    "out of gas",
+				ReturnData:    [][]byte{[]byte("data")},
+				Logs: []*receipt.LogEntry{
+					{Address: []byte("address"), Topics: [][]byte{[]byte("topic")}, Data: []byte("log data")},
+				},
+			}, nil
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "/transaction/"+hex.EncodeToString(txHash)+"/receipt", nil)
+	ws := startNodeServer(&facade)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	receiptResponse := ReceiptResponse{}
+	loadResponse(resp.Body, &receiptResponse)
+
+	rcptResp := receiptResponse.Receipt
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, hex.EncodeToString(txHash), rcptResp.TxHash)
+	assert.Equal(t, "failed", rcptResp.Status)
+	assert.Equal(t, uint64(3), rcptResp.BlockNonce)
+	assert.Equal(t, uint64(100), rcptResp.GasUsed)
+	assert.Equal(t, big.NewInt(20), rcptResp.RefundedValue)
+	assert.Equal(t, "out of gas", rcptResp.ReturnCode)
+	assert.Equal(t, []string{hex.EncodeToString([]byte("data"))}, rcptResp.ReturnData)
+	assert.Equal(t, 1, len(rcptResp.Logs))
+	assert.Equal(t, hex.EncodeToString([]byte("address")), rcptResp.Logs[0].Address)
+	assert.Equal(t, []string{hex.EncodeToString([]byte("topic"))}, rcptResp.Logs[0].Topics)
+}
+
+func TestGetTransactionReceipt_WithUnknownHashShouldReturnNotFound(t *testing.T) {
+	facade := mock.Facade{
+		GetTransactionReceiptHandler: func(hash string) (*receipt.Receipt, error) {
+			return nil, nil
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "/transaction/aa/receipt", nil)
+	ws := startNodeServer(&facade)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	receiptResponse := ReceiptResponse{}
+	loadResponse(resp.Body, &receiptResponse)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Nil(t, receiptResponse.Receipt)
+	assert.Equal(t, errors2.ErrReceiptNotFound.Error(), receiptResponse.Error)
+}
+
+func TestGetTransactionReceipt_FacadeErrorShouldReturnInternalServerError(t *testing.T) {
+	facade := mock.Facade{
+		GetTransactionReceiptHandler: func(hash string) (*receipt.Receipt, error) {
+			return nil, errors.New("storage error")
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "/transaction/aa/receipt", nil)
+	ws := startNodeServer(&facade)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	receiptResponse := ReceiptResponse{}
+	loadResponse(resp.Body, &receiptResponse)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, receiptResponse.Error, errors2.ErrGetReceipt.Error())
+}
+
 func TestGenerateTransaction_WithBadJsonShouldReturnBadRequest(t *testing.T) {
 	t.Parallel()
 
@@ -429,8 +521,8 @@ func TestSendTransaction_ErrorWhenFacadeSendTransactionError(t *testing.T) {
 
 	facade := mock.Facade{
 		SendTransactionHandler: func(nonce uint64, sender string, receiver string, value *big.Int,
-			gasPrice uint64, gasLimit uint64, code string, signature []byte) (string, error) {
-			return "", errors.New(errorString)
+			gasPrice uint64, gasLimit uint64, code string, signature []byte, chainID string, version uint32) (string, string, error) {
+			return "", "", errors.New(errorString)
 		},
 	}
 	ws := startNodeServer(&facade)
@@ -468,8 +560,8 @@ func TestSendTransaction_ReturnsSuccessfully(t *testing.T) {
 
 	facade := mock.Facade{
 		SendTransactionHandler: func(nonce uint64, sender string, receiver string, value *big.Int,
-			gasPrice uint64, gasLimit uint64, code string, signature []byte) (string, error) {
-			return txHash, nil
+			gasPrice uint64, gasLimit uint64, code string, signature []byte, chainID string, version uint32) (string, string, error) {
+			return txHash, "", nil
 		},
 	}
 	ws := startNodeServer(&facade)
@@ -495,6 +587,63 @@ func TestSendTransaction_ReturnsSuccessfully(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.Code)
 	assert.Empty(t, txHashResponse.Error)
 	assert.Equal(t, txHashResponse.TxHash, txHash)
+	assert.False(t, txHashResponse.Replaced)
+}
+
+func TestSendTransaction_ReplacementShouldReturnReplacedTxHash(t *testing.T) {
+	t.Parallel()
+	txHash := "tx hash"
+	replacedTxHash := "replaced tx hash"
+
+	facade := mock.Facade{
+		SendTransactionHandler: func(nonce uint64, sender string, receiver string, value *big.Int,
+			gasPrice uint64, gasLimit uint64, code string, signature []byte, chainID string, version uint32) (string, string, error) {
+			return txHash, replacedTxHash, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	jsonStr := `{"nonce": 1, "sender": "sender", "receiver": "receiver", "value": 10, "gasPrice": 110, "signature": "aabbccdd"}`
+
+	req, _ := http.NewRequest("POST", "/transaction/send", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	txHashResponse := TransactionHashResponse{}
+	loadResponse(resp.Body, &txHashResponse)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, txHash, txHashResponse.TxHash)
+	assert.True(t, txHashResponse.Replaced)
+	assert.Equal(t, replacedTxHash, txHashResponse.ReplacedTxHash)
+}
+
+func TestSendTransaction_ShouldForwardChainIDAndVersion(t *testing.T) {
+	t.Parallel()
+
+	receivedChainID := ""
+	receivedVersion := uint32(0)
+	facade := mock.Facade{
+		SendTransactionHandler: func(nonce uint64, sender string, receiver string, value *big.Int,
+			gasPrice uint64, gasLimit uint64, code string, signature []byte, chainID string, version uint32) (string, string, error) {
+			receivedChainID = chainID
+			receivedVersion = version
+			return "tx hash", "", nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	jsonStr := `{"nonce": 1, "sender": "sender", "receiver": "receiver", "value": 10, "signature": "aabbccdd", "chainID": "chain ID", "version": 1}`
+
+	req, _ := http.NewRequest("POST", "/transaction/send", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "chain ID", receivedChainID)
+	assert.Equal(t, uint32(1), receivedVersion)
 }
 
 func loadResponse(rsp io.Reader, destination interface{}) {
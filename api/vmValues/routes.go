@@ -8,19 +8,24 @@ import (
 	"net/http"
 
 	apiErrors "github.com/ElrondNetwork/elrond-go/api/errors"
+	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/gin-gonic/gin"
 )
 
 // FacadeHandler interface defines methods that can be used from `elrondFacade` context variable
 type FacadeHandler interface {
 	GetVmValue(address string, funcName string, argsBuff ...[]byte) ([]byte, error)
+	GetVmValueAtBlock(blockQuery *state.BlockQuery, address string, funcName string, argsBuff ...[]byte) ([]byte, error)
 }
 
-// VmValueRequest represents the structure on which user input for generating a new transaction will validate against
+// VmValueRequest represents the structure on which user input for generating a new transaction will validate against.
+// The optional block nonce or hex encoded block hash select the block whose state is queried
 type VmValueRequest struct {
-	ScAddress string   `form:"scAddress" json:"scAddress"`
-	FuncName  string   `form:"funcName" json:"funcName"`
-	Args      []string `form:"args"  json:"args"`
+	ScAddress  string   `form:"scAddress" json:"scAddress"`
+	FuncName   string   `form:"funcName" json:"funcName"`
+	Args       []string `form:"args"  json:"args"`
+	BlockNonce *uint64  `form:"blockNonce" json:"blockNonce"`
+	BlockHash  string   `form:"blockHash" json:"blockHash"`
 }
 
 // Routes defines address related routes
@@ -61,7 +66,17 @@ func vmValueFromAccount(c *gin.Context) ([]byte, int, error) {
 			errors.New(fmt.Sprintf("'%s' is not a valid hex string: %s", gval.ScAddress, err.Error()))
 	}
 
-	returnedData, err := ef.GetVmValue(string(adrBytes), gval.FuncName, argsBuff...)
+	blockQuery, err := blockQueryFromRequest(&gval)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var returnedData []byte
+	if blockQuery == nil {
+		returnedData, err = ef.GetVmValue(string(adrBytes), gval.FuncName, argsBuff...)
+	} else {
+		returnedData, err = ef.GetVmValueAtBlock(blockQuery, string(adrBytes), gval.FuncName, argsBuff...)
+	}
 	if err != nil {
 		return nil, http.StatusBadRequest, err
 	}
@@ -69,6 +84,27 @@ func vmValueFromAccount(c *gin.Context) ([]byte, int, error) {
 	return returnedData, http.StatusOK, nil
 }
 
+func blockQueryFromRequest(gval *VmValueRequest) (*state.BlockQuery, error) {
+	if gval.BlockNonce != nil && len(gval.BlockHash) > 0 {
+		return nil, apiErrors.ErrBlockNonceAndHash
+	}
+
+	if len(gval.BlockHash) > 0 {
+		hash, err := hex.DecodeString(gval.BlockHash)
+		if err != nil {
+			return nil, apiErrors.ErrInvalidBlockHash
+		}
+
+		return &state.BlockQuery{Hash: hash}, nil
+	}
+
+	if gval.BlockNonce != nil {
+		return &state.BlockQuery{Nonce: *gval.BlockNonce}, nil
+	}
+
+	return nil, nil
+}
+
 // GetVmValueAsHexBytes returns the data as byte slice
 func GetVmValueAsHexBytes(c *gin.Context) {
 	data, status, err := vmValueFromAccount(c)
@@ -15,6 +15,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/api/middleware"
 	"github.com/ElrondNetwork/elrond-go/api/mock"
 	"github.com/ElrondNetwork/elrond-go/api/vmValues"
+	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/json"
@@ -370,3 +371,75 @@ func TestGetDataValueAsInt_WithParametersShouldReturnValueAsHex(t *testing.T) {
 	assert.Equal(t, "", response.Error)
 	assert.Equal(t, valueBuff, response.Data)
 }
+
+//------- block queries
+
+func TestGetDataValueAsHexBytes_WithBlockNonceShouldQueryTheBlockState(t *testing.T) {
+	t.Parallel()
+
+	valueBuff, _ := hex.DecodeString("DEADBEEF")
+	facade := mock.Facade{
+		GetDataValueHandler: func(address string, funcName string, argsBuff ...[]byte) ([]byte, error) {
+			return nil, errors.New("the current state should not be queried")
+		},
+		GetDataValueAtBlockHandler: func(blockQuery *state.BlockQuery, address string, funcName string, argsBuff ...[]byte) ([]byte, error) {
+			if blockQuery.Nonce != 7 || len(blockQuery.Hash) != 0 {
+				return nil, errors.New("unexpected block query")
+			}
+			return valueBuff, nil
+		},
+	}
+
+	ws := startNodeServer(&facade)
+
+	jsonStr := `{"scAddress":"aaaa", "funcName":"function", "args":[], "blockNonce":7}`
+	req, _ := http.NewRequest("POST", "/get-values/hex", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := GeneralResponse{}
+	loadResponse(resp.Body, &response)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "", response.Error)
+	assert.Equal(t, hex.EncodeToString(valueBuff), response.Data)
+}
+
+func TestGetDataValueAsHexBytes_WithBlockNonceAndHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	jsonStr := `{"scAddress":"aaaa", "funcName":"function", "args":[], "blockNonce":7, "blockHash":"abcd"}`
+	req, _ := http.NewRequest("POST", "/get-values/hex", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := GeneralResponse{}
+	loadResponse(resp.Body, &response)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, response.Error, apiErrors.ErrBlockNonceAndHash.Error())
+}
+
+func TestGetDataValueAsHexBytes_WithInvalidBlockHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	jsonStr := `{"scAddress":"aaaa", "funcName":"function", "args":[], "blockHash":"not hex"}`
+	req, _ := http.NewRequest("POST", "/get-values/hex", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	response := GeneralResponse{}
+	loadResponse(resp.Body, &response)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, response.Error, apiErrors.ErrInvalidBlockHash.Error())
+}
@@ -0,0 +1,719 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"path/filepath"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go/hashing/sha256"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+)
+
+// exportedBlock is the JSON representation of a block together with its miniblocks
+type exportedBlock struct {
+	Hash            string               `json:"hash"`
+	Nonce           uint64               `json:"nonce"`
+	Round           uint64               `json:"round"`
+	Epoch           uint32               `json:"epoch"`
+	ShardId         uint32               `json:"shardId"`
+	TimeStamp       uint64               `json:"timeStamp"`
+	PrevHash        string               `json:"prevHash"`
+	RandSeed        string               `json:"randSeed"`
+	PubKeysBitmap   string               `json:"pubKeysBitmap"`
+	Signature       string               `json:"signature"`
+	RootHash        string               `json:"rootHash"`
+	TxCount         uint32               `json:"txCount"`
+	MetaBlockHashes []string             `json:"metaBlockHashes,omitempty"`
+	MiniBlocks      []*exportedMiniBlock `json:"miniBlocks"`
+}
+
+// exportedMiniBlock is the JSON representation of a miniblock together with its transactions
+type exportedMiniBlock struct {
+	Hash            string                 `json:"hash"`
+	Type            string                 `json:"type"`
+	SenderShardId   uint32                 `json:"senderShardId"`
+	ReceiverShardId uint32                 `json:"receiverShardId"`
+	Transactions    []*exportedTransaction `json:"transactions"`
+}
+
+// exportedTransaction is the JSON representation of a transaction or of a smart contract result
+type exportedTransaction struct {
+	Hash      string `json:"hash"`
+	Type      string `json:"type"`
+	Nonce     uint64 `json:"nonce"`
+	Value     string `json:"value"`
+	Sender    string `json:"sender"`
+	Receiver  string `json:"receiver"`
+	GasPrice  uint64 `json:"gasPrice,omitempty"`
+	GasLimit  uint64 `json:"gasLimit,omitempty"`
+	Data      string `json:"data,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	TxHash    string `json:"txHash,omitempty"`
+}
+
+// exportedAccount is the JSON representation of an account at the state of a block
+type exportedAccount struct {
+	Address    string            `json:"address"`
+	BlockNonce uint64            `json:"blockNonce"`
+	StateRoot  string            `json:"stateRoot"`
+	Nonce      uint64            `json:"nonce"`
+	Balance    string            `json:"balance,omitempty"`
+	CodeHash   string            `json:"codeHash,omitempty"`
+	RootHash   string            `json:"rootHash,omitempty"`
+	Storage    map[string]string `json:"storage,omitempty"`
+}
+
+// dbInspector reads the storage units of a stopped shard node. The units are opened read-only, so it never writes
+// in them
+type dbInspector struct {
+	headers         storage.Storer
+	hdrNonceHashes  storage.Storer
+	metaBlocks      storage.Storer
+	miniBlocks      storage.Storer
+	transactions    storage.Storer
+	scResults       storage.Storer
+	rewardTxs       storage.Storer
+	accountsTrie    storage.Storer
+	persisters      []storage.Persister
+	marshalizer     marshal.Marshalizer
+	hasher          hashing.Hasher
+	uint64Converter typeConverters.Uint64ByteSliceConverter
+}
+
+func newDbInspector(cfg *config.Config, path string, shardId uint32) (*dbInspector, error) {
+	hasher, err := getHasherFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	marshalizer, err := getMarshalizerFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	di := &dbInspector{
+		persisters:      make([]storage.Persister, 0),
+		marshalizer:     marshalizer,
+		hasher:          hasher,
+		uint64Converter: uint64ByteSlice.NewBigEndianConverter(),
+	}
+	defer func() {
+		if err != nil {
+			_ = di.close()
+		}
+	}()
+
+	di.headers, err = di.openUnit(cfg.BlockHeaderStorage, filepath.Join(path, cfg.BlockHeaderStorage.DB.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	di.metaBlocks, err = di.openUnit(cfg.MetaBlockStorage, filepath.Join(path, cfg.MetaBlockStorage.DB.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	di.miniBlocks, err = di.openUnit(cfg.MiniBlocksStorage, filepath.Join(path, cfg.MiniBlocksStorage.DB.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	di.transactions, err = di.openUnit(cfg.TxStorage, filepath.Join(path, cfg.TxStorage.DB.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	di.scResults, err = di.openUnit(cfg.UnsignedTransactionStorage, filepath.Join(path, cfg.UnsignedTransactionStorage.DB.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	di.rewardTxs, err = di.openUnit(cfg.RewardTxStorage, filepath.Join(path, cfg.RewardTxStorage.DB.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	di.accountsTrie, err = di.openUnit(cfg.AccountsTrieStorage, filepath.Join(path, cfg.AccountsTrieStorage.DB.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	// the nonce to hash unit of a shard is suffixed with the shard id, as done by the sharded storage units
+	hdrNonceHashesPath := fmt.Sprintf("%s%d", filepath.Join(path, cfg.ShardHdrNonceHashStorage.DB.FilePath), shardId)
+	di.hdrNonceHashes, err = di.openUnit(cfg.ShardHdrNonceHashStorage, hdrNonceHashesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return di, nil
+}
+
+// openUnit opens read-only the leveldb database found at the given path. The node writes all its units with
+// leveldb, so the other database types are not supported
+func (di *dbInspector) openUnit(cfg config.StorageConfig, dbPath string) (storage.Storer, error) {
+	dbType := storageUnit.DBType(cfg.DB.Type)
+	if dbType != storageUnit.LvlDB && dbType != storageUnit.LvlDbSerial {
+		return nil, fmt.Errorf("error opening %s: %s", dbPath, storage.ErrNotSupportedDBType.Error())
+	}
+
+	cacher, err := storageUnit.NewCache(storageUnit.CacheType(cfg.Cache.Type), cfg.Cache.Size, cfg.Cache.Shards)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %s", dbPath, err.Error())
+	}
+
+	persister, err := leveldb.NewReadOnlyDB(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %s", dbPath, err.Error())
+	}
+	di.persisters = append(di.persisters, persister)
+
+	unit, err := storageUnit.NewStorageUnit(cacher, persister)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %s", dbPath, err.Error())
+	}
+
+	return unit, nil
+}
+
+// close closes all the opened databases and returns the first error encountered
+func (di *dbInspector) close() error {
+	var firstErr error
+	for _, persister := range di.persisters {
+		err := persister.Close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	di.persisters = make([]storage.Persister, 0)
+
+	return firstErr
+}
+
+// verifyChain walks the header chain from the start nonce to the end nonce and writes every problem found. A zero
+// end nonce walks the chain up to the first missing nonce. The state root hashes are verified only if a replayer is
+// provided. It returns the number of problems found
+func (di *dbInspector) verifyChain(
+	startNonce uint64,
+	endNonce uint64,
+	consensusGroupSize uint32,
+	replayer *stateReplayer,
+	writer io.Writer,
+) (int, error) {
+	numProblems := 0
+	report := func(nonce uint64, problem string) error {
+		numProblems++
+		_, err := fmt.Fprintf(writer, "nonce %d: %s\n", nonce, problem)
+		return err
+	}
+
+	var prevHash []byte
+	if startNonce > 0 {
+		prevHash, _ = di.hdrNonceHashes.Get(di.uint64Converter.ToByteSlice(startNonce - 1))
+	}
+
+	numVerified := 0
+	for nonce := startNonce; endNonce == 0 || nonce <= endNonce; nonce++ {
+		headerHash, err := di.hdrNonceHashes.Get(di.uint64Converter.ToByteSlice(nonce))
+		if err != nil {
+			if endNonce == 0 {
+				break
+			}
+
+			err = report(nonce, "missing from the nonce to hash storage")
+			if err != nil {
+				return numProblems, err
+			}
+			prevHash = nil
+			continue
+		}
+
+		problems, err := di.verifyBlock(nonce, headerHash, prevHash, consensusGroupSize, replayer)
+		if err != nil {
+			return numProblems, err
+		}
+		for _, problem := range problems {
+			err = report(nonce, problem)
+			if err != nil {
+				return numProblems, err
+			}
+		}
+
+		prevHash = headerHash
+		numVerified++
+	}
+
+	_, err := fmt.Fprintf(writer, "verified %d blocks, found %d problems\n", numVerified, numProblems)
+	return numProblems, err
+}
+
+// verifyBlock returns the problems found for the block with the given hash. A nil previous hash skips the check of
+// the link to the previous block and a nil replayer skips the check of the state root hash
+func (di *dbInspector) verifyBlock(
+	nonce uint64,
+	headerHash []byte,
+	prevHash []byte,
+	consensusGroupSize uint32,
+	replayer *stateReplayer,
+) ([]string, error) {
+	problems := make([]string, 0)
+
+	header, err := di.getHeader(headerHash)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("header %s could not be read: %s", toHex(headerHash), err.Error()))
+		return problems, nil
+	}
+
+	computedHash, err := core.CalculateHash(di.marshalizer, di.hasher, header)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(computedHash, headerHash) {
+		problems = append(problems, fmt.Sprintf("header hash is %s, stored under %s", toHex(computedHash), toHex(headerHash)))
+	}
+	if header.Nonce != nonce {
+		problems = append(problems, fmt.Sprintf("header %s has nonce %d", toHex(headerHash), header.Nonce))
+	}
+	if prevHash != nil && !bytes.Equal(header.PrevHash, prevHash) {
+		problems = append(problems, fmt.Sprintf("previous hash is %s, expected %s", toHex(header.PrevHash), toHex(prevHash)))
+	}
+
+	// the genesis block is not signed
+	if header.Nonce > 0 {
+		problems = append(problems, checkSigners(header, consensusGroupSize)...)
+	}
+	problems = append(problems, di.checkMiniBlocks(header)...)
+
+	// the genesis state is created from the genesis file, so there is no block to replay
+	if replayer != nil && header.Nonce > 0 {
+		rootHash, err := replayer.replay(header)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("block could not be replayed: %s", err.Error()))
+		} else if !bytes.Equal(rootHash, header.RootHash) {
+			problems = append(problems, fmt.Sprintf("state root hash is %s, expected %s", toHex(rootHash), toHex(header.RootHash)))
+		}
+	}
+
+	return problems, nil
+}
+
+// checkSigners verifies that the signers bitmap has the length used by the consensus for its group size and that at
+// least 2/3 + 1 of the consensus group members signed the block
+func checkSigners(header *block.Header, consensusGroupSize uint32) []string {
+	problems := make([]string, 0)
+	if consensusGroupSize == 0 {
+		return problems
+	}
+
+	if len(header.Signature) == 0 {
+		problems = append(problems, "missing aggregated signature")
+	}
+
+	expectedLen := int(consensusGroupSize/8 + 1)
+	if len(header.PubKeysBitmap) != expectedLen {
+		problems = append(problems, fmt.Sprintf("signers bitmap has %d bytes, expected %d",
+			len(header.PubKeysBitmap), expectedLen))
+		return problems
+	}
+
+	numSigners := 0
+	for _, b := range header.PubKeysBitmap {
+		numSigners += bits.OnesCount8(b)
+	}
+
+	threshold := int(consensusGroupSize*2/3 + 1)
+	if numSigners < threshold {
+		problems = append(problems, fmt.Sprintf("block signed by %d validators, at least %d required",
+			numSigners, threshold))
+	}
+
+	return problems
+}
+
+// checkMiniBlocks verifies that every miniblock referenced by the header and all their transactions are stored
+func (di *dbInspector) checkMiniBlocks(header *block.Header) []string {
+	problems := make([]string, 0)
+	for _, mbHeader := range header.MiniBlockHeaders {
+		miniBlock, err := di.getMiniBlock(mbHeader.Hash)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("miniblock %s could not be read: %s", toHex(mbHeader.Hash), err.Error()))
+			continue
+		}
+
+		computedHash, err := core.CalculateHash(di.marshalizer, di.hasher, miniBlock)
+		if err != nil || !bytes.Equal(computedHash, mbHeader.Hash) {
+			problems = append(problems, fmt.Sprintf("miniblock %s has hash %s", toHex(mbHeader.Hash), toHex(computedHash)))
+		}
+		if uint32(len(miniBlock.TxHashes)) != mbHeader.TxCount {
+			problems = append(problems, fmt.Sprintf("miniblock %s has %d transactions, the header announces %d",
+				toHex(mbHeader.Hash), len(miniBlock.TxHashes), mbHeader.TxCount))
+		}
+
+		txUnit := di.unitForMiniBlockType(miniBlock.Type)
+		if txUnit == nil {
+			continue
+		}
+		for _, txHash := range miniBlock.TxHashes {
+			err = txUnit.Has(txHash)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("transaction %s of miniblock %s is missing",
+					toHex(txHash), toHex(mbHeader.Hash)))
+			}
+		}
+	}
+
+	return problems
+}
+
+// unitForMiniBlockType returns the unit holding the transactions of a miniblock type, or nil if the node does not
+// store them
+func (di *dbInspector) unitForMiniBlockType(mbType block.Type) storage.Storer {
+	switch mbType {
+	case block.TxBlock:
+		return di.transactions
+	case block.SmartContractResultBlock:
+		return di.scResults
+	case block.RewardsBlock:
+		return di.rewardTxs
+	}
+
+	return nil
+}
+
+// highestNonce returns the highest nonce found in the nonce to hash storage, starting from the genesis block
+func (di *dbInspector) highestNonce() (uint64, error) {
+	nonce := uint64(0)
+	for {
+		err := di.hdrNonceHashes.Has(di.uint64Converter.ToByteSlice(nonce + 1))
+		if err != nil {
+			return nonce, nil
+		}
+		nonce++
+	}
+}
+
+func (di *dbInspector) exportBlockByNonce(nonce uint64) (*exportedBlock, error) {
+	headerHash, err := di.hdrNonceHashes.Get(di.uint64Converter.ToByteSlice(nonce))
+	if err != nil {
+		return nil, fmt.Errorf("block with nonce %d not found: %s", nonce, err.Error())
+	}
+
+	return di.exportBlock(headerHash)
+}
+
+func (di *dbInspector) exportBlock(headerHash []byte) (*exportedBlock, error) {
+	header, err := di.getHeader(headerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &exportedBlock{
+		Hash:          toHex(headerHash),
+		Nonce:         header.Nonce,
+		Round:         header.Round,
+		Epoch:         header.Epoch,
+		ShardId:       header.ShardId,
+		TimeStamp:     header.TimeStamp,
+		PrevHash:      toHex(header.PrevHash),
+		RandSeed:      toHex(header.RandSeed),
+		PubKeysBitmap: toHex(header.PubKeysBitmap),
+		Signature:     toHex(header.Signature),
+		RootHash:      toHex(header.RootHash),
+		TxCount:       header.TxCount,
+		MiniBlocks:    make([]*exportedMiniBlock, 0, len(header.MiniBlockHeaders)),
+	}
+	for _, metaBlockHash := range header.MetaBlockHashes {
+		exported.MetaBlockHashes = append(exported.MetaBlockHashes, toHex(metaBlockHash))
+	}
+
+	for _, mbHeader := range header.MiniBlockHeaders {
+		miniBlock, err := di.getMiniBlock(mbHeader.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		exportedMb := &exportedMiniBlock{
+			Hash:            toHex(mbHeader.Hash),
+			Type:            miniBlockTypeName(miniBlock.Type),
+			SenderShardId:   miniBlock.SenderShardID,
+			ReceiverShardId: miniBlock.ReceiverShardID,
+			Transactions:    make([]*exportedTransaction, 0, len(miniBlock.TxHashes)),
+		}
+		if di.unitForMiniBlockType(miniBlock.Type) != nil {
+			for _, txHash := range miniBlock.TxHashes {
+				exportedTx, err := di.exportTransaction(txHash)
+				if err != nil {
+					return nil, err
+				}
+				exportedMb.Transactions = append(exportedMb.Transactions, exportedTx)
+			}
+		}
+
+		exported.MiniBlocks = append(exported.MiniBlocks, exportedMb)
+	}
+
+	return exported, nil
+}
+
+// exportTransaction searches the hash in the transactions, in the smart contract results and in the reward
+// transactions storage units
+func (di *dbInspector) exportTransaction(txHash []byte) (*exportedTransaction, error) {
+	tx, err := di.getTransaction(txHash)
+	if err == nil {
+		exported := &exportedTransaction{
+			Hash:      toHex(txHash),
+			Type:      "transaction",
+			Nonce:     tx.Nonce,
+			Sender:    toHex(tx.SndAddr),
+			Receiver:  toHex(tx.RcvAddr),
+			GasPrice:  tx.GasPrice,
+			GasLimit:  tx.GasLimit,
+			Data:      tx.Data,
+			Signature: toHex(tx.Signature),
+		}
+		if tx.Value != nil {
+			exported.Value = tx.Value.String()
+		}
+
+		return exported, nil
+	}
+
+	scr, err := di.getSmartContractResult(txHash)
+	if err == nil {
+		exported := &exportedTransaction{
+			Hash:     toHex(txHash),
+			Type:     "smartContractResult",
+			Nonce:    scr.Nonce,
+			Sender:   toHex(scr.SndAddr),
+			Receiver: toHex(scr.RcvAddr),
+			Data:     scr.Data,
+			TxHash:   toHex(scr.TxHash),
+		}
+		if scr.Value != nil {
+			exported.Value = scr.Value.String()
+		}
+
+		return exported, nil
+	}
+
+	rTx, err := di.getRewardTx(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not found", toHex(txHash))
+	}
+
+	exported := &exportedTransaction{
+		Hash:     toHex(txHash),
+		Type:     "rewardTransaction",
+		Receiver: toHex(rTx.RcvAddr),
+	}
+	if rTx.Value != nil {
+		exported.Value = rTx.Value.String()
+	}
+
+	return exported, nil
+}
+
+// exportAccount reads the account at the state of the block with the given nonce
+func (di *dbInspector) exportAccount(address []byte, nonce uint64) (*exportedAccount, error) {
+	headerHash, err := di.hdrNonceHashes.Get(di.uint64Converter.ToByteSlice(nonce))
+	if err != nil {
+		return nil, fmt.Errorf("block with nonce %d not found: %s", nonce, err.Error())
+	}
+	header, err := di.getHeader(headerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	merkleTrie, err := trie.NewTrie(di.accountsTrie, di.marshalizer, di.hasher)
+	if err != nil {
+		return nil, err
+	}
+	adb, err := state.NewAccountsDB(merkleTrie, di.hasher, di.marshalizer, factory.NewAccountCreator(), nil)
+	if err != nil {
+		return nil, err
+	}
+	err = adb.RecreateTrie(header.RootHash)
+	if err != nil {
+		return nil, errors.New("error recreating the state trie: " + err.Error())
+	}
+
+	accountHandler, err := adb.GetExistingAccount(state.NewAddress(address))
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &exportedAccount{
+		Address:    toHex(address),
+		BlockNonce: nonce,
+		StateRoot:  toHex(header.RootHash),
+		Nonce:      accountHandler.GetNonce(),
+		CodeHash:   toHex(accountHandler.GetCodeHash()),
+		RootHash:   toHex(accountHandler.GetRootHash()),
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if ok && account.Balance != nil {
+		exported.Balance = account.Balance.String()
+	}
+
+	if accountHandler.DataTrie() == nil {
+		return exported, nil
+	}
+
+	it, err := accountHandler.DataTrie().NewLeafIterator(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	exported.Storage = make(map[string]string)
+	for it.Next() {
+		exported.Storage[toHex(it.Key())] = toHex(it.Value())
+	}
+
+	return exported, it.Error()
+}
+
+func (di *dbInspector) getHeader(headerHash []byte) (*block.Header, error) {
+	buff, err := di.headers.Get(headerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &block.Header{}
+	err = di.marshalizer.Unmarshal(header, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (di *dbInspector) getMiniBlock(miniBlockHash []byte) (*block.MiniBlock, error) {
+	buff, err := di.miniBlocks.Get(miniBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	miniBlock := &block.MiniBlock{}
+	err = di.marshalizer.Unmarshal(miniBlock, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return miniBlock, nil
+}
+
+func (di *dbInspector) getMetaBlock(metaBlockHash []byte) (*block.MetaBlock, error) {
+	buff, err := di.metaBlocks.Get(metaBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	metaBlock := &block.MetaBlock{}
+	err = di.marshalizer.Unmarshal(metaBlock, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return metaBlock, nil
+}
+
+func (di *dbInspector) getTransaction(txHash []byte) (*transaction.Transaction, error) {
+	buff, err := di.transactions.Get(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &transaction.Transaction{}
+	err = di.marshalizer.Unmarshal(tx, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (di *dbInspector) getSmartContractResult(scrHash []byte) (*smartContractResult.SmartContractResult, error) {
+	buff, err := di.scResults.Get(scrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	scr := &smartContractResult.SmartContractResult{}
+	err = di.marshalizer.Unmarshal(scr, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr, nil
+}
+
+func (di *dbInspector) getRewardTx(txHash []byte) (*rewardTx.RewardTx, error) {
+	buff, err := di.rewardTxs.Get(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	rTx := &rewardTx.RewardTx{}
+	err = di.marshalizer.Unmarshal(rTx, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return rTx, nil
+}
+
+func miniBlockTypeName(mbType block.Type) string {
+	switch mbType {
+	case block.TxBlock:
+		return "TxBlock"
+	case block.StateBlock:
+		return "StateBlock"
+	case block.PeerBlock:
+		return "PeerBlock"
+	case block.SmartContractResultBlock:
+		return "SmartContractResultBlock"
+	case block.InvalidBlock:
+		return "InvalidBlock"
+	case block.RewardsBlock:
+		return "RewardsBlock"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", mbType)
+}
+
+func toHex(buff []byte) string {
+	return hex.EncodeToString(buff)
+}
+
+func getHasherFromConfig(cfg *config.Config) (hashing.Hasher, error) {
+	switch cfg.Hasher.Type {
+	case "sha256":
+		return sha256.Sha256{}, nil
+	case "blake2b":
+		return blake2b.Blake2b{}, nil
+	}
+
+	return nil, errors.New("no hasher provided in config file")
+}
+
+func getMarshalizerFromConfig(cfg *config.Config) (marshal.Marshalizer, error) {
+	switch cfg.Marshalizer.Type {
+	case "json":
+		return marshal.JsonMarshalizer{}, nil
+	}
+
+	return nil, errors.New("no marshalizer provided in config file")
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
+	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+)
+
+// testStorage holds writable units created in the layout read by the inspector
+type testStorage struct {
+	headers        storage.Storer
+	hdrNonceHashes storage.Storer
+	metaBlocks     storage.Storer
+	miniBlocks     storage.Storer
+	transactions   storage.Storer
+	scResults      storage.Storer
+	rewardTxs      storage.Storer
+	accountsTrie   storage.Storer
+	persisters     []storage.Persister
+}
+
+func createTestConfig() *config.Config {
+	storageConfig := func(filePath string) config.StorageConfig {
+		return config.StorageConfig{
+			Cache: config.CacheConfig{Type: "LRU", Size: 100, Shards: 1},
+			DB:    config.DBConfig{FilePath: filePath, Type: "LvlDBSerial", BatchDelaySeconds: 1, MaxBatchSize: 1},
+		}
+	}
+
+	return &config.Config{
+		BlockHeaderStorage:         storageConfig("BlockHeaders"),
+		ShardHdrNonceHashStorage:   storageConfig("ShardHdrHashNonce"),
+		MetaBlockStorage:           storageConfig("MetaBlock"),
+		MiniBlocksStorage:          storageConfig("MiniBlocks"),
+		TxStorage:                  storageConfig("Transactions"),
+		UnsignedTransactionStorage: storageConfig("UnsignedTransactions"),
+		RewardTxStorage:            storageConfig("RewardTransactions"),
+		AccountsTrieStorage:        storageConfig("AccountsTrie"),
+		Hasher:                     config.TypeConfig{Type: "blake2b"},
+		Marshalizer:                config.TypeConfig{Type: "json"},
+		Address:                    config.AddressConfig{Length: 32, Prefix: "0x"},
+		Consensus:                  config.TypeConfig{Type: "bls"},
+		Economics: config.EconomicsConfig{
+			FeeSettings: config.FeeSettings{
+				MinGasPrice: 1,
+				MinGasLimit: 5,
+			},
+			StakingSettings: config.StakingSettings{
+				MinStakeValue: "0",
+			},
+			RatingSettings: config.RatingSettings{
+				StartRating:                50,
+				MaxRating:                  100,
+				ProposerIncreaseRatingStep: 2,
+				SignerIncreaseRatingStep:   1,
+				ProposerDecreaseRatingStep: 4,
+				SlashingDecreaseRatingStep: 10,
+			},
+			RewardsSettings: config.RewardsSettings{
+				BlockReward: "0",
+			},
+		},
+		EpochStartConfig: config.EpochStartConfig{
+			RoundsPerEpoch: 100,
+		},
+	}
+}
+
+func createTestStorage(t *testing.T, cfg *config.Config, shardId uint32) (string, *testStorage) {
+	dir, err := ioutil.TempDir("", "dbinspect_temp")
+	assert.Nil(t, err)
+
+	ts := &testStorage{
+		persisters: make([]storage.Persister, 0),
+	}
+	openUnit := func(dbPath string) storage.Storer {
+		cacher, err := storageUnit.NewCache(storageUnit.LRUCache, 100, 1)
+		assert.Nil(t, err)
+		persister, err := leveldb.NewSerialDB(dbPath, 1, 1)
+		assert.Nil(t, err)
+		ts.persisters = append(ts.persisters, persister)
+		unit, err := storageUnit.NewStorageUnit(cacher, persister)
+		assert.Nil(t, err)
+
+		return unit
+	}
+
+	ts.headers = openUnit(filepath.Join(dir, cfg.BlockHeaderStorage.DB.FilePath))
+	ts.hdrNonceHashes = openUnit(fmt.Sprintf("%s%d", filepath.Join(dir, cfg.ShardHdrNonceHashStorage.DB.FilePath), shardId))
+	ts.metaBlocks = openUnit(filepath.Join(dir, cfg.MetaBlockStorage.DB.FilePath))
+	ts.miniBlocks = openUnit(filepath.Join(dir, cfg.MiniBlocksStorage.DB.FilePath))
+	ts.transactions = openUnit(filepath.Join(dir, cfg.TxStorage.DB.FilePath))
+	ts.scResults = openUnit(filepath.Join(dir, cfg.UnsignedTransactionStorage.DB.FilePath))
+	ts.rewardTxs = openUnit(filepath.Join(dir, cfg.RewardTxStorage.DB.FilePath))
+	ts.accountsTrie = openUnit(filepath.Join(dir, cfg.AccountsTrieStorage.DB.FilePath))
+
+	return dir, ts
+}
+
+func (ts *testStorage) put(t *testing.T, unit storage.Storer, value interface{}) []byte {
+	buff, err := marshal.JsonMarshalizer{}.Marshal(value)
+	assert.Nil(t, err)
+	hash := blake2b.Blake2b{}.Compute(string(buff))
+	err = unit.Put(hash, buff)
+	assert.Nil(t, err)
+
+	return hash
+}
+
+func (ts *testStorage) putHeader(t *testing.T, header *block.Header) []byte {
+	headerHash, err := core.CalculateHash(marshal.JsonMarshalizer{}, blake2b.Blake2b{}, header)
+	assert.Nil(t, err)
+	buff, err := marshal.JsonMarshalizer{}.Marshal(header)
+	assert.Nil(t, err)
+
+	err = ts.headers.Put(headerHash, buff)
+	assert.Nil(t, err)
+	err = ts.hdrNonceHashes.Put(uint64ByteSlice.NewBigEndianConverter().ToByteSlice(header.Nonce), headerHash)
+	assert.Nil(t, err)
+
+	return headerHash
+}
+
+func (ts *testStorage) close(t *testing.T) {
+	for _, persister := range ts.persisters {
+		err := persister.Close()
+		assert.Nil(t, err)
+	}
+}
+
+func TestCheckSigners_BitmapLengthShouldBeGroupSizeOver8Plus1(t *testing.T) {
+	t.Parallel()
+
+	header := &block.Header{Signature: []byte("signature"), PubKeysBitmap: []byte{0x3f, 0x00}}
+	assert.Equal(t, 0, len(checkSigners(header, 8)))
+
+	header.PubKeysBitmap = []byte{0xff}
+	problems := checkSigners(header, 8)
+	assert.Equal(t, []string{"signers bitmap has 1 bytes, expected 2"}, problems)
+}
+
+func TestCheckSigners_NotEnoughSignersShouldReport(t *testing.T) {
+	t.Parallel()
+
+	header := &block.Header{Signature: []byte("signature"), PubKeysBitmap: []byte{0x1f, 0x00}}
+
+	problems := checkSigners(header, 8)
+
+	assert.Equal(t, []string{"block signed by 5 validators, at least 6 required"}, problems)
+}
+
+func TestCheckSigners_MissingSignatureShouldReport(t *testing.T) {
+	t.Parallel()
+
+	header := &block.Header{PubKeysBitmap: []byte{0x01}}
+
+	problems := checkSigners(header, 1)
+
+	assert.Equal(t, []string{"missing aggregated signature"}, problems)
+}
+
+func TestNewDbInspector_MissingUnitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "dbinspect_temp")
+	assert.Nil(t, err)
+
+	di, err := newDbInspector(createTestConfig(), dir, 0)
+
+	assert.Nil(t, di)
+	assert.NotNil(t, err)
+}
+
+func TestNewDbInspector_NotSupportedDbTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig()
+	dir, ts := createTestStorage(t, cfg, 0)
+	ts.close(t)
+	cfg.TxStorage.DB.Type = string(storageUnit.BoltDB)
+
+	di, err := newDbInspector(cfg, dir, 0)
+
+	assert.Nil(t, di)
+	assert.True(t, strings.Contains(err.Error(), storage.ErrNotSupportedDBType.Error()))
+}
+
+func TestNewDbInspector_UnitsShouldBeReadOnly(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig()
+	dir, ts := createTestStorage(t, cfg, 0)
+	headerHash := ts.putHeader(t, &block.Header{Nonce: 1})
+	ts.close(t)
+
+	di, err := newDbInspector(cfg, dir, 0)
+	assert.Nil(t, err)
+
+	header, err := di.getHeader(headerHash)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), header.Nonce)
+
+	err = di.headers.Put([]byte("key"), []byte("value"))
+	assert.Equal(t, storage.ErrReadOnlyPersister, err)
+
+	err = di.close()
+	assert.Nil(t, err)
+}
+
+func TestVerifyChain_BrokenPrevHashLinkShouldReport(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig()
+	dir, ts := createTestStorage(t, cfg, 0)
+	ts.putHeader(t, &block.Header{
+		Nonce:         1,
+		PrevHash:      []byte("genesis hash"),
+		Signature:     []byte("signature"),
+		PubKeysBitmap: []byte{0x01},
+	})
+	ts.putHeader(t, &block.Header{
+		Nonce:         2,
+		PrevHash:      []byte("wrong hash"),
+		Signature:     []byte("signature"),
+		PubKeysBitmap: []byte{0x01},
+	})
+	ts.close(t)
+
+	di, err := newDbInspector(cfg, dir, 0)
+	assert.Nil(t, err)
+	defer func() {
+		_ = di.close()
+	}()
+
+	output := &bytes.Buffer{}
+	numProblems, err := di.verifyChain(1, 0, 1, nil, output)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numProblems)
+	assert.True(t, strings.Contains(output.String(), "nonce 2: previous hash is"))
+	assert.True(t, strings.Contains(output.String(), "verified 2 blocks, found 1 problems"))
+}
+
+func TestVerifyChain_MissingMiniBlockShouldReport(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig()
+	dir, ts := createTestStorage(t, cfg, 0)
+	ts.putHeader(t, &block.Header{
+		Nonce:            1,
+		Signature:        []byte("signature"),
+		PubKeysBitmap:    []byte{0x01},
+		MiniBlockHeaders: []block.MiniBlockHeader{{Hash: []byte("missing miniblock")}},
+	})
+	ts.close(t)
+
+	di, err := newDbInspector(cfg, dir, 0)
+	assert.Nil(t, err)
+	defer func() {
+		_ = di.close()
+	}()
+
+	numProblems, err := di.verifyChain(1, 0, 1, nil, &bytes.Buffer{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numProblems)
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/urfave/cli"
+)
+
+var (
+	dbInspectHelpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}} command [command options]
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+COMMANDS:
+   {{range .Commands}}{{join .Names ", "}}{{ "\t" }}{{.Usage}}
+   {{end}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+	// configurationFile defines a flag for the path to the main toml configuration file of the node
+	configurationFile = cli.StringFlag{
+		Name:  "config",
+		Usage: "The main configuration file of the node, used to open the storage units",
+		Value: "./config/config.toml",
+	}
+	// dbPath defines a flag for the path to the storage folder of the node's shard
+	dbPath = cli.StringFlag{
+		Name:  "db-path",
+		Usage: "The storage folder of the node's shard, for example ./db/Epoch_0/Shard_0",
+	}
+	// shardId defines a flag for the shard of the inspected node
+	shardId = cli.UintFlag{
+		Name:  "shard-id",
+		Usage: "The shard of the node which wrote the storage",
+		Value: 0,
+	}
+	// outputFile defines a flag for the file where the JSON output is written
+	outputFile = cli.StringFlag{
+		Name:  "output",
+		Usage: "The file where the JSON output is written. If not set, the output is printed on the console",
+	}
+	// startNonce defines a flag for the first verified block nonce
+	startNonce = cli.Uint64Flag{
+		Name:  "start-nonce",
+		Usage: "The nonce of the first verified block",
+		Value: 1,
+	}
+	// endNonce defines a flag for the last verified block nonce
+	endNonce = cli.Uint64Flag{
+		Name:  "end-nonce",
+		Usage: "The nonce of the last verified block. If not set, the blocks are verified up to the first missing nonce",
+	}
+	// nodesFile defines a flag for the initial nodes file, used to find the consensus group size
+	nodesFile = cli.StringFlag{
+		Name:  "nodes-setup-file",
+		Usage: "The initial nodes file, used to find the consensus group size when checking the signers bitmaps",
+		Value: "./config/nodesSetup.json",
+	}
+	// genesisFile defines a flag for the genesis file, used to recreate the genesis block when replaying the blocks
+	genesisFile = cli.StringFlag{
+		Name:  "genesis-file",
+		Usage: "The genesis file, used to recreate the genesis block when the state is verified",
+		Value: "./config/genesis.json",
+	}
+	// verifyState defines a flag for recomputing the state root hash of every verified block
+	verifyState = cli.BoolFlag{
+		Name: "verify-state",
+		Usage: "Replays every verified block on top of the state of its previous block and compares the resulting " +
+			"state root hash with the one of the block",
+	}
+	// nonce defines a flag for selecting a block by its nonce
+	nonce = cli.Uint64Flag{
+		Name:  "nonce",
+		Usage: "The nonce of the block",
+	}
+	// hash defines a flag for selecting an entry by its hex encoded hash
+	hash = cli.StringFlag{
+		Name:  "hash",
+		Usage: "The hex encoded hash",
+	}
+	// address defines a flag for the hex encoded address of an account
+	address = cli.StringFlag{
+		Name:  "address",
+		Usage: "The hex encoded address of the account",
+	}
+
+	errMissingDbPath      = errors.New("the db-path flag is required")
+	errMissingBlockFilter = errors.New("either the nonce or the hash flag is required")
+	errMissingHash        = errors.New("the hash flag is required")
+	errMissingAddress     = errors.New("the address flag is required")
+	errVerificationFailed = errors.New("the verification found problems in the storage")
+
+	errMissingEpochStartMetaBlock = errors.New("the epoch start metablock was not found")
+)
+
+func main() {
+	app := cli.NewApp()
+	cli.AppHelpTemplate = dbInspectHelpTemplate
+	app.Name = "Database inspection Tool"
+	app.Version = "v0.0.1"
+	app.Usage = "This binary verifies the blocks stored by a node and prints blocks, transactions and accounts as JSON. " +
+		"The node using the storage should be stopped"
+	app.Flags = []cli.Flag{configurationFile, dbPath, shardId, outputFile}
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Elrond Team",
+			Email: "contact@elrond.com",
+		},
+	}
+	app.Commands = []cli.Command{
+		{
+			Name:   "verify",
+			Usage:  "walks the header chain by nonce and verifies the links, the signers bitmaps and the block data",
+			Flags:  []cli.Flag{startNonce, endNonce, nodesFile, genesisFile, verifyState},
+			Action: verify,
+		},
+		{
+			Name:   "block",
+			Usage:  "prints a block together with its miniblocks and transactions",
+			Flags:  []cli.Flag{nonce, hash},
+			Action: printBlock,
+		},
+		{
+			Name:   "tx",
+			Usage:  "prints a transaction or a smart contract result",
+			Flags:  []cli.Flag{hash},
+			Action: printTransaction,
+		},
+		{
+			Name:   "account",
+			Usage:  "prints an account and its storage at the state of a block",
+			Flags:  []cli.Flag{address, nonce},
+			Action: printAccount,
+		},
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func verify(ctx *cli.Context) error {
+	inspector, err := createInspector(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = inspector.close()
+	}()
+
+	nodesConfig, err := sharding.NewNodesSetup(ctx.String(nodesFile.Name), math.MaxUint64)
+	if err != nil {
+		return err
+	}
+
+	var replayer *stateReplayer
+	if ctx.Bool(verifyState.Name) {
+		replayer, err = createReplayer(ctx, inspector, nodesConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	numProblems, err := inspector.verifyChain(
+		ctx.Uint64(startNonce.Name),
+		ctx.Uint64(endNonce.Name),
+		nodesConfig.ConsensusGroupSize,
+		replayer,
+		os.Stdout,
+	)
+	if err != nil {
+		return err
+	}
+	if numProblems > 0 {
+		return errVerificationFailed
+	}
+
+	return nil
+}
+
+func printBlock(ctx *cli.Context) error {
+	if !ctx.IsSet(nonce.Name) && !ctx.IsSet(hash.Name) {
+		return errMissingBlockFilter
+	}
+
+	inspector, err := createInspector(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = inspector.close()
+	}()
+
+	var exported *exportedBlock
+	if ctx.IsSet(hash.Name) {
+		var headerHash []byte
+		headerHash, err = hex.DecodeString(ctx.String(hash.Name))
+		if err != nil {
+			return err
+		}
+
+		exported, err = inspector.exportBlock(headerHash)
+	} else {
+		exported, err = inspector.exportBlockByNonce(ctx.Uint64(nonce.Name))
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeJson(ctx, exported)
+}
+
+func printTransaction(ctx *cli.Context) error {
+	if !ctx.IsSet(hash.Name) {
+		return errMissingHash
+	}
+
+	txHash, err := hex.DecodeString(ctx.String(hash.Name))
+	if err != nil {
+		return err
+	}
+
+	inspector, err := createInspector(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = inspector.close()
+	}()
+
+	exported, err := inspector.exportTransaction(txHash)
+	if err != nil {
+		return err
+	}
+
+	return writeJson(ctx, exported)
+}
+
+func printAccount(ctx *cli.Context) error {
+	if !ctx.IsSet(address.Name) {
+		return errMissingAddress
+	}
+
+	addressBytes, err := hex.DecodeString(ctx.String(address.Name))
+	if err != nil {
+		return err
+	}
+
+	inspector, err := createInspector(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = inspector.close()
+	}()
+
+	// without a nonce, the account is read at the state of the highest stored block
+	blockNonce := ctx.Uint64(nonce.Name)
+	if !ctx.IsSet(nonce.Name) {
+		blockNonce, err = inspector.highestNonce()
+		if err != nil {
+			return err
+		}
+	}
+
+	exported, err := inspector.exportAccount(addressBytes, blockNonce)
+	if err != nil {
+		return err
+	}
+
+	return writeJson(ctx, exported)
+}
+
+func createInspector(ctx *cli.Context) (*dbInspector, error) {
+	if !ctx.GlobalIsSet(dbPath.Name) {
+		return nil, errMissingDbPath
+	}
+
+	generalConfig := &config.Config{}
+	err := core.LoadTomlFile(generalConfig, ctx.GlobalString(configurationFile.Name), logger.DefaultLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	return newDbInspector(generalConfig, ctx.GlobalString(dbPath.Name), uint32(ctx.GlobalUint(shardId.Name)))
+}
+
+func createReplayer(ctx *cli.Context, inspector *dbInspector, nodesConfig *sharding.NodesSetup) (*stateReplayer, error) {
+	generalConfig := &config.Config{}
+	err := core.LoadTomlFile(generalConfig, ctx.GlobalString(configurationFile.Name), logger.DefaultLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	genesisConfig, err := sharding.NewGenesisConfig(ctx.String(genesisFile.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return newStateReplayer(generalConfig, nodesConfig, genesisConfig, inspector, uint32(ctx.GlobalUint(shardId.Name)))
+}
+
+func writeJson(ctx *cli.Context, value interface{}) error {
+	var writer io.Writer = os.Stdout
+	if ctx.GlobalIsSet(outputFile.Name) {
+		file, err := os.Create(ctx.GlobalString(outputFile.Name))
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		writer = file
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
@@ -0,0 +1,791 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/cmd/node/factory"
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/consensus/epoch"
+	"github.com/ElrondNetwork/elrond-go/consensus/slashing"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators/groupSelectors"
+	"github.com/ElrondNetwork/elrond-go/core/genesis"
+	"github.com/ElrondNetwork/elrond-go/crypto"
+	"github.com/ElrondNetwork/elrond-go/crypto/signing"
+	"github.com/ElrondNetwork/elrond-go/crypto/signing/kyber"
+	"github.com/ElrondNetwork/elrond-go/crypto/signing/kyber/singlesig"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/addressConverters"
+	factoryState "github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
+	"github.com/ElrondNetwork/elrond-go/process/factory/shard"
+	"github.com/ElrondNetwork/elrond-go/process/rating"
+	"github.com/ElrondNetwork/elrond-go/process/receipts"
+	"github.com/ElrondNetwork/elrond-go/process/smartContract"
+	"github.com/ElrondNetwork/elrond-go/process/staking"
+	"github.com/ElrondNetwork/elrond-go/process/transaction"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-vm-common"
+)
+
+const ratingSizeInBytes = 4
+
+// stateReplayer recomputes the state root hash of a stored block by executing the block on top of the state of its
+// previous block, in the same way the node processes it: the transactions, the smart contract results received from
+// other shards, the rating changes observed in the previous block, the fees credited to the leader and the stored
+// reward transactions. The changes are never committed, so the trie storage is only read
+type stateReplayer struct {
+	di                *dbInspector
+	shardCoordinator  sharding.Coordinator
+	accounts          *state.AccountsDB
+	groupSelector     consensus.ValidatorGroupSelector
+	ratingSettings    process.RatingSettingsHandler
+	ratingReader      *stateRatingReader
+	epochHandler      process.EpochHandler
+	specialAddresses  process.SpecialAddressHandler
+	txFeeHandler      process.TransactionFeeHandler
+	stakingHandler    process.StakingHandler
+	receiptsHandler   process.ReceiptsHandler
+	interimProcessors process.IntermediateProcessorContainer
+	txProcessor       process.TransactionProcessor
+	scProcessor       process.SmartContractResultProcessor
+	genesisHeader     *block.Header
+	isEpochRestored   bool
+}
+
+func newStateReplayer(
+	cfg *config.Config,
+	nodesConfig *sharding.NodesSetup,
+	genesisConfig *sharding.Genesis,
+	di *dbInspector,
+	shardId uint32,
+) (*stateReplayer, error) {
+	shardCoordinator, err := sharding.NewMultiShardCoordinator(nodesConfig.NumberOfShards(), shardId)
+	if err != nil {
+		return nil, err
+	}
+
+	addressConverter, err := addressConverters.NewPlainAddressConverter(cfg.Address.Length, cfg.Address.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	accountFactory, err := factoryState.NewAccountFactoryCreator(shardCoordinator)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisHeader, err := createGenesisHeader(genesisConfig, nodesConfig, shardCoordinator, addressConverter, accountFactory, di)
+	if err != nil {
+		return nil, errors.New("error creating the genesis block: " + err.Error())
+	}
+
+	merkleTrie, err := trie.NewTrie(di.accountsTrie, di.marshalizer, di.hasher)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := state.NewAccountsDB(merkleTrie, di.hasher, di.marshalizer, accountFactory, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	economicsData, err := economics.NewEconomicsData(&cfg.Economics)
+	if err != nil {
+		return nil, err
+	}
+
+	sr := &stateReplayer{
+		di:               di,
+		shardCoordinator: shardCoordinator,
+		accounts:         accounts,
+		ratingSettings:   economicsData,
+		genesisHeader:    genesisHeader,
+	}
+
+	err = sr.createEpochHandler(cfg, nodesConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.createProcessors(cfg, addressConverter, economicsData)
+	if err != nil {
+		return nil, err
+	}
+
+	return sr, nil
+}
+
+// createEpochHandler creates the epoch manager which loads the eligible list of the replayed blocks' epoch in the
+// group selector, loaded initially with the validators of the nodes setup file
+func (sr *stateReplayer) createEpochHandler(cfg *config.Config, nodesConfig *sharding.NodesSetup) error {
+	initialValidators, err := createInitialValidators(nodesConfig)
+	if err != nil {
+		return err
+	}
+
+	sr.groupSelector, err = groupSelectors.NewIndexHashedGroupSelector(int(nodesConfig.ConsensusGroupSize), sr.di.hasher)
+	if err != nil {
+		return err
+	}
+
+	sr.ratingReader = &stateRatingReader{
+		accounts:       sr.accounts,
+		ratingSettings: sr.ratingSettings,
+	}
+
+	sr.epochHandler, err = epoch.NewEpochManager(
+		cfg.EpochStartConfig.RoundsPerEpoch,
+		cfg.EpochStartConfig.NodesToShufflePerShard,
+		nodesConfig.ConsensusGroupSize,
+		sr.di.hasher,
+		sr.shardCoordinator,
+		sr.groupSelector,
+		sr.ratingReader,
+		initialValidators,
+	)
+
+	return err
+}
+
+// createProcessors creates the processors which change the state of a shard block, wired in the same way as in
+// the node
+func (sr *stateReplayer) createProcessors(
+	cfg *config.Config,
+	addressConverter state.AddressConverter,
+	economicsData *economics.EconomicsData,
+) error {
+	var err error
+	sr.specialAddresses, err = economics.NewSpecialAddressHolder(sr.groupSelector)
+	if err != nil {
+		return err
+	}
+
+	txFeeHandler, err := economics.NewFeeAccumulator(sr.accounts)
+	if err != nil {
+		return err
+	}
+	sr.txFeeHandler = txFeeHandler
+
+	store := sr.di.storageService()
+	sr.receiptsHandler, err = receipts.NewReceiptsCollector(store, sr.di.marshalizer)
+	if err != nil {
+		return err
+	}
+
+	vmFactory, err := shard.NewVMContainerFactory(sr.accounts, addressConverter)
+	if err != nil {
+		return err
+	}
+	vmContainer, err := vmFactory.Create()
+	if err != nil {
+		return err
+	}
+
+	interimProcFactory, err := shard.NewIntermediateProcessorsContainerFactory(
+		sr.shardCoordinator,
+		sr.di.marshalizer,
+		sr.di.hasher,
+		addressConverter,
+		store,
+	)
+	if err != nil {
+		return err
+	}
+	sr.interimProcessors, err = interimProcFactory.Create()
+	if err != nil {
+		return err
+	}
+	scForwarder, err := sr.interimProcessors.Get(block.SmartContractResultBlock)
+	if err != nil {
+		return err
+	}
+
+	argsParser, err := smartContract.NewAtArgumentParser()
+	if err != nil {
+		return err
+	}
+
+	scProcessor, err := smartContract.NewSmartContractProcessor(
+		vmContainer,
+		argsParser,
+		sr.di.hasher,
+		sr.di.marshalizer,
+		sr.accounts,
+		vmFactory.VMAccountsDB(),
+		addressConverter,
+		sr.shardCoordinator,
+		scForwarder,
+		txFeeHandler,
+		&nullLogsHandler{},
+		sr.receiptsHandler,
+	)
+	if err != nil {
+		return err
+	}
+	sr.scProcessor = scProcessor
+
+	keyGen, singleSigner, err := createBlockSigning(cfg)
+	if err != nil {
+		return err
+	}
+
+	slashingVerifier, err := slashing.NewProofVerifier(keyGen, singleSigner, sr.di.marshalizer)
+	if err != nil {
+		return err
+	}
+
+	sr.stakingHandler, err = staking.NewStakingProcessor(
+		sr.accounts,
+		sr.di.marshalizer,
+		sr.di.hasher,
+		scForwarder,
+		economicsData,
+		keyGen,
+		singleSigner,
+		slashingVerifier,
+	)
+	if err != nil {
+		return err
+	}
+
+	sr.txProcessor, err = transaction.NewTxProcessor(
+		sr.accounts,
+		sr.di.hasher,
+		addressConverter,
+		sr.di.marshalizer,
+		sr.shardCoordinator,
+		scProcessor,
+		economicsData,
+		txFeeHandler,
+		sr.stakingHandler,
+		sr.receiptsHandler,
+		scForwarder,
+	)
+
+	return err
+}
+
+// replay executes the given block on top of the state of its previous block and returns the resulting state root
+// hash. The state changes are reverted before returning
+func (sr *stateReplayer) replay(header *block.Header) ([]byte, error) {
+	prevHeader, err := sr.getPrevHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	ratingsHandler, err := sr.createRatingsHandler(prevHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	// the block is processed with the validators lists set after its previous block was committed
+	err = sr.restoreEpochState(prevHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sr.getBody(header)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.accounts.RecreateTrie(prevHeader.RootHash)
+	if err != nil {
+		return nil, errors.New("error reading the state of the previous block: " + err.Error())
+	}
+	defer func() {
+		_ = sr.accounts.RevertToSnapshot(0)
+	}()
+
+	sr.txFeeHandler.CreateBlockStarted()
+	sr.stakingHandler.CreateBlockStarted()
+	sr.receiptsHandler.CreateBlockStarted()
+	for _, key := range sr.interimProcessors.Keys() {
+		interimProc, err := sr.interimProcessors.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		interimProc.CreateBlockStarted()
+	}
+
+	// the transactions are executed before the smart contract results, as done by the transactions coordinator
+	err = sr.processTransactions(body, header.Round)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.processSmartContractResults(body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.specialAddresses.SetConsensusData(header.PrevRandSeed, header.Round)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ratingsHandler.SaveRatings()
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.creditAccumulatedFees()
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.creditRewards(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.accounts.RootHash()
+}
+
+// createRatingsHandler creates a ratings processor holding the rating changes observed in the given block. The node
+// computes them when it commits the block, with the validators lists set before the block, and adds the decreases
+// of the validators slashed in the metablocks the block finished processing
+func (sr *stateReplayer) createRatingsHandler(header *block.Header) (process.RatingsHandler, error) {
+	ratingsHandler, err := rating.NewRatingsProcessor(sr.accounts, sr.groupSelector, sr.ratingSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	// the genesis block is not committed, so it has no rating changes
+	if header.Nonce == 0 {
+		return ratingsHandler, nil
+	}
+
+	prevHeader, err := sr.getPrevHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.restoreEpochState(prevHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ratingsHandler.ProcessCommittedHeader(header, prevHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	processedMetaBlocks, err := sr.getProcessedMetaBlocks(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, metaBlock := range processedMetaBlocks {
+		ratingsHandler.ProcessSlashedPeers(metaBlock.PeerInfo)
+	}
+
+	return ratingsHandler, nil
+}
+
+// restoreEpochState loads the validators lists of the given block's epoch, from the epoch start metablock included
+// in the first block of the epoch. The validators get the ratings saved in the state of that first block, as a
+// running node does when the epoch starts
+func (sr *stateReplayer) restoreEpochState(header *block.Header) error {
+	if sr.isEpochRestored && sr.epochHandler.Epoch() == header.Epoch {
+		return nil
+	}
+
+	sr.isEpochRestored = false
+	if header.Epoch == 0 {
+		err := sr.epochHandler.RestoreEpochStart(0, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		sr.isEpochRestored = true
+		return nil
+	}
+
+	firstHeader, err := sr.getFirstHeaderOfEpoch(header)
+	if err != nil {
+		return err
+	}
+
+	for _, metaBlockHash := range firstHeader.MetaBlockHashes {
+		metaBlock, err := sr.di.getMetaBlock(metaBlockHash)
+		if err != nil {
+			return fmt.Errorf("metablock %s could not be read: %s", toHex(metaBlockHash), err.Error())
+		}
+
+		if !metaBlock.IsStartOfEpochBlock() || metaBlock.Epoch != header.Epoch {
+			continue
+		}
+
+		err = sr.ratingReader.loadRatings(firstHeader.RootHash)
+		if err != nil {
+			return err
+		}
+
+		err = sr.epochHandler.RestoreEpochStart(metaBlock.Epoch, metaBlock.EpochStart, nil)
+		if err != nil {
+			return err
+		}
+
+		sr.isEpochRestored = true
+		return nil
+	}
+
+	return fmt.Errorf("%s for epoch %d", errMissingEpochStartMetaBlock.Error(), header.Epoch)
+}
+
+// getFirstHeaderOfEpoch walks back from the given header until the previous header belongs to an older epoch
+func (sr *stateReplayer) getFirstHeaderOfEpoch(header *block.Header) (*block.Header, error) {
+	firstHeader := header
+	for firstHeader.Nonce > 1 {
+		prevHeader, err := sr.getPrevHeader(firstHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		if prevHeader.Epoch < header.Epoch {
+			break
+		}
+
+		firstHeader = prevHeader
+	}
+
+	return firstHeader, nil
+}
+
+// getProcessedMetaBlocks returns the metablocks included in the given block whose miniblocks with destination in
+// the current shard were all processed once the block was committed. The miniblocks of a metablock are processed
+// by the consecutive blocks which include the metablock
+func (sr *stateReplayer) getProcessedMetaBlocks(header *block.Header) ([]*block.MetaBlock, error) {
+	processedMetaBlocks := make([]*block.MetaBlock, 0)
+	for _, metaBlockHash := range header.MetaBlockHashes {
+		metaBlock, err := sr.di.getMetaBlock(metaBlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("metablock %s could not be read: %s", toHex(metaBlockHash), err.Error())
+		}
+
+		crossMiniBlockHashes := metaBlock.GetMiniBlockHeadersWithDst(sr.shardCoordinator.SelfId())
+		currHeader := header
+		for len(crossMiniBlockHashes) > 0 && containsHash(currHeader.MetaBlockHashes, metaBlockHash) {
+			for _, mbHeader := range currHeader.MiniBlockHeaders {
+				delete(crossMiniBlockHashes, string(mbHeader.Hash))
+			}
+			if currHeader.Nonce <= 1 {
+				break
+			}
+
+			currHeader, err = sr.di.getHeader(currHeader.PrevHash)
+			if err != nil {
+				return nil, fmt.Errorf("header %s could not be read: %s", toHex(currHeader.PrevHash), err.Error())
+			}
+		}
+
+		if len(crossMiniBlockHashes) == 0 {
+			processedMetaBlocks = append(processedMetaBlocks, metaBlock)
+		}
+	}
+
+	return processedMetaBlocks, nil
+}
+
+// getPrevHeader returns the previous header of the given one. The genesis block is not stored by the node, so it is
+// the one recreated from the genesis file
+func (sr *stateReplayer) getPrevHeader(header *block.Header) (*block.Header, error) {
+	if header.Nonce == 1 {
+		return sr.genesisHeader, nil
+	}
+
+	prevHeader, err := sr.di.getHeader(header.PrevHash)
+	if err != nil {
+		return nil, fmt.Errorf("previous header %s could not be read: %s", toHex(header.PrevHash), err.Error())
+	}
+
+	return prevHeader, nil
+}
+
+func (sr *stateReplayer) getBody(header *block.Header) (block.Body, error) {
+	body := make(block.Body, 0, len(header.MiniBlockHeaders))
+	for _, mbHeader := range header.MiniBlockHeaders {
+		miniBlock, err := sr.di.getMiniBlock(mbHeader.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("miniblock %s could not be read: %s", toHex(mbHeader.Hash), err.Error())
+		}
+
+		body = append(body, miniBlock)
+	}
+
+	return body, nil
+}
+
+func (sr *stateReplayer) processTransactions(body block.Body, round uint64) error {
+	for _, miniBlock := range body {
+		if miniBlock.Type != block.TxBlock {
+			continue
+		}
+
+		for _, txHash := range miniBlock.TxHashes {
+			tx, err := sr.di.getTransaction(txHash)
+			if err != nil {
+				return fmt.Errorf("transaction %s could not be read: %s", toHex(txHash), err.Error())
+			}
+
+			err = sr.txProcessor.ProcessTransaction(tx, round)
+			if err != nil {
+				return fmt.Errorf("transaction %s could not be executed: %s", toHex(txHash), err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// processSmartContractResults executes the smart contract results received from other shards. The ones created by
+// the block's own transactions were already applied when the transactions were executed
+func (sr *stateReplayer) processSmartContractResults(body block.Body) error {
+	for _, miniBlock := range body {
+		if miniBlock.Type != block.SmartContractResultBlock {
+			continue
+		}
+		if miniBlock.ReceiverShardID != sr.shardCoordinator.SelfId() {
+			continue
+		}
+
+		for _, scrHash := range miniBlock.TxHashes {
+			scr, err := sr.di.getSmartContractResult(scrHash)
+			if err != nil {
+				return fmt.Errorf("smart contract result %s could not be read: %s", toHex(scrHash), err.Error())
+			}
+
+			err = sr.scProcessor.ProcessSmartContractResult(scr)
+			if err != nil {
+				return fmt.Errorf("smart contract result %s could not be executed: %s", toHex(scrHash), err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// creditAccumulatedFees transfers the fees paid by the executed transactions to the reward address of the leader,
+// if it belongs to the current shard
+func (sr *stateReplayer) creditAccumulatedFees() error {
+	accumulatedFees := sr.txFeeHandler.AccumulatedFees()
+	if accumulatedFees.Cmp(big.NewInt(0)) == 0 {
+		return nil
+	}
+
+	leaderAddress := sr.specialAddresses.LeaderAddress()
+	if len(leaderAddress) == 0 {
+		return nil
+	}
+
+	adrLeader := state.NewAddress(leaderAddress)
+	if sr.shardCoordinator.ComputeId(adrLeader) != sr.shardCoordinator.SelfId() {
+		return nil
+	}
+
+	return sr.creditBalance(adrLeader, accumulatedFees)
+}
+
+// creditRewards credits the stored reward transactions of the block. The node stores only the reward transactions
+// minted in its own shard
+func (sr *stateReplayer) creditRewards(body block.Body) error {
+	for _, miniBlock := range body {
+		if miniBlock.Type != block.RewardsBlock {
+			continue
+		}
+
+		for _, txHash := range miniBlock.TxHashes {
+			rTx, err := sr.di.getRewardTx(txHash)
+			if err != nil {
+				return fmt.Errorf("reward transaction %s could not be read: %s", toHex(txHash), err.Error())
+			}
+
+			err = sr.creditBalance(state.NewAddress(rTx.RcvAddr), rTx.Value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (sr *stateReplayer) creditBalance(adrReceiver state.AddressContainer, value *big.Int) error {
+	acntWrp, err := sr.accounts.GetAccountWithJournal(adrReceiver)
+	if err != nil {
+		return err
+	}
+
+	acntReceiver, ok := acntWrp.(*state.Account)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	return acntReceiver.SetBalanceWithJournal(big.NewInt(0).Add(acntReceiver.Balance, value))
+}
+
+// stateRatingReader reads the ratings saved in the data trie of the ratings account, at the state of a block. These
+// are the ratings known by a running node once that block was committed
+type stateRatingReader struct {
+	accounts       state.AccountsAdapter
+	ratingSettings process.RatingSettingsHandler
+	acntRatings    state.AccountHandler
+}
+
+// loadRatings loads the ratings account found in the state with the given root hash
+func (srr *stateRatingReader) loadRatings(rootHash []byte) error {
+	srr.acntRatings = nil
+
+	accounts, err := srr.accounts.RecreateReadOnly(rootHash)
+	if err != nil {
+		return err
+	}
+
+	acntRatings, err := accounts.GetExistingAccount(state.NewAddress(process.RatingsAddress))
+	if err == state.ErrAccNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	srr.acntRatings = acntRatings
+	return nil
+}
+
+// GetRating returns the loaded rating of a validator, or the start rating if none was saved
+func (srr *stateRatingReader) GetRating(pubKey string) int32 {
+	if srr.acntRatings == nil || srr.acntRatings.DataTrie() == nil {
+		return srr.ratingSettings.StartRating()
+	}
+
+	buff, err := srr.acntRatings.DataTrieTracker().RetrieveValue([]byte(pubKey))
+	if err != nil || len(buff) != ratingSizeInBytes {
+		return srr.ratingSettings.StartRating()
+	}
+
+	return int32(binary.BigEndian.Uint32(buff))
+}
+
+// nullLogsHandler drops the logs of the executed smart contract calls, as they do not change the state
+type nullLogsHandler struct {
+}
+
+// SaveLogs does nothing
+func (*nullLogsHandler) SaveLogs(txHash []byte, logs []*vmcommon.LogEntry) {
+}
+
+// createGenesisHeader recreates the genesis block of the shard from the genesis file, as the node does
+func createGenesisHeader(
+	genesisConfig *sharding.Genesis,
+	nodesConfig *sharding.NodesSetup,
+	shardCoordinator sharding.Coordinator,
+	addressConverter state.AddressConverter,
+	accountFactory state.AccountFactory,
+	di *dbInspector,
+) (*block.Header, error) {
+	memDb, err := memorydb.New()
+	if err != nil {
+		return nil, err
+	}
+	merkleTrie, err := trie.NewTrie(memDb, di.marshalizer, di.hasher)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := state.NewAccountsDB(merkleTrie, di.hasher, di.marshalizer, accountFactory, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	initialBalances, err := genesisConfig.InitialNodesBalances(shardCoordinator, addressConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisHeader, err := genesis.CreateShardGenesisBlockFromInitialBalances(
+		accounts,
+		shardCoordinator,
+		addressConverter,
+		initialBalances,
+		uint64(nodesConfig.StartTime),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	header, ok := genesisHeader.(*block.Header)
+	if !ok {
+		return nil, process.ErrWrongTypeAssertion
+	}
+
+	return header, nil
+}
+
+// createInitialValidators creates the validators lists of all shards, using the public keys and the reward addresses
+// of the initial nodes
+func createInitialValidators(nodesConfig *sharding.NodesSetup) (map[uint32][]consensus.Validator, error) {
+	pubKeys := nodesConfig.InitialNodesPubKeys()
+	addresses := nodesConfig.InitialNodesAddresses()
+
+	initialValidators := make(map[uint32][]consensus.Validator, len(pubKeys))
+	for shardId, shardPubKeys := range pubKeys {
+		validatorsList := make([]consensus.Validator, 0, len(shardPubKeys))
+		for i := 0; i < len(shardPubKeys); i++ {
+			validator, err := validators.NewValidator(big.NewInt(0), 0, []byte(shardPubKeys[i]), []byte(addresses[shardId][i]))
+			if err != nil {
+				return nil, err
+			}
+
+			validatorsList = append(validatorsList, validator)
+		}
+
+		initialValidators[shardId] = validatorsList
+	}
+
+	return initialValidators, nil
+}
+
+// createBlockSigning creates the key generator and the signer of the node keys, which verify the slashing proofs and
+// the owner signatures found in the staking transactions, using the signature scheme of the consensus
+func createBlockSigning(cfg *config.Config) (crypto.KeyGenerator, crypto.SingleSigner, error) {
+	switch cfg.Consensus.Type {
+	case factory.BlsConsensusType:
+		return signing.NewKeyGenerator(kyber.NewSuitePairingBn256()), &singlesig.BlsSingleSigner{}, nil
+	case factory.BnConsensusType:
+		return signing.NewKeyGenerator(kyber.NewBlakeSHA256Ed25519()), &singlesig.SchnorrSigner{}, nil
+	}
+
+	return nil, nil, errors.New("no consensus type provided in config file")
+}
+
+func containsHash(hashes [][]byte, hash []byte) bool {
+	for _, h := range hashes {
+		if string(h) == string(hash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storageService returns the opened units as a storage service, needed by the processors which save data
+func (di *dbInspector) storageService() dataRetriever.StorageService {
+	store := dataRetriever.NewChainStorer()
+	store.AddStorer(dataRetriever.TransactionUnit, di.transactions)
+	store.AddStorer(dataRetriever.MiniBlockUnit, di.miniBlocks)
+	store.AddStorer(dataRetriever.BlockHeaderUnit, di.headers)
+	store.AddStorer(dataRetriever.MetaBlockUnit, di.metaBlocks)
+	store.AddStorer(dataRetriever.UnsignedTransactionUnit, di.scResults)
+	store.AddStorer(dataRetriever.RewardTransactionUnit, di.rewardTxs)
+
+	return store
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/genesis"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/addressConverters"
+	factoryState "github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSenderAddress   = bytes.Repeat([]byte{1}, 32)
+	testReceiverAddress = bytes.Repeat([]byte{2}, 32)
+	testLeaderAddress   = bytes.Repeat([]byte{3}, 32)
+)
+
+// replayTestChain holds a data directory with one block, transferring a value between two accounts
+type replayTestChain struct {
+	dir              string
+	nodesConfig      *sharding.NodesSetup
+	genesisConfig    *sharding.Genesis
+	header           *block.Header
+	expectedRootHash []byte
+}
+
+func createReplayTestSetupFiles(t *testing.T, dir string) (*sharding.NodesSetup, *sharding.Genesis) {
+	nodesFile := filepath.Join(dir, "nodesSetup.json")
+	nodesJson := fmt.Sprintf(`{"startTime": 0, "roundDuration": 4000, "consensusGroupSize": 1, "minNodesPerShard": 1,
+		"initialNodes": [{"pubkey": "%s", "address": "%s"}]}`,
+		hex.EncodeToString([]byte("validator public key")), hex.EncodeToString(testLeaderAddress))
+	err := ioutil.WriteFile(nodesFile, []byte(nodesJson), 0644)
+	assert.Nil(t, err)
+
+	genesisFile := filepath.Join(dir, "genesis.json")
+	genesisJson := fmt.Sprintf(`{"initialBalances": [{"pubkey": "%s", "balance": "1000"}]}`,
+		hex.EncodeToString(testSenderAddress))
+	err = ioutil.WriteFile(genesisFile, []byte(genesisJson), 0644)
+	assert.Nil(t, err)
+
+	nodesConfig, err := sharding.NewNodesSetup(nodesFile, math.MaxUint64)
+	assert.Nil(t, err)
+	genesisConfig, err := sharding.NewGenesisConfig(genesisFile)
+	assert.Nil(t, err)
+
+	return nodesConfig, genesisConfig
+}
+
+func createReplayTestChain(t *testing.T, storeExpectedRootHash bool) *replayTestChain {
+	cfg := createTestConfig()
+	dir, ts := createTestStorage(t, cfg, 0)
+	nodesConfig, genesisConfig := createReplayTestSetupFiles(t, dir)
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(1, 0)
+	addressConverter, _ := addressConverters.NewPlainAddressConverter(32, "0x")
+	accountFactory, _ := factoryState.NewAccountFactoryCreator(shardCoordinator)
+	merkleTrie, _ := trie.NewTrie(ts.accountsTrie, marshal.JsonMarshalizer{}, blake2b.Blake2b{})
+	accounts, _ := state.NewAccountsDB(merkleTrie, blake2b.Blake2b{}, marshal.JsonMarshalizer{}, accountFactory, nil)
+
+	// the node commits the genesis state, without storing the genesis block
+	initialBalances, err := genesisConfig.InitialNodesBalances(shardCoordinator, addressConverter)
+	assert.Nil(t, err)
+	genesisHeader, err := genesis.CreateShardGenesisBlockFromInitialBalances(
+		accounts,
+		shardCoordinator,
+		addressConverter,
+		initialBalances,
+		0,
+	)
+	assert.Nil(t, err)
+
+	// the transfer of 100 pays a fee of 5, credited to the only validator, which is the leader
+	setBalance := func(address []byte, nonce uint64, balance int64) {
+		acnt, err := accounts.GetAccountWithJournal(state.NewAddress(address))
+		assert.Nil(t, err)
+		err = acnt.(*state.Account).SetNonceWithJournal(nonce)
+		assert.Nil(t, err)
+		err = acnt.(*state.Account).SetBalanceWithJournal(big.NewInt(balance))
+		assert.Nil(t, err)
+	}
+	setBalance(testSenderAddress, 1, 895)
+	setBalance(testReceiverAddress, 0, 100)
+	setBalance(testLeaderAddress, 0, 5)
+	expectedRootHash, err := accounts.Commit()
+	assert.Nil(t, err)
+
+	tx := &transaction.Transaction{
+		Nonce:    0,
+		Value:    big.NewInt(100),
+		SndAddr:  testSenderAddress,
+		RcvAddr:  testReceiverAddress,
+		GasPrice: 1,
+		GasLimit: 5,
+	}
+	txHash := ts.put(t, ts.transactions, tx)
+	miniBlock := &block.MiniBlock{TxHashes: [][]byte{txHash}, Type: block.TxBlock}
+	miniBlockHash := ts.put(t, ts.miniBlocks, miniBlock)
+
+	rootHash := []byte("wrong root hash")
+	if storeExpectedRootHash {
+		rootHash = expectedRootHash
+	}
+	header := &block.Header{
+		Nonce:            1,
+		Round:            1,
+		PrevHash:         []byte("genesis hash"),
+		PrevRandSeed:     genesisHeader.GetRandSeed(),
+		RandSeed:         []byte("rand seed"),
+		PubKeysBitmap:    []byte{0x01},
+		Signature:        []byte("signature"),
+		RootHash:         rootHash,
+		MiniBlockHeaders: []block.MiniBlockHeader{{Hash: miniBlockHash, Type: block.TxBlock, TxCount: 1}},
+		TxCount:          1,
+	}
+	ts.putHeader(t, header)
+	ts.close(t)
+
+	return &replayTestChain{
+		dir:              dir,
+		nodesConfig:      nodesConfig,
+		genesisConfig:    genesisConfig,
+		header:           header,
+		expectedRootHash: expectedRootHash,
+	}
+}
+
+func TestNewStateReplayer_WrongConsensusTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	chain := createReplayTestChain(t, true)
+	cfg := createTestConfig()
+	di, err := newDbInspector(cfg, chain.dir, 0)
+	assert.Nil(t, err)
+	defer func() {
+		_ = di.close()
+	}()
+
+	cfg.Consensus.Type = "wrong type"
+	sr, err := newStateReplayer(cfg, chain.nodesConfig, chain.genesisConfig, di, 0)
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+}
+
+func TestStateReplayer_ReplayShouldRecomputeTheRootHash(t *testing.T) {
+	t.Parallel()
+
+	chain := createReplayTestChain(t, true)
+	cfg := createTestConfig()
+	di, err := newDbInspector(cfg, chain.dir, 0)
+	assert.Nil(t, err)
+	defer func() {
+		_ = di.close()
+	}()
+
+	sr, err := newStateReplayer(cfg, chain.nodesConfig, chain.genesisConfig, di, 0)
+	assert.Nil(t, err)
+
+	rootHash, err := sr.replay(chain.header)
+	assert.Nil(t, err)
+	assert.Equal(t, chain.expectedRootHash, rootHash)
+
+	// the changes are reverted, so replaying again gives the same result
+	rootHash, err = sr.replay(chain.header)
+	assert.Nil(t, err)
+	assert.Equal(t, chain.expectedRootHash, rootHash)
+}
+
+func TestVerifyChain_ReplayedBlocksShouldNotReportProblems(t *testing.T) {
+	t.Parallel()
+
+	chain := createReplayTestChain(t, true)
+	cfg := createTestConfig()
+	di, err := newDbInspector(cfg, chain.dir, 0)
+	assert.Nil(t, err)
+	defer func() {
+		_ = di.close()
+	}()
+
+	sr, err := newStateReplayer(cfg, chain.nodesConfig, chain.genesisConfig, di, 0)
+	assert.Nil(t, err)
+
+	output := &bytes.Buffer{}
+	numProblems, err := di.verifyChain(1, 0, 1, sr, output)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numProblems, output.String())
+}
+
+func TestVerifyChain_WrongStateRootHashShouldReport(t *testing.T) {
+	t.Parallel()
+
+	chain := createReplayTestChain(t, false)
+	cfg := createTestConfig()
+	di, err := newDbInspector(cfg, chain.dir, 0)
+	assert.Nil(t, err)
+	defer func() {
+		_ = di.close()
+	}()
+
+	sr, err := newStateReplayer(cfg, chain.nodesConfig, chain.genesisConfig, di, 0)
+	assert.Nil(t, err)
+
+	output := &bytes.Buffer{}
+	numProblems, err := di.verifyChain(1, 0, 1, sr, output)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numProblems)
+	expectedProblem := fmt.Sprintf("nonce 1: state root hash is %s, expected %s",
+		toHex(chain.expectedRootHash), toHex([]byte("wrong root hash")))
+	assert.True(t, strings.Contains(output.String(), expectedProblem), output.String())
+}
@@ -14,7 +14,11 @@ import (
 
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/consensus/epoch"
 	"github.com/ElrondNetwork/elrond-go/consensus/round"
+	"github.com/ElrondNetwork/elrond-go/consensus/slashing"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators/groupSelectors"
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/genesis"
 	"github.com/ElrondNetwork/elrond-go/core/logger"
@@ -54,10 +58,17 @@ import (
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/block"
 	"github.com/ElrondNetwork/elrond-go/process/coordinator"
+	"github.com/ElrondNetwork/elrond-go/process/dataValidators"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
 	"github.com/ElrondNetwork/elrond-go/process/factory/metachain"
 	"github.com/ElrondNetwork/elrond-go/process/factory/shard"
+	"github.com/ElrondNetwork/elrond-go/process/journal"
+	"github.com/ElrondNetwork/elrond-go/process/rating"
+	"github.com/ElrondNetwork/elrond-go/process/receipts"
+	"github.com/ElrondNetwork/elrond-go/process/rewards"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract"
+	"github.com/ElrondNetwork/elrond-go/process/staking"
 	processSync "github.com/ElrondNetwork/elrond-go/process/sync"
 	"github.com/ElrondNetwork/elrond-go/process/track"
 	"github.com/ElrondNetwork/elrond-go/process/transaction"
@@ -65,6 +76,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/statusHandler"
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/pruning"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/btcsuite/btcd/btcec"
 	libp2pCrypto "github.com/libp2p/go-libp2p-core/crypto"
@@ -87,6 +99,18 @@ const (
 
 var log = logger.DefaultLogger()
 
+// epochStorerUnits are the storage units which can write each epoch in a new database
+var epochStorerUnits = []dataRetriever.UnitType{
+	dataRetriever.TransactionUnit,
+	dataRetriever.UnsignedTransactionUnit,
+	dataRetriever.MiniBlockUnit,
+	dataRetriever.BlockHeaderUnit,
+	dataRetriever.ReceiptsUnit,
+	dataRetriever.RewardTransactionUnit,
+	dataRetriever.MetaShardDataUnit,
+	dataRetriever.MetaPeerDataUnit,
+}
+
 // Network struct holds the network components of the Elrond protocol
 type Network struct {
 	NetMessenger p2p.Messenger
@@ -97,6 +121,8 @@ type Core struct {
 	Hasher                   hashing.Hasher
 	Marshalizer              marshal.Marshalizer
 	Trie                     data.Trie
+	TrieStorage              data.DBWriteCacher
+	TriePruner               data.TriePruner
 	Uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter
 	StatusHandler            core.AppStatusHandler
 }
@@ -110,31 +136,36 @@ type State struct {
 
 // Data struct holds the data components of the Elrond protocol
 type Data struct {
-	Blkc         data.ChainHandler
-	Store        dataRetriever.StorageService
-	Datapool     dataRetriever.PoolsHolder
-	MetaDatapool dataRetriever.MetaPoolsHolder
+	Blkc              data.ChainHandler
+	Store             dataRetriever.StorageService
+	Datapool          dataRetriever.PoolsHolder
+	MetaDatapool      dataRetriever.MetaPoolsHolder
+	CommittedAccounts process.CommittedAccountsProvider
 }
 
 // Crypto struct holds the crypto components of the Elrond protocol
 type Crypto struct {
-	TxSingleSigner crypto.SingleSigner
-	SingleSigner   crypto.SingleSigner
-	MultiSigner    crypto.MultiSigner
-	TxSignKeyGen   crypto.KeyGenerator
-	TxSignPrivKey  crypto.PrivateKey
-	TxSignPubKey   crypto.PublicKey
-	InitialPubKeys map[uint32][]string
+	TxSingleSigner  crypto.SingleSigner
+	SingleSigner    crypto.SingleSigner
+	MultiSigner     crypto.MultiSigner
+	BlockSignKeyGen crypto.KeyGenerator
+	TxSignKeyGen    crypto.KeyGenerator
+	TxSignPrivKey   crypto.PrivateKey
+	TxSignPubKey    crypto.PublicKey
+	InitialPubKeys  map[uint32][]string
 }
 
 // Process struct holds the process components of the Elrond protocol
 type Process struct {
-	InterceptorsContainer process.InterceptorsContainer
-	ResolversFinder       dataRetriever.ResolversFinder
-	Rounder               consensus.Rounder
-	ForkDetector          process.ForkDetector
-	BlockProcessor        process.BlockProcessor
-	BlockTracker          process.BlocksTracker
+	InterceptorsContainer  process.InterceptorsContainer
+	ResolversFinder        dataRetriever.ResolversFinder
+	Rounder                consensus.Rounder
+	ForkDetector           process.ForkDetector
+	BlockProcessor         process.BlockProcessor
+	BlockTracker           process.BlocksTracker
+	ValidatorGroupSelector consensus.ValidatorGroupSelector
+	StateSyncer            process.StateSyncer
+	CommitJournal          process.CommitJournal
 }
 
 type coreComponentsFactoryArgs struct {
@@ -162,7 +193,13 @@ func CoreComponentsFactory(args *coreComponentsFactoryArgs) (*Core, error) {
 		return nil, errors.New("could not create marshalizer: " + err.Error())
 	}
 
-	merkleTrie, err := getTrie(args.config.AccountsTrieStorage, marshalizer, hasher, args.uniqueID)
+	merkleTrie, trieStorage, triePruner, err := getTrie(
+		args.config.AccountsTrieStorage,
+		args.config.TriePruning,
+		marshalizer,
+		hasher,
+		args.uniqueID,
+	)
 	if err != nil {
 		return nil, errors.New("error creating trie: " + err.Error())
 	}
@@ -172,6 +209,8 @@ func CoreComponentsFactory(args *coreComponentsFactoryArgs) (*Core, error) {
 		Hasher:                   hasher,
 		Marshalizer:              marshalizer,
 		Trie:                     merkleTrie,
+		TrieStorage:              trieStorage,
+		TriePruner:               triePruner,
 		Uint64ByteSliceConverter: uint64ByteSliceConverter,
 		StatusHandler:            statusHandler.NewNilStatusHandler(),
 	}, nil
@@ -211,7 +250,13 @@ func StateComponentsFactory(args *stateComponentsFactoryArgs) (*State, error) {
 		return nil, errors.New("could not create account factory: " + err.Error())
 	}
 
-	accountsAdapter, err := state.NewAccountsDB(args.core.Trie, args.core.Hasher, args.core.Marshalizer, accountFactory)
+	accountsAdapter, err := state.NewAccountsDB(
+		args.core.Trie,
+		args.core.Hasher,
+		args.core.Marshalizer,
+		accountFactory,
+		args.core.TriePruner,
+	)
 	if err != nil {
 		return nil, errors.New("could not create accounts adapter: " + err.Error())
 	}
@@ -232,16 +277,28 @@ type dataComponentsFactoryArgs struct {
 	config           *config.Config
 	shardCoordinator sharding.Coordinator
 	core             *Core
+	state            *State
 	uniqueID         string
+	dbPathTemplate   string
 }
 
-// NewDataComponentsFactoryArgs initializes the arguments necessary for creating the data components
-func NewDataComponentsFactoryArgs(config *config.Config, shardCoordinator sharding.Coordinator, core *Core, uniqueID string) *dataComponentsFactoryArgs {
+// NewDataComponentsFactoryArgs initializes the arguments necessary for creating the data components. The database
+// path template holds the epoch placeholder, being used by the storers which write each epoch in a new database
+func NewDataComponentsFactoryArgs(
+	config *config.Config,
+	shardCoordinator sharding.Coordinator,
+	core *Core,
+	state *State,
+	uniqueID string,
+	dbPathTemplate string,
+) *dataComponentsFactoryArgs {
 	return &dataComponentsFactoryArgs{
 		config:           config,
 		shardCoordinator: shardCoordinator,
 		core:             core,
+		state:            state,
 		uniqueID:         uniqueID,
+		dbPathTemplate:   dbPathTemplate,
 	}
 }
 
@@ -254,13 +311,18 @@ func DataComponentsFactory(args *dataComponentsFactoryArgs) (*Data, error) {
 		return nil, errors.New("could not create block chain: " + err.Error())
 	}
 
-	store, err := createDataStoreFromConfig(args.config, args.shardCoordinator, args.uniqueID)
+	store, err := createDataStoreFromConfig(args.config, args.shardCoordinator, args.uniqueID, args.dbPathTemplate)
 	if err != nil {
 		return nil, errors.New("could not create local data store: " + err.Error())
 	}
 
+	committedAccounts, err := state.NewCommittedAccountsProvider(args.state.AccountsAdapter, blkc)
+	if err != nil {
+		return nil, errors.New("could not create committed accounts provider: " + err.Error())
+	}
+
 	if args.shardCoordinator.SelfId() < args.shardCoordinator.NumberOfShards() {
-		datapool, err = createShardDataPoolFromConfig(args.config, args.core.Uint64ByteSliceConverter)
+		datapool, err = createShardDataPoolFromConfig(args.config, args.core.Uint64ByteSliceConverter, committedAccounts)
 		if err != nil {
 			return nil, errors.New("could not create shard data pools: " + err.Error())
 		}
@@ -276,10 +338,11 @@ func DataComponentsFactory(args *dataComponentsFactoryArgs) (*Data, error) {
 	}
 
 	return &Data{
-		Blkc:         blkc,
-		Store:        store,
-		Datapool:     datapool,
-		MetaDatapool: metaDatapool,
+		Blkc:              blkc,
+		Store:             store,
+		Datapool:          datapool,
+		MetaDatapool:      metaDatapool,
+		CommittedAccounts: committedAccounts,
 	}, nil
 }
 
@@ -361,13 +424,14 @@ func CryptoComponentsFactory(args *cryptoComponentsFactoryArgs) (*Crypto, error)
 	args.log.Info("Starting with tx sign public key: " + GetPkEncoded(txSignPubKey))
 
 	return &Crypto{
-		TxSingleSigner: txSingleSigner,
-		SingleSigner:   singleSigner,
-		MultiSigner:    multiSigner,
-		TxSignKeyGen:   txSignKeyGen,
-		TxSignPrivKey:  txSignPrivKey,
-		TxSignPubKey:   txSignPubKey,
-		InitialPubKeys: initialPubKeys,
+		TxSingleSigner:  txSingleSigner,
+		SingleSigner:    singleSigner,
+		MultiSigner:     multiSigner,
+		BlockSignKeyGen: args.keyGen,
+		TxSignKeyGen:    txSignKeyGen,
+		TxSignPrivKey:   txSignPrivKey,
+		TxSignPubKey:    txSignPubKey,
+		InitialPubKeys:  initialPubKeys,
 	}, nil
 }
 
@@ -391,6 +455,7 @@ func NetworkComponentsFactory(p2pConfig *config.P2PConfig, log *logger.Logger, c
 }
 
 type processComponentsFactoryArgs struct {
+	config               *config.Config
 	genesisConfig        *sharding.Genesis
 	nodesConfig          *sharding.NodesSetup
 	syncer               ntp.SyncTimer
@@ -401,10 +466,13 @@ type processComponentsFactoryArgs struct {
 	state                *State
 	network              *Network
 	coreServiceContainer serviceContainer.Core
+	scLogsHandler        process.SmartContractLogsHandler
+	economicsData        *economics.EconomicsData
 }
 
 // NewProcessComponentsFactoryArgs initializes the arguments necessary for creating the process components
 func NewProcessComponentsFactoryArgs(
+	config *config.Config,
 	genesisConfig *sharding.Genesis,
 	nodesConfig *sharding.NodesSetup,
 	syncer ntp.SyncTimer,
@@ -415,8 +483,11 @@ func NewProcessComponentsFactoryArgs(
 	state *State,
 	network *Network,
 	coreServiceContainer serviceContainer.Core,
+	scLogsHandler process.SmartContractLogsHandler,
+	economicsData *economics.EconomicsData,
 ) *processComponentsFactoryArgs {
 	return &processComponentsFactoryArgs{
+		config:               config,
 		genesisConfig:        genesisConfig,
 		nodesConfig:          nodesConfig,
 		syncer:               syncer,
@@ -427,13 +498,48 @@ func NewProcessComponentsFactoryArgs(
 		state:                state,
 		network:              network,
 		coreServiceContainer: coreServiceContainer,
+		scLogsHandler:        scLogsHandler,
+		economicsData:        economicsData,
 	}
 }
 
 // ProcessComponentsFactory creates the process components
 func ProcessComponentsFactory(args *processComponentsFactoryArgs) (*Process, error) {
+	rounder, err := round.NewRound(
+		time.Unix(args.nodesConfig.StartTime, 0),
+		args.syncer.CurrentTime(),
+		time.Millisecond*time.Duration(args.nodesConfig.RoundDuration),
+		args.syncer)
+	if err != nil {
+		return nil, err
+	}
+
+	forkDetector, err := processSync.NewBasicForkDetector(rounder)
+	if err != nil {
+		return nil, err
+	}
+
+	headerValidator, err := dataValidators.NewHeaderValidator(forkDetector, rounder, args.shardCoordinator)
+	if err != nil {
+		return nil, err
+	}
+
+	err = headerValidator.SetAppStatusHandler(args.core.StatusHandler)
+	if err != nil {
+		return nil, err
+	}
+
 	interceptorContainerFactory, resolversContainerFactory, err := newInterceptorAndResolverContainerFactory(
-		args.shardCoordinator, args.data, args.core, args.crypto, args.state, args.network)
+		args.shardCoordinator,
+		args.data,
+		args.core,
+		args.crypto,
+		args.state,
+		args.network,
+		args.config,
+		args.economicsData,
+		headerValidator,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -454,27 +560,89 @@ func ProcessComponentsFactory(args *processComponentsFactoryArgs) (*Process, err
 		return nil, err
 	}
 
-	rounder, err := round.NewRound(
-		time.Unix(args.nodesConfig.StartTime, 0),
-		args.syncer.CurrentTime(),
-		time.Millisecond*time.Duration(args.nodesConfig.RoundDuration),
-		args.syncer)
+	shardsGenesisBlocks, err := generateGenesisHeadersForInit(
+		args.nodesConfig,
+		args.genesisConfig,
+		args.shardCoordinator,
+		args.state.AddressConverter,
+		args.core.Hasher,
+		args.core.Marshalizer,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	forkDetector, err := processSync.NewBasicForkDetector(rounder)
+	initialValidators, err := createInitialValidators(args.nodesConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	shardsGenesisBlocks, err := generateGenesisHeadersForInit(
+	validatorGroupSelector, err := createValidatorGroupSelector(
 		args.nodesConfig,
-		args.genesisConfig,
 		args.shardCoordinator,
-		args.state.AddressConverter,
 		args.core.Hasher,
+		initialValidators,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ratingsHandler, err := rating.NewRatingsProcessor(
+		args.state.AccountsAdapter,
+		validatorGroupSelector,
+		args.economicsData,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	epochHandler, err := epoch.NewEpochManager(
+		args.config.EpochStartConfig.RoundsPerEpoch,
+		args.config.EpochStartConfig.NodesToShufflePerShard,
+		args.nodesConfig.ConsensusGroupSize,
+		args.core.Hasher,
+		args.shardCoordinator,
+		validatorGroupSelector,
+		ratingsHandler,
+		initialValidators,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, unitType := range epochStorerUnits {
+		epochChangeHandler, ok := args.data.Store.GetStorer(unitType).(consensus.EpochChangeHandler)
+		if ok {
+			epochHandler.RegisterEpochChangeHandler(epochChangeHandler)
+		}
+	}
+
+	genesisTotalSupply := args.genesisConfig.TotalSupply()
+	rewardsCalculator, err := economics.NewRewardsCalculator(
+		args.economicsData,
+		genesisTotalSupply,
+		args.nodesConfig.RoundDuration,
+		args.shardCoordinator.NumberOfShards(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	slashingVerifier, err := slashing.NewProofVerifier(
+		args.crypto.BlockSignKeyGen,
+		args.crypto.SingleSigner,
+		args.core.Marshalizer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commitJournal, err := journal.NewCommitJournal(
+		args.data.Store,
+		args.state.AccountsAdapter,
+		args.core.TrieStorage,
 		args.core.Marshalizer,
+		args.config.CommitJournal.NumEntriesToKeep,
 	)
 	if err != nil {
 		return nil, err
@@ -483,27 +651,81 @@ func ProcessComponentsFactory(args *processComponentsFactoryArgs) (*Process, err
 	blockProcessor, blockTracker, err := newBlockProcessorAndTracker(
 		resolversFinder,
 		args.shardCoordinator,
+		validatorGroupSelector,
+		epochHandler,
+		ratingsHandler,
+		rewardsCalculator,
+		slashingVerifier,
+		genesisTotalSupply,
 		args.data,
 		args.core,
+		args.crypto,
 		args.state,
 		forkDetector,
 		shardsGenesisBlocks,
 		args.coreServiceContainer,
+		args.scLogsHandler,
+		args.economicsData,
+		commitJournal,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	stateSyncer, err := newStateSyncer(args.config.StateSync, resolversFinder, args.shardCoordinator, args.data, args.core,
+		args.state, blockProcessor)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Process{
-		InterceptorsContainer: interceptorsContainer,
-		ResolversFinder:       resolversFinder,
-		Rounder:               rounder,
-		ForkDetector:          forkDetector,
-		BlockProcessor:        blockProcessor,
-		BlockTracker:          blockTracker,
+		InterceptorsContainer:  interceptorsContainer,
+		ResolversFinder:        resolversFinder,
+		Rounder:                rounder,
+		ForkDetector:           forkDetector,
+		BlockProcessor:         blockProcessor,
+		BlockTracker:           blockTracker,
+		ValidatorGroupSelector: validatorGroupSelector,
+		StateSyncer:            stateSyncer,
+		CommitJournal:          commitJournal,
 	}, nil
 }
 
+// newStateSyncer creates the state syncer of a shard node, it returns nil if the state sync is disabled
+func newStateSyncer(
+	cfg config.StateSyncConfig,
+	resolversFinder dataRetriever.ResolversFinder,
+	shardCoordinator sharding.Coordinator,
+	data *Data,
+	core *Core,
+	stateComponents *State,
+	blockProcessor process.BlockProcessor,
+) (process.StateSyncer, error) {
+	if !cfg.Enabled || shardCoordinator.SelfId() >= shardCoordinator.NumberOfShards() {
+		return nil, nil
+	}
+
+	referencesExtractor, err := state.NewDataTrieReferencesExtractor(core.Marshalizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return processSync.NewStateSync(
+		data.Datapool,
+		resolversFinder,
+		core.TrieStorage,
+		core.TriePruner,
+		referencesExtractor,
+		data.Blkc,
+		blockProcessor,
+		stateComponents.AccountsAdapter,
+		core.Hasher,
+		core.Marshalizer,
+		shardCoordinator,
+		time.Duration(cfg.RequestTimeInMs)*time.Millisecond,
+	)
+}
+
 type seedRandReader struct {
 	index int
 	seed  []byte
@@ -569,17 +791,48 @@ func getMarshalizerFromConfig(cfg *config.Config) (marshal.Marshalizer, error) {
 	return nil, errors.New("no marshalizer provided in config file")
 }
 
-func getTrie(cfg config.StorageConfig, marshalizer marshal.Marshalizer, hasher hashing.Hasher, uniqueID string) (data.Trie, error) {
+func getTrie(
+	cfg config.StorageConfig,
+	pruningCfg config.TriePruningConfig,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	uniqueID string,
+) (data.Trie, data.DBWriteCacher, data.TriePruner, error) {
 	accountsTrieStorage, err := storageUnit.NewStorageUnitFromConf(
 		getCacherFromConfig(cfg.Cache),
 		getDBFromConfig(cfg.DB, uniqueID),
 		getBloomFromConfig(cfg.Bloom),
 	)
 	if err != nil {
-		return nil, errors.New("error creating accountsTrieStorage: " + err.Error())
+		return nil, nil, nil, errors.New("error creating accountsTrieStorage: " + err.Error())
 	}
 
-	return trie.NewTrie(accountsTrieStorage, marshalizer, hasher)
+	if pruningCfg.ArchiveMode {
+		merkleTrie, err := trie.NewTrie(accountsTrieStorage, marshalizer, hasher)
+		return merkleTrie, accountsTrieStorage, nil, err
+	}
+
+	referencesExtractor, err := state.NewDataTrieReferencesExtractor(marshalizer)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pruningStorage, err := trie.NewPruningStorage(
+		accountsTrieStorage,
+		marshalizer,
+		referencesExtractor,
+		pruningCfg.NumRootsToKeep,
+	)
+	if err != nil {
+		return nil, nil, nil, errors.New("error creating trie pruning storage: " + err.Error())
+	}
+
+	merkleTrie, err := trie.NewTrie(pruningStorage, marshalizer, hasher)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return merkleTrie, pruningStorage, pruningStorage, nil
 }
 
 func createBlockChainFromConfig(config *config.Config, coordinator sharding.Coordinator, ash core.AppStatusHandler) (data.ChainHandler, error) {
@@ -628,12 +881,13 @@ func createDataStoreFromConfig(
 	config *config.Config,
 	shardCoordinator sharding.Coordinator,
 	uniqueID string,
+	dbPathTemplate string,
 ) (dataRetriever.StorageService, error) {
 	if shardCoordinator.SelfId() < shardCoordinator.NumberOfShards() {
-		return createShardDataStoreFromConfig(config, shardCoordinator, uniqueID)
+		return createShardDataStoreFromConfig(config, shardCoordinator, uniqueID, dbPathTemplate)
 	}
 	if shardCoordinator.SelfId() == sharding.MetachainShardId {
-		return createMetaChainDataStoreFromConfig(config, shardCoordinator, uniqueID)
+		return createMetaChainDataStoreFromConfig(config, shardCoordinator, uniqueID, dbPathTemplate)
 	}
 	return nil, errors.New("can not create data store")
 }
@@ -642,8 +896,10 @@ func createShardDataStoreFromConfig(
 	config *config.Config,
 	shardCoordinator sharding.Coordinator,
 	uniqueID string,
+	dbPathTemplate string,
 ) (dataRetriever.StorageService, error) {
-	var headerUnit, peerBlockUnit, miniBlockUnit, txUnit, metachainHeaderUnit, unsignedTxUnit, metaHdrHashNonceUnit, shardHdrHashNonceUnit *storageUnit.Unit
+	var peerBlockUnit, metachainHeaderUnit, metaHdrHashNonceUnit, shardHdrHashNonceUnit, commitJournalUnit *storageUnit.Unit
+	var headerUnit, miniBlockUnit, txUnit, unsignedTxUnit, receiptsUnit, rewardTxUnit storage.Storer
 	var err error
 
 	defer func() {
@@ -673,29 +929,29 @@ func createShardDataStoreFromConfig(
 			if shardHdrHashNonceUnit != nil {
 				_ = shardHdrHashNonceUnit.DestroyUnit()
 			}
+			if receiptsUnit != nil {
+				_ = receiptsUnit.DestroyUnit()
+			}
+			if rewardTxUnit != nil {
+				_ = rewardTxUnit.DestroyUnit()
+			}
+			if commitJournalUnit != nil {
+				_ = commitJournalUnit.DestroyUnit()
+			}
 		}
 	}()
 
-	txUnit, err = storageUnit.NewStorageUnitFromConf(
-		getCacherFromConfig(config.TxStorage.Cache),
-		getDBFromConfig(config.TxStorage.DB, uniqueID),
-		getBloomFromConfig(config.TxStorage.Bloom))
+	txUnit, err = createEpochStorer(config.TxStorage, config.StoragePruning, uniqueID, dbPathTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	unsignedTxUnit, err = storageUnit.NewStorageUnitFromConf(
-		getCacherFromConfig(config.UnsignedTransactionStorage.Cache),
-		getDBFromConfig(config.UnsignedTransactionStorage.DB, uniqueID),
-		getBloomFromConfig(config.UnsignedTransactionStorage.Bloom))
+	unsignedTxUnit, err = createEpochStorer(config.UnsignedTransactionStorage, config.StoragePruning, uniqueID, dbPathTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	miniBlockUnit, err = storageUnit.NewStorageUnitFromConf(
-		getCacherFromConfig(config.MiniBlocksStorage.Cache),
-		getDBFromConfig(config.MiniBlocksStorage.DB, uniqueID),
-		getBloomFromConfig(config.MiniBlocksStorage.Bloom))
+	miniBlockUnit, err = createEpochStorer(config.MiniBlocksStorage, config.StoragePruning, uniqueID, dbPathTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -708,10 +964,7 @@ func createShardDataStoreFromConfig(
 		return nil, err
 	}
 
-	headerUnit, err = storageUnit.NewStorageUnitFromConf(
-		getCacherFromConfig(config.BlockHeaderStorage.Cache),
-		getDBFromConfig(config.BlockHeaderStorage.DB, uniqueID),
-		getBloomFromConfig(config.BlockHeaderStorage.Bloom))
+	headerUnit, err = createEpochStorer(config.BlockHeaderStorage, config.StoragePruning, uniqueID, dbPathTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -743,6 +996,24 @@ func createShardDataStoreFromConfig(
 		return nil, err
 	}
 
+	receiptsUnit, err = createEpochStorer(config.ReceiptsStorage, config.StoragePruning, uniqueID, dbPathTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	rewardTxUnit, err = createEpochStorer(config.RewardTxStorage, config.StoragePruning, uniqueID, dbPathTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	commitJournalUnit, err = storageUnit.NewStorageUnitFromConf(
+		getCacherFromConfig(config.CommitJournalStorage.Cache),
+		getDBFromConfig(config.CommitJournalStorage.DB, uniqueID),
+		getBloomFromConfig(config.CommitJournalStorage.Bloom))
+	if err != nil {
+		return nil, err
+	}
+
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, txUnit)
 	store.AddStorer(dataRetriever.MiniBlockUnit, miniBlockUnit)
@@ -753,6 +1024,9 @@ func createShardDataStoreFromConfig(
 	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, metaHdrHashNonceUnit)
 	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(shardCoordinator.SelfId())
 	store.AddStorer(hdrNonceHashDataUnit, shardHdrHashNonceUnit)
+	store.AddStorer(dataRetriever.ReceiptsUnit, receiptsUnit)
+	store.AddStorer(dataRetriever.RewardTransactionUnit, rewardTxUnit)
+	store.AddStorer(dataRetriever.CommitJournalUnit, commitJournalUnit)
 
 	return store, err
 }
@@ -761,8 +1035,10 @@ func createMetaChainDataStoreFromConfig(
 	config *config.Config,
 	shardCoordinator sharding.Coordinator,
 	uniqueID string,
+	dbPathTemplate string,
 ) (dataRetriever.StorageService, error) {
-	var peerDataUnit, shardDataUnit, metaBlockUnit, headerUnit, metaHdrHashNonceUnit *storageUnit.Unit
+	var metaBlockUnit, metaHdrHashNonceUnit, commitJournalUnit *storageUnit.Unit
+	var peerDataUnit, shardDataUnit, headerUnit storage.Storer
 	var shardHdrHashNonceUnits []*storageUnit.Unit
 	var err error
 
@@ -784,6 +1060,9 @@ func createMetaChainDataStoreFromConfig(
 			if metaHdrHashNonceUnit != nil {
 				_ = metaHdrHashNonceUnit.DestroyUnit()
 			}
+			if commitJournalUnit != nil {
+				_ = commitJournalUnit.DestroyUnit()
+			}
 			if shardHdrHashNonceUnits != nil {
 				for i := uint32(0); i < shardCoordinator.NumberOfShards(); i++ {
 					_ = shardHdrHashNonceUnits[i].DestroyUnit()
@@ -800,26 +1079,17 @@ func createMetaChainDataStoreFromConfig(
 		return nil, err
 	}
 
-	shardDataUnit, err = storageUnit.NewStorageUnitFromConf(
-		getCacherFromConfig(config.ShardDataStorage.Cache),
-		getDBFromConfig(config.ShardDataStorage.DB, uniqueID),
-		getBloomFromConfig(config.ShardDataStorage.Bloom))
+	shardDataUnit, err = createEpochStorer(config.ShardDataStorage, config.StoragePruning, uniqueID, dbPathTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	peerDataUnit, err = storageUnit.NewStorageUnitFromConf(
-		getCacherFromConfig(config.PeerDataStorage.Cache),
-		getDBFromConfig(config.PeerDataStorage.DB, uniqueID),
-		getBloomFromConfig(config.PeerDataStorage.Bloom))
+	peerDataUnit, err = createEpochStorer(config.PeerDataStorage, config.StoragePruning, uniqueID, dbPathTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	headerUnit, err = storageUnit.NewStorageUnitFromConf(
-		getCacherFromConfig(config.BlockHeaderStorage.Cache),
-		getDBFromConfig(config.BlockHeaderStorage.DB, uniqueID),
-		getBloomFromConfig(config.BlockHeaderStorage.Bloom))
+	headerUnit, err = createEpochStorer(config.BlockHeaderStorage, config.StoragePruning, uniqueID, dbPathTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -833,6 +1103,14 @@ func createMetaChainDataStoreFromConfig(
 		return nil, err
 	}
 
+	commitJournalUnit, err = storageUnit.NewStorageUnitFromConf(
+		getCacherFromConfig(config.CommitJournalStorage.Cache),
+		getDBFromConfig(config.CommitJournalStorage.DB, uniqueID),
+		getBloomFromConfig(config.CommitJournalStorage.Bloom))
+	if err != nil {
+		return nil, err
+	}
+
 	shardHdrHashNonceUnits = make([]*storageUnit.Unit, shardCoordinator.NumberOfShards())
 	for i := uint32(0); i < shardCoordinator.NumberOfShards(); i++ {
 		shardHdrHashNonceUnits[i], err = storageUnit.NewShardedStorageUnitFromConf(
@@ -852,6 +1130,7 @@ func createMetaChainDataStoreFromConfig(
 	store.AddStorer(dataRetriever.MetaPeerDataUnit, peerDataUnit)
 	store.AddStorer(dataRetriever.BlockHeaderUnit, headerUnit)
 	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, metaHdrHashNonceUnit)
+	store.AddStorer(dataRetriever.CommitJournalUnit, commitJournalUnit)
 	for i := uint32(0); i < shardCoordinator.NumberOfShards(); i++ {
 		hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(i)
 		store.AddStorer(hdrNonceHashDataUnit, shardHdrHashNonceUnits[i])
@@ -863,11 +1142,17 @@ func createMetaChainDataStoreFromConfig(
 func createShardDataPoolFromConfig(
 	config *config.Config,
 	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter,
+	nonceProvider dataRetriever.AccountNonceProvider,
 ) (dataRetriever.PoolsHolder, error) {
 
 	log.Info("creatingShardDataPool from config")
 
-	txPool, err := shardedData.NewShardedData(getCacherFromConfig(config.TxDataPool))
+	txPool, err := shardedData.NewShardedTxPool(
+		getCacherFromConfig(config.TxDataPool),
+		config.TxPool.MaxTxsPerSender,
+		config.TxPool.ReplacementGasPricePercentage,
+		nonceProvider,
+	)
 	if err != nil {
 		log.Info("error creating txpool")
 		return nil, err
@@ -919,6 +1204,13 @@ func createShardDataPoolFromConfig(
 		return nil, err
 	}
 
+	cacherCfg = getCacherFromConfig(config.TrieNodesDataPool)
+	trieNodes, err := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	if err != nil {
+		log.Info("error creating trieNodes")
+		return nil, err
+	}
+
 	return dataPool.NewShardedDataPool(
 		txPool,
 		uTxPool,
@@ -927,6 +1219,7 @@ func createShardDataPoolFromConfig(
 		txBlockBody,
 		peerChangeBlockBody,
 		metaBlockBody,
+		trieNodes,
 	)
 }
 
@@ -1061,12 +1354,16 @@ func newInterceptorAndResolverContainerFactory(
 	crypto *Crypto,
 	state *State,
 	network *Network,
+	config *config.Config,
+	economicsData *economics.EconomicsData,
+	headerValidator process.HeaderValidator,
 ) (process.InterceptorsContainerFactory, dataRetriever.ResolversContainerFactory, error) {
 	if shardCoordinator.SelfId() < shardCoordinator.NumberOfShards() {
-		return newShardInterceptorAndResolverContainerFactory(shardCoordinator, data, core, crypto, state, network)
+		return newShardInterceptorAndResolverContainerFactory(
+			shardCoordinator, data, core, crypto, state, network, config, economicsData, headerValidator)
 	}
 	if shardCoordinator.SelfId() == sharding.MetachainShardId {
-		return newMetaInterceptorAndResolverContainerFactory(shardCoordinator, data, core, crypto, network)
+		return newMetaInterceptorAndResolverContainerFactory(shardCoordinator, data, core, crypto, network, headerValidator)
 	}
 
 	return nil, nil, errors.New("could not create interceptor and resolver container factory")
@@ -1079,7 +1376,26 @@ func newShardInterceptorAndResolverContainerFactory(
 	crypto *Crypto,
 	state *State,
 	network *Network,
+	config *config.Config,
+	economicsData *economics.EconomicsData,
+	headerValidator process.HeaderValidator,
 ) (process.InterceptorsContainerFactory, dataRetriever.ResolversContainerFactory, error) {
+	txValidator, err := dataValidators.NewTxValidator(
+		data.CommittedAccounts,
+		shardCoordinator,
+		state.AddressConverter,
+		economicsData,
+		int(config.TxPool.MaxTxDataLength),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = txValidator.SetAppStatusHandler(core.StatusHandler)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	//TODO add a real chronology validator and remove null chronology validator
 	interceptorContainerFactory, err := shard.NewInterceptorsContainerFactory(
 		shardCoordinator,
@@ -1093,6 +1409,9 @@ func newShardInterceptorAndResolverContainerFactory(
 		data.Datapool,
 		state.AddressConverter,
 		&nullChronologyValidator{},
+		txValidator,
+		headerValidator,
+		[]byte(config.GeneralSettings.NetworkID),
 	)
 	if err != nil {
 		return nil, nil, err
@@ -1111,6 +1430,7 @@ func newShardInterceptorAndResolverContainerFactory(
 		data.Datapool,
 		core.Uint64ByteSliceConverter,
 		dataPacker,
+		core.TrieStorage,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -1125,6 +1445,7 @@ func newMetaInterceptorAndResolverContainerFactory(
 	core *Core,
 	crypto *Crypto,
 	network *Network,
+	headerValidator process.HeaderValidator,
 ) (process.InterceptorsContainerFactory, dataRetriever.ResolversContainerFactory, error) {
 	//TODO add a real chronology validator and remove null chronology validator
 	interceptorContainerFactory, err := metachain.NewInterceptorsContainerFactory(
@@ -1136,6 +1457,7 @@ func newMetaInterceptorAndResolverContainerFactory(
 		crypto.MultiSigner,
 		data.MetaDatapool,
 		&nullChronologyValidator{},
+		headerValidator,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -1223,18 +1545,31 @@ func generateGenesisHeadersForInit(
 func newBlockProcessorAndTracker(
 	resolversFinder dataRetriever.ResolversFinder,
 	shardCoordinator sharding.Coordinator,
+	validatorGroupSelector consensus.ValidatorGroupSelector,
+	epochHandler process.EpochHandler,
+	ratingsHandler process.RatingsHandler,
+	rewardsCalculator process.RewardsCalculator,
+	slashingVerifier process.SlashingProofVerifier,
+	genesisTotalSupply *big.Int,
 	data *Data,
 	core *Core,
+	crypto *Crypto,
 	state *State,
 	forkDetector process.ForkDetector,
 	shardsGenesisBlocks map[uint32]data.HeaderHandler,
 	coreServiceContainer serviceContainer.Core,
+	scLogsHandler process.SmartContractLogsHandler,
+	economicsData *economics.EconomicsData,
+	commitJournal process.CommitJournal,
 ) (process.BlockProcessor, process.BlocksTracker, error) {
 	if shardCoordinator.SelfId() < shardCoordinator.NumberOfShards() {
-		return newShardBlockProcessorAndTracker(resolversFinder, shardCoordinator, data, core, state, forkDetector, shardsGenesisBlocks, coreServiceContainer)
+		return newShardBlockProcessorAndTracker(resolversFinder, shardCoordinator, validatorGroupSelector, epochHandler,
+			ratingsHandler, rewardsCalculator, slashingVerifier, data, core, crypto, state, forkDetector, shardsGenesisBlocks,
+			coreServiceContainer, scLogsHandler, economicsData, commitJournal)
 	}
 	if shardCoordinator.SelfId() == sharding.MetachainShardId {
-		return newMetaBlockProcessorAndTracker(resolversFinder, shardCoordinator, data, core, state, forkDetector, shardsGenesisBlocks, coreServiceContainer)
+		return newMetaBlockProcessorAndTracker(resolversFinder, shardCoordinator, epochHandler, genesisTotalSupply, data,
+			core, state, forkDetector, shardsGenesisBlocks, coreServiceContainer, commitJournal)
 	}
 
 	return nil, nil, errors.New("could not create block processor and tracker")
@@ -1243,18 +1578,42 @@ func newBlockProcessorAndTracker(
 func newShardBlockProcessorAndTracker(
 	resolversFinder dataRetriever.ResolversFinder,
 	shardCoordinator sharding.Coordinator,
+	validatorGroupSelector consensus.ValidatorGroupSelector,
+	epochHandler process.EpochHandler,
+	ratingsHandler process.RatingsHandler,
+	rewardsCalculator process.RewardsCalculator,
+	slashingVerifier process.SlashingProofVerifier,
 	data *Data,
 	core *Core,
+	crypto *Crypto,
 	state *State,
 	forkDetector process.ForkDetector,
 	shardsGenesisBlocks map[uint32]data.HeaderHandler,
 	coreServiceContainer serviceContainer.Core,
+	scLogsHandler process.SmartContractLogsHandler,
+	economicsData *economics.EconomicsData,
+	commitJournal process.CommitJournal,
 ) (process.BlockProcessor, process.BlocksTracker, error) {
 	argsParser, err := smartContract.NewAtArgumentParser()
 	if err != nil {
 		return nil, nil, err
 	}
 
+	specialAddressHolder, err := economics.NewSpecialAddressHolder(validatorGroupSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txFeeHandler, err := economics.NewFeeAccumulator(state.AccountsAdapter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receiptsHandler, err := receipts.NewReceiptsCollector(data.Store, core.Marshalizer)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	vmFactory, err := shard.NewVMContainerFactory(state.AccountsAdapter, state.AddressConverter)
 	if err != nil {
 		return nil, nil, err
@@ -1296,6 +1655,23 @@ func newShardBlockProcessorAndTracker(
 		state.AddressConverter,
 		shardCoordinator,
 		scForwarder,
+		txFeeHandler,
+		scLogsHandler,
+		receiptsHandler,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stakingHandler, err := staking.NewStakingProcessor(
+		state.AccountsAdapter,
+		core.Marshalizer,
+		core.Hasher,
+		scForwarder,
+		economicsData,
+		crypto.BlockSignKeyGen,
+		crypto.SingleSigner,
+		slashingVerifier,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -1320,6 +1696,11 @@ func newShardBlockProcessorAndTracker(
 		core.Marshalizer,
 		shardCoordinator,
 		scProcessor,
+		economicsData,
+		txFeeHandler,
+		stakingHandler,
+		receiptsHandler,
+		scForwarder,
 	)
 	if err != nil {
 		return nil, nil, errors.New("could not create transaction processor: " + err.Error())
@@ -1369,6 +1750,20 @@ func newShardBlockProcessorAndTracker(
 		return nil, nil, err
 	}
 
+	rewardsHandler, err := rewards.NewRewardsProcessor(
+		state.AccountsAdapter,
+		data.Store,
+		shardCoordinator,
+		validatorGroupSelector,
+		core.Hasher,
+		core.Marshalizer,
+		rewardsCalculator,
+		scForwarder,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	blockProcessor, err := block.NewShardProcessor(
 		coreServiceContainer,
 		data.Datapool,
@@ -1383,6 +1778,14 @@ func newShardBlockProcessorAndTracker(
 		requestHandler,
 		txCoordinator,
 		core.Uint64ByteSliceConverter,
+		txFeeHandler,
+		specialAddressHolder,
+		epochHandler,
+		stakingHandler,
+		ratingsHandler,
+		rewardsHandler,
+		receiptsHandler,
+		commitJournal,
 	)
 	if err != nil {
 		return nil, nil, errors.New("could not create block processor: " + err.Error())
@@ -1399,12 +1802,15 @@ func newShardBlockProcessorAndTracker(
 func newMetaBlockProcessorAndTracker(
 	resolversFinder dataRetriever.ResolversFinder,
 	shardCoordinator sharding.Coordinator,
+	epochHandler process.EpochHandler,
+	genesisTotalSupply *big.Int,
 	data *Data,
 	core *Core,
 	state *State,
 	forkDetector process.ForkDetector,
 	shardsGenesisBlocks map[uint32]data.HeaderHandler,
 	coreServiceContainer serviceContainer.Core,
+	commitJournal process.CommitJournal,
 ) (process.BlockProcessor, process.BlocksTracker, error) {
 	requestHandler, err := requestHandlers.NewMetaResolverRequestHandler(resolversFinder, factory.ShardHeadersForMetachainTopic)
 	if err != nil {
@@ -1416,6 +1822,11 @@ func newMetaBlockProcessorAndTracker(
 		return nil, nil, err
 	}
 
+	totalSupplyHandler, err := rewards.NewTotalSupplyTracker(state.AccountsAdapter, genesisTotalSupply)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	metaProcessor, err := block.NewMetaProcessor(
 		coreServiceContainer,
 		state.AccountsAdapter,
@@ -1428,6 +1839,9 @@ func newMetaBlockProcessorAndTracker(
 		shardsGenesisBlocks,
 		requestHandler,
 		core.Uint64ByteSliceConverter,
+		epochHandler,
+		totalSupplyHandler,
+		commitJournal,
 	)
 	if err != nil {
 		return nil, nil, errors.New("could not create block processor: " + err.Error())
@@ -1435,6 +1849,103 @@ func newMetaBlockProcessorAndTracker(
 
 	return metaProcessor, blockTracker, nil
 }
+
+// createInitialValidators creates the validators lists of all shards, using the public keys and the reward addresses
+// of the initial nodes
+func createInitialValidators(nodesConfig *sharding.NodesSetup) (map[uint32][]consensus.Validator, error) {
+	pubKeys := nodesConfig.InitialNodesPubKeys()
+	addresses := nodesConfig.InitialNodesAddresses()
+
+	initialValidators := make(map[uint32][]consensus.Validator, len(pubKeys))
+	for shardId, shardPubKeys := range pubKeys {
+		validatorsList := make([]consensus.Validator, 0, len(shardPubKeys))
+		for i := 0; i < len(shardPubKeys); i++ {
+			validator, err := validators.NewValidator(big.NewInt(0), 0, []byte(shardPubKeys[i]), []byte(addresses[shardId][i]))
+			if err != nil {
+				return nil, err
+			}
+
+			validatorsList = append(validatorsList, validator)
+		}
+
+		initialValidators[shardId] = validatorsList
+	}
+
+	return initialValidators, nil
+}
+
+// createValidatorGroupSelector creates the validator group selector of the current shard, loaded with the initial
+// validators of the shard
+func createValidatorGroupSelector(
+	nodesConfig *sharding.NodesSetup,
+	shardCoordinator sharding.Coordinator,
+	hasher hashing.Hasher,
+	initialValidators map[uint32][]consensus.Validator,
+) (consensus.ValidatorGroupSelector, error) {
+	consensusGroupSize := nodesConfig.ConsensusGroupSize
+	if shardCoordinator.SelfId() == sharding.MetachainShardId {
+		consensusGroupSize = nodesConfig.MetaChainConsensusGroupSize
+	}
+
+	validatorGroupSelector, err := groupSelectors.NewIndexHashedGroupSelector(int(consensusGroupSize), hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorsList, ok := initialValidators[shardCoordinator.SelfId()]
+	if !ok || len(validatorsList) == 0 {
+		return nil, errors.New("could not create validator group selector as there are no validators for shard")
+	}
+
+	err = validatorGroupSelector.LoadEligibleList(validatorsList)
+	if err != nil {
+		return nil, err
+	}
+
+	return validatorGroupSelector, nil
+}
+
+// createEpochStorer creates a pruning storer which writes each epoch in a new database when the storage pruning is
+// enabled, or a storage unit which writes all the epochs in the same database otherwise
+func createEpochStorer(
+	cfg config.StorageConfig,
+	pruningCfg config.StoragePruningConfig,
+	uniqueID string,
+	dbPathTemplate string,
+) (storage.Storer, error) {
+	if !pruningCfg.Enabled {
+		unit, err := storageUnit.NewStorageUnitFromConf(
+			getCacherFromConfig(cfg.Cache),
+			getDBFromConfig(cfg.DB, uniqueID),
+			getBloomFromConfig(cfg.Bloom))
+		if err != nil {
+			return nil, err
+		}
+
+		return unit, nil
+	}
+
+	cacher, err := storageUnit.NewCache(storageUnit.CacheType(cfg.Cache.Type), cfg.Cache.Size, cfg.Cache.Shards)
+	if err != nil {
+		return nil, err
+	}
+
+	// the pruning storer continues from the newest epoch found on disk, so it is created from epoch 0
+	pruningStorer, err := pruning.NewPruningStorer(
+		cacher,
+		storageUnit.NewPersisterFactory(storageUnit.DBType(cfg.DB.Type), cfg.DB.BatchDelaySeconds, cfg.DB.MaxBatchSize),
+		filepath.Join(dbPathTemplate, cfg.DB.FilePath),
+		pruningCfg.NumActivePersisters,
+		pruningCfg.FullArchive,
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pruningStorer, nil
+}
+
 func getCacherFromConfig(cfg config.CacheConfig) storageUnit.CacheConfig {
 	return storageUnit.CacheConfig{
 		Size:   cfg.Size,
@@ -1474,7 +1985,7 @@ func generateInMemoryAccountsAdapter(
 ) state.AccountsAdapter {
 
 	tr, _ := trie.NewTrie(createMemUnit(), marshalizer, hasher)
-	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marshalizer, accountFactory)
+	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marshalizer, accountFactory, nil)
 
 	return adb
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/appStatusPolling"
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/core/logger"
 	"github.com/ElrondNetwork/elrond-go/core/serviceContainer"
@@ -36,10 +37,12 @@ import (
 	"github.com/ElrondNetwork/elrond-go/node"
 	"github.com/ElrondNetwork/elrond-go/node/external"
 	"github.com/ElrondNetwork/elrond-go/ntp"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract/hooks"
 	"github.com/ElrondNetwork/elrond-go/sharding"
 	"github.com/ElrondNetwork/elrond-go/statusHandler"
+	"github.com/ElrondNetwork/elrond-go/storage/pruning"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/ElrondNetwork/elrond-vm/iele/elrond/node/endpoint"
 	"github.com/google/gops/agent"
@@ -457,6 +460,11 @@ func startNode(ctx *cli.Context, log *logger.Logger, version string) error {
 		defaultDBPath,
 		fmt.Sprintf("%s_%d", defaultEpochString, 0),
 		fmt.Sprintf("%s_%s", defaultShardString, shardId))
+	dbPathTemplate := filepath.Join(
+		workingDir,
+		defaultDBPath,
+		fmt.Sprintf("%s_%s", defaultEpochString, pruning.EpochPlaceholder),
+		fmt.Sprintf("%s_%s", defaultShardString, shardId))
 
 	storageCleanup := ctx.GlobalBool(storageCleanup.Name)
 	if storageCleanup {
@@ -533,8 +541,18 @@ func startNode(ctx *cli.Context, log *logger.Logger, version string) error {
 	coreComponents.StatusHandler.SetUInt64Value(core.MetricCountConsensus, 0)
 	coreComponents.StatusHandler.SetUInt64Value(core.MetricCountLeader, 0)
 	coreComponents.StatusHandler.SetUInt64Value(core.MetricCountAcceptedBlocks, 0)
+	coreComponents.StatusHandler.SetUInt64Value(core.MetricNumRejectedTxs, 0)
+	coreComponents.StatusHandler.SetUInt64Value(core.MetricNumDroppedStaleHeaders, 0)
+	coreComponents.StatusHandler.SetUInt64Value(core.MetricNumDroppedFutureHeaders, 0)
 
-	dataArgs := factory.NewDataComponentsFactoryArgs(generalConfig, shardCoordinator, coreComponents, uniqueDBFolder)
+	dataArgs := factory.NewDataComponentsFactoryArgs(
+		generalConfig,
+		shardCoordinator,
+		coreComponents,
+		stateComponents,
+		uniqueDBFolder,
+		dbPathTemplate,
+	)
 	dataComponents, err := factory.DataComponentsFactory(dataArgs)
 	if err != nil {
 		return err
@@ -585,15 +603,26 @@ func startNode(ctx *cli.Context, log *logger.Logger, version string) error {
 		if err != nil {
 			return err
 		}
+	}
 
-		err = setServiceContainer(shardCoordinator, tpsBenchmark)
-		if err != nil {
-			return err
-		}
+	eventsDispatcher, err := events.NewEventsDispatcher(generalConfig.EventsStream.SubscriberBufferSize)
+	if err != nil {
+		return err
+	}
+
+	err = setServiceContainer(shardCoordinator, tpsBenchmark, eventsDispatcher)
+	if err != nil {
+		return err
+	}
+
+	economicsData, err := economics.NewEconomicsData(&generalConfig.Economics)
+	if err != nil {
+		return err
 	}
 
-	processArgs := factory.NewProcessComponentsFactoryArgs(genesisConfig, nodesConfig, syncer, shardCoordinator,
-		dataComponents, coreComponents, cryptoComponents, stateComponents, networkComponents, coreServiceContainer)
+	processArgs := factory.NewProcessComponentsFactoryArgs(generalConfig, genesisConfig, nodesConfig, syncer, shardCoordinator,
+		dataComponents, coreComponents, cryptoComponents, stateComponents, networkComponents, coreServiceContainer,
+		eventsDispatcher, economicsData)
 	processComponents, err := factory.ProcessComponentsFactory(processArgs)
 	if err != nil {
 		return err
@@ -613,6 +642,7 @@ func startNode(ctx *cli.Context, log *logger.Logger, version string) error {
 		cryptoComponents,
 		processComponents,
 		networkComponents,
+		economicsData,
 		uint64(ctx.GlobalUint(bootstrapRoundIndex.Name)),
 		version,
 	)
@@ -625,7 +655,7 @@ func startNode(ctx *cli.Context, log *logger.Logger, version string) error {
 		return err
 	}
 
-	apiResolver, err := createApiResolver(vmAccountsDB)
+	apiResolver, err := createApiResolver(vmAccountsDB, stateComponents.AddressConverter)
 	if err != nil {
 		return err
 	}
@@ -660,6 +690,7 @@ func startNode(ctx *cli.Context, log *logger.Logger, version string) error {
 	ef.SetLogger(log)
 	ef.SetSyncer(syncer)
 	ef.SetTpsBenchmark(tpsBenchmark)
+	ef.SetEventsSubscriber(eventsDispatcher)
 	ef.SetConfig(efConfig)
 
 	wg := sync.WaitGroup{}
@@ -1004,6 +1035,7 @@ func createNode(
 	crypto *factory.Crypto,
 	process *factory.Process,
 	network *factory.Network,
+	economicsData *economics.EconomicsData,
 	bootstrapRoundIndex uint64,
 	version string,
 ) (*node.Node, error) {
@@ -1045,6 +1077,10 @@ func createNode(
 		node.WithTxStorageSize(config.TxStorage.Cache.Size),
 		node.WithBootstrapRoundIndex(bootstrapRoundIndex),
 		node.WithAppStatusHandler(core.StatusHandler),
+		node.WithTxFeeHandler(economicsData),
+		node.WithValidatorGroupSelector(process.ValidatorGroupSelector),
+		node.WithCommitJournal(process.CommitJournal),
+		node.WithChainID([]byte(config.GeneralSettings.NetworkID)),
 	)
 	if err != nil {
 		return nil, errors.New("error creating node: " + err.Error())
@@ -1063,6 +1099,12 @@ func createNode(
 		if err != nil {
 			return nil, errors.New("error creating node: " + err.Error())
 		}
+		if process.StateSyncer != nil {
+			err = nd.ApplyOptions(node.WithStateSyncer(process.StateSyncer))
+			if err != nil {
+				return nil, errors.New("error creating node: " + err.Error())
+			}
+		}
 		err = nd.CreateShardedStores()
 		if err != nil {
 			return nil, err
@@ -1112,19 +1154,29 @@ func initLogFileAndStatsMonitor(config *config.Config, pubKey crypto.PublicKey,
 	return nil
 }
 
-func setServiceContainer(shardCoordinator sharding.Coordinator, tpsBenchmark *statistics.TpsBenchmark) error {
+func setServiceContainer(
+	shardCoordinator sharding.Coordinator,
+	tpsBenchmark *statistics.TpsBenchmark,
+	eventsNotifier events.Notifier,
+) error {
+	opts := []serviceContainer.Option{serviceContainer.WithEventsNotifier(eventsNotifier)}
+	if dbIndexer != nil {
+		opts = append(opts, serviceContainer.WithIndexer(dbIndexer))
+	}
+
 	var err error
 	if shardCoordinator.SelfId() < shardCoordinator.NumberOfShards() {
-		coreServiceContainer, err = serviceContainer.NewServiceContainer(serviceContainer.WithIndexer(dbIndexer))
+		coreServiceContainer, err = serviceContainer.NewServiceContainer(opts...)
 		if err != nil {
 			return err
 		}
 		return nil
 	}
 	if shardCoordinator.SelfId() == sharding.MetachainShardId {
-		coreServiceContainer, err = serviceContainer.NewServiceContainer(
-			serviceContainer.WithIndexer(dbIndexer),
-			serviceContainer.WithTPSBenchmark(tpsBenchmark))
+		if dbIndexer != nil {
+			opts = append(opts, serviceContainer.WithTPSBenchmark(tpsBenchmark))
+		}
+		coreServiceContainer, err = serviceContainer.NewServiceContainer(opts...)
 		if err != nil {
 			return err
 		}
@@ -1158,7 +1210,10 @@ func startStatisticsMonitor(file *os.File, config config.ResourceStatsConfig, lo
 	return nil
 }
 
-func createApiResolver(vmAccountsDB vmcommon.BlockchainHook) (facade.ApiResolver, error) {
+func createApiResolver(
+	vmAccountsDB vmcommon.BlockchainHook,
+	addressConverter state.AddressConverter,
+) (facade.ApiResolver, error) {
 	//TODO replace this with a vm factory
 	cryptoHook := hooks.NewVMCryptoHook()
 	ieleVM := endpoint.NewElrondIeleVM(vmAccountsDB, cryptoHook, endpoint.ElrondTestnet)
@@ -1168,5 +1223,10 @@ func createApiResolver(vmAccountsDB vmcommon.BlockchainHook) (facade.ApiResolver
 		return nil, err
 	}
 
-	return external.NewNodeApiResolver(scDataGetter)
+	scDataGetterFactory, err := external.NewScDataGetterFactory(addressConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	return external.NewNodeApiResolver(scDataGetter, scDataGetterFactory)
 }
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go/hashing/sha256"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/urfave/cli"
+)
+
+var (
+	stateExportHelpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+	// configurationFile defines a flag for the path to the main toml configuration file of the node
+	configurationFile = cli.StringFlag{
+		Name:  "config",
+		Usage: "The main configuration file of the node, used to open the accounts trie storage",
+		Value: "./config/config.toml",
+	}
+	// dbPath defines a flag for the path to the storage folder of the node's shard
+	dbPath = cli.StringFlag{
+		Name:  "db-path",
+		Usage: "The storage folder of the node's shard, for example ./db/Epoch_0/Shard_0",
+	}
+	// rootHash defines a flag for the state root hash to be exported
+	rootHash = cli.StringFlag{
+		Name:  "root-hash",
+		Usage: "The hex encoded state root hash to be exported",
+	}
+	// outputFile defines a flag for the file where the state is exported
+	outputFile = cli.StringFlag{
+		Name:  "output",
+		Usage: "The JSON file where the state is exported",
+		Value: "./state.json",
+	}
+	// metachain defines a flag for exporting the state of a metachain node
+	metachain = cli.BoolFlag{
+		Name:  "metachain",
+		Usage: "Exports the state of a metachain node instead of a shard node",
+	}
+
+	errMissingDbPath   = errors.New("the db-path flag is required")
+	errMissingRootHash = errors.New("the root-hash flag is required")
+)
+
+// exportedAccount is the JSON representation of an exported account
+type exportedAccount struct {
+	Address  string            `json:"address"`
+	Nonce    uint64            `json:"nonce"`
+	Balance  string            `json:"balance,omitempty"`
+	CodeHash string            `json:"codeHash,omitempty"`
+	Code     string            `json:"code,omitempty"`
+	RootHash string            `json:"rootHash,omitempty"`
+	Storage  map[string]string `json:"storage,omitempty"`
+}
+
+func main() {
+	app := cli.NewApp()
+	cli.AppHelpTemplate = stateExportHelpTemplate
+	app.Name = "State export Tool"
+	app.Version = "v0.0.1"
+	app.Usage = "This binary exports all the accounts and their storage at a given state root hash to a JSON file. " +
+		"The node using the storage should be stopped"
+	app.Flags = []cli.Flag{configurationFile, dbPath, rootHash, outputFile, metachain}
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Elrond Team",
+			Email: "contact@elrond.com",
+		},
+	}
+
+	app.Action = func(c *cli.Context) error {
+		return exportState(c)
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func exportState(ctx *cli.Context) error {
+	if !ctx.IsSet(dbPath.Name) {
+		return errMissingDbPath
+	}
+	if !ctx.IsSet(rootHash.Name) {
+		return errMissingRootHash
+	}
+
+	root, err := hex.DecodeString(ctx.GlobalString(rootHash.Name))
+	if err != nil {
+		return err
+	}
+
+	generalConfig := &config.Config{}
+	err = core.LoadTomlFile(generalConfig, ctx.GlobalString(configurationFile.Name), logger.DefaultLogger())
+	if err != nil {
+		return err
+	}
+
+	adb, err := createAccountsDB(generalConfig, ctx.GlobalString(dbPath.Name), ctx.GlobalBool(metachain.Name))
+	if err != nil {
+		return err
+	}
+
+	err = adb.RecreateTrie(root)
+	if err != nil {
+		return errors.New("error recreating the state trie: " + err.Error())
+	}
+
+	file, err := os.Create(ctx.GlobalString(outputFile.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	numAccounts, err := writeState(bufio.NewWriter(file), adb, root)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("exported %d accounts to %s\n", numAccounts, ctx.GlobalString(outputFile.Name))
+	return nil
+}
+
+func createAccountsDB(cfg *config.Config, path string, isMetachain bool) (*state.AccountsDB, error) {
+	hasher, err := getHasherFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	marshalizer, err := getMarshalizerFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	accountsTrieStorage, err := storageUnit.NewStorageUnitFromConf(
+		storageUnit.CacheConfig{
+			Size:   cfg.AccountsTrieStorage.Cache.Size,
+			Type:   storageUnit.CacheType(cfg.AccountsTrieStorage.Cache.Type),
+			Shards: cfg.AccountsTrieStorage.Cache.Shards,
+		},
+		storageUnit.DBConfig{
+			FilePath:          filepath.Join(path, cfg.AccountsTrieStorage.DB.FilePath),
+			Type:              storageUnit.DBType(cfg.AccountsTrieStorage.DB.Type),
+			MaxBatchSize:      cfg.AccountsTrieStorage.DB.MaxBatchSize,
+			BatchDelaySeconds: cfg.AccountsTrieStorage.DB.BatchDelaySeconds,
+		},
+		storageUnit.BloomConfig{},
+	)
+	if err != nil {
+		return nil, errors.New("error opening accountsTrieStorage: " + err.Error())
+	}
+
+	merkleTrie, err := trie.NewTrie(accountsTrieStorage, marshalizer, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	accountFactory := factory.NewAccountCreator()
+	if isMetachain {
+		accountFactory = factory.NewMetaAccountCreator()
+	}
+
+	return state.NewAccountsDB(merkleTrie, hasher, marshalizer, accountFactory, nil)
+}
+
+// writeState streams the accounts to the writer, so that the whole state is never held in memory
+func writeState(writer *bufio.Writer, adb *state.AccountsDB, root []byte) (int, error) {
+	_, err := writer.WriteString(fmt.Sprintf("{\n\"rootHash\": \"%s\",\n\"accounts\": [", hex.EncodeToString(root)))
+	if err != nil {
+		return 0, err
+	}
+
+	numAccounts := 0
+	err = adb.WalkAccounts(func(accountHandler state.AccountHandler) error {
+		account, err := exportAccount(accountHandler)
+		if err != nil {
+			return err
+		}
+
+		buff, err := json.Marshal(account)
+		if err != nil {
+			return err
+		}
+
+		separator := ",\n"
+		if numAccounts == 0 {
+			separator = "\n"
+		}
+		numAccounts++
+
+		_, err = writer.WriteString(separator + string(buff))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = writer.WriteString("\n]\n}\n")
+	if err != nil {
+		return 0, err
+	}
+
+	return numAccounts, writer.Flush()
+}
+
+func exportAccount(accountHandler state.AccountHandler) (*exportedAccount, error) {
+	account := &exportedAccount{
+		Address:  hex.EncodeToString(accountHandler.AddressContainer().Bytes()),
+		Nonce:    accountHandler.GetNonce(),
+		CodeHash: hex.EncodeToString(accountHandler.GetCodeHash()),
+		Code:     hex.EncodeToString(accountHandler.GetCode()),
+		RootHash: hex.EncodeToString(accountHandler.GetRootHash()),
+	}
+
+	shardAccount, ok := accountHandler.(*state.Account)
+	if ok && shardAccount.Balance != nil {
+		account.Balance = shardAccount.Balance.String()
+	}
+
+	if accountHandler.DataTrie() == nil {
+		return account, nil
+	}
+
+	it, err := accountHandler.DataTrie().NewLeafIterator(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Storage = make(map[string]string)
+	for it.Next() {
+		account.Storage[hex.EncodeToString(it.Key())] = hex.EncodeToString(it.Value())
+	}
+
+	return account, it.Error()
+}
+
+func getHasherFromConfig(cfg *config.Config) (hashing.Hasher, error) {
+	switch cfg.Hasher.Type {
+	case "sha256":
+		return sha256.Sha256{}, nil
+	case "blake2b":
+		return blake2b.Blake2b{}, nil
+	}
+
+	return nil, errors.New("no hasher provided in config file")
+}
+
+func getMarshalizerFromConfig(cfg *config.Config) (marshal.Marshalizer, error) {
+	switch cfg.Marshalizer.Type {
+	case "json":
+		return marshal.JsonMarshalizer{}, nil
+	}
+
+	return nil, errors.New("no marshalizer provided in config file")
+}
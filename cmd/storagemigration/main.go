@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/hashing/sha256"
+	"github.com/ElrondNetwork/elrond-go/storage/migration"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/urfave/cli"
+)
+
+const (
+	migrationSuffix = "_migration"
+	backupSuffix    = "_backup"
+)
+
+var (
+	storageMigrationHelpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+	// configurationFile defines a flag for the path to the main toml configuration file of the node
+	configurationFile = cli.StringFlag{
+		Name:  "config",
+		Usage: "The main configuration file of the node, holding the storage units and their current database types",
+		Value: "./config/config.toml",
+	}
+	// dbPath defines a flag for the path to the storage folder of the node's shard
+	dbPath = cli.StringFlag{
+		Name:  "db-path",
+		Usage: "The storage folder of the node's shard, for example ./db/Epoch_0/Shard_0. The folders of the same shard in the other epochs are migrated too",
+	}
+	// targetType defines a flag for the database type the storage units are migrated to
+	targetType = cli.StringFlag{
+		Name:  "target-type",
+		Usage: "The database type the storage units are migrated to: LvlDB, LvlDBSerial, BadgerDB or BoltDB",
+	}
+	// outputConfigFile defines a flag for the configuration file written with the migrated database types
+	outputConfigFile = cli.StringFlag{
+		Name:  "output-config",
+		Usage: "If set, a copy of the configuration file using the target database type for the migrated units is written here",
+	}
+
+	errMissingDbPath     = errors.New("the db-path flag is required")
+	errInvalidTargetType = errors.New("the target-type flag should be one of LvlDB, LvlDBSerial, BadgerDB or BoltDB")
+	errLeftoverMigration = errors.New("a previous migration folder exists, it should be inspected and removed first")
+	errNothingToMigrate  = errors.New("no storage unit found to be migrated")
+	errExistingBackup    = errors.New("a backup of the unit already exists, it should be moved or removed first")
+	typeLineRegex        = regexp.MustCompile(`^(\s*Type\s*=\s*)"[^"]*"(.*)$`)
+	sectionLineRegex     = regexp.MustCompile(`^\s*\[([^\]]+)\]`)
+	epochDirRegex        = regexp.MustCompile(`^Epoch_\d+$`)
+	supportedTargetTypes = []storageUnit.DBType{storageUnit.LvlDB, storageUnit.LvlDbSerial, storageUnit.BadgerDB, storageUnit.BoltDB}
+	storageConfigType    = reflect.TypeOf(config.StorageConfig{})
+)
+
+// unitToMigrate is a storage unit directory found in one of the node's storage folders
+type unitToMigrate struct {
+	configName  string
+	storagePath string
+	dirName     string
+	config      config.StorageConfig
+}
+
+func (unit *unitToMigrate) path() string {
+	return filepath.Join(unit.storagePath, unit.dirName)
+}
+
+func (unit *unitToMigrate) migrationPath() string {
+	return filepath.Join(unit.storagePath+migrationSuffix, unit.dirName)
+}
+
+func (unit *unitToMigrate) backupPath() string {
+	return filepath.Join(unit.storagePath+backupSuffix, unit.dirName)
+}
+
+func main() {
+	app := cli.NewApp()
+	cli.AppHelpTemplate = storageMigrationHelpTemplate
+	app.Name = "Storage migration Tool"
+	app.Version = "v0.0.1"
+	app.Usage = "This binary copies the storage units of a node to another database type, verifies the copies and " +
+		"swaps them with the original units, which are kept in a backup folder. The node using the storage should be stopped"
+	app.Flags = []cli.Flag{configurationFile, dbPath, targetType, outputConfigFile}
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Elrond Team",
+			Email: "contact@elrond.com",
+		},
+	}
+
+	app.Action = func(c *cli.Context) error {
+		return migrateStorage(c)
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func migrateStorage(ctx *cli.Context) error {
+	if !ctx.IsSet(dbPath.Name) {
+		return errMissingDbPath
+	}
+	target, err := getTargetType(ctx.GlobalString(targetType.Name))
+	if err != nil {
+		return err
+	}
+
+	configPath := ctx.GlobalString(configurationFile.Name)
+	generalConfig := &config.Config{}
+	err = core.LoadTomlFile(generalConfig, configPath, logger.DefaultLogger())
+	if err != nil {
+		return err
+	}
+
+	storagePaths, err := findStoragePaths(filepath.Clean(ctx.GlobalString(dbPath.Name)))
+	if err != nil {
+		return err
+	}
+
+	units := make([]*unitToMigrate, 0)
+	for _, storagePath := range storagePaths {
+		_, err = os.Stat(storagePath + migrationSuffix)
+		if err == nil {
+			return fmt.Errorf("%s: %s", errLeftoverMigration.Error(), storagePath+migrationSuffix)
+		}
+
+		storageUnits, err := findUnitsToMigrate(generalConfig, storagePath, target)
+		if err != nil {
+			return err
+		}
+		units = append(units, storageUnits...)
+	}
+	if len(units) == 0 {
+		return errNothingToMigrate
+	}
+	for _, unit := range units {
+		_, err = os.Stat(unit.backupPath())
+		if err == nil {
+			return fmt.Errorf("%s: %s", errExistingBackup.Error(), unit.backupPath())
+		}
+	}
+
+	// all the units are copied and verified before any of them is swapped, so a failed migration leaves the
+	// original storage folders untouched
+	for _, unit := range units {
+		err = migrateUnit(unit, target)
+		if err != nil {
+			removeMigrationFolders(storagePaths)
+			return fmt.Errorf("error migrating %s: %s. The original units were not changed", unit.path(), err.Error())
+		}
+	}
+
+	for i, unit := range units {
+		err = os.MkdirAll(unit.storagePath+backupSuffix, os.ModePerm)
+		if err == nil {
+			err = migration.SwapDirectories(unit.path(), unit.migrationPath(), unit.backupPath())
+		}
+		if err != nil {
+			restoreSwappedUnits(units[:i])
+			return fmt.Errorf("error swapping %s: %s. The original units were restored", unit.path(), err.Error())
+		}
+	}
+	for _, storagePath := range storagePaths {
+		_ = os.Remove(storagePath + migrationSuffix)
+	}
+
+	fmt.Printf("migrated %d storage units to %s, the original units were moved to the storage folders suffixed "+
+		"with %s\n", len(units), target, backupSuffix)
+
+	if !ctx.GlobalIsSet(outputConfigFile.Name) {
+		fmt.Printf("the DB.Type of the migrated units should be set to %s in the node configuration\n", target)
+		return nil
+	}
+
+	err = writeMigratedConfig(configPath, ctx.GlobalString(outputConfigFile.Name), units, target)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("the configuration using the migrated units was written to %s\n", ctx.GlobalString(outputConfigFile.Name))
+	return nil
+}
+
+// restoreSwappedUnits moves the original units back from the backup folders, so a failed swap leaves the storage
+// folders as they were before the migration
+func restoreSwappedUnits(units []*unitToMigrate) {
+	for i := len(units) - 1; i >= 0; i-- {
+		err := migration.RestoreDirectories(units[i].path(), units[i].migrationPath(), units[i].backupPath())
+		if err != nil {
+			fmt.Printf("error restoring %s from %s: %s\n", units[i].path(), units[i].backupPath(), err.Error())
+		}
+	}
+}
+
+func removeMigrationFolders(storagePaths []string) {
+	for _, storagePath := range storagePaths {
+		_ = os.RemoveAll(storagePath + migrationSuffix)
+	}
+}
+
+// findStoragePaths returns the given storage folder together with the folders of the same shard in the other
+// epochs, as the units with storage pruning enabled keep the data of every epoch in a different Epoch_[E] folder.
+// A folder which is not placed in an epoch folder is returned alone
+func findStoragePaths(path string) ([]string, error) {
+	shardDirName := filepath.Base(path)
+	epochDir := filepath.Dir(path)
+	if !epochDirRegex.MatchString(filepath.Base(epochDir)) {
+		return []string{path}, nil
+	}
+
+	dbDir := filepath.Dir(epochDir)
+	dirs, err := ioutil.ReadDir(dbDir)
+	if err != nil {
+		return nil, err
+	}
+
+	storagePaths := make([]string, 0)
+	for _, dir := range dirs {
+		if !dir.IsDir() || !epochDirRegex.MatchString(dir.Name()) {
+			continue
+		}
+
+		storagePath := filepath.Join(dbDir, dir.Name(), shardDirName)
+		info, err := os.Stat(storagePath)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		storagePaths = append(storagePaths, storagePath)
+	}
+
+	return storagePaths, nil
+}
+
+func getTargetType(name string) (storageUnit.DBType, error) {
+	for _, dbType := range supportedTargetTypes {
+		if string(dbType) == name {
+			return dbType, nil
+		}
+	}
+
+	return "", errInvalidTargetType
+}
+
+// findUnitsToMigrate returns the directories of the storage units configured in the config file which are not
+// already of the target type. The sharded units are stored in directories suffixed with the shard id
+func findUnitsToMigrate(cfg *config.Config, storagePath string, target storageUnit.DBType) ([]*unitToMigrate, error) {
+	dirs, err := ioutil.ReadDir(storagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	units := make([]*unitToMigrate, 0)
+	cfgValue := reflect.ValueOf(*cfg)
+	for i := 0; i < cfgValue.NumField(); i++ {
+		if cfgValue.Field(i).Type() != storageConfigType {
+			continue
+		}
+
+		storageConfig := cfgValue.Field(i).Interface().(config.StorageConfig)
+		if storageConfig.DB.FilePath == "" || storageUnit.DBType(storageConfig.DB.Type) == target {
+			continue
+		}
+
+		dirRegex := regexp.MustCompile("^" + regexp.QuoteMeta(storageConfig.DB.FilePath) + `\d*$`)
+		for _, dir := range dirs {
+			if !dir.IsDir() || !dirRegex.MatchString(dir.Name()) {
+				continue
+			}
+
+			units = append(units, &unitToMigrate{
+				configName:  cfgValue.Type().Field(i).Name,
+				storagePath: storagePath,
+				dirName:     dir.Name(),
+				config:      storageConfig,
+			})
+		}
+	}
+
+	return units, nil
+}
+
+func migrateUnit(unit *unitToMigrate, target storageUnit.DBType) error {
+	err := os.MkdirAll(unit.storagePath+migrationSuffix, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	dbConfig := unit.config.DB
+	maxBatchSize := dbConfig.MaxBatchSize
+	if target == storageUnit.BoltDB {
+		// a BoltDB put waits until its batch is full or the batch delay passes, so the sequential copy writes
+		// every entry in its own transaction
+		maxBatchSize = 1
+	}
+
+	migrator, err := migration.NewUnitMigrator(
+		storageUnit.NewPersisterFactory(storageUnit.DBType(dbConfig.Type), dbConfig.BatchDelaySeconds, dbConfig.MaxBatchSize),
+		storageUnit.NewPersisterFactory(target, dbConfig.BatchDelaySeconds, maxBatchSize),
+		sha256.Sha256{},
+	)
+	if err != nil {
+		return err
+	}
+
+	// the migrated directory keeps the name of the original one, as BoltDB names its bucket after it
+	result, err := migrator.Migrate(unit.path(), unit.migrationPath())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: copied %d keys from %s, content hash %s\n",
+		unit.path(), result.NumKeys, dbConfig.Type, hex.EncodeToString(result.ContentHash))
+	return nil
+}
+
+// writeMigratedConfig copies the config file, replacing the database type in the DB section of every migrated
+// unit. The file is rewritten line by line so that its comments and layout are kept
+func writeMigratedConfig(configPath string, outputPath string, units []*unitToMigrate, target storageUnit.DBType) error {
+	migratedSections := make(map[string]bool)
+	for _, unit := range units {
+		migratedSections[unit.configName+".DB"] = true
+	}
+
+	input, err := os.Open(configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = input.Close()
+	}()
+
+	lines := make([]string, 0)
+	currentSection := ""
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		matches := sectionLineRegex.FindStringSubmatch(line)
+		if matches != nil {
+			currentSection = strings.TrimSpace(matches[1])
+		} else if migratedSections[currentSection] {
+			line = typeLineRegex.ReplaceAllString(line, fmt.Sprintf(`${1}"%s"${2}`, target))
+		}
+
+		lines = append(lines, line)
+	}
+	if scanner.Err() != nil {
+		return scanner.Err()
+	}
+
+	return ioutil.WriteFile(outputPath, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
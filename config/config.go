@@ -64,12 +64,19 @@ type Config struct {
 	UnsignedTransactionStorage StorageConfig
 	ShardHdrNonceHashStorage   StorageConfig
 	MetaHdrNonceHashStorage    StorageConfig
+	ReceiptsStorage            StorageConfig
+	RewardTxStorage            StorageConfig
+	CommitJournalStorage       StorageConfig
+	StoragePruning             StoragePruningConfig
+	CommitJournal              CommitJournalConfig
 
 	ShardDataStorage StorageConfig
 	MetaBlockStorage StorageConfig
 	PeerDataStorage  StorageConfig
 
 	AccountsTrieStorage StorageConfig
+	TriePruning         TriePruningConfig
+	StateSync           StateSyncConfig
 	BadBlocksCache      CacheConfig
 
 	TxBlockBodyDataPool         CacheConfig
@@ -80,11 +87,14 @@ type Config struct {
 	TxDataPool                  CacheConfig
 	UnsignedTransactionDataPool CacheConfig
 	MetaBlockBodyDataPool       CacheConfig
+	TrieNodesDataPool           CacheConfig
 
 	MiniBlockHeaderHashesDataPool CacheConfig
 	ShardHeadersDataPool          CacheConfig
 	MetaHeaderNoncesDataPool      CacheConfig
 
+	TxPool TxPoolConfig
+
 	Logger         LoggerConfig
 	Address        AddressConfig
 	Hasher         TypeConfig
@@ -96,6 +106,10 @@ type Config struct {
 	GeneralSettings GeneralSettingsConfig
 	Consensus       TypeConfig
 	Explorer        ExplorerConfig
+	EventsStream    EventsStreamConfig
+	Economics       EconomicsConfig
+
+	EpochStartConfig EpochStartConfig
 
 	NTPConfig NTPConfig
 }
@@ -156,6 +170,88 @@ type ExplorerConfig struct {
 	IndexerURL string
 }
 
+// TriePruningConfig will hold the settings for removing the state trie nodes which are no longer reachable
+type TriePruningConfig struct {
+	ArchiveMode    bool
+	NumRootsToKeep uint32
+}
+
+// StoragePruningConfig will hold the settings for keeping the block data only for the most recent epochs
+type StoragePruningConfig struct {
+	Enabled             bool
+	FullArchive         bool
+	NumActivePersisters uint32
+}
+
+// CommitJournalConfig will hold the settings of the journal used to roll back the partially persisted blocks
+type CommitJournalConfig struct {
+	NumEntriesToKeep uint32
+}
+
+// StateSyncConfig will hold the settings for syncing the state of the latest notarized block
+type StateSyncConfig struct {
+	Enabled         bool
+	RequestTimeInMs uint32
+}
+
+// EventsStreamConfig will hold the configuration for the events stream
+type EventsStreamConfig struct {
+	SubscriberBufferSize int
+}
+
+// FeeSettings will hold the transaction fee settings
+type FeeSettings struct {
+	MinGasPrice    uint64
+	MinGasLimit    uint64
+	GasPerDataByte uint64
+}
+
+// StakingSettings will hold the settings used when validators lock and release their stake
+type StakingSettings struct {
+	MinStakeValue      string
+	UnBondPeriod       uint64
+	SlashingPercentage float64
+}
+
+// RatingSettings will hold the settings used to compute the ratings of the validators
+type RatingSettings struct {
+	StartRating                int32
+	MinRating                  int32
+	MaxRating                  int32
+	ProposerIncreaseRatingStep int32
+	SignerIncreaseRatingStep   int32
+	ProposerDecreaseRatingStep int32
+	SlashingDecreaseRatingStep int32
+}
+
+// RewardsSettings will hold the settings used to compute the rewards minted for each shard block
+type RewardsSettings struct {
+	BlockReward          string
+	LeaderPercentage     float64
+	YearlyInflationRates []float64
+}
+
+// EconomicsConfig will hold the economics settings of the network
+type EconomicsConfig struct {
+	FeeSettings     FeeSettings
+	StakingSettings StakingSettings
+	RatingSettings  RatingSettings
+	RewardsSettings RewardsSettings
+}
+
+// EpochStartConfig will hold the settings used when a new epoch starts
+type EpochStartConfig struct {
+	RoundsPerEpoch         uint64
+	NodesToShufflePerShard uint32
+}
+
+// TxPoolConfig will hold the settings of the transaction pool
+type TxPoolConfig struct {
+	MaxTxsPerSender               uint32
+	ReplacementGasPricePercentage uint32
+	MaxTxDataLength               uint32
+}
+
 // ServersConfig will hold all the confidential settings for servers
 type ServersConfig struct {
 	ElasticSearch ElasticSearchConfig
@@ -0,0 +1,510 @@
+package epoch
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+var log = logger.DefaultLogger()
+
+// epochManager keeps track of the current epoch and of the validators lists of all shards. The metachain uses it
+// to create the epoch start data, while all nodes use it to switch their own shard eligible list when a new epoch
+// starts. The metachain also keeps the nodes which staked, in a waiting list, and the validators which unstaked,
+// until the next epoch start moves them in or out of the shards. The validators of the new lists get the ratings
+// provided by the rating reader when the epoch starts, so the group selection is weighted with them for a whole epoch.
+// The epoch and the lists are kept in memory, so they are restored from the last epoch start meta block each time
+// the node restarts or rolls back blocks
+type epochManager struct {
+	roundsPerEpoch         uint64
+	nodesToShufflePerShard uint32
+	minNodesPerShard       uint32
+	hasher                 hashing.Hasher
+	shardCoordinator       sharding.Coordinator
+	groupSelector          consensus.ValidatorGroupSelector
+	ratingReader           consensus.RatingReader
+	initialValidators      map[uint32][]consensus.Validator
+
+	mutEpoch   sync.RWMutex
+	epoch      uint32
+	validators map[uint32][]consensus.Validator
+	waiting    []consensus.Validator
+	leaving    map[string]struct{}
+
+	mutHandlers         sync.RWMutex
+	epochChangeHandlers []consensus.EpochChangeHandler
+}
+
+// NewEpochManager creates a new epoch manager which starts from epoch 0 with the given validators lists. The
+// unstaked validators leave a shard only as long as the shard keeps at least minNodesPerShard validators
+func NewEpochManager(
+	roundsPerEpoch uint64,
+	nodesToShufflePerShard uint32,
+	minNodesPerShard uint32,
+	hasher hashing.Hasher,
+	shardCoordinator sharding.Coordinator,
+	groupSelector consensus.ValidatorGroupSelector,
+	ratingReader consensus.RatingReader,
+	initialValidators map[uint32][]consensus.Validator,
+) (*epochManager, error) {
+	if roundsPerEpoch == 0 {
+		return nil, ErrInvalidRoundsPerEpoch
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+	if shardCoordinator == nil {
+		return nil, ErrNilShardCoordinator
+	}
+	if groupSelector == nil {
+		return nil, ErrNilValidatorGroupSelector
+	}
+	if ratingReader == nil {
+		return nil, ErrNilRatingReader
+	}
+	if initialValidators == nil {
+		return nil, ErrNilInitialValidators
+	}
+
+	for shardId := uint32(0); shardId < shardCoordinator.NumberOfShards(); shardId++ {
+		shardValidators := initialValidators[shardId]
+		if len(shardValidators) == 0 {
+			return nil, ErrMissingShardValidators
+		}
+		if int(nodesToShufflePerShard) > len(shardValidators) {
+			return nil, ErrInvalidNodesToShuffle
+		}
+	}
+
+	return &epochManager{
+		roundsPerEpoch:         roundsPerEpoch,
+		nodesToShufflePerShard: nodesToShufflePerShard,
+		minNodesPerShard:       minNodesPerShard,
+		hasher:                 hasher,
+		shardCoordinator:       shardCoordinator,
+		groupSelector:          groupSelector,
+		ratingReader:           ratingReader,
+		initialValidators:      initialValidators,
+		epoch:                  0,
+		validators:             initialValidators,
+		waiting:                make([]consensus.Validator, 0),
+		leaving:                make(map[string]struct{}),
+		epochChangeHandlers:    make([]consensus.EpochChangeHandler, 0),
+	}, nil
+}
+
+// Epoch returns the current epoch
+func (em *epochManager) Epoch() uint32 {
+	em.mutEpoch.RLock()
+	defer em.mutEpoch.RUnlock()
+
+	return em.epoch
+}
+
+// EpochForRound returns the epoch a round belongs to
+func (em *epochManager) EpochForRound(round uint64) uint32 {
+	return uint32(round / em.roundsPerEpoch)
+}
+
+// IsEpochStart returns true if the given round belongs to an epoch newer than the current one
+func (em *epochManager) IsEpochStart(round uint64) bool {
+	return em.EpochForRound(round) > em.Epoch()
+}
+
+// CreateEpochStartData removes the unstaked validators, shuffles the remaining validators lists using the given
+// randomness and adds the waiting nodes. It returns the new validators lists of all shards, ordered by shard id
+func (em *epochManager) CreateEpochStartData(randomness []byte) ([]block.EpochStartShardData, error) {
+	if randomness == nil {
+		return nil, ErrNilRandomness
+	}
+
+	em.mutEpoch.RLock()
+	remainingValidators := em.removeLeavingValidators()
+	shuffledValidators := em.shuffleValidators(remainingValidators, randomness)
+	em.addWaitingValidators(shuffledValidators)
+	em.mutEpoch.RUnlock()
+
+	epochStart := make([]block.EpochStartShardData, 0, len(shuffledValidators))
+	for shardId := uint32(0); shardId < em.shardCoordinator.NumberOfShards(); shardId++ {
+		shardValidators := shuffledValidators[shardId]
+		shardData := block.EpochStartShardData{
+			ShardId:    shardId,
+			PublicKeys: make([][]byte, len(shardValidators)),
+			Addresses:  make([][]byte, len(shardValidators)),
+			Stakes:     make([][]byte, len(shardValidators)),
+		}
+
+		for i, v := range shardValidators {
+			shardData.PublicKeys[i] = v.PubKey()
+			shardData.Addresses[i] = v.Address()
+			shardData.Stakes[i] = make([]byte, 0)
+			if v.Stake() != nil {
+				shardData.Stakes[i] = v.Stake().Bytes()
+			}
+		}
+
+		epochStart = append(epochStart, shardData)
+	}
+
+	return epochStart, nil
+}
+
+// SetEpochStart sets the new epoch and the validators lists from an epoch start meta block. If the current node is
+// a shard node, the eligible list of its shard is switched to the new one. The waiting nodes which became validators
+// and the leaving validators which were removed are not tracked anymore
+func (em *epochManager) SetEpochStart(epoch uint32, epochStart []block.EpochStartShardData) error {
+	newValidators, err := em.createValidatorsFromEpochStart(epochStart)
+	if err != nil {
+		return err
+	}
+
+	selfId := em.shardCoordinator.SelfId()
+	if selfId < em.shardCoordinator.NumberOfShards() {
+		err = em.groupSelector.LoadEligibleList(newValidators[selfId])
+		if err != nil {
+			return err
+		}
+	}
+
+	em.mutEpoch.Lock()
+	em.epoch = epoch
+	em.validators = newValidators
+	em.pruneWaitingAndLeaving()
+	em.mutEpoch.Unlock()
+
+	em.notifyEpochChangeHandlers(epoch)
+
+	return nil
+}
+
+// RestoreEpochStart sets the epoch and the validators lists from the epoch start meta block of the given epoch and
+// rebuilds the waiting list and the leaving validators from the peer changes committed since that block. Epoch 0 has
+// no epoch start data, so the initial validators lists are restored instead
+func (em *epochManager) RestoreEpochStart(
+	epoch uint32,
+	epochStart []block.EpochStartShardData,
+	peerInfo []block.PeerData,
+) error {
+	newValidators := em.initialValidators
+	if epoch > 0 || len(epochStart) > 0 {
+		var err error
+		newValidators, err = em.createValidatorsFromEpochStart(epochStart)
+		if err != nil {
+			return err
+		}
+	}
+
+	registered, err := createRegisteredValidators(peerInfo)
+	if err != nil {
+		return err
+	}
+
+	selfId := em.shardCoordinator.SelfId()
+	if selfId < em.shardCoordinator.NumberOfShards() {
+		err = em.groupSelector.LoadEligibleList(newValidators[selfId])
+		if err != nil {
+			return err
+		}
+	}
+
+	em.mutEpoch.Lock()
+	em.epoch = epoch
+	em.validators = newValidators
+	em.waiting = make([]consensus.Validator, 0)
+	em.leaving = make(map[string]struct{})
+	em.applyPeerInfo(peerInfo, registered)
+	em.mutEpoch.Unlock()
+
+	em.notifyEpochChangeHandlers(epoch)
+
+	return nil
+}
+
+// RegisterEpochChangeHandler adds a handler which is notified each time a new epoch starts
+func (em *epochManager) RegisterEpochChangeHandler(handler consensus.EpochChangeHandler) {
+	if handler == nil {
+		return
+	}
+
+	em.mutHandlers.Lock()
+	em.epochChangeHandlers = append(em.epochChangeHandlers, handler)
+	em.mutHandlers.Unlock()
+}
+
+// notifyEpochChangeHandlers only logs the errors of the handlers, as the new epoch has already been set
+func (em *epochManager) notifyEpochChangeHandlers(epoch uint32) {
+	em.mutHandlers.RLock()
+	defer em.mutHandlers.RUnlock()
+
+	for _, handler := range em.epochChangeHandlers {
+		log.LogIfError(handler.ChangeEpoch(epoch))
+	}
+}
+
+// ProcessPeerInfo updates the waiting list and the leaving validators with the peer changes of a committed meta
+// block. The changes take effect when the next epoch starts
+func (em *epochManager) ProcessPeerInfo(peerInfo []block.PeerData) error {
+	registered, err := createRegisteredValidators(peerInfo)
+	if err != nil {
+		return err
+	}
+
+	em.mutEpoch.Lock()
+	em.applyPeerInfo(peerInfo, registered)
+	em.mutEpoch.Unlock()
+
+	return nil
+}
+
+// createRegisteredValidators creates the validators of the registrations, at the same index as their peer data
+func createRegisteredValidators(peerInfo []block.PeerData) ([]consensus.Validator, error) {
+	registered := make([]consensus.Validator, len(peerInfo))
+	for i, peerData := range peerInfo {
+		if peerData.Action != block.PeerRegistrantion {
+			continue
+		}
+
+		v, err := validators.NewValidator(peerData.Value, 0, peerData.PublicKey, peerData.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		registered[i] = v
+	}
+
+	return registered, nil
+}
+
+func (em *epochManager) applyPeerInfo(peerInfo []block.PeerData, registered []consensus.Validator) {
+	for i, peerData := range peerInfo {
+		switch peerData.Action {
+		case block.PeerRegistrantion:
+			if em.isValidator(peerData.PublicKey) || containsValidator(em.waiting, registered[i]) {
+				continue
+			}
+			em.waiting = append(em.waiting, registered[i])
+		case block.PeerDeregistration:
+			if em.removeFromWaiting(peerData.PublicKey) {
+				continue
+			}
+			if em.isValidator(peerData.PublicKey) {
+				em.leaving[string(peerData.PublicKey)] = struct{}{}
+			}
+		}
+	}
+}
+
+func (em *epochManager) isValidator(pubKey []byte) bool {
+	for _, shardValidators := range em.validators {
+		for _, v := range shardValidators {
+			if bytes.Equal(v.PubKey(), pubKey) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (em *epochManager) removeFromWaiting(pubKey []byte) bool {
+	for i, v := range em.waiting {
+		if bytes.Equal(v.PubKey(), pubKey) {
+			em.waiting = append(em.waiting[:i], em.waiting[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (em *epochManager) pruneWaitingAndLeaving() {
+	waiting := make([]consensus.Validator, 0, len(em.waiting))
+	for _, v := range em.waiting {
+		if !em.isValidator(v.PubKey()) {
+			waiting = append(waiting, v)
+		}
+	}
+	em.waiting = waiting
+
+	for pubKey := range em.leaving {
+		if !em.isValidator([]byte(pubKey)) {
+			delete(em.leaving, pubKey)
+		}
+	}
+}
+
+func (em *epochManager) createValidatorsFromEpochStart(
+	epochStart []block.EpochStartShardData,
+) (map[uint32][]consensus.Validator, error) {
+	if len(epochStart) != int(em.shardCoordinator.NumberOfShards()) {
+		return nil, ErrInvalidEpochStartData
+	}
+
+	newValidators := make(map[uint32][]consensus.Validator, len(epochStart))
+	for _, shardData := range epochStart {
+		if shardData.ShardId >= em.shardCoordinator.NumberOfShards() {
+			return nil, ErrInvalidEpochStartData
+		}
+		if len(shardData.PublicKeys) == 0 {
+			return nil, ErrMissingShardValidators
+		}
+		if len(shardData.PublicKeys) != len(shardData.Addresses) {
+			return nil, ErrInvalidEpochStartData
+		}
+		hasStakes := len(shardData.Stakes) > 0
+		if hasStakes && len(shardData.PublicKeys) != len(shardData.Stakes) {
+			return nil, ErrInvalidEpochStartData
+		}
+
+		shardValidators := make([]consensus.Validator, 0, len(shardData.PublicKeys))
+		for i := 0; i < len(shardData.PublicKeys); i++ {
+			stake := big.NewInt(0)
+			if hasStakes {
+				stake.SetBytes(shardData.Stakes[i])
+			}
+
+			rating := em.ratingReader.GetRating(string(shardData.PublicKeys[i]))
+			v, err := validators.NewValidator(stake, rating, shardData.PublicKeys[i], shardData.Addresses[i])
+			if err != nil {
+				return nil, err
+			}
+
+			shardValidators = append(shardValidators, v)
+		}
+
+		newValidators[shardData.ShardId] = shardValidators
+	}
+
+	return newValidators, nil
+}
+
+// removeLeavingValidators returns the current validators lists without the unstaked validators. A shard keeps at
+// least minNodesPerShard validators, and never less than the number of validators shuffled, so the validators
+// which can not leave yet remain in the shard until a following epoch
+func (em *epochManager) removeLeavingValidators() map[uint32][]consensus.Validator {
+	nbShards := em.shardCoordinator.NumberOfShards()
+	remaining := make(map[uint32][]consensus.Validator, nbShards)
+
+	minShardSize := em.minNodesPerShard
+	if minShardSize < em.nodesToShufflePerShard {
+		minShardSize = em.nodesToShufflePerShard
+	}
+
+	for shardId := uint32(0); shardId < nbShards; shardId++ {
+		shardValidators := em.validators[shardId]
+		maxRemoved := len(shardValidators) - int(minShardSize)
+
+		shardRemaining := make([]consensus.Validator, 0, len(shardValidators))
+		for i, v := range shardValidators {
+			_, isLeaving := em.leaving[string(v.PubKey())]
+			removed := i - len(shardRemaining)
+			if isLeaving && removed < maxRemoved {
+				continue
+			}
+
+			shardRemaining = append(shardRemaining, v)
+		}
+
+		remaining[shardId] = shardRemaining
+	}
+
+	return remaining
+}
+
+// addWaitingValidators adds the waiting nodes, in the order they staked, each one to the shard which has the
+// fewest validators at that moment
+func (em *epochManager) addWaitingValidators(shardsValidators map[uint32][]consensus.Validator) {
+	nbShards := em.shardCoordinator.NumberOfShards()
+
+	for _, v := range em.waiting {
+		selectedShard := uint32(0)
+		for shardId := uint32(1); shardId < nbShards; shardId++ {
+			if len(shardsValidators[shardId]) < len(shardsValidators[selectedShard]) {
+				selectedShard = shardId
+			}
+		}
+
+		shardsValidators[selectedShard] = append(shardsValidators[selectedShard], v)
+	}
+}
+
+// shuffleValidators moves nodesToShufflePerShard validators out of each shard and redistributes them evenly
+// between shards. The leaving validators are the ones with the lowest hash of randomness and public key, so that
+// all nodes compute the same result for the same randomness. The validators that remain keep their order
+func (em *epochManager) shuffleValidators(
+	validatorsLists map[uint32][]consensus.Validator,
+	randomness []byte,
+) map[uint32][]consensus.Validator {
+	nbShards := em.shardCoordinator.NumberOfShards()
+	shuffled := make(map[uint32][]consensus.Validator, nbShards)
+	leaving := make([]consensus.Validator, 0, em.nodesToShufflePerShard*nbShards)
+
+	for shardId := uint32(0); shardId < nbShards; shardId++ {
+		shardValidators := validatorsLists[shardId]
+		sortedValidators := em.sortByRandomness(shardValidators, randomness)
+		shardLeaving := sortedValidators[:em.nodesToShufflePerShard]
+
+		remaining := make([]consensus.Validator, 0, len(shardValidators))
+		for _, v := range shardValidators {
+			if !containsValidator(shardLeaving, v) {
+				remaining = append(remaining, v)
+			}
+		}
+
+		shuffled[shardId] = remaining
+		leaving = append(leaving, shardLeaving...)
+	}
+
+	leaving = em.sortByRandomness(leaving, randomness)
+	for shardId := uint32(0); shardId < nbShards; shardId++ {
+		start := shardId * em.nodesToShufflePerShard
+		end := start + em.nodesToShufflePerShard
+		shuffled[shardId] = append(shuffled[shardId], leaving[start:end]...)
+	}
+
+	return shuffled
+}
+
+func (em *epochManager) sortByRandomness(list []consensus.Validator, randomness []byte) []consensus.Validator {
+	type validatorWithHash struct {
+		validator consensus.Validator
+		hash      []byte
+	}
+
+	withHashes := make([]validatorWithHash, len(list))
+	for i, v := range list {
+		withHashes[i] = validatorWithHash{
+			validator: v,
+			hash:      em.hasher.Compute(string(randomness) + string(v.PubKey())),
+		}
+	}
+
+	sort.Slice(withHashes, func(i, j int) bool {
+		return bytes.Compare(withHashes[i].hash, withHashes[j].hash) < 0
+	})
+
+	sorted := make([]consensus.Validator, len(withHashes))
+	for i := range withHashes {
+		sorted[i] = withHashes[i].validator
+	}
+
+	return sorted
+}
+
+func containsValidator(list []consensus.Validator, validator consensus.Validator) bool {
+	for _, v := range list {
+		if bytes.Equal(v.PubKey(), validator.PubKey()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,598 @@
+package epoch_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/consensus/epoch"
+	"github.com/ElrondNetwork/elrond-go/consensus/mock"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/stretchr/testify/assert"
+)
+
+func createValidators(nbShards uint32, nbValidatorsPerShard int) map[uint32][]consensus.Validator {
+	validatorsMap := make(map[uint32][]consensus.Validator)
+	for shardId := uint32(0); shardId < nbShards; shardId++ {
+		for i := 0; i < nbValidatorsPerShard; i++ {
+			pubKey := []byte(fmt.Sprintf("pk_%d_%d", shardId, i))
+			address := []byte(fmt.Sprintf("address_%d_%d", shardId, i))
+			v, _ := validators.NewValidator(big.NewInt(0), 0, pubKey, address)
+			validatorsMap[shardId] = append(validatorsMap[shardId], v)
+		}
+	}
+
+	return validatorsMap
+}
+
+func TestNewEpochManager_InvalidRoundsPerEpochShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, err := epoch.NewEpochManager(0, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrInvalidRoundsPerEpoch, err)
+}
+
+func TestNewEpochManager_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, err := epoch.NewEpochManager(10, 1, 1, nil, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrNilHasher, err)
+}
+
+func TestNewEpochManager_NilShardCoordinatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	em, err := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, nil, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrNilShardCoordinator, err)
+}
+
+func TestNewEpochManager_NilGroupSelectorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, err := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, nil, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrNilValidatorGroupSelector, err)
+}
+
+func TestNewEpochManager_NilRatingReaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, err := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, nil, createValidators(2, 3))
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrNilRatingReader, err)
+}
+
+func TestNewEpochManager_NilInitialValidatorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, err := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, nil)
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrNilInitialValidators, err)
+}
+
+func TestNewEpochManager_MissingShardValidatorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(3, 0)
+	em, err := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrMissingShardValidators, err)
+}
+
+func TestNewEpochManager_TooManyNodesToShuffleShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, err := epoch.NewEpochManager(10, 4, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Nil(t, em)
+	assert.Equal(t, epoch.ErrInvalidNodesToShuffle, err)
+}
+
+func TestNewEpochManager_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, err := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, em)
+	assert.Equal(t, uint32(0), em.Epoch())
+}
+
+func TestEpochManager_EpochForRound(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	assert.Equal(t, uint32(0), em.EpochForRound(0))
+	assert.Equal(t, uint32(0), em.EpochForRound(9))
+	assert.Equal(t, uint32(1), em.EpochForRound(10))
+	assert.Equal(t, uint32(2), em.EpochForRound(25))
+	assert.False(t, em.IsEpochStart(9))
+	assert.True(t, em.IsEpochStart(10))
+}
+
+func TestEpochManager_CreateEpochStartDataNilRandomnessShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	epochStart, err := em.CreateEpochStartData(nil)
+
+	assert.Nil(t, epochStart)
+	assert.Equal(t, epoch.ErrNilRandomness, err)
+}
+
+func TestEpochManager_CreateEpochStartDataShouldShufflePartially(t *testing.T) {
+	t.Parallel()
+
+	nbShards := uint32(3)
+	nbValidators := 5
+	nodesToShuffle := 2
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(nbShards, 0)
+	initialValidators := createValidators(nbShards, nbValidators)
+	em, _ := epoch.NewEpochManager(10, uint32(nodesToShuffle), 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, initialValidators)
+
+	epochStart, err := em.CreateEpochStartData([]byte("randomness"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int(nbShards), len(epochStart))
+
+	allPubKeys := make(map[string]bool)
+	for shardId, shardData := range epochStart {
+		assert.Equal(t, uint32(shardId), shardData.ShardId)
+		assert.Equal(t, nbValidators, len(shardData.PublicKeys))
+		assert.Equal(t, nbValidators, len(shardData.Addresses))
+
+		for i, pk := range shardData.PublicKeys {
+			allPubKeys[string(pk)] = true
+
+			isFromSameShard := false
+			for _, v := range initialValidators[uint32(shardId)] {
+				if string(v.PubKey()) == string(pk) {
+					isFromSameShard = true
+					assert.Equal(t, v.Address(), shardData.Addresses[i])
+				}
+			}
+
+			if i < nbValidators-nodesToShuffle {
+				assert.True(t, isFromSameShard)
+			}
+		}
+	}
+	assert.Equal(t, int(nbShards)*nbValidators, len(allPubKeys))
+}
+
+func TestEpochManager_CreateEpochStartDataShouldBeDeterministic(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em1, _ := epoch.NewEpochManager(10, 2, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 4))
+	em2, _ := epoch.NewEpochManager(10, 2, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 4))
+
+	epochStart1, _ := em1.CreateEpochStartData([]byte("randomness"))
+	epochStart2, _ := em2.CreateEpochStartData([]byte("randomness"))
+
+	assert.Equal(t, epochStart1, epochStart2)
+}
+
+func TestEpochManager_SetEpochStartInvalidDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	epochStart := []block.EpochStartShardData{
+		{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}, Addresses: [][]byte{}},
+		{ShardId: 1, PublicKeys: [][]byte{[]byte("pk")}, Addresses: [][]byte{[]byte("address")}},
+	}
+	err := em.SetEpochStart(1, epochStart)
+
+	assert.Equal(t, epoch.ErrInvalidEpochStartData, err)
+	assert.Equal(t, uint32(0), em.Epoch())
+}
+
+func TestEpochManager_SetEpochStartMissingShardShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	epochStart := []block.EpochStartShardData{
+		{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}, Addresses: [][]byte{[]byte("address")}},
+	}
+	err := em.SetEpochStart(1, epochStart)
+
+	assert.Equal(t, epoch.ErrInvalidEpochStartData, err)
+}
+
+func TestEpochManager_SetEpochStartShouldSwitchSelfShardEligibleList(t *testing.T) {
+	t.Parallel()
+
+	var loadedList []consensus.Validator
+	selector := mock.ValidatorGroupSelectorMock{
+		LoadEligibleListCalled: func(eligibleList []consensus.Validator) error {
+			loadedList = eligibleList
+			return nil
+		},
+	}
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 1)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, selector, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	err := em.SetEpochStart(1, epochStart)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), em.Epoch())
+	assert.Equal(t, len(epochStart[1].PublicKeys), len(loadedList))
+	for i, v := range loadedList {
+		assert.Equal(t, epochStart[1].PublicKeys[i], v.PubKey())
+		assert.Equal(t, epochStart[1].Addresses[i], v.Address())
+	}
+}
+
+func TestEpochManager_SetEpochStartShouldNotifyTheEpochChangeHandlers(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	notifiedEpochs := make([]uint32, 0)
+	handler := &mock.EpochChangeHandlerStub{
+		ChangeEpochCalled: func(epoch uint32) error {
+			notifiedEpochs = append(notifiedEpochs, epoch)
+			return errors.New("handler error")
+		},
+	}
+	em.RegisterEpochChangeHandler(handler)
+	em.RegisterEpochChangeHandler(handler)
+	em.RegisterEpochChangeHandler(nil)
+
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	err := em.SetEpochStart(1, epochStart)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{1, 1}, notifiedEpochs)
+}
+
+func TestEpochManager_SetEpochStartShouldSetRatingsAndStakes(t *testing.T) {
+	t.Parallel()
+
+	var loadedList []consensus.Validator
+	selector := mock.ValidatorGroupSelectorMock{
+		LoadEligibleListCalled: func(eligibleList []consensus.Validator) error {
+			loadedList = eligibleList
+			return nil
+		},
+	}
+	ratingReader := &mock.RatingReaderStub{
+		GetRatingCalled: func(pubKey string) int32 {
+			return int32(len(pubKey))
+		},
+	}
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(1, 0)
+	em, _ := epoch.NewEpochManager(10, 0, 1, mock.HasherMock{}, shardCoordinator, selector, ratingReader, createValidators(1, 2))
+
+	epochStart := []block.EpochStartShardData{
+		{
+			ShardId:    0,
+			PublicKeys: [][]byte{[]byte("pk"), []byte("long_pk")},
+			Addresses:  [][]byte{[]byte("address1"), []byte("address2")},
+			Stakes:     [][]byte{big.NewInt(1000).Bytes(), big.NewInt(0).Bytes()},
+		},
+	}
+	err := em.SetEpochStart(1, epochStart)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(loadedList))
+	assert.Equal(t, int32(2), loadedList[0].Rating())
+	assert.Equal(t, big.NewInt(1000), loadedList[0].Stake())
+	assert.Equal(t, int32(7), loadedList[1].Rating())
+	assert.Equal(t, uint64(0), loadedList[1].Stake().Uint64())
+}
+
+func TestEpochManager_SetEpochStartStakesNotMatchingPublicKeysShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(1, 0)
+	em, _ := epoch.NewEpochManager(10, 0, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(1, 2))
+
+	epochStart := []block.EpochStartShardData{
+		{
+			ShardId:    0,
+			PublicKeys: [][]byte{[]byte("pk1"), []byte("pk2")},
+			Addresses:  [][]byte{[]byte("address1"), []byte("address2")},
+			Stakes:     [][]byte{big.NewInt(1000).Bytes()},
+		},
+	}
+	err := em.SetEpochStart(1, epochStart)
+
+	assert.Equal(t, epoch.ErrInvalidEpochStartData, err)
+	assert.Equal(t, uint32(0), em.Epoch())
+}
+
+func TestEpochManager_SetEpochStartOnMetachainShouldNotSwitchEligibleList(t *testing.T) {
+	t.Parallel()
+
+	loadCalled := false
+	selector := mock.ValidatorGroupSelectorMock{
+		LoadEligibleListCalled: func(eligibleList []consensus.Validator) error {
+			loadCalled = true
+			return nil
+		},
+	}
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, selector, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	err := em.SetEpochStart(1, epochStart)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), em.Epoch())
+	assert.False(t, loadCalled)
+}
+
+func createPeerData(pubKey string, action block.PeerAction) block.PeerData {
+	return block.PeerData{
+		PublicKey: []byte(pubKey),
+		Action:    action,
+		Value:     big.NewInt(1000),
+		Address:   []byte("address_" + pubKey),
+	}
+}
+
+func getAllPubKeys(epochStart []block.EpochStartShardData) map[string]uint32 {
+	pubKeys := make(map[string]uint32)
+	for _, shardData := range epochStart {
+		for _, pk := range shardData.PublicKeys {
+			pubKeys[string(pk)] = shardData.ShardId
+		}
+	}
+
+	return pubKeys
+}
+
+func TestEpochManager_ProcessPeerInfoInvalidPeerDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	peerData := createPeerData("new_pk", block.PeerRegistrantion)
+	peerData.Value = nil
+	err := em.ProcessPeerInfo([]block.PeerData{peerData})
+
+	assert.Equal(t, validators.ErrNilStake, err)
+}
+
+func TestEpochManager_ProcessPeerInfoRegistrationShouldAddValidatorsAtEpochStart(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	err := em.ProcessPeerInfo([]block.PeerData{
+		createPeerData("new_pk_1", block.PeerRegistrantion),
+		createPeerData("new_pk_2", block.PeerRegistrantion),
+		createPeerData("pk_0_0", block.PeerRegistrantion),
+	})
+	assert.Nil(t, err)
+
+	epochStart, err := em.CreateEpochStartData([]byte("randomness"))
+	assert.Nil(t, err)
+
+	pubKeys := getAllPubKeys(epochStart)
+	assert.Equal(t, 8, len(pubKeys))
+	assert.Equal(t, 4, len(epochStart[0].PublicKeys))
+	assert.Equal(t, 4, len(epochStart[1].PublicKeys))
+	assert.NotEqual(t, pubKeys["new_pk_1"], pubKeys["new_pk_2"])
+	for _, shardData := range epochStart {
+		for i, pk := range shardData.PublicKeys {
+			if string(pk) == "new_pk_1" {
+				assert.Equal(t, []byte("address_new_pk_1"), shardData.Addresses[i])
+			}
+		}
+	}
+}
+
+func TestEpochManager_ProcessPeerInfoDeregistrationShouldRemoveValidatorAtEpochStart(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	err := em.ProcessPeerInfo([]block.PeerData{createPeerData("pk_0_0", block.PeerDeregistration)})
+	assert.Nil(t, err)
+
+	epochStart, err := em.CreateEpochStartData([]byte("randomness"))
+	assert.Nil(t, err)
+
+	pubKeys := getAllPubKeys(epochStart)
+	assert.Equal(t, 5, len(pubKeys))
+	_, found := pubKeys["pk_0_0"]
+	assert.False(t, found)
+}
+
+func TestEpochManager_ProcessPeerInfoDeregistrationShouldKeepMinNodesPerShard(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 2, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	err := em.ProcessPeerInfo([]block.PeerData{
+		createPeerData("pk_0_0", block.PeerDeregistration),
+		createPeerData("pk_0_1", block.PeerDeregistration),
+	})
+	assert.Nil(t, err)
+
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	pubKeys := getAllPubKeys(epochStart)
+	_, found0 := pubKeys["pk_0_0"]
+	_, found1 := pubKeys["pk_0_1"]
+
+	assert.Equal(t, 5, len(pubKeys))
+	assert.False(t, found0)
+	assert.True(t, found1)
+}
+
+func TestEpochManager_ProcessPeerInfoDeregistrationOfWaitingNodeShouldRemoveIt(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	_ = em.ProcessPeerInfo([]block.PeerData{createPeerData("new_pk", block.PeerRegistrantion)})
+	_ = em.ProcessPeerInfo([]block.PeerData{createPeerData("new_pk", block.PeerDeregistration)})
+
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	pubKeys := getAllPubKeys(epochStart)
+	_, found := pubKeys["new_pk"]
+
+	assert.Equal(t, 6, len(pubKeys))
+	assert.False(t, found)
+}
+
+func TestEpochManager_SetEpochStartShouldStopTrackingAppliedPeerChanges(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	_ = em.ProcessPeerInfo([]block.PeerData{
+		createPeerData("new_pk", block.PeerRegistrantion),
+		createPeerData("pk_1_2", block.PeerDeregistration),
+	})
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	err := em.SetEpochStart(1, epochStart)
+	assert.Nil(t, err)
+
+	epochStart, _ = em.CreateEpochStartData([]byte("other randomness"))
+	pubKeys := getAllPubKeys(epochStart)
+	_, foundNew := pubKeys["new_pk"]
+	_, foundLeaving := pubKeys["pk_1_2"]
+
+	assert.Equal(t, 6, len(pubKeys))
+	assert.True(t, foundNew)
+	assert.False(t, foundLeaving)
+}
+
+func TestEpochManager_CreateEpochStartDataShouldKeepStakes(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	_ = em.ProcessPeerInfo([]block.PeerData{createPeerData("new_pk", block.PeerRegistrantion)})
+	epochStart, err := em.CreateEpochStartData([]byte("randomness"))
+	assert.Nil(t, err)
+
+	found := false
+	for _, shardData := range epochStart {
+		assert.Equal(t, len(shardData.PublicKeys), len(shardData.Stakes))
+		for i, pk := range shardData.PublicKeys {
+			if string(pk) == "new_pk" {
+				found = true
+				assert.Equal(t, big.NewInt(1000).Bytes(), shardData.Stakes[i])
+				continue
+			}
+			assert.Equal(t, 0, len(shardData.Stakes[i]))
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestEpochManager_RestoreEpochStartShouldRestoreTheEpochAndThePeerChanges(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, sharding.MetachainShardId)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	_ = em.ProcessPeerInfo([]block.PeerData{createPeerData("new_pk_1", block.PeerRegistrantion)})
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	_ = em.SetEpochStart(1, epochStart)
+	peerInfo := []block.PeerData{
+		createPeerData("new_pk_2", block.PeerRegistrantion),
+		createPeerData("pk_1_0", block.PeerDeregistration),
+	}
+	_ = em.ProcessPeerInfo(peerInfo)
+
+	restoredEm, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+	notifiedEpochs := make([]uint32, 0)
+	restoredEm.RegisterEpochChangeHandler(&mock.EpochChangeHandlerStub{
+		ChangeEpochCalled: func(epoch uint32) error {
+			notifiedEpochs = append(notifiedEpochs, epoch)
+			return nil
+		},
+	})
+	err := restoredEm.RestoreEpochStart(1, epochStart, peerInfo)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), restoredEm.Epoch())
+	assert.Equal(t, []uint32{1}, notifiedEpochs)
+	expectedEpochStart, _ := em.CreateEpochStartData([]byte("next randomness"))
+	restoredEpochStart, _ := restoredEm.CreateEpochStartData([]byte("next randomness"))
+	assert.Equal(t, expectedEpochStart, restoredEpochStart)
+	assert.Contains(t, getAllPubKeys(restoredEpochStart), "new_pk_2")
+	assert.NotContains(t, getAllPubKeys(restoredEpochStart), "pk_1_0")
+}
+
+func TestEpochManager_RestoreEpochStartOfFirstEpochShouldRestoreTheInitialValidators(t *testing.T) {
+	t.Parallel()
+
+	var loadedList []consensus.Validator
+	selector := mock.ValidatorGroupSelectorMock{
+		LoadEligibleListCalled: func(eligibleList []consensus.Validator) error {
+			loadedList = eligibleList
+			return nil
+		},
+	}
+	initialValidators := createValidators(2, 3)
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 1)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, selector, &mock.RatingReaderStub{}, initialValidators)
+
+	_ = em.ProcessPeerInfo([]block.PeerData{createPeerData("new_pk", block.PeerRegistrantion)})
+	epochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	_ = em.SetEpochStart(1, epochStart)
+
+	err := em.RestoreEpochStart(0, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), em.Epoch())
+	assert.Equal(t, initialValidators[1], loadedList)
+	restoredEpochStart, _ := em.CreateEpochStartData([]byte("randomness"))
+	assert.NotContains(t, getAllPubKeys(restoredEpochStart), "new_pk")
+}
+
+func TestEpochManager_RestoreEpochStartMissingEpochStartDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+	em, _ := epoch.NewEpochManager(10, 1, 1, mock.HasherMock{}, shardCoordinator, mock.ValidatorGroupSelectorMock{}, &mock.RatingReaderStub{}, createValidators(2, 3))
+
+	err := em.RestoreEpochStart(2, nil, nil)
+
+	assert.Equal(t, epoch.ErrInvalidEpochStartData, err)
+	assert.Equal(t, uint32(0), em.Epoch())
+}
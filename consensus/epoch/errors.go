@@ -0,0 +1,36 @@
+package epoch
+
+import (
+	"errors"
+)
+
+// ErrInvalidRoundsPerEpoch signals that an invalid number of rounds per epoch has been provided
+var ErrInvalidRoundsPerEpoch = errors.New("invalid rounds per epoch")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator has been provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilValidatorGroupSelector signals that a nil validator group selector has been provided
+var ErrNilValidatorGroupSelector = errors.New("nil validator group selector")
+
+// ErrNilRatingReader signals that a nil rating reader has been provided
+var ErrNilRatingReader = errors.New("nil rating reader")
+
+// ErrNilInitialValidators signals that a nil map of initial validators has been provided
+var ErrNilInitialValidators = errors.New("nil initial validators")
+
+// ErrMissingShardValidators signals that the validators list of a shard is missing or empty
+var ErrMissingShardValidators = errors.New("missing shard validators")
+
+// ErrInvalidNodesToShuffle signals that the number of validators to be shuffled is higher than the size
+// of a shard's validators list
+var ErrInvalidNodesToShuffle = errors.New("invalid number of nodes to shuffle per shard")
+
+// ErrInvalidEpochStartData signals that the epoch start data is not consistent
+var ErrInvalidEpochStartData = errors.New("invalid epoch start data")
+
+// ErrNilRandomness signals that a nil randomness source has been provided
+var ErrNilRandomness = errors.New("nil randomness source")
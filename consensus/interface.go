@@ -53,17 +53,29 @@ type Validator interface {
 	Stake() *big.Int
 	Rating() int32
 	PubKey() []byte
+	Address() []byte
 }
 
 // ValidatorGroupSelector defines the behaviour of a struct able to do validator group selection
 type ValidatorGroupSelector interface {
 	PublicKeysSelector
+	EligibleListProvider
 	LoadEligibleList(eligibleList []Validator) error
 	ComputeValidatorsGroup(randomness []byte) (validatorsGroup []Validator, err error)
 	ConsensusGroupSize() int
 	SetConsensusGroupSize(int) error
 }
 
+// RatingReader provides the current rating of a validator
+type RatingReader interface {
+	GetRating(pubKey string) int32
+}
+
+// EligibleListProvider provides the current eligible list of the shard
+type EligibleListProvider interface {
+	EligibleList() []Validator
+}
+
 // PublicKeysSelector allows retrieval of eligible validators public keys selected by a bitmap
 type PublicKeysSelector interface {
 	GetSelectedPublicKeys(selection []byte) (publicKeys []string, err error)
@@ -78,7 +90,23 @@ type BroadcastMessenger interface {
 	BroadcastConsensusMessage(*Message) error
 }
 
+// EquivocationDetector keeps track of the signed consensus messages in order to detect the validators which sign
+// two different headers in the same round
+type EquivocationDetector interface {
+	ProcessSignedMessage(message *Message)
+}
+
+// SlashingProofSender sends a slashing proof to the network, so the offender can be slashed
+type SlashingProofSender interface {
+	SendSlashingProof(proof []byte) error
+}
+
 // P2PMessenger defines a subset of the p2p.Messenger interface
 type P2PMessenger interface {
 	Broadcast(topic string, buff []byte)
 }
+
+// EpochChangeHandler is notified each time a new epoch starts
+type EpochChangeHandler interface {
+	ChangeEpoch(epoch uint32) error
+}
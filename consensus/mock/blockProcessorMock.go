@@ -14,6 +14,7 @@ type BlockProcessorMock struct {
 	RevertAccountStateCalled         func()
 	CreateGenesisBlockCalled         func(balances map[string]*big.Int) (data.HeaderHandler, error)
 	CreateBlockCalled                func(round uint64, haveTime func() bool) (data.BodyHandler, error)
+	SetConsensusDataCalled           func(prevHeader data.HeaderHandler, round uint64)
 	RestoreBlockIntoPoolsCalled      func(header data.HeaderHandler, body data.BodyHandler) error
 	SetOnRequestTransactionCalled    func(f func(destShardID uint32, txHash []byte))
 	CreateBlockHeaderCalled          func(body data.BodyHandler, round uint64, haveTime func() bool) (data.HeaderHandler, error)
@@ -21,6 +22,7 @@ type BlockProcessorMock struct {
 	DecodeBlockBodyCalled            func(dta []byte) data.BodyHandler
 	DecodeBlockHeaderCalled          func(dta []byte) data.HeaderHandler
 	AddLastNotarizedHdrCalled        func(shardId uint32, processedHdr data.HeaderHandler)
+	RestoreEpochStateCalled          func(header data.HeaderHandler) error
 }
 
 // ProcessBlock mocks pocessing a block
@@ -43,6 +45,12 @@ func (blProcMock *BlockProcessorMock) CreateBlockBody(round uint64, haveTime fun
 	return blProcMock.CreateBlockCalled(round, haveTime)
 }
 
+func (blProcMock *BlockProcessorMock) SetConsensusData(prevHeader data.HeaderHandler, round uint64) {
+	if blProcMock.SetConsensusDataCalled != nil {
+		blProcMock.SetConsensusDataCalled(prevHeader, round)
+	}
+}
+
 func (blProcMock *BlockProcessorMock) RestoreBlockIntoPools(header data.HeaderHandler, body data.BodyHandler) error {
 	return blProcMock.RestoreBlockIntoPoolsCalled(header, body)
 }
@@ -66,3 +74,12 @@ func (blProcMock BlockProcessorMock) DecodeBlockHeader(dta []byte) data.HeaderHa
 func (blProcMock BlockProcessorMock) AddLastNotarizedHdr(shardId uint32, processedHdr data.HeaderHandler) {
 	blProcMock.AddLastNotarizedHdrCalled(shardId, processedHdr)
 }
+
+// RestoreEpochState mocks the restore of the epoch state from a committed block
+func (blProcMock *BlockProcessorMock) RestoreEpochState(header data.HeaderHandler) error {
+	if blProcMock.RestoreEpochStateCalled == nil {
+		return nil
+	}
+
+	return blProcMock.RestoreEpochStateCalled(header)
+}
@@ -0,0 +1,9 @@
+package mock
+
+type EpochChangeHandlerStub struct {
+	ChangeEpochCalled func(epoch uint32) error
+}
+
+func (ech *EpochChangeHandlerStub) ChangeEpoch(epoch uint32) error {
+	return ech.ChangeEpochCalled(epoch)
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/consensus"
+)
+
+type EquivocationDetectorStub struct {
+	ProcessSignedMessageCalled func(message *consensus.Message)
+}
+
+func (eds *EquivocationDetectorStub) ProcessSignedMessage(message *consensus.Message) {
+	if eds.ProcessSignedMessageCalled != nil {
+		eds.ProcessSignedMessageCalled(message)
+	}
+}
@@ -0,0 +1,12 @@
+package mock
+
+type RatingReaderStub struct {
+	GetRatingCalled func(pubKey string) int32
+}
+
+func (rrs *RatingReaderStub) GetRating(pubKey string) int32 {
+	if rrs.GetRatingCalled == nil {
+		return 0
+	}
+	return rrs.GetRatingCalled(pubKey)
+}
@@ -0,0 +1,12 @@
+package mock
+
+type SlashingProofSenderStub struct {
+	SendSlashingProofCalled func(proof []byte) error
+}
+
+func (spss *SlashingProofSenderStub) SendSlashingProof(proof []byte) error {
+	if spss.SendSlashingProofCalled == nil {
+		return nil
+	}
+	return spss.SendSlashingProofCalled(proof)
+}
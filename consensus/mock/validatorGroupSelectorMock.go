@@ -8,6 +8,8 @@ import (
 
 type ValidatorGroupSelectorMock struct {
 	ComputeValidatorsGroupCalled func([]byte) ([]consensus.Validator, error)
+	LoadEligibleListCalled       func(eligibleList []consensus.Validator) error
+	EligibleListCalled           func() []consensus.Validator
 }
 
 func (vgsm ValidatorGroupSelectorMock) ComputeValidatorsGroup(randomness []byte) (validatorsGroup []consensus.Validator, err error) {
@@ -35,6 +37,18 @@ func (vgsm ValidatorGroupSelectorMock) ConsensusGroupSize() int {
 }
 
 func (vgsm ValidatorGroupSelectorMock) LoadEligibleList(eligibleList []consensus.Validator) error {
+	if vgsm.LoadEligibleListCalled != nil {
+		return vgsm.LoadEligibleListCalled(eligibleList)
+	}
+
+	return nil
+}
+
+func (vgsm ValidatorGroupSelectorMock) EligibleList() []consensus.Validator {
+	if vgsm.EligibleListCalled != nil {
+		return vgsm.EligibleListCalled()
+	}
+
 	return nil
 }
 
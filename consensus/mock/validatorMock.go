@@ -5,9 +5,10 @@ import (
 )
 
 type ValidatorMock struct {
-	stake  *big.Int
-	rating int32
-	pubKey []byte
+	stake   *big.Int
+	rating  int32
+	pubKey  []byte
+	address []byte
 }
 
 func NewValidatorMock(stake *big.Int, rating int32, pubKey []byte) *ValidatorMock {
@@ -25,3 +26,7 @@ func (vm *ValidatorMock) Rating() int32 {
 func (vm *ValidatorMock) PubKey() []byte {
 	return vm.pubKey
 }
+
+func (vm *ValidatorMock) Address() []byte {
+	return vm.address
+}
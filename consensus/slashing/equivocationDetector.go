@@ -0,0 +1,194 @@
+package slashing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+var log = logger.DefaultLogger()
+
+// maxRoundsKept is the number of rounds, before and after the current one, for which the signed messages are kept
+const maxRoundsKept = 10
+
+type signerMessageKey struct {
+	pubKey  string
+	msgType int
+}
+
+// equivocationDetector records the first signed message received from every validator, for every message type, in
+// the recent rounds. When a validator signs a message for a different header in the same round, it builds a
+// slashing proof out of the two messages. Every node of the shard detects the same offence, so only the one chosen
+// as reporter for it sends the proof to the network. The reporter is chosen from the current eligible list, which
+// changes every epoch
+type equivocationDetector struct {
+	rounder              consensus.Rounder
+	marshalizer          marshal.Marshalizer
+	proofSender          consensus.SlashingProofSender
+	eligibleListProvider consensus.EligibleListProvider
+	selfPubKey           string
+
+	mutMessages sync.Mutex
+	messages    map[int64]map[signerMessageKey]*consensus.Message
+	reported    map[int64]map[string]struct{}
+}
+
+// NewEquivocationDetector creates a new equivocation detector
+func NewEquivocationDetector(
+	rounder consensus.Rounder,
+	marshalizer marshal.Marshalizer,
+	proofSender consensus.SlashingProofSender,
+	eligibleListProvider consensus.EligibleListProvider,
+	selfPubKey string,
+) (*equivocationDetector, error) {
+	if rounder == nil {
+		return nil, ErrNilRounder
+	}
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if proofSender == nil {
+		return nil, ErrNilSlashingProofSender
+	}
+	if eligibleListProvider == nil {
+		return nil, ErrNilEligibleListProvider
+	}
+	if len(selfPubKey) == 0 {
+		return nil, ErrNilPublicKey
+	}
+
+	return &equivocationDetector{
+		rounder:              rounder,
+		marshalizer:          marshalizer,
+		proofSender:          proofSender,
+		eligibleListProvider: eligibleListProvider,
+		selfPubKey:           selfPubKey,
+		messages:             make(map[int64]map[signerMessageKey]*consensus.Message),
+		reported:             make(map[int64]map[string]struct{}),
+	}, nil
+}
+
+// ProcessSignedMessage records a consensus message whose signature has already been checked and sends a slashing
+// proof if its signer has already signed a message of the same type for another header in the same round and this
+// node is the reporter of the offence
+func (ed *equivocationDetector) ProcessSignedMessage(message *consensus.Message) {
+	if message == nil || len(message.BlockHeaderHash) == 0 || len(message.Signature) == 0 {
+		return
+	}
+
+	proof := ed.recordMessage(message)
+	if proof == nil {
+		return
+	}
+
+	log.Info(fmt.Sprintf("validator %s signed two different headers in round %d\n",
+		core.GetTrimmedPk(hex.EncodeToString(message.PubKey)),
+		message.RoundIndex,
+	))
+
+	if !ed.isSelfReporter(string(message.PubKey), message.RoundIndex) {
+		return
+	}
+
+	buff, err := ed.marshalizer.Marshal(proof)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+
+	err = ed.proofSender.SendSlashingProof(buff)
+	if err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// recordMessage keeps the message if it is the first one of its type received from its signer in its round and
+// returns a slashing proof if it conflicts with the recorded one. Every signer is reported only once per round
+func (ed *equivocationDetector) recordMessage(message *consensus.Message) *SlashingProof {
+	ed.mutMessages.Lock()
+	defer ed.mutMessages.Unlock()
+
+	currentRound := ed.rounder.Index()
+	if message.RoundIndex < currentRound-maxRoundsKept || message.RoundIndex > currentRound+maxRoundsKept {
+		return nil
+	}
+	ed.removeOldRounds(currentRound)
+
+	roundMessages, ok := ed.messages[message.RoundIndex]
+	if !ok {
+		roundMessages = make(map[signerMessageKey]*consensus.Message)
+		ed.messages[message.RoundIndex] = roundMessages
+	}
+
+	key := signerMessageKey{pubKey: string(message.PubKey), msgType: message.MsgType}
+	recorded, ok := roundMessages[key]
+	if !ok {
+		roundMessages[key] = message
+		return nil
+	}
+	if bytes.Equal(recorded.BlockHeaderHash, message.BlockHeaderHash) {
+		return nil
+	}
+
+	roundReported, ok := ed.reported[message.RoundIndex]
+	if !ok {
+		roundReported = make(map[string]struct{})
+		ed.reported[message.RoundIndex] = roundReported
+	}
+	if _, ok = roundReported[key.pubKey]; ok {
+		return nil
+	}
+	roundReported[key.pubKey] = struct{}{}
+
+	return &SlashingProof{
+		FirstMessage:  recorded,
+		SecondMessage: message,
+	}
+}
+
+func (ed *equivocationDetector) removeOldRounds(currentRound int64) {
+	for round := range ed.messages {
+		if round < currentRound-maxRoundsKept {
+			delete(ed.messages, round)
+		}
+	}
+	for round := range ed.reported {
+		if round < currentRound-maxRoundsKept {
+			delete(ed.reported, round)
+		}
+	}
+}
+
+// isSelfReporter returns true if this node has to send the slashing proof of the given offender for the given round.
+// The reporter is the validator found after the offender in the eligible list at an offset which rotates with the
+// round, so it is never the offender and all the nodes agree on it without exchanging any message
+func (ed *equivocationDetector) isSelfReporter(offender string, round int64) bool {
+	eligibleList := ed.eligibleListProvider.EligibleList()
+
+	offenderIndex := -1
+	for i, validator := range eligibleList {
+		if string(validator.PubKey()) == offender {
+			offenderIndex = i
+			break
+		}
+	}
+
+	numValidators := int64(len(eligibleList))
+	if offenderIndex < 0 || numValidators < 2 {
+		return false
+	}
+
+	offset := round % (numValidators - 1)
+	if offset < 0 {
+		offset += numValidators - 1
+	}
+	reporterIndex := (int64(offenderIndex) + 1 + offset) % numValidators
+
+	return string(eligibleList[reporterIndex].PubKey()) == ed.selfPubKey
+}
@@ -0,0 +1,263 @@
+package slashing_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/consensus/epoch"
+	"github.com/ElrondNetwork/elrond-go/consensus/mock"
+	"github.com/ElrondNetwork/elrond-go/consensus/slashing"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators/groupSelectors"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/stretchr/testify/assert"
+)
+
+var validators = []string{"pk", "pk1", "pk2"}
+
+func createEligibleList(pubKeys ...string) []consensus.Validator {
+	eligibleList := make([]consensus.Validator, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		eligibleList = append(eligibleList, mock.NewValidatorMock(big.NewInt(0), 0, []byte(pubKey)))
+	}
+
+	return eligibleList
+}
+
+func createEligibleListProvider() consensus.EligibleListProvider {
+	return mock.ValidatorGroupSelectorMock{
+		EligibleListCalled: func() []consensus.Validator {
+			return createEligibleList(validators...)
+		},
+	}
+}
+
+func createDetector(rounder *mock.RounderMock, selfPubKey string, proofs *[][]byte) consensus.EquivocationDetector {
+	proofSender := &mock.SlashingProofSenderStub{
+		SendSlashingProofCalled: func(proof []byte) error {
+			*proofs = append(*proofs, proof)
+			return nil
+		},
+	}
+	ed, _ := slashing.NewEquivocationDetector(rounder, mock.MarshalizerMock{}, proofSender, createEligibleListProvider(), selfPubKey)
+
+	return ed
+}
+
+//------- NewEquivocationDetector
+
+func TestNewEquivocationDetector_NilRounderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, err := slashing.NewEquivocationDetector(nil, mock.MarshalizerMock{}, &mock.SlashingProofSenderStub{}, createEligibleListProvider(), "pk1")
+
+	assert.Nil(t, ed)
+	assert.Equal(t, slashing.ErrNilRounder, err)
+}
+
+func TestNewEquivocationDetector_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, err := slashing.NewEquivocationDetector(&mock.RounderMock{}, nil, &mock.SlashingProofSenderStub{}, createEligibleListProvider(), "pk1")
+
+	assert.Nil(t, ed)
+	assert.Equal(t, slashing.ErrNilMarshalizer, err)
+}
+
+func TestNewEquivocationDetector_NilProofSenderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, err := slashing.NewEquivocationDetector(&mock.RounderMock{}, mock.MarshalizerMock{}, nil, createEligibleListProvider(), "pk1")
+
+	assert.Nil(t, ed)
+	assert.Equal(t, slashing.ErrNilSlashingProofSender, err)
+}
+
+func TestNewEquivocationDetector_NilEligibleListProviderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, err := slashing.NewEquivocationDetector(
+		&mock.RounderMock{},
+		mock.MarshalizerMock{},
+		&mock.SlashingProofSenderStub{},
+		nil,
+		"pk1",
+	)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, slashing.ErrNilEligibleListProvider, err)
+}
+
+func TestNewEquivocationDetector_EmptySelfPubKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, err := slashing.NewEquivocationDetector(
+		&mock.RounderMock{},
+		mock.MarshalizerMock{},
+		&mock.SlashingProofSenderStub{},
+		createEligibleListProvider(),
+		"",
+	)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, slashing.ErrNilPublicKey, err)
+}
+
+func TestNewEquivocationDetector_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	ed, err := slashing.NewEquivocationDetector(&mock.RounderMock{}, mock.MarshalizerMock{}, &mock.SlashingProofSenderStub{}, createEligibleListProvider(), "pk1")
+
+	assert.NotNil(t, ed)
+	assert.Nil(t, err)
+}
+
+//------- ProcessSignedMessage
+
+func TestEquivocationDetector_ProcessSignedMessageSameHeaderShouldNotSendProof(t *testing.T) {
+	t.Parallel()
+
+	proofs := make([][]byte, 0)
+	ed := createDetector(&mock.RounderMock{RoundIndex: 3}, "pk2", &proofs)
+
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+
+	assert.Equal(t, 0, len(proofs))
+}
+
+func TestEquivocationDetector_ProcessSignedMessageDifferentRoundsShouldNotSendProof(t *testing.T) {
+	t.Parallel()
+
+	proofs := make([][]byte, 0)
+	ed := createDetector(&mock.RounderMock{RoundIndex: 3}, "pk2", &proofs)
+
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 4))
+
+	assert.Equal(t, 0, len(proofs))
+}
+
+func TestEquivocationDetector_ProcessSignedMessageConflictingHeadersShouldSendVerifiableProofOnce(t *testing.T) {
+	t.Parallel()
+
+	proofs := make([][]byte, 0)
+	ed := createDetector(&mock.RounderMock{RoundIndex: 3}, "pk2", &proofs)
+
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 3))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash3"), 3))
+
+	assert.Equal(t, 1, len(proofs))
+
+	pubKey, round, err := createProofVerifier().VerifyProof(proofs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pk"), pubKey)
+	assert.Equal(t, uint64(3), round)
+}
+
+func TestEquivocationDetector_ProcessSignedMessageOldRoundShouldBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	proofs := make([][]byte, 0)
+	rounder := &mock.RounderMock{RoundIndex: 3}
+	ed := createDetector(rounder, "pk2", &proofs)
+
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+	rounder.RoundIndex = 100
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 3))
+
+	assert.Equal(t, 0, len(proofs))
+}
+
+func TestEquivocationDetector_ProcessSignedMessageConflictingHeadersShouldBeSentOnlyByTheReporter(t *testing.T) {
+	t.Parallel()
+
+	numSent := 0
+	for _, selfPubKey := range validators {
+		proofs := make([][]byte, 0)
+		ed := createDetector(&mock.RounderMock{RoundIndex: 3}, selfPubKey, &proofs)
+
+		ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+		ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 3))
+
+		numSent += len(proofs)
+	}
+
+	assert.Equal(t, 1, numSent)
+}
+
+func TestEquivocationDetector_ProcessSignedMessageReporterShouldRotateWithTheRound(t *testing.T) {
+	t.Parallel()
+
+	proofs := make([][]byte, 0)
+	ed := createDetector(&mock.RounderMock{RoundIndex: 4}, "pk1", &proofs)
+
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 3))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 4))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 4))
+
+	assert.Equal(t, 1, len(proofs))
+	pubKey, round, err := createProofVerifier().VerifyProof(proofs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pk"), pubKey)
+	assert.Equal(t, uint64(4), round)
+}
+
+func TestEquivocationDetector_ProcessSignedMessageShouldReportValidatorWhichJoinedInANewEpoch(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(1, 0)
+	groupSelector, _ := groupSelectors.NewIndexHashedGroupSelector(1, mock.HasherMock{})
+	initialValidators := map[uint32][]consensus.Validator{0: createEligibleList("pk1", "pk2")}
+	_ = groupSelector.LoadEligibleList(initialValidators[0])
+	em, _ := epoch.NewEpochManager(
+		10,
+		0,
+		1,
+		mock.HasherMock{},
+		shardCoordinator,
+		groupSelector,
+		&mock.RatingReaderStub{},
+		initialValidators,
+	)
+
+	proofs := make([][]byte, 0)
+	proofSender := &mock.SlashingProofSenderStub{
+		SendSlashingProofCalled: func(proof []byte) error {
+			proofs = append(proofs, proof)
+			return nil
+		},
+	}
+	ed, _ := slashing.NewEquivocationDetector(
+		&mock.RounderMock{RoundIndex: 4},
+		mock.MarshalizerMock{},
+		proofSender,
+		groupSelector,
+		"pk2",
+	)
+
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 3))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 3))
+	assert.Equal(t, 0, len(proofs))
+
+	err := em.SetEpochStart(1, []block.EpochStartShardData{
+		{
+			ShardId:    0,
+			PublicKeys: [][]byte{[]byte("pk1"), []byte("pk"), []byte("pk2")},
+			Addresses:  [][]byte{[]byte("address1"), []byte("address"), []byte("address2")},
+		},
+	})
+	assert.Nil(t, err)
+
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash1"), 4))
+	ed.ProcessSignedMessage(createSignedMessage([]byte("hash2"), 4))
+
+	assert.Equal(t, 1, len(proofs))
+	pubKey, round, err := createProofVerifier().VerifyProof(proofs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pk"), pubKey)
+	assert.Equal(t, uint64(4), round)
+}
@@ -0,0 +1,53 @@
+package slashing
+
+import (
+	"errors"
+)
+
+// ErrNilKeyGenerator signals that a nil key generator has been provided
+var ErrNilKeyGenerator = errors.New("nil key generator")
+
+// ErrNilSingleSigner signals that a nil single signer has been provided
+var ErrNilSingleSigner = errors.New("nil single signer")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilRounder signals that a nil rounder has been provided
+var ErrNilRounder = errors.New("nil rounder")
+
+// ErrNilSlashingProofSender signals that a nil slashing proof sender has been provided
+var ErrNilSlashingProofSender = errors.New("nil slashing proof sender")
+
+// ErrNilEligibleListProvider signals that a nil eligible list provider has been provided
+var ErrNilEligibleListProvider = errors.New("nil eligible list provider")
+
+// ErrNilSlashingProof signals that a nil or empty slashing proof has been provided
+var ErrNilSlashingProof = errors.New("nil slashing proof")
+
+// ErrNilProofMessage signals that one of the messages of a slashing proof is missing
+var ErrNilProofMessage = errors.New("nil message in slashing proof")
+
+// ErrNilPublicKey signals that a message of a slashing proof has no public key
+var ErrNilPublicKey = errors.New("nil public key")
+
+// ErrNilSignature signals that a message of a slashing proof has no signature
+var ErrNilSignature = errors.New("nil signature")
+
+// ErrDifferentSigners signals that the messages of a slashing proof were signed by different validators
+var ErrDifferentSigners = errors.New("slashing proof messages have different signers")
+
+// ErrDifferentRounds signals that the messages of a slashing proof were signed for different rounds
+var ErrDifferentRounds = errors.New("slashing proof messages have different rounds")
+
+// ErrDifferentMessageTypes signals that the messages of a slashing proof have different types
+var ErrDifferentMessageTypes = errors.New("slashing proof messages have different types")
+
+// ErrInvalidRound signals that the messages of a slashing proof have an invalid round
+var ErrInvalidRound = errors.New("invalid round in slashing proof")
+
+// ErrNilHeaderHash signals that a message of a slashing proof has no header hash
+var ErrNilHeaderHash = errors.New("nil header hash")
+
+// ErrSameHeaderHash signals that the messages of a slashing proof were signed for the same header
+var ErrSameHeaderHash = errors.New("slashing proof messages have the same header hash")
@@ -0,0 +1,122 @@
+package slashing
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/crypto"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// proofVerifier checks the slashing proofs received in the slashing transactions
+type proofVerifier struct {
+	keyGen       crypto.KeyGenerator
+	singleSigner crypto.SingleSigner
+	marshalizer  marshal.Marshalizer
+}
+
+// NewProofVerifier creates a new slashing proof verifier
+func NewProofVerifier(
+	keyGen crypto.KeyGenerator,
+	singleSigner crypto.SingleSigner,
+	marshalizer marshal.Marshalizer,
+) (*proofVerifier, error) {
+	if keyGen == nil {
+		return nil, ErrNilKeyGenerator
+	}
+	if singleSigner == nil {
+		return nil, ErrNilSingleSigner
+	}
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+
+	return &proofVerifier{
+		keyGen:       keyGen,
+		singleSigner: singleSigner,
+		marshalizer:  marshalizer,
+	}, nil
+}
+
+// VerifyProof checks that the marshalized proof holds two messages of the same type, signed by the same validator in
+// the same round for different headers, and returns the public key of the offender and the round
+func (pv *proofVerifier) VerifyProof(proof []byte) ([]byte, uint64, error) {
+	if len(proof) == 0 {
+		return nil, 0, ErrNilSlashingProof
+	}
+
+	slashingProof := &SlashingProof{}
+	err := pv.marshalizer.Unmarshal(slashingProof, proof)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	first := slashingProof.FirstMessage
+	second := slashingProof.SecondMessage
+	err = checkConflictingMessages(first, second)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = pv.checkSignature(first)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = pv.checkSignature(second)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return first.PubKey, uint64(first.RoundIndex), nil
+}
+
+func checkConflictingMessages(first *consensus.Message, second *consensus.Message) error {
+	if first == nil || second == nil {
+		return ErrNilProofMessage
+	}
+	if len(first.PubKey) == 0 {
+		return ErrNilPublicKey
+	}
+	if !bytes.Equal(first.PubKey, second.PubKey) {
+		return ErrDifferentSigners
+	}
+	if first.RoundIndex < 0 {
+		return ErrInvalidRound
+	}
+	if first.RoundIndex != second.RoundIndex {
+		return ErrDifferentRounds
+	}
+	if first.MsgType != second.MsgType {
+		return ErrDifferentMessageTypes
+	}
+	if len(first.BlockHeaderHash) == 0 || len(second.BlockHeaderHash) == 0 {
+		return ErrNilHeaderHash
+	}
+	if bytes.Equal(first.BlockHeaderHash, second.BlockHeaderHash) {
+		return ErrSameHeaderHash
+	}
+
+	return nil
+}
+
+// checkSignature verifies the signature of a consensus message in the same way the consensus worker does
+func (pv *proofVerifier) checkSignature(message *consensus.Message) error {
+	if len(message.Signature) == 0 {
+		return ErrNilSignature
+	}
+
+	pubKey, err := pv.keyGen.PublicKeyFromByteArray(message.PubKey)
+	if err != nil {
+		return err
+	}
+
+	dataNoSig := *message
+	dataNoSig.Signature = nil
+	dataNoSigBuff, err := pv.marshalizer.Marshal(dataNoSig)
+	if err != nil {
+		return err
+	}
+
+	return pv.singleSigner.Verify(pubKey, dataNoSigBuff, message.Signature)
+}
@@ -0,0 +1,172 @@
+package slashing_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/consensus/mock"
+	"github.com/ElrondNetwork/elrond-go/consensus/slashing"
+	"github.com/ElrondNetwork/elrond-go/crypto"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/stretchr/testify/assert"
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// createSingleSigner returns a signer whose signatures are the signed data prefixed with "sig"
+func createSingleSigner() *mock.SingleSignerMock {
+	return &mock.SingleSignerMock{
+		SignStub: func(private crypto.PrivateKey, msg []byte) ([]byte, error) {
+			return append([]byte("sig"), msg...), nil
+		},
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			if !bytes.Equal(sig, append([]byte("sig"), msg...)) {
+				return errInvalidSignature
+			}
+			return nil
+		},
+	}
+}
+
+func createSignedMessage(hash []byte, round int64) *consensus.Message {
+	message := consensus.NewConsensusMessage(hash, nil, []byte("pk"), nil, 1, 0, round)
+	buff, _ := mock.MarshalizerMock{}.Marshal(*message)
+	message.Signature, _ = createSingleSigner().Sign(nil, buff)
+
+	return message
+}
+
+func createProof(first *consensus.Message, second *consensus.Message) []byte {
+	buff, _ := mock.MarshalizerMock{}.Marshal(&slashing.SlashingProof{FirstMessage: first, SecondMessage: second})
+	return buff
+}
+
+func createProofVerifier() process.SlashingProofVerifier {
+	keyGen, _, _ := mock.InitKeys()
+	pv, _ := slashing.NewProofVerifier(keyGen, createSingleSigner(), mock.MarshalizerMock{})
+	return pv
+}
+
+//------- NewProofVerifier
+
+func TestNewProofVerifier_NilKeyGeneratorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pv, err := slashing.NewProofVerifier(nil, createSingleSigner(), mock.MarshalizerMock{})
+
+	assert.Nil(t, pv)
+	assert.Equal(t, slashing.ErrNilKeyGenerator, err)
+}
+
+func TestNewProofVerifier_NilSingleSignerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	keyGen, _, _ := mock.InitKeys()
+	pv, err := slashing.NewProofVerifier(keyGen, nil, mock.MarshalizerMock{})
+
+	assert.Nil(t, pv)
+	assert.Equal(t, slashing.ErrNilSingleSigner, err)
+}
+
+func TestNewProofVerifier_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	keyGen, _, _ := mock.InitKeys()
+	pv, err := slashing.NewProofVerifier(keyGen, createSingleSigner(), nil)
+
+	assert.Nil(t, pv)
+	assert.Equal(t, slashing.ErrNilMarshalizer, err)
+}
+
+func TestNewProofVerifier_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	keyGen, _, _ := mock.InitKeys()
+	pv, err := slashing.NewProofVerifier(keyGen, createSingleSigner(), mock.MarshalizerMock{})
+
+	assert.NotNil(t, pv)
+	assert.Nil(t, err)
+}
+
+//------- VerifyProof
+
+func TestProofVerifier_VerifyProofEmptyProofShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := createProofVerifier().VerifyProof(nil)
+
+	assert.Equal(t, slashing.ErrNilSlashingProof, err)
+}
+
+func TestProofVerifier_VerifyProofMissingMessageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	proof := createProof(createSignedMessage([]byte("hash1"), 3), nil)
+	_, _, err := createProofVerifier().VerifyProof(proof)
+
+	assert.Equal(t, slashing.ErrNilProofMessage, err)
+}
+
+func TestProofVerifier_VerifyProofDifferentSignersShouldErr(t *testing.T) {
+	t.Parallel()
+
+	second := createSignedMessage([]byte("hash2"), 3)
+	second.PubKey = []byte("other pk")
+	proof := createProof(createSignedMessage([]byte("hash1"), 3), second)
+	_, _, err := createProofVerifier().VerifyProof(proof)
+
+	assert.Equal(t, slashing.ErrDifferentSigners, err)
+}
+
+func TestProofVerifier_VerifyProofDifferentRoundsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	proof := createProof(createSignedMessage([]byte("hash1"), 3), createSignedMessage([]byte("hash2"), 4))
+	_, _, err := createProofVerifier().VerifyProof(proof)
+
+	assert.Equal(t, slashing.ErrDifferentRounds, err)
+}
+
+func TestProofVerifier_VerifyProofDifferentMessageTypesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	second := createSignedMessage([]byte("hash2"), 3)
+	second.MsgType = 2
+	proof := createProof(createSignedMessage([]byte("hash1"), 3), second)
+	_, _, err := createProofVerifier().VerifyProof(proof)
+
+	assert.Equal(t, slashing.ErrDifferentMessageTypes, err)
+}
+
+func TestProofVerifier_VerifyProofSameHeaderHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	proof := createProof(createSignedMessage([]byte("hash1"), 3), createSignedMessage([]byte("hash1"), 3))
+	_, _, err := createProofVerifier().VerifyProof(proof)
+
+	assert.Equal(t, slashing.ErrSameHeaderHash, err)
+}
+
+func TestProofVerifier_VerifyProofInvalidSignatureShouldErr(t *testing.T) {
+	t.Parallel()
+
+	second := createSignedMessage([]byte("hash2"), 3)
+	second.BlockHeaderHash = []byte("hash3")
+	proof := createProof(createSignedMessage([]byte("hash1"), 3), second)
+	_, _, err := createProofVerifier().VerifyProof(proof)
+
+	assert.Equal(t, errInvalidSignature, err)
+}
+
+func TestProofVerifier_VerifyProofShouldReturnOffenderAndRound(t *testing.T) {
+	t.Parallel()
+
+	proof := createProof(createSignedMessage([]byte("hash1"), 3), createSignedMessage([]byte("hash2"), 3))
+	pubKey, round, err := createProofVerifier().VerifyProof(proof)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pk"), pubKey)
+	assert.Equal(t, uint64(3), round)
+}
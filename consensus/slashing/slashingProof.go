@@ -0,0 +1,12 @@
+package slashing
+
+import (
+	"github.com/ElrondNetwork/elrond-go/consensus"
+)
+
+// SlashingProof holds two conflicting consensus messages signed by the same validator in the same round. Each message
+// keeps its signature, so anyone can check that the validator signed two different headers
+type SlashingProof struct {
+	FirstMessage  *consensus.Message
+	SecondMessage *consensus.Message
+}
@@ -218,5 +218,7 @@ func (sr *SubroundStartRound) generateNextConsensusGroup(roundIndex int64) error
 
 	sr.SetConsensusGroup(nextConsensusGroup)
 
+	sr.BlockProcessor().SetConsensusData(currentHeader, uint64(roundIndex))
+
 	return nil
 }
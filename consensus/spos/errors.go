@@ -153,3 +153,6 @@ var ErrNilExecuteStoredMessages = errors.New("executeStoredMessages is nil")
 
 // ErrNilAppStatusHandler defines the error for setting a nil AppStatusHandler
 var ErrNilAppStatusHandler = errors.New("nil AppStatusHandler")
+
+// ErrNilEquivocationDetector is raised when a valid equivocation detector is expected but nil used
+var ErrNilEquivocationDetector = errors.New("equivocation detector is nil")
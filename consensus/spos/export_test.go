@@ -83,6 +83,10 @@ func (wrk *Worker) SetRounder(rounder consensus.Rounder) {
 	wrk.rounder = rounder
 }
 
+func (wrk *Worker) SetEquivocationDetector(equivocationDetector consensus.EquivocationDetector) {
+	wrk.equivocationDetector = equivocationDetector
+}
+
 func (wrk *Worker) CheckSignature(cnsData *consensus.Message) error {
 	return wrk.checkSignature(cnsData)
 }
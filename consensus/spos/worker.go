@@ -33,6 +33,8 @@ type Worker struct {
 	singleSigner       crypto.SingleSigner
 	syncTimer          ntp.SyncTimer
 
+	equivocationDetector consensus.EquivocationDetector
+
 	receivedMessages      map[consensus.MessageType][]*consensus.Message
 	receivedMessagesCalls map[consensus.MessageType]func(*consensus.Message) bool
 
@@ -58,6 +60,7 @@ func NewWorker(
 	shardCoordinator sharding.Coordinator,
 	singleSigner crypto.SingleSigner,
 	syncTimer ntp.SyncTimer,
+	equivocationDetector consensus.EquivocationDetector,
 ) (*Worker, error) {
 	err := checkNewWorkerParams(
 		consensusService,
@@ -73,6 +76,7 @@ func NewWorker(
 		shardCoordinator,
 		singleSigner,
 		syncTimer,
+		equivocationDetector,
 	)
 	if err != nil {
 		return nil, err
@@ -92,6 +96,8 @@ func NewWorker(
 		shardCoordinator:   shardCoordinator,
 		singleSigner:       singleSigner,
 		syncTimer:          syncTimer,
+
+		equivocationDetector: equivocationDetector,
 	}
 
 	wrk.executeMessageChannel = make(chan *consensus.Message)
@@ -119,6 +125,7 @@ func checkNewWorkerParams(
 	shardCoordinator sharding.Coordinator,
 	singleSigner crypto.SingleSigner,
 	syncTimer ntp.SyncTimer,
+	equivocationDetector consensus.EquivocationDetector,
 ) error {
 	if consensusService == nil {
 		return ErrNilConsensusService
@@ -159,6 +166,9 @@ func checkNewWorkerParams(
 	if syncTimer == nil {
 		return ErrNilSyncTimer
 	}
+	if equivocationDetector == nil {
+		return ErrNilEquivocationDetector
+	}
 
 	return nil
 }
@@ -248,6 +258,8 @@ func (wrk *Worker) ProcessReceivedMessage(message p2p.MessageP2P) error {
 		return ErrInvalidSignature
 	}
 
+	wrk.equivocationDetector.ProcessSignedMessage(cnsDta)
+
 	if wrk.consensusService.IsMessageWithBlockHeader(msgType) {
 		headerHash := cnsDta.BlockHeaderHash
 		header := wrk.blockProcessor.DecodeBlockHeader(cnsDta.SubRoundData)
@@ -64,7 +64,8 @@ func initWorker() *spos.Worker {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	return sposWorker
 }
@@ -109,7 +110,8 @@ func TestWorker_NewWorkerConsensusServiceNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilConsensusService, err)
@@ -143,7 +145,8 @@ func TestWorker_NewWorkerBlockProcessorNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilBlockProcessor, err)
@@ -177,7 +180,8 @@ func TestWorker_NewWorkerBlockTrackerNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilBlocksTracker, err)
@@ -212,7 +216,8 @@ func TestWorker_NewWorkerBootstrapperNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilBootstrapper, err)
@@ -247,7 +252,8 @@ func TestWorker_NewWorkerBroadcastMessengerNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilBroadcastMessenger, err)
@@ -281,7 +287,8 @@ func TestWorker_NewWorkerConsensusStateNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilConsensusState, err)
@@ -315,7 +322,8 @@ func TestWorker_NewWorkerForkDetectorNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilForkDetector, err)
@@ -349,7 +357,8 @@ func TestWorker_NewWorkerKeyGeneratorNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilKeyGenerator, err)
@@ -383,7 +392,8 @@ func TestWorker_NewWorkerMarshalizerNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilMarshalizer, err)
@@ -417,7 +427,8 @@ func TestWorker_NewWorkerRounderNilShouldFail(t *testing.T) {
 		nil,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilRounder, err)
@@ -451,7 +462,8 @@ func TestWorker_NewWorkerShardCoordinatorNilShouldFail(t *testing.T) {
 		rounderMock,
 		nil,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilShardCoordinator, err)
@@ -485,7 +497,8 @@ func TestWorker_NewWorkerSingleSignerNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		nil,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilSingleSigner, err)
@@ -519,12 +532,49 @@ func TestWorker_NewWorkerSyncTimerNilShouldFail(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		nil)
+		nil,
+		&mock.EquivocationDetectorStub{})
 
 	assert.Nil(t, wrk)
 	assert.Equal(t, spos.ErrNilSyncTimer, err)
 }
 
+func TestWorker_NewWorkerEquivocationDetectorNilShouldFail(t *testing.T) {
+	t.Parallel()
+	blockProcessor := &mock.BlockProcessorMock{}
+	blockTrackerMock := &mock.BlocksTrackerMock{}
+	bootstrapperMock := &mock.BootstrapperMock{}
+	broadcastMessengerMock := &mock.BroadcastMessengerMock{}
+	consensusState := initConsensusState()
+	forkDetectorMock := &mock.ForkDetectorMock{}
+	keyGeneratorMock := &mock.KeyGenMock{}
+	marshalizerMock := mock.MarshalizerMock{}
+	rounderMock := initRounderMock()
+	shardCoordinatorMock := mock.ShardCoordinatorMock{}
+	singleSignerMock := &mock.SingleSignerMock{}
+	syncTimerMock := &mock.SyncTimerMock{}
+	bnService, _ := bn.NewConsensusService()
+
+	wrk, err := spos.NewWorker(
+		bnService,
+		blockProcessor,
+		blockTrackerMock,
+		bootstrapperMock,
+		broadcastMessengerMock,
+		consensusState,
+		forkDetectorMock,
+		keyGeneratorMock,
+		marshalizerMock,
+		rounderMock,
+		shardCoordinatorMock,
+		singleSignerMock,
+		syncTimerMock,
+		nil)
+
+	assert.Nil(t, wrk)
+	assert.Equal(t, spos.ErrNilEquivocationDetector, err)
+}
+
 func TestWorker_NewWorkerShouldWork(t *testing.T) {
 	t.Parallel()
 	blockProcessor := &mock.BlockProcessorMock{}
@@ -554,7 +604,8 @@ func TestWorker_NewWorkerShouldWork(t *testing.T) {
 		rounderMock,
 		shardCoordinatorMock,
 		singleSignerMock,
-		syncTimerMock)
+		syncTimerMock,
+		&mock.EquivocationDetectorStub{})
 
 	assert.NotNil(t, wrk)
 	assert.Nil(t, err)
@@ -841,6 +892,32 @@ func TestWorker_ProcessReceivedMessageOkValsShouldWork(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestWorker_ProcessReceivedMessageShouldSendSignedMessageToEquivocationDetector(t *testing.T) {
+	t.Parallel()
+	wrk := *initWorker()
+	var processedMessage *consensus.Message
+	wrk.SetEquivocationDetector(&mock.EquivocationDetectorStub{
+		ProcessSignedMessageCalled: func(message *consensus.Message) {
+			processedMessage = message
+		},
+	})
+	cnsMsg := consensus.NewConsensusMessage(
+		[]byte("header hash"),
+		nil,
+		[]byte(wrk.ConsensusState().ConsensusGroup()[0]),
+		[]byte("sig"),
+		int(bn.MtBlockHeader),
+		uint64(wrk.Rounder().TimeStamp().Unix()),
+		0,
+	)
+	buff, _ := wrk.Marshalizer().Marshal(cnsMsg)
+	err := wrk.ProcessReceivedMessage(&mock.P2PMessageMock{DataField: buff})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, processedMessage)
+	assert.Equal(t, cnsMsg.BlockHeaderHash, processedMessage.BlockHeaderHash)
+}
+
 func TestWorker_CheckSelfStateShouldErrMessageFromItself(t *testing.T) {
 	t.Parallel()
 	wrk := *initWorker()
@@ -4,6 +4,6 @@ import (
 	"github.com/ElrondNetwork/elrond-go/consensus"
 )
 
-func (ihgs *indexHashedGroupSelector) EligibleList() []consensus.Validator {
-	return ihgs.eligibleList
+func (ihgs *indexHashedGroupSelector) ExpandedEligibleList() []consensus.Validator {
+	return ihgs.expandedEligibleList
 }
@@ -4,13 +4,21 @@ import (
 	"bytes"
 	"encoding/binary"
 	"math/big"
+	"sync"
 
 	"github.com/ElrondNetwork/elrond-go/consensus"
 	"github.com/ElrondNetwork/elrond-go/hashing"
 )
 
+// ratingUnitsPerWeight is the rating a validator needs for each extra entry in the expanded eligible list
+const ratingUnitsPerWeight = 10
+
+// maxStakeWeight limits the number of entries a validator gets in the expanded eligible list because of its stake
+const maxStakeWeight = 4
+
 type indexHashedGroupSelector struct {
 	hasher               hashing.Hasher
+	mutEligibleList      sync.RWMutex
 	eligibleList         []consensus.Validator
 	expandedEligibleList []consensus.Validator
 	consensusGroupSize   int
@@ -42,21 +50,39 @@ func (ihgs *indexHashedGroupSelector) LoadEligibleList(eligibleList []consensus.
 		return ErrNilInputSlice
 	}
 
+	ihgs.mutEligibleList.Lock()
 	ihgs.eligibleList = make([]consensus.Validator, len(eligibleList))
 	copy(ihgs.eligibleList, eligibleList)
+	ihgs.expandedEligibleList = ihgs.expandEligibleList()
+	ihgs.mutEligibleList.Unlock()
+
 	return nil
 }
 
+// EligibleList returns a copy of the eligible list
+func (ihgs *indexHashedGroupSelector) EligibleList() []consensus.Validator {
+	ihgs.mutEligibleList.RLock()
+	defer ihgs.mutEligibleList.RUnlock()
+
+	eligibleList := make([]consensus.Validator, len(ihgs.eligibleList))
+	copy(eligibleList, ihgs.eligibleList)
+
+	return eligibleList
+}
+
 // ComputeValidatorsGroup will generate a list of validators based on the the eligible list,
 // consensus group size and a randomness source
 // Steps:
-// 1. generate expanded eligible list by multiplying entries from eligible list according to stake and rating -> TODO
+// 1. generate expanded eligible list by multiplying entries from eligible list according to stake and rating
 // 2. for each value in [0, consensusGroupSize), compute proposedindex = Hash( [index as string] CONCAT randomness) % len(eligible list)
 // 3. if proposed index is already in the temp validator list, then proposedIndex++ (and then % len(eligible list) as to not
 //    exceed the maximum index value permitted by the validator list), and then recheck against temp validator list until
 //    the item at the new proposed index is not found in the list. This new proposed index will be called checked index
 // 4. the item at the checked index is appended in the temp validator list
 func (ihgs *indexHashedGroupSelector) ComputeValidatorsGroup(randomness []byte) (validatorsGroup []consensus.Validator, err error) {
+	ihgs.mutEligibleList.RLock()
+	defer ihgs.mutEligibleList.RUnlock()
+
 	if len(ihgs.eligibleList) < ihgs.consensusGroupSize {
 		return nil, ErrSmallEligibleListSize
 	}
@@ -65,15 +91,15 @@ func (ihgs *indexHashedGroupSelector) ComputeValidatorsGroup(randomness []byte)
 		return nil, ErrNilRandomness
 	}
 
-	ihgs.expandedEligibleList = ihgs.expandEligibleList()
+	expandedEligibleList := ihgs.expandedEligibleList
 
 	tempList := make([]consensus.Validator, 0)
 
 	for startIdx := 0; startIdx < ihgs.consensusGroupSize; startIdx++ {
-		proposedIndex := ihgs.computeListIndex(startIdx, string(randomness))
+		proposedIndex := ihgs.computeListIndex(startIdx, string(randomness), len(expandedEligibleList))
 
-		checkedIndex := ihgs.checkIndex(proposedIndex, tempList)
-		tempList = append(tempList, ihgs.expandedEligibleList[checkedIndex])
+		checkedIndex := ihgs.checkIndex(proposedIndex, tempList, expandedEligibleList)
+		tempList = append(tempList, expandedEligibleList[checkedIndex])
 	}
 
 	return tempList, nil
@@ -82,6 +108,9 @@ func (ihgs *indexHashedGroupSelector) ComputeValidatorsGroup(randomness []byte)
 // GetSelectedPublicKeys returns the stringified public keys of the marked validators in the selection bitmap
 // TODO: This function needs to be revised when the requirements are clarified
 func (ihgs *indexHashedGroupSelector) GetSelectedPublicKeys(selection []byte) (publicKeys []string, err error) {
+	ihgs.mutEligibleList.RLock()
+	defer ihgs.mutEligibleList.RUnlock()
+
 	selectionLen := uint16(len(selection) * 8) // 8 selection bits in each byte
 	shardEligibleLen := uint16(len(ihgs.eligibleList))
 	invalidSelection := selectionLen < shardEligibleLen
@@ -115,13 +144,92 @@ func (ihgs *indexHashedGroupSelector) GetSelectedPublicKeys(selection []byte) (p
 	return publicKeys, nil
 }
 
+// expandEligibleList returns a list in which each validator from the eligible list is found as many times as its
+// weight. The weight grows with the rating of the validator and with its stake, relative to the lowest stake of the
+// eligible list, so the validators with a poor rating are selected less often. The weights are divided by their
+// greatest common divisor, so a list of validators with the same rating and stake is not expanded at all
 func (ihgs *indexHashedGroupSelector) expandEligibleList() []consensus.Validator {
-	//TODO implement an expand eligible list variant
-	return ihgs.eligibleList
+	weights := computeWeights(ihgs.eligibleList)
+
+	expandedListLen := 0
+	for _, weight := range weights {
+		expandedListLen += weight
+	}
+
+	expandedList := make([]consensus.Validator, 0, expandedListLen)
+	for i, v := range ihgs.eligibleList {
+		for j := 0; j < weights[i]; j++ {
+			expandedList = append(expandedList, v)
+		}
+	}
+
+	return expandedList
+}
+
+func computeWeights(eligibleList []consensus.Validator) []int {
+	minStake := computeMinStake(eligibleList)
+
+	weights := make([]int, len(eligibleList))
+	divisor := 0
+	for i, v := range eligibleList {
+		weights[i] = computeRatingWeight(v.Rating()) * computeStakeWeight(v.Stake(), minStake)
+		divisor = gcd(divisor, weights[i])
+	}
+
+	for i := range weights {
+		weights[i] /= divisor
+	}
+
+	return weights
+}
+
+func computeRatingWeight(rating int32) int {
+	if rating <= 0 {
+		return 1
+	}
+
+	return 1 + int(rating/ratingUnitsPerWeight)
+}
+
+func computeStakeWeight(stake *big.Int, minStake *big.Int) int {
+	if stake == nil || stake.Sign() <= 0 || minStake == nil {
+		return 1
+	}
+
+	weight := big.NewInt(0).Div(stake, minStake)
+	if weight.Cmp(big.NewInt(maxStakeWeight)) > 0 {
+		return maxStakeWeight
+	}
+
+	return int(weight.Int64())
+}
+
+// computeMinStake returns the lowest positive stake of the eligible list or nil if no validator has staked
+func computeMinStake(eligibleList []consensus.Validator) *big.Int {
+	var minStake *big.Int
+	for _, v := range eligibleList {
+		stake := v.Stake()
+		if stake == nil || stake.Sign() <= 0 {
+			continue
+		}
+		if minStake == nil || stake.Cmp(minStake) < 0 {
+			minStake = stake
+		}
+	}
+
+	return minStake
+}
+
+func gcd(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
 }
 
 // computeListIndex computes a proposed index from expanded eligible list
-func (ihgs *indexHashedGroupSelector) computeListIndex(currentIndex int, randomSource string) int {
+func (ihgs *indexHashedGroupSelector) computeListIndex(currentIndex int, randomSource string, expandedListLen int) int {
 	buffCurrentIndex := make([]byte, 8)
 	binary.BigEndian.PutUint64(buffCurrentIndex, uint64(currentIndex))
 
@@ -131,19 +239,23 @@ func (ihgs *indexHashedGroupSelector) computeListIndex(currentIndex int, randomS
 	computedLargeIndex.SetBytes(indexHash)
 
 	// computedListIndex = computedLargeIndex % len(expandedEligibleList)
-	computedListIndex := big.NewInt(0).Mod(computedLargeIndex, big.NewInt(int64(len(ihgs.expandedEligibleList)))).Int64()
+	computedListIndex := big.NewInt(0).Mod(computedLargeIndex, big.NewInt(int64(expandedListLen))).Int64()
 	return int(computedListIndex)
 }
 
 // checkIndex returns a checked index starting from a proposed index
-func (ihgs *indexHashedGroupSelector) checkIndex(proposedIndex int, selectedList []consensus.Validator) int {
+func (ihgs *indexHashedGroupSelector) checkIndex(
+	proposedIndex int,
+	selectedList []consensus.Validator,
+	expandedEligibleList []consensus.Validator,
+) int {
 
 	for {
-		v := ihgs.expandedEligibleList[proposedIndex]
+		v := expandedEligibleList[proposedIndex]
 
 		if ihgs.validatorIsInList(v, selectedList) {
 			proposedIndex++
-			proposedIndex = proposedIndex % len(ihgs.expandedEligibleList)
+			proposedIndex = proposedIndex % len(expandedEligibleList)
 			continue
 		}
 
@@ -298,16 +298,17 @@ func TestIndexHashedGroupSelector_ComputeValidatorsGroupTest6From10ValidatorsSho
 
 	ihgs, _ := groupSelectors.NewIndexHashedGroupSelector(6, hasher)
 
+	//all validators have the same stake and rating, so the expanded eligible list is the eligible list
 	validator0 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk0"))
-	validator1 := mock.NewValidatorMock(big.NewInt(2), 2, []byte("pk1"))
-	validator2 := mock.NewValidatorMock(big.NewInt(3), 3, []byte("pk2"))
-	validator3 := mock.NewValidatorMock(big.NewInt(4), 4, []byte("pk3"))
-	validator4 := mock.NewValidatorMock(big.NewInt(5), 5, []byte("pk4"))
-	validator5 := mock.NewValidatorMock(big.NewInt(6), 6, []byte("pk5"))
-	validator6 := mock.NewValidatorMock(big.NewInt(7), 7, []byte("pk6"))
-	validator7 := mock.NewValidatorMock(big.NewInt(8), 8, []byte("pk7"))
-	validator8 := mock.NewValidatorMock(big.NewInt(9), 9, []byte("pk8"))
-	validator9 := mock.NewValidatorMock(big.NewInt(10), 10, []byte("pk9"))
+	validator1 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk1"))
+	validator2 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk2"))
+	validator3 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk3"))
+	validator4 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk4"))
+	validator5 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk5"))
+	validator6 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk6"))
+	validator7 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk7"))
+	validator8 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk8"))
+	validator9 := mock.NewValidatorMock(big.NewInt(1), 1, []byte("pk9"))
 
 	list := []consensus.Validator{
 		validator0,
@@ -338,6 +339,99 @@ func TestIndexHashedGroupSelector_ComputeValidatorsGroupTest6From10ValidatorsSho
 
 }
 
+//------- expandEligibleList
+
+func TestIndexHashedGroupSelector_ExpandEligibleListSameRatingAndStakeShouldNotExpand(t *testing.T) {
+	t.Parallel()
+
+	ihgs, _ := groupSelectors.NewIndexHashedGroupSelector(2, mock.HasherMock{})
+	list := []consensus.Validator{
+		mock.NewValidatorMock(big.NewInt(500), 50, []byte("pk0")),
+		mock.NewValidatorMock(big.NewInt(500), 50, []byte("pk1")),
+		mock.NewValidatorMock(big.NewInt(500), 50, []byte("pk2")),
+	}
+
+	_ = ihgs.LoadEligibleList(list)
+
+	assert.Equal(t, list, ihgs.ExpandedEligibleList())
+}
+
+func TestIndexHashedGroupSelector_ExpandEligibleListShouldWeightByRating(t *testing.T) {
+	t.Parallel()
+
+	ihgs, _ := groupSelectors.NewIndexHashedGroupSelector(2, mock.HasherMock{})
+	validator0 := mock.NewValidatorMock(big.NewInt(0), -5, []byte("pk0"))
+	validator1 := mock.NewValidatorMock(big.NewInt(0), 9, []byte("pk1"))
+	validator2 := mock.NewValidatorMock(big.NewInt(0), 25, []byte("pk2"))
+
+	_ = ihgs.LoadEligibleList([]consensus.Validator{validator0, validator1, validator2})
+
+	expected := []consensus.Validator{validator0, validator1, validator2, validator2, validator2}
+	assert.Equal(t, expected, ihgs.ExpandedEligibleList())
+}
+
+func TestIndexHashedGroupSelector_ExpandEligibleListShouldWeightByStake(t *testing.T) {
+	t.Parallel()
+
+	ihgs, _ := groupSelectors.NewIndexHashedGroupSelector(2, mock.HasherMock{})
+	validator0 := mock.NewValidatorMock(big.NewInt(0), 0, []byte("pk0"))
+	validator1 := mock.NewValidatorMock(big.NewInt(10), 0, []byte("pk1"))
+	validator2 := mock.NewValidatorMock(big.NewInt(25), 0, []byte("pk2"))
+	validator3 := mock.NewValidatorMock(big.NewInt(1000), 0, []byte("pk3"))
+
+	_ = ihgs.LoadEligibleList([]consensus.Validator{validator0, validator1, validator2, validator3})
+
+	expected := []consensus.Validator{
+		validator0,
+		validator1,
+		validator2, validator2,
+		validator3, validator3, validator3, validator3,
+	}
+	assert.Equal(t, expected, ihgs.ExpandedEligibleList())
+}
+
+func TestIndexHashedGroupSelector_ExpandEligibleListShouldCombineRatingAndStakeWeights(t *testing.T) {
+	t.Parallel()
+
+	ihgs, _ := groupSelectors.NewIndexHashedGroupSelector(2, mock.HasherMock{})
+	validator0 := mock.NewValidatorMock(big.NewInt(10), 10, []byte("pk0"))
+	validator1 := mock.NewValidatorMock(big.NewInt(20), 10, []byte("pk1"))
+	validator2 := mock.NewValidatorMock(big.NewInt(10), 50, []byte("pk2"))
+
+	_ = ihgs.LoadEligibleList([]consensus.Validator{validator0, validator1, validator2})
+
+	//weights are 2, 4 and 6, divided by their greatest common divisor
+	expected := []consensus.Validator{
+		validator0,
+		validator1, validator1,
+		validator2, validator2, validator2,
+	}
+	assert.Equal(t, expected, ihgs.ExpandedEligibleList())
+}
+
+func TestIndexHashedGroupSelector_ComputeValidatorsGroupShouldSelectFromExpandedList(t *testing.T) {
+	t.Parallel()
+
+	hasher := &mock.HasherStub{}
+	randomness := "randomness"
+	hasher.ComputeCalled = func(s string) []byte {
+		return convertBigIntToBytes(big.NewInt(2))
+	}
+
+	ihgs, _ := groupSelectors.NewIndexHashedGroupSelector(1, hasher)
+	validator0 := mock.NewValidatorMock(big.NewInt(0), 0, []byte("pk0"))
+	validator1 := mock.NewValidatorMock(big.NewInt(0), 0, []byte("pk1"))
+	validator2 := mock.NewValidatorMock(big.NewInt(0), 10, []byte("pk2"))
+
+	_ = ihgs.LoadEligibleList([]consensus.Validator{validator0, validator1, validator2})
+
+	//the expanded list is pk0, pk1, pk2, pk2 and index 2 is picked
+	list, err := ihgs.ComputeValidatorsGroup([]byte(randomness))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []consensus.Validator{validator2}, list)
+}
+
 func BenchmarkIndexHashedGroupSelector_ComputeValidatorsGroup21of400(b *testing.B) {
 	consensusGroupSize := 21
 
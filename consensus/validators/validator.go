@@ -5,13 +5,14 @@ import (
 )
 
 type validator struct {
-	stake  *big.Int
-	rating int32
-	pubKey []byte
+	stake   *big.Int
+	rating  int32
+	pubKey  []byte
+	address []byte
 }
 
 // NewValidator creates a new instance of a validator
-func NewValidator(stake *big.Int, rating int32, pubKey []byte) (*validator, error) {
+func NewValidator(stake *big.Int, rating int32, pubKey []byte, address []byte) (*validator, error) {
 	if stake == nil {
 		return nil, ErrNilStake
 	}
@@ -25,9 +26,10 @@ func NewValidator(stake *big.Int, rating int32, pubKey []byte) (*validator, erro
 	}
 
 	return &validator{
-		stake:  stake,
-		rating: rating,
-		pubKey: pubKey,
+		stake:   stake,
+		rating:  rating,
+		pubKey:  pubKey,
+		address: address,
 	}, nil
 }
 
@@ -45,3 +47,8 @@ func (v *validator) Rating() int32 {
 func (v *validator) PubKey() []byte {
 	return v.pubKey
 }
+
+// Address returns the validator's reward address
+func (v *validator) Address() []byte {
+	return v.address
+}
@@ -11,7 +11,7 @@ import (
 func TestValidator_NewValidatorShouldFailOnNilStake(t *testing.T) {
 	t.Parallel()
 
-	validator, err := validators.NewValidator(nil, 0, []byte("pk1"))
+	validator, err := validators.NewValidator(nil, 0, []byte("pk1"), nil)
 
 	assert.Nil(t, validator)
 	assert.Equal(t, validators.ErrNilStake, err)
@@ -20,7 +20,7 @@ func TestValidator_NewValidatorShouldFailOnNilStake(t *testing.T) {
 func TestValidator_NewValidatorShouldFailOnNegativeStake(t *testing.T) {
 	t.Parallel()
 
-	validator, err := validators.NewValidator(big.NewInt(-1), 0, []byte("pk1"))
+	validator, err := validators.NewValidator(big.NewInt(-1), 0, []byte("pk1"), nil)
 
 	assert.Nil(t, validator)
 	assert.Equal(t, validators.ErrNegativeStake, err)
@@ -29,7 +29,7 @@ func TestValidator_NewValidatorShouldFailOnNegativeStake(t *testing.T) {
 func TestValidator_NewValidatorShouldFailOnNilPublickKey(t *testing.T) {
 	t.Parallel()
 
-	validator, err := validators.NewValidator(big.NewInt(0), 0, nil)
+	validator, err := validators.NewValidator(big.NewInt(0), 0, nil, nil)
 
 	assert.Nil(t, validator)
 	assert.Equal(t, validators.ErrNilPubKey, err)
@@ -38,7 +38,7 @@ func TestValidator_NewValidatorShouldFailOnNilPublickKey(t *testing.T) {
 func TestValidator_NewValidatorShouldWork(t *testing.T) {
 	t.Parallel()
 
-	validator, err := validators.NewValidator(big.NewInt(0), 0, []byte("pk1"))
+	validator, err := validators.NewValidator(big.NewInt(0), 0, []byte("pk1"), nil)
 
 	assert.NotNil(t, validator)
 	assert.Nil(t, err)
@@ -47,7 +47,7 @@ func TestValidator_NewValidatorShouldWork(t *testing.T) {
 func TestValidator_StakeShouldWork(t *testing.T) {
 	t.Parallel()
 
-	validator, _ := validators.NewValidator(big.NewInt(1), 0, []byte("pk1"))
+	validator, _ := validators.NewValidator(big.NewInt(1), 0, []byte("pk1"), nil)
 
 	assert.Equal(t, big.NewInt(1), validator.Stake())
 }
@@ -55,7 +55,15 @@ func TestValidator_StakeShouldWork(t *testing.T) {
 func TestValidator_PubKeyShouldWork(t *testing.T) {
 	t.Parallel()
 
-	validator, _ := validators.NewValidator(big.NewInt(0), 0, []byte("pk1"))
+	validator, _ := validators.NewValidator(big.NewInt(0), 0, []byte("pk1"), nil)
 
 	assert.Equal(t, []byte("pk1"), validator.PubKey())
 }
+
+func TestValidator_AddressShouldWork(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validators.NewValidator(big.NewInt(0), 0, []byte("pk1"), []byte("addr1"))
+
+	assert.Equal(t, []byte("addr1"), validator.Address())
+}
@@ -107,3 +107,14 @@ const MetricRoundTime = "erd_round_time"
 
 // MetricAppVersion is the metric for the current app version
 const MetricAppVersion = "erd_app_version"
+
+// MetricNumRejectedTxs is the metric that stores the number of intercepted transactions rejected by the validator
+const MetricNumRejectedTxs = "erd_num_rejected_txs"
+
+// MetricNumDroppedStaleHeaders is the metric that stores the number of intercepted headers dropped as they were
+// behind the final block
+const MetricNumDroppedStaleHeaders = "erd_num_dropped_stale_headers"
+
+// MetricNumDroppedFutureHeaders is the metric that stores the number of intercepted headers dropped as their round
+// did not start yet
+const MetricNumDroppedFutureHeaders = "erd_num_dropped_future_headers"
@@ -0,0 +1,229 @@
+package events
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-vm-common"
+)
+
+var log = logger.DefaultLogger()
+
+// eventsDispatcher builds the events of the committed blocks and pushes them to the subscribers. The logs of the
+// smart contract calls are kept from the moment they are executed until a block is committed, when the logs of its
+// transactions are pushed and the rest, produced by blocks which were not committed, are dropped
+type eventsDispatcher struct {
+	subscriberBufferSize int
+
+	mutSubscriptions sync.RWMutex
+	subscriptions    map[uint64]*Subscription
+	lastID           uint64
+
+	mutLogs sync.Mutex
+	logs    map[string][]*vmcommon.LogEntry
+}
+
+// NewEventsDispatcher creates a new events dispatcher. The subscriber buffer size is the number of events which
+// can wait to be read by a subscriber, as the slow subscribers lose the events instead of delaying the commit
+func NewEventsDispatcher(subscriberBufferSize int) (*eventsDispatcher, error) {
+	if subscriberBufferSize <= 0 {
+		return nil, ErrInvalidSubscriberBufferSize
+	}
+
+	return &eventsDispatcher{
+		subscriberBufferSize: subscriberBufferSize,
+		subscriptions:        make(map[uint64]*Subscription),
+		logs:                 make(map[string][]*vmcommon.LogEntry),
+	}, nil
+}
+
+// Subscribe creates a subscription for the given event types
+func (ed *eventsDispatcher) Subscribe(eventTypes []EventType) (*Subscription, error) {
+	if len(eventTypes) == 0 {
+		return nil, ErrNoEventTypes
+	}
+
+	subscribedTypes := make(map[EventType]struct{}, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !isKnownEventType(eventType) {
+			return nil, ErrUnknownEventType
+		}
+		subscribedTypes[eventType] = struct{}{}
+	}
+
+	ed.mutSubscriptions.Lock()
+	ed.lastID++
+	subscription := &Subscription{
+		id:         ed.lastID,
+		eventTypes: subscribedTypes,
+		events:     make(chan *Event, ed.subscriberBufferSize),
+	}
+	ed.subscriptions[subscription.id] = subscription
+	ed.mutSubscriptions.Unlock()
+
+	return subscription, nil
+}
+
+// Unsubscribe cancels the subscription and closes its events channel
+func (ed *eventsDispatcher) Unsubscribe(subscription *Subscription) {
+	if subscription == nil {
+		return
+	}
+
+	ed.mutSubscriptions.Lock()
+	delete(ed.subscriptions, subscription.id)
+	ed.mutSubscriptions.Unlock()
+
+	subscription.close()
+}
+
+// SaveLogs keeps the logs of an executed smart contract call until its block is committed
+func (ed *eventsDispatcher) SaveLogs(txHash []byte, logs []*vmcommon.LogEntry) {
+	if len(logs) == 0 || !ed.hasSubscriptions() {
+		return
+	}
+
+	ed.mutLogs.Lock()
+	ed.logs[string(txHash)] = logs
+	ed.mutLogs.Unlock()
+}
+
+// NotifyCommittedBlock pushes the events of a committed block to the subscribers
+func (ed *eventsDispatcher) NotifyCommittedBlock(
+	headerHash []byte,
+	header data.HeaderHandler,
+	txs map[string]data.TransactionHandler,
+) {
+	ed.mutLogs.Lock()
+	logs := ed.logs
+	ed.logs = make(map[string][]*vmcommon.LogEntry)
+	ed.mutLogs.Unlock()
+
+	if header == nil || header.IsInterfaceNil() || !ed.hasSubscriptions() {
+		return
+	}
+
+	blockEvent := Event{
+		ShardID:    header.GetShardID(),
+		BlockNonce: header.GetNonce(),
+		BlockRound: header.GetRound(),
+		BlockHash:  hex.EncodeToString(headerHash),
+	}
+
+	events := make([]*Event, 0)
+	events = append(events, newEvent(blockEvent, CommittedHeaderEvent))
+
+	txHashes := make([]string, 0, len(txs))
+	for txHash := range txs {
+		txHashes = append(txHashes, txHash)
+	}
+	sort.Strings(txHashes)
+
+	changedAccounts := make(map[string]struct{})
+	for _, txHash := range txHashes {
+		tx := txs[txHash]
+		if tx == nil || tx.IsInterfaceNil() {
+			continue
+		}
+
+		txEvent := newEvent(blockEvent, ExecutedTransactionEvent)
+		txEvent.TxHash = hex.EncodeToString([]byte(txHash))
+		txEvent.Sender = hex.EncodeToString(tx.GetSndAddress())
+		txEvent.Receiver = hex.EncodeToString(tx.GetRecvAddress())
+		if tx.GetValue() != nil {
+			txEvent.Value = tx.GetValue().String()
+		}
+		events = append(events, txEvent)
+
+		changedAccounts[string(tx.GetSndAddress())] = struct{}{}
+		changedAccounts[string(tx.GetRecvAddress())] = struct{}{}
+
+		for _, logEntry := range logs[txHash] {
+			events = append(events, newLogEvent(blockEvent, txEvent.TxHash, logEntry))
+		}
+	}
+
+	addresses := make([]string, 0, len(changedAccounts))
+	for address := range changedAccounts {
+		if len(address) > 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		accountEvent := newEvent(blockEvent, AccountChangedEvent)
+		accountEvent.Address = hex.EncodeToString([]byte(address))
+		events = append(events, accountEvent)
+	}
+
+	ed.push(events)
+}
+
+func (ed *eventsDispatcher) push(events []*Event) {
+	ed.mutSubscriptions.RLock()
+	defer ed.mutSubscriptions.RUnlock()
+
+	for _, subscription := range ed.subscriptions {
+		droppedEvents := 0
+		for _, event := range events {
+			if !subscription.isSubscribedTo(event.Type) {
+				continue
+			}
+			if !subscription.push(event) {
+				droppedEvents++
+			}
+		}
+
+		if droppedEvents > 0 {
+			log.Debug(fmt.Sprintf("subscription %d is too slow, %d events dropped\n", subscription.id, droppedEvents))
+		}
+	}
+}
+
+func (ed *eventsDispatcher) hasSubscriptions() bool {
+	ed.mutSubscriptions.RLock()
+	defer ed.mutSubscriptions.RUnlock()
+
+	return len(ed.subscriptions) > 0
+}
+
+func newEvent(blockEvent Event, eventType EventType) *Event {
+	event := blockEvent
+	event.Type = eventType
+
+	return &event
+}
+
+func newLogEvent(blockEvent Event, txHash string, logEntry *vmcommon.LogEntry) *Event {
+	logEvent := newEvent(blockEvent, SmartContractLogEvent)
+	logEvent.TxHash = txHash
+	if logEntry == nil {
+		return logEvent
+	}
+
+	logEvent.Address = hex.EncodeToString(logEntry.Address)
+	logEvent.Data = hex.EncodeToString(logEntry.Data)
+	logEvent.Topics = make([]string, 0, len(logEntry.Topics))
+	for _, topic := range logEntry.Topics {
+		if topic != nil {
+			logEvent.Topics = append(logEvent.Topics, hex.EncodeToString(topic.Bytes()))
+		}
+	}
+
+	return logEvent
+}
+
+func isKnownEventType(eventType EventType) bool {
+	for _, knownType := range AllEventTypes {
+		if knownType == eventType {
+			return true
+		}
+	}
+
+	return false
+}
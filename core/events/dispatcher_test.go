@@ -0,0 +1,170 @@
+package events_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/events"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/assert"
+)
+
+func readEvents(subscription *events.Subscription) []*events.Event {
+	received := make([]*events.Event, 0)
+	for {
+		select {
+		case event := <-subscription.Events():
+			received = append(received, event)
+		default:
+			return received
+		}
+	}
+}
+
+func createCommittedBlock() ([]byte, data.HeaderHandler, map[string]data.TransactionHandler) {
+	header := &block.Header{Nonce: 5, Round: 7, ShardId: 1}
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			SndAddr: []byte("sender"),
+			RcvAddr: []byte("receiver"),
+			Value:   big.NewInt(10),
+		},
+	}
+
+	return []byte("headerHash"), header, txs
+}
+
+func TestNewEventsDispatcher_InvalidBufferSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, err := events.NewEventsDispatcher(0)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, events.ErrInvalidSubscriberBufferSize, err)
+}
+
+func TestNewEventsDispatcher_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	ed, err := events.NewEventsDispatcher(10)
+
+	assert.NotNil(t, ed)
+	assert.Nil(t, err)
+}
+
+func TestEventsDispatcher_SubscribeNoEventTypesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := events.NewEventsDispatcher(10)
+	subscription, err := ed.Subscribe(nil)
+
+	assert.Nil(t, subscription)
+	assert.Equal(t, events.ErrNoEventTypes, err)
+}
+
+func TestEventsDispatcher_SubscribeUnknownEventTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := events.NewEventsDispatcher(10)
+	subscription, err := ed.Subscribe([]events.EventType{events.CommittedHeaderEvent, "unknown"})
+
+	assert.Nil(t, subscription)
+	assert.Equal(t, events.ErrUnknownEventType, err)
+}
+
+func TestEventsDispatcher_NotifyCommittedBlockShouldPushAllEvents(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := events.NewEventsDispatcher(10)
+	subscription, _ := ed.Subscribe(events.AllEventTypes)
+
+	ed.SaveLogs([]byte("txHash"), []*vmcommon.LogEntry{
+		{Address: []byte("sc"), Topics: []*big.Int{big.NewInt(1)}, Data: []byte("data")},
+	})
+	headerHash, header, txs := createCommittedBlock()
+	ed.NotifyCommittedBlock(headerHash, header, txs)
+
+	received := readEvents(subscription)
+	assert.Equal(t, 5, len(received))
+
+	assert.Equal(t, events.CommittedHeaderEvent, received[0].Type)
+	assert.Equal(t, uint32(1), received[0].ShardID)
+	assert.Equal(t, uint64(5), received[0].BlockNonce)
+	assert.Equal(t, uint64(7), received[0].BlockRound)
+	assert.Equal(t, hex.EncodeToString(headerHash), received[0].BlockHash)
+
+	assert.Equal(t, events.ExecutedTransactionEvent, received[1].Type)
+	assert.Equal(t, hex.EncodeToString([]byte("txHash")), received[1].TxHash)
+	assert.Equal(t, hex.EncodeToString([]byte("sender")), received[1].Sender)
+	assert.Equal(t, hex.EncodeToString([]byte("receiver")), received[1].Receiver)
+	assert.Equal(t, "10", received[1].Value)
+
+	assert.Equal(t, events.SmartContractLogEvent, received[2].Type)
+	assert.Equal(t, hex.EncodeToString([]byte("sc")), received[2].Address)
+	assert.Equal(t, []string{"01"}, received[2].Topics)
+	assert.Equal(t, hex.EncodeToString([]byte("data")), received[2].Data)
+
+	assert.Equal(t, events.AccountChangedEvent, received[3].Type)
+	assert.Equal(t, hex.EncodeToString([]byte("receiver")), received[3].Address)
+	assert.Equal(t, events.AccountChangedEvent, received[4].Type)
+	assert.Equal(t, hex.EncodeToString([]byte("sender")), received[4].Address)
+}
+
+func TestEventsDispatcher_NotifyCommittedBlockShouldFilterByEventType(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := events.NewEventsDispatcher(10)
+	subscription, _ := ed.Subscribe([]events.EventType{events.ExecutedTransactionEvent})
+
+	headerHash, header, txs := createCommittedBlock()
+	ed.NotifyCommittedBlock(headerHash, header, txs)
+
+	received := readEvents(subscription)
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, events.ExecutedTransactionEvent, received[0].Type)
+}
+
+func TestEventsDispatcher_NotifyCommittedBlockShouldDropLogsOfOtherTxs(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := events.NewEventsDispatcher(10)
+	subscription, _ := ed.Subscribe([]events.EventType{events.SmartContractLogEvent})
+
+	ed.SaveLogs([]byte("notCommittedTxHash"), []*vmcommon.LogEntry{{Address: []byte("sc")}})
+	headerHash, header, txs := createCommittedBlock()
+	ed.NotifyCommittedBlock(headerHash, header, txs)
+
+	assert.Equal(t, 0, len(readEvents(subscription)))
+}
+
+func TestEventsDispatcher_NotifyCommittedBlockSlowSubscriberShouldNotBlock(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := events.NewEventsDispatcher(1)
+	subscription, _ := ed.Subscribe(events.AllEventTypes)
+
+	headerHash, header, txs := createCommittedBlock()
+	ed.NotifyCommittedBlock(headerHash, header, txs)
+
+	received := readEvents(subscription)
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, events.CommittedHeaderEvent, received[0].Type)
+}
+
+func TestEventsDispatcher_UnsubscribeShouldCloseTheEventsChannel(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := events.NewEventsDispatcher(10)
+	subscription, _ := ed.Subscribe(events.AllEventTypes)
+
+	ed.Unsubscribe(subscription)
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+
+	headerHash, header, txs := createCommittedBlock()
+	ed.NotifyCommittedBlock(headerHash, header, txs)
+}
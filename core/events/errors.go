@@ -0,0 +1,14 @@
+package events
+
+import (
+	"errors"
+)
+
+// ErrInvalidSubscriberBufferSize signals that an invalid subscriber buffer size has been provided
+var ErrInvalidSubscriberBufferSize = errors.New("invalid subscriber buffer size")
+
+// ErrUnknownEventType signals that an unknown event type has been provided
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// ErrNoEventTypes signals that a subscription without event types has been requested
+var ErrNoEventTypes = errors.New("no event types provided")
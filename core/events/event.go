@@ -0,0 +1,40 @@
+package events
+
+// EventType defines the type of an event pushed to the subscribers
+type EventType string
+
+const (
+	// CommittedHeaderEvent is pushed for every committed block header
+	CommittedHeaderEvent EventType = "committedHeader"
+	// ExecutedTransactionEvent is pushed for every transaction executed by a committed block
+	ExecutedTransactionEvent EventType = "executedTransaction"
+	// AccountChangedEvent is pushed for every account touched by the transactions of a committed block
+	AccountChangedEvent EventType = "accountChanged"
+	// SmartContractLogEvent is pushed for every log entry of the smart contract calls executed by a committed block
+	SmartContractLogEvent EventType = "smartContractLog"
+)
+
+// AllEventTypes holds all the event types which can be subscribed to
+var AllEventTypes = []EventType{
+	CommittedHeaderEvent,
+	ExecutedTransactionEvent,
+	AccountChangedEvent,
+	SmartContractLogEvent,
+}
+
+// Event holds the information pushed to the subscribers. All the events carry the committed block they come from,
+// while the other fields are set depending on the event type. Hashes, addresses and data are hex encoded
+type Event struct {
+	Type       EventType `json:"type"`
+	ShardID    uint32    `json:"shardId"`
+	BlockNonce uint64    `json:"blockNonce"`
+	BlockRound uint64    `json:"blockRound"`
+	BlockHash  string    `json:"blockHash"`
+	TxHash     string    `json:"txHash,omitempty"`
+	Sender     string    `json:"sender,omitempty"`
+	Receiver   string    `json:"receiver,omitempty"`
+	Value      string    `json:"value,omitempty"`
+	Address    string    `json:"address,omitempty"`
+	Topics     []string  `json:"topics,omitempty"`
+	Data       string    `json:"data,omitempty"`
+}
@@ -0,0 +1,16 @@
+package events
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+// Notifier builds the events of the committed blocks and pushes them to the subscribers
+type Notifier interface {
+	NotifyCommittedBlock(headerHash []byte, header data.HeaderHandler, txs map[string]data.TransactionHandler)
+}
+
+// Subscriber allows the clients to subscribe to the events of the committed blocks
+type Subscriber interface {
+	Subscribe(eventTypes []EventType) (*Subscription, error)
+	Unsubscribe(subscription *Subscription)
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"sync"
+)
+
+// Subscription receives the events of the types it was created for
+type Subscription struct {
+	id         uint64
+	eventTypes map[EventType]struct{}
+
+	mutEvents sync.RWMutex
+	events    chan *Event
+	closed    bool
+}
+
+// Events returns the channel the events are pushed on. It is closed when the subscription is cancelled
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+func (s *Subscription) isSubscribedTo(eventType EventType) bool {
+	_, ok := s.eventTypes[eventType]
+	return ok
+}
+
+// push sends the event without blocking and returns false if the subscriber does not keep up with the events
+func (s *Subscription) push(event *Event) bool {
+	s.mutEvents.RLock()
+	defer s.mutEvents.RUnlock()
+
+	if s.closed {
+		return true
+	}
+
+	select {
+	case s.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Subscription) close() {
+	s.mutEvents.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+	s.mutEvents.Unlock()
+}
@@ -1,21 +1,25 @@
 package serviceContainer
 
 import (
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
 )
 
 type serviceContainer struct {
-	indexer      indexer.Indexer
-	tpsBenchmark statistics.TPSBenchmark
+	indexer        indexer.Indexer
+	tpsBenchmark   statistics.TPSBenchmark
+	eventsNotifier events.Notifier
 }
 
 // Option represents a functional configuration parameter that
-//  can operate over the serviceContainer struct
+//
+//	can operate over the serviceContainer struct
 type Option func(container *serviceContainer) error
 
 // NewServiceContainer creates a new serviceContainer responsible in
-//  providing access to all injected core features
+//
+//	providing access to all injected core features
 func NewServiceContainer(opts ...Option) (Core, error) {
 	sc := &serviceContainer{}
 	for _, opt := range opts {
@@ -37,6 +41,11 @@ func (sc *serviceContainer) TPSBenchmark() statistics.TPSBenchmark {
 	return sc.tpsBenchmark
 }
 
+// EventsNotifier returns the core package's events notifier
+func (sc *serviceContainer) EventsNotifier() events.Notifier {
+	return sc.eventsNotifier
+}
+
 // WithIndexer sets up the database indexer for the core serviceContainer
 func WithIndexer(indexer indexer.Indexer) Option {
 	return func(sc *serviceContainer) error {
@@ -52,3 +61,11 @@ func WithTPSBenchmark(tpsBenchmark statistics.TPSBenchmark) Option {
 		return nil
 	}
 }
+
+// WithEventsNotifier sets up the events notifier for the core serviceContainer
+func WithEventsNotifier(eventsNotifier events.Notifier) Option {
+	return func(sc *serviceContainer) error {
+		sc.eventsNotifier = eventsNotifier
+		return nil
+	}
+}
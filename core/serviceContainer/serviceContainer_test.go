@@ -1,6 +1,7 @@
 package serviceContainer_test
 
 import (
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"testing"
 
 	"github.com/ElrondNetwork/elrond-go/core/mock"
@@ -54,3 +55,12 @@ func TestServiceContainer_NewServiceContainerWithNilTPSBenchmark(t *testing.T) {
 	assert.NotNil(t, sc)
 	assert.Nil(t, sc.TPSBenchmark())
 }
+
+func TestServiceContainer_NewServiceContainerWithEventsNotifier(t *testing.T) {
+	eventsNotifier, _ := events.NewEventsDispatcher(10)
+
+	sc, err := serviceContainer.NewServiceContainer(serviceContainer.WithEventsNotifier(eventsNotifier))
+	assert.Nil(t, err)
+	assert.NotNil(t, sc)
+	assert.Equal(t, eventsNotifier, sc.EventsNotifier())
+}
@@ -0,0 +1,16 @@
+package core
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+// Overwrite replaces the value of the given key by removing the old value first, as the storage units do not
+// replace the values found in their caches
+func Overwrite(db data.DBRemoveCacher, key []byte, val []byte) error {
+	err := db.Remove(key)
+	if err != nil {
+		return err
+	}
+
+	return db.Put(key, val)
+}
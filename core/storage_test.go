@@ -0,0 +1,34 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverwrite_MissingKeyShouldPut(t *testing.T) {
+	t.Parallel()
+
+	db, _ := memorydb.New()
+
+	err := core.Overwrite(db, []byte("key"), []byte("value"))
+	assert.Nil(t, err)
+
+	val, _ := db.Get([]byte("key"))
+	assert.Equal(t, []byte("value"), val)
+}
+
+func TestOverwrite_ExistingKeyShouldReplaceTheValue(t *testing.T) {
+	t.Parallel()
+
+	db, _ := memorydb.New()
+	_ = db.Put([]byte("key"), []byte("old value"))
+
+	err := core.Overwrite(db, []byte("key"), []byte("new value"))
+	assert.Nil(t, err)
+
+	val, _ := db.Get([]byte("key"))
+	assert.Equal(t, []byte("new value"), val)
+}
@@ -3,6 +3,7 @@ package block
 import (
 	"fmt"
 	"io"
+	"math/big"
 
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block/capnp"
@@ -36,6 +37,8 @@ const (
 	SmartContractResultBlock Type = 3
 	// InvalidBlock identifies identifies an invalid miniblock
 	InvalidBlock Type = 4
+	// RewardsBlock identifies a miniblock holding reward transactions
+	RewardsBlock Type = 5
 )
 
 // String returns the string representation of the Type
@@ -51,6 +54,8 @@ func (bType Type) String() string {
 		return "SmartContractResultBody"
 	case InvalidBlock:
 		return "InvalidBlock"
+	case RewardsBlock:
+		return "RewardsBody"
 	default:
 		return fmt.Sprintf("Unknown(%d)", bType)
 	}
@@ -99,6 +104,8 @@ type Header struct {
 	RootHash         []byte            `capid:"13"`
 	MetaBlockHashes  [][]byte          `capid:"14"`
 	TxCount          uint32            `capid:"15"`
+	PeerInfo         []PeerData        `capid:"16"`
+	Rewards          *big.Int          `capid:"17"`
 	processedMBs     map[string]bool
 }
 
@@ -162,6 +169,20 @@ func HeaderCapnToGo(src capnp.HeaderCapn, dest *Header) *Header {
 
 	dest.TxCount = src.TxCount()
 
+	peerInfoLen := src.PeerInfo().Len()
+	dest.PeerInfo = make([]PeerData, peerInfoLen)
+	for i := 0; i < peerInfoLen; i++ {
+		dest.PeerInfo[i] = *PeerDataCapnToGo(src.PeerInfo().At(i), nil)
+	}
+
+	if len(src.Rewards()) > 0 {
+		dest.Rewards = big.NewInt(0)
+		err := dest.Rewards.GobDecode(src.Rewards())
+		if err != nil {
+			return nil
+		}
+	}
+
 	return dest
 }
 
@@ -210,6 +231,19 @@ func HeaderGoToCapn(seg *capn.Segment, src *Header) capnp.HeaderCapn {
 
 	dest.SetTxCount(src.TxCount)
 
+	if len(src.PeerInfo) > 0 {
+		peerInfoList := capnp.NewPeerDataCapnList(seg, len(src.PeerInfo))
+		plist := capn.PointerList(peerInfoList)
+
+		for i, elem := range src.PeerInfo {
+			_ = plist.Set(i, capn.Object(PeerDataGoToCapn(seg, &elem)))
+		}
+		dest.SetPeerInfo(peerInfoList)
+	}
+
+	rewards, _ := src.Rewards.GobEncode()
+	dest.SetRewards(rewards)
+
 	return dest
 }
 
@@ -522,7 +556,7 @@ func (h *Header) IsInterfaceNil() bool {
 
 // ItemsInHeader gets the number of items(hashes) added in block header
 func (h *Header) ItemsInHeader() uint32 {
-	itemsInHeader := len(h.MiniBlockHeaders) + len(h.PeerChanges) + len(h.MetaBlockHashes)
+	itemsInHeader := len(h.MiniBlockHeaders) + len(h.PeerChanges) + len(h.MetaBlockHashes) + len(h.PeerInfo)
 	return uint32(itemsInHeader)
 }
 
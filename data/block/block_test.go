@@ -2,6 +2,7 @@ package block_test
 
 import (
 	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/ElrondNetwork/elrond-go/data"
@@ -24,6 +25,14 @@ func TestHeader_SaveLoad(t *testing.T) {
 		ShardIdDest: uint32(0),
 	}
 
+	pd := block.PeerData{
+		PublicKey: []byte("public key"),
+		Action:    block.PeerRegistrantion,
+		TimeStamp: uint64(1234),
+		Value:     big.NewInt(1),
+		Address:   []byte("address"),
+	}
+
 	h := block.Header{
 		Nonce:            uint64(1),
 		PrevHash:         []byte("previous hash"),
@@ -41,6 +50,8 @@ func TestHeader_SaveLoad(t *testing.T) {
 		RootHash:         []byte("root hash"),
 		MetaBlockHashes:  make([][]byte, 0),
 		TxCount:          uint32(10),
+		PeerInfo:         []block.PeerData{pd},
+		Rewards:          big.NewInt(100),
 	}
 
 	var b bytes.Buffer
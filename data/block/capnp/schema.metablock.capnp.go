@@ -12,9 +12,9 @@ import (
 
 type PeerDataCapn C.Struct
 
-func NewPeerDataCapn(s *C.Segment) PeerDataCapn      { return PeerDataCapn(s.NewStruct(16, 2)) }
-func NewRootPeerDataCapn(s *C.Segment) PeerDataCapn  { return PeerDataCapn(s.NewRootStruct(16, 2)) }
-func AutoNewPeerDataCapn(s *C.Segment) PeerDataCapn  { return PeerDataCapn(s.NewStructAR(16, 2)) }
+func NewPeerDataCapn(s *C.Segment) PeerDataCapn      { return PeerDataCapn(s.NewStruct(16, 3)) }
+func NewRootPeerDataCapn(s *C.Segment) PeerDataCapn  { return PeerDataCapn(s.NewRootStruct(16, 3)) }
+func AutoNewPeerDataCapn(s *C.Segment) PeerDataCapn  { return PeerDataCapn(s.NewStructAR(16, 3)) }
 func ReadRootPeerDataCapn(s *C.Segment) PeerDataCapn { return PeerDataCapn(s.Root(0).ToStruct()) }
 func (s PeerDataCapn) PublicKey() []byte             { return C.Struct(s).GetObject(0).ToData() }
 func (s PeerDataCapn) SetPublicKey(v []byte)         { C.Struct(s).SetObject(0, s.Segment.NewData(v)) }
@@ -24,6 +24,8 @@ func (s PeerDataCapn) Timestamp() uint64             { return C.Struct(s).Get64(
 func (s PeerDataCapn) SetTimestamp(v uint64)         { C.Struct(s).Set64(8, v) }
 func (s PeerDataCapn) Value() []byte                 { return C.Struct(s).GetObject(1).ToData() }
 func (s PeerDataCapn) SetValue(v []byte)             { C.Struct(s).SetObject(1, s.Segment.NewData(v)) }
+func (s PeerDataCapn) Address() []byte               { return C.Struct(s).GetObject(2).ToData() }
+func (s PeerDataCapn) SetAddress(v []byte)           { C.Struct(s).SetObject(2, s.Segment.NewData(v)) }
 func (s PeerDataCapn) WriteJSON(w io.Writer) error {
 	b := bufio.NewWriter(w)
 	var err error
@@ -105,6 +107,25 @@ func (s PeerDataCapn) WriteJSON(w io.Writer) error {
 			return err
 		}
 	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"address\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Address()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte('}')
 	if err != nil {
 		return err
@@ -198,6 +219,25 @@ func (s PeerDataCapn) WriteCapLit(w io.Writer) error {
 			return err
 		}
 	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("address = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Address()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte(')')
 	if err != nil {
 		return err
@@ -214,7 +254,7 @@ func (s PeerDataCapn) MarshalCapLit() ([]byte, error) {
 type PeerDataCapn_List C.PointerList
 
 func NewPeerDataCapnList(s *C.Segment, sz int) PeerDataCapn_List {
-	return PeerDataCapn_List(s.NewCompositeList(16, 2, sz))
+	return PeerDataCapn_List(s.NewCompositeList(16, 3, sz))
 }
 func (s PeerDataCapn_List) Len() int { return C.PointerList(s).Len() }
 func (s PeerDataCapn_List) At(i int) PeerDataCapn {
@@ -712,11 +752,349 @@ func (s ShardDataCapn_List) ToArray() []ShardDataCapn {
 }
 func (s ShardDataCapn_List) Set(i int, item ShardDataCapn) { C.PointerList(s).Set(i, C.Object(item)) }
 
+type EpochStartShardDataCapn C.Struct
+
+func NewEpochStartShardDataCapn(s *C.Segment) EpochStartShardDataCapn {
+	return EpochStartShardDataCapn(s.NewStruct(8, 3))
+}
+func NewRootEpochStartShardDataCapn(s *C.Segment) EpochStartShardDataCapn {
+	return EpochStartShardDataCapn(s.NewRootStruct(8, 3))
+}
+func AutoNewEpochStartShardDataCapn(s *C.Segment) EpochStartShardDataCapn {
+	return EpochStartShardDataCapn(s.NewStructAR(8, 3))
+}
+func ReadRootEpochStartShardDataCapn(s *C.Segment) EpochStartShardDataCapn {
+	return EpochStartShardDataCapn(s.Root(0).ToStruct())
+}
+func (s EpochStartShardDataCapn) ShardId() uint32            { return C.Struct(s).Get32(0) }
+func (s EpochStartShardDataCapn) SetShardId(v uint32)        { C.Struct(s).Set32(0, v) }
+func (s EpochStartShardDataCapn) PublicKeys() C.DataList     { return C.DataList(C.Struct(s).GetObject(0)) }
+func (s EpochStartShardDataCapn) SetPublicKeys(v C.DataList) { C.Struct(s).SetObject(0, C.Object(v)) }
+func (s EpochStartShardDataCapn) Addresses() C.DataList      { return C.DataList(C.Struct(s).GetObject(1)) }
+func (s EpochStartShardDataCapn) SetAddresses(v C.DataList)  { C.Struct(s).SetObject(1, C.Object(v)) }
+func (s EpochStartShardDataCapn) Stakes() C.DataList         { return C.DataList(C.Struct(s).GetObject(2)) }
+func (s EpochStartShardDataCapn) SetStakes(v C.DataList)     { C.Struct(s).SetObject(2, C.Object(v)) }
+func (s EpochStartShardDataCapn) WriteJSON(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('{')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"shardId\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ShardId()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"publicKeys\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.PublicKeys()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"addresses\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Addresses()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"stakes\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Stakes()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte('}')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s EpochStartShardDataCapn) MarshalJSON() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteJSON(&b)
+	return b.Bytes(), err
+}
+func (s EpochStartShardDataCapn) WriteCapLit(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('(')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("shardId = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ShardId()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("publicKeys = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.PublicKeys()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("addresses = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Addresses()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("stakes = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Stakes()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(')')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s EpochStartShardDataCapn) MarshalCapLit() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteCapLit(&b)
+	return b.Bytes(), err
+}
+
+type EpochStartShardDataCapn_List C.PointerList
+
+func NewEpochStartShardDataCapnList(s *C.Segment, sz int) EpochStartShardDataCapn_List {
+	return EpochStartShardDataCapn_List(s.NewCompositeList(8, 3, sz))
+}
+func (s EpochStartShardDataCapn_List) Len() int { return C.PointerList(s).Len() }
+func (s EpochStartShardDataCapn_List) At(i int) EpochStartShardDataCapn {
+	return EpochStartShardDataCapn(C.PointerList(s).At(i).ToStruct())
+}
+func (s EpochStartShardDataCapn_List) ToArray() []EpochStartShardDataCapn {
+	n := s.Len()
+	a := make([]EpochStartShardDataCapn, n)
+	for i := 0; i < n; i++ {
+		a[i] = s.At(i)
+	}
+	return a
+}
+func (s EpochStartShardDataCapn_List) Set(i int, item EpochStartShardDataCapn) {
+	C.PointerList(s).Set(i, C.Object(item))
+}
+
 type MetaBlockCapn C.Struct
 
-func NewMetaBlockCapn(s *C.Segment) MetaBlockCapn      { return MetaBlockCapn(s.NewStruct(32, 8)) }
-func NewRootMetaBlockCapn(s *C.Segment) MetaBlockCapn  { return MetaBlockCapn(s.NewRootStruct(32, 8)) }
-func AutoNewMetaBlockCapn(s *C.Segment) MetaBlockCapn  { return MetaBlockCapn(s.NewStructAR(32, 8)) }
+func NewMetaBlockCapn(s *C.Segment) MetaBlockCapn      { return MetaBlockCapn(s.NewStruct(32, 9)) }
+func NewRootMetaBlockCapn(s *C.Segment) MetaBlockCapn  { return MetaBlockCapn(s.NewRootStruct(32, 9)) }
+func AutoNewMetaBlockCapn(s *C.Segment) MetaBlockCapn  { return MetaBlockCapn(s.NewStructAR(32, 9)) }
 func ReadRootMetaBlockCapn(s *C.Segment) MetaBlockCapn { return MetaBlockCapn(s.Root(0).ToStruct()) }
 func (s MetaBlockCapn) Nonce() uint64                  { return C.Struct(s).Get64(0) }
 func (s MetaBlockCapn) SetNonce(v uint64)              { C.Struct(s).Set64(0, v) }
@@ -748,6 +1126,12 @@ func (s MetaBlockCapn) RootHash() []byte                { return C.Struct(s).Get
 func (s MetaBlockCapn) SetRootHash(v []byte)            { C.Struct(s).SetObject(7, s.Segment.NewData(v)) }
 func (s MetaBlockCapn) TxCount() uint32                 { return C.Struct(s).Get32(12) }
 func (s MetaBlockCapn) SetTxCount(v uint32)             { C.Struct(s).Set32(12, v) }
+func (s MetaBlockCapn) EpochStart() EpochStartShardDataCapn_List {
+	return EpochStartShardDataCapn_List(C.Struct(s).GetObject(8))
+}
+func (s MetaBlockCapn) SetEpochStart(v EpochStartShardDataCapn_List) {
+	C.Struct(s).SetObject(8, C.Object(v))
+}
 func (s MetaBlockCapn) WriteJSON(w io.Writer) error {
 	b := bufio.NewWriter(w)
 	var err error
@@ -1028,6 +1412,39 @@ func (s MetaBlockCapn) WriteJSON(w io.Writer) error {
 			return err
 		}
 	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"epochStart\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.EpochStart()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				err = s.WriteJSON(b)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte('}')
 	if err != nil {
 		return err
@@ -1320,6 +1737,39 @@ func (s MetaBlockCapn) WriteCapLit(w io.Writer) error {
 			return err
 		}
 	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("epochStart = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.EpochStart()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				err = s.WriteCapLit(b)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte(')')
 	if err != nil {
 		return err
@@ -1336,7 +1786,7 @@ func (s MetaBlockCapn) MarshalCapLit() ([]byte, error) {
 type MetaBlockCapn_List C.PointerList
 
 func NewMetaBlockCapnList(s *C.Segment, sz int) MetaBlockCapn_List {
-	return MetaBlockCapn_List(s.NewCompositeList(32, 8, sz))
+	return MetaBlockCapn_List(s.NewCompositeList(32, 9, sz))
 }
 func (s MetaBlockCapn_List) Len() int { return C.PointerList(s).Len() }
 func (s MetaBlockCapn_List) At(i int) MetaBlockCapn {
@@ -17,6 +17,7 @@ type PeerAction uint8
 const (
 	PeerRegistrantion PeerAction = iota + 1
 	PeerDeregistration
+	PeerSlashing
 )
 
 func (pa PeerAction) String() string {
@@ -25,6 +26,8 @@ func (pa PeerAction) String() string {
 		return "PeerRegistration"
 	case PeerDeregistration:
 		return "PeerDeregistration"
+	case PeerSlashing:
+		return "PeerSlashing"
 	default:
 		return fmt.Sprintf("Unknown type (%d)", pa)
 	}
@@ -33,11 +36,14 @@ func (pa PeerAction) String() string {
 // PeerData holds information about actions taken by a peer:
 //  - a peer can register with an amount to become a validator
 //  - a peer can choose to deregister and get back the deposited value
+//  - a peer can be slashed for signing two different headers in the same round
+// The address is the one the peer registered as the owner of the stake and receiver of the rewards
 type PeerData struct {
 	PublicKey []byte     `capid:"0"`
 	Action    PeerAction `capid:"1"`
 	TimeStamp uint64     `capid:"2"`
 	Value     *big.Int   `capid:"3"`
+	Address   []byte     `capid:"4"`
 }
 
 // ShardMiniBlockHeader holds data for one shard miniblock header
@@ -56,21 +62,31 @@ type ShardData struct {
 	TxCount               uint32                 `capid:"3"`
 }
 
+// EpochStartShardData holds the validators list of one shard for the epoch started by a metablock.
+// The public keys, the reward addresses and the stakes of the validators are aligned by index
+type EpochStartShardData struct {
+	ShardId    uint32   `capid:"0"`
+	PublicKeys [][]byte `capid:"1"`
+	Addresses  [][]byte `capid:"2"`
+	Stakes     [][]byte `capid:"3"`
+}
+
 // MetaBlock holds the data that will be saved to the metachain each round
 type MetaBlock struct {
-	Nonce         uint64      `capid:"0"`
-	Epoch         uint32      `capid:"1"`
-	Round         uint64      `capid:"2"`
-	TimeStamp     uint64      `capid:"3"`
-	ShardInfo     []ShardData `capid:"4"`
-	PeerInfo      []PeerData  `capid:"5"`
-	Signature     []byte      `capid:"6"`
-	PubKeysBitmap []byte      `capid:"7"`
-	PrevHash      []byte      `capid:"8"`
-	PrevRandSeed  []byte      `capid:"9"`
-	RandSeed      []byte      `capid:"10"`
-	RootHash      []byte      `capid:"11"`
-	TxCount       uint32      `capid:"12"`
+	Nonce         uint64                `capid:"0"`
+	Epoch         uint32                `capid:"1"`
+	Round         uint64                `capid:"2"`
+	TimeStamp     uint64                `capid:"3"`
+	ShardInfo     []ShardData           `capid:"4"`
+	PeerInfo      []PeerData            `capid:"5"`
+	Signature     []byte                `capid:"6"`
+	PubKeysBitmap []byte                `capid:"7"`
+	PrevHash      []byte                `capid:"8"`
+	PrevRandSeed  []byte                `capid:"9"`
+	RandSeed      []byte                `capid:"10"`
+	RootHash      []byte                `capid:"11"`
+	TxCount       uint32                `capid:"12"`
+	EpochStart    []EpochStartShardData `capid:"13"`
 	processedMBs  map[string]bool
 }
 
@@ -116,6 +132,25 @@ func (s *ShardData) Load(r io.Reader) error {
 	return nil
 }
 
+// Save saves the serialized data of an EpochStartShardData into a stream through Capnp protocol
+func (e *EpochStartShardData) Save(w io.Writer) error {
+	seg := capn.NewBuffer(nil)
+	EpochStartShardDataGoToCapn(seg, e)
+	_, err := seg.WriteTo(w)
+	return err
+}
+
+// Load loads the data from the stream into an EpochStartShardData object through Capnp protocol
+func (e *EpochStartShardData) Load(r io.Reader) error {
+	capMsg, err := capn.ReadFromStream(r, nil)
+	if err != nil {
+		return err
+	}
+	z := capnp.ReadRootEpochStartShardDataCapn(capMsg)
+	EpochStartShardDataCapnToGo(z, e)
+	return nil
+}
+
 // Save saves the serialized data of a MetaBlock into a stream through Capnp protocol
 func (m *MetaBlock) Save(w io.Writer) error {
 	seg := capn.NewBuffer(nil)
@@ -143,6 +178,7 @@ func PeerDataGoToCapn(seg *capn.Segment, src *PeerData) capnp.PeerDataCapn {
 	dest.SetAction(uint8(src.Action))
 	dest.SetTimestamp(src.TimeStamp)
 	dest.SetValue(value)
+	dest.SetAddress(src.Address)
 
 	return dest
 }
@@ -158,6 +194,7 @@ func PeerDataCapnToGo(src capnp.PeerDataCapn, dest *PeerData) *PeerData {
 	dest.PublicKey = src.PublicKey()
 	dest.Action = PeerAction(src.Action())
 	dest.TimeStamp = src.Timestamp()
+	dest.Address = src.Address()
 	err := dest.Value.GobDecode(src.Value())
 	if err != nil {
 		return nil
@@ -232,6 +269,63 @@ func ShardDataCapnToGo(src capnp.ShardDataCapn, dest *ShardData) *ShardData {
 	return dest
 }
 
+// EpochStartShardDataGoToCapn is a helper function to copy fields from an EpochStartShardData object to an
+// EpochStartShardDataCapn object
+func EpochStartShardDataGoToCapn(seg *capn.Segment, src *EpochStartShardData) capnp.EpochStartShardDataCapn {
+	dest := capnp.AutoNewEpochStartShardDataCapn(seg)
+
+	dest.SetShardId(src.ShardId)
+
+	pubKeysList := seg.NewDataList(len(src.PublicKeys))
+	for i := range src.PublicKeys {
+		pubKeysList.Set(i, src.PublicKeys[i])
+	}
+	dest.SetPublicKeys(pubKeysList)
+
+	addressesList := seg.NewDataList(len(src.Addresses))
+	for i := range src.Addresses {
+		addressesList.Set(i, src.Addresses[i])
+	}
+	dest.SetAddresses(addressesList)
+
+	stakesList := seg.NewDataList(len(src.Stakes))
+	for i := range src.Stakes {
+		stakesList.Set(i, src.Stakes[i])
+	}
+	dest.SetStakes(stakesList)
+
+	return dest
+}
+
+// EpochStartShardDataCapnToGo is a helper function to copy fields from an EpochStartShardDataCapn object to an
+// EpochStartShardData object
+func EpochStartShardDataCapnToGo(src capnp.EpochStartShardDataCapn, dest *EpochStartShardData) *EpochStartShardData {
+	if dest == nil {
+		dest = &EpochStartShardData{}
+	}
+	dest.ShardId = src.ShardId()
+
+	n := src.PublicKeys().Len()
+	dest.PublicKeys = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		dest.PublicKeys[i] = src.PublicKeys().At(i)
+	}
+
+	n = src.Addresses().Len()
+	dest.Addresses = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		dest.Addresses[i] = src.Addresses().At(i)
+	}
+
+	n = src.Stakes().Len()
+	dest.Stakes = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		dest.Stakes[i] = src.Stakes().At(i)
+	}
+
+	return dest
+}
+
 // MetaBlockGoToCapn is a helper function to copy fields from a MetaBlock object to a MetaBlockCapn object
 func MetaBlockGoToCapn(seg *capn.Segment, src *MetaBlock) capnp.MetaBlockCapn {
 	dest := capnp.AutoNewMetaBlockCapn(seg)
@@ -269,6 +363,16 @@ func MetaBlockGoToCapn(seg *capn.Segment, src *MetaBlock) capnp.MetaBlockCapn {
 	dest.SetRootHash(src.RootHash)
 	dest.SetTxCount(src.TxCount)
 
+	if len(src.EpochStart) > 0 {
+		typedList := capnp.NewEpochStartShardDataCapnList(seg, len(src.EpochStart))
+		plist := capn.PointerList(typedList)
+
+		for i, elem := range src.EpochStart {
+			_ = plist.Set(i, capn.Object(EpochStartShardDataGoToCapn(seg, &elem)))
+		}
+		dest.SetEpochStart(typedList)
+	}
+
 	return dest
 }
 
@@ -300,6 +404,12 @@ func MetaBlockCapnToGo(src capnp.MetaBlockCapn, dest *MetaBlock) *MetaBlock {
 	dest.RootHash = src.RootHash()
 	dest.TxCount = src.TxCount()
 
+	n = src.EpochStart().Len()
+	dest.EpochStart = make([]EpochStartShardData, n)
+	for i := 0; i < n; i++ {
+		dest.EpochStart[i] = *EpochStartShardDataCapnToGo(src.EpochStart().At(i), nil)
+	}
+
 	return dest
 }
 
@@ -418,6 +528,12 @@ func (m *MetaBlock) SetTxCount(txCount uint32) {
 	m.TxCount = txCount
 }
 
+// IsStartOfEpochBlock verifies if the meta block starts a new epoch, case in which it holds the new
+// validators lists of the shards
+func (m *MetaBlock) IsStartOfEpochBlock() bool {
+	return len(m.EpochStart) > 0
+}
+
 // GetMiniBlockHeadersWithDst as a map of hashes and sender IDs
 func (m *MetaBlock) GetMiniBlockHeadersWithDst(destId uint32) map[string]uint32 {
 	hashDst := make(map[string]uint32, 0)
@@ -16,6 +16,7 @@ func TestPeerData_SaveLoad(t *testing.T) {
 		Action:    block.PeerRegistrantion,
 		TimeStamp: uint64(1234),
 		Value:     big.NewInt(1),
+		Address:   []byte("address"),
 	}
 	var b bytes.Buffer
 	pd.Save(&b)
@@ -51,12 +52,30 @@ func TestShardData_SaveLoad(t *testing.T) {
 	assert.Equal(t, loadSd, sd)
 }
 
+func TestEpochStartShardData_SaveLoad(t *testing.T) {
+	esd := block.EpochStartShardData{
+		ShardId:    uint32(1),
+		PublicKeys: [][]byte{[]byte("pk1"), []byte("pk2")},
+		Addresses:  [][]byte{[]byte("address1"), []byte("address2")},
+		Stakes:     [][]byte{big.NewInt(10).Bytes(), big.NewInt(20).Bytes()},
+	}
+
+	var b bytes.Buffer
+	esd.Save(&b)
+
+	loadEsd := block.EpochStartShardData{}
+	loadEsd.Load(&b)
+
+	assert.Equal(t, loadEsd, esd)
+}
+
 func TestMetaBlock_SaveLoad(t *testing.T) {
 	pd := block.PeerData{
 		PublicKey: []byte("public key"),
 		Action:    block.PeerRegistrantion,
 		TimeStamp: uint64(1234),
 		Value:     big.NewInt(1),
+		Address:   []byte("address"),
 	}
 
 	mbh := block.ShardMiniBlockHeader{
@@ -87,6 +106,14 @@ func TestMetaBlock_SaveLoad(t *testing.T) {
 		RandSeed:      []byte("random seed"),
 		RootHash:      []byte("root hash"),
 		TxCount:       uint32(1),
+		EpochStart: []block.EpochStartShardData{
+			{
+				ShardId:    uint32(0),
+				PublicKeys: [][]byte{[]byte("pk")},
+				Addresses:  [][]byte{[]byte("address")},
+				Stakes:     [][]byte{big.NewInt(10).Bytes()},
+			},
+		},
 	}
 	var b bytes.Buffer
 	mb.Save(&b)
@@ -108,6 +135,16 @@ func TestMetaBlock_GetEpoch(t *testing.T) {
 	assert.Equal(t, epoch, m.GetEpoch())
 }
 
+func TestMetaBlock_IsStartOfEpochBlock(t *testing.T) {
+	t.Parallel()
+
+	m := block.MetaBlock{}
+	assert.False(t, m.IsStartOfEpochBlock())
+
+	m.EpochStart = []block.EpochStartShardData{{ShardId: 0}}
+	assert.True(t, m.IsStartOfEpochBlock())
+}
+
 func TestMetaBlock_GetShard(t *testing.T) {
 	t.Parallel()
 
@@ -90,9 +90,19 @@ type Trie interface {
 	Prove(key []byte) ([][]byte, error)
 	VerifyProof(proofs [][]byte, key []byte) (bool, error)
 	Commit() error
+	GetDirtyHashes() ([][]byte, error)
 	Recreate(root []byte) (Trie, error)
 	String() string
 	DeepClone() (Trie, error)
+	NewLeafIterator(startKey []byte) (TrieLeafIterator, error)
+}
+
+// TrieLeafIterator walks the leaves of a trie in the ascending order of their keys
+type TrieLeafIterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Error() error
 }
 
 // DBWriteCacher is used to cache changes made to the trie, and only write to the database when it's needed
@@ -100,3 +110,19 @@ type DBWriteCacher interface {
 	Put(key, val []byte) error
 	Get(key []byte) ([]byte, error)
 }
+
+// DBRemoveCacher is a DBWriteCacher that also allows removing the stored entries
+type DBRemoveCacher interface {
+	DBWriteCacher
+	Remove(key []byte) error
+}
+
+// TriePruner keeps the most recent committed trie roots and removes the trie nodes no longer reachable from them
+type TriePruner interface {
+	AddRoot(rootHash []byte) error
+}
+
+// LeafReferencesExtractor returns the roots of other tries referenced by the value held in a trie leaf
+type LeafReferencesExtractor interface {
+	ExtractReferences(value []byte) [][]byte
+}
@@ -0,0 +1,13 @@
+package mock
+
+type TriePrunerStub struct {
+	AddRootCalled func(rootHash []byte) error
+}
+
+func (tps *TriePrunerStub) AddRoot(rootHash []byte) error {
+	if tps.AddRootCalled != nil {
+		return tps.AddRootCalled(rootHash)
+	}
+
+	return nil
+}
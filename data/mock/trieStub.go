@@ -9,15 +9,17 @@ import (
 var errNotImplemented = errors.New("not implemented")
 
 type TrieStub struct {
-	GetCalled         func(key []byte) ([]byte, error)
-	UpdateCalled      func(key, value []byte) error
-	DeleteCalled      func(key []byte) error
-	RootCalled        func() ([]byte, error)
-	ProveCalled       func(key []byte) ([][]byte, error)
-	VerifyProofCalled func(proofs [][]byte, key []byte) (bool, error)
-	CommitCalled      func() error
-	RecreateCalled    func(root []byte) (data.Trie, error)
-	DeepCloneCalled   func() (data.Trie, error)
+	GetCalled             func(key []byte) ([]byte, error)
+	UpdateCalled          func(key, value []byte) error
+	DeleteCalled          func(key []byte) error
+	RootCalled            func() ([]byte, error)
+	ProveCalled           func(key []byte) ([][]byte, error)
+	VerifyProofCalled     func(proofs [][]byte, key []byte) (bool, error)
+	CommitCalled          func() error
+	GetDirtyHashesCalled  func() ([][]byte, error)
+	RecreateCalled        func(root []byte) (data.Trie, error)
+	DeepCloneCalled       func() (data.Trie, error)
+	NewLeafIteratorCalled func(startKey []byte) (data.TrieLeafIterator, error)
 }
 
 func (ts *TrieStub) Get(key []byte) ([]byte, error) {
@@ -76,6 +78,14 @@ func (ts *TrieStub) Commit() error {
 	return errNotImplemented
 }
 
+func (ts *TrieStub) GetDirtyHashes() ([][]byte, error) {
+	if ts.GetDirtyHashesCalled != nil {
+		return ts.GetDirtyHashesCalled()
+	}
+
+	return nil, errNotImplemented
+}
+
 func (ts *TrieStub) Recreate(root []byte) (data.Trie, error) {
 	if ts.RecreateCalled != nil {
 		return ts.RecreateCalled(root)
@@ -91,3 +101,11 @@ func (ts *TrieStub) String() string {
 func (ts *TrieStub) DeepClone() (data.Trie, error) {
 	return ts.DeepCloneCalled()
 }
+
+func (ts *TrieStub) NewLeafIterator(startKey []byte) (data.TrieLeafIterator, error) {
+	if ts.NewLeafIteratorCalled != nil {
+		return ts.NewLeafIteratorCalled(startKey)
+	}
+
+	return nil, errNotImplemented
+}
@@ -0,0 +1,712 @@
+package capnp
+
+// AUTO GENERATED - DO NOT EDIT
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	C "github.com/glycerine/go-capnproto"
+	"io"
+)
+
+type LogEntryCapn C.Struct
+
+func NewLogEntryCapn(s *C.Segment) LogEntryCapn      { return LogEntryCapn(s.NewStruct(0, 3)) }
+func NewRootLogEntryCapn(s *C.Segment) LogEntryCapn  { return LogEntryCapn(s.NewRootStruct(0, 3)) }
+func AutoNewLogEntryCapn(s *C.Segment) LogEntryCapn  { return LogEntryCapn(s.NewStructAR(0, 3)) }
+func ReadRootLogEntryCapn(s *C.Segment) LogEntryCapn { return LogEntryCapn(s.Root(0).ToStruct()) }
+func (s LogEntryCapn) Address() []byte               { return C.Struct(s).GetObject(0).ToData() }
+func (s LogEntryCapn) SetAddress(v []byte)           { C.Struct(s).SetObject(0, s.Segment.NewData(v)) }
+func (s LogEntryCapn) Topics() C.DataList            { return C.DataList(C.Struct(s).GetObject(1)) }
+func (s LogEntryCapn) SetTopics(v C.DataList)        { C.Struct(s).SetObject(1, C.Object(v)) }
+func (s LogEntryCapn) Data() []byte                  { return C.Struct(s).GetObject(2).ToData() }
+func (s LogEntryCapn) SetData(v []byte)              { C.Struct(s).SetObject(2, s.Segment.NewData(v)) }
+func (s LogEntryCapn) WriteJSON(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('{')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"address\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Address()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"topics\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Topics()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"data\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Data()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte('}')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s LogEntryCapn) MarshalJSON() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteJSON(&b)
+	return b.Bytes(), err
+}
+func (s LogEntryCapn) WriteCapLit(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('(')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("address = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Address()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("topics = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Topics()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("data = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Data()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(')')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s LogEntryCapn) MarshalCapLit() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteCapLit(&b)
+	return b.Bytes(), err
+}
+
+type LogEntryCapn_List C.PointerList
+
+func NewLogEntryCapnList(s *C.Segment, sz int) LogEntryCapn_List {
+	return LogEntryCapn_List(s.NewCompositeList(0, 3, sz))
+}
+func (s LogEntryCapn_List) Len() int { return C.PointerList(s).Len() }
+func (s LogEntryCapn_List) At(i int) LogEntryCapn {
+	return LogEntryCapn(C.PointerList(s).At(i).ToStruct())
+}
+func (s LogEntryCapn_List) ToArray() []LogEntryCapn {
+	n := s.Len()
+	a := make([]LogEntryCapn, n)
+	for i := 0; i < n; i++ {
+		a[i] = s.At(i)
+	}
+	return a
+}
+func (s LogEntryCapn_List) Set(i int, item LogEntryCapn) { C.PointerList(s).Set(i, C.Object(item)) }
+
+type ReceiptCapn C.Struct
+
+func NewReceiptCapn(s *C.Segment) ReceiptCapn      { return ReceiptCapn(s.NewStruct(24, 6)) }
+func NewRootReceiptCapn(s *C.Segment) ReceiptCapn  { return ReceiptCapn(s.NewRootStruct(24, 6)) }
+func AutoNewReceiptCapn(s *C.Segment) ReceiptCapn  { return ReceiptCapn(s.NewStructAR(24, 6)) }
+func ReadRootReceiptCapn(s *C.Segment) ReceiptCapn { return ReceiptCapn(s.Root(0).ToStruct()) }
+func (s ReceiptCapn) TxHash() []byte               { return C.Struct(s).GetObject(0).ToData() }
+func (s ReceiptCapn) SetTxHash(v []byte)           { C.Struct(s).SetObject(0, s.Segment.NewData(v)) }
+func (s ReceiptCapn) Status() uint8                { return C.Struct(s).Get8(0) }
+func (s ReceiptCapn) SetStatus(v uint8)            { C.Struct(s).Set8(0, v) }
+func (s ReceiptCapn) BlockHash() []byte            { return C.Struct(s).GetObject(1).ToData() }
+func (s ReceiptCapn) SetBlockHash(v []byte)        { C.Struct(s).SetObject(1, s.Segment.NewData(v)) }
+func (s ReceiptCapn) BlockNonce() uint64           { return C.Struct(s).Get64(8) }
+func (s ReceiptCapn) SetBlockNonce(v uint64)       { C.Struct(s).Set64(8, v) }
+func (s ReceiptCapn) GasUsed() uint64              { return C.Struct(s).Get64(16) }
+func (s ReceiptCapn) SetGasUsed(v uint64)          { C.Struct(s).Set64(16, v) }
+func (s ReceiptCapn) RefundedValue() []byte        { return C.Struct(s).GetObject(2).ToData() }
+func (s ReceiptCapn) SetRefundedValue(v []byte)    { C.Struct(s).SetObject(2, s.Segment.NewData(v)) }
+func (s ReceiptCapn) ReturnCode() []byte           { return C.Struct(s).GetObject(3).ToData() }
+func (s ReceiptCapn) SetReturnCode(v []byte)       { C.Struct(s).SetObject(3, s.Segment.NewData(v)) }
+func (s ReceiptCapn) ReturnData() C.DataList       { return C.DataList(C.Struct(s).GetObject(4)) }
+func (s ReceiptCapn) SetReturnData(v C.DataList)   { C.Struct(s).SetObject(4, C.Object(v)) }
+func (s ReceiptCapn) Logs() LogEntryCapn_List {
+	return LogEntryCapn_List(C.Struct(s).GetObject(5))
+}
+func (s ReceiptCapn) SetLogs(v LogEntryCapn_List) { C.Struct(s).SetObject(5, C.Object(v)) }
+func (s ReceiptCapn) WriteJSON(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('{')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"txHash\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.TxHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"status\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Status()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"blockHash\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"blockNonce\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockNonce()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"gasUsed\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.GasUsed()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"refundedValue\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.RefundedValue()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"returnCode\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ReturnCode()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"returnData\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ReturnData()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"logs\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Logs()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				err = s.WriteJSON(b)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte('}')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s ReceiptCapn) MarshalJSON() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteJSON(&b)
+	return b.Bytes(), err
+}
+func (s ReceiptCapn) WriteCapLit(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('(')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("txHash = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.TxHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("status = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Status()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("blockHash = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("blockNonce = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockNonce()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("gasUsed = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.GasUsed()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("refundedValue = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.RefundedValue()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("returnCode = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ReturnCode()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("returnData = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ReturnData()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				buf, err = json.Marshal(s)
+				if err != nil {
+					return err
+				}
+				_, err = b.Write(buf)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("logs = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Logs()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				err = s.WriteCapLit(b)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(')')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s ReceiptCapn) MarshalCapLit() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteCapLit(&b)
+	return b.Bytes(), err
+}
+
+type ReceiptCapn_List C.PointerList
+
+func NewReceiptCapnList(s *C.Segment, sz int) ReceiptCapn_List {
+	return ReceiptCapn_List(s.NewCompositeList(24, 6, sz))
+}
+func (s ReceiptCapn_List) Len() int { return C.PointerList(s).Len() }
+func (s ReceiptCapn_List) At(i int) ReceiptCapn {
+	return ReceiptCapn(C.PointerList(s).At(i).ToStruct())
+}
+func (s ReceiptCapn_List) ToArray() []ReceiptCapn {
+	n := s.Len()
+	a := make([]ReceiptCapn, n)
+	for i := 0; i < n; i++ {
+		a[i] = s.At(i)
+	}
+	return a
+}
+func (s ReceiptCapn_List) Set(i int, item ReceiptCapn) { C.PointerList(s).Set(i, C.Object(item)) }
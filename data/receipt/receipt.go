@@ -0,0 +1,167 @@
+package receipt
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/data/receipt/capnp"
+	"github.com/glycerine/go-capnproto"
+)
+
+// Status is the execution status of a transaction included in a committed block
+type Status uint8
+
+const (
+	// Executed signals that the transaction was successfully executed
+	Executed Status = iota
+	// Failed signals that the transaction paid its fees but the execution of its smart contract call failed
+	Failed
+)
+
+// String returns the human readable execution status
+func (s Status) String() string {
+	switch s {
+	case Executed:
+		return "executed"
+	case Failed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// LogEntry holds a log entry produced by a smart contract call
+type LogEntry struct {
+	Address []byte   `capid:"0" json:"address"`
+	Topics  [][]byte `capid:"1" json:"topics"`
+	Data    []byte   `capid:"2" json:"data"`
+}
+
+// Receipt holds the outcome of the execution of a transaction included in a committed block
+type Receipt struct {
+	TxHash        []byte      `capid:"0" json:"txHash"`
+	Status        Status      `capid:"1" json:"status"`
+	BlockHash     []byte      `capid:"2" json:"blockHash"`
+	BlockNonce    uint64      `capid:"3" json:"blockNonce"`
+	GasUsed       uint64      `capid:"4" json:"gasUsed"`
+	RefundedValue *big.Int    `capid:"5" json:"refundedValue"`
+	ReturnCode    string      `capid:"6" json:"returnCode"`
+	ReturnData    [][]byte    `capid:"7" json:"returnData"`
+	Logs          []*LogEntry `capid:"8" json:"logs"`
+}
+
+// Save saves the serialized data of a Receipt into a stream through Capnp protocol
+func (r *Receipt) Save(w io.Writer) error {
+	seg := capn.NewBuffer(nil)
+	ReceiptGoToCapn(seg, r)
+	_, err := seg.WriteTo(w)
+	return err
+}
+
+// Load loads the data from the stream into a Receipt object through Capnp protocol
+func (r *Receipt) Load(reader io.Reader) error {
+	capMsg, err := capn.ReadFromStream(reader, nil)
+	if err != nil {
+		return err
+	}
+
+	z := capnp.ReadRootReceiptCapn(capMsg)
+	ReceiptCapnToGo(z, r)
+	return nil
+}
+
+// ReceiptCapnToGo is a helper function to copy fields from a ReceiptCapn object to a Receipt object
+func ReceiptCapnToGo(src capnp.ReceiptCapn, dest *Receipt) *Receipt {
+	if dest == nil {
+		dest = &Receipt{}
+	}
+
+	if dest.RefundedValue == nil {
+		dest.RefundedValue = big.NewInt(0)
+	}
+
+	dest.TxHash = src.TxHash()
+	dest.Status = Status(src.Status())
+	dest.BlockHash = src.BlockHash()
+	dest.BlockNonce = src.BlockNonce()
+	dest.GasUsed = src.GasUsed()
+	err := dest.RefundedValue.GobDecode(src.RefundedValue())
+	if err != nil {
+		return nil
+	}
+	dest.ReturnCode = string(src.ReturnCode())
+
+	n := src.ReturnData().Len()
+	dest.ReturnData = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		dest.ReturnData[i] = src.ReturnData().At(i)
+	}
+
+	n = src.Logs().Len()
+	dest.Logs = make([]*LogEntry, n)
+	for i := 0; i < n; i++ {
+		dest.Logs[i] = LogEntryCapnToGo(src.Logs().At(i), nil)
+	}
+
+	return dest
+}
+
+// ReceiptGoToCapn is a helper function to copy fields from a Receipt object to a ReceiptCapn object
+func ReceiptGoToCapn(seg *capn.Segment, src *Receipt) capnp.ReceiptCapn {
+	dest := capnp.AutoNewReceiptCapn(seg)
+
+	refundedValue, _ := src.RefundedValue.GobEncode()
+	dest.SetTxHash(src.TxHash)
+	dest.SetStatus(uint8(src.Status))
+	dest.SetBlockHash(src.BlockHash)
+	dest.SetBlockNonce(src.BlockNonce)
+	dest.SetGasUsed(src.GasUsed)
+	dest.SetRefundedValue(refundedValue)
+	dest.SetReturnCode([]byte(src.ReturnCode))
+
+	returnDataList := seg.NewDataList(len(src.ReturnData))
+	for i := range src.ReturnData {
+		returnDataList.Set(i, src.ReturnData[i])
+	}
+	dest.SetReturnData(returnDataList)
+
+	logsList := capnp.NewLogEntryCapnList(seg, len(src.Logs))
+	for i := range src.Logs {
+		logsList.Set(i, LogEntryGoToCapn(seg, src.Logs[i]))
+	}
+	dest.SetLogs(logsList)
+
+	return dest
+}
+
+// LogEntryCapnToGo is a helper function to copy fields from a LogEntryCapn object to a LogEntry object
+func LogEntryCapnToGo(src capnp.LogEntryCapn, dest *LogEntry) *LogEntry {
+	if dest == nil {
+		dest = &LogEntry{}
+	}
+
+	dest.Address = src.Address()
+	n := src.Topics().Len()
+	dest.Topics = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		dest.Topics[i] = src.Topics().At(i)
+	}
+	dest.Data = src.Data()
+
+	return dest
+}
+
+// LogEntryGoToCapn is a helper function to copy fields from a LogEntry object to a LogEntryCapn object
+func LogEntryGoToCapn(seg *capn.Segment, src *LogEntry) capnp.LogEntryCapn {
+	dest := capnp.AutoNewLogEntryCapn(seg)
+
+	dest.SetAddress(src.Address)
+	topicsList := seg.NewDataList(len(src.Topics))
+	for i := range src.Topics {
+		topicsList.Set(i, src.Topics[i])
+	}
+	dest.SetTopics(topicsList)
+	dest.SetData(src.Data)
+
+	return dest
+}
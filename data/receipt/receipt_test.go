@@ -0,0 +1,46 @@
+package receipt_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceipt_SaveLoad(t *testing.T) {
+	rS := receipt.Receipt{
+		TxHash:        []byte("tx_hash"),
+		Status:        receipt.Failed,
+		BlockHash:     []byte("block_hash"),
+		BlockNonce:    uint64(5),
+		GasUsed:       uint64(100),
+		RefundedValue: big.NewInt(30),
+		ReturnCode:    "out of gas",
+		ReturnData:    [][]byte{[]byte("return_data1"), []byte("return_data2")},
+		Logs: []*receipt.LogEntry{
+			{
+				Address: []byte("sc_address"),
+				Topics:  [][]byte{[]byte("topic1"), []byte("topic2")},
+				Data:    []byte("data"),
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	_ = rS.Save(&b)
+
+	loadR := receipt.Receipt{}
+	_ = loadR.Load(&b)
+
+	assert.Equal(t, rS, loadR)
+}
+
+func TestStatus_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "executed", receipt.Executed.String())
+	assert.Equal(t, "failed", receipt.Failed.String())
+	assert.Equal(t, "unknown", receipt.Status(10).String())
+}
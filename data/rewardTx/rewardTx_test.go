@@ -0,0 +1,76 @@
+package rewardTx_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/rewardTx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardTx_SaveLoad(t *testing.T) {
+	rtxS := rewardTx.RewardTx{
+		Round:   uint64(1),
+		Value:   big.NewInt(3),
+		RcvAddr: []byte("receiver_address"),
+		ShardId: uint32(4),
+	}
+
+	var b bytes.Buffer
+	_ = rtxS.Save(&b)
+
+	loadRTX := rewardTx.RewardTx{}
+	_ = loadRTX.Load(&b)
+
+	assert.Equal(t, rtxS, loadRTX)
+}
+
+func TestRewardTx_GetRecvAddr(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("data")
+	rtx := &rewardTx.RewardTx{RcvAddr: data}
+
+	assert.Equal(t, data, rtx.GetRecvAddress())
+}
+
+func TestRewardTx_GetValue(t *testing.T) {
+	t.Parallel()
+
+	value := big.NewInt(10)
+	rtx := &rewardTx.RewardTx{Value: value}
+
+	assert.Equal(t, value, rtx.GetValue())
+}
+
+func TestRewardTx_SetSndAddrAndDataShouldBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	rtx := &rewardTx.RewardTx{}
+	rtx.SetSndAddress([]byte("sender"))
+	rtx.SetData("data")
+
+	assert.Nil(t, rtx.GetSndAddress())
+	assert.Equal(t, "", rtx.GetData())
+}
+
+func TestRewardTx_SetRecvAddr(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("data")
+	rtx := &rewardTx.RewardTx{}
+	rtx.SetRecvAddress(data)
+
+	assert.Equal(t, data, rtx.GetRecvAddress())
+}
+
+func TestRewardTx_SetValue(t *testing.T) {
+	t.Parallel()
+
+	value := big.NewInt(10)
+	rtx := &rewardTx.RewardTx{}
+	rtx.SetValue(value)
+
+	assert.Equal(t, value, rtx.GetValue())
+}
@@ -0,0 +1,14 @@
+package state
+
+// AccountProof holds the merkle proof of an account and, when a storage key was requested, the merkle proof of that
+// key in the account data trie. The proofs are valid against the root hash of the header with the given nonce and hash
+type AccountProof struct {
+	HeaderNonce  uint64
+	HeaderHash   []byte
+	RootHash     []byte
+	Address      []byte
+	Proof        [][]byte
+	Key          []byte
+	DataRootHash []byte
+	DataProof    [][]byte
+}
@@ -17,17 +17,20 @@ type AccountsDB struct {
 	hasher         hashing.Hasher
 	marshalizer    marshal.Marshalizer
 	accountFactory AccountFactory
+	triePruner     data.TriePruner
 
 	entries    []JournalEntry
 	mutEntries sync.RWMutex
 }
 
-// NewAccountsDB creates a new account manager
+// NewAccountsDB creates a new account manager. The trie pruner is optional, a nil value keeps all the
+// committed states (archive mode)
 func NewAccountsDB(
 	trie data.Trie,
 	hasher hashing.Hasher,
 	marshalizer marshal.Marshalizer,
 	accountFactory AccountFactory,
+	triePruner data.TriePruner,
 ) (*AccountsDB, error) {
 	if trie == nil {
 		return nil, ErrNilTrie
@@ -47,6 +50,7 @@ func NewAccountsDB(
 		hasher:         hasher,
 		marshalizer:    marshalizer,
 		accountFactory: accountFactory,
+		triePruner:     triePruner,
 		entries:        make([]JournalEntry, 0),
 		mutEntries:     sync.RWMutex{},
 	}, nil
@@ -435,9 +439,47 @@ func (adb *AccountsDB) Commit() ([]byte, error) {
 		return nil, err
 	}
 
+	//Step 4. keep the new root and prune the old ones
+	if adb.triePruner != nil {
+		err = adb.triePruner.AddRoot(root)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return root, nil
 }
 
+// GetDirtyTrieHashes returns the hashes of the trie nodes which will be written by the next commit, from the data
+// tries of the changed accounts and from the main trie
+func (adb *AccountsDB) GetDirtyTrieHashes() ([][]byte, error) {
+	adb.mutEntries.RLock()
+	jEntries := make([]JournalEntry, len(adb.entries))
+	copy(jEntries, adb.entries)
+	adb.mutEntries.RUnlock()
+
+	dirtyHashes := make([][]byte, 0)
+	for i := 0; i < len(jEntries); i++ {
+		jed, found := jEntries[i].(*BaseJournalEntryData)
+		if !found {
+			continue
+		}
+
+		hashes, err := jed.Trie().GetDirtyHashes()
+		if err != nil {
+			return nil, err
+		}
+		dirtyHashes = append(dirtyHashes, hashes...)
+	}
+
+	hashes, err := adb.mainTrie.GetDirtyHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dirtyHashes, hashes...), nil
+}
+
 // loadCode retrieves and saves the SC code inside AccountState object. Errors if something went wrong
 func (adb *AccountsDB) loadCode(accountHandler AccountHandler) error {
 	if accountHandler.GetCodeHash() == nil || len(accountHandler.GetCodeHash()) == 0 {
@@ -476,6 +518,81 @@ func (adb *AccountsDB) RecreateTrie(rootHash []byte) error {
 	return nil
 }
 
+// RecreateReadOnly returns a new accounts adapter over the state with the given root hash. The live state of this
+// adapter is not changed and the returned adapter should only be used for reading, as its changes are never pruned
+func (adb *AccountsDB) RecreateReadOnly(rootHash []byte) (AccountsAdapter, error) {
+	emptyTrie, err := adb.mainTrie.Recreate(make([]byte, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	readOnlyAdb, err := NewAccountsDB(emptyTrie, adb.hasher, adb.marshalizer, adb.accountFactory, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = readOnlyAdb.RecreateTrie(rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return readOnlyAdb, nil
+}
+
+// WalkAccounts calls the handler for every account held by the main trie, in the ascending order of the
+// addresses. The code and the data trie of each account are loaded before calling the handler, so the account
+// storage can be walked through its data trie. The walk stops at the first error returned by the handler
+func (adb *AccountsDB) WalkAccounts(handler func(accountHandler AccountHandler) error) error {
+	if handler == nil {
+		return ErrNilWalkHandler
+	}
+
+	it, err := adb.mainTrie.NewLeafIterator(nil)
+	if err != nil {
+		return err
+	}
+
+	for it.Next() {
+		// the main trie also holds the SC codes, keyed by their hash
+		if bytes.Equal(adb.hasher.Compute(string(it.Value())), it.Key()) {
+			continue
+		}
+
+		acnt, err := adb.accountFactory.CreateAccount(NewAddress(it.Key()), adb)
+		if err != nil {
+			return err
+		}
+
+		err = adb.marshalizer.Unmarshal(acnt, it.Value())
+		if err != nil {
+			return err
+		}
+
+		err = adb.loadCodeAndDataIntoAccountHandler(acnt)
+		if err != nil {
+			return err
+		}
+
+		err = handler(acnt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return it.Error()
+}
+
+// Prove returns the merkle proof of the key in the trie having the given root hash, which can either be the main
+// trie or the data trie of an account
+func (adb *AccountsDB) Prove(rootHash []byte, key []byte) ([][]byte, error) {
+	tr, err := adb.mainTrie.Recreate(rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr.Prove(key)
+}
+
 // Journalize adds a new object to entries list. Concurrent safe.
 func (adb *AccountsDB) Journalize(entry JournalEntry) {
 	if entry == nil {
@@ -17,7 +17,7 @@ func generateAccountDBFromTrie(trie data.Trie) *state.AccountsDB {
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
 			return mock.NewAccountWrapMock(address, tracker), nil
 		},
-	})
+	}, nil)
 	return accnt
 }
 
@@ -45,6 +45,7 @@ func TestNewAccountsDB_WithNilTrieShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		&mock.MarshalizerMock{},
 		&mock.AccountsFactoryStub{},
+		nil,
 	)
 
 	assert.Nil(t, adb)
@@ -59,6 +60,7 @@ func TestNewAccountsDB_WithNilHasherShouldErr(t *testing.T) {
 		nil,
 		&mock.MarshalizerMock{},
 		&mock.AccountsFactoryStub{},
+		nil,
 	)
 
 	assert.Nil(t, adb)
@@ -73,6 +75,7 @@ func TestNewAccountsDB_WithNilMarshalizerShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		nil,
 		&mock.AccountsFactoryStub{},
+		nil,
 	)
 
 	assert.Nil(t, adb)
@@ -87,6 +90,7 @@ func TestNewAccountsDB_WithNilAddressFactoryShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		&mock.MarshalizerMock{},
 		nil,
+		nil,
 	)
 
 	assert.Nil(t, adb)
@@ -101,6 +105,7 @@ func TestNewAccountsDB_OkValsShouldWork(t *testing.T) {
 		mock.HasherMock{},
 		&mock.MarshalizerMock{},
 		&mock.AccountsFactoryStub{},
+		nil,
 	)
 
 	assert.NotNil(t, adb)
@@ -340,7 +345,7 @@ func TestAccountsDB_SaveJournalizedAccountMalfunctionMarshalizerShouldErr(t *tes
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
 			return mock.NewAccountWrapMock(address, tracker), nil
 		},
-	})
+	}, nil)
 
 	marshalizer.Fail = true
 
@@ -504,7 +509,7 @@ func TestAccountsDB_GetAccountAccountNotFound(t *testing.T) {
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
 			return mock.NewAccountWrapMock(address, tracker), nil
 		},
-	})
+	}, nil)
 
 	//Step 3. call get, should return a copy of DbAccount, recover an Account object
 	recoveredAccount, err := adb.GetAccount(adr)
@@ -557,7 +562,7 @@ func TestAccountsDB_LoadCodeOkValsShouldWork(t *testing.T) {
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
 			return mock.NewAccountWrapMock(address, tracker), nil
 		},
-	})
+	}, nil)
 
 	//just search a hash. Any hash will do
 	account.SetCodeHash(adr.Bytes())
@@ -693,6 +698,103 @@ func TestAccountsDB_CommitShouldCallCommitFromTrie(t *testing.T) {
 	assert.Equal(t, 2, commitCalled)
 }
 
+func TestAccountsDB_GetDirtyTrieHashesShouldReturnTheHashesOfAllTheChangedTries(t *testing.T) {
+	t.Parallel()
+
+	account := generateAccount()
+	dataTrieStub := mock.TrieStub{
+		GetDirtyHashesCalled: func() ([][]byte, error) {
+			return [][]byte{[]byte("data trie node")}, nil
+		},
+	}
+	mainTrieStub := mock.TrieStub{
+		GetDirtyHashesCalled: func() ([][]byte, error) {
+			return [][]byte{[]byte("main trie node 1"), []byte("main trie node 2")}, nil
+		},
+	}
+
+	adb := generateAccountDBFromTrie(&mainTrieStub)
+	entry, _ := state.NewBaseJournalEntryData(account, &dataTrieStub)
+	adb.Journalize(entry)
+
+	hashes, err := adb.GetDirtyTrieHashes()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("data trie node"), []byte("main trie node 1"), []byte("main trie node 2")}, hashes)
+}
+
+func TestAccountsDB_GetDirtyTrieHashesDataTrieErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	account := generateAccount()
+	dataTrieStub := mock.TrieStub{
+		GetDirtyHashesCalled: func() ([][]byte, error) {
+			return nil, expectedErr
+		},
+	}
+
+	adb := generateAccountDBFromTrie(&mock.TrieStub{})
+	entry, _ := state.NewBaseJournalEntryData(account, &dataTrieStub)
+	adb.Journalize(entry)
+
+	hashes, err := adb.GetDirtyTrieHashes()
+	assert.Nil(t, hashes)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestAccountsDB_CommitShouldAddRootToTriePruner(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	trieStub := mock.TrieStub{
+		CommitCalled: func() error {
+			return nil
+		},
+		RootCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+	}
+
+	var addedRoot []byte
+	adb, _ := state.NewAccountsDB(&trieStub, mock.HasherMock{}, &mock.MarshalizerMock{}, &mock.AccountsFactoryStub{},
+		&mock.TriePrunerStub{
+			AddRootCalled: func(root []byte) error {
+				addedRoot = root
+				return nil
+			},
+		})
+
+	root, err := adb.Commit()
+	assert.Nil(t, err)
+	assert.Equal(t, rootHash, root)
+	assert.Equal(t, rootHash, addedRoot)
+}
+
+func TestAccountsDB_CommitTriePrunerErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("pruner failure")
+	trieStub := mock.TrieStub{
+		CommitCalled: func() error {
+			return nil
+		},
+		RootCalled: func() (i []byte, e error) {
+			return []byte("root hash"), nil
+		},
+	}
+
+	adb, _ := state.NewAccountsDB(&trieStub, mock.HasherMock{}, &mock.MarshalizerMock{}, &mock.AccountsFactoryStub{},
+		&mock.TriePrunerStub{
+			AddRootCalled: func(root []byte) error {
+				return errExpected
+			},
+		})
+
+	root, err := adb.Commit()
+	assert.Nil(t, root)
+	assert.Equal(t, errExpected, err)
+}
+
 //------- RecreateTrie
 
 func TestAccountsDB_RecreateTrieMalfunctionTrieShouldErr(t *testing.T) {
@@ -751,3 +853,91 @@ func TestAccountsDB_RecreateTrieOkValsShouldWork(t *testing.T) {
 	assert.True(t, wasCalled)
 
 }
+
+//------- RecreateReadOnly
+
+func TestAccountsDB_RecreateReadOnlyMalfunctionTrieShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("failure")
+	trieStub := &mock.TrieStub{
+		RecreateCalled: func(root []byte) (data.Trie, error) {
+			if len(root) == 0 {
+				return &mock.TrieStub{RecreateCalled: func(root []byte) (data.Trie, error) {
+					return nil, errExpected
+				}}, nil
+			}
+			return nil, errExpected
+		},
+	}
+
+	adb := generateAccountDBFromTrie(trieStub)
+	readOnlyAdb, err := adb.RecreateReadOnly([]byte("root hash"))
+
+	assert.Nil(t, readOnlyAdb)
+	assert.Equal(t, errExpected, err)
+}
+
+func TestAccountsDB_RecreateReadOnlyShouldNotChangeTheLiveState(t *testing.T) {
+	t.Parallel()
+
+	liveRoot := []byte("live root hash")
+	oldRoot := []byte("old root hash")
+	oldTrie := &mock.TrieStub{
+		RootCalled: func() ([]byte, error) {
+			return oldRoot, nil
+		},
+	}
+	trieStub := &mock.TrieStub{
+		RootCalled: func() ([]byte, error) {
+			return liveRoot, nil
+		},
+	}
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
+		if len(root) == 0 {
+			return &mock.TrieStub{RecreateCalled: func(root []byte) (data.Trie, error) {
+				assert.Equal(t, oldRoot, root)
+				return oldTrie, nil
+			}}, nil
+		}
+		return nil, errors.New("unexpected recreate")
+	}
+
+	adb := generateAccountDBFromTrie(trieStub)
+	readOnlyAdb, err := adb.RecreateReadOnly(oldRoot)
+	assert.Nil(t, err)
+
+	rootHash, _ := readOnlyAdb.RootHash()
+	assert.Equal(t, oldRoot, rootHash)
+	rootHash, _ = adb.RootHash()
+	assert.Equal(t, liveRoot, rootHash)
+}
+
+//------- WalkAccounts
+
+func TestAccountsDB_WalkAccountsNilHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := generateAccountDBFromTrie(&mock.TrieStub{})
+	err := adb.WalkAccounts(nil)
+
+	assert.Equal(t, state.ErrNilWalkHandler, err)
+}
+
+func TestAccountsDB_WalkAccountsIteratorErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("iterator error")
+	trieStub := &mock.TrieStub{
+		NewLeafIteratorCalled: func(startKey []byte) (data.TrieLeafIterator, error) {
+			return nil, errExpected
+		},
+	}
+
+	adb := generateAccountDBFromTrie(trieStub)
+	err := adb.WalkAccounts(func(accountHandler state.AccountHandler) error {
+		return nil
+	})
+
+	assert.Equal(t, errExpected, err)
+}
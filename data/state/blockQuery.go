@@ -0,0 +1,8 @@
+package state
+
+// BlockQuery selects the block whose committed state is read. The block is selected by its hash, when provided,
+// otherwise by its nonce
+type BlockQuery struct {
+	Nonce uint64
+	Hash  []byte
+}
@@ -0,0 +1,92 @@
+package state
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+// committedAccountsProvider returns the accounts found in the state of the last committed block, so the pool and the
+// interceptors never read the state changed by the block under execution. A single provider is shared by all of
+// them, so the committed state is recreated only once for every committed block
+type committedAccountsProvider struct {
+	accounts   AccountsAdapter
+	blockChain data.ChainHandler
+
+	mutCommittedState      sync.Mutex
+	committedStateRootHash []byte
+	committedState         AccountsAdapter
+}
+
+// NewCommittedAccountsProvider creates a new committed accounts provider
+func NewCommittedAccountsProvider(accounts AccountsAdapter, blockChain data.ChainHandler) (*committedAccountsProvider, error) {
+	if accounts == nil {
+		return nil, ErrNilAccountsAdapter
+	}
+	if blockChain == nil {
+		return nil, ErrNilBlockChain
+	}
+
+	return &committedAccountsProvider{
+		accounts:   accounts,
+		blockChain: blockChain,
+	}, nil
+}
+
+// GetExistingAccount returns an account found in the committed state. The reads are serialized, as the provider is
+// called concurrently by the interceptors
+func (cp *committedAccountsProvider) GetExistingAccount(address AddressContainer) (AccountHandler, error) {
+	cp.mutCommittedState.Lock()
+	defer cp.mutCommittedState.Unlock()
+
+	committedState, err := cp.getCommittedState()
+	if err != nil {
+		return nil, err
+	}
+
+	return committedState.GetExistingAccount(address)
+}
+
+// GetAccountNonce returns the nonce of an existing account. It returns an error if the account is not found in
+// the committed state
+func (cp *committedAccountsProvider) GetAccountNonce(address []byte) (uint64, error) {
+	acnt, err := cp.GetExistingAccount(NewAddress(address))
+	if err != nil {
+		return 0, err
+	}
+
+	account, ok := acnt.(*Account)
+	if !ok {
+		return 0, ErrWrongTypeAssertion
+	}
+
+	return account.Nonce, nil
+}
+
+// getCommittedState returns a read only accounts adapter over the state of the last committed block, which is
+// recreated only when a new block is committed
+func (cp *committedAccountsProvider) getCommittedState() (AccountsAdapter, error) {
+	header := cp.blockChain.GetCurrentBlockHeader()
+	if header == nil || header.IsInterfaceNil() {
+		header = cp.blockChain.GetGenesisHeader()
+	}
+	if header == nil || header.IsInterfaceNil() {
+		return nil, ErrNilBlockHeader
+	}
+
+	rootHash := header.GetRootHash()
+	if cp.committedState != nil && bytes.Equal(rootHash, cp.committedStateRootHash) {
+		return cp.committedState, nil
+	}
+
+	committedState, err := cp.accounts.RecreateReadOnly(rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	cp.committedState = committedState
+	cp.committedStateRootHash = rootHash
+
+	return committedState, nil
+}
@@ -0,0 +1,178 @@
+package state_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/stretchr/testify/assert"
+)
+
+var committedRootHash = []byte("committed root hash")
+
+// createAccountsDBWithAccountNonce creates an accounts adapter holding the account with the given nonce only in the
+// state of the committed root hash, while its current state holds a changed nonce
+func createAccountsDBWithAccountNonce(nonce uint64, found bool) *state.AccountsDB {
+	marshalizer := &mock.MarshalizerMock{}
+	buffAccount, _ := marshalizer.Marshal(&state.Account{Nonce: nonce})
+	buffChangedAccount, _ := marshalizer.Marshal(&state.Account{Nonce: nonce + 100})
+
+	committedTrie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			if !found {
+				return nil, nil
+			}
+			return buffAccount, nil
+		},
+	}
+	var emptyTrie *mock.TrieStub
+	emptyTrie = &mock.TrieStub{
+		RecreateCalled: func(root []byte) (data.Trie, error) {
+			if bytes.Equal(root, committedRootHash) {
+				return committedTrie, nil
+			}
+			return emptyTrie, nil
+		},
+	}
+	currentTrie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return buffChangedAccount, nil
+		},
+		RecreateCalled: emptyTrie.RecreateCalled,
+	}
+
+	adb, _ := state.NewAccountsDB(currentTrie, mock.HasherMock{}, marshalizer, &mock.AccountsFactoryStub{
+		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
+			return state.NewAccount(address, tracker)
+		},
+	}, nil)
+
+	return adb
+}
+
+func createCommittedBlockChain() *mock.BlockChainMock {
+	return &mock.BlockChainMock{
+		GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+			return &block.Header{RootHash: committedRootHash}
+		},
+	}
+}
+
+func TestNewCommittedAccountsProvider_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cp, err := state.NewCommittedAccountsProvider(nil, createCommittedBlockChain())
+
+	assert.Nil(t, cp)
+	assert.Equal(t, state.ErrNilAccountsAdapter, err)
+}
+
+func TestNewCommittedAccountsProvider_NilBlockChainShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cp, err := state.NewCommittedAccountsProvider(createAccountsDBWithAccountNonce(0, true), nil)
+
+	assert.Nil(t, cp)
+	assert.Equal(t, state.ErrNilBlockChain, err)
+}
+
+func TestCommittedAccountsProvider_GetAccountNonceWithoutHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := state.NewCommittedAccountsProvider(createAccountsDBWithAccountNonce(7, true), &mock.BlockChainMock{})
+
+	_, err := cp.GetAccountNonce([]byte("address"))
+
+	assert.Equal(t, state.ErrNilBlockHeader, err)
+}
+
+func TestCommittedAccountsProvider_GetAccountNonceNotFoundShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := state.NewCommittedAccountsProvider(createAccountsDBWithAccountNonce(0, false), createCommittedBlockChain())
+
+	_, err := cp.GetAccountNonce([]byte("address"))
+
+	assert.Equal(t, state.ErrAccNotFound, err)
+}
+
+func TestCommittedAccountsProvider_GetAccountNonceShouldReadTheCommittedState(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := state.NewCommittedAccountsProvider(createAccountsDBWithAccountNonce(7, true), createCommittedBlockChain())
+
+	nonce, err := cp.GetAccountNonce([]byte("address"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), nonce)
+}
+
+func TestCommittedAccountsProvider_GetAccountNonceShouldUseTheGenesisHeaderBeforeTheFirstBlock(t *testing.T) {
+	t.Parallel()
+
+	blockChain := &mock.BlockChainMock{
+		GetGenesisHeaderCalled: func() data.HeaderHandler {
+			return &block.Header{RootHash: committedRootHash}
+		},
+	}
+	cp, _ := state.NewCommittedAccountsProvider(createAccountsDBWithAccountNonce(7, true), blockChain)
+
+	nonce, err := cp.GetAccountNonce([]byte("address"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), nonce)
+}
+
+func TestCommittedAccountsProvider_GetExistingAccountShouldReadTheCommittedState(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := state.NewCommittedAccountsProvider(createAccountsDBWithAccountNonce(7, true), createCommittedBlockChain())
+
+	acnt, err := cp.GetExistingAccount(state.NewAddress([]byte("address")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), acnt.GetNonce())
+}
+
+func TestCommittedAccountsProvider_GetExistingAccountShouldRecreateTheStateOnlyForNewBlocks(t *testing.T) {
+	t.Parallel()
+
+	numRecreates := 0
+	marshalizer := &mock.MarshalizerMock{}
+	buffAccount, _ := marshalizer.Marshal(&state.Account{Nonce: 7})
+	var trie *mock.TrieStub
+	trie = &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return buffAccount, nil
+		},
+		RecreateCalled: func(root []byte) (data.Trie, error) {
+			if len(root) > 0 {
+				numRecreates++
+			}
+			return trie, nil
+		},
+	}
+	adb, _ := state.NewAccountsDB(trie, mock.HasherMock{}, marshalizer, &mock.AccountsFactoryStub{
+		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
+			return state.NewAccount(address, tracker)
+		},
+	}, nil)
+	header := &block.Header{RootHash: []byte("root hash 1")}
+	blockChain := &mock.BlockChainMock{
+		GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+			return header
+		},
+	}
+	cp, _ := state.NewCommittedAccountsProvider(adb, blockChain)
+
+	_, _ = cp.GetExistingAccount(state.NewAddress([]byte("address")))
+	_, _ = cp.GetAccountNonce([]byte("address"))
+	assert.Equal(t, 1, numRecreates)
+
+	header = &block.Header{RootHash: []byte("root hash 2")}
+	_, _ = cp.GetExistingAccount(state.NewAddress([]byte("address")))
+	assert.Equal(t, 2, numRecreates)
+}
@@ -0,0 +1,38 @@
+package state
+
+import (
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// dataTrieReferencesExtractor returns the data trie root referenced by an account saved in the main trie
+type dataTrieReferencesExtractor struct {
+	marshalizer marshal.Marshalizer
+}
+
+// NewDataTrieReferencesExtractor creates a new data trie references extractor
+func NewDataTrieReferencesExtractor(marshalizer marshal.Marshalizer) (*dataTrieReferencesExtractor, error) {
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+
+	return &dataTrieReferencesExtractor{
+		marshalizer: marshalizer,
+	}, nil
+}
+
+// ExtractReferences returns the data trie root hash of the account encoded in value. Values that do not hold
+// an account with a data trie have no references
+func (dtre *dataTrieReferencesExtractor) ExtractReferences(value []byte) [][]byte {
+	account := &dataTrieAccount{}
+	err := dtre.marshalizer.Unmarshal(account, value)
+	if err != nil || len(account.RootHash) != HashLength {
+		return make([][]byte, 0)
+	}
+
+	return [][]byte{account.RootHash}
+}
+
+// dataTrieAccount holds the data trie root hash field common to all account types
+type dataTrieAccount struct {
+	RootHash []byte
+}
@@ -0,0 +1,57 @@
+package state_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDataTrieReferencesExtractor_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dtre, err := state.NewDataTrieReferencesExtractor(nil)
+
+	assert.Nil(t, dtre)
+	assert.Equal(t, state.ErrNilMarshalizer, err)
+}
+
+func TestDataTrieReferencesExtractor_ExtractReferencesAccountWithDataTrie(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	dtre, _ := state.NewDataTrieReferencesExtractor(marshalizer)
+
+	rootHash := make([]byte, state.HashLength)
+	rootHash[0] = 1
+	buff, _ := marshalizer.Marshal(&state.Account{Nonce: 1, Balance: big.NewInt(10), RootHash: rootHash})
+
+	assert.Equal(t, [][]byte{rootHash}, dtre.ExtractReferences(buff))
+}
+
+func TestDataTrieReferencesExtractor_ExtractReferencesMetaAccountWithDataTrie(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	dtre, _ := state.NewDataTrieReferencesExtractor(marshalizer)
+
+	rootHash := make([]byte, state.HashLength)
+	rootHash[0] = 2
+	buff, _ := marshalizer.Marshal(&state.MetaAccount{Round: 1, TxCount: big.NewInt(10), RootHash: rootHash})
+
+	assert.Equal(t, [][]byte{rootHash}, dtre.ExtractReferences(buff))
+}
+
+func TestDataTrieReferencesExtractor_ExtractReferencesWithoutDataTrieShouldBeEmpty(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	dtre, _ := state.NewDataTrieReferencesExtractor(marshalizer)
+
+	buff, _ := marshalizer.Marshal(&state.Account{Nonce: 1, Balance: big.NewInt(10)})
+
+	assert.Equal(t, 0, len(dtre.ExtractReferences(buff)))
+	assert.Equal(t, 0, len(dtre.ExtractReferences([]byte("contract code"))))
+}
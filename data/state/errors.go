@@ -130,3 +130,21 @@ var ErrBech32ConvertError = errors.New("can't convert bech32 string")
 
 // ErrBech32WrongAddr signals that the string provided might not be in bech32 format
 var ErrBech32WrongAddr = errors.New("wrong bech32 string")
+
+// ErrNilWalkHandler signals that a nil accounts walk handler has been provided
+var ErrNilWalkHandler = errors.New("nil accounts walk handler")
+
+// ErrNilAccountProof signals that a nil account proof has been provided
+var ErrNilAccountProof = errors.New("nil account proof")
+
+// ErrMissingKeyProof signals that the account proof does not hold the proof of a storage key
+var ErrMissingKeyProof = errors.New("the account proof does not hold a storage key proof")
+
+// ErrDataRootHashMismatch signals that the proven data trie root hash differs from the one of the proven account
+var ErrDataRootHashMismatch = errors.New("the data trie root hash does not match the one of the account")
+
+// ErrNilBlockChain signals that a nil blockchain has been provided
+var ErrNilBlockChain = errors.New("nil blockchain")
+
+// ErrNilBlockHeader signals that the committed block header could not be found
+var ErrNilBlockHeader = errors.New("nil block header")
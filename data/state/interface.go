@@ -80,6 +80,7 @@ type AccountsAdapter interface {
 	HasAccount(addressContainer AddressContainer) (bool, error)
 	RemoveAccount(addressContainer AddressContainer) error
 	Commit() ([]byte, error)
+	Journalize(entry JournalEntry)
 	JournalLen() int
 	RevertToSnapshot(snapshot int) error
 	RootHash() ([]byte, error)
@@ -87,6 +88,10 @@ type AccountsAdapter interface {
 	PutCode(accountHandler AccountHandler, code []byte) error
 	RemoveCode(codeHash []byte) error
 	SaveDataTrie(accountHandler AccountHandler) error
+	WalkAccounts(handler func(accountHandler AccountHandler) error) error
+	Prove(rootHash []byte, key []byte) ([][]byte, error)
+	RecreateReadOnly(rootHash []byte) (AccountsAdapter, error)
+	GetDirtyTrieHashes() ([][]byte, error)
 }
 
 // JournalEntry will be used to implement different state changes to be able to easily revert them
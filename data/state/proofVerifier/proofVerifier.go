@@ -0,0 +1,59 @@
+package proofVerifier
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// VerifyAccountProof checks that the account proof links its root hash to the account stored at its address and
+// returns the proven account. The caller should make sure, independently of the node that provided the proof,
+// that the root hash is the one of the header with the given nonce and hash
+func VerifyAccountProof(
+	accountProof *state.AccountProof,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (*state.Account, error) {
+	if accountProof == nil {
+		return nil, state.ErrNilAccountProof
+	}
+
+	buff, err := trie.VerifyProofAndGetValue(accountProof.RootHash, accountProof.Address, accountProof.Proof,
+		marshalizer, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &state.Account{}
+	err = marshalizer.Unmarshal(account, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// VerifyAccountKeyProof checks the account proof and the proof of the storage key in the account data trie and
+// returns the value stored at that key
+func VerifyAccountKeyProof(
+	accountProof *state.AccountProof,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) ([]byte, error) {
+	account, err := VerifyAccountProof(accountProof, marshalizer, hasher)
+	if err != nil {
+		return nil, err
+	}
+	if len(accountProof.Key) == 0 {
+		return nil, state.ErrMissingKeyProof
+	}
+	if !bytes.Equal(account.RootHash, accountProof.DataRootHash) {
+		return nil, state.ErrDataRootHashMismatch
+	}
+
+	return trie.VerifyProofAndGetValue(accountProof.DataRootHash, accountProof.Key, accountProof.DataProof,
+		marshalizer, hasher)
+}
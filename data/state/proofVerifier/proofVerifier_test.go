@@ -0,0 +1,116 @@
+package proofVerifier_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/state/proofVerifier"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+var marshalizer = &mock.MarshalizerMock{}
+var hasher = mock.HasherMock{}
+
+func createAccountProof(t *testing.T) *state.AccountProof {
+	db, _ := mock.NewMemDbMock()
+	tr, _ := trie.NewTrie(db, marshalizer, hasher)
+	adb, _ := state.NewAccountsDB(tr, hasher, marshalizer, factory.NewAccountCreator(), nil)
+
+	address := []byte("12345678901234567890123456789012")
+	acc, _ := adb.GetAccountWithJournal(state.NewAddress(address))
+	_ = acc.(*state.Account).SetBalanceWithJournal(big.NewInt(1000))
+	acc.DataTrieTracker().SaveKeyValue([]byte("key"), []byte("value"))
+	_ = adb.SaveDataTrie(acc)
+
+	otherAcc, _ := adb.GetAccountWithJournal(state.NewAddress([]byte("22345678901234567890123456789012")))
+	_ = otherAcc.(*state.Account).SetBalanceWithJournal(big.NewInt(1))
+
+	rootHash, err := adb.Commit()
+	assert.Nil(t, err)
+
+	proof, err := adb.Prove(rootHash, address)
+	assert.Nil(t, err)
+	dataProof, err := adb.Prove(acc.GetRootHash(), []byte("key"))
+	assert.Nil(t, err)
+
+	return &state.AccountProof{
+		RootHash:     rootHash,
+		Address:      address,
+		Proof:        proof,
+		Key:          []byte("key"),
+		DataRootHash: acc.GetRootHash(),
+		DataProof:    dataProof,
+	}
+}
+
+func TestVerifyAccountProof_NilProofShouldErr(t *testing.T) {
+	t.Parallel()
+
+	account, err := proofVerifier.VerifyAccountProof(nil, marshalizer, hasher)
+
+	assert.Nil(t, account)
+	assert.Equal(t, state.ErrNilAccountProof, err)
+}
+
+func TestVerifyAccountProof_ValidProofShouldReturnTheAccount(t *testing.T) {
+	t.Parallel()
+
+	accountProof := createAccountProof(t)
+
+	account, err := proofVerifier.VerifyAccountProof(accountProof, marshalizer, hasher)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), account.Balance)
+	assert.Equal(t, accountProof.DataRootHash, account.RootHash)
+}
+
+func TestVerifyAccountProof_TamperedRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	accountProof := createAccountProof(t)
+	accountProof.RootHash = hasher.Compute("other root")
+
+	account, err := proofVerifier.VerifyAccountProof(accountProof, marshalizer, hasher)
+
+	assert.Nil(t, account)
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
+
+func TestVerifyAccountKeyProof_ValidProofShouldReturnTheValue(t *testing.T) {
+	t.Parallel()
+
+	accountProof := createAccountProof(t)
+
+	val, err := proofVerifier.VerifyAccountKeyProof(accountProof, marshalizer, hasher)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), val)
+}
+
+func TestVerifyAccountKeyProof_MissingKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	accountProof := createAccountProof(t)
+	accountProof.Key = nil
+
+	val, err := proofVerifier.VerifyAccountKeyProof(accountProof, marshalizer, hasher)
+
+	assert.Nil(t, val)
+	assert.Equal(t, state.ErrMissingKeyProof, err)
+}
+
+func TestVerifyAccountKeyProof_DataRootHashMismatchShouldErr(t *testing.T) {
+	t.Parallel()
+
+	accountProof := createAccountProof(t)
+	accountProof.DataRootHash = hasher.Compute("other data root")
+
+	val, err := proofVerifier.VerifyAccountKeyProof(accountProof, marshalizer, hasher)
+
+	assert.Nil(t, val)
+	assert.Equal(t, state.ErrDataRootHashMismatch, err)
+}
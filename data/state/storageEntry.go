@@ -0,0 +1,7 @@
+package state
+
+// StorageEntry holds a key from the data trie of an account and the value stored at that key
+type StorageEntry struct {
+	Key   []byte
+	Value []byte
+}
@@ -12,12 +12,12 @@ import (
 
 type TransactionCapn C.Struct
 
-func NewTransactionCapn(s *C.Segment) TransactionCapn { return TransactionCapn(s.NewStruct(24, 6)) }
+func NewTransactionCapn(s *C.Segment) TransactionCapn { return TransactionCapn(s.NewStruct(32, 7)) }
 func NewRootTransactionCapn(s *C.Segment) TransactionCapn {
-	return TransactionCapn(s.NewRootStruct(24, 6))
+	return TransactionCapn(s.NewRootStruct(32, 7))
 }
 func AutoNewTransactionCapn(s *C.Segment) TransactionCapn {
-	return TransactionCapn(s.NewStructAR(24, 6))
+	return TransactionCapn(s.NewStructAR(32, 7))
 }
 func ReadRootTransactionCapn(s *C.Segment) TransactionCapn {
 	return TransactionCapn(s.Root(0).ToStruct())
@@ -41,6 +41,10 @@ func (s TransactionCapn) Signature() []byte     { return C.Struct(s).GetObject(4
 func (s TransactionCapn) SetSignature(v []byte) { C.Struct(s).SetObject(4, s.Segment.NewData(v)) }
 func (s TransactionCapn) Challenge() []byte     { return C.Struct(s).GetObject(5).ToData() }
 func (s TransactionCapn) SetChallenge(v []byte) { C.Struct(s).SetObject(5, s.Segment.NewData(v)) }
+func (s TransactionCapn) ChainID() []byte       { return C.Struct(s).GetObject(6).ToData() }
+func (s TransactionCapn) SetChainID(v []byte)   { C.Struct(s).SetObject(6, s.Segment.NewData(v)) }
+func (s TransactionCapn) Version() uint32       { return C.Struct(s).Get32(24) }
+func (s TransactionCapn) SetVersion(v uint32)   { C.Struct(s).Set32(24, v) }
 func (s TransactionCapn) WriteJSON(w io.Writer) error {
 	b := bufio.NewWriter(w)
 	var err error
@@ -217,6 +221,44 @@ func (s TransactionCapn) WriteJSON(w io.Writer) error {
 			return err
 		}
 	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"chainID\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ChainID()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"version\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Version()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte('}')
 	if err != nil {
 		return err
@@ -405,6 +447,44 @@ func (s TransactionCapn) WriteCapLit(w io.Writer) error {
 			return err
 		}
 	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("chainID = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ChainID()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("version = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Version()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte(')')
 	if err != nil {
 		return err
@@ -421,7 +501,7 @@ func (s TransactionCapn) MarshalCapLit() ([]byte, error) {
 type TransactionCapn_List C.PointerList
 
 func NewTransactionCapnList(s *C.Segment, sz int) TransactionCapn_List {
-	return TransactionCapn_List(s.NewCompositeList(24, 6, sz))
+	return TransactionCapn_List(s.NewCompositeList(32, 7, sz))
 }
 func (s TransactionCapn_List) Len() int { return C.PointerList(s).Len() }
 func (s TransactionCapn_List) At(i int) TransactionCapn {
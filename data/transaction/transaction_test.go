@@ -2,6 +2,7 @@ package transaction_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"math/big"
 	"testing"
 
@@ -20,6 +21,8 @@ func TestTransaction_SaveLoad(t *testing.T) {
 		Data:      "tx_data",
 		Signature: []byte("signature"),
 		Challenge: []byte("challange"),
+		ChainID:   []byte("chain ID"),
+		Version:   uint32(1),
 	}
 
 	var b bytes.Buffer
@@ -106,3 +109,16 @@ func TestTransaction_SetValue(t *testing.T) {
 
 	assert.Equal(t, value, tx.Value)
 }
+
+func TestTransaction_JsonWithoutChainIDShouldOmitChainIDAndVersion(t *testing.T) {
+	tx := transaction.Transaction{
+		Nonce: uint64(1),
+		Value: big.NewInt(1),
+	}
+
+	buff, err := json.Marshal(&tx)
+
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(buff, []byte("chainID")))
+	assert.False(t, bytes.Contains(buff, []byte("version")))
+}
@@ -273,6 +273,31 @@ func (bn *branchNode) commit(level byte, db data.DBWriteCacher, marshalizer mars
 	return nil
 }
 
+func (bn *branchNode) getDirtyHashes() ([][]byte, error) {
+	err := bn.isEmptyOrNil()
+	if err != nil {
+		return nil, err
+	}
+
+	dirtyHashes := make([][]byte, 0)
+	if !bn.dirty {
+		return dirtyHashes, nil
+	}
+	for i := range bn.children {
+		if bn.children[i] == nil {
+			continue
+		}
+
+		hashes, err := bn.children[i].getDirtyHashes()
+		if err != nil {
+			return nil, err
+		}
+		dirtyHashes = append(dirtyHashes, hashes...)
+	}
+
+	return append(dirtyHashes, bn.hash), nil
+}
+
 func (bn *branchNode) getEncodedNode(marshalizer marshal.Marshalizer) ([]byte, error) {
 	err := bn.isEmptyOrNil()
 	if err != nil {
@@ -33,3 +33,12 @@ var ErrEmptyNode = errors.New("the node is empty")
 
 // ErrNilNode is raised when we reach a nil node
 var ErrNilNode = errors.New("the node is nil")
+
+// ErrNilLeafReferencesExtractor is raised when a nil leaf references extractor is provided
+var ErrNilLeafReferencesExtractor = errors.New("nil leaf references extractor")
+
+// ErrInvalidNumRootsToKeep is raised when the pruning storage is asked to keep no roots
+var ErrInvalidNumRootsToKeep = errors.New("the number of roots to keep should be greater than 0")
+
+// ErrInvalidProof is raised when the proof nodes do not link the root hash to the leaf stored at the proven key
+var ErrInvalidProof = errors.New("invalid merkle proof")
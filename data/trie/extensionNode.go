@@ -194,6 +194,26 @@ func (en *extensionNode) commit(level byte, db data.DBWriteCacher, marshalizer m
 	return nil
 }
 
+func (en *extensionNode) getDirtyHashes() ([][]byte, error) {
+	err := en.isEmptyOrNil()
+	if err != nil {
+		return nil, err
+	}
+
+	dirtyHashes := make([][]byte, 0)
+	if !en.dirty {
+		return dirtyHashes, nil
+	}
+	if en.child != nil {
+		dirtyHashes, err = en.child.getDirtyHashes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(dirtyHashes, en.hash), nil
+}
+
 func (en *extensionNode) getEncodedNode(marshalizer marshal.Marshalizer) ([]byte, error) {
 	err := en.isEmptyOrNil()
 	if err != nil {
@@ -0,0 +1,142 @@
+package trie
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// iteratedNode is a node waiting to be visited by the leaf iterator. The node is loaded from the database
+// only when it is visited, if it was not already resolved in memory
+type iteratedNode struct {
+	n      node
+	hash   []byte
+	prefix []byte
+}
+
+// leafIterator walks the leaves of a trie in the ascending order of their keys, starting with the first key
+// greater than or equal to the start key. The trie should not be modified while it is iterated
+type leafIterator struct {
+	stack       []iteratedNode
+	startHex    []byte
+	startKey    []byte
+	db          data.DBWriteCacher
+	marshalizer marshal.Marshalizer
+
+	key   []byte
+	value []byte
+	err   error
+}
+
+// NewLeafIterator returns an iterator over the trie leaves, starting from the given key. A nil start key
+// iterates over all the leaves
+func (tr *patriciaMerkleTrie) NewLeafIterator(startKey []byte) (data.TrieLeafIterator, error) {
+	tr.mutOperation.RLock()
+	defer tr.mutOperation.RUnlock()
+
+	it := &leafIterator{
+		stack:       make([]iteratedNode, 0),
+		startHex:    keyBytesToHex(startKey),
+		startKey:    startKey,
+		db:          tr.db,
+		marshalizer: tr.marshalizer,
+	}
+
+	if tr.root != nil {
+		it.stack = append(it.stack, iteratedNode{n: tr.root, prefix: make([]byte, 0)})
+	}
+
+	return it, nil
+}
+
+// Next moves the iterator to the next leaf. It returns false when there are no more leaves or an error occurred
+func (it *leafIterator) Next() bool {
+	it.key = nil
+	it.value = nil
+
+	for len(it.stack) > 0 && it.err == nil {
+		current := it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+
+		n := current.n
+		if n == nil {
+			n, it.err = getNodeFromDBAndDecode(current.hash, it.db, it.marshalizer)
+			if it.err != nil {
+				return false
+			}
+		}
+
+		switch n := n.(type) {
+		case *leafNode:
+			key := hexToKeyBytes(concat(current.prefix, n.Key...))
+			if bytes.Compare(key, it.startKey) < 0 {
+				continue
+			}
+
+			it.key = key
+			it.value = n.Value
+			return true
+		case *extensionNode:
+			it.push(n.child, n.EncodedChild, concat(current.prefix, n.Key...))
+		case *branchNode:
+			// the children are pushed in reverse order so that the smallest key is visited first. The
+			// terminator position holds the key ending at this branch, which is smaller than all the others
+			for i := hexTerminator - 1; i >= 0; i-- {
+				it.push(n.children[i], n.EncodedChildren[i], concat(current.prefix, byte(i)))
+			}
+			it.push(n.children[hexTerminator], n.EncodedChildren[hexTerminator], concat(current.prefix, hexTerminator))
+		}
+	}
+
+	return false
+}
+
+func (it *leafIterator) push(n node, hash []byte, prefix []byte) {
+	if n == nil && len(hash) == 0 {
+		return
+	}
+	if compareHexPrefixes(prefix, it.startHex) < 0 {
+		return
+	}
+
+	it.stack = append(it.stack, iteratedNode{n: n, hash: hash, prefix: prefix})
+}
+
+// Key returns the key of the current leaf
+func (it *leafIterator) Key() []byte {
+	return it.key
+}
+
+// Value returns the value of the current leaf
+func (it *leafIterator) Value() []byte {
+	return it.value
+}
+
+// Error returns the error which stopped the iteration, if any
+func (it *leafIterator) Error() error {
+	return it.err
+}
+
+// compareHexPrefixes compares the given prefix with the same length prefix of the hex key. The terminator is
+// considered smaller than any other nibble, as a key is smaller than all the keys it prefixes
+func compareHexPrefixes(prefix []byte, hexKey []byte) int {
+	for i := 0; i < len(prefix) && i < len(hexKey); i++ {
+		a, b := int(prefix[i]), int(hexKey[i])
+		if a == hexTerminator {
+			a = -1
+		}
+		if b == hexTerminator {
+			b = -1
+		}
+
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,117 @@
+package trie_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+func getAllLeaves(it data.TrieLeafIterator) ([][]byte, [][]byte) {
+	keys := make([][]byte, 0)
+	values := make([][]byte, 0)
+	for it.Next() {
+		keys = append(keys, it.Key())
+		values = append(values, it.Value())
+	}
+
+	return keys, values
+}
+
+func TestPatriciaMerkleTrie_NewLeafIteratorEmptyTrieShouldNotReturnLeaves(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	tr, _ := trie.NewTrie(db, marshalizer, hasher)
+
+	it, err := tr.NewLeafIterator(nil)
+	assert.Nil(t, err)
+
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Error())
+}
+
+func TestPatriciaMerkleTrie_NewLeafIteratorShouldReturnLeavesOrderedByKey(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Update([]byte("do"), []byte("verb"))
+	_ = tr.Update([]byte("horse"), []byte("stallion"))
+
+	it, _ := tr.NewLeafIterator(nil)
+	keys, values := getAllLeaves(it)
+
+	assert.Nil(t, it.Error())
+	assert.Equal(t, [][]byte{[]byte("do"), []byte("doe"), []byte("dog"), []byte("dogglesworth"), []byte("horse")}, keys)
+	assert.Equal(t, [][]byte{[]byte("verb"), []byte("reindeer"), []byte("puppy"), []byte("cat"), []byte("stallion")}, values)
+}
+
+func TestPatriciaMerkleTrie_NewLeafIteratorShouldStartFromTheGivenKey(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Update([]byte("do"), []byte("verb"))
+	_ = tr.Update([]byte("horse"), []byte("stallion"))
+
+	it, _ := tr.NewLeafIterator([]byte("dog"))
+	keys, _ := getAllLeaves(it)
+	assert.Equal(t, [][]byte{[]byte("dog"), []byte("dogglesworth"), []byte("horse")}, keys)
+
+	it, _ = tr.NewLeafIterator([]byte("doge"))
+	keys, _ = getAllLeaves(it)
+	assert.Equal(t, [][]byte{[]byte("dogglesworth"), []byte("horse")}, keys)
+
+	it, _ = tr.NewLeafIterator([]byte("z"))
+	keys, _ = getAllLeaves(it)
+	assert.Equal(t, 0, len(keys))
+}
+
+func TestPatriciaMerkleTrie_NewLeafIteratorOnCommittedTrieShouldLoadNodesFromDB(t *testing.T) {
+	t.Parallel()
+
+	tr, values := initTrieMultipleValues(100)
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	recreatedTrie, _ := tr.Recreate(rootHash)
+
+	sort.Slice(values, func(i, j int) bool {
+		return bytes.Compare(values[i], values[j]) < 0
+	})
+
+	it, _ := recreatedTrie.NewLeafIterator(nil)
+	keys, leafValues := getAllLeaves(it)
+
+	assert.Nil(t, it.Error())
+	assert.Equal(t, values, keys)
+	assert.Equal(t, values, leafValues)
+
+	it, _ = recreatedTrie.NewLeafIterator(values[50])
+	keys, _ = getAllLeaves(it)
+	assert.Equal(t, values[50:], keys)
+}
+
+func TestPatriciaMerkleTrie_NewLeafIteratorMissingNodeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	tr, _ := trie.NewTrie(db, marshalizer, hasher)
+	_ = tr.Update([]byte("doe"), []byte("reindeer"))
+	_ = tr.Update([]byte("dog"), []byte("puppy"))
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+
+	incompleteDb, _ := mock.NewMemDbMock()
+	encRoot, _ := db.Get(rootHash)
+	_ = incompleteDb.Put(rootHash, encRoot)
+	emptyTrie, _ := trie.NewTrie(incompleteDb, marshalizer, hasher)
+	incompleteTrie, _ := emptyTrie.Recreate(rootHash)
+
+	it, _ := incompleteTrie.NewLeafIterator(nil)
+
+	assert.False(t, it.Next())
+	assert.NotNil(t, it.Error())
+}
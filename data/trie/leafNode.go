@@ -128,6 +128,20 @@ func (ln *leafNode) commit(level byte, db data.DBWriteCacher, marshalizer marsha
 	return encodeNodeAndCommitToDB(ln, db, marshalizer, hasher)
 }
 
+func (ln *leafNode) getDirtyHashes() ([][]byte, error) {
+	err := ln.isEmptyOrNil()
+	if err != nil {
+		return nil, err
+	}
+
+	dirtyHashes := make([][]byte, 0)
+	if !ln.dirty {
+		return dirtyHashes, nil
+	}
+
+	return append(dirtyHashes, ln.hash), nil
+}
+
 func (ln *leafNode) getEncodedNode(marshalizer marshal.Marshalizer) ([]byte, error) {
 	err := ln.isEmptyOrNil()
 	if err != nil {
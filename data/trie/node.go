@@ -26,6 +26,7 @@ type node interface {
 	isDirty() bool
 	getEncodedNode(marshal.Marshalizer) ([]byte, error)
 	commit(level byte, dbw data.DBWriteCacher, marshalizer marshal.Marshalizer, hasher hashing.Hasher) error
+	getDirtyHashes() ([][]byte, error) // the hashes of the dirty nodes should be already set
 	resolveCollapsed(pos byte, dbw data.DBWriteCacher, marshalizer marshal.Marshalizer) error
 	hashNode(marshalizer marshal.Marshalizer, hasher hashing.Hasher) ([]byte, error)
 	hashChildren(marshalizer marshal.Marshalizer, hasher hashing.Hasher) error
@@ -183,6 +184,26 @@ func decodeNode(encNode []byte, marshalizer marshal.Marshalizer) (node, error) {
 	return node, nil
 }
 
+// DecodeNodeReferences decodes the given trie node and returns the hashes of its children. For a leaf node,
+// the held value is also returned
+func DecodeNodeReferences(encNode []byte, marshalizer marshal.Marshalizer) ([][]byte, []byte, error) {
+	if marshalizer == nil {
+		return nil, nil, ErrNilMarshalizer
+	}
+
+	n, err := decodeNode(encNode, marshalizer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var leafValue []byte
+	if ln, ok := n.(*leafNode); ok {
+		leafValue = ln.Value
+	}
+
+	return getChildrenHashes(n), leafValue, nil
+}
+
 func getEmptyNodeOfType(t byte) (node, error) {
 	var decNode node
 	switch t {
@@ -215,6 +236,20 @@ func keyBytesToHex(str []byte) []byte {
 	return nibbles
 }
 
+// hexToKeyBytes transforms hex nibbles, ending with the terminator, back into key bytes
+func hexToKeyBytes(hex []byte) []byte {
+	if len(hex) > 0 && hex[len(hex)-1] == hexTerminator {
+		hex = hex[:len(hex)-1]
+	}
+
+	key := make([]byte, len(hex)/2)
+	for i := range key {
+		key[i] = hex[i*2]*hexTerminator + hex[i*2+1]
+	}
+
+	return key
+}
+
 // prefixLen returns the length of the common prefix of a and b.
 func prefixLen(a, b []byte) int {
 	i := 0
@@ -429,3 +429,35 @@ func TestPrefixLen(t *testing.T) {
 		assert.Equal(t, test[i].length, prefixLen(test[i].a, test[i].b))
 	}
 }
+
+func TestDecodeNodeReferences_BranchNodeShouldReturnChildrenHashes(t *testing.T) {
+	t.Parallel()
+	marsh, _ := getTestMarshAndHasher()
+	_, collapsedBn := getBnAndCollapsedBn()
+	encNode, _ := collapsedBn.getEncodedNode(marsh)
+
+	childrenHashes, leafValue, err := DecodeNodeReferences(encNode, marsh)
+	assert.Nil(t, err)
+	assert.Nil(t, leafValue)
+	assert.Equal(t, [][]byte{collapsedBn.EncodedChildren[2], collapsedBn.EncodedChildren[6], collapsedBn.EncodedChildren[13]}, childrenHashes)
+}
+
+func TestDecodeNodeReferences_LeafNodeShouldReturnValue(t *testing.T) {
+	t.Parallel()
+	marsh, _ := getTestMarshAndHasher()
+	ln := getLn()
+	encNode, _ := ln.getEncodedNode(marsh)
+
+	childrenHashes, leafValue, err := DecodeNodeReferences(encNode, marsh)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(childrenHashes))
+	assert.Equal(t, []byte("dog"), leafValue)
+}
+
+func TestDecodeNodeReferences_InvalidEncodingShouldErr(t *testing.T) {
+	t.Parallel()
+	marsh, _ := getTestMarshAndHasher()
+
+	_, _, err := DecodeNodeReferences(nil, marsh)
+	assert.Equal(t, ErrInvalidEncoding, err)
+}
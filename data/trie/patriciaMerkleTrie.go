@@ -226,6 +226,25 @@ func (tr *patriciaMerkleTrie) Commit() error {
 	return nil
 }
 
+// GetDirtyHashes returns the hashes of the nodes which will be written in the database by the next commit
+func (tr *patriciaMerkleTrie) GetDirtyHashes() ([][]byte, error) {
+	tr.mutOperation.Lock()
+	defer tr.mutOperation.Unlock()
+
+	if tr.root == nil {
+		return make([][]byte, 0), nil
+	}
+	if tr.root.isCollapsed() {
+		return make([][]byte, 0), nil
+	}
+	err := tr.root.setRootHash(tr.marshalizer, tr.hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr.root.getDirtyHashes()
+}
+
 // Recreate returns a new trie that has the given root hash and database
 func (tr *patriciaMerkleTrie) Recreate(root []byte) (data.Trie, error) {
 	tr.mutOperation.Lock()
@@ -255,6 +255,63 @@ func TestPatriciaMerkleTree_CommitEmptyRoot(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestPatriciaMerkleTree_GetDirtyHashesShouldReturnTheNodesWrittenByCommit(t *testing.T) {
+	db, _ := mock.NewMemDbMock()
+	tr, _ := trie.NewTrie(db, marshalizer, hasher)
+	_ = tr.Update([]byte("doe"), []byte("reindeer"))
+	_ = tr.Update([]byte("dog"), []byte("puppy"))
+	_ = tr.Update([]byte("dogglesworth"), []byte("cat"))
+
+	hashes, err := tr.GetDirtyHashes()
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(hashes))
+	for _, hash := range hashes {
+		_, err = db.Get(hash)
+		assert.NotNil(t, err)
+	}
+
+	_ = tr.Commit()
+	for _, hash := range hashes {
+		_, err = db.Get(hash)
+		assert.Nil(t, err)
+	}
+	root, _ := tr.Root()
+	assert.Contains(t, hashes, root)
+}
+
+func TestPatriciaMerkleTree_GetDirtyHashesAfterUpdateShouldReturnOnlyTheChangedNodes(t *testing.T) {
+	db, _ := mock.NewMemDbMock()
+	tr, _ := trie.NewTrie(db, marshalizer, hasher)
+	_ = tr.Update([]byte("doe"), []byte("reindeer"))
+	_ = tr.Update([]byte("dog"), []byte("puppy"))
+	_ = tr.Update([]byte("dogglesworth"), []byte("cat"))
+	_ = tr.Commit()
+
+	hashes, err := tr.GetDirtyHashes()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(hashes))
+
+	_ = tr.Update([]byte("doe"), []byte("deer"))
+	hashes, err = tr.GetDirtyHashes()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(hashes))
+
+	_ = tr.Commit()
+	for _, hash := range hashes {
+		_, err = db.Get(hash)
+		assert.Nil(t, err)
+	}
+}
+
+func TestPatriciaMerkleTree_GetDirtyHashesEmptyTrieShouldReturnEmpty(t *testing.T) {
+	db, _ := mock.NewMemDbMock()
+	tr, _ := trie.NewTrie(db, marshalizer, hasher)
+
+	hashes, err := tr.GetDirtyHashes()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(hashes))
+}
+
 func TestPatriciaMerkleTree_GetAfterCommit(t *testing.T) {
 	tr := initTrie()
 
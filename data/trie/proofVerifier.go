@@ -0,0 +1,61 @@
+package trie
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+// VerifyProofAndGetValue checks that the proof nodes, as returned by Prove, link the root hash to the leaf stored
+// at the given key and returns the value held by that leaf. It does not need access to the trie storage, so the
+// proofs can be verified offline
+func VerifyProofAndGetValue(
+	rootHash []byte,
+	key []byte,
+	proof [][]byte,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) ([]byte, error) {
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+
+	wantHash := rootHash
+	hexKey := keyBytesToHex(key)
+	for _, encNode := range proof {
+		if !bytes.Equal(hasher.Compute(string(encNode)), wantHash) {
+			return nil, ErrInvalidProof
+		}
+
+		n, err := decodeNode(encNode, marshalizer)
+		if err != nil {
+			return nil, err
+		}
+
+		switch n := n.(type) {
+		case *extensionNode:
+			if !bytes.HasPrefix(hexKey, n.Key) {
+				return nil, ErrInvalidProof
+			}
+			hexKey = hexKey[len(n.Key):]
+			wantHash = n.EncodedChild
+		case *branchNode:
+			if len(hexKey) == 0 || childPosOutOfRange(hexKey[firstByte]) {
+				return nil, ErrInvalidProof
+			}
+			wantHash = n.EncodedChildren[hexKey[firstByte]]
+			hexKey = hexKey[1:]
+		case *leafNode:
+			if !bytes.Equal(hexKey, n.Key) {
+				return nil, ErrInvalidProof
+			}
+			return n.Value, nil
+		}
+	}
+
+	return nil, ErrInvalidProof
+}
@@ -0,0 +1,84 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyProofAndGetValue_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	val, err := trie.VerifyProofAndGetValue(nil, []byte("dog"), nil, nil, hasher)
+
+	assert.Nil(t, val)
+	assert.Equal(t, trie.ErrNilMarshalizer, err)
+}
+
+func TestVerifyProofAndGetValue_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	val, err := trie.VerifyProofAndGetValue(nil, []byte("dog"), nil, marshalizer, nil)
+
+	assert.Nil(t, val)
+	assert.Equal(t, trie.ErrNilHasher, err)
+}
+
+func TestVerifyProofAndGetValue_ValidProofShouldReturnTheValue(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+
+	for key, expectedValue := range map[string]string{"doe": "reindeer", "dog": "puppy", "dogglesworth": "cat"} {
+		proof, err := tr.Prove([]byte(key))
+		assert.Nil(t, err)
+
+		val, err := trie.VerifyProofAndGetValue(rootHash, []byte(key), proof, marshalizer, hasher)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(expectedValue), val)
+	}
+}
+
+func TestVerifyProofAndGetValue_WrongKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	proof, _ := tr.Prove([]byte("dog"))
+
+	val, err := trie.VerifyProofAndGetValue(rootHash, []byte("doe"), proof, marshalizer, hasher)
+
+	assert.Nil(t, val)
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
+
+func TestVerifyProofAndGetValue_WrongRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Commit()
+	proof, _ := tr.Prove([]byte("dog"))
+
+	val, err := trie.VerifyProofAndGetValue([]byte("wrong root hash"), []byte("dog"), proof, marshalizer, hasher)
+
+	assert.Nil(t, val)
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
+
+func TestVerifyProofAndGetValue_IncompleteProofShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr := initTrie()
+	_ = tr.Commit()
+	rootHash, _ := tr.Root()
+	proof, _ := tr.Prove([]byte("dog"))
+
+	val, err := trie.VerifyProofAndGetValue(rootHash, []byte("dog"), proof[:len(proof)-1], marshalizer, hasher)
+
+	assert.Nil(t, val)
+	assert.Equal(t, trie.ErrInvalidProof, err)
+}
@@ -0,0 +1,349 @@
+package trie
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+)
+
+var log = logger.DefaultLogger()
+
+const refCountPrefix = "refCount_"
+
+var rootsKey = []byte("pruningStorageRoots")
+
+type refCount struct {
+	count      uint64
+	references [][]byte
+}
+
+// pruningStorage wraps the storage used by the tries and keeps a reference counter for every node it writes.
+// The counter of a node is incremented by each stored parent, by each leaf referencing it as a trie root and by each
+// committed root it represents. When a root becomes older than the last numRootsToKeep roots, its counter is
+// decremented in the background and the nodes that are no longer referenced are removed from the storage.
+// Nodes written before pruning was enabled have no counter and are never removed.
+type pruningStorage struct {
+	db                  data.DBRemoveCacher
+	marshalizer         marshal.Marshalizer
+	referencesExtractor data.LeafReferencesExtractor
+	numRootsToKeep      int
+
+	mutOperation sync.Mutex
+	roots        [][]byte
+	pinnedNodes  map[string]uint32
+	chanPrune    chan []byte
+}
+
+// NewPruningStorage creates a trie storage that removes the nodes unreachable from the last numRootsToKeep roots
+func NewPruningStorage(
+	db data.DBRemoveCacher,
+	marshalizer marshal.Marshalizer,
+	referencesExtractor data.LeafReferencesExtractor,
+	numRootsToKeep uint32,
+) (*pruningStorage, error) {
+	if db == nil {
+		return nil, ErrNilDatabase
+	}
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if referencesExtractor == nil {
+		return nil, ErrNilLeafReferencesExtractor
+	}
+	if numRootsToKeep == 0 {
+		return nil, ErrInvalidNumRootsToKeep
+	}
+
+	ps := &pruningStorage{
+		db:                  db,
+		marshalizer:         marshalizer,
+		referencesExtractor: referencesExtractor,
+		numRootsToKeep:      int(numRootsToKeep),
+		roots:               make([][]byte, 0),
+		pinnedNodes:         make(map[string]uint32),
+		chanPrune:           make(chan []byte, numRootsToKeep),
+	}
+
+	encRoots, err := db.Get(rootsKey)
+	if err == nil {
+		ps.roots, err = decodeByteSlices(encRoots)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	go ps.pruneEvictedRoots()
+
+	return ps, nil
+}
+
+// Put stores a trie node. The reference counters of the node's children and of the tries referenced by a leaf are
+// incremented only when the node is written for the first time
+func (ps *pruningStorage) Put(key, val []byte) error {
+	ps.mutOperation.Lock()
+	defer ps.mutOperation.Unlock()
+
+	_, err := ps.db.Get(key)
+	if err == nil {
+		// the node is kept until the next root is added, as the parent that will reference it is not yet written
+		return ps.pinIfTracked(key)
+	}
+
+	n, err := decodeNode(val, ps.marshalizer)
+	if err != nil {
+		return err
+	}
+
+	leafReferences := make([][]byte, 0)
+	if ln, ok := n.(*leafNode); ok {
+		for _, reference := range ps.referencesExtractor.ExtractReferences(ln.Value) {
+			incremented, err := ps.increment(reference)
+			if err != nil {
+				return err
+			}
+			if incremented {
+				leafReferences = append(leafReferences, reference)
+			}
+		}
+	}
+
+	for _, childHash := range getChildrenHashes(n) {
+		_, err = ps.increment(childHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ps.db.Put(key, val)
+	if err != nil {
+		return err
+	}
+
+	return ps.putRefCount(key, &refCount{references: leafReferences})
+}
+
+// Get returns the node stored for the given key
+func (ps *pruningStorage) Get(key []byte) ([]byte, error) {
+	return ps.db.Get(key)
+}
+
+// AddRoot marks the given root as committed. When more than numRootsToKeep roots were added, the oldest one
+// is scheduled for pruning
+func (ps *pruningStorage) AddRoot(rootHash []byte) error {
+	ps.mutOperation.Lock()
+
+	_, err := ps.increment(rootHash)
+	if err != nil {
+		ps.mutOperation.Unlock()
+		return err
+	}
+
+	err = ps.releasePinnedNodes()
+	if err != nil {
+		ps.mutOperation.Unlock()
+		return err
+	}
+
+	ps.roots = append(ps.roots, rootHash)
+	evictedRoots := make([][]byte, 0)
+	for len(ps.roots) > ps.numRootsToKeep {
+		evictedRoots = append(evictedRoots, ps.roots[0])
+		ps.roots = ps.roots[1:]
+	}
+
+	err = core.Overwrite(ps.db, rootsKey, encodeByteSlices(ps.roots))
+	ps.mutOperation.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, evictedRoot := range evictedRoots {
+		ps.chanPrune <- evictedRoot
+	}
+
+	return nil
+}
+
+func (ps *pruningStorage) pruneEvictedRoots() {
+	for rootHash := range ps.chanPrune {
+		ps.mutOperation.Lock()
+		err := ps.decrement(rootHash)
+		ps.mutOperation.Unlock()
+
+		if err != nil {
+			log.Error("trie pruning: " + err.Error())
+		}
+	}
+}
+
+func (ps *pruningStorage) pinIfTracked(key []byte) error {
+	incremented, err := ps.increment(key)
+	if err != nil {
+		return err
+	}
+	if incremented {
+		ps.pinnedNodes[string(key)]++
+	}
+
+	return nil
+}
+
+func (ps *pruningStorage) releasePinnedNodes() error {
+	for key, pins := range ps.pinnedNodes {
+		for i := uint32(0); i < pins; i++ {
+			err := ps.decrement([]byte(key))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	ps.pinnedNodes = make(map[string]uint32)
+
+	return nil
+}
+
+// increment returns false if the node has no reference counter, thus it is not tracked
+func (ps *pruningStorage) increment(key []byte) (bool, error) {
+	rc, err := ps.getRefCount(key)
+	if err != nil {
+		return false, nil
+	}
+
+	rc.count++
+	err = ps.putRefCount(key, rc)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (ps *pruningStorage) decrement(key []byte) error {
+	keys := [][]byte{key}
+	for len(keys) > 0 {
+		key = keys[len(keys)-1]
+		keys = keys[:len(keys)-1]
+
+		rc, err := ps.getRefCount(key)
+		if err != nil || rc.count == 0 {
+			continue
+		}
+
+		rc.count--
+		if rc.count > 0 {
+			err = ps.putRefCount(key, rc)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		encNode, err := ps.db.Get(key)
+		if err != nil {
+			return err
+		}
+		n, err := decodeNode(encNode, ps.marshalizer)
+		if err != nil {
+			return err
+		}
+
+		err = ps.db.Remove(key)
+		if err != nil {
+			return err
+		}
+		err = ps.db.Remove(refCountKey(key))
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, getChildrenHashes(n)...)
+		keys = append(keys, rc.references...)
+	}
+
+	return nil
+}
+
+func (ps *pruningStorage) getRefCount(key []byte) (*refCount, error) {
+	buff, err := ps.db.Get(refCountKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(buff) < 8 {
+		return nil, ErrInvalidEncoding
+	}
+
+	references, err := decodeByteSlices(buff[8:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &refCount{
+		count:      binary.BigEndian.Uint64(buff[:8]),
+		references: references,
+	}, nil
+}
+
+func (ps *pruningStorage) putRefCount(key []byte, rc *refCount) error {
+	buff := make([]byte, 8)
+	binary.BigEndian.PutUint64(buff, rc.count)
+	buff = append(buff, encodeByteSlices(rc.references)...)
+
+	return core.Overwrite(ps.db, refCountKey(key), buff)
+}
+
+func refCountKey(key []byte) []byte {
+	return append([]byte(refCountPrefix), key...)
+}
+
+func getChildrenHashes(n node) [][]byte {
+	hashes := make([][]byte, 0)
+	switch n := n.(type) {
+	case *branchNode:
+		for _, childHash := range n.EncodedChildren {
+			if len(childHash) > 0 {
+				hashes = append(hashes, childHash)
+			}
+		}
+	case *extensionNode:
+		if len(n.EncodedChild) > 0 {
+			hashes = append(hashes, n.EncodedChild)
+		}
+	}
+
+	return hashes
+}
+
+func encodeByteSlices(slices [][]byte) []byte {
+	buff := make([]byte, 0)
+	lenBuff := make([]byte, 4)
+	for _, slice := range slices {
+		binary.BigEndian.PutUint32(lenBuff, uint32(len(slice)))
+		buff = append(buff, lenBuff...)
+		buff = append(buff, slice...)
+	}
+
+	return buff
+}
+
+func decodeByteSlices(buff []byte) ([][]byte, error) {
+	slices := make([][]byte, 0)
+	for len(buff) > 0 {
+		if len(buff) < 4 {
+			return nil, ErrInvalidEncoding
+		}
+		sliceLen := int(binary.BigEndian.Uint32(buff[:4]))
+		buff = buff[4:]
+		if len(buff) < sliceLen {
+			return nil, ErrInvalidEncoding
+		}
+
+		slices = append(slices, buff[:sliceLen])
+		buff = buff[sliceLen:]
+	}
+
+	return slices, nil
+}
@@ -0,0 +1,216 @@
+package trie_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/mock"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitUntilRemoved(db data.DBWriteCacher, key []byte) bool {
+	for i := 0; i < 100; i++ {
+		_, err := db.Get(key)
+		if err != nil {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
+
+func updateCommitAndAddRoot(t *testing.T, tr data.Trie, pruner data.TriePruner, key []byte, value []byte) []byte {
+	err := tr.Update(key, value)
+	assert.Nil(t, err)
+	err = tr.Commit()
+	assert.Nil(t, err)
+
+	root, _ := tr.Root()
+	err = pruner.AddRoot(root)
+	assert.Nil(t, err)
+
+	return root
+}
+
+func TestNewPruningStorage_NilDatabaseShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ps, err := trie.NewPruningStorage(nil, marshalizer, &mock.LeafReferencesExtractorStub{}, 1)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, trie.ErrNilDatabase, err)
+}
+
+func TestNewPruningStorage_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	ps, err := trie.NewPruningStorage(db, nil, &mock.LeafReferencesExtractorStub{}, 1)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, trie.ErrNilMarshalizer, err)
+}
+
+func TestNewPruningStorage_NilReferencesExtractorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	ps, err := trie.NewPruningStorage(db, marshalizer, nil, 1)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, trie.ErrNilLeafReferencesExtractor, err)
+}
+
+func TestNewPruningStorage_NoRootsToKeepShouldErr(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	ps, err := trie.NewPruningStorage(db, marshalizer, &mock.LeafReferencesExtractorStub{}, 0)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, trie.ErrInvalidNumRootsToKeep, err)
+}
+
+func TestNewPruningStorage_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	ps, err := trie.NewPruningStorage(db, marshalizer, &mock.LeafReferencesExtractorStub{}, 1)
+
+	assert.NotNil(t, ps)
+	assert.Nil(t, err)
+}
+
+func TestPruningStorage_OldRootsArePrunedAndLastOnesKept(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	ps, _ := trie.NewPruningStorage(db, marshalizer, &mock.LeafReferencesExtractorStub{}, 2)
+	tr, _ := trie.NewTrie(ps, marshalizer, hasher)
+
+	_ = tr.Update([]byte("doe"), []byte("reindeer"))
+	root1 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("puppy"))
+	root2 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("cat"))
+	root3 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("horse"))
+
+	assert.True(t, waitUntilRemoved(db, root1))
+
+	_, err := tr.Recreate(root1)
+	assert.NotNil(t, err)
+
+	tr2, err := tr.Recreate(root2)
+	assert.Nil(t, err)
+	val, _ := tr2.Get([]byte("dog"))
+	assert.Equal(t, []byte("cat"), val)
+	val, _ = tr2.Get([]byte("doe"))
+	assert.Equal(t, []byte("reindeer"), val)
+
+	tr3, err := tr.Recreate(root3)
+	assert.Nil(t, err)
+	val, _ = tr3.Get([]byte("dog"))
+	assert.Equal(t, []byte("horse"), val)
+	val, _ = tr3.Get([]byte("doe"))
+	assert.Equal(t, []byte("reindeer"), val)
+}
+
+func TestPruningStorage_RevertedValueShouldNotBePruned(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	ps, _ := trie.NewPruningStorage(db, marshalizer, &mock.LeafReferencesExtractorStub{}, 1)
+	tr, _ := trie.NewTrie(ps, marshalizer, hasher)
+
+	_ = tr.Update([]byte("doe"), []byte("reindeer"))
+	root1 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("puppy"))
+	root2 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("cat"))
+	assert.True(t, waitUntilRemoved(db, root1))
+
+	root3 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("puppy"))
+	assert.Equal(t, root1, root3)
+	assert.True(t, waitUntilRemoved(db, root2))
+
+	tr3, err := tr.Recreate(root3)
+	assert.Nil(t, err)
+	val, _ := tr3.Get([]byte("dog"))
+	assert.Equal(t, []byte("puppy"), val)
+	val, _ = tr3.Get([]byte("doe"))
+	assert.Equal(t, []byte("reindeer"), val)
+}
+
+func TestPruningStorage_NodesWrittenBeforePruningShouldNotBeRemoved(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	archiveTrie, _ := trie.NewTrie(db, marshalizer, hasher)
+	_ = archiveTrie.Update([]byte("doe"), []byte("reindeer"))
+	_ = archiveTrie.Update([]byte("dog"), []byte("puppy"))
+	_ = archiveTrie.Commit()
+	archiveRoot, _ := archiveTrie.Root()
+
+	ps, _ := trie.NewPruningStorage(db, marshalizer, &mock.LeafReferencesExtractorStub{}, 1)
+	prunedTrie, _ := trie.NewTrie(ps, marshalizer, hasher)
+	tr, _ := prunedTrie.Recreate(archiveRoot)
+	root1 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("cat"))
+	_ = updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("horse"))
+	assert.True(t, waitUntilRemoved(db, root1))
+
+	trArchive, err := tr.Recreate(archiveRoot)
+	assert.Nil(t, err)
+	val, _ := trArchive.Get([]byte("dog"))
+	assert.Equal(t, []byte("puppy"), val)
+}
+
+func TestPruningStorage_ReferencedTrieShouldBePrunedWithTheReferencingLeaf(t *testing.T) {
+	t.Parallel()
+
+	referencePrefix := []byte("ref")
+	extractor := &mock.LeafReferencesExtractorStub{
+		ExtractReferencesCalled: func(value []byte) [][]byte {
+			if len(value) > len(referencePrefix) && string(value[:len(referencePrefix)]) == string(referencePrefix) {
+				return [][]byte{value[len(referencePrefix):]}
+			}
+			return make([][]byte, 0)
+		},
+	}
+
+	db, _ := mock.NewMemDbMock()
+	ps, _ := trie.NewPruningStorage(db, marshalizer, extractor, 1)
+	mainTrie, _ := trie.NewTrie(ps, marshalizer, hasher)
+
+	dataTrie, _ := mainTrie.Recreate(make([]byte, 0))
+	_ = dataTrie.Update([]byte("key"), []byte("value"))
+	_ = dataTrie.Commit()
+	dataRoot, _ := dataTrie.Root()
+
+	_ = mainTrie.Update([]byte("doe"), []byte("reindeer"))
+	_ = updateCommitAndAddRoot(t, mainTrie, ps, []byte("dog"), append(referencePrefix, dataRoot...))
+	root2 := updateCommitAndAddRoot(t, mainTrie, ps, []byte("doe"), []byte("cat"))
+
+	_, err := db.Get(dataRoot)
+	assert.Nil(t, err)
+
+	_ = updateCommitAndAddRoot(t, mainTrie, ps, []byte("dog"), []byte("puppy"))
+	assert.True(t, waitUntilRemoved(db, root2))
+	assert.True(t, waitUntilRemoved(db, dataRoot))
+}
+
+func TestPruningStorage_RootsShouldBeLoadedFromStorage(t *testing.T) {
+	t.Parallel()
+
+	db, _ := mock.NewMemDbMock()
+	ps, _ := trie.NewPruningStorage(db, marshalizer, &mock.LeafReferencesExtractorStub{}, 1)
+	tr, _ := trie.NewTrie(ps, marshalizer, hasher)
+
+	_ = tr.Update([]byte("doe"), []byte("reindeer"))
+	root1 := updateCommitAndAddRoot(t, tr, ps, []byte("dog"), []byte("puppy"))
+
+	psReloaded, _ := trie.NewPruningStorage(db, marshalizer, &mock.LeafReferencesExtractorStub{}, 1)
+	emptyTrie, _ := trie.NewTrie(psReloaded, marshalizer, hasher)
+	trReloaded, _ := emptyTrie.Recreate(root1)
+	_ = updateCommitAndAddRoot(t, trReloaded, psReloaded, []byte("dog"), []byte("cat"))
+
+	assert.True(t, waitUntilRemoved(db, root1))
+}
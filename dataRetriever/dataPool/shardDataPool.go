@@ -13,6 +13,7 @@ type shardedDataPool struct {
 	headersNonces        dataRetriever.Uint64SyncMapCacher
 	miniBlocks           storage.Cacher
 	peerChangesBlocks    storage.Cacher
+	trieNodes            storage.Cacher
 }
 
 // NewShardedDataPool creates a data pools holder object
@@ -24,6 +25,7 @@ func NewShardedDataPool(
 	miniBlocks storage.Cacher,
 	peerChangesBlocks storage.Cacher,
 	metaBlocks storage.Cacher,
+	trieNodes storage.Cacher,
 ) (*shardedDataPool, error) {
 
 	if transactions == nil {
@@ -47,6 +49,9 @@ func NewShardedDataPool(
 	if metaBlocks == nil {
 		return nil, dataRetriever.ErrNilMetaBlockPool
 	}
+	if trieNodes == nil {
+		return nil, dataRetriever.ErrNilTrieNodesPool
+	}
 
 	return &shardedDataPool{
 		transactions:         transactions,
@@ -56,6 +61,7 @@ func NewShardedDataPool(
 		miniBlocks:           miniBlocks,
 		peerChangesBlocks:    peerChangesBlocks,
 		metaBlocks:           metaBlocks,
+		trieNodes:            trieNodes,
 	}, nil
 }
 
@@ -94,3 +100,8 @@ func (tdp *shardedDataPool) PeerChangesBlocks() storage.Cacher {
 func (tdp *shardedDataPool) MetaBlocks() storage.Cacher {
 	return tdp.metaBlocks
 }
+
+// TrieNodes returns the holder for the trie nodes received while syncing the state
+func (tdp *shardedDataPool) TrieNodes() storage.Cacher {
+	return tdp.trieNodes
+}
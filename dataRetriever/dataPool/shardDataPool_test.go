@@ -20,6 +20,7 @@ func TestNewShardedDataPool_NilTransactionsShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilTxDataPool, err)
@@ -35,6 +36,7 @@ func TestNewShardedDataPool_NilUnsignedTransactionsShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilUnsignedTransactionPool, err)
@@ -50,6 +52,7 @@ func TestNewShardedDataPool_NilHeadersShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilHeadersDataPool, err)
@@ -65,6 +68,7 @@ func TestNewShardedDataPool_NilHeaderNoncesShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilHeadersNoncesDataPool, err)
@@ -80,6 +84,7 @@ func TestNewShardedDataPool_NilTxBlocksShouldErr(t *testing.T) {
 		nil,
 		&mock.CacherStub{},
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilTxBlockDataPool, err)
@@ -95,6 +100,7 @@ func TestNewShardedDataPool_NilPeerBlocksShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		nil,
 		&mock.CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilPeerChangeBlockDataPool, err)
@@ -110,12 +116,29 @@ func TestNewShardedDataPool_NilMetaBlocksShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		nil,
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilMetaBlockPool, err)
 	assert.Nil(t, tdp)
 }
 
+func TestNewShardedDataPool_NilTrieNodesShouldErr(t *testing.T) {
+	tdp, err := dataPool.NewShardedDataPool(
+		&mock.ShardedDataStub{},
+		&mock.ShardedDataStub{},
+		&mock.CacherStub{},
+		&mock.Uint64SyncMapCacherStub{},
+		&mock.CacherStub{},
+		&mock.CacherStub{},
+		&mock.CacherStub{},
+		nil,
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilTrieNodesPool, err)
+	assert.Nil(t, tdp)
+}
+
 func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	transactions := &mock.ShardedDataStub{}
 	scResults := &mock.ShardedDataStub{}
@@ -124,6 +147,7 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	txBlocks := &mock.CacherStub{}
 	peersBlock := &mock.CacherStub{}
 	metaChainBlocks := &mock.CacherStub{}
+	trieNodes := &mock.CacherStub{}
 	tdp, err := dataPool.NewShardedDataPool(
 		transactions,
 		scResults,
@@ -132,6 +156,7 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 		txBlocks,
 		peersBlock,
 		metaChainBlocks,
+		trieNodes,
 	)
 
 	assert.Nil(t, err)
@@ -143,4 +168,5 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	assert.True(t, peersBlock == tdp.PeerChangesBlocks())
 	assert.True(t, metaChainBlocks == tdp.MetaBlocks())
 	assert.True(t, scResults == tdp.UnsignedTransactions())
+	assert.True(t, trieNodes == tdp.TrieNodes())
 }
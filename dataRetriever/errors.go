@@ -146,6 +146,12 @@ var ErrNilCacher = errors.New("nil cacher")
 // ErrNilMetaBlockPool signals that a nil meta block data pool was provided
 var ErrNilMetaBlockPool = errors.New("nil meta block data pool")
 
+// ErrNilTrieNodesPool signals that a nil trie nodes data pool was provided
+var ErrNilTrieNodesPool = errors.New("nil trie nodes data pool")
+
+// ErrNilTrieStorage signals that a nil trie storage has been provided
+var ErrNilTrieStorage = errors.New("nil trie storage")
+
 // ErrNilMiniBlockHashesPool signals that a nil meta block data pool was provided
 var ErrNilMiniBlockHashesPool = errors.New("nil meta block mini block hashes data pool")
 
@@ -187,3 +193,34 @@ var ErrInvalidMaxTxRequest = errors.New("max tx request number is invalid")
 
 // ErrNilPeerListCreator signals that a nil peer list creator implementation has been provided
 var ErrNilPeerListCreator = errors.New("nil peer list creator provided")
+
+// ErrNilAccountNonceProvider signals that a nil account nonce provider has been provided
+var ErrNilAccountNonceProvider = errors.New("nil account nonce provider")
+
+// ErrInvalidMaxTxsPerSender signals that an invalid maximum number of transactions per sender has been provided
+var ErrInvalidMaxTxsPerSender = errors.New("invalid maximum number of transactions per sender")
+
+// ErrInvalidTxPoolSize signals that an invalid transaction pool size has been provided
+var ErrInvalidTxPoolSize = errors.New("invalid transaction pool size")
+
+// ErrNotTransaction signals that a value which is not a transaction has been added to the transaction pool
+var ErrNotTransaction = errors.New("value is not a transaction")
+
+// ErrTxNonceAlreadyExecuted signals that the nonce of a transaction is lower than the nonce of its sender's account
+var ErrTxNonceAlreadyExecuted = errors.New("transaction nonce already executed")
+
+// ErrTxNonceTooHigh signals that the nonce of a transaction is too far ahead of the nonce of its sender
+var ErrTxNonceTooHigh = errors.New("transaction nonce too high")
+
+// ErrDuplicatedTxNonce signals that the pool already holds a transaction with the same sender and nonce, which is not
+// replaced as the gas price is not high enough
+var ErrDuplicatedTxNonce = errors.New("duplicated transaction nonce")
+
+// ErrTooManyTxsFromSender signals that the pool already holds the maximum number of transactions of a sender
+var ErrTooManyTxsFromSender = errors.New("too many transactions from sender")
+
+// ErrTxPoolFull signals that the pool is full and holds no transaction paying less than the added one
+var ErrTxPoolFull = errors.New("transaction pool is full")
+
+// ErrTooManyHashesRequested signals that a request holds more hashes than a resolver serves in one message
+var ErrTooManyHashesRequested = errors.New("too many hashes requested")
@@ -2,6 +2,7 @@ package shard
 
 import (
 	"github.com/ElrondNetwork/elrond-go/core/random"
+	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever/factory/containers"
@@ -23,6 +24,7 @@ type resolversContainerFactory struct {
 	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter
 	intRandomizer            dataRetriever.IntRandomizer
 	dataPacker               dataRetriever.DataPacker
+	trieStorage              data.DBWriteCacher
 }
 
 // NewResolversContainerFactory creates a new container filled with topic resolvers
@@ -34,6 +36,7 @@ func NewResolversContainerFactory(
 	dataPools dataRetriever.PoolsHolder,
 	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter,
 	dataPacker dataRetriever.DataPacker,
+	trieStorage data.DBWriteCacher,
 ) (*resolversContainerFactory, error) {
 
 	if shardCoordinator == nil {
@@ -57,6 +60,9 @@ func NewResolversContainerFactory(
 	if dataPacker == nil {
 		return nil, dataRetriever.ErrNilDataPacker
 	}
+	if trieStorage == nil {
+		return nil, dataRetriever.ErrNilTrieStorage
+	}
 
 	return &resolversContainerFactory{
 		shardCoordinator:         shardCoordinator,
@@ -67,6 +73,7 @@ func NewResolversContainerFactory(
 		uint64ByteSliceConverter: uint64ByteSliceConverter,
 		intRandomizer:            &random.ConcurrentSafeIntRandomizer{},
 		dataPacker:               dataPacker,
+		trieStorage:              trieStorage,
 	}, nil
 }
 
@@ -141,6 +148,15 @@ func (rcf *resolversContainerFactory) Create() (dataRetriever.ResolversContainer
 		return nil, err
 	}
 
+	keys, resolverSlice, err = rcf.generateTrieNodesResolver()
+	if err != nil {
+		return nil, err
+	}
+	err = container.AddMultiple(keys, resolverSlice)
+	if err != nil {
+		return nil, err
+	}
+
 	return container, nil
 }
 
@@ -515,3 +531,48 @@ func (rcf *resolversContainerFactory) generateMetablockHeaderResolver() ([]strin
 
 	return []string{identifierHdr}, []dataRetriever.Resolver{resolver}, nil
 }
+
+//------- TrieNodes resolver
+
+func (rcf *resolversContainerFactory) generateTrieNodesResolver() ([]string, []dataRetriever.Resolver, error) {
+	shardC := rcf.shardCoordinator
+
+	//only one intrashard trie nodes topic
+	identifierTrieNodes := factory.TrieNodesTopic + shardC.CommunicationIdentifier(shardC.SelfId())
+
+	peerListCreator, err := topicResolverSender.NewDiffPeerListCreator(rcf.messenger, identifierTrieNodes, emptyExcludePeersOnTopic)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolverSender, err := topicResolverSender.NewTopicResolverSender(
+		rcf.messenger,
+		identifierTrieNodes,
+		peerListCreator,
+		rcf.marshalizer,
+		rcf.intRandomizer,
+		shardC.SelfId(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolver, err := resolvers.NewTrieNodesResolver(
+		resolverSender,
+		rcf.trieStorage,
+		rcf.marshalizer,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	//add on the request topic
+	_, err = rcf.createTopicAndAssignHandler(
+		identifierTrieNodes+resolverSender.TopicRequestSuffix(),
+		resolver,
+		false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []string{identifierTrieNodes}, []dataRetriever.Resolver{resolver}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/p2p"
 	"github.com/ElrondNetwork/elrond-go/storage"
 )
@@ -30,6 +31,12 @@ const (
 	UnsignedTransactionUnit UnitType = 7
 	// MetaHdrNonceHashDataUnit is the meta header nonce-hash pair data unit identifier
 	MetaHdrNonceHashDataUnit UnitType = 8
+	// ReceiptsUnit is the transaction receipts storage unit identifier
+	ReceiptsUnit UnitType = 9
+	// CommitJournalUnit is the storage unit identifier of the journal of the committed blocks
+	CommitJournalUnit UnitType = 10
+	// RewardTransactionUnit is the reward transactions storage unit identifier
+	RewardTransactionUnit UnitType = 11
 
 	// ShardHdrNonceHashDataUnit is the header nonce-hash pair data unit identifier
 	//TODO: Add only unit types lower than 100
@@ -58,6 +65,12 @@ type MiniBlocksResolver interface {
 	GetMiniBlocks(hashes [][]byte) block.MiniBlockSlice // TODO miniblockresolver should not know about miniblockslice
 }
 
+// TrieNodesResolver defines what a trie nodes resolver should do
+type TrieNodesResolver interface {
+	Resolver
+	RequestDataFromHashArray(hashes [][]byte) error
+}
+
 // TopicResolverSender defines what sending operations are allowed for a topic resolver
 type TopicResolverSender interface {
 	SendOnRequestTopic(rd *RequestData) error
@@ -162,6 +175,17 @@ type ShardedDataCacherNotifier interface {
 	CreateShardStore(cacheId string)
 }
 
+// AccountNonceProvider provides the nonce of an account found in the current state
+type AccountNonceProvider interface {
+	GetAccountNonce(address []byte) (uint64, error)
+}
+
+// TxReplacementChecker finds the pooled transaction which would be replaced by a transaction with the same sender and
+// nonce and a higher gas price
+type TxReplacementChecker interface {
+	ReplacedTxHash(tx *transaction.Transaction) []byte
+}
+
 // ShardIdHashMap represents a map for shardId and hash
 type ShardIdHashMap interface {
 	Load(shardId uint32) ([]byte, bool)
@@ -189,6 +213,7 @@ type PoolsHolder interface {
 	MiniBlocks() storage.Cacher
 	PeerChangesBlocks() storage.Cacher
 	MetaBlocks() storage.Cacher
+	TrieNodes() storage.Cacher
 }
 
 // MetaPoolsHolder defines getter for data pools for metachain
@@ -0,0 +1,9 @@
+package mock
+
+type AccountNonceProviderStub struct {
+	GetAccountNonceCalled func(address []byte) (uint64, error)
+}
+
+func (anps *AccountNonceProviderStub) GetAccountNonce(address []byte) (uint64, error) {
+	return anps.GetAccountNonceCalled(address)
+}
@@ -32,6 +32,9 @@ const (
 	NonceType
 )
 
+// MaxTrieNodesPerRequest defines the maximum number of trie nodes which can be requested in one message
+const MaxTrieNodesPerRequest = 100
+
 // RequestData holds the requested data
 // This struct will be serialized and sent to the other peers
 type RequestData struct {
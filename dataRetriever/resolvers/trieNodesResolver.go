@@ -0,0 +1,125 @@
+package resolvers
+
+import (
+	"fmt"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/p2p"
+)
+
+// TrieNodesResolver is a wrapper over Resolver that is specialized in resolving trie node requests
+type TrieNodesResolver struct {
+	dataRetriever.TopicResolverSender
+	trieStorage data.DBWriteCacher
+	marshalizer marshal.Marshalizer
+}
+
+// NewTrieNodesResolver creates a new trie nodes resolver
+func NewTrieNodesResolver(
+	senderResolver dataRetriever.TopicResolverSender,
+	trieStorage data.DBWriteCacher,
+	marshalizer marshal.Marshalizer,
+) (*TrieNodesResolver, error) {
+
+	if senderResolver == nil {
+		return nil, dataRetriever.ErrNilResolverSender
+	}
+	if trieStorage == nil {
+		return nil, dataRetriever.ErrNilTrieStorage
+	}
+	if marshalizer == nil {
+		return nil, dataRetriever.ErrNilMarshalizer
+	}
+
+	tnRes := &TrieNodesResolver{
+		TopicResolverSender: senderResolver,
+		trieStorage:         trieStorage,
+		marshalizer:         marshalizer,
+	}
+
+	return tnRes, nil
+}
+
+// ProcessReceivedMessage will be the callback func from the p2p.Messenger and will be called each time a new message was received
+// (for the topic this validator was registered to, usually a request topic)
+func (tnRes *TrieNodesResolver) ProcessReceivedMessage(message p2p.MessageP2P) error {
+	rd := &dataRetriever.RequestData{}
+	err := rd.Unmarshal(tnRes.marshalizer, message)
+	if err != nil {
+		return err
+	}
+
+	buff, err := tnRes.resolveTrieNodesRequest(rd)
+	if err != nil {
+		return err
+	}
+
+	if buff == nil {
+		log.Debug(fmt.Sprintf("missing data: %v", rd))
+		return nil
+	}
+
+	return tnRes.Send(buff, message.Peer())
+}
+
+func (tnRes *TrieNodesResolver) resolveTrieNodesRequest(rd *dataRetriever.RequestData) ([]byte, error) {
+	if rd.Value == nil {
+		return nil, dataRetriever.ErrNilValue
+	}
+
+	hashes := make([][]byte, 0)
+	switch rd.Type {
+	case dataRetriever.HashType:
+		hashes = append(hashes, rd.Value)
+	case dataRetriever.HashArrayType:
+		err := tnRes.marshalizer.Unmarshal(&hashes, rd.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) > dataRetriever.MaxTrieNodesPerRequest {
+			return nil, dataRetriever.ErrTooManyHashesRequested
+		}
+	default:
+		return nil, dataRetriever.ErrInvalidRequestType
+	}
+
+	// the nodes missing from this peer's storage are skipped, the requester will ask for them again
+	encodedNodes := make([][]byte, 0)
+	for _, hash := range hashes {
+		encNode, err := tnRes.trieStorage.Get(hash)
+		if err != nil {
+			continue
+		}
+
+		encodedNodes = append(encodedNodes, encNode)
+	}
+
+	if len(encodedNodes) == 0 {
+		return nil, nil
+	}
+
+	return tnRes.marshalizer.Marshal(encodedNodes)
+}
+
+// RequestDataFromHash requests a trie node from other peers having input the node hash
+func (tnRes *TrieNodesResolver) RequestDataFromHash(hash []byte) error {
+	return tnRes.SendOnRequestTopic(&dataRetriever.RequestData{
+		Type:  dataRetriever.HashType,
+		Value: hash,
+	})
+}
+
+// RequestDataFromHashArray requests a list of trie nodes from other peers having input the nodes hashes
+func (tnRes *TrieNodesResolver) RequestDataFromHashArray(hashes [][]byte) error {
+	buff, err := tnRes.marshalizer.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+
+	return tnRes.SendOnRequestTopic(&dataRetriever.RequestData{
+		Type:  dataRetriever.HashArrayType,
+		Value: buff,
+	})
+}
@@ -0,0 +1,211 @@
+package resolvers_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/mock"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/resolvers"
+	"github.com/ElrondNetwork/elrond-go/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+//------- NewTrieNodesResolver
+
+func TestNewTrieNodesResolver_NilSenderResolverShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodesResolver(
+		nil,
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilResolverSender, err)
+	assert.Nil(t, tnRes)
+}
+
+func TestNewTrieNodesResolver_NilTrieStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{},
+		nil,
+		&mock.MarshalizerMock{},
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilTrieStorage, err)
+	assert.Nil(t, tnRes)
+}
+
+func TestNewTrieNodesResolver_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		nil,
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilMarshalizer, err)
+	assert.Nil(t, tnRes)
+}
+
+func TestNewTrieNodesResolver_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+	)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tnRes)
+}
+
+//------- ProcessReceivedMessage
+
+func TestTrieNodesResolver_ProcessReceivedMessageNilValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, _ := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashType, nil))
+	assert.Equal(t, dataRetriever.ErrNilValue, err)
+}
+
+func TestTrieNodesResolver_ProcessReceivedMessageWrongTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, _ := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.NonceType, make([]byte, 0)))
+	assert.Equal(t, dataRetriever.ErrInvalidRequestType, err)
+}
+
+func TestTrieNodesResolver_ProcessReceivedMessageShouldSendTheFoundNodes(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	foundHash := []byte("found")
+	foundNode := []byte("encoded node")
+	requestedBuff, _ := marshalizer.Marshal([][]byte{foundHash, []byte("missing")})
+
+	var sentNodes [][]byte
+	tnRes, _ := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{
+			SendCalled: func(buff []byte, peer p2p.PeerID) error {
+				return marshalizer.Unmarshal(&sentNodes, buff)
+			},
+		},
+		&mock.StorerStub{
+			GetCalled: func(key []byte) ([]byte, error) {
+				if bytes.Equal(key, foundHash) {
+					return foundNode, nil
+				}
+				return nil, errors.New("key not found")
+			},
+		},
+		marshalizer,
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashArrayType, requestedBuff))
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{foundNode}, sentNodes)
+}
+
+func TestTrieNodesResolver_ProcessReceivedMessageTooManyHashesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	hashes := make([][]byte, dataRetriever.MaxTrieNodesPerRequest+1)
+	for i := range hashes {
+		hashes[i] = []byte("hash")
+	}
+	requestedBuff, _ := marshalizer.Marshal(hashes)
+
+	wasSent := false
+	tnRes, _ := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{
+			SendCalled: func(buff []byte, peer p2p.PeerID) error {
+				wasSent = true
+				return nil
+			},
+		},
+		&mock.StorerStub{
+			GetCalled: func(key []byte) ([]byte, error) {
+				assert.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		},
+		marshalizer,
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashArrayType, requestedBuff))
+
+	assert.Equal(t, dataRetriever.ErrTooManyHashesRequested, err)
+	assert.False(t, wasSent)
+}
+
+func TestTrieNodesResolver_ProcessReceivedMessageMissingNodesShouldNotSend(t *testing.T) {
+	t.Parallel()
+
+	wasSent := false
+	tnRes, _ := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{
+			SendCalled: func(buff []byte, peer p2p.PeerID) error {
+				wasSent = true
+				return nil
+			},
+		},
+		&mock.StorerStub{
+			GetCalled: func(key []byte) ([]byte, error) {
+				return nil, errors.New("key not found")
+			},
+		},
+		&mock.MarshalizerMock{},
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashType, []byte("missing")))
+
+	assert.Nil(t, err)
+	assert.False(t, wasSent)
+}
+
+//------- RequestDataFromHashArray
+
+func TestTrieNodesResolver_RequestDataFromHashArrayShouldWork(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	hashes := [][]byte{[]byte("hash1"), []byte("hash2")}
+
+	var requestedHashes [][]byte
+	tnRes, _ := resolvers.NewTrieNodesResolver(
+		&mock.TopicResolverSenderStub{
+			SendOnRequestTopicCalled: func(rd *dataRetriever.RequestData) error {
+				assert.Equal(t, dataRetriever.HashArrayType, rd.Type)
+				return marshalizer.Unmarshal(&requestedHashes, rd.Value)
+			},
+		},
+		&mock.StorerStub{},
+		marshalizer,
+	)
+
+	err := tnRes.RequestDataFromHashArray(hashes)
+
+	assert.Nil(t, err)
+	assert.Equal(t, hashes, requestedHashes)
+}
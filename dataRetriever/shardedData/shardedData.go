@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 )
@@ -23,6 +25,8 @@ type shardedData struct {
 	//  data hashes that have that shard as destination
 	shardedDataStore map[string]*shardStore
 	cacherConfig     storageUnit.CacheConfig
+	createCacher     func(cacherConfig storageUnit.CacheConfig) (storage.Cacher, error)
+	txSenders        *txSenders
 
 	mutAddedDataHandlers sync.RWMutex
 	addedDataHandlers    []func(key []byte)
@@ -42,6 +46,45 @@ func NewShardedData(cacherConfig storageUnit.CacheConfig) (*shardedData, error)
 
 	return &shardedData{
 		cacherConfig:         cacherConfig,
+		createCacher:         createStorageUnitCacher,
+		mutShardedDataStore:  sync.RWMutex{},
+		shardedDataStore:     make(map[string]*shardStore),
+		mutAddedDataHandlers: sync.RWMutex{},
+		addedDataHandlers:    make([]func(key []byte), 0),
+	}, nil
+}
+
+// NewShardedTxPool creates an empty pool of transactions. Its shard stores keep the transactions of every sender
+// in nonce order, hold the transactions following a nonce gap until the gap is filled, reject the already executed
+// nonces and the ones too far ahead, limit the number of transactions of a sender, replace a transaction by one with
+// the same nonce and a gas price higher by at least the replacement percentage and, when full, evict the lowest
+// paying transactions. The size of the cacher config is the capacity of each shard store, while its type and number
+// of shards are not used
+func NewShardedTxPool(
+	cacherConfig storageUnit.CacheConfig,
+	maxTxsPerSender uint32,
+	replacementGasPricePercentage uint32,
+	nonceProvider dataRetriever.AccountNonceProvider,
+) (*shardedData, error) {
+	if cacherConfig.Size == 0 {
+		return nil, dataRetriever.ErrInvalidTxPoolSize
+	}
+	if maxTxsPerSender == 0 {
+		return nil, dataRetriever.ErrInvalidMaxTxsPerSender
+	}
+	if nonceProvider == nil {
+		return nil, dataRetriever.ErrNilAccountNonceProvider
+	}
+
+	senders := newTxSenders(maxTxsPerSender, replacementGasPricePercentage, nonceProvider)
+	createTxCache := func(cacherConfig storageUnit.CacheConfig) (storage.Cacher, error) {
+		return newTxCache(senders, int(cacherConfig.Size)), nil
+	}
+
+	return &shardedData{
+		cacherConfig:         cacherConfig,
+		createCacher:         createTxCache,
+		txSenders:            senders,
 		mutShardedDataStore:  sync.RWMutex{},
 		shardedDataStore:     make(map[string]*shardStore),
 		mutAddedDataHandlers: sync.RWMutex{},
@@ -50,13 +93,17 @@ func NewShardedData(cacherConfig storageUnit.CacheConfig) (*shardedData, error)
 }
 
 func verifyCacherConfig(cacherConfig storageUnit.CacheConfig) error {
-	_, err := newShardStore("", cacherConfig)
+	_, err := createStorageUnitCacher(cacherConfig)
 	return err
 }
 
+func createStorageUnitCacher(cacherConfig storageUnit.CacheConfig) (storage.Cacher, error) {
+	return storageUnit.NewCache(cacherConfig.Type, cacherConfig.Size, cacherConfig.Shards)
+}
+
 // newShardStore is responsible for creating an empty shardStore
-func newShardStore(cacheId string, cacherConfig storageUnit.CacheConfig) (*shardStore, error) {
-	cacher, err := storageUnit.NewCache(cacherConfig.Type, cacherConfig.Size, cacherConfig.Shards)
+func (sd *shardedData) newShardStore(cacheId string) (*shardStore, error) {
+	cacher, err := sd.createCacher(sd.cacherConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +122,7 @@ func (sd *shardedData) CreateShardStore(cacheId string) {
 }
 
 func (sd *shardedData) newShardStoreNoLock(cacheId string) *shardStore {
-	shardStore, err := newShardStore(cacheId, sd.cacherConfig)
+	shardStore, err := sd.newShardStore(cacheId)
 	log.LogIfError(err)
 
 	sd.shardedDataStore[cacheId] = shardStore
@@ -112,6 +159,10 @@ func (sd *shardedData) AddData(key []byte, data interface{}, cacheId string) {
 	sd.mutShardedDataStore.Unlock()
 
 	found, _ := mp.DataStore.HasOrAdd(key, data)
+	if !found && !mp.DataStore.Has(key) {
+		// the data was rejected by the cacher
+		return
+	}
 
 	if !found {
 		sd.mutAddedDataHandlers.RLock()
@@ -225,6 +276,16 @@ func (sd *shardedData) ClearShardStore(cacheId string) {
 	mp.Clear()
 }
 
+// ReplacedTxHash returns the hash of the pooled transaction which would be replaced by the given transaction, or
+// nil if the transaction replaces none or the pool does not hold transactions
+func (sd *shardedData) ReplacedTxHash(tx *transaction.Transaction) []byte {
+	if sd.txSenders == nil || tx == nil {
+		return nil
+	}
+
+	return sd.txSenders.replacedTxHash(tx)
+}
+
 // RegisterHandler registers a new handler to be called when a new data is added
 func (sd *shardedData) RegisterHandler(handler func(key []byte)) {
 	if handler == nil {
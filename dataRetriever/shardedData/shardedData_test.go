@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/mock"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever/shardedData"
 	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/stretchr/testify/assert"
@@ -315,3 +317,65 @@ func TestShardedData_SearchFirstDataFoundShouldRetResults(t *testing.T) {
 	assert.NotNil(t, value)
 	assert.True(t, ok)
 }
+
+//------- NewShardedTxPool
+
+func TestNewShardedTxPool_ZeroSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sd, err := shardedData.NewShardedTxPool(storageUnit.CacheConfig{Size: 0}, 10, 10, &mock.AccountNonceProviderStub{})
+
+	assert.Nil(t, sd)
+	assert.Equal(t, dataRetriever.ErrInvalidTxPoolSize, err)
+}
+
+func TestNewShardedTxPool_ZeroMaxTxsPerSenderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sd, err := shardedData.NewShardedTxPool(defaultTestConfig, 0, 10, &mock.AccountNonceProviderStub{})
+
+	assert.Nil(t, sd)
+	assert.Equal(t, dataRetriever.ErrInvalidMaxTxsPerSender, err)
+}
+
+func TestNewShardedTxPool_NilNonceProviderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sd, err := shardedData.NewShardedTxPool(defaultTestConfig, 10, 10, nil)
+
+	assert.Nil(t, sd)
+	assert.Equal(t, dataRetriever.ErrNilAccountNonceProvider, err)
+}
+
+func TestNewShardedTxPool_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sd, err := shardedData.NewShardedTxPool(defaultTestConfig, 10, 10, &mock.AccountNonceProviderStub{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sd)
+}
+
+func TestShardedTxPool_AddDataRejectedShouldNotCallHandlers(t *testing.T) {
+	t.Parallel()
+
+	sd, _ := shardedData.NewShardedTxPool(defaultTestConfig, 10, 10, &mock.AccountNonceProviderStub{
+		GetAccountNonceCalled: func(address []byte) (uint64, error) {
+			return 5, nil
+		},
+	})
+
+	chDone := make(chan struct{}, 1)
+	sd.RegisterHandler(func(key []byte) {
+		chDone <- struct{}{}
+	})
+
+	sd.AddData([]byte("hash"), &transaction.Transaction{Nonce: 4, SndAddr: []byte("sender")}, "1")
+
+	select {
+	case <-chDone:
+		assert.Fail(t, "should have not been called")
+	case <-time.After(time.Millisecond * 100):
+	}
+	assert.Equal(t, 0, sd.ShardDataStore("1").Len())
+}
@@ -0,0 +1,508 @@
+package shardedData
+
+import (
+	"container/heap"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+)
+
+type pooledTx struct {
+	hash  string
+	tx    *transaction.Transaction
+	cache *txCache
+	seq   uint64
+}
+
+// senderTxs holds the pooled transactions of a sender ordered by nonce. The transactions following a nonce gap are
+// held until the missing nonces arrive, as the transactions of a sender may reach the pool in any order. The sender
+// is kept in the tails heap of the cache holding its last transaction
+type senderTxs struct {
+	address    string
+	txs        []*pooledTx
+	tails      *senderTails
+	tailsIndex int
+}
+
+func (st *senderTxs) tail() *pooledTx {
+	return st.txs[len(st.txs)-1]
+}
+
+// firstIndex returns the position of the first transaction with a nonce not lower than the given one
+func (st *senderTxs) firstIndex(nonce uint64) int {
+	return sort.Search(len(st.txs), func(i int) bool {
+		return st.txs[i].tx.Nonce >= nonce
+	})
+}
+
+func (st *senderTxs) findNonce(nonce uint64) *pooledTx {
+	index := st.firstIndex(nonce)
+	if index < len(st.txs) && st.txs[index].tx.Nonce == nonce {
+		return st.txs[index]
+	}
+
+	return nil
+}
+
+func (st *senderTxs) insert(ptx *pooledTx) {
+	index := st.firstIndex(ptx.tx.Nonce + 1)
+	st.txs = append(st.txs, nil)
+	copy(st.txs[index+1:], st.txs[index:])
+	st.txs[index] = ptx
+}
+
+func (st *senderTxs) remove(ptx *pooledTx) bool {
+	for index := st.firstIndex(ptx.tx.Nonce); index < len(st.txs) && st.txs[index].tx.Nonce == ptx.tx.Nonce; index++ {
+		if st.txs[index] == ptx {
+			st.txs = append(st.txs[:index], st.txs[index+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// senderTails implements heap.Interface, ordering the senders whose last transaction lives in a cache by the gas
+// price of that transaction, so the cache finds its eviction victim without scanning the whole pool
+type senderTails []*senderTxs
+
+func (sts senderTails) Len() int {
+	return len(sts)
+}
+
+func (sts senderTails) Less(i, j int) bool {
+	return isLowerPaying(sts[i].tail(), sts[j].tail())
+}
+
+func (sts senderTails) Swap(i, j int) {
+	sts[i], sts[j] = sts[j], sts[i]
+	sts[i].tailsIndex = i
+	sts[j].tailsIndex = j
+}
+
+func (sts *senderTails) Push(x interface{}) {
+	sender := x.(*senderTxs)
+	sender.tails = sts
+	sender.tailsIndex = len(*sts)
+	*sts = append(*sts, sender)
+}
+
+func (sts *senderTails) Pop() interface{} {
+	old := *sts
+	n := len(old)
+	sender := old[n-1]
+	old[n-1] = nil
+	*sts = old[:n-1]
+	sender.tails = nil
+	sender.tailsIndex = -1
+
+	return sender
+}
+
+// lowestPaying returns the lowest paying sender tail, skipping the given sender. As the skipped sender can only be
+// the root, the next lowest paying tail is one of its children
+func (sts senderTails) lowestPaying(skippedAddress string) *pooledTx {
+	if len(sts) == 0 {
+		return nil
+	}
+	if sts[0].address != skippedAddress {
+		return sts[0].tail()
+	}
+
+	var lowest *pooledTx
+	for i := 1; i <= 2 && i < len(sts); i++ {
+		if lowest == nil || isLowerPaying(sts[i].tail(), lowest) {
+			lowest = sts[i].tail()
+		}
+	}
+
+	return lowest
+}
+
+// txSenders indexes the transactions of a pool by sender. It is shared by all the caches of the pool, as the
+// transactions of a sender are spread over the caches of all the destination shards, and its mutex guards all the
+// caches of the pool
+type txSenders struct {
+	mut                           sync.Mutex
+	maxTxsPerSender               uint32
+	replacementGasPricePercentage uint32
+	nonceProvider                 dataRetriever.AccountNonceProvider
+	senders                       map[string]*senderTxs
+	lastSeq                       uint64
+}
+
+func newTxSenders(
+	maxTxsPerSender uint32,
+	replacementGasPricePercentage uint32,
+	nonceProvider dataRetriever.AccountNonceProvider,
+) *txSenders {
+	return &txSenders{
+		maxTxsPerSender:               maxTxsPerSender,
+		replacementGasPricePercentage: replacementGasPricePercentage,
+		nonceProvider:                 nonceProvider,
+		senders:                       make(map[string]*senderTxs),
+	}
+}
+
+// checkNonceNoLock checks that a transaction can be added to the transactions of its sender and returns the pooled
+// transaction with the same nonce it replaces, if any. Unlike the already executed nonces and the ones too far ahead
+// to ever be executed within the sender limit, a nonce gap is deliberately not rejected: the interceptors, the
+// requests made while processing a block and the restored blocks all add the transactions of a sender in any order.
+// The transactions following a gap are still never executed before it is filled, as the block creation skips the
+// rest of a sender once one of its transactions fails. The nonce is compared with the state only for the senders
+// found in it, as the accounts of other shards are not, while the duplicate, replacement and sender limit rules apply
+// to all the senders
+func (ts *txSenders) checkNonceNoLock(tx *transaction.Transaction) (*pooledTx, error) {
+	err := ts.checkAccountNonce(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := ts.senders[string(tx.SndAddr)]
+	if sender == nil {
+		return nil, nil
+	}
+
+	pooled := sender.findNonce(tx.Nonce)
+	if pooled != nil {
+		if !ts.isReplacement(pooled.tx, tx) {
+			return nil, dataRetriever.ErrDuplicatedTxNonce
+		}
+		return pooled, nil
+	}
+	if uint32(len(sender.txs)) >= ts.maxTxsPerSender {
+		return nil, dataRetriever.ErrTooManyTxsFromSender
+	}
+
+	return nil, nil
+}
+
+// checkAccountNonce checks the nonce of the transaction against the nonce of its sender in the current state. It
+// accepts the transaction if its sender is not found in the current state
+func (ts *txSenders) checkAccountNonce(tx *transaction.Transaction) error {
+	accountNonce, err := ts.nonceProvider.GetAccountNonce(tx.SndAddr)
+	if err == state.ErrAccNotFound || err == state.ErrNilBlockHeader {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if tx.Nonce < accountNonce {
+		return dataRetriever.ErrTxNonceAlreadyExecuted
+	}
+	if tx.Nonce-accountNonce >= uint64(ts.maxTxsPerSender) {
+		return dataRetriever.ErrTxNonceTooHigh
+	}
+
+	return nil
+}
+
+// isReplacement returns true if the new transaction pays a gas price higher than the one of the pooled transaction
+// by at least the replacement percentage
+func (ts *txSenders) isReplacement(pooled *transaction.Transaction, tx *transaction.Transaction) bool {
+	if tx.GasPrice <= pooled.GasPrice {
+		return false
+	}
+
+	minGasPrice := big.NewInt(0).SetUint64(pooled.GasPrice)
+	minGasPrice.Mul(minGasPrice, big.NewInt(int64(100+ts.replacementGasPricePercentage)))
+	gasPrice := big.NewInt(0).SetUint64(tx.GasPrice)
+	gasPrice.Mul(gasPrice, big.NewInt(100))
+
+	return gasPrice.Cmp(minGasPrice) >= 0
+}
+
+// replacedTxHash returns the hash of the pooled transaction which would be replaced by the given transaction
+func (ts *txSenders) replacedTxHash(tx *transaction.Transaction) []byte {
+	ts.mut.Lock()
+	defer ts.mut.Unlock()
+
+	replaced, err := ts.checkNonceNoLock(tx)
+	if err != nil || replaced == nil {
+		return nil
+	}
+
+	return []byte(replaced.hash)
+}
+
+func (ts *txSenders) addNoLock(ptx *pooledTx) {
+	sender := ts.senders[string(ptx.tx.SndAddr)]
+	if sender == nil {
+		sender = &senderTxs{
+			address:    string(ptx.tx.SndAddr),
+			txs:        make([]*pooledTx, 0),
+			tailsIndex: -1,
+		}
+		ts.senders[sender.address] = sender
+	}
+
+	sender.insert(ptx)
+	ts.updateTailNoLock(sender)
+}
+
+func (ts *txSenders) removeNoLock(ptx *pooledTx) {
+	sender := ts.senders[string(ptx.tx.SndAddr)]
+	if sender == nil || !sender.remove(ptx) {
+		return
+	}
+
+	ts.updateTailNoLock(sender)
+}
+
+// updateTailNoLock moves the sender in the tails heap of the cache holding its last transaction, or drops it once
+// it has no transaction left
+func (ts *txSenders) updateTailNoLock(sender *senderTxs) {
+	if len(sender.txs) == 0 {
+		if sender.tails != nil {
+			heap.Remove(sender.tails, sender.tailsIndex)
+		}
+		delete(ts.senders, sender.address)
+		return
+	}
+
+	tails := &sender.tail().cache.tails
+	if sender.tails == tails {
+		heap.Fix(tails, sender.tailsIndex)
+		return
+	}
+	if sender.tails != nil {
+		heap.Remove(sender.tails, sender.tailsIndex)
+	}
+	heap.Push(tails, sender)
+}
+
+// txCache is the cacher used by the shard stores of a transaction pool. It keeps the transactions of every sender
+// in nonce order, holding the ones after a nonce gap until the gap is filled, replaces a transaction by a new one with the same nonce and a high enough gas
+// price, and when it is full it evicts the lowest paying transaction which is the last one
+// of its sender, so the remaining transactions of that sender can still be executed
+type txCache struct {
+	senders  *txSenders
+	capacity int
+	txs      map[string]*pooledTx
+	tails    senderTails
+
+	mutAddedDataHandlers sync.RWMutex
+	addedDataHandlers    []func(key []byte)
+}
+
+func newTxCache(senders *txSenders, capacity int) *txCache {
+	return &txCache{
+		senders:           senders,
+		capacity:          capacity,
+		txs:               make(map[string]*pooledTx),
+		tails:             make(senderTails, 0),
+		addedDataHandlers: make([]func(key []byte), 0),
+	}
+}
+
+// Clear is used to completely clear the cache.
+func (tc *txCache) Clear() {
+	tc.senders.mut.Lock()
+	for _, ptx := range tc.txs {
+		tc.senders.removeNoLock(ptx)
+	}
+	tc.txs = make(map[string]*pooledTx)
+	tc.senders.mut.Unlock()
+}
+
+// Put adds a transaction to the cache if it passes the pool rules. Returns true if an eviction occurred.
+func (tc *txCache) Put(key []byte, value interface{}) (evicted bool) {
+	_, evicted = tc.HasOrAdd(key, value)
+	return evicted
+}
+
+// Get looks up a key's value from the cache.
+func (tc *txCache) Get(key []byte) (value interface{}, ok bool) {
+	return tc.Peek(key)
+}
+
+// Has checks if a key is in the cache.
+func (tc *txCache) Has(key []byte) bool {
+	tc.senders.mut.Lock()
+	_, ok := tc.txs[string(key)]
+	tc.senders.mut.Unlock()
+
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found).
+func (tc *txCache) Peek(key []byte) (value interface{}, ok bool) {
+	tc.senders.mut.Lock()
+	ptx, ok := tc.txs[string(key)]
+	tc.senders.mut.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+	return ptx.tx, true
+}
+
+// HasOrAdd checks if a key is in the cache and, if not, adds the transaction if it passes the pool rules.
+// Returns whether found and whether an eviction occurred. A rejected transaction is neither found nor added
+func (tc *txCache) HasOrAdd(key []byte, value interface{}) (found, evicted bool) {
+	tc.senders.mut.Lock()
+	if _, ok := tc.txs[string(key)]; ok {
+		tc.senders.mut.Unlock()
+		return true, false
+	}
+
+	evicted, err := tc.addNoLock(key, value)
+	tc.senders.mut.Unlock()
+
+	if err != nil {
+		log.Debug(fmt.Sprintf("transaction not added in pool: %s\n", err.Error()))
+		return false, false
+	}
+
+	tc.callAddedDataHandlers(key)
+
+	return false, evicted
+}
+
+func (tc *txCache) addNoLock(key []byte, value interface{}) (bool, error) {
+	tx, ok := value.(*transaction.Transaction)
+	if !ok || tx == nil {
+		return false, dataRetriever.ErrNotTransaction
+	}
+
+	replaced, err := tc.senders.checkNonceNoLock(tx)
+	if err != nil {
+		return false, err
+	}
+
+	// the replaced transaction is removed only once the new one is certainly added, as it might live in another cache
+	replacedInCache := replaced != nil && replaced.cache == tc
+	evicted := false
+	if len(tc.txs) >= tc.capacity && !replacedInCache {
+		err = tc.evictNoLock(tx)
+		if err != nil {
+			return false, err
+		}
+		evicted = true
+	}
+
+	if replaced != nil {
+		log.Debug(fmt.Sprintf("transaction %s replaced in pool by a higher gas price one\n", core.ToHex([]byte(replaced.hash))))
+		replaced.cache.removeNoLock(replaced)
+	}
+
+	tc.senders.lastSeq++
+	ptx := &pooledTx{
+		hash:  string(key),
+		tx:    tx,
+		cache: tc,
+		seq:   tc.senders.lastSeq,
+	}
+	tc.txs[ptx.hash] = ptx
+	tc.senders.addNoLock(ptx)
+
+	return evicted, nil
+}
+
+// evictNoLock removes the lowest paying transaction of the cache which is the last one of its sender, if it pays
+// less than the transaction which is added
+func (tc *txCache) evictNoLock(tx *transaction.Transaction) error {
+	victim := tc.tails.lowestPaying(string(tx.SndAddr))
+	if victim == nil || victim.tx.GasPrice >= tx.GasPrice {
+		return dataRetriever.ErrTxPoolFull
+	}
+
+	tc.removeNoLock(victim)
+
+	return nil
+}
+
+// isLowerPaying orders the transactions by gas price and, for the same gas price, the newest first
+func isLowerPaying(first *pooledTx, second *pooledTx) bool {
+	if first.tx.GasPrice != second.tx.GasPrice {
+		return first.tx.GasPrice < second.tx.GasPrice
+	}
+
+	return first.seq > second.seq
+}
+
+func (tc *txCache) removeNoLock(ptx *pooledTx) {
+	delete(tc.txs, ptx.hash)
+	tc.senders.removeNoLock(ptx)
+}
+
+// RegisterHandler registers a new handler to be called when a new data is added
+func (tc *txCache) RegisterHandler(handler func(key []byte)) {
+	if handler == nil {
+		log.Error("attempt to register a nil handler to a cacher object")
+		return
+	}
+
+	tc.mutAddedDataHandlers.Lock()
+	tc.addedDataHandlers = append(tc.addedDataHandlers, handler)
+	tc.mutAddedDataHandlers.Unlock()
+}
+
+func (tc *txCache) callAddedDataHandlers(key []byte) {
+	tc.mutAddedDataHandlers.RLock()
+	for _, handler := range tc.addedDataHandlers {
+		go handler(key)
+	}
+	tc.mutAddedDataHandlers.RUnlock()
+}
+
+// Remove removes the provided key from the cache.
+func (tc *txCache) Remove(key []byte) {
+	tc.senders.mut.Lock()
+	ptx, ok := tc.txs[string(key)]
+	if ok {
+		tc.removeNoLock(ptx)
+	}
+	tc.senders.mut.Unlock()
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (tc *txCache) RemoveOldest() {
+	tc.senders.mut.Lock()
+	var oldest *pooledTx
+	for _, ptx := range tc.txs {
+		if oldest == nil || ptx.seq < oldest.seq {
+			oldest = ptx
+		}
+	}
+	if oldest != nil {
+		tc.removeNoLock(oldest)
+	}
+	tc.senders.mut.Unlock()
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest.
+func (tc *txCache) Keys() [][]byte {
+	tc.senders.mut.Lock()
+	ptxs := make([]*pooledTx, 0, len(tc.txs))
+	for _, ptx := range tc.txs {
+		ptxs = append(ptxs, ptx)
+	}
+	tc.senders.mut.Unlock()
+
+	sort.Slice(ptxs, func(i, j int) bool {
+		return ptxs[i].seq < ptxs[j].seq
+	})
+
+	keys := make([][]byte, len(ptxs))
+	for i, ptx := range ptxs {
+		keys[i] = []byte(ptx.hash)
+	}
+
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (tc *txCache) Len() int {
+	tc.senders.mut.Lock()
+	defer tc.senders.mut.Unlock()
+
+	return len(tc.txs)
+}
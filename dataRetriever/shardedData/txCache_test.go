@@ -0,0 +1,359 @@
+package shardedData_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/mock"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever/shardedData"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTxPool(size uint32, maxTxsPerSender uint32, accountNonces map[string]uint64) dataRetriever.ShardedDataCacherNotifier {
+	sd, _ := shardedData.NewShardedTxPool(
+		storageUnit.CacheConfig{Size: size},
+		maxTxsPerSender,
+		10,
+		&mock.AccountNonceProviderStub{
+			GetAccountNonceCalled: func(address []byte) (uint64, error) {
+				nonce, ok := accountNonces[string(address)]
+				if !ok {
+					return 0, state.ErrAccNotFound
+				}
+				return nonce, nil
+			},
+		},
+	)
+
+	return sd
+}
+
+func createTx(sender string, nonce uint64, gasPrice uint64) *transaction.Transaction {
+	return &transaction.Transaction{
+		SndAddr:  []byte(sender),
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+	}
+}
+
+func TestTxCache_AddTxWithExecutedNonceShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{"alice": 5})
+
+	sd.AddData([]byte("tx1"), createTx("alice", 4, 1), "1")
+
+	assert.False(t, sd.ShardDataStore("1").Has([]byte("tx1")))
+}
+
+func TestTxCache_AddTxWithNonceGapShouldBeHeldUntilTheGapIsFilled(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{"alice": 5})
+
+	sd.AddData([]byte("tx7"), createTx("alice", 7, 1), "1")
+	sd.AddData([]byte("tx6"), createTx("alice", 6, 1), "2")
+	sd.AddData([]byte("tx5"), createTx("alice", 5, 1), "1")
+
+	assert.True(t, sd.ShardDataStore("1").Has([]byte("tx7")))
+	assert.True(t, sd.ShardDataStore("2").Has([]byte("tx6")))
+	assert.True(t, sd.ShardDataStore("1").Has([]byte("tx5")))
+}
+
+func TestTxCache_AddTxWithNonceTooFarAheadShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 3, map[string]uint64{"alice": 5})
+
+	sd.AddData([]byte("tx7"), createTx("alice", 7, 1), "1")
+	sd.AddData([]byte("tx8"), createTx("alice", 8, 1), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.True(t, store.Has([]byte("tx7")))
+	assert.False(t, store.Has([]byte("tx8")))
+}
+
+func TestTxCache_AddTxShouldCheckNoncesOverAllShardStores(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{"alice": 5})
+
+	sd.AddData([]byte("tx1"), createTx("alice", 5, 1), "0")
+	sd.AddData([]byte("tx2"), createTx("alice", 6, 1), "1")
+	sd.AddData([]byte("tx3"), createTx("alice", 6, 1), "2")
+
+	assert.True(t, sd.ShardDataStore("0").Has([]byte("tx1")))
+	assert.True(t, sd.ShardDataStore("1").Has([]byte("tx2")))
+	assert.False(t, sd.ShardDataStore("2").Has([]byte("tx3")))
+}
+
+func TestTxCache_AddTxWithDuplicatedNonceShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{"alice": 5})
+
+	sd.AddData([]byte("tx1"), createTx("alice", 5, 1), "1")
+	sd.AddData([]byte("tx2"), createTx("alice", 5, 1), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.True(t, store.Has([]byte("tx1")))
+	assert.False(t, store.Has([]byte("tx2")))
+}
+
+func TestTxCache_AddTxWithSameNonceAndHigherGasPriceShouldReplace(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 2, map[string]uint64{"alice": 5})
+
+	sd.AddData([]byte("tx1"), createTx("alice", 5, 100), "1")
+	sd.AddData([]byte("tx2"), createTx("alice", 6, 100), "1")
+	sd.AddData([]byte("tx3"), createTx("alice", 5, 110), "2")
+
+	assert.False(t, sd.ShardDataStore("1").Has([]byte("tx1")))
+	assert.True(t, sd.ShardDataStore("1").Has([]byte("tx2")))
+	assert.True(t, sd.ShardDataStore("2").Has([]byte("tx3")))
+}
+
+func TestTxCache_AddTxWithSameNonceAndNotEnoughHigherGasPriceShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{"alice": 5})
+
+	sd.AddData([]byte("tx1"), createTx("alice", 5, 100), "1")
+	sd.AddData([]byte("tx2"), createTx("alice", 5, 109), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.True(t, store.Has([]byte("tx1")))
+	assert.False(t, store.Has([]byte("tx2")))
+}
+
+func TestTxCache_ReplacedTxHashShouldReturnTheReplacedTx(t *testing.T) {
+	t.Parallel()
+
+	sd, _ := shardedData.NewShardedTxPool(
+		storageUnit.CacheConfig{Size: 100},
+		10,
+		10,
+		&mock.AccountNonceProviderStub{
+			GetAccountNonceCalled: func(address []byte) (uint64, error) {
+				return 5, nil
+			},
+		},
+	)
+
+	sd.AddData([]byte("tx1"), createTx("alice", 5, 100), "1")
+
+	assert.Equal(t, []byte("tx1"), sd.ReplacedTxHash(createTx("alice", 5, 110)))
+	assert.Nil(t, sd.ReplacedTxHash(createTx("alice", 5, 105)))
+	assert.Nil(t, sd.ReplacedTxHash(createTx("alice", 6, 110)))
+	assert.Nil(t, sd.ReplacedTxHash(createTx("bob", 5, 110)))
+	assert.True(t, sd.ShardDataStore("1").Has([]byte("tx1")))
+}
+
+func TestTxCache_AddTooManyTxsFromSenderShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 2, map[string]uint64{"alice": 0})
+
+	sd.AddData([]byte("tx1"), createTx("alice", 0, 1), "1")
+	sd.AddData([]byte("tx2"), createTx("alice", 1, 1), "1")
+	sd.AddData([]byte("tx3"), createTx("alice", 2, 1), "1")
+
+	assert.Equal(t, 2, sd.ShardDataStore("1").Len())
+	assert.False(t, sd.ShardDataStore("1").Has([]byte("tx3")))
+}
+
+func TestTxCache_AddTxFromUnknownSenderShouldNotCheckNonces(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{})
+
+	sd.AddData([]byte("tx1"), createTx("bob", 7, 1), "1")
+	sd.AddData([]byte("tx2"), createTx("bob", 3, 1), "1")
+
+	assert.Equal(t, 2, sd.ShardDataStore("1").Len())
+}
+
+func TestTxCache_AddTxFromUnknownSenderWithDuplicatedNonceShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{})
+
+	sd.AddData([]byte("tx1"), createTx("bob", 5, 100), "1")
+	sd.AddData([]byte("tx2"), createTx("bob", 5, 100), "2")
+
+	assert.True(t, sd.ShardDataStore("1").Has([]byte("tx1")))
+	assert.False(t, sd.ShardDataStore("2").Has([]byte("tx2")))
+}
+
+func TestTxCache_AddTxFromUnknownSenderWithSameNonceAndHigherGasPriceShouldReplace(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{})
+
+	sd.AddData([]byte("tx1"), createTx("bob", 5, 100), "1")
+	sd.AddData([]byte("tx2"), createTx("bob", 5, 110), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.False(t, store.Has([]byte("tx1")))
+	assert.True(t, store.Has([]byte("tx2")))
+}
+
+func TestTxCache_AddTooManyTxsFromUnknownSenderShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 2, map[string]uint64{})
+
+	sd.AddData([]byte("tx1"), createTx("bob", 0, 1), "1")
+	sd.AddData([]byte("tx2"), createTx("bob", 1, 1), "1")
+	sd.AddData([]byte("tx3"), createTx("bob", 2, 1), "1")
+
+	assert.Equal(t, 2, sd.ShardDataStore("1").Len())
+	assert.False(t, sd.ShardDataStore("1").Has([]byte("tx3")))
+}
+
+func TestTxCache_AddTxWhenNonceProviderFailsShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd, _ := shardedData.NewShardedTxPool(
+		storageUnit.CacheConfig{Size: 100},
+		10,
+		10,
+		&mock.AccountNonceProviderStub{
+			GetAccountNonceCalled: func(address []byte) (uint64, error) {
+				return 0, errors.New("trie error")
+			},
+		},
+	)
+
+	sd.AddData([]byte("tx1"), createTx("alice", 5, 100), "1")
+
+	assert.Equal(t, 0, sd.ShardDataStore("1").Len())
+}
+
+func TestTxCache_AddNotTransactionShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{})
+
+	sd.AddData([]byte("data"), []byte("not a transaction"), "1")
+
+	assert.Equal(t, 0, sd.ShardDataStore("1").Len())
+}
+
+func TestTxCache_FullCacheShouldEvictLowestPayingLastNonce(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(3, 10, map[string]uint64{"alice": 0, "bob": 0, "carol": 0})
+
+	sd.AddData([]byte("alice0"), createTx("alice", 0, 1), "1")
+	sd.AddData([]byte("alice1"), createTx("alice", 1, 5), "1")
+	sd.AddData([]byte("bob0"), createTx("bob", 0, 3), "1")
+	sd.AddData([]byte("carol0"), createTx("carol", 0, 4), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.Equal(t, 3, store.Len())
+	assert.True(t, store.Has([]byte("alice0")))
+	assert.True(t, store.Has([]byte("alice1")))
+	assert.False(t, store.Has([]byte("bob0")))
+	assert.True(t, store.Has([]byte("carol0")))
+}
+
+func TestTxCache_FullCacheShouldNotEvictTheTxsOfTheAddingSender(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(3, 10, map[string]uint64{"alice": 0, "bob": 0})
+
+	sd.AddData([]byte("alice0"), createTx("alice", 0, 1), "1")
+	sd.AddData([]byte("bob0"), createTx("bob", 0, 3), "1")
+	sd.AddData([]byte("bob1"), createTx("bob", 1, 2), "2")
+	sd.AddData([]byte("carol0"), createTx("carol", 0, 4), "1")
+	sd.AddData([]byte("alice1"), createTx("alice", 1, 5), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.Equal(t, 3, store.Len())
+	assert.True(t, store.Has([]byte("alice0")))
+	assert.True(t, store.Has([]byte("alice1")))
+	assert.True(t, store.Has([]byte("bob0")))
+	assert.False(t, store.Has([]byte("carol0")))
+	assert.True(t, sd.ShardDataStore("2").Has([]byte("bob1")))
+}
+
+func TestTxCache_FullCacheShouldRejectLowerPayingTx(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(2, 10, map[string]uint64{"alice": 0, "bob": 0, "carol": 0})
+
+	sd.AddData([]byte("alice0"), createTx("alice", 0, 5), "1")
+	sd.AddData([]byte("bob0"), createTx("bob", 0, 5), "1")
+	sd.AddData([]byte("carol0"), createTx("carol", 0, 5), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.Equal(t, 2, store.Len())
+	assert.False(t, store.Has([]byte("carol0")))
+}
+
+func TestTxCache_FullCacheRejectingReplacementShouldKeepTheReplacedTx(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(2, 10, map[string]uint64{"alice": 0, "bob": 0, "carol": 0})
+
+	sd.AddData([]byte("alice0"), createTx("alice", 0, 100), "2")
+	sd.AddData([]byte("bob0"), createTx("bob", 0, 200), "1")
+	sd.AddData([]byte("carol0"), createTx("carol", 0, 200), "1")
+	sd.AddData([]byte("alice0bis"), createTx("alice", 0, 150), "1")
+
+	assert.True(t, sd.ShardDataStore("2").Has([]byte("alice0")))
+	assert.False(t, sd.ShardDataStore("1").Has([]byte("alice0bis")))
+	assert.Equal(t, 2, sd.ShardDataStore("1").Len())
+}
+
+func TestTxCache_FullCacheReplacingTxInSameCacheShouldNotEvict(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(2, 10, map[string]uint64{"alice": 0, "bob": 0})
+
+	sd.AddData([]byte("alice0"), createTx("alice", 0, 100), "1")
+	sd.AddData([]byte("bob0"), createTx("bob", 0, 1), "1")
+	sd.AddData([]byte("alice0bis"), createTx("alice", 0, 150), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.Equal(t, 2, store.Len())
+	assert.False(t, store.Has([]byte("alice0")))
+	assert.True(t, store.Has([]byte("alice0bis")))
+	assert.True(t, store.Has([]byte("bob0")))
+}
+
+func TestTxCache_RemoveLastNonceShouldAllowItAgain(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{"alice": 0})
+
+	sd.AddData([]byte("tx1"), createTx("alice", 0, 1), "1")
+	sd.AddData([]byte("tx2"), createTx("alice", 1, 1), "1")
+	sd.ShardDataStore("1").Remove([]byte("tx2"))
+	sd.AddData([]byte("tx3"), createTx("alice", 1, 2), "1")
+
+	store := sd.ShardDataStore("1")
+	assert.False(t, store.Has([]byte("tx2")))
+	assert.True(t, store.Has([]byte("tx3")))
+}
+
+func TestTxCache_KeysShouldBeSortedFromOldestToNewest(t *testing.T) {
+	t.Parallel()
+
+	sd := createTxPool(100, 10, map[string]uint64{})
+
+	sd.AddData([]byte("c"), createTx("alice", 0, 1), "1")
+	sd.AddData([]byte("a"), createTx("bob", 0, 1), "1")
+	sd.AddData([]byte("b"), createTx("carol", 0, 1), "1")
+
+	assert.Equal(t, [][]byte{[]byte("c"), []byte("a"), []byte("b")}, sd.ShardDataStore("1").Keys())
+
+	sd.ShardDataStore("1").RemoveOldest()
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, sd.ShardDataStore("1").Keys())
+}
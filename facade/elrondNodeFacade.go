@@ -8,8 +8,10 @@ import (
 
 	"github.com/ElrondNetwork/elrond-go/api"
 	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"github.com/ElrondNetwork/elrond-go/core/logger"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat"
@@ -32,6 +34,7 @@ type ElrondNodeFacade struct {
 	tpsBenchmark           *statistics.TpsBenchmark
 	config                 *config.FacadeConfig
 	restAPIServerDebugMode bool
+	eventsSubscriber       events.Subscriber
 }
 
 // NewElrondNodeFacade creates a new Facade with a NodeWrapper
@@ -55,6 +58,11 @@ func (ef *ElrondNodeFacade) SetLogger(log *logger.Logger) {
 	ef.log = log
 }
 
+// SetEventsSubscriber sets the subscriber used by the events stream
+func (ef *ElrondNodeFacade) SetEventsSubscriber(eventsSubscriber events.Subscriber) {
+	ef.eventsSubscriber = eventsSubscriber
+}
+
 // SetSyncer sets the current syncer
 func (ef *ElrondNodeFacade) SetSyncer(syncer ntp.SyncTimer) {
 	ef.syncer = syncer
@@ -164,6 +172,11 @@ func (ef *ElrondNodeFacade) GetBalance(address string) (*big.Int, error) {
 	return ef.node.GetBalance(address)
 }
 
+// GetBalanceAtBlock gets the balance for a specific address from the state committed by the queried block
+func (ef *ElrondNodeFacade) GetBalanceAtBlock(address string, blockQuery *state.BlockQuery) (*big.Int, error) {
+	return ef.node.GetBalanceAtBlock(address, blockQuery)
+}
+
 // GenerateTransaction generates a transaction from a sender, receiver, value and data
 func (ef *ElrondNodeFacade) GenerateTransaction(senderHex string, receiverHex string, value *big.Int,
 	data string) (*transaction.Transaction,
@@ -181,9 +194,11 @@ func (ef *ElrondNodeFacade) SendTransaction(
 	gasLimit uint64,
 	transactionData string,
 	signature []byte,
-) (string, error) {
+	chainID string,
+	version uint32,
+) (string, string, error) {
 
-	return ef.node.SendTransaction(nonce, senderHex, receiverHex, value, gasPrice, gasLimit, transactionData, signature)
+	return ef.node.SendTransaction(nonce, senderHex, receiverHex, value, gasPrice, gasLimit, transactionData, signature, chainID, version)
 }
 
 // GetTransaction gets the transaction with a specified hash
@@ -191,12 +206,49 @@ func (ef *ElrondNodeFacade) GetTransaction(hash string) (*transaction.Transactio
 	return ef.node.GetTransaction(hash)
 }
 
+// GetTransactionReceipt gets the receipt of the transaction with a specified hash
+func (ef *ElrondNodeFacade) GetTransactionReceipt(hash string) (*receipt.Receipt, error) {
+	return ef.node.GetTransactionReceipt(hash)
+}
+
 // GetAccount returns an accountResponse containing information
 // about the account correlated with provided address
 func (ef *ElrondNodeFacade) GetAccount(address string) (*state.Account, error) {
 	return ef.node.GetAccount(address)
 }
 
+// GetAccountAtBlock returns an accountResponse containing information about the account correlated with
+// provided address, from the state committed by the queried block
+func (ef *ElrondNodeFacade) GetAccountAtBlock(address string, blockQuery *state.BlockQuery) (*state.Account, error) {
+	return ef.node.GetAccountAtBlock(address, blockQuery)
+}
+
+// GetStorageValue returns the value stored at the hex encoded key in the data trie of the account
+func (ef *ElrondNodeFacade) GetStorageValue(address string, key string) ([]byte, error) {
+	return ef.node.GetStorageValue(address, key)
+}
+
+// GetStorageEntries returns at most maxEntries entries from the data trie of the account, starting with the hex
+// encoded start key, and the key of the entry following them, if any
+func (ef *ElrondNodeFacade) GetStorageEntries(
+	address string,
+	startKey string,
+	maxEntries int,
+) ([]*state.StorageEntry, []byte, error) {
+	return ef.node.GetStorageEntries(address, startKey, maxEntries)
+}
+
+// GetAccountProof returns the merkle proof of the account stored at the provided address,
+// against the state root hash of the current block
+func (ef *ElrondNodeFacade) GetAccountProof(address string) (*state.AccountProof, error) {
+	return ef.node.GetAccountProof(address)
+}
+
+// GetAccountKeyProof returns the merkle proofs of the account and of the hex encoded storage key
+func (ef *ElrondNodeFacade) GetAccountKeyProof(address string, key string) (*state.AccountProof, error) {
+	return ef.node.GetAccountKeyProof(address, key)
+}
+
 // GetCurrentPublicKey gets the current nodes public Key
 func (ef *ElrondNodeFacade) GetCurrentPublicKey() string {
 	return ef.node.GetCurrentPublicKey()
@@ -234,11 +286,44 @@ func (ef *ElrondNodeFacade) GetHeartbeats() ([]heartbeat.PubKeyHeartbeat, error)
 	return hbStatus, nil
 }
 
+// SubscribeEvents creates a subscription to the events of the given types
+func (ef *ElrondNodeFacade) SubscribeEvents(eventTypes []events.EventType) (*events.Subscription, error) {
+	if ef.eventsSubscriber == nil {
+		return nil, ErrEventsStreamNotActive
+	}
+
+	return ef.eventsSubscriber.Subscribe(eventTypes)
+}
+
+// UnsubscribeEvents cancels an events subscription
+func (ef *ElrondNodeFacade) UnsubscribeEvents(subscription *events.Subscription) {
+	if ef.eventsSubscriber == nil {
+		return
+	}
+
+	ef.eventsSubscriber.Unsubscribe(subscription)
+}
+
 // GetVmValue retrieves data from existing SC trie
 func (ef *ElrondNodeFacade) GetVmValue(address string, funcName string, argsBuff ...[]byte) ([]byte, error) {
 	return ef.apiResolver.GetVmValue(address, funcName, argsBuff...)
 }
 
+// GetVmValueAtBlock retrieves data from the SC trie as it was committed by the queried block
+func (ef *ElrondNodeFacade) GetVmValueAtBlock(
+	blockQuery *state.BlockQuery,
+	address string,
+	funcName string,
+	argsBuff ...[]byte,
+) ([]byte, error) {
+	accounts, err := ef.node.GetAccountsAtBlock(blockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return ef.apiResolver.GetVmValueFromAccounts(accounts, address, funcName, argsBuff...)
+}
+
 // PprofEnabled returns if profiling mode should be active or not on the application
 func (ef *ElrondNodeFacade) PprofEnabled() bool {
 	return ef.config.PprofEnabled
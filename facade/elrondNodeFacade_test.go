@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/facade/mock"
@@ -355,6 +357,25 @@ func TestElrondFacade_GetTransactionWithValidInputsShouldNotReturnError(t *testi
 	assert.Equal(t, testTx, tx)
 }
 
+func TestElrondFacade_GetTransactionReceiptShouldCallNode(t *testing.T) {
+	testHash := "testHash"
+	testReceipt := &receipt.Receipt{Status: receipt.Executed}
+	node := &mock.NodeMock{
+		GetTransactionReceiptHandler: func(hash string) (*receipt.Receipt, error) {
+			if hash == testHash {
+				return testReceipt, nil
+			}
+			return nil, nil
+		},
+	}
+
+	ef := createElrondNodeFacadeWithMockResolver(node)
+
+	rcpt, err := ef.GetTransactionReceipt(testHash)
+	assert.Nil(t, err)
+	assert.Equal(t, testReceipt, rcpt)
+}
+
 func TestElrondFacade_GetTransactionWithUnknowHashShouldReturnNilAndNoError(t *testing.T) {
 	testHash := "testHash"
 	testTx := &transaction.Transaction{}
@@ -395,12 +416,12 @@ func TestElrondNodeFacade_SetSyncer(t *testing.T) {
 func TestElrondNodeFacade_SendTransaction(t *testing.T) {
 	called := 0
 	node := &mock.NodeMock{}
-	node.SendTransactionHandler = func(nonce uint64, sender string, receiver string, amount *big.Int, code string, signature []byte) (string, error) {
+	node.SendTransactionHandler = func(nonce uint64, sender string, receiver string, amount *big.Int, code string, signature []byte) (string, string, error) {
 		called++
-		return "", nil
+		return "", "", nil
 	}
 	ef := createElrondNodeFacadeWithMockResolver(node)
-	_, _ = ef.SendTransaction(1, "test", "test", big.NewInt(0), 0, 0, "code", []byte{})
+	_, _, _ = ef.SendTransaction(1, "test", "test", big.NewInt(0), 0, 0, "code", []byte{}, "chain ID", 1)
 	assert.Equal(t, called, 1)
 }
 
@@ -416,6 +437,83 @@ func TestElrondNodeFacade_GetAccount(t *testing.T) {
 	assert.Equal(t, called, 1)
 }
 
+func TestElrondNodeFacade_GetStorageValue(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetStorageValueHandler = func(address string, key string) ([]byte, error) {
+		called++
+		return nil, nil
+	}
+	ef := createElrondNodeFacadeWithMockResolver(node)
+	_, _ = ef.GetStorageValue("test", "key")
+	assert.Equal(t, called, 1)
+}
+
+func TestElrondNodeFacade_GetStorageEntries(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetStorageEntriesHandler = func(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error) {
+		called++
+		assert.Equal(t, 10, maxEntries)
+		return nil, nil, nil
+	}
+	ef := createElrondNodeFacadeWithMockResolver(node)
+	_, _, _ = ef.GetStorageEntries("test", "", 10)
+	assert.Equal(t, called, 1)
+}
+
+func TestElrondNodeFacade_GetAccountProof(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetAccountProofHandler = func(address string) (*state.AccountProof, error) {
+		called++
+		return nil, nil
+	}
+	ef := createElrondNodeFacadeWithMockResolver(node)
+	_, _ = ef.GetAccountProof("test")
+	assert.Equal(t, called, 1)
+}
+
+func TestElrondNodeFacade_GetAccountKeyProof(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetAccountKeyProofHandler = func(address string, key string) (*state.AccountProof, error) {
+		called++
+		return nil, nil
+	}
+	ef := createElrondNodeFacadeWithMockResolver(node)
+	_, _ = ef.GetAccountKeyProof("test", "key")
+	assert.Equal(t, called, 1)
+}
+
+func TestElrondNodeFacade_GetAccountAtBlock(t *testing.T) {
+	called := 0
+	blockQuery := &state.BlockQuery{Hash: []byte("hash")}
+	node := &mock.NodeMock{}
+	node.GetAccountAtBlockHandler = func(address string, query *state.BlockQuery) (*state.Account, error) {
+		called++
+		assert.Equal(t, blockQuery, query)
+		return nil, nil
+	}
+	ef := createElrondNodeFacadeWithMockResolver(node)
+	_, _ = ef.GetAccountAtBlock("test", blockQuery)
+	assert.Equal(t, called, 1)
+}
+
+func TestElrondNodeFacade_GetBalanceAtBlock(t *testing.T) {
+	called := 0
+	blockQuery := &state.BlockQuery{Nonce: 2}
+	node := &mock.NodeMock{}
+	node.GetBalanceAtBlockHandler = func(address string, query *state.BlockQuery) (*big.Int, error) {
+		called++
+		assert.Equal(t, blockQuery, query)
+		return nil, nil
+	}
+	ef := createElrondNodeFacadeWithMockResolver(node)
+	_, _ = ef.GetBalanceAtBlock("test", blockQuery)
+	assert.Equal(t, called, 1)
+}
+
 func TestElrondNodeFacade_GetCurrentPublicKey(t *testing.T) {
 	called := 0
 	node := &mock.NodeMock{}
@@ -514,6 +612,57 @@ func TestElrondNodeFacade_GetDataValue(t *testing.T) {
 	assert.True(t, wasCalled)
 }
 
+func TestElrondNodeFacade_GetVmValueAtBlockNodeErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("no such block")
+	ef := NewElrondNodeFacade(
+		&mock.NodeMock{
+			GetAccountsAtBlockHandler: func(blockQuery *state.BlockQuery) (state.AccountsAdapter, error) {
+				return nil, errExpected
+			},
+		},
+		&mock.ApiResolverStub{},
+		false,
+	)
+
+	value, err := ef.GetVmValueAtBlock(&state.BlockQuery{Nonce: 3}, "", "")
+	assert.Nil(t, value)
+	assert.Equal(t, errExpected, err)
+}
+
+func TestElrondNodeFacade_GetVmValueAtBlockShouldUseTheAccountsOfTheQueriedBlock(t *testing.T) {
+	t.Parallel()
+
+	blockQuery := &state.BlockQuery{Nonce: 3}
+	accounts := &state.AccountsDB{}
+	expectedValue := []byte("value")
+	ef := NewElrondNodeFacade(
+		&mock.NodeMock{
+			GetAccountsAtBlockHandler: func(query *state.BlockQuery) (state.AccountsAdapter, error) {
+				assert.Equal(t, blockQuery, query)
+				return accounts, nil
+			},
+		},
+		&mock.ApiResolverStub{
+			GetVmValueFromAccountsHandler: func(
+				accountsAdapter state.AccountsAdapter,
+				address string,
+				funcName string,
+				argsBuff ...[]byte,
+			) ([]byte, error) {
+				assert.True(t, accounts == accountsAdapter)
+				return expectedValue, nil
+			},
+		},
+		false,
+	)
+
+	value, err := ef.GetVmValueAtBlock(blockQuery, "address", "get")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, value)
+}
+
 func TestElrondNodeFacade_RestApiPortNilConfig(t *testing.T) {
 	ef := createElrondNodeFacadeWithMockNodeAndResolver()
 	ef.SetConfig(nil)
@@ -548,3 +697,30 @@ func TestElrondNodeFacade_RestApiPortCorrectPortSpecified(t *testing.T) {
 
 	assert.Equal(t, port, ef.RestApiPort())
 }
+
+func TestElrondNodeFacade_SubscribeEventsWithoutSubscriberShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ef := createElrondNodeFacadeWithMockNodeAndResolver()
+
+	subscription, err := ef.SubscribeEvents(events.AllEventTypes)
+
+	assert.Nil(t, subscription)
+	assert.Equal(t, ErrEventsStreamNotActive, err)
+}
+
+func TestElrondNodeFacade_SubscribeEventsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	ef := createElrondNodeFacadeWithMockNodeAndResolver()
+	eventsDispatcher, _ := events.NewEventsDispatcher(10)
+	ef.SetEventsSubscriber(eventsDispatcher)
+
+	subscription, err := ef.SubscribeEvents(events.AllEventTypes)
+	assert.Nil(t, err)
+	assert.NotNil(t, subscription)
+
+	ef.UnsubscribeEvents(subscription)
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+}
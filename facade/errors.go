@@ -4,3 +4,6 @@ import "github.com/pkg/errors"
 
 // ErrHeartbeatsNotActive signals that the heartbeat system is not active
 var ErrHeartbeatsNotActive = errors.New("heartbeat system not active")
+
+// ErrEventsStreamNotActive signals that the events stream is not active
+var ErrEventsStreamNotActive = errors.New("events stream not active")
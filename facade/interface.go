@@ -3,6 +3,7 @@ package facade
 import (
 	"math/big"
 
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat"
@@ -32,12 +33,16 @@ type NodeWrapper interface {
 	//GenerateTransaction generates a new transaction with sender, receiver, amount and code
 	GenerateTransaction(senderHex string, receiverHex string, amount *big.Int, code string) (*transaction.Transaction, error)
 
-	//SendTransaction will send a new transaction on the topic channel
-	SendTransaction(nonce uint64, senderHex string, receiverHex string, value *big.Int, gasPrice uint64, gasLimit uint64, transactionData string, signature []byte) (string, error)
+	//SendTransaction will send a new transaction on the topic channel and returns its hash and the hash of the
+	//transaction it replaces, if any
+	SendTransaction(nonce uint64, senderHex string, receiverHex string, value *big.Int, gasPrice uint64, gasLimit uint64, transactionData string, signature []byte, chainID string, version uint32) (string, string, error)
 
 	//GetTransaction gets the transaction
 	GetTransaction(hash string) (*transaction.Transaction, error)
 
+	//GetTransactionReceipt gets the receipt of a committed transaction
+	GetTransactionReceipt(hash string) (*receipt.Receipt, error)
+
 	// GetCurrentPublicKey gets the current nodes public Key
 	GetCurrentPublicKey() string
 
@@ -53,6 +58,27 @@ type NodeWrapper interface {
 	//  about the account corelated with provided address
 	GetAccount(address string) (*state.Account, error)
 
+	// GetAccountAtBlock returns the account correlated with provided address from the state of the queried block
+	GetAccountAtBlock(address string, blockQuery *state.BlockQuery) (*state.Account, error)
+
+	//GetBalanceAtBlock returns the balance for a specific address from the state of the queried block
+	GetBalanceAtBlock(address string, blockQuery *state.BlockQuery) (*big.Int, error)
+
+	// GetAccountsAtBlock returns a read-only accounts adapter over the state of the queried block
+	GetAccountsAtBlock(blockQuery *state.BlockQuery) (state.AccountsAdapter, error)
+
+	// GetStorageValue returns the value stored at a key in the data trie of an account
+	GetStorageValue(address string, key string) ([]byte, error)
+
+	// GetStorageEntries returns a page of entries from the data trie of an account and the start key of the next page
+	GetStorageEntries(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error)
+
+	// GetAccountProof returns the merkle proof of the account stored at the provided address
+	GetAccountProof(address string) (*state.AccountProof, error)
+
+	// GetAccountKeyProof returns the merkle proofs of the account and of one of its storage keys
+	GetAccountKeyProof(address string, key string) (*state.AccountProof, error)
+
 	// GetHeartbeats returns the heartbeat status for each public key defined in genesis.json
 	GetHeartbeats() []heartbeat.PubKeyHeartbeat
 }
@@ -60,4 +86,5 @@ type NodeWrapper interface {
 // ApiResolver defines a structure capable of resolving REST API requests
 type ApiResolver interface {
 	GetVmValue(address string, funcName string, argsBuff ...[]byte) ([]byte, error)
+	GetVmValueFromAccounts(accounts state.AccountsAdapter, address string, funcName string, argsBuff ...[]byte) ([]byte, error)
 }
@@ -1,9 +1,21 @@
 package mock
 
+import "github.com/ElrondNetwork/elrond-go/data/state"
+
 type ApiResolverStub struct {
-	GetVmValueHandler func(address string, funcName string, argsBuff ...[]byte) ([]byte, error)
+	GetVmValueHandler             func(address string, funcName string, argsBuff ...[]byte) ([]byte, error)
+	GetVmValueFromAccountsHandler func(accounts state.AccountsAdapter, address string, funcName string, argsBuff ...[]byte) ([]byte, error)
 }
 
 func (ars *ApiResolverStub) GetVmValue(address string, funcName string, argsBuff ...[]byte) ([]byte, error) {
 	return ars.GetVmValueHandler(address, funcName, argsBuff...)
 }
+
+func (ars *ApiResolverStub) GetVmValueFromAccounts(
+	accounts state.AccountsAdapter,
+	address string,
+	funcName string,
+	argsBuff ...[]byte,
+) ([]byte, error) {
+	return ars.GetVmValueFromAccountsHandler(accounts, address, funcName, argsBuff...)
+}
@@ -3,6 +3,7 @@ package mock
 import (
 	"math/big"
 
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/node/heartbeat"
@@ -19,8 +20,16 @@ type NodeMock struct {
 	GetBalanceHandler                              func(address string) (*big.Int, error)
 	GenerateTransactionHandler                     func(sender string, receiver string, amount *big.Int, code string) (*transaction.Transaction, error)
 	GetTransactionHandler                          func(hash string) (*transaction.Transaction, error)
-	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, amount *big.Int, code string, signature []byte) (string, error)
+	GetTransactionReceiptHandler                   func(hash string) (*receipt.Receipt, error)
+	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, amount *big.Int, code string, signature []byte) (string, string, error)
 	GetAccountHandler                              func(address string) (*state.Account, error)
+	GetAccountAtBlockHandler                       func(address string, blockQuery *state.BlockQuery) (*state.Account, error)
+	GetBalanceAtBlockHandler                       func(address string, blockQuery *state.BlockQuery) (*big.Int, error)
+	GetAccountsAtBlockHandler                      func(blockQuery *state.BlockQuery) (state.AccountsAdapter, error)
+	GetStorageValueHandler                         func(address string, key string) ([]byte, error)
+	GetStorageEntriesHandler                       func(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error)
+	GetAccountProofHandler                         func(address string) (*state.AccountProof, error)
+	GetAccountKeyProofHandler                      func(address string, key string) (*state.AccountProof, error)
 	GetCurrentPublicKeyHandler                     func() string
 	GenerateAndSendBulkTransactionsHandler         func(destination string, value *big.Int, nrTransactions uint64) error
 	GenerateAndSendBulkTransactionsOneByOneHandler func(destination string, value *big.Int, nrTransactions uint64) error
@@ -67,7 +76,11 @@ func (nm *NodeMock) GetTransaction(hash string) (*transaction.Transaction, error
 	return nm.GetTransactionHandler(hash)
 }
 
-func (nm *NodeMock) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, transactionData string, signature []byte) (string, error) {
+func (nm *NodeMock) GetTransactionReceipt(hash string) (*receipt.Receipt, error) {
+	return nm.GetTransactionReceiptHandler(hash)
+}
+
+func (nm *NodeMock) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, transactionData string, signature []byte, chainID string, version uint32) (string, string, error) {
 	return nm.SendTransactionHandler(nonce, sender, receiver, value, transactionData, signature)
 }
 
@@ -87,6 +100,34 @@ func (nm *NodeMock) GetAccount(address string) (*state.Account, error) {
 	return nm.GetAccountHandler(address)
 }
 
+func (nm *NodeMock) GetAccountAtBlock(address string, blockQuery *state.BlockQuery) (*state.Account, error) {
+	return nm.GetAccountAtBlockHandler(address, blockQuery)
+}
+
+func (nm *NodeMock) GetBalanceAtBlock(address string, blockQuery *state.BlockQuery) (*big.Int, error) {
+	return nm.GetBalanceAtBlockHandler(address, blockQuery)
+}
+
+func (nm *NodeMock) GetAccountsAtBlock(blockQuery *state.BlockQuery) (state.AccountsAdapter, error) {
+	return nm.GetAccountsAtBlockHandler(blockQuery)
+}
+
+func (nm *NodeMock) GetStorageValue(address string, key string) ([]byte, error) {
+	return nm.GetStorageValueHandler(address, key)
+}
+
+func (nm *NodeMock) GetStorageEntries(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error) {
+	return nm.GetStorageEntriesHandler(address, startKey, maxEntries)
+}
+
+func (nm *NodeMock) GetAccountProof(address string) (*state.AccountProof, error) {
+	return nm.GetAccountProofHandler(address)
+}
+
+func (nm *NodeMock) GetAccountKeyProof(address string, key string) (*state.AccountProof, error) {
+	return nm.GetAccountKeyProofHandler(address, key)
+}
+
 func (nm *NodeMock) GetHeartbeats() []heartbeat.PubKeyHeartbeat {
 	return nm.GetHeartbeatsHandler()
 }
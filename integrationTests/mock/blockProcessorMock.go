@@ -16,12 +16,14 @@ type BlockProcessorMock struct {
 	CommitBlockCalled                func(blockChain data.ChainHandler, header data.HeaderHandler, body data.BodyHandler) error
 	RevertAccountStateCalled         func()
 	CreateBlockCalled                func(round uint64, haveTime func() bool) (data.BodyHandler, error)
+	SetConsensusDataCalled           func(prevHeader data.HeaderHandler, round uint64)
 	RestoreBlockIntoPoolsCalled      func(header data.HeaderHandler, body data.BodyHandler) error
 	CreateBlockHeaderCalled          func(body data.BodyHandler, round uint64, haveTime func() bool) (data.HeaderHandler, error)
 	MarshalizedDataToBroadcastCalled func(header data.HeaderHandler, body data.BodyHandler) (map[uint32][]byte, map[string][][]byte, error)
 	DecodeBlockBodyCalled            func(dta []byte) data.BodyHandler
 	DecodeBlockHeaderCalled          func(dta []byte) data.HeaderHandler
 	AddLastNotarizedHdrCalled        func(shardId uint32, processedHdr data.HeaderHandler)
+	RestoreEpochStateCalled          func(header data.HeaderHandler) error
 }
 
 // ProcessBlock mocks pocessing a block
@@ -44,6 +46,12 @@ func (blProcMock *BlockProcessorMock) CreateBlockBody(round uint64, haveTime fun
 	return blProcMock.CreateBlockCalled(round, haveTime)
 }
 
+func (blProcMock *BlockProcessorMock) SetConsensusData(prevHeader data.HeaderHandler, round uint64) {
+	if blProcMock.SetConsensusDataCalled != nil {
+		blProcMock.SetConsensusDataCalled(prevHeader, round)
+	}
+}
+
 func (blProcMock *BlockProcessorMock) RestoreBlockIntoPools(header data.HeaderHandler, body data.BodyHandler) error {
 	return blProcMock.RestoreBlockIntoPoolsCalled(header, body)
 }
@@ -91,3 +99,12 @@ func (blProcMock BlockProcessorMock) DecodeBlockHeader(dta []byte) data.HeaderHa
 func (blProcMock BlockProcessorMock) AddLastNotarizedHdr(shardId uint32, processedHdr data.HeaderHandler) {
 	blProcMock.AddLastNotarizedHdrCalled(shardId, processedHdr)
 }
+
+// RestoreEpochState mocks the restore of the epoch state from a committed block
+func (blProcMock *BlockProcessorMock) RestoreEpochState(header data.HeaderHandler) error {
+	if blProcMock.RestoreEpochStateCalled == nil {
+		return nil
+	}
+
+	return blProcMock.RestoreEpochStateCalled(header)
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/block"
+)
+
+type EpochHandlerStub struct {
+	EpochCalled                func() uint32
+	EpochForRoundCalled        func(round uint64) uint32
+	IsEpochStartCalled         func(round uint64) bool
+	CreateEpochStartDataCalled func(randomness []byte) ([]block.EpochStartShardData, error)
+	SetEpochStartCalled        func(epoch uint32, epochStart []block.EpochStartShardData) error
+	ProcessPeerInfoCalled      func(peerInfo []block.PeerData) error
+	RestoreEpochStartCalled    func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error
+}
+
+func (ehs *EpochHandlerStub) Epoch() uint32 {
+	if ehs.EpochCalled == nil {
+		return 0
+	}
+	return ehs.EpochCalled()
+}
+
+func (ehs *EpochHandlerStub) EpochForRound(round uint64) uint32 {
+	if ehs.EpochForRoundCalled == nil {
+		return 0
+	}
+	return ehs.EpochForRoundCalled(round)
+}
+
+func (ehs *EpochHandlerStub) IsEpochStart(round uint64) bool {
+	if ehs.IsEpochStartCalled == nil {
+		return false
+	}
+	return ehs.IsEpochStartCalled(round)
+}
+
+func (ehs *EpochHandlerStub) CreateEpochStartData(randomness []byte) ([]block.EpochStartShardData, error) {
+	if ehs.CreateEpochStartDataCalled == nil {
+		return make([]block.EpochStartShardData, 0), nil
+	}
+	return ehs.CreateEpochStartDataCalled(randomness)
+}
+
+func (ehs *EpochHandlerStub) SetEpochStart(epoch uint32, epochStart []block.EpochStartShardData) error {
+	if ehs.SetEpochStartCalled == nil {
+		return nil
+	}
+	return ehs.SetEpochStartCalled(epoch, epochStart)
+}
+
+func (ehs *EpochHandlerStub) ProcessPeerInfo(peerInfo []block.PeerData) error {
+	if ehs.ProcessPeerInfoCalled == nil {
+		return nil
+	}
+	return ehs.ProcessPeerInfoCalled(peerInfo)
+}
+
+func (ehs *EpochHandlerStub) RestoreEpochStart(
+	epoch uint32,
+	epochStart []block.EpochStartShardData,
+	peerInfo []block.PeerData,
+) error {
+	if ehs.RestoreEpochStartCalled == nil {
+		return nil
+	}
+	return ehs.RestoreEpochStartCalled(epoch, epochStart, peerInfo)
+}
@@ -0,0 +1,53 @@
+package mock
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+)
+
+type RewardsHandlerStub struct {
+	CreateBlockStartedCalled     func()
+	CreateRewardsMiniBlockCalled func(round uint64, leaderAddress []byte, signedHeader data.HeaderHandler) (*block.MiniBlock, error)
+	ForwardFeesCalled            func(round uint64, leaderAddress []byte, fees *big.Int) error
+	AccumulatedRewardsCalled     func() *big.Int
+	SaveRewardTxsCalled          func(txHashes [][]byte)
+}
+
+func (rhs *RewardsHandlerStub) CreateBlockStarted() {
+	if rhs.CreateBlockStartedCalled != nil {
+		rhs.CreateBlockStartedCalled()
+	}
+}
+
+func (rhs *RewardsHandlerStub) CreateRewardsMiniBlock(
+	round uint64,
+	leaderAddress []byte,
+	signedHeader data.HeaderHandler,
+) (*block.MiniBlock, error) {
+	if rhs.CreateRewardsMiniBlockCalled == nil {
+		return nil, nil
+	}
+	return rhs.CreateRewardsMiniBlockCalled(round, leaderAddress, signedHeader)
+}
+
+func (rhs *RewardsHandlerStub) ForwardFees(round uint64, leaderAddress []byte, fees *big.Int) error {
+	if rhs.ForwardFeesCalled == nil {
+		return nil
+	}
+	return rhs.ForwardFeesCalled(round, leaderAddress, fees)
+}
+
+func (rhs *RewardsHandlerStub) AccumulatedRewards() *big.Int {
+	if rhs.AccumulatedRewardsCalled == nil {
+		return big.NewInt(0)
+	}
+	return rhs.AccumulatedRewardsCalled()
+}
+
+func (rhs *RewardsHandlerStub) SaveRewardTxs(txHashes [][]byte) {
+	if rhs.SaveRewardTxsCalled != nil {
+		rhs.SaveRewardTxsCalled(txHashes)
+	}
+}
@@ -1,14 +1,16 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/core/statistics"
 )
 
 // ServiceContainerMock is a mock implementation of the Core interface
 type ServiceContainerMock struct {
-	IndexerCalled      func() indexer.Indexer
-	TPSBenchmarkCalled func() statistics.TPSBenchmark
+	IndexerCalled        func() indexer.Indexer
+	TPSBenchmarkCalled   func() statistics.TPSBenchmark
+	EventsNotifierCalled func() events.Notifier
 }
 
 // Indexer returns a mock implementation for core.Indexer
@@ -26,3 +28,11 @@ func (scm *ServiceContainerMock) TPSBenchmark() statistics.TPSBenchmark {
 	}
 	return nil
 }
+
+// EventsNotifier returns a mock implementation for events.Notifier
+func (scm *ServiceContainerMock) EventsNotifier() events.Notifier {
+	if scm.EventsNotifierCalled != nil {
+		return scm.EventsNotifierCalled()
+	}
+	return nil
+}
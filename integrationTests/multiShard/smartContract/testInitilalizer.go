@@ -45,6 +45,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/block"
 	"github.com/ElrondNetwork/elrond-go/process/coordinator"
+	"github.com/ElrondNetwork/elrond-go/process/dataValidators"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
 	metaProcess "github.com/ElrondNetwork/elrond-go/process/factory/metachain"
 	"github.com/ElrondNetwork/elrond-go/process/factory/shard"
@@ -65,6 +66,7 @@ var testHasher = sha256.Sha256{}
 var testMarshalizer = &marshal.JsonMarshalizer{}
 var testAddressConverter, _ = addressConverters.NewPlainAddressConverter(32, "0x")
 var testMultiSig = mock.NewMultiSigner(1)
+var testChainID = []byte("chain ID")
 var rootHash = []byte("root hash")
 var addrConv, _ = addressConverters.NewPlainAddressConverter(32, "0x")
 
@@ -132,6 +134,8 @@ func createTestShardStore(numOfShards uint32) dataRetriever.StorageService {
 	store.AddStorer(dataRetriever.PeerChangesUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
 	store.AddStorer(dataRetriever.UnsignedTransactionUnit, createMemUnit())
+	store.AddStorer(dataRetriever.ReceiptsUnit, createMemUnit())
+	store.AddStorer(dataRetriever.RewardTransactionUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
 
 	for i := uint32(0); i < numOfShards; i++ {
@@ -161,6 +165,9 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 	cacherCfg = storageUnit.CacheConfig{Size: 100000, Type: storageUnit.LRUCache}
 	metaBlocks, _ := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
+	cacherCfg = storageUnit.CacheConfig{Size: 100000, Type: storageUnit.LRUCache}
+	trieNodes, _ := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+
 	dPool, _ := dataPool.NewShardedDataPool(
 		txPool,
 		uTxPool,
@@ -169,6 +176,7 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 		txBlockBody,
 		peerChangeBlockBody,
 		metaBlocks,
+		trieNodes,
 	)
 
 	return dPool
@@ -183,7 +191,7 @@ func createAccountsDB() *state.AccountsDB {
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (wrapper state.AccountHandler, e error) {
 			return state.NewAccount(address, tracker)
 		},
-	})
+	}, nil)
 	return adb
 }
 
@@ -228,6 +236,8 @@ func createNetNode(
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 	dataPacker, _ := partitioning.NewSizeDataPacker(testMarshalizer)
 
+	txValidator, _ := dataValidators.NewNilTxValidator()
+	headerValidator, _ := dataValidators.NewNilHeaderValidator()
 	interceptorContainerFactory, _ := shard.NewInterceptorsContainerFactory(
 		shardCoordinator,
 		messenger,
@@ -240,6 +250,9 @@ func createNetNode(
 		dPool,
 		testAddressConverter,
 		&mock.ChronologyValidatorMock{},
+		txValidator,
+		headerValidator,
+		testChainID,
 	)
 	interceptorsContainer, err := interceptorContainerFactory.Create()
 	if err != nil {
@@ -254,6 +267,7 @@ func createNetNode(
 		dPool,
 		uint64Converter,
 		dataPacker,
+		createMemUnit(),
 	)
 	resolversContainer, _ := resolversContainerFactory.Create()
 	resolversFinder, _ := containers.NewResolversFinder(resolversContainer, shardCoordinator)
@@ -285,6 +299,9 @@ func createNetNode(
 		addrConv,
 		shardCoordinator,
 		scForwarder,
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
 	)
 
 	txProcessor, _ := transaction.NewTxProcessor(
@@ -294,6 +311,11 @@ func createNetNode(
 		testMarshalizer,
 		shardCoordinator,
 		scProcessor,
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		scForwarder,
 	)
 
 	fact, _ := shard.NewPreProcessorsContainerFactory(
@@ -354,6 +376,14 @@ func createNetNode(
 		requestHandler,
 		tc,
 		uint64Converter,
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	_ = blkc.SetGenesisHeader(genesisBlocks[shardCoordinator.SelfId()])
@@ -590,6 +620,7 @@ func createMetaNetNode(
 	store := createTestMetaStore(shardCoordinator)
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 
+	headerValidator, _ := dataValidators.NewNilHeaderValidator()
 	interceptorContainerFactory, _ := metaProcess.NewInterceptorsContainerFactory(
 		shardCoordinator,
 		tn.messenger,
@@ -599,6 +630,7 @@ func createMetaNetNode(
 		testMultiSig,
 		dPool,
 		&mock.ChronologyValidatorMock{},
+		headerValidator,
 	)
 	interceptorsContainer, err := interceptorContainerFactory.Create()
 	if err != nil {
@@ -641,6 +673,9 @@ func createMetaNetNode(
 		genesisBlocks,
 		requestHandler,
 		uint64Converter,
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	_ = tn.blkc.SetGenesisHeader(genesisBlocks[sharding.MetachainShardId])
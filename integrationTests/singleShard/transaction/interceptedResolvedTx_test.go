@@ -54,6 +54,8 @@ func TestNode_RequestInterceptTransactionWithMessenger(t *testing.T) {
 		RcvAddr: integrationTests.TestHasher.Compute("receiver"),
 		SndAddr: buffPk1,
 		Data:    "tx notarized data",
+		ChainID: integrationTests.ChainID,
+		Version: process.MinTransactionVersion,
 	}
 
 	txBuff, _ := integrationTests.TestMarshalizer.Marshal(&tx)
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"math/rand"
 	"runtime"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -317,7 +318,7 @@ func TestAccountsDB_CommitTwoOkAccountsWithRecreationFromStorageShouldWork(t *te
 	fmt.Printf("Data committed! Root: %v\n", base64.StdEncoding.EncodeToString(rootHash))
 
 	tr, _ := trie.NewTrie(mu, integrationTests.TestMarshalizer, integrationTests.TestHasher)
-	adb, _ = state.NewAccountsDB(tr, integrationTests.TestHasher, integrationTests.TestMarshalizer, factory.NewAccountCreator())
+	adb, _ = state.NewAccountsDB(tr, integrationTests.TestHasher, integrationTests.TestMarshalizer, factory.NewAccountCreator(), nil)
 
 	//reloading a new trie to test if data is inside
 	err = adb.RecreateTrie(h)
@@ -409,6 +410,109 @@ func TestAccountsDB_CommitAccountDataShouldWork(t *testing.T) {
 	fmt.Printf("State root - empty: %v\n", base64.StdEncoding.EncodeToString(rootHash))
 }
 
+func TestAccountsDB_WalkAccountsShouldReturnAllAccountsWithTheirData(t *testing.T) {
+	t.Parallel()
+
+	adb, _, _ := integrationTests.CreateAccountsDB(nil)
+
+	addresses := make([][]byte, 0)
+	for i := byte(0); i < 10; i++ {
+		adr := integrationTests.CreateRandomAddress()
+		addresses = append(addresses, adr.Bytes())
+
+		account, err := adb.GetAccountWithJournal(adr)
+		assert.Nil(t, err)
+		err = account.(*state.Account).SetBalanceWithJournal(big.NewInt(int64(i)))
+		assert.Nil(t, err)
+	}
+
+	scAccount, _ := adb.GetAccountWithJournal(state.NewAddress(addresses[0]))
+	err := adb.PutCode(scAccount, []byte("sc code"))
+	assert.Nil(t, err)
+	scAccount.DataTrieTracker().SaveKeyValue([]byte("key"), []byte("value"))
+	err = adb.SaveDataTrie(scAccount)
+	assert.Nil(t, err)
+
+	_, err = adb.Commit()
+	assert.Nil(t, err)
+
+	walkedAddresses := make([][]byte, 0)
+	err = adb.WalkAccounts(func(accountHandler state.AccountHandler) error {
+		walkedAddresses = append(walkedAddresses, accountHandler.AddressContainer().Bytes())
+		if !bytes.Equal(accountHandler.AddressContainer().Bytes(), addresses[0]) {
+			return nil
+		}
+
+		assert.Equal(t, []byte("sc code"), accountHandler.GetCode())
+		val, err := accountHandler.DataTrie().Get([]byte("key"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), val)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i], addresses[j]) < 0
+	})
+	assert.Equal(t, addresses, walkedAddresses)
+}
+
+//------- Pruning
+
+func TestAccountsDB_PruningShouldKeepTheLastStatesAndRemoveTheOlderOnes(t *testing.T) {
+	t.Parallel()
+
+	store := integrationTests.CreateMemUnit()
+	referencesExtractor, _ := state.NewDataTrieReferencesExtractor(integrationTests.TestMarshalizer)
+	pruningStorage, _ := trie.NewPruningStorage(store, integrationTests.TestMarshalizer, referencesExtractor, 2)
+	tr, _ := trie.NewTrie(pruningStorage, integrationTests.TestMarshalizer, integrationTests.TestHasher)
+	adb, _ := state.NewAccountsDB(
+		tr,
+		integrationTests.TestHasher,
+		integrationTests.TestMarshalizer,
+		factory.NewAccountCreator(),
+		pruningStorage,
+	)
+
+	adr := integrationTests.CreateRandomAddress()
+	key := []byte("key")
+	roots := make([][]byte, 0)
+	for i := 0; i < 4; i++ {
+		account, err := adb.GetAccountWithJournal(adr)
+		assert.Nil(t, err)
+		err = account.(*state.Account).SetNonceWithJournal(uint64(i))
+		assert.Nil(t, err)
+		account.DataTrieTracker().SaveKeyValue(key, []byte(fmt.Sprintf("value%d", i)))
+		err = adb.SaveDataTrie(account)
+		assert.Nil(t, err)
+
+		rootHash, err := adb.Commit()
+		assert.Nil(t, err)
+		roots = append(roots, rootHash)
+	}
+
+	pruned := false
+	for i := 0; i < 100 && !pruned; i++ {
+		_, err := tr.Recreate(roots[1])
+		pruned = err != nil
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, pruned)
+
+	for i := 2; i < 4; i++ {
+		err := adb.RecreateTrie(roots[i])
+		assert.Nil(t, err)
+
+		account, err := adb.GetExistingAccount(adr)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(i), account.(*state.Account).Nonce)
+
+		value, err := account.DataTrieTracker().RetrieveValue(key)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("value%d", i)), value)
+	}
+}
+
 //------- Revert
 
 func TestAccountsDB_RevertNonceStepByStepAccountDataShouldWork(t *testing.T) {
@@ -998,7 +1102,7 @@ func createAccounts(
 	cache, _ := storageUnit.NewCache(storageUnit.LRUCache, 10, 1)
 	store, _ := storageUnit.NewStorageUnit(cache, persist)
 	tr, _ := trie.NewTrie(store, integrationTests.TestMarshalizer, integrationTests.TestHasher)
-	adb, _ := state.NewAccountsDB(tr, integrationTests.TestHasher, integrationTests.TestMarshalizer, factory.NewAccountCreator())
+	adb, _ := state.NewAccountsDB(tr, integrationTests.TestHasher, integrationTests.TestMarshalizer, factory.NewAccountCreator(), nil)
 
 	addr := make([]state.AddressContainer, nrOfAccounts)
 	for i := 0; i < nrOfAccounts; i++ {
@@ -109,6 +109,9 @@ func CreateTestShardDataPool(txPool dataRetriever.ShardedDataCacherNotifier) dat
 	cacherCfg = storageUnit.CacheConfig{Size: 100000, Type: storageUnit.LRUCache}
 	metaBlocks, _ := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
+	cacherCfg = storageUnit.CacheConfig{Size: 100000, Type: storageUnit.LRUCache}
+	trieNodes, _ := storageUnit.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+
 	dPool, _ := dataPool.NewShardedDataPool(
 		txPool,
 		uTxPool,
@@ -117,6 +120,7 @@ func CreateTestShardDataPool(txPool dataRetriever.ShardedDataCacherNotifier) dat
 		txBlockBody,
 		peerChangeBlockBody,
 		metaBlocks,
+		trieNodes,
 	)
 
 	return dPool
@@ -165,6 +169,8 @@ func CreateShardStore(numOfShards uint32) dataRetriever.StorageService {
 	store.AddStorer(dataRetriever.PeerChangesUnit, CreateMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, CreateMemUnit())
 	store.AddStorer(dataRetriever.UnsignedTransactionUnit, CreateMemUnit())
+	store.AddStorer(dataRetriever.ReceiptsUnit, CreateMemUnit())
+	store.AddStorer(dataRetriever.RewardTransactionUnit, CreateMemUnit())
 	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, CreateMemUnit())
 
 	for i := uint32(0); i < numOfShards; i++ {
@@ -200,7 +206,7 @@ func CreateAccountsDB(shardCoordinator sharding.Coordinator) (*state.AccountsDB,
 
 	store := CreateMemUnit()
 	tr, _ := trie.NewTrie(store, TestMarshalizer, TestHasher)
-	adb, _ := state.NewAccountsDB(tr, TestHasher, TestMarshalizer, accountFactory)
+	adb, _ := state.NewAccountsDB(tr, TestHasher, TestMarshalizer, accountFactory, nil)
 
 	return adb, tr, store
 }
@@ -421,7 +427,7 @@ func AdbEmulateBalanceTxExecution(acntSrc, acntDest *state.Account, value *big.I
 // CreateSimpleTxProcessor returns a transaction processor
 func CreateSimpleTxProcessor(accnts state.AccountsAdapter) process.TransactionProcessor {
 	shardCoordinator := mock.NewMultiShardsCoordinatorMock(1)
-	txProcessor, _ := txProc.NewTxProcessor(accnts, TestHasher, TestAddressConverter, TestMarshalizer, shardCoordinator, &mock.SCProcessorMock{})
+	txProcessor, _ := txProc.NewTxProcessor(accnts, TestHasher, TestAddressConverter, TestMarshalizer, shardCoordinator, &mock.SCProcessorMock{}, &mock.FeeHandlerStub{}, &mock.TxFeeHandlerStub{}, &mock.StakingHandlerStub{}, &mock.ReceiptsHandlerStub{}, &mock.IntermediateTransactionHandlerMock{})
 
 	return txProcessor
 }
@@ -746,6 +752,8 @@ func generateTx(
 		GasPrice: uint64(args.gasPrice),
 		GasLimit: uint64(args.gasLimit),
 		Data:     args.data,
+		ChainID:  ChainID,
+		Version:  process.MinTransactionVersion,
 	}
 	txBuff, _ := TestMarshalizer.Marshal(tx)
 	tx.Signature, _ = signer.Sign(skSign, txBuff)
@@ -985,9 +993,11 @@ func generateValidTx(
 		node.WithAddressConverter(TestAddressConverter),
 		node.WithKeyGen(signing.NewKeyGenerator(kyber.NewBlakeSHA256Ed25519())),
 		node.WithTxSingleSigner(&singlesig.SchnorrSigner{}),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithTxSignPrivKey(skSender),
 		node.WithTxSignPubKey(pkSender),
 		node.WithAccountsAdapter(accnts),
+		node.WithChainID(ChainID),
 	)
 
 	tx, err := mockNode.GenerateTransaction(
@@ -31,9 +31,11 @@ import (
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/block"
 	"github.com/ElrondNetwork/elrond-go/process/coordinator"
+	"github.com/ElrondNetwork/elrond-go/process/dataValidators"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
 	metaProcess "github.com/ElrondNetwork/elrond-go/process/factory/metachain"
 	"github.com/ElrondNetwork/elrond-go/process/factory/shard"
+	"github.com/ElrondNetwork/elrond-go/process/receipts"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract/hooks"
 	"github.com/ElrondNetwork/elrond-go/process/transaction"
@@ -57,6 +59,9 @@ var TestMultiSig = mock.NewMultiSigner(1)
 // TestUint64Converter represents an uint64 to byte slice converter
 var TestUint64Converter = uint64ByteSlice.NewBigEndianConverter()
 
+// ChainID is the chain ID signed by the transactions of the test networks
+var ChainID = []byte("integration tests chain ID")
+
 // TestProcessorNode represents a container type of class used in integration tests
 // with all its fields exported
 type TestProcessorNode struct {
@@ -69,6 +74,7 @@ type TestProcessorNode struct {
 	MetaDataPool  dataRetriever.MetaPoolsHolder
 	Storage       dataRetriever.StorageService
 	AccntState    state.AccountsAdapter
+	TrieStorage   data.DBWriteCacher
 	BlockChain    data.ChainHandler
 	GenesisBlocks map[uint32]data.HeaderHandler
 
@@ -86,6 +92,7 @@ type TestProcessorNode struct {
 	BlockchainHook         vmcommon.BlockchainHook
 	ArgsParser             process.ArgumentsParser
 	ScProcessor            process.SmartContractProcessor
+	ReceiptsHandler        process.ReceiptsHandler
 	PreProcessorsContainer process.PreProcessorsContainer
 
 	ForkDetector       process.ForkDetector
@@ -143,7 +150,7 @@ func NewTestProcessorNodeWithCustomDataPool(maxShards uint32, nodeShardId uint32
 
 func (tpn *TestProcessorNode) initTestNode() {
 	tpn.initStorage()
-	tpn.AccntState, _, _ = CreateAccountsDB(tpn.ShardCoordinator)
+	tpn.AccntState, _, tpn.TrieStorage = CreateAccountsDB(tpn.ShardCoordinator)
 	tpn.initChainHandler()
 	tpn.GenesisBlocks = CreateGenesisBlocks(tpn.ShardCoordinator)
 	tpn.initInterceptors()
@@ -190,6 +197,7 @@ func (tpn *TestProcessorNode) initChainHandler() {
 func (tpn *TestProcessorNode) initInterceptors() {
 	var err error
 	if tpn.ShardCoordinator.SelfId() == sharding.MetachainShardId {
+		headerValidator, _ := dataValidators.NewNilHeaderValidator()
 		interceptorContainerFactory, _ := metaProcess.NewInterceptorsContainerFactory(
 			tpn.ShardCoordinator,
 			tpn.Messenger,
@@ -199,6 +207,7 @@ func (tpn *TestProcessorNode) initInterceptors() {
 			TestMultiSig,
 			tpn.MetaDataPool,
 			&mock.ChronologyValidatorMock{},
+			headerValidator,
 		)
 
 		tpn.InterceptorsContainer, err = interceptorContainerFactory.Create()
@@ -206,6 +215,8 @@ func (tpn *TestProcessorNode) initInterceptors() {
 			fmt.Println(err.Error())
 		}
 	} else {
+		txValidator, _ := dataValidators.NewNilTxValidator()
+		headerValidator, _ := dataValidators.NewNilHeaderValidator()
 		interceptorContainerFactory, _ := shard.NewInterceptorsContainerFactory(
 			tpn.ShardCoordinator,
 			tpn.Messenger,
@@ -218,6 +229,9 @@ func (tpn *TestProcessorNode) initInterceptors() {
 			tpn.ShardDataPool,
 			TestAddressConverter,
 			&mock.ChronologyValidatorMock{},
+			txValidator,
+			headerValidator,
+			ChainID,
 		)
 
 		tpn.InterceptorsContainer, err = interceptorContainerFactory.Create()
@@ -255,6 +269,7 @@ func (tpn *TestProcessorNode) initResolvers() {
 			tpn.ShardDataPool,
 			TestUint64Converter,
 			dataPacker,
+			tpn.TrieStorage,
 		)
 
 		tpn.ResolversContainer, _ = resolversContainerFactory.Create()
@@ -294,6 +309,7 @@ func (tpn *TestProcessorNode) initInnerProcessors() {
 		}}
 
 	tpn.ArgsParser, _ = smartContract.NewAtArgumentParser()
+	tpn.ReceiptsHandler, _ = receipts.NewReceiptsCollector(tpn.Storage, TestMarshalizer)
 	tpn.ScProcessor, _ = smartContract.NewSmartContractProcessor(
 		vmContainer,
 		tpn.ArgsParser,
@@ -304,6 +320,9 @@ func (tpn *TestProcessorNode) initInnerProcessors() {
 		TestAddressConverter,
 		tpn.ShardCoordinator,
 		tpn.ScrForwarder,
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		tpn.ReceiptsHandler,
 	)
 
 	tpn.TxProcessor, _ = transaction.NewTxProcessor(
@@ -313,6 +332,11 @@ func (tpn *TestProcessorNode) initInnerProcessors() {
 		TestMarshalizer,
 		tpn.ShardCoordinator,
 		tpn.ScProcessor,
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		tpn.ReceiptsHandler,
+		tpn.ScrForwarder,
 	)
 
 	fact, _ := shard.NewPreProcessorsContainerFactory(
@@ -379,6 +403,9 @@ func (tpn *TestProcessorNode) initBlockProcessor() {
 			tpn.GenesisBlocks,
 			tpn.RequestHandler,
 			TestUint64Converter,
+			&mock.EpochHandlerStub{},
+			&mock.TotalSupplyHandlerStub{},
+			&mock.CommitJournalStub{},
 		)
 	} else {
 		tpn.BlockProcessor, err = block.NewShardProcessor(
@@ -395,6 +422,14 @@ func (tpn *TestProcessorNode) initBlockProcessor() {
 			tpn.RequestHandler,
 			tpn.TxCoordinator,
 			TestUint64Converter,
+			&mock.TxFeeHandlerStub{},
+			&mock.SpecialAddressHandlerMock{},
+			&mock.EpochHandlerStub{},
+			&mock.StakingHandlerStub{},
+			&mock.RatingsHandlerStub{},
+			&mock.RewardsHandlerStub{},
+			tpn.ReceiptsHandler,
+			&mock.CommitJournalStub{},
 		)
 	}
 
@@ -432,8 +467,10 @@ func (tpn *TestProcessorNode) initNode() {
 		node.WithResolversFinder(tpn.ResolverFinder),
 		node.WithBlockProcessor(tpn.BlockProcessor),
 		node.WithTxSingleSigner(tpn.OwnAccount.SingleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithDataStore(tpn.Storage),
 		node.WithSyncer(&mock.SyncTimerMock{}),
+		node.WithChainID(ChainID),
 	)
 	if err != nil {
 		fmt.Printf("Error creating node: %s\n", err.Error())
@@ -456,7 +493,7 @@ func (tpn *TestProcessorNode) initNode() {
 
 // SendTransaction can send a transaction (it does the dispatching)
 func (tpn *TestProcessorNode) SendTransaction(tx *dataTransaction.Transaction) (string, error) {
-	txHash, err := tpn.Node.SendTransaction(
+	txHash, _, err := tpn.Node.SendTransaction(
 		tx.Nonce,
 		hex.EncodeToString(tx.SndAddr),
 		hex.EncodeToString(tx.RcvAddr),
@@ -465,6 +502,8 @@ func (tpn *TestProcessorNode) SendTransaction(tx *dataTransaction.Transaction) (
 		tx.GasLimit,
 		tx.Data,
 		tx.Signature,
+		string(tx.ChainID),
+		tx.Version,
 	)
 	return txHash, err
 }
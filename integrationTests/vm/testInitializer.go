@@ -55,7 +55,7 @@ func CreateInMemoryShardAccountsDB() *state.AccountsDB {
 	store := CreateMemUnit()
 
 	tr, _ := trie.NewTrie(store, marsh, testHasher)
-	adb, _ := state.NewAccountsDB(tr, testHasher, marsh, &accountFactory{})
+	adb, _ := state.NewAccountsDB(tr, testHasher, marsh, &accountFactory{}, nil)
 
 	return adb
 }
@@ -91,8 +91,11 @@ func CreateTxProcessorWithOneSCExecutorMockVM(accnts state.AccountsAdapter, opGa
 		addrConv,
 		oneShardCoordinator,
 		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
 	)
-	txProcessor, _ := transaction.NewTxProcessor(accnts, testHasher, addrConv, testMarshalizer, oneShardCoordinator, scProcessor)
+	txProcessor, _ := transaction.NewTxProcessor(accnts, testHasher, addrConv, testMarshalizer, oneShardCoordinator, scProcessor, &mock.FeeHandlerStub{}, &mock.TxFeeHandlerStub{}, &mock.StakingHandlerStub{}, &mock.ReceiptsHandlerStub{}, &mock.IntermediateTransactionHandlerMock{})
 
 	return txProcessor
 }
@@ -135,8 +138,11 @@ func CreateTxProcessorWithOneSCExecutorIeleVM(
 		addrConv,
 		oneShardCoordinator,
 		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
 	)
-	txProcessor, _ := transaction.NewTxProcessor(accnts, testHasher, addrConv, testMarshalizer, oneShardCoordinator, scProcessor)
+	txProcessor, _ := transaction.NewTxProcessor(accnts, testHasher, addrConv, testMarshalizer, oneShardCoordinator, scProcessor, &mock.FeeHandlerStub{}, &mock.TxFeeHandlerStub{}, &mock.StakingHandlerStub{}, &mock.ReceiptsHandlerStub{}, &mock.IntermediateTransactionHandlerMock{})
 
 	return txProcessor, blockChainHook
 }
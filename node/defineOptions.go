@@ -397,3 +397,58 @@ func WithAppStatusHandler(aph core.AppStatusHandler) Option {
 		return ErrNilStatusHandler
 	}
 }
+
+// WithTxFeeHandler sets up the tx fee handler for the Node
+func WithTxFeeHandler(feeHandler process.FeeHandler) Option {
+	return func(n *Node) error {
+		if feeHandler == nil {
+			return ErrNilTxFeeHandler
+		}
+		n.feeHandler = feeHandler
+		return nil
+	}
+}
+
+// WithChainID sets up the chain ID signed by the transactions generated by the node
+func WithChainID(chainID []byte) Option {
+	return func(n *Node) error {
+		if len(chainID) == 0 {
+			return ErrInvalidChainID
+		}
+		n.chainID = chainID
+		return nil
+	}
+}
+
+// WithValidatorGroupSelector sets up the validator group selector used by the consensus
+func WithValidatorGroupSelector(validatorGroupSelector consensus.ValidatorGroupSelector) Option {
+	return func(n *Node) error {
+		if validatorGroupSelector == nil {
+			return ErrNilValidatorGroupSelector
+		}
+		n.validatorGroupSelector = validatorGroupSelector
+		return nil
+	}
+}
+
+// WithStateSyncer sets up the state syncer used when the node can not bootstrap from its storage
+func WithStateSyncer(stateSyncer process.StateSyncer) Option {
+	return func(n *Node) error {
+		if stateSyncer == nil {
+			return ErrNilStateSyncer
+		}
+		n.stateSyncer = stateSyncer
+		return nil
+	}
+}
+
+// WithCommitJournal sets up the journal used to roll back the blocks which were only partially persisted
+func WithCommitJournal(commitJournal process.CommitJournal) Option {
+	return func(n *Node) error {
+		if commitJournal == nil {
+			return ErrNilCommitJournal
+		}
+		n.commitJournal = commitJournal
+		return nil
+	}
+}
@@ -763,3 +763,128 @@ func TestWithAppStatusHandler_OkAshShouldPass(t *testing.T) {
 	assert.IsType(t, &statusHandler.NilStatusHandler{}, node.appStatusHandler)
 	assert.Nil(t, err)
 }
+
+func TestWithTxFeeHandler_NilFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithTxFeeHandler(nil)
+	err := opt(node)
+
+	assert.Nil(t, node.feeHandler)
+	assert.Equal(t, ErrNilTxFeeHandler, err)
+}
+
+func TestWithTxFeeHandler_OkFeeHandlerShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	feeHandler := &mock.FeeHandlerStub{}
+	opt := WithTxFeeHandler(feeHandler)
+	err := opt(node)
+
+	assert.True(t, node.feeHandler == feeHandler)
+	assert.Nil(t, err)
+}
+
+func TestWithChainID_EmptyChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithChainID(nil)
+	err := opt(node)
+
+	assert.Nil(t, node.chainID)
+	assert.Equal(t, ErrInvalidChainID, err)
+}
+
+func TestWithChainID_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	chainID := []byte("chain ID")
+	opt := WithChainID(chainID)
+	err := opt(node)
+
+	assert.Equal(t, chainID, node.chainID)
+	assert.Nil(t, err)
+}
+
+func TestWithValidatorGroupSelector_NilSelectorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithValidatorGroupSelector(nil)
+	err := opt(node)
+
+	assert.Nil(t, node.validatorGroupSelector)
+	assert.Equal(t, ErrNilValidatorGroupSelector, err)
+}
+
+func TestWithValidatorGroupSelector_OkSelectorShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	validatorGroupSelector := &mock.ValidatorGroupSelectorStub{}
+	opt := WithValidatorGroupSelector(validatorGroupSelector)
+	err := opt(node)
+
+	assert.True(t, node.validatorGroupSelector == validatorGroupSelector)
+	assert.Nil(t, err)
+}
+
+func TestWithStateSyncer_NilStateSyncerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithStateSyncer(nil)
+	err := opt(node)
+
+	assert.Nil(t, node.stateSyncer)
+	assert.Equal(t, ErrNilStateSyncer, err)
+}
+
+func TestWithStateSyncer_OkStateSyncerShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	stateSyncer := &mock.StateSyncerStub{}
+	opt := WithStateSyncer(stateSyncer)
+	err := opt(node)
+
+	assert.True(t, node.stateSyncer == stateSyncer)
+	assert.Nil(t, err)
+}
+
+func TestWithCommitJournal_NilCommitJournalShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithCommitJournal(nil)
+	err := opt(node)
+
+	assert.Nil(t, node.commitJournal)
+	assert.Equal(t, ErrNilCommitJournal, err)
+}
+
+func TestWithCommitJournal_OkCommitJournalShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	commitJournal := &mock.CommitJournalStub{}
+	opt := WithCommitJournal(commitJournal)
+	err := opt(node)
+
+	assert.True(t, node.commitJournal == commitJournal)
+	assert.Nil(t, err)
+}
@@ -120,3 +120,30 @@ var ErrSystemBusyGeneratingTransactions = errors.New("system busy while generati
 
 // ErrNilStatusHandler is returned when the status handler is nil
 var ErrNilStatusHandler = errors.New("nil AppStatusHandler")
+
+// ErrNilTxFeeHandler is raised when a valid fee handler is expected but nil used
+var ErrNilTxFeeHandler = errors.New("trying to set a nil tx fee handler")
+
+// ErrNilValidatorGroupSelector is raised when a valid validator group selector is expected but nil used
+var ErrNilValidatorGroupSelector = errors.New("trying to set a nil validator group selector")
+
+// ErrNilStateSyncer is raised when a valid state syncer is expected but nil used
+var ErrNilStateSyncer = errors.New("trying to set a nil state syncer")
+
+// ErrEmptyStorageKey is raised when an empty account storage key is provided
+var ErrEmptyStorageKey = errors.New("empty storage key")
+
+// ErrAccountWithoutDataTrie is raised when a storage key is requested from an account which has no data trie
+var ErrAccountWithoutDataTrie = errors.New("the account has no data trie")
+
+// ErrNilBlockQuery signals that a nil block query has been provided
+var ErrNilBlockQuery = errors.New("nil block query")
+
+// ErrInvalidMaxEntries signals that the maximum number of entries to be returned is not a positive number
+var ErrInvalidMaxEntries = errors.New("the maximum number of entries should be positive")
+
+// ErrNilCommitJournal is raised when a valid commit journal is expected but nil used
+var ErrNilCommitJournal = errors.New("trying to set a nil commit journal")
+
+// ErrInvalidChainID is raised when an empty chain ID is provided
+var ErrInvalidChainID = errors.New("invalid chain ID")
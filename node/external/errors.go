@@ -19,3 +19,12 @@ var ErrNilMarshalizer = errors.New("nil Marshalizer")
 
 // ErrNilScDataGetter signals that a nil data getter has been provided
 var ErrNilScDataGetter = errors.New("nil SC data getter")
+
+// ErrNilScDataGetterFactory signals that a nil data getter factory has been provided
+var ErrNilScDataGetterFactory = errors.New("nil SC data getter factory")
+
+// ErrNilAddressConverter signals that an operation has been attempted to or with a nil AddressConverter implementation
+var ErrNilAddressConverter = errors.New("nil AddressConverter")
+
+// ErrNilAccountsAdapter signals that an operation has been attempted to or with a nil AccountsAdapter implementation
+var ErrNilAccountsAdapter = errors.New("nil AccountsAdapter")
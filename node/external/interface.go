@@ -1,6 +1,13 @@
 package external
 
+import "github.com/ElrondNetwork/elrond-go/data/state"
+
 // ScDataGetter defines how data should be get from a SC account
 type ScDataGetter interface {
 	Get(scAddress []byte, funcName string, args ...[]byte) ([]byte, error)
 }
+
+// ScDataGetterFactory defines how SC data getters reading the state from a given accounts adapter are created
+type ScDataGetterFactory interface {
+	Create(accounts state.AccountsAdapter) (ScDataGetter, error)
+}
@@ -1,18 +1,25 @@
 package external
 
+import "github.com/ElrondNetwork/elrond-go/data/state"
+
 // NodeApiResolver can resolve API requests
 type NodeApiResolver struct {
-	scDataGetter ScDataGetter
+	scDataGetter        ScDataGetter
+	scDataGetterFactory ScDataGetterFactory
 }
 
 // NewNodeApiResolver creates a new NodeApiResolver instance
-func NewNodeApiResolver(scDataGetter ScDataGetter) (*NodeApiResolver, error) {
+func NewNodeApiResolver(scDataGetter ScDataGetter, scDataGetterFactory ScDataGetterFactory) (*NodeApiResolver, error) {
 	if scDataGetter == nil {
 		return nil, ErrNilScDataGetter
 	}
+	if scDataGetterFactory == nil {
+		return nil, ErrNilScDataGetterFactory
+	}
 
 	return &NodeApiResolver{
-		scDataGetter: scDataGetter,
+		scDataGetter:        scDataGetter,
+		scDataGetterFactory: scDataGetterFactory,
 	}, nil
 }
 
@@ -20,3 +27,23 @@ func NewNodeApiResolver(scDataGetter ScDataGetter) (*NodeApiResolver, error) {
 func (nar *NodeApiResolver) GetVmValue(address string, funcName string, argsBuff ...[]byte) ([]byte, error) {
 	return nar.scDataGetter.Get([]byte(address), funcName, argsBuff...)
 }
+
+// GetVmValueFromAccounts retrieves data stored in a SC account through a VM reading the state from the
+// provided accounts adapter
+func (nar *NodeApiResolver) GetVmValueFromAccounts(
+	accounts state.AccountsAdapter,
+	address string,
+	funcName string,
+	argsBuff ...[]byte,
+) ([]byte, error) {
+	if accounts == nil {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	scDataGetter, err := nar.scDataGetterFactory.Create(accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return scDataGetter.Get([]byte(address), funcName, argsBuff...)
+}
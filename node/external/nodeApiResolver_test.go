@@ -3,6 +3,7 @@ package external_test
 import (
 	"testing"
 
+	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/node/external"
 	"github.com/ElrondNetwork/elrond-go/node/mock"
 	"github.com/stretchr/testify/assert"
@@ -11,16 +12,25 @@ import (
 func TestNewNodeApiResolver_NilScDataGetterShouldErr(t *testing.T) {
 	t.Parallel()
 
-	nar, err := external.NewNodeApiResolver(nil)
+	nar, err := external.NewNodeApiResolver(nil, &mock.ScDataGetterFactoryStub{})
 
 	assert.Nil(t, nar)
 	assert.Equal(t, external.ErrNilScDataGetter, err)
 }
 
+func TestNewNodeApiResolver_NilScDataGetterFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	nar, err := external.NewNodeApiResolver(&mock.ScDataGetterStub{}, nil)
+
+	assert.Nil(t, nar)
+	assert.Equal(t, external.ErrNilScDataGetterFactory, err)
+}
+
 func TestNewNodeApiResolver_ShouldWork(t *testing.T) {
 	t.Parallel()
 
-	nar, err := external.NewNodeApiResolver(&mock.ScDataGetterStub{})
+	nar, err := external.NewNodeApiResolver(&mock.ScDataGetterStub{}, &mock.ScDataGetterFactoryStub{})
 
 	assert.NotNil(t, nar)
 	assert.Nil(t, err)
@@ -35,9 +45,50 @@ func TestNodeApiResolver_GetDataValueShouldCall(t *testing.T) {
 			wasCalled = true
 			return make([]byte, 0), nil
 		},
-	})
+	}, &mock.ScDataGetterFactoryStub{})
 
 	_, _ = nar.GetVmValue("", "")
 
 	assert.True(t, wasCalled)
 }
+
+func TestNodeApiResolver_GetVmValueFromAccountsNilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	nar, _ := external.NewNodeApiResolver(&mock.ScDataGetterStub{}, &mock.ScDataGetterFactoryStub{})
+
+	value, err := nar.GetVmValueFromAccounts(nil, "", "")
+
+	assert.Nil(t, value)
+	assert.Equal(t, external.ErrNilAccountsAdapter, err)
+}
+
+func TestNodeApiResolver_GetVmValueFromAccountsShouldUseAGetterOverTheProvidedAccounts(t *testing.T) {
+	t.Parallel()
+
+	accounts := &mock.AccountsStub{}
+	expectedValue := []byte("value")
+	nar, _ := external.NewNodeApiResolver(
+		&mock.ScDataGetterStub{
+			GetCalled: func(scAddress []byte, funcName string, args ...[]byte) ([]byte, error) {
+				assert.Fail(t, "the live state getter should not be called")
+				return nil, nil
+			},
+		},
+		&mock.ScDataGetterFactoryStub{
+			CreateCalled: func(accountsAdapter state.AccountsAdapter) (external.ScDataGetter, error) {
+				assert.True(t, accounts == accountsAdapter)
+				return &mock.ScDataGetterStub{
+					GetCalled: func(scAddress []byte, funcName string, args ...[]byte) ([]byte, error) {
+						return expectedValue, nil
+					},
+				}, nil
+			},
+		},
+	)
+
+	value, err := nar.GetVmValueFromAccounts(accounts, "address", "get")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, value)
+}
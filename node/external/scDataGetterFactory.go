@@ -0,0 +1,49 @@
+package external
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process/factory"
+	"github.com/ElrondNetwork/elrond-go/process/factory/shard"
+	"github.com/ElrondNetwork/elrond-go/process/smartContract"
+)
+
+// scDataGetterFactory creates SC data getters running their own VM over a given accounts adapter
+type scDataGetterFactory struct {
+	addressConverter state.AddressConverter
+}
+
+// NewScDataGetterFactory creates a new scDataGetterFactory instance
+func NewScDataGetterFactory(addressConverter state.AddressConverter) (*scDataGetterFactory, error) {
+	if addressConverter == nil {
+		return nil, ErrNilAddressConverter
+	}
+
+	return &scDataGetterFactory{
+		addressConverter: addressConverter,
+	}, nil
+}
+
+// Create returns a new SC data getter which reads the SC accounts from the provided accounts adapter
+func (sdgf *scDataGetterFactory) Create(accounts state.AccountsAdapter) (ScDataGetter, error) {
+	vmFactory, err := shard.NewVMContainerFactory(accounts, sdgf.addressConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	vmContainer, err := vmFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := vmContainer.Get([]byte(factory.IELEVirtualMachine))
+	if err != nil {
+		return nil, err
+	}
+
+	scDataGetter, err := smartContract.NewSCDataGetter(vm)
+	if err != nil {
+		return nil, err
+	}
+
+	return scDataGetter, nil
+}
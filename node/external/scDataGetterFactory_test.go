@@ -0,0 +1,40 @@
+package external_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/node/external"
+	"github.com/ElrondNetwork/elrond-go/node/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScDataGetterFactory_NilAddressConverterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sdgf, err := external.NewScDataGetterFactory(nil)
+
+	assert.Nil(t, sdgf)
+	assert.Equal(t, external.ErrNilAddressConverter, err)
+}
+
+func TestScDataGetterFactory_CreateShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sdgf, _ := external.NewScDataGetterFactory(mock.NewAddressConverterFake(32, ""))
+
+	scDataGetter, err := sdgf.Create(&mock.AccountsStub{})
+
+	assert.NotNil(t, scDataGetter)
+	assert.Nil(t, err)
+}
+
+func TestScDataGetterFactory_CreateNilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sdgf, _ := external.NewScDataGetterFactory(mock.NewAddressConverterFake(32, ""))
+
+	scDataGetter, err := sdgf.Create(nil)
+
+	assert.Nil(t, scDataGetter)
+	assert.NotNil(t, err)
+}
@@ -19,12 +19,20 @@ type AccountsStub struct {
 	SaveDataTrieCalled          func(acountWrapper state.AccountHandler) error
 	RootHashCalled              func() ([]byte, error)
 	RecreateTrieCalled          func(rootHash []byte) error
+	WalkAccountsCalled          func(handler func(accountHandler state.AccountHandler) error) error
+	ProveCalled                 func(rootHash []byte, key []byte) ([][]byte, error)
+	RecreateReadOnlyCalled      func(rootHash []byte) (state.AccountsAdapter, error)
+	GetDirtyTrieHashesCalled    func() ([][]byte, error)
 }
 
 func (aam *AccountsStub) AddJournalEntry(je state.JournalEntry) {
 	aam.AddJournalEntryCalled(je)
 }
 
+func (aam *AccountsStub) Journalize(entry state.JournalEntry) {
+	aam.AddJournalEntryCalled(entry)
+}
+
 func (aam *AccountsStub) Commit() ([]byte, error) {
 	return aam.CommitCalled()
 }
@@ -76,3 +84,19 @@ func (aam *AccountsStub) RootHash() ([]byte, error) {
 func (aam *AccountsStub) RecreateTrie(rootHash []byte) error {
 	return aam.RecreateTrieCalled(rootHash)
 }
+
+func (aam *AccountsStub) WalkAccounts(handler func(accountHandler state.AccountHandler) error) error {
+	return aam.WalkAccountsCalled(handler)
+}
+
+func (aam *AccountsStub) Prove(rootHash []byte, key []byte) ([][]byte, error) {
+	return aam.ProveCalled(rootHash, key)
+}
+
+func (aam *AccountsStub) RecreateReadOnly(rootHash []byte) (state.AccountsAdapter, error) {
+	return aam.RecreateReadOnlyCalled(rootHash)
+}
+
+func (aam *AccountsStub) GetDirtyTrieHashes() ([][]byte, error) {
+	return aam.GetDirtyTrieHashesCalled()
+}
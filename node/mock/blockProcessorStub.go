@@ -14,6 +14,7 @@ type BlockProcessorStub struct {
 	RevertAccountStateCalled         func()
 	CreateGenesisBlockCalled         func(balances map[string]*big.Int) (data.HeaderHandler, error)
 	CreateBlockBodyCalled            func(round uint64, haveTime func() bool) (data.BodyHandler, error)
+	SetConsensusDataCalled           func(prevHeader data.HeaderHandler, round uint64)
 	RestoreBlockIntoPoolsCalled      func(header data.HeaderHandler, body data.BodyHandler) error
 	SetOnRequestTransactionCalled    func(f func(destShardID uint32, txHash []byte))
 	CreateBlockHeaderCalled          func(body data.BodyHandler, round uint64, haveTime func() bool) (data.HeaderHandler, error)
@@ -21,6 +22,7 @@ type BlockProcessorStub struct {
 	DecodeBlockBodyCalled            func(dta []byte) data.BodyHandler
 	DecodeBlockHeaderCalled          func(dta []byte) data.HeaderHandler
 	AddLastNotarizedHdrCalled        func(shardId uint32, processedHdr data.HeaderHandler)
+	RestoreEpochStateCalled          func(header data.HeaderHandler) error
 }
 
 // ProcessBlock mocks pocessing a block
@@ -48,6 +50,12 @@ func (blProcMock *BlockProcessorStub) CreateBlockBody(round uint64, haveTime fun
 	return blProcMock.CreateBlockBodyCalled(round, haveTime)
 }
 
+func (blProcMock *BlockProcessorStub) SetConsensusData(prevHeader data.HeaderHandler, round uint64) {
+	if blProcMock.SetConsensusDataCalled != nil {
+		blProcMock.SetConsensusDataCalled(prevHeader, round)
+	}
+}
+
 func (blProcMock *BlockProcessorStub) RestoreBlockIntoPools(header data.HeaderHandler, body data.BodyHandler) error {
 	return blProcMock.RestoreBlockIntoPoolsCalled(header, body)
 }
@@ -71,3 +79,12 @@ func (blProcMock BlockProcessorStub) DecodeBlockHeader(dta []byte) data.HeaderHa
 func (blProcMock BlockProcessorStub) AddLastNotarizedHdr(shardId uint32, processedHdr data.HeaderHandler) {
 	blProcMock.AddLastNotarizedHdrCalled(shardId, processedHdr)
 }
+
+// RestoreEpochState mocks the restore of the epoch state from a committed block
+func (blProcMock *BlockProcessorStub) RestoreEpochState(header data.HeaderHandler) error {
+	if blProcMock.RestoreEpochStateCalled == nil {
+		return nil
+	}
+
+	return blProcMock.RestoreEpochStateCalled(header)
+}
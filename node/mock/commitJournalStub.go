@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+)
+
+type CommitJournalStub struct {
+	RecordCalled                   func(nonce uint64, rootHash []byte, unitsKeys map[dataRetriever.UnitType][][]byte) error
+	RollbackIncompleteBlocksCalled func() (int, error)
+}
+
+func (cjs *CommitJournalStub) Record(nonce uint64, rootHash []byte, unitsKeys map[dataRetriever.UnitType][][]byte) error {
+	if cjs.RecordCalled != nil {
+		return cjs.RecordCalled(nonce, rootHash, unitsKeys)
+	}
+
+	return nil
+}
+
+func (cjs *CommitJournalStub) RollbackIncompleteBlocks() (int, error) {
+	if cjs.RollbackIncompleteBlocksCalled != nil {
+		return cjs.RollbackIncompleteBlocksCalled()
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+)
+
+type FeeHandlerStub struct {
+	MinGasPriceCalled           func() uint64
+	ComputeGasLimitCalled       func(tx *transaction.Transaction) uint64
+	ComputeFeeCalled            func(tx *transaction.Transaction) *big.Int
+	CheckValidityTxValuesCalled func(tx *transaction.Transaction) error
+}
+
+func (fhs *FeeHandlerStub) MinGasPrice() uint64 {
+	if fhs.MinGasPriceCalled == nil {
+		return 0
+	}
+	return fhs.MinGasPriceCalled()
+}
+
+func (fhs *FeeHandlerStub) ComputeGasLimit(tx *transaction.Transaction) uint64 {
+	if fhs.ComputeGasLimitCalled == nil {
+		return 0
+	}
+	return fhs.ComputeGasLimitCalled(tx)
+}
+
+func (fhs *FeeHandlerStub) ComputeFee(tx *transaction.Transaction) *big.Int {
+	if fhs.ComputeFeeCalled == nil {
+		return big.NewInt(0)
+	}
+	return fhs.ComputeFeeCalled(tx)
+}
+
+func (fhs *FeeHandlerStub) CheckValidityTxValues(tx *transaction.Transaction) error {
+	if fhs.CheckValidityTxValuesCalled == nil {
+		return nil
+	}
+	return fhs.CheckValidityTxValuesCalled(tx)
+}
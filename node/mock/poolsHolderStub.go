@@ -13,6 +13,7 @@ type PoolsHolderStub struct {
 	UnsignedTransactionsCalled func() dataRetriever.ShardedDataCacherNotifier
 	MiniBlocksCalled           func() storage.Cacher
 	MetaBlocksCalled           func() storage.Cacher
+	TrieNodesCalled            func() storage.Cacher
 	MetaHeadersNoncesCalled    func() dataRetriever.Uint64SyncMapCacher
 }
 
@@ -40,6 +41,10 @@ func (phs *PoolsHolderStub) MetaBlocks() storage.Cacher {
 	return phs.MetaBlocksCalled()
 }
 
+func (phs *PoolsHolderStub) TrieNodes() storage.Cacher {
+	return phs.TrieNodesCalled()
+}
+
 func (phs *PoolsHolderStub) MetaHeadersNonces() dataRetriever.Uint64SyncMapCacher {
 	return phs.MetaHeadersNoncesCalled()
 }
@@ -0,0 +1,14 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/node/external"
+)
+
+type ScDataGetterFactoryStub struct {
+	CreateCalled func(accounts state.AccountsAdapter) (external.ScDataGetter, error)
+}
+
+func (sdgfs *ScDataGetterFactoryStub) Create(accounts state.AccountsAdapter) (external.ScDataGetter, error) {
+	return sdgfs.CreateCalled(accounts)
+}
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
@@ -17,6 +18,7 @@ type ShardedDataStub struct {
 	ClearShardStoreCalled         func(cacheId string)
 	RemoveSetOfDataFromPoolCalled func(keys [][]byte, destCacheId string)
 	CreateShardStoreCalled        func(destCacheId string)
+	ReplacedTxHashCalled          func(tx *transaction.Transaction) []byte
 }
 
 func (sd *ShardedDataStub) RegisterHandler(handler func(key []byte)) {
@@ -66,3 +68,10 @@ func (sd *ShardedDataStub) RemoveSetOfDataFromPool(keys [][]byte, cacheId string
 func (sd *ShardedDataStub) CreateShardStore(cacheId string) {
 	sd.CreateShardStoreCalled(cacheId)
 }
+
+func (sd *ShardedDataStub) ReplacedTxHash(tx *transaction.Transaction) []byte {
+	if sd.ReplacedTxHashCalled != nil {
+		return sd.ReplacedTxHashCalled(tx)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package mock
+
+type StateSyncerStub struct {
+	SyncStateCalled func() error
+}
+
+func (sss *StateSyncerStub) SyncState() error {
+	return sss.SyncStateCalled()
+}
@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/consensus"
+)
+
+type ValidatorGroupSelectorStub struct {
+	ComputeValidatorsGroupCalled func(randomness []byte) ([]consensus.Validator, error)
+	LoadEligibleListCalled       func(eligibleList []consensus.Validator) error
+	GetSelectedPublicKeysCalled  func(selection []byte) ([]string, error)
+	EligibleListCalled           func() []consensus.Validator
+}
+
+func (vgss *ValidatorGroupSelectorStub) GetSelectedPublicKeys(selection []byte) (publicKeys []string, err error) {
+	return vgss.GetSelectedPublicKeysCalled(selection)
+}
+
+func (vgss *ValidatorGroupSelectorStub) LoadEligibleList(eligibleList []consensus.Validator) error {
+	return vgss.LoadEligibleListCalled(eligibleList)
+}
+
+func (vgss *ValidatorGroupSelectorStub) EligibleList() []consensus.Validator {
+	return vgss.EligibleListCalled()
+}
+
+func (vgss *ValidatorGroupSelectorStub) ComputeValidatorsGroup(randomness []byte) (validatorsGroup []consensus.Validator, err error) {
+	return vgss.ComputeValidatorsGroupCalled(randomness)
+}
+
+func (vgss *ValidatorGroupSelectorStub) ConsensusGroupSize() int {
+	panic("implement me")
+}
+
+func (vgss *ValidatorGroupSelectorStub) SetConsensusGroupSize(int) error {
+	panic("implement me")
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
@@ -12,6 +13,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/config"
 	"github.com/ElrondNetwork/elrond-go/consensus"
 	"github.com/ElrondNetwork/elrond-go/consensus/chronology"
+	"github.com/ElrondNetwork/elrond-go/consensus/slashing"
 	"github.com/ElrondNetwork/elrond-go/consensus/spos"
 	"github.com/ElrondNetwork/elrond-go/consensus/spos/sposFactory"
 	"github.com/ElrondNetwork/elrond-go/consensus/validators"
@@ -21,7 +23,10 @@ import (
 	"github.com/ElrondNetwork/elrond-go/core/logger"
 	"github.com/ElrondNetwork/elrond-go/crypto"
 	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/proofVerifier"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/data/typeConverters"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever"
@@ -32,9 +37,11 @@ import (
 	"github.com/ElrondNetwork/elrond-go/p2p"
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
+	"github.com/ElrondNetwork/elrond-go/process/staking"
 	"github.com/ElrondNetwork/elrond-go/process/sync"
 	"github.com/ElrondNetwork/elrond-go/sharding"
 	"github.com/ElrondNetwork/elrond-go/statusHandler"
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 // WaitTime defines the time in milliseconds until node waits the requested info from the network
@@ -86,6 +93,11 @@ type Node struct {
 	txSingleSigner crypto.SingleSigner
 	multiSigner    crypto.MultiSigner
 	forkDetector   process.ForkDetector
+	feeHandler     process.FeeHandler
+
+	validatorGroupSelector consensus.ValidatorGroupSelector
+	stateSyncer            process.StateSyncer
+	commitJournal          process.CommitJournal
 
 	blkc             data.ChainHandler
 	dataPool         dataRetriever.PoolsHolder
@@ -101,6 +113,7 @@ type Node struct {
 	txStorageSize            uint32
 	currentSendingGoRoutines int32
 	bootstrapRoundIndex      uint64
+	chainID                  []byte
 }
 
 // ApplyOptions can set up different configurable options of a Node instance
@@ -265,6 +278,30 @@ func (n *Node) StartConsensus() error {
 		return err
 	}
 
+	validatorGroupSelector := n.validatorGroupSelector
+	if validatorGroupSelector == nil {
+		validatorGroupSelector, err = n.createValidatorGroupSelector()
+		if err != nil {
+			return err
+		}
+	}
+
+	selfPubKey, err := n.pubKey.ToByteArray()
+	if err != nil {
+		return err
+	}
+
+	equivocationDetector, err := slashing.NewEquivocationDetector(
+		n.rounder,
+		n.marshalizer,
+		n,
+		validatorGroupSelector,
+		string(selfPubKey),
+	)
+	if err != nil {
+		return err
+	}
+
 	worker, err := spos.NewWorker(
 		consensusService,
 		n.blockProcessor,
@@ -279,6 +316,7 @@ func (n *Node) StartConsensus() error {
 		n.shardCoordinator,
 		n.singleSigner,
 		n.syncTimer,
+		equivocationDetector,
 	)
 	if err != nil {
 		return err
@@ -289,10 +327,6 @@ func (n *Node) StartConsensus() error {
 		return err
 	}
 
-	validatorGroupSelector, err := n.createValidatorGroupSelector()
-	if err != nil {
-		return err
-	}
 
 	consensusDataContainer, err := spos.NewConsensusCore(
 		n.blkc,
@@ -385,11 +419,29 @@ func (n *Node) GetBalance(addressHex string) (*big.Int, error) {
 		return nil, errors.New("initialize AccountsAdapter and AddressConverter first")
 	}
 
+	return n.getBalance(n.accounts, addressHex)
+}
+
+// GetBalanceAtBlock gets the balance for a specific address from the state committed by the queried block
+func (n *Node) GetBalanceAtBlock(addressHex string, blockQuery *state.BlockQuery) (*big.Int, error) {
+	if n.addrConverter == nil || n.accounts == nil {
+		return nil, errors.New("initialize AccountsAdapter and AddressConverter first")
+	}
+
+	accounts, err := n.GetAccountsAtBlock(blockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.getBalance(accounts, addressHex)
+}
+
+func (n *Node) getBalance(accounts state.AccountsAdapter, addressHex string) (*big.Int, error) {
 	address, err := n.addrConverter.CreateAddressFromHex(addressHex)
 	if err != nil {
 		return nil, errors.New("invalid address, could not decode from hex: " + err.Error())
 	}
-	accWrp, err := n.accounts.GetExistingAccount(address)
+	accWrp, err := accounts.GetExistingAccount(address)
 	if err != nil {
 		return nil, errors.New("could not fetch sender address from provided param: " + err.Error())
 	}
@@ -452,6 +504,8 @@ func (n *Node) createShardBootstrapper(rounder consensus.Rounder) (process.Boots
 		n.shardCoordinator,
 		n.accounts,
 		n.bootstrapRoundIndex,
+		n.stateSyncer,
+		n.commitJournal,
 	)
 	if err != nil {
 		return nil, err
@@ -475,6 +529,7 @@ func (n *Node) createMetaChainBootstrapper(rounder consensus.Rounder) (process.B
 		n.shardCoordinator,
 		n.accounts,
 		n.bootstrapRoundIndex,
+		n.commitJournal,
 	)
 
 	if err != nil {
@@ -527,7 +582,7 @@ func (n *Node) createValidatorGroupSelector() (consensus.ValidatorGroupSelector,
 	}
 
 	for i := 0; i < len(n.initialNodesPubkeys[shID]); i++ {
-		validator, err := validators.NewValidator(big.NewInt(0), 0, []byte(n.initialNodesPubkeys[shID][i]))
+		validator, err := validators.NewValidator(big.NewInt(0), 0, []byte(n.initialNodesPubkeys[shID][i]), nil)
 		if err != nil {
 			return nil, err
 		}
@@ -567,7 +622,8 @@ func (n *Node) createConsensusTopic(messageProcessor p2p.MessageProcessor, shard
 	return n.messenger.RegisterMessageProcessor(n.consensusTopic, messageProcessor)
 }
 
-// SendTransaction will send a new transaction on the topic channel
+// SendTransaction will send a new transaction on the topic channel. Besides the transaction hash, it returns the hash
+// of the pooled transaction with the same sender and nonce replaced by the new one, if the node's pool holds it
 func (n *Node) SendTransaction(
 	nonce uint64,
 	senderHex string,
@@ -576,20 +632,22 @@ func (n *Node) SendTransaction(
 	gasPrice uint64,
 	gasLimit uint64,
 	transactionData string,
-	signature []byte) (string, error) {
+	signature []byte,
+	chainID string,
+	version uint32) (string, string, error) {
 
 	if n.shardCoordinator == nil {
-		return "", ErrNilShardCoordinator
+		return "", "", ErrNilShardCoordinator
 	}
 
 	sender, err := n.addrConverter.CreateAddressFromHex(senderHex)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	receiver, err := n.addrConverter.CreateAddressFromHex(receiverHex)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	senderShardId := n.shardCoordinator.ComputeId(sender)
@@ -603,18 +661,21 @@ func (n *Node) SendTransaction(
 		GasLimit:  gasLimit,
 		Data:      transactionData,
 		Signature: signature,
+		ChainID:   []byte(chainID),
+		Version:   version,
 	}
 
 	txBuff, err := n.marshalizer.Marshal(&tx)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	txHexHash := hex.EncodeToString(n.hasher.Compute(string(txBuff)))
+	replacedTxHexHash := n.replacedTxHexHash(&tx)
 
 	marshalizedTx, err := n.marshalizer.Marshal([][]byte{txBuff})
 	if err != nil {
-		return "", errors.New("could not marshal transaction")
+		return "", "", errors.New("could not marshal transaction")
 	}
 
 	//the topic identifier is made of the current shard id and sender's shard id
@@ -626,7 +687,58 @@ func (n *Node) SendTransaction(
 		marshalizedTx,
 	)
 
-	return txHexHash, nil
+	return txHexHash, replacedTxHexHash, nil
+}
+
+// replacedTxHexHash returns the hex encoded hash of the pooled transaction replaced by the given transaction or an
+// empty string if there is none
+func (n *Node) replacedTxHexHash(tx *transaction.Transaction) string {
+	if n.dataPool == nil {
+		return ""
+	}
+
+	replacementChecker, ok := n.dataPool.Transactions().(dataRetriever.TxReplacementChecker)
+	if !ok {
+		return ""
+	}
+
+	return hex.EncodeToString(replacementChecker.ReplacedTxHash(tx))
+}
+
+// SendSlashingProof sends a transaction holding the slashing proof to the staking account, signed with the
+// transaction sign key of the node. The transaction is broadcast on the topic shared with the metachain
+func (n *Node) SendSlashingProof(proof []byte) error {
+	err := n.generateBulkTransactionsChecks(1)
+	if err != nil {
+		return err
+	}
+
+	nonce, senderAddressBytes, stakingAddressBytes, _, err := n.generateBulkTransactionsPrepareParams(
+		hex.EncodeToString(process.StakingAddress),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, signedTxBuff, err := n.generateAndSignTxBuffArray(
+		nonce,
+		big.NewInt(0),
+		stakingAddressBytes,
+		senderAddressBytes,
+		staking.SlashingTxData(proof),
+	)
+	if err != nil {
+		return err
+	}
+
+	identifier := factory.TransactionTopic + n.shardCoordinator.CommunicationIdentifier(sharding.MetachainShardId)
+	n.messenger.BroadcastOnChannel(
+		SendTransactionsPipe,
+		identifier,
+		signedTxBuff,
+	)
+
+	return nil
 }
 
 //GetTransaction gets the transaction
@@ -634,6 +746,38 @@ func (n *Node) GetTransaction(hash string) (*transaction.Transaction, error) {
 	return nil, fmt.Errorf("not yet implemented")
 }
 
+// GetTransactionReceipt gets the receipt saved for the transaction with the given hex encoded hash. It returns
+// nil if the transaction has no committed receipt
+func (n *Node) GetTransactionReceipt(hash string) (*receipt.Receipt, error) {
+	if n.store == nil {
+		return nil, ErrNilStore
+	}
+	if n.marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+
+	txHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	buff, err := n.store.Get(dataRetriever.ReceiptsUnit, txHash)
+	if err == storage.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rcpt := &receipt.Receipt{}
+	err = n.marshalizer.Unmarshal(rcpt, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return rcpt, nil
+}
+
 // GetCurrentPublicKey will return the current node's public key
 func (n *Node) GetCurrentPublicKey() string {
 	if n.txSignPubKey != nil {
@@ -652,12 +796,117 @@ func (n *Node) GetAccount(address string) (*state.Account, error) {
 		return nil, ErrNilAccountsAdapter
 	}
 
+	return n.getAccount(n.accounts, address)
+}
+
+// GetAccountAtBlock will return account details for a given address from the state committed by the queried block
+func (n *Node) GetAccountAtBlock(address string, blockQuery *state.BlockQuery) (*state.Account, error) {
+	if n.addrConverter == nil {
+		return nil, ErrNilAddressConverter
+	}
+
+	accounts, err := n.GetAccountsAtBlock(blockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.getAccount(accounts, address)
+}
+
+// GetAccountsAtBlock returns a read-only accounts adapter over the state committed by the queried block.
+// The live state of the node is not affected
+func (n *Node) GetAccountsAtBlock(blockQuery *state.BlockQuery) (state.AccountsAdapter, error) {
+	if n.accounts == nil {
+		return nil, ErrNilAccountsAdapter
+	}
+	if blockQuery == nil {
+		return nil, ErrNilBlockQuery
+	}
+
+	header, err := n.getHeaderForBlockQuery(blockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.accounts.RecreateReadOnly(header.GetRootHash())
+}
+
+func (n *Node) getHeaderForBlockQuery(blockQuery *state.BlockQuery) (data.HeaderHandler, error) {
+	if n.blkc == nil {
+		return nil, ErrNilBlockchain
+	}
+	if n.store == nil {
+		return nil, ErrNilStore
+	}
+	if n.marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if n.uint64ByteSliceConverter == nil {
+		return nil, ErrNilUint64ByteSliceConverter
+	}
+	if n.shardCoordinator == nil {
+		return nil, ErrNilShardCoordinator
+	}
+
+	// the shard genesis header is not saved in the headers storage
+	genesisHeader := n.blkc.GetGenesisHeader()
+	isGenesisQuery := bytes.Equal(blockQuery.Hash, n.blkc.GetGenesisHeaderHash()) ||
+		len(blockQuery.Hash) == 0 && blockQuery.Nonce == 0
+	if genesisHeader != nil && isGenesisQuery {
+		return genesisHeader, nil
+	}
+
+	if n.shardCoordinator.SelfId() == sharding.MetachainShardId {
+		return n.getMetaHeaderForBlockQuery(blockQuery)
+	}
+
+	var header *block.Header
+	var err error
+	if len(blockQuery.Hash) > 0 {
+		header, err = process.GetShardHeaderFromStorage(blockQuery.Hash, n.marshalizer, n.store)
+	} else {
+		header, _, err = process.GetShardHeaderFromStorageWithNonce(
+			blockQuery.Nonce,
+			n.shardCoordinator.SelfId(),
+			n.store,
+			n.uint64ByteSliceConverter,
+			n.marshalizer,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (n *Node) getMetaHeaderForBlockQuery(blockQuery *state.BlockQuery) (data.HeaderHandler, error) {
+	var header *block.MetaBlock
+	var err error
+	if len(blockQuery.Hash) > 0 {
+		header, err = process.GetMetaHeaderFromStorage(blockQuery.Hash, n.marshalizer, n.store)
+	} else {
+		header, _, err = process.GetMetaHeaderFromStorageWithNonce(
+			blockQuery.Nonce,
+			n.store,
+			n.uint64ByteSliceConverter,
+			n.marshalizer,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (n *Node) getAccount(accounts state.AccountsAdapter, address string) (*state.Account, error) {
 	addr, err := n.addrConverter.CreateAddressFromHex(address)
 	if err != nil {
 		return nil, err
 	}
 
-	accWrp, err := n.accounts.GetExistingAccount(addr)
+	accWrp, err := accounts.GetExistingAccount(addr)
 	if err != nil {
 		if err == state.ErrAccNotFound {
 			return &state.Account{
@@ -678,6 +927,207 @@ func (n *Node) GetAccount(address string) (*state.Account, error) {
 	return account, nil
 }
 
+// GetStorageValue returns the value stored at the hex encoded key in the data trie of the account. An empty
+// value is returned if the account or the key does not exist
+func (n *Node) GetStorageValue(address string, key string) ([]byte, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyBytes) == 0 {
+		return nil, ErrEmptyStorageKey
+	}
+
+	account, err := n.getExistingAccountHandler(address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.DataTrie() == nil {
+		return make([]byte, 0), nil
+	}
+
+	return account.DataTrieTracker().RetrieveValue(keyBytes)
+}
+
+// GetStorageEntries returns at most maxEntries entries from the data trie of the account, in the ascending order
+// of their keys, starting with the hex encoded start key. If more entries exist, the key of the next entry is
+// returned as well, so that it can be used as the start key of the following page
+func (n *Node) GetStorageEntries(address string, startKey string, maxEntries int) ([]*state.StorageEntry, []byte, error) {
+	if maxEntries <= 0 {
+		return nil, nil, ErrInvalidMaxEntries
+	}
+
+	startKeyBytes, err := hex.DecodeString(startKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	account, err := n.getExistingAccountHandler(address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]*state.StorageEntry, 0)
+	if account == nil || account.DataTrie() == nil {
+		return entries, nil, nil
+	}
+
+	it, err := account.DataTrie().NewLeafIterator(startKeyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for it.Next() {
+		if len(entries) == maxEntries {
+			return entries, it.Key(), nil
+		}
+
+		entries = append(entries, &state.StorageEntry{Key: it.Key(), Value: it.Value()})
+	}
+
+	return entries, nil, it.Error()
+}
+
+// getExistingAccountHandler returns the account with the provided hex encoded address from the state of the
+// current block, with its code and data trie loaded, or nil if the account does not exist
+func (n *Node) getExistingAccountHandler(address string) (state.AccountHandler, error) {
+	if n.addrConverter == nil {
+		return nil, ErrNilAddressConverter
+	}
+
+	addr, err := n.addrConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := n.getCurrentBlockAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := accounts.GetExistingAccount(addr)
+	if err == state.ErrAccNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// getCurrentBlockAccounts returns a read-only accounts adapter over the state committed by the current block, so
+// the reads never see the changes of the block under execution
+func (n *Node) getCurrentBlockAccounts() (state.AccountsAdapter, error) {
+	if n.accounts == nil {
+		return nil, ErrNilAccountsAdapter
+	}
+	if n.blkc == nil {
+		return nil, ErrNilBlockchain
+	}
+
+	header := n.blkc.GetCurrentBlockHeader()
+	if header == nil {
+		header = n.blkc.GetGenesisHeader()
+	}
+	if header == nil {
+		return nil, ErrNilBlockHeader
+	}
+
+	return n.accounts.RecreateReadOnly(header.GetRootHash())
+}
+
+// GetAccountProof returns the merkle proof of the account, valid against the state root hash of the current block
+func (n *Node) GetAccountProof(address string) (*state.AccountProof, error) {
+	return n.getAccountProof(address, nil)
+}
+
+// GetAccountKeyProof returns the merkle proofs of the account and of the hex encoded key from its data trie, valid
+// against the state root hash of the current block
+func (n *Node) GetAccountKeyProof(address string, key string) (*state.AccountProof, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyBytes) == 0 {
+		return nil, ErrEmptyStorageKey
+	}
+
+	return n.getAccountProof(address, keyBytes)
+}
+
+func (n *Node) getAccountProof(address string, key []byte) (*state.AccountProof, error) {
+	if n.addrConverter == nil {
+		return nil, ErrNilAddressConverter
+	}
+	if n.accounts == nil {
+		return nil, ErrNilAccountsAdapter
+	}
+	if n.blkc == nil {
+		return nil, ErrNilBlockchain
+	}
+	if n.marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if n.hasher == nil {
+		return nil, ErrNilHasher
+	}
+
+	addr, err := n.addrConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, err
+	}
+
+	header := n.blkc.GetCurrentBlockHeader()
+	if header == nil {
+		header = n.blkc.GetGenesisHeader()
+	}
+	if header == nil {
+		return nil, ErrNilBlockHeader
+	}
+
+	// the hash is computed from the header instead of being read from the blockchain, which may commit another
+	// block in between
+	headerHash, err := core.CalculateHash(n.marshalizer, n.hasher, header)
+	if err != nil {
+		return nil, err
+	}
+
+	accountProof := &state.AccountProof{
+		HeaderNonce: header.GetNonce(),
+		HeaderHash:  headerHash,
+		RootHash:    header.GetRootHash(),
+		Address:     addr.Bytes(),
+	}
+
+	accountProof.Proof, err = n.accounts.Prove(accountProof.RootHash, accountProof.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return accountProof, nil
+	}
+
+	// the data trie root hash is read from the proven account, as the current account may hold uncommitted changes
+	account, err := proofVerifier.VerifyAccountProof(accountProof, n.marshalizer, n.hasher)
+	if err != nil {
+		return nil, err
+	}
+	if len(account.RootHash) == 0 {
+		return nil, ErrAccountWithoutDataTrie
+	}
+
+	accountProof.Key = key
+	accountProof.DataRootHash = account.RootHash
+	accountProof.DataProof, err = n.accounts.Prove(account.RootHash, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountProof, nil
+}
+
 // StartHeartbeat starts the node's heartbeat processing/signaling module
 func (n *Node) StartHeartbeat(config config.HeartbeatConfig, versionNumber string, nodeDisplayName string) error {
 	if !config.Enabled {
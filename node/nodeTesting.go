@@ -234,6 +234,9 @@ func (n *Node) generateBulkTransactionsChecks(noOfTx uint64) error {
 	if n.accounts == nil {
 		return ErrNilAccountsAdapter
 	}
+	if n.feeHandler == nil {
+		return ErrNilTxFeeHandler
+	}
 
 	return nil
 }
@@ -291,12 +294,16 @@ func (n *Node) generateAndSignSingleTx(
 	}
 
 	tx := transaction.Transaction{
-		Nonce:   nonce,
-		Value:   value,
-		RcvAddr: rcvAddrBytes,
-		SndAddr: sndAddrBytes,
-		Data:    data,
+		Nonce:    nonce,
+		Value:    value,
+		RcvAddr:  rcvAddrBytes,
+		SndAddr:  sndAddrBytes,
+		Data:     data,
+		GasPrice: n.feeHandler.MinGasPrice(),
+		ChainID:  n.chainID,
+		Version:  process.MinTransactionVersion,
 	}
+	tx.GasLimit = n.feeHandler.ComputeGasLimit(&tx)
 
 	marshalizedTx, err := n.marshalizer.Marshal(&tx)
 	if err != nil {
@@ -346,6 +353,9 @@ func (n *Node) GenerateTransaction(senderHex string, receiverHex string, value *
 	if n.txSignPrivKey == nil {
 		return nil, errors.New("initialize PrivateKey first")
 	}
+	if n.feeHandler == nil {
+		return nil, ErrNilTxFeeHandler
+	}
 
 	receiverAddress, err := n.addrConverter.CreateAddressFromHex(receiverHex)
 	if err != nil {
@@ -45,6 +45,7 @@ func TestGenerateAndSendBulkTransactions_NilAccountAdapterShouldErr(t *testing.T
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 	)
 
@@ -52,6 +53,30 @@ func TestGenerateAndSendBulkTransactions_NilAccountAdapterShouldErr(t *testing.T
 	assert.Equal(t, node.ErrNilAccountsAdapter, err)
 }
 
+func TestGenerateAndSendBulkTransactions_NilTxFeeHandlerShouldErr(t *testing.T) {
+	marshalizer := &mock.MarshalizerFake{}
+
+	addrConverter := mock.NewAddressConverterFake(32, "0x")
+	keyGen := &mock.KeyGenMock{}
+	sk, pk := keyGen.GeneratePair()
+	accAdapter := getAccAdapter(big.NewInt(0))
+	singleSigner := &mock.SinglesignMock{}
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(marshalizer),
+		node.WithHasher(mock.HasherMock{}),
+		node.WithAddressConverter(addrConverter),
+		node.WithAccountsAdapter(accAdapter),
+		node.WithTxSignPrivKey(sk),
+		node.WithTxSignPubKey(pk),
+		node.WithTxSingleSigner(singleSigner),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+	)
+
+	err := n.GenerateAndSendBulkTransactions(createDummyHexAddress(64), big.NewInt(0), 1)
+	assert.Equal(t, node.ErrNilTxFeeHandler, err)
+}
+
 func TestGenerateAndSendBulkTransactions_NilSingleSignerShouldErr(t *testing.T) {
 	marshalizer := &mock.MarshalizerFake{}
 
@@ -91,6 +116,7 @@ func TestGenerateAndSendBulkTransactions_NilShardCoordinatorShouldErr(t *testing
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 
 	err := n.GenerateAndSendBulkTransactions(createDummyHexAddress(64), big.NewInt(0), 1)
@@ -111,6 +137,7 @@ func TestGenerateAndSendBulkTransactions_NilAddressConverterShouldErr(t *testing
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 
 	err := n.GenerateAndSendBulkTransactions(createDummyHexAddress(64), big.NewInt(0), 1)
@@ -137,6 +164,7 @@ func TestGenerateAndSendBulkTransactions_NilTransactionPoolShouldErr(t *testing.
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 		node.WithDataPool(dataPool),
 	)
@@ -173,6 +201,7 @@ func TestGenerateAndSendBulkTransactions_TooManyTransactionsInPoolShouldErr(t *t
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 		node.WithDataPool(dataPool),
 		node.WithTxStorageSize(100000),
@@ -207,6 +236,7 @@ func TestGenerateAndSendBulkTransactions_NilPrivateKeyShouldErr(t *testing.T) {
 		node.WithTxSignPubKey(pk),
 		node.WithMarshalizer(&mock.MarshalizerFake{}),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 		node.WithDataPool(dataPool),
 	)
@@ -255,6 +285,7 @@ func TestGenerateAndSendBulkTransactions_InvalidReceiverAddressShouldErr(t *test
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 		node.WithDataPool(dataPool),
 	)
@@ -288,6 +319,7 @@ func TestGenerateAndSendBulkTransactions_CreateAddressFromPublicKeyBytesErrorsSh
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 		node.WithDataPool(dataPool),
 	)
@@ -321,6 +353,7 @@ func TestGenerateAndSendBulkTransactions_MarshalizerErrorsShouldErr(t *testing.T
 		node.WithTxSignPubKey(pk),
 		node.WithMarshalizer(marshalizer),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 		node.WithDataPool(dataPool),
 	)
@@ -398,6 +431,7 @@ func TestGenerateAndSendBulkTransactions_ShouldWork(t *testing.T) {
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithTxSingleSigner(signer),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 		node.WithShardCoordinator(shardCoordinator),
 		node.WithMessenger(mes),
 		node.WithDataPool(dataPool),
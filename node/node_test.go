@@ -16,14 +16,22 @@ import (
 	"github.com/ElrondNetwork/elrond-go/crypto"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/state/proofVerifier"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/data/typeConverters/uint64ByteSlice"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/node"
 	"github.com/ElrondNetwork/elrond-go/node/mock"
 	"github.com/ElrondNetwork/elrond-go/p2p"
 	"github.com/ElrondNetwork/elrond-go/process"
+	procFactory "github.com/ElrondNetwork/elrond-go/process/factory"
 	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -244,6 +252,7 @@ func TestGetBalance_CreateAddressFailsShouldError(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 	_, err := n.GetBalance("address")
 	assert.NotNil(t, err)
@@ -390,6 +399,7 @@ func TestGenerateTransaction_GetAccountFailsShouldError(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(&mock.SinglesignMock{}),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 	_, err := n.GenerateTransaction(createDummyHexAddress(64), createDummyHexAddress(64), big.NewInt(10), "code")
 	assert.NotNil(t, err)
@@ -413,6 +423,7 @@ func TestGenerateTransaction_GetAccountReturnsNilShouldWork(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 	_, err := n.GenerateTransaction(createDummyHexAddress(64), createDummyHexAddress(64), big.NewInt(10), "code")
 	assert.Nil(t, err)
@@ -432,6 +443,7 @@ func TestGenerateTransaction_GetExistingAccountShouldWork(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 	_, err := n.GenerateTransaction(createDummyHexAddress(64), createDummyHexAddress(64), big.NewInt(10), "code")
 	assert.Nil(t, err)
@@ -455,6 +467,7 @@ func TestGenerateTransaction_MarshalErrorsShouldError(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 	_, err := n.GenerateTransaction("sender", "receiver", big.NewInt(10), "code")
 	assert.NotNil(t, err)
@@ -474,6 +487,7 @@ func TestGenerateTransaction_SignTxErrorsShouldError(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 	_, err := n.GenerateTransaction(createDummyHexAddress(64), createDummyHexAddress(64), big.NewInt(10), "code")
 	assert.NotNil(t, err)
@@ -494,6 +508,7 @@ func TestGenerateTransaction_ShouldSetCorrectSignature(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 
 	tx, err := n.GenerateTransaction(createDummyHexAddress(64), createDummyHexAddress(64), big.NewInt(10), "code")
@@ -524,6 +539,7 @@ func TestGenerateTransaction_ShouldSetCorrectNonce(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 
 	tx, err := n.GenerateTransaction(createDummyHexAddress(64), createDummyHexAddress(64), big.NewInt(10), "code")
@@ -545,6 +561,7 @@ func TestGenerateTransaction_CorrectParamsShouldNotError(t *testing.T) {
 		node.WithAccountsAdapter(accAdapter),
 		node.WithTxSignPrivKey(privateKey),
 		node.WithTxSingleSigner(singleSigner),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
 	)
 	_, err := n.GenerateTransaction(createDummyHexAddress(64), createDummyHexAddress(64), big.NewInt(10), "code")
 	assert.Nil(t, err)
@@ -576,11 +593,13 @@ func TestSendTransaction_ShouldWork(t *testing.T) {
 	receiver := createDummyHexAddress(64)
 	txData := "data"
 	signature := []byte("signature")
+	chainID := "chain ID"
+	version := uint32(1)
 
 	senderBuff, _ := adrConverter.CreateAddressFromHex(sender)
 	receiverBuff, _ := adrConverter.CreateAddressFromHex(receiver)
 
-	txHexHashResulted, err := n.SendTransaction(
+	txHexHashResulted, replacedTxHexHash, err := n.SendTransaction(
 		nonce,
 		sender,
 		receiver,
@@ -588,7 +607,9 @@ func TestSendTransaction_ShouldWork(t *testing.T) {
 		0,
 		0,
 		txData,
-		signature)
+		signature,
+		chainID,
+		version)
 
 	marshalizedTx, _ := marshalizer.Marshal(&transaction.Transaction{
 		Nonce:     nonce,
@@ -597,14 +618,112 @@ func TestSendTransaction_ShouldWork(t *testing.T) {
 		RcvAddr:   receiverBuff.Bytes(),
 		Data:      txData,
 		Signature: signature,
+		ChainID:   []byte(chainID),
+		Version:   version,
 	})
 	txHexHashExpected := hex.EncodeToString(hasher.Compute(string(marshalizedTx)))
 
 	assert.Nil(t, err)
 	assert.Equal(t, txHexHashExpected, txHexHashResulted)
+	assert.Empty(t, replacedTxHexHash)
 	assert.True(t, txSent)
 }
 
+func TestSendTransaction_ReplacementShouldReturnReplacedTxHash(t *testing.T) {
+	marshalizer := &mock.MarshalizerFake{}
+	adrConverter := mock.NewAddressConverterFake(32, "0x")
+	replacedTxHash := []byte("replaced tx hash")
+	gasPrice := uint64(110)
+
+	dataPool := &mock.PoolsHolderStub{
+		TransactionsCalled: func() dataRetriever.ShardedDataCacherNotifier {
+			return &mock.ShardedDataStub{
+				ReplacedTxHashCalled: func(tx *transaction.Transaction) []byte {
+					if tx.GasPrice == gasPrice {
+						return replacedTxHash
+					}
+					return nil
+				},
+			}
+		},
+	}
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(marshalizer),
+		node.WithAddressConverter(adrConverter),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithMessenger(&mock.MessengerStub{
+			BroadcastOnChannelCalled: func(pipe string, topic string, buff []byte) {},
+		}),
+		node.WithHasher(&mock.HasherFake{}),
+		node.WithDataPool(dataPool),
+	)
+
+	_, replacedTxHexHash, err := n.SendTransaction(
+		50,
+		createDummyHexAddress(64),
+		createDummyHexAddress(64),
+		big.NewInt(0),
+		gasPrice,
+		0,
+		"",
+		[]byte("signature"),
+		"chain ID",
+		1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(replacedTxHash), replacedTxHexHash)
+}
+
+func TestSendSlashingProof_NilTxSignPublicKeyShouldErr(t *testing.T) {
+	n, _ := node.NewNode(
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+	)
+
+	err := n.SendSlashingProof([]byte("proof"))
+
+	assert.Equal(t, node.ErrNilPublicKey, err)
+}
+
+func TestSendSlashingProof_ShouldSendSlashingTxToStakingAccountOnMetachainTopic(t *testing.T) {
+	marshalizer := &mock.MarshalizerFake{}
+	var sentTx *transaction.Transaction
+	sentTopic := ""
+	mes := &mock.MessengerStub{
+		BroadcastOnChannelCalled: func(pipe string, topic string, buff []byte) {
+			sentTopic = topic
+			txsBuff := make([][]byte, 0)
+			_ = marshalizer.Unmarshal(&txsBuff, buff)
+			sentTx = &transaction.Transaction{}
+			_ = marshalizer.Unmarshal(sentTx, txsBuff[0])
+		},
+	}
+	keyGen := &mock.KeyGenMock{}
+	sk, pk := keyGen.GeneratePair()
+	n, _ := node.NewNode(
+		node.WithMarshalizer(marshalizer),
+		node.WithHasher(mock.HasherMock{}),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(getAccAdapter(big.NewInt(0))),
+		node.WithTxSignPrivKey(sk),
+		node.WithTxSignPubKey(pk),
+		node.WithTxSingleSigner(&mock.SinglesignMock{}),
+		node.WithTxFeeHandler(&mock.FeeHandlerStub{}),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithMessenger(mes),
+	)
+
+	err := n.SendSlashingProof([]byte("proof"))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sentTx)
+	assert.Equal(t, process.StakingAddress, sentTx.RcvAddr)
+	assert.Equal(t, big.NewInt(0), sentTx.Value)
+	assert.Equal(t, "slash@"+hex.EncodeToString([]byte("proof")), sentTx.Data)
+	assert.Equal(t, procFactory.TransactionTopic+"_0_META", sentTopic)
+}
+
 func TestCreateShardedStores_NilShardCoordinatorShouldError(t *testing.T) {
 	messenger := getMessenger()
 	dataPool := &mock.PoolsHolderStub{}
@@ -1315,6 +1434,107 @@ func TestNode_CreateMetaGenesisBlockShouldCreateSaveAndStoreMetaBlock(t *testing
 	assert.Equal(t, savedHeaderHash, storedHeaderKey)
 }
 
+//------- GetTransactionReceipt
+
+func TestNode_GetTransactionReceiptWithNilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+	)
+
+	rcpt, err := n.GetTransactionReceipt("aa")
+
+	assert.Nil(t, rcpt)
+	assert.Equal(t, node.ErrNilStore, err)
+}
+
+func TestNode_GetTransactionReceiptInvalidHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithDataStore(&mock.ChainStorerMock{}),
+	)
+
+	rcpt, err := n.GetTransactionReceipt("not hex")
+
+	assert.Nil(t, rcpt)
+	assert.NotNil(t, err)
+}
+
+func TestNode_GetTransactionReceiptNotFoundShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				return nil, storage.ErrKeyNotFound
+			},
+		}),
+	)
+
+	rcpt, err := n.GetTransactionReceipt("aa")
+
+	assert.Nil(t, rcpt)
+	assert.Nil(t, err)
+}
+
+func TestNode_GetTransactionReceiptStorageErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("storage failure")
+	n, _ := node.NewNode(
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				return nil, errExpected
+			},
+		}),
+	)
+
+	rcpt, err := n.GetTransactionReceipt("aa")
+
+	assert.Nil(t, rcpt)
+	assert.Equal(t, errExpected, err)
+}
+
+func TestNode_GetTransactionReceiptShouldWork(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	savedReceipt := &receipt.Receipt{
+		TxHash:        txHash,
+		Status:        receipt.Executed,
+		BlockNonce:    7,
+		GasUsed:       10,
+		RefundedValue: big.NewInt(5),
+	}
+	marshalizer := &mock.MarshalizerFake{}
+	buff, _ := marshalizer.Marshal(savedReceipt)
+
+	var requestedUnit dataRetriever.UnitType
+	n, _ := node.NewNode(
+		node.WithMarshalizer(marshalizer),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				requestedUnit = unitType
+				if bytes.Equal(key, txHash) {
+					return buff, nil
+				}
+				return nil, storage.ErrKeyNotFound
+			},
+		}),
+	)
+
+	rcpt, err := n.GetTransactionReceipt(hex.EncodeToString(txHash))
+
+	assert.Nil(t, err)
+	assert.Equal(t, dataRetriever.ReceiptsUnit, requestedUnit)
+	assert.Equal(t, savedReceipt, rcpt)
+}
+
 //------- GetAccount
 
 func TestNode_GetAccountWithNilAccountsAdapterShouldErr(t *testing.T) {
@@ -1549,3 +1769,355 @@ func TestNode_AppStatusHandlerShouldSetUInt64Value(t *testing.T) {
 		assert.Fail(t, "Timeout - function not called")
 	}
 }
+
+//------- GetAccountProof
+
+func createAccountsDBWithStorage(address []byte) (*state.AccountsDB, []byte) {
+	db, _ := memorydb.New()
+	tr, _ := trie.NewTrie(db, &mock.MarshalizerFake{}, mock.HasherFake{})
+	adb, _ := state.NewAccountsDB(tr, mock.HasherFake{}, &mock.MarshalizerFake{}, factory.NewAccountCreator(), nil)
+
+	acc, _ := adb.GetAccountWithJournal(state.NewAddress(address))
+	_ = acc.(*state.Account).SetBalanceWithJournal(big.NewInt(100))
+	acc.DataTrieTracker().SaveKeyValue([]byte("key"), []byte("value"))
+	_ = adb.SaveDataTrie(acc)
+	rootHash, _ := adb.Commit()
+
+	return adb, rootHash
+}
+
+func TestNode_GetAccountProofWithNilAccountsAdapterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+	)
+
+	accountProof, err := n.GetAccountProof(createDummyHexAddress(64))
+
+	assert.Nil(t, accountProof)
+	assert.Equal(t, node.ErrNilAccountsAdapter, err)
+}
+
+func TestNode_GetAccountProofNoBlockHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithAccountsAdapter(&mock.AccountsStub{}),
+		node.WithBlockChain(&mock.BlockChainMock{}),
+		node.WithMarshalizer(&mock.MarshalizerMock{}),
+		node.WithHasher(&mock.HasherMock{}),
+	)
+
+	accountProof, err := n.GetAccountProof(createDummyHexAddress(64))
+
+	assert.Nil(t, accountProof)
+	assert.Equal(t, node.ErrNilBlockHeader, err)
+}
+
+func TestNode_GetAccountProofShouldProveAgainstTheCurrentHeaderRootHash(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	header := &block.Header{Nonce: 7, RootHash: rootHash}
+	headerHash, _ := core.CalculateHash(&mock.MarshalizerMock{}, &mock.HasherMock{}, header)
+	proof := [][]byte{[]byte("node1"), []byte("node2")}
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithAccountsAdapter(&mock.AccountsStub{
+			ProveCalled: func(root []byte, key []byte) ([][]byte, error) {
+				assert.Equal(t, rootHash, root)
+				return proof, nil
+			},
+		}),
+		node.WithBlockChain(&mock.BlockChainMock{
+			GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+				return header
+			},
+			GetCurrentBlockHeaderHashCalled: func() []byte {
+				return []byte("hash of a newer header")
+			},
+		}),
+		node.WithMarshalizer(&mock.MarshalizerMock{}),
+		node.WithHasher(&mock.HasherMock{}),
+	)
+
+	accountProof, err := n.GetAccountProof(createDummyHexAddress(64))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), accountProof.HeaderNonce)
+	assert.Equal(t, headerHash, accountProof.HeaderHash)
+	assert.Equal(t, rootHash, accountProof.RootHash)
+	assert.Equal(t, proof, accountProof.Proof)
+	assert.Nil(t, accountProof.DataProof)
+}
+
+func TestNode_GetAccountKeyProofEmptyKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+
+	accountProof, err := n.GetAccountKeyProof(createDummyHexAddress(64), "")
+
+	assert.Nil(t, accountProof)
+	assert.Equal(t, node.ErrEmptyStorageKey, err)
+}
+
+func TestNode_GetAccountKeyProofShouldProveTheAccountAndTheKey(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("12345678901234567890123456789012")
+	adb, rootHash := createAccountsDBWithStorage(address)
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithAccountsAdapter(adb),
+		node.WithBlockChain(&mock.BlockChainMock{
+			GetGenesisHeaderCalled: func() data.HeaderHandler {
+				return &block.Header{RootHash: rootHash}
+			},
+		}),
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithHasher(mock.HasherFake{}),
+	)
+
+	accountProof, err := n.GetAccountKeyProof(hex.EncodeToString(address), hex.EncodeToString([]byte("key")))
+	assert.Nil(t, err)
+
+	val, err := proofVerifier.VerifyAccountKeyProof(accountProof, &mock.MarshalizerFake{}, mock.HasherFake{})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), val)
+}
+
+//------- GetAccountAtBlock
+
+func createMemStorer() storage.Storer {
+	cache, _ := storageUnit.NewCache(storageUnit.LRUCache, 10, 1)
+	db, _ := memorydb.New()
+	storer, _ := storageUnit.NewStorageUnit(cache, db)
+	return storer
+}
+
+func createNodeWithHistoricalState(address []byte) (*node.Node, []byte) {
+	marshalizer := &mock.MarshalizerFake{}
+	db, _ := memorydb.New()
+	tr, _ := trie.NewTrie(db, marshalizer, mock.HasherFake{})
+	adb, _ := state.NewAccountsDB(tr, mock.HasherFake{}, marshalizer, factory.NewAccountCreator(), nil)
+
+	acc, _ := adb.GetAccountWithJournal(state.NewAddress(address))
+	_ = acc.(*state.Account).SetBalanceWithJournal(big.NewInt(100))
+	oldRootHash, _ := adb.Commit()
+
+	acc, _ = adb.GetAccountWithJournal(state.NewAddress(address))
+	_ = acc.(*state.Account).SetBalanceWithJournal(big.NewInt(200))
+	_, _ = adb.Commit()
+
+	converter := uint64ByteSlice.NewBigEndianConverter()
+	headerHash := []byte("header hash")
+	buffHeader, _ := marshalizer.Marshal(&block.Header{Nonce: 5, RootHash: oldRootHash})
+	headers := createMemStorer()
+	_ = headers.Put(headerHash, buffHeader)
+	nonceToHash := createMemStorer()
+	_ = nonceToHash.Put(converter.ToByteSlice(5), headerHash)
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithAccountsAdapter(adb),
+		node.WithBlockChain(&mock.BlockChainMock{}),
+		node.WithMarshalizer(marshalizer),
+		node.WithHasher(mock.HasherFake{}),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithUint64ByteSliceConverter(converter),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+				switch unitType {
+				case dataRetriever.BlockHeaderUnit:
+					return headers
+				case dataRetriever.ShardHdrNonceHashDataUnit:
+					return nonceToHash
+				}
+				return nil
+			},
+		}),
+	)
+
+	return n, headerHash
+}
+
+func TestNode_GetAccountAtBlockNilBlockQueryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithAccountsAdapter(&mock.AccountsStub{}),
+	)
+
+	account, err := n.GetAccountAtBlock(createDummyHexAddress(64), nil)
+
+	assert.Nil(t, account)
+	assert.Equal(t, node.ErrNilBlockQuery, err)
+}
+
+func TestNode_GetAccountAtBlockMissingHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("12345678901234567890123456789012")
+	n, _ := createNodeWithHistoricalState(address)
+
+	account, err := n.GetAccountAtBlock(hex.EncodeToString(address), &state.BlockQuery{Nonce: 6})
+
+	assert.Nil(t, account)
+	assert.NotNil(t, err)
+}
+
+func TestNode_GetAccountAtBlockShouldReadTheStateOfTheQueriedBlock(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("12345678901234567890123456789012")
+	n, headerHash := createNodeWithHistoricalState(address)
+
+	account, err := n.GetAccountAtBlock(hex.EncodeToString(address), &state.BlockQuery{Nonce: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), account.Balance)
+
+	balance, err := n.GetBalanceAtBlock(hex.EncodeToString(address), &state.BlockQuery{Hash: headerHash})
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), balance)
+
+	balance, err = n.GetBalance(hex.EncodeToString(address))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(200), balance)
+}
+
+func TestNode_GetAccountsAtBlockGenesisShouldUseTheGenesisHeader(t *testing.T) {
+	t.Parallel()
+
+	genesisRootHash := []byte("genesis root hash")
+	recreatedRootHash := make([]byte, 0)
+	n, _ := node.NewNode(
+		node.WithAccountsAdapter(&mock.AccountsStub{
+			RecreateReadOnlyCalled: func(rootHash []byte) (state.AccountsAdapter, error) {
+				recreatedRootHash = rootHash
+				return &mock.AccountsStub{}, nil
+			},
+		}),
+		node.WithBlockChain(&mock.BlockChainMock{
+			GetGenesisHeaderCalled: func() data.HeaderHandler {
+				return &block.Header{RootHash: genesisRootHash}
+			},
+		}),
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithUint64ByteSliceConverter(uint64ByteSlice.NewBigEndianConverter()),
+		node.WithDataStore(&mock.ChainStorerMock{}),
+	)
+
+	accounts, err := n.GetAccountsAtBlock(&state.BlockQuery{Nonce: 0})
+
+	assert.NotNil(t, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, genesisRootHash, recreatedRootHash)
+}
+
+//------- GetStorageValue and GetStorageEntries
+
+func createNodeWithStorageEntries(address []byte, entries map[string]string) *node.Node {
+	db, _ := memorydb.New()
+	tr, _ := trie.NewTrie(db, &mock.MarshalizerFake{}, mock.HasherFake{})
+	adb, _ := state.NewAccountsDB(tr, mock.HasherFake{}, &mock.MarshalizerFake{}, factory.NewAccountCreator(), nil)
+
+	acc, _ := adb.GetAccountWithJournal(state.NewAddress(address))
+	for key, value := range entries {
+		acc.DataTrieTracker().SaveKeyValue([]byte(key), []byte(value))
+	}
+	_ = adb.SaveDataTrie(acc)
+	rootHash, _ := adb.Commit()
+
+	// the changes which are not committed by a block should not be visible
+	acc, _ = adb.GetAccountWithJournal(state.NewAddress(address))
+	for key := range entries {
+		acc.DataTrieTracker().SaveKeyValue([]byte(key), []byte("uncommitted"))
+	}
+	acc.DataTrieTracker().SaveKeyValue([]byte("uncommitted"), []byte("uncommitted"))
+	_ = adb.SaveDataTrie(acc)
+
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "")),
+		node.WithAccountsAdapter(adb),
+		node.WithBlockChain(&mock.BlockChainMock{
+			GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+				return &block.Header{RootHash: rootHash}
+			},
+		}),
+	)
+
+	return n
+}
+
+func TestNode_GetStorageValueEmptyKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+
+	value, err := n.GetStorageValue(createDummyHexAddress(64), "")
+
+	assert.Nil(t, value)
+	assert.Equal(t, node.ErrEmptyStorageKey, err)
+}
+
+func TestNode_GetStorageValueShouldReadFromTheDataTrie(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("12345678901234567890123456789012")
+	n := createNodeWithStorageEntries(address, map[string]string{"key": "value"})
+
+	value, err := n.GetStorageValue(hex.EncodeToString(address), hex.EncodeToString([]byte("key")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestNode_GetStorageValueMissingAccountShouldReturnEmptyValue(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("12345678901234567890123456789012")
+	n := createNodeWithStorageEntries(address, map[string]string{"key": "value"})
+
+	value, err := n.GetStorageValue(createDummyHexAddress(64), hex.EncodeToString([]byte("key")))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(value))
+}
+
+func TestNode_GetStorageEntriesInvalidMaxEntriesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode()
+
+	entries, nextKey, err := n.GetStorageEntries(createDummyHexAddress(64), "", 0)
+
+	assert.Nil(t, entries)
+	assert.Nil(t, nextKey)
+	assert.Equal(t, node.ErrInvalidMaxEntries, err)
+}
+
+func TestNode_GetStorageEntriesShouldPaginateTheDataTrie(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("12345678901234567890123456789012")
+	n := createNodeWithStorageEntries(address, map[string]string{"a": "1", "b": "2", "c": "3"})
+
+	entries, nextKey, err := n.GetStorageEntries(hex.EncodeToString(address), "", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*state.StorageEntry{
+		{Key: []byte("a"), Value: []byte("1")},
+		{Key: []byte("b"), Value: []byte("2")},
+	}, entries)
+	assert.Equal(t, []byte("c"), nextKey)
+
+	entries, nextKey, err = n.GetStorageEntries(hex.EncodeToString(address), hex.EncodeToString(nextKey), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*state.StorageEntry{{Key: []byte("c"), Value: []byte("3")}}, entries)
+	assert.Nil(t, nextKey)
+}
@@ -38,6 +38,8 @@ type baseProcessor struct {
 	store              dataRetriever.StorageService
 	uint64Converter    typeConverters.Uint64ByteSliceConverter
 	blockSizeThrottler process.BlockSizeThrottler
+	epochHandler       process.EpochHandler
+	commitJournal      process.CommitJournal
 
 	mutNotarizedHdrs sync.RWMutex
 	notarizedHdrs    mapShardHeaders
@@ -469,6 +471,7 @@ func checkProcessorNilParameters(
 	store dataRetriever.StorageService,
 	shardCoordinator sharding.Coordinator,
 	uint64Converter typeConverters.Uint64ByteSliceConverter,
+	epochHandler process.EpochHandler,
 ) error {
 
 	if accounts == nil {
@@ -492,6 +495,33 @@ func checkProcessorNilParameters(
 	if uint64Converter == nil {
 		return process.ErrNilUint64Converter
 	}
+	if epochHandler == nil {
+		return process.ErrNilEpochHandler
+	}
 
 	return nil
 }
+
+// isPeerInfoEqual checks if two peer data lists hold the same peer changes, in the same order
+func isPeerInfoEqual(first []block.PeerData, second []block.PeerData) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for i := 0; i < len(first); i++ {
+		if !bytes.Equal(first[i].PublicKey, second[i].PublicKey) {
+			return false
+		}
+		if first[i].Action != second[i].Action || first[i].TimeStamp != second[i].TimeStamp {
+			return false
+		}
+		if !bytes.Equal(first[i].Address, second[i].Address) {
+			return false
+		}
+		if first[i].Value == nil || second[i].Value == nil || first[i].Value.Cmp(second[i].Value) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
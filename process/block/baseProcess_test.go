@@ -3,6 +3,7 @@ package block_test
 import (
 	"bytes"
 	"errors"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -323,6 +324,14 @@ func TestBlockProcessor_CheckBlockValidity(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blkc := createTestBlockchain()
 	body := &block.Body{}
@@ -405,6 +414,14 @@ func TestVerifyStateRoot_ShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.True(t, bp.VerifyStateRoot(rootHash))
 }
@@ -429,6 +446,14 @@ func TestBlockProcessor_computeHeaderHashMarshalizerFail1ShouldErr(t *testing.T)
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr, txBlock := createTestHdrTxBlockBody()
 	expectedError := errors.New("marshalizer fail")
@@ -465,6 +490,14 @@ func TestBlockPorcessor_ComputeNewNoncePrevHashShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr, txBlock := createTestHdrTxBlockBody()
 	marshalizer.MarshalCalled = func(obj interface{}) (bytes []byte, e error) {
@@ -815,3 +848,64 @@ func TestBaseProcessor_SaveLastNoterizedHdrMetaGood(t *testing.T) {
 
 	assert.Equal(t, highestNonce, base.LastNotarizedHdrForShard(sharding.MetachainShardId).GetNonce())
 }
+
+func createPeerInfo() []block.PeerData {
+	return []block.PeerData{
+		{
+			PublicKey: []byte("pk1"),
+			Action:    block.PeerRegistrantion,
+			TimeStamp: 10,
+			Value:     big.NewInt(1000),
+			Address:   []byte("address1"),
+		},
+		{
+			PublicKey: []byte("pk2"),
+			Action:    block.PeerDeregistration,
+			TimeStamp: 11,
+			Value:     big.NewInt(2000),
+			Address:   []byte("address2"),
+		},
+	}
+}
+
+func TestBaseProcessor_IsPeerInfoEqualShouldWork(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, blproc.IsPeerInfoEqual(createPeerInfo(), createPeerInfo()))
+	assert.True(t, blproc.IsPeerInfoEqual(nil, make([]block.PeerData, 0)))
+}
+
+func TestBaseProcessor_IsPeerInfoEqualDifferentLengthShouldReturnFalse(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, blproc.IsPeerInfoEqual(createPeerInfo(), createPeerInfo()[:1]))
+}
+
+func TestBaseProcessor_IsPeerInfoEqualDifferentOrderShouldReturnFalse(t *testing.T) {
+	t.Parallel()
+
+	peerInfo := createPeerInfo()
+	peerInfo[0], peerInfo[1] = peerInfo[1], peerInfo[0]
+
+	assert.False(t, blproc.IsPeerInfoEqual(createPeerInfo(), peerInfo))
+}
+
+func TestBaseProcessor_IsPeerInfoEqualDifferentFieldsShouldReturnFalse(t *testing.T) {
+	t.Parallel()
+
+	peerInfo := createPeerInfo()
+	peerInfo[1].Value = big.NewInt(2001)
+	assert.False(t, blproc.IsPeerInfoEqual(createPeerInfo(), peerInfo))
+
+	peerInfo = createPeerInfo()
+	peerInfo[1].Address = []byte("address3")
+	assert.False(t, blproc.IsPeerInfoEqual(createPeerInfo(), peerInfo))
+
+	peerInfo = createPeerInfo()
+	peerInfo[0].Action = block.PeerDeregistration
+	assert.False(t, blproc.IsPeerInfoEqual(createPeerInfo(), peerInfo))
+
+	peerInfo = createPeerInfo()
+	peerInfo[0].Value = nil
+	assert.False(t, blproc.IsPeerInfoEqual(createPeerInfo(), peerInfo))
+}
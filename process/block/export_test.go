@@ -51,6 +51,14 @@ func (sp *shardProcessor) RemoveProcessedMetablocksFromPool(processedMetaHdrs []
 	return sp.removeProcessedMetablocksFromPool(processedMetaHdrs)
 }
 
+func (sp *shardProcessor) CheckEpochCorrectness(header *block.Header) error {
+	return sp.checkEpochCorrectness(header)
+}
+
+func (sp *shardProcessor) ApplyEpochStart(header *block.Header) error {
+	return sp.applyEpochStart(header)
+}
+
 func NewShardProcessorEmptyWith3shards(tdp dataRetriever.PoolsHolder, genesisBlocks map[uint32]data.HeaderHandler) (*shardProcessor, error) {
 	shardProcessor, err := NewShardProcessor(
 		&mock.ServiceContainerMock{},
@@ -66,6 +74,14 @@ func NewShardProcessorEmptyWith3shards(tdp dataRetriever.PoolsHolder, genesisBlo
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	return shardProcessor, err
 }
@@ -83,10 +99,17 @@ func NewMetaProcessorBasicSingleShard(mdp dataRetriever.MetaPoolsHolder, genesis
 		genesisBlocks,
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	return mp, err
 }
 
+func (mp *metaProcessor) CheckEpochCorrectness(header *block.MetaBlock) error {
+	return mp.checkEpochCorrectness(header)
+}
+
 func (mp *metaProcessor) RequestBlockHeaders(header *block.MetaBlock) (uint32, uint32) {
 	return mp.requestShardHeaders(header)
 }
@@ -279,3 +302,35 @@ func (sp *shardProcessor) GetAllMiniBlockDstMeFromMeta(
 ) (map[string][]byte, error) {
 	return sp.getAllMiniBlockDstMeFromMeta(round, metaHashes)
 }
+
+type ShardProcessor = shardProcessor
+
+type MetaProcessor = metaProcessor
+
+func (sp *shardProcessor) CreditAccumulatedFees(round uint64) error {
+	return sp.creditAccumulatedFees(round)
+}
+
+func IsPeerInfoEqual(first []block.PeerData, second []block.PeerData) bool {
+	return isPeerInfoEqual(first, second)
+}
+
+func (mp *metaProcessor) CreatePeerInfo(shardInfo []block.ShardData) ([]block.PeerData, error) {
+	return mp.createPeerInfo(shardInfo)
+}
+
+func (mp *metaProcessor) AddShardRewards(shardInfo []block.ShardData) error {
+	return mp.addShardRewards(shardInfo)
+}
+
+func (mp *metaProcessor) BurnSlashedStake(peerInfo []block.PeerData) error {
+	return mp.burnSlashedStake(peerInfo)
+}
+
+func (sp *shardProcessor) ProcessRewards(body block.Body, header *block.Header) error {
+	return sp.processRewards(body, header)
+}
+
+func (sp *shardProcessor) ProcessSlashedPeers(processedMetaHdrs []data.HeaderHandler) {
+	sp.processSlashedPeers(processedMetaHdrs)
+}
@@ -0,0 +1,68 @@
+package interceptors
+
+import (
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/p2p"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// TrieNodesInterceptor represents an interceptor used for the trie nodes sent to the nodes syncing the state
+type TrieNodesInterceptor struct {
+	*messageChecker
+	marshalizer marshal.Marshalizer
+	hasher      hashing.Hasher
+	trieNodes   storage.Cacher
+}
+
+// NewTrieNodesInterceptor creates a new instance of a TrieNodesInterceptor
+func NewTrieNodesInterceptor(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	trieNodes storage.Cacher,
+) (*TrieNodesInterceptor, error) {
+
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, process.ErrNilHasher
+	}
+	if trieNodes == nil {
+		return nil, process.ErrNilTrieNodesPool
+	}
+
+	return &TrieNodesInterceptor{
+		messageChecker: &messageChecker{},
+		marshalizer:    marshalizer,
+		hasher:         hasher,
+		trieNodes:      trieNodes,
+	}, nil
+}
+
+// ProcessReceivedMessage will be the callback func from the p2p.Messenger and will be called each time a new message was received
+// (for the topic this validator was registered to)
+func (tni *TrieNodesInterceptor) ProcessReceivedMessage(message p2p.MessageP2P) error {
+	err := tni.checkMessage(message)
+	if err != nil {
+		return err
+	}
+
+	encodedNodes := make([][]byte, 0)
+	err = tni.marshalizer.Unmarshal(&encodedNodes, message.Data())
+	if err != nil {
+		return err
+	}
+
+	// the nodes are keyed by their computed hash, so a node will only be found by the one requesting its hash
+	for _, encNode := range encodedNodes {
+		if len(encNode) == 0 {
+			continue
+		}
+
+		tni.trieNodes.HasOrAdd(tni.hasher.Compute(string(encNode)), encNode)
+	}
+
+	return nil
+}
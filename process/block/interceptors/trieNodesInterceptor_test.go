@@ -0,0 +1,81 @@
+package interceptors_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/block/interceptors"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+//------- NewTrieNodesInterceptor
+
+func TestNewTrieNodesInterceptor_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(nil, mock.HasherMock{}, mock.NewCacherMock())
+
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+	assert.Nil(t, tni)
+}
+
+func TestNewTrieNodesInterceptor_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, nil, mock.NewCacherMock())
+
+	assert.Equal(t, process.ErrNilHasher, err)
+	assert.Nil(t, tni)
+}
+
+func TestNewTrieNodesInterceptor_NilPoolShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, mock.HasherMock{}, nil)
+
+	assert.Equal(t, process.ErrNilTrieNodesPool, err)
+	assert.Nil(t, tni)
+}
+
+func TestNewTrieNodesInterceptor_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, mock.HasherMock{}, mock.NewCacherMock())
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tni)
+}
+
+//------- ProcessReceivedMessage
+
+func TestTrieNodesInterceptor_ProcessReceivedMessageNilMessageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, _ := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, mock.HasherMock{}, mock.NewCacherMock())
+
+	assert.Equal(t, process.ErrNilMessage, tni.ProcessReceivedMessage(nil))
+}
+
+func TestTrieNodesInterceptor_ProcessReceivedMessageShouldAddNodesByTheirHash(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	hasher := mock.HasherMock{}
+	cache := mock.NewCacherMock()
+	tni, _ := interceptors.NewTrieNodesInterceptor(marshalizer, hasher, cache)
+
+	node1 := []byte("encoded node 1")
+	node2 := []byte("encoded node 2")
+	buff, _ := marshalizer.Marshal([][]byte{node1, node2})
+
+	err := tni.ProcessReceivedMessage(&mock.P2PMessageMock{DataField: buff})
+	assert.Nil(t, err)
+
+	val, ok := cache.Peek(hasher.Compute(string(node1)))
+	assert.True(t, ok)
+	assert.Equal(t, node1, val)
+	val, ok = cache.Peek(hasher.Compute(string(node2)))
+	assert.True(t, ok)
+	assert.Equal(t, node2, val)
+}
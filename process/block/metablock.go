@@ -1,8 +1,10 @@
 package block
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"math/big"
 	"sort"
 	"sync"
 	"time"
@@ -42,6 +44,11 @@ type metaProcessor struct {
 	nextKValidity uint32
 
 	chRcvAllHdrs chan bool
+
+	mutConsensusData sync.RWMutex
+	prevRandSeed     []byte
+
+	totalSupplyHandler process.TotalSupplyHandler
 }
 
 // NewMetaProcessor creates a new metaProcessor object
@@ -57,6 +64,9 @@ func NewMetaProcessor(
 	startHeaders map[uint32]data.HeaderHandler,
 	requestHandler process.RequestHandler,
 	uint64Converter typeConverters.Uint64ByteSliceConverter,
+	epochHandler process.EpochHandler,
+	totalSupplyHandler process.TotalSupplyHandler,
+	commitJournal process.CommitJournal,
 ) (*metaProcessor, error) {
 
 	err := checkProcessorNilParameters(
@@ -66,7 +76,8 @@ func NewMetaProcessor(
 		marshalizer,
 		store,
 		shardCoordinator,
-		uint64Converter)
+		uint64Converter,
+		epochHandler)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +91,12 @@ func NewMetaProcessor(
 	if requestHandler == nil {
 		return nil, process.ErrNilRequestHandler
 	}
+	if totalSupplyHandler == nil {
+		return nil, process.ErrNilTotalSupplyHandler
+	}
+	if commitJournal == nil {
+		return nil, process.ErrNilCommitJournal
+	}
 
 	blockSizeThrottler, err := throttle.NewBlockSizeThrottle()
 	if err != nil {
@@ -95,6 +112,8 @@ func NewMetaProcessor(
 		store:                         store,
 		shardCoordinator:              shardCoordinator,
 		uint64Converter:               uint64Converter,
+		epochHandler:                  epochHandler,
+		commitJournal:                 commitJournal,
 		onRequestHeaderHandler:        requestHandler.RequestHeader,
 		onRequestHeaderHandlerByNonce: requestHandler.RequestHeaderByNonce,
 	}
@@ -105,9 +124,10 @@ func NewMetaProcessor(
 	}
 
 	mp := metaProcessor{
-		core:          core,
-		baseProcessor: base,
-		dataPool:      dataPool,
+		core:               core,
+		baseProcessor:      base,
+		dataPool:           dataPool,
+		totalSupplyHandler: totalSupplyHandler,
 	}
 
 	mp.requestedShardHdrsHashes = make(map[string]bool)
@@ -149,6 +169,11 @@ func (mp *metaProcessor) ProcessBlock(
 		return process.ErrWrongTypeAssertion
 	}
 
+	err = mp.checkEpochCorrectness(header)
+	if err != nil {
+		return err
+	}
+
 	requestedShardHdrs, requestedFinalShardHdrs := mp.requestShardHeaders(header)
 
 	if haveTime() < 0 {
@@ -186,6 +211,15 @@ func (mp *metaProcessor) ProcessBlock(
 		return err
 	}
 
+	peerInfo, err := mp.createPeerInfo(header.ShardInfo)
+	if err != nil {
+		return err
+	}
+
+	if !isPeerInfoEqual(peerInfo, header.PeerInfo) {
+		return process.ErrPeerInfoDoesNotMatch
+	}
+
 	defer func() {
 		if err != nil {
 			mp.RevertAccountState()
@@ -197,6 +231,16 @@ func (mp *metaProcessor) ProcessBlock(
 		return err
 	}
 
+	err = mp.addShardRewards(header.ShardInfo)
+	if err != nil {
+		return err
+	}
+
+	err = mp.burnSlashedStake(header.PeerInfo)
+	if err != nil {
+		return err
+	}
+
 	if !mp.verifyStateRoot(header.GetRootHash()) {
 		err = process.ErrRootStateMissmatch
 		return err
@@ -353,6 +397,122 @@ func (mp *metaProcessor) CreateBlockBody(round uint64, haveTime func() bool) (da
 	return &block.MetaBlockBody{}, nil
 }
 
+// SetConsensusData sets the random seed of the previous header, used as randomness when the metachain creates an
+// epoch start block
+func (mp *metaProcessor) SetConsensusData(prevHeader data.HeaderHandler, round uint64) {
+	if prevHeader == nil || prevHeader.IsInterfaceNil() {
+		return
+	}
+
+	mp.mutConsensusData.Lock()
+	mp.prevRandSeed = prevHeader.GetRandSeed()
+	mp.mutConsensusData.Unlock()
+}
+
+// checkEpochCorrectness checks if the epoch of the header matches its round and, if the header starts a new epoch,
+// if the epoch start data is the same as the one computed from the header's previous random seed
+func (mp *metaProcessor) checkEpochCorrectness(header *block.MetaBlock) error {
+	if header.Epoch != mp.epochHandler.EpochForRound(header.Round) {
+		return process.ErrEpochDoesNotMatch
+	}
+
+	if !mp.epochHandler.IsEpochStart(header.Round) {
+		if header.IsStartOfEpochBlock() {
+			return process.ErrEpochStartDataDoesNotMatch
+		}
+
+		return nil
+	}
+
+	epochStart, err := mp.epochHandler.CreateEpochStartData(header.PrevRandSeed)
+	if err != nil {
+		return err
+	}
+
+	if !isEpochStartDataEqual(epochStart, header.EpochStart) {
+		return process.ErrEpochStartDataDoesNotMatch
+	}
+
+	return nil
+}
+
+func isEpochStartDataEqual(first []block.EpochStartShardData, second []block.EpochStartShardData) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for i := 0; i < len(first); i++ {
+		if first[i].ShardId != second[i].ShardId {
+			return false
+		}
+		if !isByteSlicesListEqual(first[i].PublicKeys, second[i].PublicKeys) {
+			return false
+		}
+		if !isByteSlicesListEqual(first[i].Addresses, second[i].Addresses) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isByteSlicesListEqual(first [][]byte, second [][]byte) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for i := 0; i < len(first); i++ {
+		if !bytes.Equal(first[i], second[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RestoreEpochState restores the epoch, the validators lists, the waiting list and the leaving validators of the given
+// committed block, from the last epoch start metablock and the peer changes of the metablocks committed since then
+func (mp *metaProcessor) RestoreEpochState(headerHandler data.HeaderHandler) error {
+	if headerHandler == nil || headerHandler.IsInterfaceNil() {
+		return process.ErrNilBlockHeader
+	}
+
+	header, ok := headerHandler.(*block.MetaBlock)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	peerInfoLists := make([][]block.PeerData, 0)
+	currHeader := header
+	for currHeader.Nonce > 0 {
+		peerInfoLists = append(peerInfoLists, currHeader.PeerInfo)
+		if currHeader.IsStartOfEpochBlock() || currHeader.Nonce == 1 {
+			break
+		}
+
+		prevHeader, err := process.GetMetaHeader(currHeader.PrevHash, mp.dataPool.MetaChainBlocks(), mp.marshalizer, mp.store)
+		if err != nil {
+			return err
+		}
+
+		currHeader = prevHeader
+	}
+
+	peerInfo := make([]block.PeerData, 0)
+	for i := len(peerInfoLists) - 1; i >= 0; i-- {
+		peerInfo = append(peerInfo, peerInfoLists[i]...)
+	}
+
+	if currHeader.IsStartOfEpochBlock() {
+		return mp.epochHandler.RestoreEpochStart(currHeader.Epoch, currHeader.EpochStart, peerInfo)
+	}
+	if header.Epoch > 0 {
+		return process.ErrMissingEpochStartMetaBlock
+	}
+
+	return mp.epochHandler.RestoreEpochStart(0, nil, peerInfo)
+}
+
 func (mp *metaProcessor) processBlockHeaders(header *block.MetaBlock, round uint64, haveTime func() time.Duration) error {
 	hdrPool := mp.dataPool.ShardHeaders()
 
@@ -432,6 +592,20 @@ func (mp *metaProcessor) CommitBlock(
 
 	headerHash := mp.hasher.Compute(string(buff))
 	nonceToByteSlice := mp.uint64Converter.ToByteSlice(header.Nonce)
+
+	shardHeaders := make([]*block.Header, len(header.ShardInfo))
+	for i := 0; i < len(header.ShardInfo); i++ {
+		shardHeaders[i], err = process.GetShardHeaderFromPool(header.ShardInfo[i].HeaderHash, mp.dataPool.ShardHeaders())
+		if shardHeaders[i] == nil {
+			return err
+		}
+	}
+
+	err = mp.recordCommitJournal(header, headerHash, shardHeaders)
+	if err != nil {
+		return err
+	}
+
 	errNotCritical := mp.store.Put(dataRetriever.MetaHdrNonceHashDataUnit, nonceToByteSlice, headerHash)
 	log.LogIfError(errNotCritical)
 
@@ -457,10 +631,7 @@ func (mp *metaProcessor) CommitBlock(
 
 	for i := 0; i < len(header.ShardInfo); i++ {
 		shardData := header.ShardInfo[i]
-		header, err := process.GetShardHeaderFromPool(shardData.HeaderHash, mp.dataPool.ShardHeaders())
-		if header == nil {
-			return err
-		}
+		header := shardHeaders[i]
 
 		tempHeaderPool[string(shardData.HeaderHash)] = header
 
@@ -488,10 +659,31 @@ func (mp *metaProcessor) CommitBlock(
 		return err
 	}
 
+	if header.IsStartOfEpochBlock() {
+		err = mp.epochHandler.SetEpochStart(header.Epoch, header.EpochStart)
+		if err != nil {
+			return err
+		}
+
+		log.Info(fmt.Sprintf("epoch %d has started with metaBlock with nonce %d\n", header.Epoch, header.Nonce))
+	}
+
+	err = mp.epochHandler.ProcessPeerInfo(header.PeerInfo)
+	if err != nil {
+		return err
+	}
+
 	log.Info(fmt.Sprintf("metaBlock with nonce %d and hash %s has been committed successfully\n",
 		header.Nonce,
 		core.ToB64(headerHash)))
 
+	totalSupply, errNotCritical := mp.totalSupplyHandler.TotalSupply()
+	if errNotCritical != nil {
+		log.Debug(errNotCritical.Error())
+	} else {
+		log.Debug(fmt.Sprintf("total supply is %s after metaBlock with nonce %d\n", totalSupply.String(), header.Nonce))
+	}
+
 	errNotCritical = mp.removeBlockInfoFromPool(header)
 	if errNotCritical != nil {
 		log.Info(errNotCritical.Error())
@@ -523,6 +715,10 @@ func (mp *metaProcessor) CommitBlock(
 
 	mp.indexBlock(header, tempHeaderPool)
 
+	if mp.core != nil && mp.core.EventsNotifier() != nil {
+		mp.core.EventsNotifier().NotifyCommittedBlock(headerHash, header, nil)
+	}
+
 	go mp.displayMetaBlock(header)
 
 	mp.blockSizeThrottler.Succeed(header.Round)
@@ -530,6 +726,27 @@ func (mp *metaProcessor) CommitBlock(
 	return nil
 }
 
+// recordCommitJournal records the keys which the commit of the block writes in the storage units, before any of
+// them is written. Besides the metablock, the commit writes the notarized shard headers and their nonces
+func (mp *metaProcessor) recordCommitJournal(
+	header *block.MetaBlock,
+	headerHash []byte,
+	shardHeaders []*block.Header,
+) error {
+	unitsKeys := map[dataRetriever.UnitType][][]byte{
+		dataRetriever.MetaHdrNonceHashDataUnit: {mp.uint64Converter.ToByteSlice(header.Nonce)},
+		dataRetriever.MetaBlockUnit:            {headerHash},
+	}
+
+	for i, shardHeader := range shardHeaders {
+		hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(shardHeader.ShardId)
+		unitsKeys[hdrNonceHashDataUnit] = append(unitsKeys[hdrNonceHashDataUnit], mp.uint64Converter.ToByteSlice(shardHeader.Nonce))
+		unitsKeys[dataRetriever.BlockHeaderUnit] = append(unitsKeys[dataRetriever.BlockHeaderUnit], header.ShardInfo[i].HeaderHash)
+	}
+
+	return mp.commitJournal.Record(header.Nonce, header.RootHash, unitsKeys)
+}
+
 func (mp *metaProcessor) saveLastNotarizedHeader(header *block.MetaBlock) error {
 	mp.mutNotarizedHdrs.Lock()
 	defer mp.mutNotarizedHdrs.Unlock()
@@ -1043,12 +1260,54 @@ func (mp *metaProcessor) createShardInfo(
 	return shardInfo, nil
 }
 
-func (mp *metaProcessor) createPeerInfo() ([]block.PeerData, error) {
-	// TODO: to be implemented
+// createPeerInfo gathers the peer changes produced by the staking transactions of the shard headers included in a
+// metablock, in the order the shard headers are included
+func (mp *metaProcessor) createPeerInfo(shardInfo []block.ShardData) ([]block.PeerData, error) {
 	peerInfo := make([]block.PeerData, 0)
+
+	for _, shardData := range shardInfo {
+		header, err := process.GetShardHeaderFromPool(shardData.HeaderHash, mp.dataPool.ShardHeaders())
+		if err != nil {
+			return nil, err
+		}
+
+		peerInfo = append(peerInfo, header.PeerInfo...)
+	}
+
 	return peerInfo, nil
 }
 
+// addShardRewards adds the rewards minted by the notarized shard headers to the total supply
+func (mp *metaProcessor) addShardRewards(shardInfo []block.ShardData) error {
+	rewards := big.NewInt(0)
+
+	for _, shardData := range shardInfo {
+		header, err := process.GetShardHeaderFromPool(shardData.HeaderHash, mp.dataPool.ShardHeaders())
+		if err != nil {
+			return err
+		}
+
+		if header.Rewards != nil {
+			rewards.Add(rewards, header.Rewards)
+		}
+	}
+
+	return mp.totalSupplyHandler.AddRewards(rewards)
+}
+
+// burnSlashedStake removes from the total supply the stake burnt by the slashings of the notarized shard headers
+func (mp *metaProcessor) burnSlashedStake(peerInfo []block.PeerData) error {
+	burnt := big.NewInt(0)
+
+	for _, peerData := range peerInfo {
+		if peerData.Action == block.PeerSlashing && peerData.Value != nil {
+			burnt.Add(burnt, peerData.Value)
+		}
+	}
+
+	return mp.totalSupplyHandler.Burn(burnt)
+}
+
 // CreateBlockHeader creates a miniblock header list given a block body
 func (mp *metaProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round uint64, haveTime func() bool) (data.HeaderHandler, error) {
 	log.Debug(fmt.Sprintf("started creating block header in round %d\n", round))
@@ -1069,7 +1328,17 @@ func (mp *metaProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round u
 		return nil, err
 	}
 
-	peerInfo, err := mp.createPeerInfo()
+	peerInfo, err := mp.createPeerInfo(shardInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mp.addShardRewards(shardInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mp.burnSlashedStake(peerInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -1078,6 +1347,18 @@ func (mp *metaProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round u
 	header.PeerInfo = peerInfo
 	header.RootHash = mp.getRootHash()
 	header.TxCount = getTxCount(shardInfo)
+	header.Epoch = mp.epochHandler.EpochForRound(round)
+
+	if mp.epochHandler.IsEpochStart(round) {
+		mp.mutConsensusData.RLock()
+		prevRandSeed := mp.prevRandSeed
+		mp.mutConsensusData.RUnlock()
+
+		header.EpochStart, err = mp.epochHandler.CreateEpochStartData(prevRandSeed)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	mp.blockSizeThrottler.Add(
 		uint64(round),
@@ -3,6 +3,8 @@ package block_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -10,11 +12,13 @@ import (
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block"
 	"github.com/ElrondNetwork/elrond-go/data/blockchain"
+	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever/dataPool"
 	"github.com/ElrondNetwork/elrond-go/process"
 	blproc "github.com/ElrondNetwork/elrond-go/process/block"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/rewards"
 	"github.com/ElrondNetwork/elrond-go/sharding"
 	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/stretchr/testify/assert"
@@ -137,6 +141,9 @@ func TestNewMetaProcessor_NilAccountsAdapterShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
 	assert.Nil(t, be)
@@ -157,6 +164,9 @@ func TestNewMetaProcessor_NilDataPoolShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilDataPoolHolder, err)
 	assert.Nil(t, be)
@@ -178,6 +188,9 @@ func TestNewMetaProcessor_NilForkDetectorShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilForkDetector, err)
 	assert.Nil(t, be)
@@ -199,6 +212,9 @@ func TestNewMetaProcessor_NilShardCoordinatorShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
 	assert.Nil(t, be)
@@ -220,6 +236,9 @@ func TestNewMetaProcessor_NilHasherShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilHasher, err)
 	assert.Nil(t, be)
@@ -241,6 +260,9 @@ func TestNewMetaProcessor_NilMarshalizerShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilMarshalizer, err)
 	assert.Nil(t, be)
@@ -262,6 +284,9 @@ func TestNewMetaProcessor_NilChainStorerShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilStorage, err)
 	assert.Nil(t, be)
@@ -283,11 +308,86 @@ func TestNewMetaProcessor_NilRequestHeaderHandlerShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		nil,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilRequestHandler, err)
 	assert.Nil(t, be)
 }
 
+func TestNewMetaProcessor_NilEpochHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	be, err := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		&mock.AccountsStub{},
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.ChainStorerMock{},
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		nil,
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilEpochHandler, err)
+	assert.Nil(t, be)
+}
+
+func TestNewMetaProcessor_NilTotalSupplyHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	be, err := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		&mock.AccountsStub{},
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.ChainStorerMock{},
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		nil,
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilTotalSupplyHandler, err)
+	assert.Nil(t, be)
+}
+
+func TestNewMetaProcessor_NilCommitJournalShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	be, err := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		&mock.AccountsStub{},
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.ChainStorerMock{},
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		nil,
+	)
+	assert.Equal(t, process.ErrNilCommitJournal, err)
+	assert.Nil(t, be)
+}
+
 func TestNewMetaProcessor_OkValsShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -378,6 +478,9 @@ func TestMetaProcessor_ProcessWithDirtyAccountShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	// should return err
 	err := mp.ProcessBlock(blkc, &hdr, body, haveTime)
@@ -482,6 +585,9 @@ func TestMetaProcessor_ProcessBlockWithErrOnVerifyStateRootCallShouldRevertState
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	go func() {
@@ -491,12 +597,57 @@ func TestMetaProcessor_ProcessBlockWithErrOnVerifyStateRootCallShouldRevertState
 	// should return err
 	mp.SetNextKValidity(0)
 	hdr.ShardInfo = make([]block.ShardData, 0)
+	hdr.PeerInfo = make([]block.PeerData, 0)
 	err := mp.ProcessBlock(blkc, hdr, body, haveTime)
 
 	assert.Equal(t, process.ErrRootStateMissmatch, err)
 	assert.True(t, wasCalled)
 }
 
+func TestMetaProcessor_ProcessBlockWithPeerInfoNotMatchingShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	blkc := &blockchain.MetaChain{
+		CurrentBlock: &block.MetaBlock{
+			Nonce: 0,
+		},
+	}
+	hdr := createMetaBlockHeader()
+	body := &block.MetaBlockBody{}
+	mp, _ := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		&mock.AccountsStub{
+			JournalLenCalled: func() int {
+				return 0
+			},
+		},
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.ChainStorerMock{},
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	go func() {
+		mp.ChRcvAllHdrs() <- true
+	}()
+
+	mp.SetNextKValidity(0)
+	hdr.ShardInfo = make([]block.ShardData, 0)
+	hdr.PeerInfo = []block.PeerData{{PublicKey: []byte("pk"), Value: big.NewInt(10)}}
+	err := mp.ProcessBlock(blkc, hdr, body, haveTime)
+
+	assert.Equal(t, process.ErrPeerInfoDoesNotMatch, err)
+}
+
 //------- processBlockHeader
 
 func TestMetaProcessor_ProcessBlockHeaderShouldPass(t *testing.T) {
@@ -519,6 +670,9 @@ func TestMetaProcessor_ProcessBlockHeaderShouldPass(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	txHash := []byte("txhash")
@@ -573,6 +727,9 @@ func TestMetaProcessor_RequestFinalMissingHeaderShouldPass(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	mdp.HeadersNoncesCalled = func() dataRetriever.Uint64SyncMapCacher {
 		cs := &mock.Uint64SyncMapCacherStub{}
@@ -614,6 +771,9 @@ func TestMetaProcessor_CommitBlockNilBlockchainShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blk := &block.MetaBlockBody{}
 	err := mp.CommitBlock(nil, &block.MetaBlock{}, blk)
@@ -653,6 +813,9 @@ func TestMetaProcessor_CommitBlockMarshalizerFailForHeaderShouldErr(t *testing.T
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blkc := createTestBlockchain()
 	err := mp.CommitBlock(blkc, hdr, body)
@@ -697,6 +860,9 @@ func TestMetaProcessor_CommitBlockStorageFailsForHeaderShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	blkc, _ := blockchain.NewMetaChain(
@@ -732,6 +898,9 @@ func TestMetaProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	mdp.HeadersNoncesCalled = func() dataRetriever.Uint64SyncMapCacher {
@@ -770,6 +939,9 @@ func TestMetaProcessor_CommitBlockNoTxInPoolShouldErr(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	mdp.ShardHeadersCalled = func() storage.Cacher {
@@ -832,6 +1004,9 @@ func TestMetaProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	removeHdrWasCalled := false
@@ -863,6 +1038,89 @@ func TestMetaProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 	time.Sleep(time.Second)
 }
 
+func TestMetaProcessor_CommitBlockShouldRecordTheNotarizedShardHeaders(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	hdr := createMetaBlockHeader()
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			return []byte("rootHash"), nil
+		},
+		RootHashCalled: func() ([]byte, error) {
+			return []byte("rootHash"), nil
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, finalHeader data.HeaderHandler, finalHeaderHash []byte) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{
+		ComputeCalled: func(s string) []byte {
+			return []byte("meta_hash")
+		},
+	}
+	store := initStore()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, &mock.StorerStub{
+		PutCalled: func(key, data []byte) error {
+			return nil
+		},
+	})
+
+	var recordedKeys map[dataRetriever.UnitType][][]byte
+	mp, _ := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		accounts,
+		mdp,
+		fd,
+		mock.NewOneShardCoordinatorMock(),
+		hasher,
+		&mock.MarshalizerMock{},
+		store,
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{
+			ToByteSliceCalled: func(nonce uint64) []byte {
+				return []byte(fmt.Sprintf("nonce_%d", nonce))
+			},
+		},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{
+			RecordCalled: func(nonce uint64, rootHash []byte, unitsKeys map[dataRetriever.UnitType][][]byte) error {
+				recordedKeys = unitsKeys
+				return nil
+			},
+		},
+	)
+
+	mdp.ShardHeadersCalled = func() storage.Cacher {
+		cs := &mock.CacherStub{}
+		cs.RegisterHandlerCalled = func(i func(key []byte)) {
+		}
+		cs.PeekCalled = func(key []byte) (value interface{}, ok bool) {
+			return &block.Header{ShardId: 0, Nonce: 5}, true
+		}
+		cs.RemoveCalled = func(key []byte) {
+		}
+		cs.LenCalled = func() int {
+			return 0
+		}
+		return cs
+	}
+
+	err := mp.CommitBlock(createTestBlockchain(), hdr, &block.MetaBlockBody{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("meta_hash")}, recordedKeys[dataRetriever.MetaBlockUnit])
+	assert.Equal(t, [][]byte{[]byte("nonce_1")}, recordedKeys[dataRetriever.MetaHdrNonceHashDataUnit])
+	assert.Equal(t, [][]byte{[]byte("hdr_hash1")}, recordedKeys[dataRetriever.BlockHeaderUnit])
+	assert.Equal(t, [][]byte{[]byte("nonce_5")}, recordedKeys[dataRetriever.ShardHdrNonceHashDataUnit])
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
 func TestBlockProc_RequestTransactionFromNetwork(t *testing.T) {
 	t.Parallel()
 
@@ -879,6 +1137,9 @@ func TestBlockProc_RequestTransactionFromNetwork(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	mdp.ShardHeadersCalled = func() storage.Cacher {
 		cs := &mock.CacherStub{}
@@ -916,6 +1177,9 @@ func TestMetaProcessor_RemoveBlockInfoFromPoolShouldErrNilMetaBlockHeader(t *tes
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	err := mp.RemoveBlockInfoFromPool(nil)
 	assert.NotNil(t, err)
@@ -938,6 +1202,9 @@ func TestMetaProcessor_RemoveBlockInfoFromPoolShouldWork(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	header := createMetaBlockHeader()
 	err := mp.RemoveBlockInfoFromPool(header)
@@ -964,6 +1231,9 @@ func TestMetaProcessor_DisplayLogInfo(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr.PrevHash = hasher.Compute("prev hash")
 	mp.DisplayMetaBlock(hdr)
@@ -988,6 +1258,9 @@ func TestMetaProcessor_CreateBlockHeaderShouldNotReturnNilWhenCreateShardInfoFai
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	haveTime := func() bool { return true }
 	hdr, err := mp.CreateBlockHeader(nil, 0, haveTime)
@@ -1017,6 +1290,9 @@ func TestMetaProcessor_CreateBlockHeaderShouldWork(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	haveTime := func() bool { return true }
@@ -1025,6 +1301,38 @@ func TestMetaProcessor_CreateBlockHeaderShouldWork(t *testing.T) {
 	assert.NotNil(t, hdr)
 }
 
+func TestMetaProcessor_CreateBlockHeaderEpochStartShouldAddEpochStartData(t *testing.T) {
+	t.Parallel()
+
+	prevRandSeed := []byte("prev rand seed")
+	epochStart := []block.EpochStartShardData{
+		{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}, Addresses: [][]byte{[]byte("addr")}},
+	}
+	var usedRandomness []byte
+	mp := createMetaProcessorWithEpochHandler(&mock.EpochHandlerStub{
+		EpochForRoundCalled: func(round uint64) uint32 {
+			return 2
+		},
+		IsEpochStartCalled: func(round uint64) bool {
+			return true
+		},
+		CreateEpochStartDataCalled: func(randomness []byte) ([]block.EpochStartShardData, error) {
+			usedRandomness = randomness
+			return epochStart, nil
+		},
+	})
+
+	mp.SetConsensusData(&block.MetaBlock{RandSeed: prevRandSeed}, 20)
+	haveTime := func() bool { return true }
+	hdr, err := mp.CreateBlockHeader(nil, 20, haveTime)
+
+	assert.Nil(t, err)
+	metaHdr := hdr.(*block.MetaBlock)
+	assert.Equal(t, uint32(2), metaHdr.Epoch)
+	assert.Equal(t, epochStart, metaHdr.EpochStart)
+	assert.Equal(t, prevRandSeed, usedRandomness)
+}
+
 func TestMetaProcessor_CommitBlockShouldRevertAccountStateWhenErr(t *testing.T) {
 	t.Parallel()
 
@@ -1048,6 +1356,9 @@ func TestMetaProcessor_CommitBlockShouldRevertAccountStateWhenErr(t *testing.T)
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	err := mp.CommitBlock(nil, nil, nil)
 	assert.NotNil(t, err)
@@ -1070,6 +1381,9 @@ func TestMetaProcessor_MarshalizedDataToBroadcastShouldWork(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	msh, mstx, err := mp.MarshalizedDataToBroadcast(&block.MetaBlock{}, &block.MetaBlockBody{})
@@ -1099,6 +1413,9 @@ func TestMetaProcessor_ReceivedHeaderShouldEraseRequested(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	//add 3 tx hashes on requested list
@@ -1192,6 +1509,9 @@ func TestMetaProcessor_CreateShardInfoShouldWorkNoHdrAddedNotValid(t *testing.T)
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(5)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	haveTime := func() bool { return true }
@@ -1271,6 +1591,9 @@ func TestMetaProcessor_CreateShardInfoShouldWorkNoHdrAddedNotFinal(t *testing.T)
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(5)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	haveTime := func() bool { return true }
@@ -1388,6 +1711,9 @@ func TestMetaProcessor_CreateShardInfoShouldWorkHdrsAdded(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	haveTime := func() bool { return true }
@@ -1554,6 +1880,9 @@ func TestMetaProcessor_CreateShardInfoEmptyBlockHDRRoundTooHigh(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	haveTime := func() bool { return true }
@@ -1678,6 +2007,9 @@ func TestMetaProcessor_RestoreBlockIntoPoolsShouldErrNilMetaBlockHeader(t *testi
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	err := mp.RestoreBlockIntoPools(nil, nil)
 	assert.NotNil(t, err)
@@ -1721,6 +2053,9 @@ func TestMetaProcessor_RestoreBlockIntoPoolsShouldWork(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	mhdr := createMetaBlockHeader()
@@ -1760,6 +2095,9 @@ func TestMetaProcessor_CreateLastNotarizedHdrs(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -1853,6 +2191,9 @@ func TestMetaProcessor_CheckShardHeadersValidity(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -1947,6 +2288,9 @@ func TestMetaProcessor_CheckShardHeadersValidityWrongNonceFromLastNoted(t *testi
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -2004,6 +2348,9 @@ func TestMetaProcessor_CheckShardHeadersValidityRoundZeroLastNoted(t *testing.T)
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -2067,6 +2414,9 @@ func TestMetaProcessor_CheckShardHeadersFinality(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -2179,6 +2529,9 @@ func TestMetaProcessor_IsHdrConstructionValid(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -2285,6 +2638,9 @@ func TestMetaProcessor_IsShardHeaderValidFinal(t *testing.T) {
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(noOfShards)),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -2394,6 +2750,9 @@ func TestMetaProcessor_DecodeBlockBody(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	body := &block.MetaBlockBody{}
 	message, err := marshalizerMock.Marshal(body)
@@ -2422,6 +2781,9 @@ func TestMetaProcessor_DecodeBlockHeader(t *testing.T) {
 		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
 		&mock.RequestHandlerMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr := &block.MetaBlock{}
 	hdr.Nonce = 1
@@ -2440,3 +2802,389 @@ func TestMetaProcessor_DecodeBlockHeader(t *testing.T) {
 	assert.Equal(t, hdr, dcdHdr)
 	assert.Equal(t, []byte("A"), dcdHdr.GetSignature())
 }
+
+//------- checkEpochCorrectness
+
+func createMetaProcessorWithEpochHandler(epochHandler process.EpochHandler) *blproc.MetaProcessor {
+	mp, _ := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		&mock.AccountsStub{
+			RootHashCalled: func() ([]byte, error) {
+				return []byte("root"), nil
+			},
+		},
+		initMetaDataPool(),
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initStore(),
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		epochHandler,
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	return mp
+}
+
+func TestMetaProcessor_CheckEpochCorrectnessWrongEpochShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mp := createMetaProcessorWithEpochHandler(&mock.EpochHandlerStub{
+		EpochForRoundCalled: func(round uint64) uint32 {
+			return 1
+		},
+	})
+
+	err := mp.CheckEpochCorrectness(&block.MetaBlock{Round: 10, Epoch: 2})
+
+	assert.Equal(t, process.ErrEpochDoesNotMatch, err)
+}
+
+func TestMetaProcessor_CheckEpochCorrectnessUnexpectedEpochStartShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mp := createMetaProcessorWithEpochHandler(&mock.EpochHandlerStub{})
+
+	err := mp.CheckEpochCorrectness(&block.MetaBlock{
+		EpochStart: []block.EpochStartShardData{{ShardId: 0}},
+	})
+
+	assert.Equal(t, process.ErrEpochStartDataDoesNotMatch, err)
+}
+
+func TestMetaProcessor_CheckEpochCorrectnessEpochStartDataMismatchShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mp := createMetaProcessorWithEpochHandler(&mock.EpochHandlerStub{
+		IsEpochStartCalled: func(round uint64) bool {
+			return true
+		},
+		CreateEpochStartDataCalled: func(randomness []byte) ([]block.EpochStartShardData, error) {
+			return []block.EpochStartShardData{
+				{ShardId: 0, PublicKeys: [][]byte{[]byte("pk1")}, Addresses: [][]byte{[]byte("addr1")}},
+			}, nil
+		},
+	})
+
+	err := mp.CheckEpochCorrectness(&block.MetaBlock{
+		EpochStart: []block.EpochStartShardData{
+			{ShardId: 0, PublicKeys: [][]byte{[]byte("pk2")}, Addresses: [][]byte{[]byte("addr1")}},
+		},
+	})
+
+	assert.Equal(t, process.ErrEpochStartDataDoesNotMatch, err)
+}
+
+func TestMetaProcessor_CheckEpochCorrectnessCreateEpochStartDataErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	mp := createMetaProcessorWithEpochHandler(&mock.EpochHandlerStub{
+		IsEpochStartCalled: func(round uint64) bool {
+			return true
+		},
+		CreateEpochStartDataCalled: func(randomness []byte) ([]block.EpochStartShardData, error) {
+			return nil, errExpected
+		},
+	})
+
+	err := mp.CheckEpochCorrectness(&block.MetaBlock{})
+
+	assert.Equal(t, errExpected, err)
+}
+
+func TestMetaProcessor_CheckEpochCorrectnessEpochStartShouldWork(t *testing.T) {
+	t.Parallel()
+
+	prevRandSeed := []byte("prev rand seed")
+	mp := createMetaProcessorWithEpochHandler(&mock.EpochHandlerStub{
+		IsEpochStartCalled: func(round uint64) bool {
+			return true
+		},
+		CreateEpochStartDataCalled: func(randomness []byte) ([]block.EpochStartShardData, error) {
+			return []block.EpochStartShardData{
+				{ShardId: 0, PublicKeys: [][]byte{randomness}, Addresses: [][]byte{[]byte("addr1")}},
+			}, nil
+		},
+	})
+
+	err := mp.CheckEpochCorrectness(&block.MetaBlock{
+		PrevRandSeed: prevRandSeed,
+		EpochStart: []block.EpochStartShardData{
+			{ShardId: 0, PublicKeys: [][]byte{prevRandSeed}, Addresses: [][]byte{[]byte("addr1")}},
+		},
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestMetaProcessor_CreatePeerInfoMissingShardHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mdp := mock.NewMetaPoolsHolderFake()
+	mp, _ := blproc.NewMetaProcessorBasicSingleShard(mdp, createGenesisBlocks(mock.NewOneShardCoordinatorMock()))
+
+	peerInfo, err := mp.CreatePeerInfo([]block.ShardData{{HeaderHash: []byte("missing")}})
+
+	assert.Nil(t, peerInfo)
+	assert.Equal(t, process.ErrMissingHeader, err)
+}
+
+func TestMetaProcessor_CreatePeerInfoShouldKeepShardHeadersOrder(t *testing.T) {
+	t.Parallel()
+
+	mdp := mock.NewMetaPoolsHolderFake()
+	pk1 := block.PeerData{PublicKey: []byte("pk1"), Action: block.PeerRegistrantion, Value: big.NewInt(10)}
+	pk2 := block.PeerData{PublicKey: []byte("pk2"), Action: block.PeerDeregistration, Value: big.NewInt(20)}
+	pk3 := block.PeerData{PublicKey: []byte("pk3"), Action: block.PeerRegistrantion, Value: big.NewInt(30)}
+	mdp.ShardHeaders().Put([]byte("hash1"), &block.Header{ShardId: 0, PeerInfo: []block.PeerData{pk1, pk2}})
+	mdp.ShardHeaders().Put([]byte("hash2"), &block.Header{ShardId: 0})
+	mdp.ShardHeaders().Put([]byte("hash3"), &block.Header{ShardId: 0, PeerInfo: []block.PeerData{pk3}})
+	mp, _ := blproc.NewMetaProcessorBasicSingleShard(mdp, createGenesisBlocks(mock.NewOneShardCoordinatorMock()))
+
+	shardInfo := []block.ShardData{
+		{HeaderHash: []byte("hash3")},
+		{HeaderHash: []byte("hash2")},
+		{HeaderHash: []byte("hash1")},
+	}
+	peerInfo, err := mp.CreatePeerInfo(shardInfo)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []block.PeerData{pk3, pk1, pk2}, peerInfo)
+}
+
+func TestMetaProcessor_AddShardRewardsShouldAddRewardsOfAllShardHeaders(t *testing.T) {
+	t.Parallel()
+
+	mdp := mock.NewMetaPoolsHolderFake()
+	mdp.ShardHeaders().Put([]byte("hash1"), &block.Header{ShardId: 0, Rewards: big.NewInt(10)})
+	mdp.ShardHeaders().Put([]byte("hash2"), &block.Header{ShardId: 0})
+	mdp.ShardHeaders().Put([]byte("hash3"), &block.Header{ShardId: 0, Rewards: big.NewInt(5)})
+	addedRewards := big.NewInt(0)
+	mp, _ := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		&mock.AccountsStub{},
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.ChainStorerMock{},
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		&mock.TotalSupplyHandlerStub{
+			AddRewardsCalled: func(rewards *big.Int) error {
+				addedRewards.Add(addedRewards, rewards)
+				return nil
+			},
+		},
+		&mock.CommitJournalStub{},
+	)
+
+	shardInfo := []block.ShardData{
+		{HeaderHash: []byte("hash1")},
+		{HeaderHash: []byte("hash2")},
+		{HeaderHash: []byte("hash3")},
+	}
+	err := mp.AddShardRewards(shardInfo)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(15), addedRewards)
+}
+
+func TestMetaProcessor_BurnSlashedStakeShouldBurnTheSlashedValues(t *testing.T) {
+	t.Parallel()
+
+	mdp := mock.NewMetaPoolsHolderFake()
+	genesisBlocks := createGenesisBlocks(mock.NewOneShardCoordinatorMock())
+	storage := make(map[string][]byte)
+	tracker := &mock.AccountTrackerStub{
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+	}
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			acnt, _ := state.NewAccount(addressContainer, tracker)
+			acnt.SetDataTrie(&mock.TrieStub{
+				GetCalled: func(key []byte) ([]byte, error) {
+					return storage[string(key)], nil
+				},
+			})
+			return acnt, nil
+		},
+		SaveDataTrieCalled: func(acountWrapper state.AccountHandler) error {
+			for k, v := range acountWrapper.DataTrieTracker().DirtyData() {
+				storage[k] = v
+			}
+			acountWrapper.DataTrieTracker().ClearDataCaches()
+			return nil
+		},
+	}
+	totalSupplyTracker, _ := rewards.NewTotalSupplyTracker(accounts, big.NewInt(1000))
+	mp, _ := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		accounts,
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.ChainStorerMock{},
+		genesisBlocks,
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.EpochHandlerStub{},
+		totalSupplyTracker,
+		&mock.CommitJournalStub{},
+	)
+
+	peerInfo := []block.PeerData{
+		{PublicKey: []byte("pk1"), Action: block.PeerSlashing, Value: big.NewInt(100)},
+		{PublicKey: []byte("pk2"), Action: block.PeerRegistrantion, Value: big.NewInt(500)},
+		{PublicKey: []byte("pk3"), Action: block.PeerSlashing, Value: big.NewInt(0)},
+		{PublicKey: []byte("pk4"), Action: block.PeerSlashing, Value: big.NewInt(25)},
+	}
+	err := mp.BurnSlashedStake(peerInfo)
+
+	assert.Nil(t, err)
+	totalSupply, _ := totalSupplyTracker.TotalSupply()
+	assert.Equal(t, big.NewInt(875), totalSupply)
+}
+
+func TestMetaProcessor_AddShardRewardsMissingShardHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	mdp := mock.NewMetaPoolsHolderFake()
+	mp, _ := blproc.NewMetaProcessorBasicSingleShard(mdp, createGenesisBlocks(mock.NewOneShardCoordinatorMock()))
+
+	err := mp.AddShardRewards([]block.ShardData{{HeaderHash: []byte("missing")}})
+
+	assert.Equal(t, process.ErrMissingHeader, err)
+}
+
+func createMetaProcessorForEpochRestore(
+	store dataRetriever.StorageService,
+	epochHandler process.EpochHandler,
+) *blproc.MetaProcessor {
+	mp, _ := blproc.NewMetaProcessor(
+		&mock.ServiceContainerMock{},
+		&mock.AccountsStub{},
+		initMetaDataPool(),
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		store,
+		createGenesisBlocks(mock.NewOneShardCoordinatorMock()),
+		&mock.RequestHandlerMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		epochHandler,
+		&mock.TotalSupplyHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	return mp
+}
+
+func TestMetaProcessor_RestoreEpochStateShouldReplayThePeerChangesSinceTheEpochStart(t *testing.T) {
+	t.Parallel()
+
+	epochStart := []block.EpochStartShardData{{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}}}
+	peerData := func(pubKey string) []block.PeerData {
+		return []block.PeerData{{PublicKey: []byte(pubKey), Action: block.PeerRegistrantion}}
+	}
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta5", &block.MetaBlock{
+		Nonce:    5,
+		Epoch:    1,
+		PeerInfo: peerData("pk_before_epoch"),
+	})
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta6", &block.MetaBlock{
+		Nonce:      6,
+		Epoch:      2,
+		PrevHash:   []byte("meta5"),
+		EpochStart: epochStart,
+		PeerInfo:   peerData("pk_epoch_start"),
+	})
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta7", &block.MetaBlock{
+		Nonce:    7,
+		Epoch:    2,
+		PrevHash: []byte("meta6"),
+		PeerInfo: peerData("pk_7"),
+	})
+
+	restoredEpoch := uint32(0)
+	var restoredEpochStart []block.EpochStartShardData
+	var restoredPeerInfo []block.PeerData
+	epochHandler := &mock.EpochHandlerStub{
+		RestoreEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error {
+			restoredEpoch = epoch
+			restoredEpochStart = epochStart
+			restoredPeerInfo = peerInfo
+			return nil
+		},
+	}
+	mp := createMetaProcessorForEpochRestore(store, epochHandler)
+
+	err := mp.RestoreEpochState(&block.MetaBlock{Nonce: 8, Epoch: 2, PrevHash: []byte("meta7"), PeerInfo: peerData("pk_8")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), restoredEpoch)
+	assert.Equal(t, epochStart, restoredEpochStart)
+	assert.Equal(t, 3, len(restoredPeerInfo))
+	assert.Equal(t, []byte("pk_epoch_start"), restoredPeerInfo[0].PublicKey)
+	assert.Equal(t, []byte("pk_7"), restoredPeerInfo[1].PublicKey)
+	assert.Equal(t, []byte("pk_8"), restoredPeerInfo[2].PublicKey)
+}
+
+func TestMetaProcessor_RestoreEpochStateFirstEpochShouldReplayThePeerChangesSinceGenesis(t *testing.T) {
+	t.Parallel()
+
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta1", &block.MetaBlock{
+		Nonce:    1,
+		PeerInfo: []block.PeerData{{PublicKey: []byte("pk_1"), Action: block.PeerRegistrantion}},
+	})
+
+	restoredEpoch := uint32(5)
+	var restoredEpochStart []block.EpochStartShardData
+	var restoredPeerInfo []block.PeerData
+	epochHandler := &mock.EpochHandlerStub{
+		RestoreEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error {
+			restoredEpoch = epoch
+			restoredEpochStart = epochStart
+			restoredPeerInfo = peerInfo
+			return nil
+		},
+	}
+	mp := createMetaProcessorForEpochRestore(store, epochHandler)
+
+	err := mp.RestoreEpochState(&block.MetaBlock{Nonce: 2, PrevHash: []byte("meta1")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), restoredEpoch)
+	assert.Nil(t, restoredEpochStart)
+	assert.Equal(t, 1, len(restoredPeerInfo))
+	assert.Equal(t, []byte("pk_1"), restoredPeerInfo[0].PublicKey)
+}
+
+func TestMetaProcessor_RestoreEpochStateMissingEpochStartShouldErr(t *testing.T) {
+	t.Parallel()
+
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta1", &block.MetaBlock{Nonce: 1, Epoch: 1})
+	mp := createMetaProcessorForEpochRestore(store, &mock.EpochHandlerStub{})
+
+	err := mp.RestoreEpochState(&block.MetaBlock{Nonce: 2, Epoch: 1, PrevHash: []byte("meta1")})
+
+	assert.Equal(t, process.ErrMissingEpochStartMetaBlock, err)
+}
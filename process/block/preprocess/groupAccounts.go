@@ -0,0 +1,241 @@
+package preprocess
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// groupAccounts is the accounts adapter used to execute a group of transactions. It starts from the accounts loaded
+// before the execution of the group and keeps the modified accounts and the journal of the group, so it never
+// accesses the accounts adapter of the node. It is used by a single go routine
+type groupAccounts struct {
+	marshalizer marshal.Marshalizer
+	loaded      map[string][]byte
+	updated     map[string][]byte
+	addresses   map[string]state.AddressContainer
+	updatedKeys []string
+	entries     []state.JournalEntry
+}
+
+func newGroupAccounts(marshalizer marshal.Marshalizer, loaded map[string][]byte) *groupAccounts {
+	return &groupAccounts{
+		marshalizer: marshalizer,
+		loaded:      loaded,
+		updated:     make(map[string][]byte),
+		addresses:   make(map[string]state.AddressContainer),
+		updatedKeys: make([]string, 0),
+		entries:     make([]state.JournalEntry, 0),
+	}
+}
+
+// getAccountBytes returns the marshalized account from the given address or nil if the account does not exist
+func (ga *groupAccounts) getAccountBytes(key string) ([]byte, error) {
+	val, ok := ga.updated[key]
+	if ok {
+		return val, nil
+	}
+
+	val, ok = ga.loaded[key]
+	if !ok {
+		return nil, process.ErrAccountNotLoaded
+	}
+
+	return val, nil
+}
+
+func (ga *groupAccounts) getAccount(addressContainer state.AddressContainer) (*state.Account, error) {
+	val, err := ga.getAccountBytes(string(addressContainer.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	if len(val) == 0 {
+		return nil, nil
+	}
+
+	account, err := state.NewAccount(addressContainer, ga)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ga.marshalizer.Unmarshal(account, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetAccountWithJournal returns the account from the given address, creating it if it is missing
+func (ga *groupAccounts) GetAccountWithJournal(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+	account, err := ga.getAccount(addressContainer)
+	if err != nil {
+		return nil, err
+	}
+	if account != nil {
+		return account, nil
+	}
+
+	account, err = state.NewAccount(addressContainer, ga)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := state.NewBaseJournalEntryCreation(addressContainer.Bytes(), ga)
+	if err != nil {
+		return nil, err
+	}
+
+	ga.Journalize(entry)
+	err = ga.SaveAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetExistingAccount returns the account from the given address or an error if it is missing
+func (ga *groupAccounts) GetExistingAccount(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+	account, err := ga.getAccount(addressContainer)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, state.ErrAccNotFound
+	}
+
+	return account, nil
+}
+
+// HasAccount returns true if the account from the given address exists
+func (ga *groupAccounts) HasAccount(addressContainer state.AddressContainer) (bool, error) {
+	val, err := ga.getAccountBytes(string(addressContainer.Bytes()))
+	if err != nil {
+		return false, err
+	}
+
+	return len(val) > 0, nil
+}
+
+// SaveAccount keeps the account as modified by the group
+func (ga *groupAccounts) SaveAccount(accountHandler state.AccountHandler) error {
+	if accountHandler == nil || accountHandler.IsInterfaceNil() {
+		return state.ErrNilAccountHandler
+	}
+
+	buff, err := ga.marshalizer.Marshal(accountHandler)
+	if err != nil {
+		return err
+	}
+
+	ga.addresses[string(accountHandler.AddressContainer().Bytes())] = accountHandler.AddressContainer()
+
+	return ga.Update(accountHandler.AddressContainer().Bytes(), buff)
+}
+
+// Update sets the marshalized account of the given key. An empty value marks the account as removed
+func (ga *groupAccounts) Update(key, value []byte) error {
+	_, ok := ga.updated[string(key)]
+	if !ok {
+		ga.updatedKeys = append(ga.updatedKeys, string(key))
+	}
+
+	ga.updated[string(key)] = value
+
+	return nil
+}
+
+// RemoveAccount marks the account from the given address as removed
+func (ga *groupAccounts) RemoveAccount(addressContainer state.AddressContainer) error {
+	return ga.Update(addressContainer.Bytes(), make([]byte, 0))
+}
+
+// Journalize adds a new entry to the journal of the group
+func (ga *groupAccounts) Journalize(entry state.JournalEntry) {
+	if entry == nil {
+		return
+	}
+
+	ga.entries = append(ga.entries, entry)
+}
+
+// JournalLen returns the number of entries in the journal of the group
+func (ga *groupAccounts) JournalLen() int {
+	return len(ga.entries)
+}
+
+// RevertToSnapshot reverts the journal entries of the group added after the given snapshot
+func (ga *groupAccounts) RevertToSnapshot(snapshot int) error {
+	if snapshot > len(ga.entries) || snapshot < 0 {
+		return nil
+	}
+
+	for i := len(ga.entries) - 1; i >= snapshot; i-- {
+		account, err := ga.entries[i].Revert()
+		if err != nil {
+			return err
+		}
+
+		if account != nil {
+			err = ga.SaveAccount(account)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	ga.entries = ga.entries[:snapshot]
+
+	return nil
+}
+
+// Commit is not supported, the modified accounts are merged by the scheduler
+func (ga *groupAccounts) Commit() ([]byte, error) {
+	return nil, process.ErrOperationNotSupported
+}
+
+// RootHash is not supported, as the group does not hold the accounts trie
+func (ga *groupAccounts) RootHash() ([]byte, error) {
+	return nil, process.ErrOperationNotSupported
+}
+
+// RecreateTrie is not supported, as the group does not hold the accounts trie
+func (ga *groupAccounts) RecreateTrie(_ []byte) error {
+	return process.ErrOperationNotSupported
+}
+
+// PutCode is not supported, the groups only execute move balance transactions
+func (ga *groupAccounts) PutCode(_ state.AccountHandler, _ []byte) error {
+	return process.ErrOperationNotSupported
+}
+
+// RemoveCode is not supported, the groups only execute move balance transactions
+func (ga *groupAccounts) RemoveCode(_ []byte) error {
+	return process.ErrOperationNotSupported
+}
+
+// SaveDataTrie is not supported, the groups only execute move balance transactions
+func (ga *groupAccounts) SaveDataTrie(_ state.AccountHandler) error {
+	return process.ErrOperationNotSupported
+}
+
+// WalkAccounts is not supported, as the group does not hold the accounts trie
+func (ga *groupAccounts) WalkAccounts(_ func(accountHandler state.AccountHandler) error) error {
+	return process.ErrOperationNotSupported
+}
+
+// Prove is not supported, as the group does not hold the accounts trie
+func (ga *groupAccounts) Prove(_ []byte, _ []byte) ([][]byte, error) {
+	return nil, process.ErrOperationNotSupported
+}
+
+// RecreateReadOnly is not supported, as the group does not hold the accounts trie
+func (ga *groupAccounts) RecreateReadOnly(_ []byte) (state.AccountsAdapter, error) {
+	return nil, process.ErrOperationNotSupported
+}
+
+// GetDirtyTrieHashes is not supported, as the group does not hold the accounts trie
+func (ga *groupAccounts) GetDirtyTrieHashes() ([][]byte, error) {
+	return nil, process.ErrOperationNotSupported
+}
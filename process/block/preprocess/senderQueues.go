@@ -0,0 +1,70 @@
+package preprocess
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+)
+
+// senderQueue holds the transactions of a sender and the position of the first one not yet selected. It implements
+// sort.Interface, ordering the transactions by nonce
+type senderQueue struct {
+	transactions []*transaction.Transaction
+	txHashes     [][]byte
+	index        int
+}
+
+func (sq *senderQueue) Len() int {
+	return len(sq.transactions)
+}
+
+func (sq *senderQueue) Less(i, j int) bool {
+	return sq.transactions[i].Nonce < sq.transactions[j].Nonce
+}
+
+func (sq *senderQueue) Swap(i, j int) {
+	sq.transactions[i], sq.transactions[j] = sq.transactions[j], sq.transactions[i]
+	sq.txHashes[i], sq.txHashes[j] = sq.txHashes[j], sq.txHashes[i]
+}
+
+func (sq *senderQueue) head() *transaction.Transaction {
+	return sq.transactions[sq.index]
+}
+
+// senderQueues implements heap.Interface, ordering the senders by the gas price of their next transaction. The
+// senders with the same gas price are ordered by the nonce of their next transaction and then by address, so all
+// the nodes select the transactions in the same way
+type senderQueues []*senderQueue
+
+func (sqs senderQueues) Len() int {
+	return len(sqs)
+}
+
+func (sqs senderQueues) Less(i, j int) bool {
+	first, second := sqs[i].head(), sqs[j].head()
+	if first.GasPrice != second.GasPrice {
+		return first.GasPrice > second.GasPrice
+	}
+	if first.Nonce != second.Nonce {
+		return first.Nonce < second.Nonce
+	}
+
+	return bytes.Compare(first.SndAddr, second.SndAddr) < 0
+}
+
+func (sqs senderQueues) Swap(i, j int) {
+	sqs[i], sqs[j] = sqs[j], sqs[i]
+}
+
+func (sqs *senderQueues) Push(x interface{}) {
+	*sqs = append(*sqs, x.(*senderQueue))
+}
+
+func (sqs *senderQueues) Pop() interface{} {
+	old := *sqs
+	n := len(old)
+	sq := old[n-1]
+	*sqs = old[:n-1]
+
+	return sq
+}
@@ -2,7 +2,9 @@ package preprocess
 
 import (
 	"bytes"
+	"container/heap"
 	"fmt"
+	"runtime"
 	"sort"
 	"time"
 
@@ -32,6 +34,7 @@ type transactions struct {
 	storage              dataRetriever.StorageService
 	txProcessor          process.TransactionProcessor
 	accounts             state.AccountsAdapter
+	scheduler            *txScheduler
 }
 
 // NewTransactionPreprocessor creates a new transaction preprocessor object
@@ -86,6 +89,17 @@ func NewTransactionPreprocessor(
 		accounts:             accounts,
 	}
 
+	// the independent transactions of a miniblock are executed concurrently if the transaction processor allows it
+	parallelTxProcessor, ok := txProcessor.(process.ParallelTransactionProcessor)
+	if ok {
+		scheduler, err := newTxScheduler(accounts, parallelTxProcessor, marshalizer, runtime.NumCPU())
+		if err != nil {
+			return nil, err
+		}
+
+		txs.scheduler = scheduler
+	}
+
 	txs.chRcvAllTxs = make(chan bool)
 	txs.txPool.RegisterHandler(txs.receivedTransaction)
 
@@ -188,6 +202,14 @@ func (txs *transactions) ProcessBlockTransactions(body block.Body, round uint64,
 			continue
 		}
 
+		if txs.scheduler != nil {
+			err := txs.processMiniBlockTransactions(miniBlock, round, haveTime)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		for j := 0; j < len(miniBlock.TxHashes); j++ {
 			if haveTime() < 0 {
 				return process.ErrTimeIsOut
@@ -223,6 +245,52 @@ func (txs *transactions) ProcessBlockTransactions(body block.Body, round uint64,
 	return nil
 }
 
+// processMiniBlockTransactions executes the transactions of a miniblock from the block body with the scheduler. If
+// a transaction fails, it is removed from the pool as in the sequential execution
+func (txs *transactions) processMiniBlockTransactions(
+	miniBlock *block.MiniBlock,
+	round uint64,
+	haveTime func() time.Duration,
+) error {
+	miniBlockTxs := make([]*transaction.Transaction, 0, len(miniBlock.TxHashes))
+	for _, txHash := range miniBlock.TxHashes {
+		txs.txsForCurrBlock.mutTxsForBlock.RLock()
+		txInfo := txs.txsForCurrBlock.txHashAndInfo[string(txHash)]
+		txs.txsForCurrBlock.mutTxsForBlock.RUnlock()
+
+		if txInfo == nil || txInfo.tx == nil {
+			return process.ErrMissingTransaction
+		}
+
+		currTx, ok := txInfo.tx.(*transaction.Transaction)
+		if !ok {
+			return process.ErrWrongTypeAssertion
+		}
+
+		miniBlockTxs = append(miniBlockTxs, currTx)
+	}
+
+	failedIndex, err := txs.scheduler.executeTransactions(
+		miniBlockTxs,
+		round,
+		func() bool {
+			return haveTime() >= 0
+		},
+	)
+	if failedIndex >= 0 {
+		txs.removeBadTransaction(miniBlock.TxHashes[failedIndex], err, miniBlock.SenderShardID, miniBlock.ReceiverShardID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for index, txHash := range miniBlock.TxHashes {
+		txs.saveProcessedTransaction(txHash, miniBlockTxs[index], miniBlock.SenderShardID, miniBlock.ReceiverShardID)
+	}
+
+	return nil
+}
+
 // SaveTxBlockToStorage saves transactions from body into storage
 func (txs *transactions) SaveTxBlockToStorage(body block.Body) error {
 	for i := 0; i < len(body); i++ {
@@ -296,22 +364,36 @@ func (txs *transactions) processAndRemoveBadTransaction(
 ) error {
 
 	err := txs.txProcessor.ProcessTransaction(transaction, round)
+	txs.removeBadTransaction(transactionHash, err, sndShardId, dstShardId)
+	if err != nil {
+		return err
+	}
+
+	txs.saveProcessedTransaction(transactionHash, transaction, sndShardId, dstShardId)
+
+	return nil
+}
+
+// removeBadTransaction removes the transaction from the pool if its execution failed because of its nonce or of the
+// sender balance
+func (txs *transactions) removeBadTransaction(transactionHash []byte, err error, sndShardId uint32, dstShardId uint32) {
 	if err == process.ErrLowerNonceInTransaction ||
 		err == process.ErrInsufficientFunds {
 		strCache := process.ShardCacherIdentifier(sndShardId, dstShardId)
 		txs.txPool.RemoveData(transactionHash, strCache)
 	}
+}
 
-	if err != nil {
-		return err
-	}
-
+func (txs *transactions) saveProcessedTransaction(
+	transactionHash []byte,
+	transaction *transaction.Transaction,
+	sndShardId uint32,
+	dstShardId uint32,
+) {
 	txShardInfo := &txShardInfo{senderShardID: sndShardId, receiverShardID: dstShardId}
 	txs.txsForCurrBlock.mutTxsForBlock.Lock()
 	txs.txsForCurrBlock.txHashAndInfo[string(transactionHash)] = &txInfo{tx: transaction, txShardInfo: txShardInfo}
 	txs.txsForCurrBlock.mutTxsForBlock.Unlock()
-
-	return nil
 }
 
 // RequestTransactionsForMiniBlock requests missing transactions for a certain miniblock
@@ -406,7 +488,7 @@ func (txs *transactions) CreateAndProcessMiniBlock(sndShardId, dstShardId uint32
 	txStore := txs.txPool.ShardDataStore(strCache)
 
 	timeBefore := time.Now()
-	orderedTxes, orderedTxHashes, err := SortTxByNonce(txStore)
+	orderedTxes, orderedTxHashes, err := SortTxByGasPriceAndNonce(txStore)
 	timeAfter := time.Now()
 
 	if err != nil {
@@ -428,6 +510,9 @@ func (txs *transactions) CreateAndProcessMiniBlock(sndShardId, dstShardId uint32
 	miniBlock.Type = block.TxBlock
 	log.Info(fmt.Sprintf("creating mini blocks has been started: have %d txs in pool for shard id %d\n", len(orderedTxes), miniBlock.ReceiverShardID))
 
+	// once a transaction of a sender is not added, the next nonces of that sender can not be executed either
+	skippedSenders := make(map[string]struct{})
+
 	addedTxs := 0
 	addedGasLimitPerCrossShardMiniblock := uint64(0)
 	for index := range orderedTxes {
@@ -439,12 +524,18 @@ func (txs *transactions) CreateAndProcessMiniBlock(sndShardId, dstShardId uint32
 			continue
 		}
 
+		sender := string(orderedTxes[index].SndAddr)
+		if _, ok := skippedSenders[sender]; ok {
+			continue
+		}
+
 		currTxGasLimit := minGasLimitForTx
 		if isSmartContractAddress(orderedTxes[index].RcvAddr) {
 			currTxGasLimit = orderedTxes[index].GasLimit
 		}
 
 		if addedGasLimitPerCrossShardMiniblock+currTxGasLimit > process.MaxGasLimitPerMiniBlock {
+			skippedSenders[sender] = struct{}{}
 			continue
 		}
 
@@ -461,6 +552,7 @@ func (txs *transactions) CreateAndProcessMiniBlock(sndShardId, dstShardId uint32
 
 		if err != nil {
 			log.Debug(err.Error())
+			skippedSenders[sender] = struct{}{}
 			err = txs.accounts.RevertToSnapshot(snapshot)
 			if err != nil {
 				log.Error(err.Error())
@@ -492,16 +584,9 @@ func (txs *transactions) ProcessMiniBlock(miniBlock *block.MiniBlock, haveTime f
 		return err
 	}
 
-	for index := range miniBlockTxs {
-		if !haveTime() {
-			err = process.ErrTimeIsOut
-			return err
-		}
-
-		err = txs.txProcessor.ProcessTransaction(miniBlockTxs[index], round)
-		if err != nil {
-			return err
-		}
+	err = txs.executeMiniBlockTxs(miniBlockTxs, haveTime, round)
+	if err != nil {
+		return err
 	}
 
 	txShardInfo := &txShardInfo{senderShardID: miniBlock.SenderShardID, receiverShardID: miniBlock.ReceiverShardID}
@@ -515,20 +600,36 @@ func (txs *transactions) ProcessMiniBlock(miniBlock *block.MiniBlock, haveTime f
 	return nil
 }
 
-// SortTxByNonce sort transactions according to nonces
-func SortTxByNonce(txShardStore storage.Cacher) ([]*transaction.Transaction, [][]byte, error) {
-	if txShardStore == nil {
-		return nil, nil, process.ErrNilCacher
+func (txs *transactions) executeMiniBlockTxs(miniBlockTxs []*transaction.Transaction, haveTime func() bool, round uint64) error {
+	if txs.scheduler != nil {
+		_, err := txs.scheduler.executeTransactions(miniBlockTxs, round, haveTime)
+		return err
 	}
 
-	transactions := make([]*transaction.Transaction, 0)
-	txHashes := make([][]byte, 0)
+	for index := range miniBlockTxs {
+		if !haveTime() {
+			return process.ErrTimeIsOut
+		}
 
-	mTxHashes := make(map[uint64][][]byte)
-	mTransactions := make(map[uint64][]*transaction.Transaction)
+		err := txs.txProcessor.ProcessTransaction(miniBlockTxs[index], round)
+		if err != nil {
+			return err
+		}
+	}
 
-	nonces := make([]uint64, 0)
+	return nil
+}
+
+// SortTxByGasPriceAndNonce sorts the transactions of a shard store for execution. The transactions of every sender
+// are kept in nonce order, while the senders are interleaved by the gas price of their next transaction, so the
+// best paying transactions are selected first without breaking the nonce order of any sender
+func SortTxByGasPriceAndNonce(txShardStore storage.Cacher) ([]*transaction.Transaction, [][]byte, error) {
+	if txShardStore == nil {
+		return nil, nil, process.ErrNilCacher
+	}
 
+	queues := make(map[string]*senderQueue)
+	numTxs := 0
 	for _, key := range txShardStore.Keys() {
 		val, _ := txShardStore.Peek(key)
 		if val == nil {
@@ -540,27 +641,40 @@ func SortTxByNonce(txShardStore storage.Cacher) ([]*transaction.Transaction, [][
 			continue
 		}
 
-		if mTxHashes[tx.Nonce] == nil {
-			nonces = append(nonces, tx.Nonce)
-			mTxHashes[tx.Nonce] = make([][]byte, 0)
-			mTransactions[tx.Nonce] = make([]*transaction.Transaction, 0)
+		sq, ok := queues[string(tx.SndAddr)]
+		if !ok {
+			sq = &senderQueue{
+				transactions: make([]*transaction.Transaction, 0),
+				txHashes:     make([][]byte, 0),
+			}
+			queues[string(tx.SndAddr)] = sq
 		}
 
-		mTxHashes[tx.Nonce] = append(mTxHashes[tx.Nonce], key)
-		mTransactions[tx.Nonce] = append(mTransactions[tx.Nonce], tx)
+		sq.transactions = append(sq.transactions, tx)
+		sq.txHashes = append(sq.txHashes, key)
+		numTxs++
 	}
 
-	sort.Slice(nonces, func(i, j int) bool {
-		return nonces[i] < nonces[j]
-	})
+	sqs := make(senderQueues, 0, len(queues))
+	for _, sq := range queues {
+		sort.Stable(sq)
+		sqs = append(sqs, sq)
+	}
+	heap.Init(&sqs)
 
-	for _, nonce := range nonces {
-		keys := mTxHashes[nonce]
+	transactions := make([]*transaction.Transaction, 0, numTxs)
+	txHashes := make([][]byte, 0, numTxs)
+	for sqs.Len() > 0 {
+		sq := sqs[0]
+		transactions = append(transactions, sq.head())
+		txHashes = append(txHashes, sq.txHashes[sq.index])
 
-		for idx, key := range keys {
-			txHashes = append(txHashes, key)
-			transactions = append(transactions, mTransactions[nonce][idx])
+		sq.index++
+		if sq.index == len(sq.transactions) {
+			heap.Pop(&sqs)
+			continue
 		}
+		heap.Fix(&sqs, 0)
 	}
 
 	return transactions, txHashes, nil
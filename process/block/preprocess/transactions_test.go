@@ -675,7 +675,62 @@ func TestTransactions_CreateAndProcessMiniBlockCrossShardGasLimitAddOnly5asSCCal
 	assert.Equal(t, numTxsToAdd, len(mb.TxHashes))
 }
 
-//------- SortTxByNonce
+func TestTransactions_CreateAndProcessMiniBlockShouldSkipNextNoncesOfFailedSender(t *testing.T) {
+	t.Parallel()
+
+	txPool, _ := shardedData.NewShardedData(storageUnit.CacheConfig{Size: 100000, Type: storageUnit.LRUCache})
+	requestTransaction := func(shardID uint32, txHashes [][]byte) {}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+
+	txs, _ := NewTransactionPreprocessor(
+		txPool,
+		&mock.ChainStorerMock{},
+		hasher,
+		marshalizer,
+		&mock.TxProcessorMock{ProcessTransactionCalled: func(transaction *transaction.Transaction, round uint64) error {
+			if bytes.Equal(transaction.SndAddr, []byte("alice")) && transaction.Nonce == 1 {
+				return process.ErrHigherNonceInTransaction
+			}
+			return nil
+		}},
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.AccountsStub{
+			JournalLenCalled: func() int {
+				return 0
+			},
+			RevertToSnapshotCalled: func(snapshot int) error {
+				return nil
+			},
+		},
+		requestTransaction,
+	)
+	assert.NotNil(t, txs)
+
+	sndShardId := uint32(0)
+	dstShardId := uint32(1)
+	strCache := process.ShardCacherIdentifier(sndShardId, dstShardId)
+
+	poolTxs := []*transaction.Transaction{
+		{SndAddr: []byte("alice"), Nonce: 0, GasPrice: 10},
+		{SndAddr: []byte("alice"), Nonce: 1, GasPrice: 10},
+		{SndAddr: []byte("alice"), Nonce: 2, GasPrice: 10},
+		{SndAddr: []byte("bob"), Nonce: 0, GasPrice: 1},
+	}
+	for _, tx := range poolTxs {
+		txHash, _ := core.CalculateHash(marshalizer, hasher, tx)
+		txPool.AddData(txHash, tx, strCache)
+	}
+
+	mb, err := txs.CreateAndProcessMiniBlock(sndShardId, dstShardId, process.MaxItemsInBlock, haveTimeTrue, 10)
+	assert.Nil(t, err)
+
+	aliceTx0Hash, _ := core.CalculateHash(marshalizer, hasher, poolTxs[0])
+	bobTx0Hash, _ := core.CalculateHash(marshalizer, hasher, poolTxs[3])
+	assert.Equal(t, [][]byte{aliceTx0Hash, bobTx0Hash}, mb.TxHashes)
+}
+
+//------- SortTxByGasPriceAndNonce
 
 var r *rand.Rand
 var mutex sync.Mutex
@@ -684,29 +739,29 @@ func init() {
 	r = rand.New(rand.NewSource(time.Now().UnixNano()))
 }
 
-func TestSortTxByNonce_NilCacherShouldErr(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_NilCacherShouldErr(t *testing.T) {
 	t.Parallel()
-	transactions, txHashes, err := SortTxByNonce(nil)
+	transactions, txHashes, err := SortTxByGasPriceAndNonce(nil)
 	assert.Nil(t, transactions)
 	assert.Nil(t, txHashes)
 	assert.Equal(t, process.ErrNilCacher, err)
 }
 
-func TestSortTxByNonce_EmptyCacherShouldReturnEmpty(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_EmptyCacherShouldReturnEmpty(t *testing.T) {
 	t.Parallel()
 	cacher, _ := storageUnit.NewCache(storageUnit.LRUCache, 100, 1)
-	transactions, txHashes, err := SortTxByNonce(cacher)
+	transactions, txHashes, err := SortTxByGasPriceAndNonce(cacher)
 	assert.Equal(t, 0, len(transactions))
 	assert.Equal(t, 0, len(txHashes))
 	assert.Nil(t, err)
 }
 
-func TestSortTxByNonce_OneTxShouldWork(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_OneTxShouldWork(t *testing.T) {
 	t.Parallel()
 	cacher, _ := storageUnit.NewCache(storageUnit.LRUCache, 100, 1)
 	hash, tx := createRandTx(r)
 	cacher.HasOrAdd(hash, tx)
-	transactions, txHashes, err := SortTxByNonce(cacher)
+	transactions, txHashes, err := SortTxByGasPriceAndNonce(cacher)
 	assert.Equal(t, 1, len(transactions))
 	assert.Equal(t, 1, len(txHashes))
 	assert.Nil(t, err)
@@ -745,35 +800,35 @@ func txInSlice(tx *transaction.Transaction, transactions []*transaction.Transact
 	return false
 }
 
-func TestSortTxByNonce_MoreTransactionsShouldNotErr(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_MoreTransactionsShouldNotErr(t *testing.T) {
 	t.Parallel()
 	cache, _, _ := genCacherTransactionsHashes(100)
-	_, _, err := SortTxByNonce(cache)
+	_, _, err := SortTxByGasPriceAndNonce(cache)
 	assert.Nil(t, err)
 }
 
-func TestSortTxByNonce_MoreTransactionsShouldRetSameSize(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_MoreTransactionsShouldRetSameSize(t *testing.T) {
 	t.Parallel()
 	cache, genTransactions, _ := genCacherTransactionsHashes(100)
-	transactions, txHashes, _ := SortTxByNonce(cache)
+	transactions, txHashes, _ := SortTxByGasPriceAndNonce(cache)
 	assert.Equal(t, len(genTransactions), len(transactions))
 	assert.Equal(t, len(genTransactions), len(txHashes))
 }
 
-func TestSortTxByNonce_MoreTransactionsShouldContainSameElements(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_MoreTransactionsShouldContainSameElements(t *testing.T) {
 	t.Parallel()
 	cache, genTransactions, genHashes := genCacherTransactionsHashes(100)
-	transactions, txHashes, _ := SortTxByNonce(cache)
+	transactions, txHashes, _ := SortTxByGasPriceAndNonce(cache)
 	for i := 0; i < len(genTransactions); i++ {
 		assert.True(t, hashInSlice(genHashes[i], txHashes))
 		assert.True(t, txInSlice(genTransactions[i], transactions))
 	}
 }
 
-func TestSortTxByNonce_MoreTransactionsShouldContainSortedElements(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_MoreTransactionsShouldContainSortedElements(t *testing.T) {
 	t.Parallel()
 	cache, _, _ := genCacherTransactionsHashes(100)
-	transactions, _, _ := SortTxByNonce(cache)
+	transactions, _, _ := SortTxByGasPriceAndNonce(cache)
 	lastNonce := uint64(0)
 	for i := 0; i < len(transactions); i++ {
 		tx := transactions[i]
@@ -783,7 +838,7 @@ func TestSortTxByNonce_MoreTransactionsShouldContainSortedElements(t *testing.T)
 	}
 }
 
-func TestSortTxByNonce_TransactionsWithSameNonceShouldGetSorted(t *testing.T) {
+func TestSortTxByGasPriceAndNonce_TransactionsWithSameNonceShouldGetSorted(t *testing.T) {
 	t.Parallel()
 	transactions := []*transaction.Transaction{
 		{Nonce: 1, Signature: []byte("sig1")},
@@ -800,7 +855,7 @@ func TestSortTxByNonce_TransactionsWithSameNonceShouldGetSorted(t *testing.T) {
 
 		cache.Put(hash, tx)
 	}
-	sortedTxs, _, _ := SortTxByNonce(cache)
+	sortedTxs, _, _ := SortTxByGasPriceAndNonce(cache)
 	lastNonce := uint64(0)
 	for i := 0; i < len(sortedTxs); i++ {
 		tx := sortedTxs[i]
@@ -824,6 +879,39 @@ func TestSortTxByNonce_TransactionsWithSameNonceShouldGetSorted(t *testing.T) {
 	}
 }
 
+func TestSortTxByGasPriceAndNonce_ShouldOrderSendersByGasPriceAndKeepNonceOrder(t *testing.T) {
+	t.Parallel()
+
+	transactions := []*transaction.Transaction{
+		{SndAddr: []byte("alice"), Nonce: 2, GasPrice: 10},
+		{SndAddr: []byte("bob"), Nonce: 5, GasPrice: 5},
+		{SndAddr: []byte("alice"), Nonce: 1, GasPrice: 1},
+		{SndAddr: []byte("carol"), Nonce: 7, GasPrice: 3},
+		{SndAddr: []byte("bob"), Nonce: 6, GasPrice: 8},
+	}
+	cache, _ := storageUnit.NewCache(storageUnit.LRUCache, uint32(len(transactions)), 1)
+	for _, tx := range transactions {
+		marshalizer := &mock.MarshalizerMock{}
+		buffTx, _ := marshalizer.Marshal(tx)
+		hash := mock.HasherMock{}.Compute(string(buffTx))
+
+		cache.Put(hash, tx)
+	}
+
+	sortedTxs, txHashes, err := SortTxByGasPriceAndNonce(cache)
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(transactions), len(txHashes))
+	expectedTxs := []*transaction.Transaction{
+		transactions[1],
+		transactions[4],
+		transactions[3],
+		transactions[2],
+		transactions[0],
+	}
+	assert.Equal(t, expectedTxs, sortedTxs)
+}
+
 func genCacherTransactionsHashes(noOfTx int) (storage.Cacher, []*transaction.Transaction, [][]byte) {
 	cacher, _ := storageUnit.NewCache(storageUnit.LRUCache, uint32(noOfTx), 1)
 	genHashes := make([][]byte, 0)
@@ -838,10 +926,10 @@ func genCacherTransactionsHashes(noOfTx int) (storage.Cacher, []*transaction.Tra
 	return cacher, genTransactions, genHashes
 }
 
-func BenchmarkSortTxByNonce1(b *testing.B) {
+func BenchmarkSortTxByGasPriceAndNonce1(b *testing.B) {
 	cache, _, _ := genCacherTransactionsHashes(10000)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _, _ = SortTxByNonce(cache)
+		_, _, _ = SortTxByGasPriceAndNonce(cache)
 	}
 }
@@ -0,0 +1,402 @@
+package preprocess
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// txGroup holds the indexes of the transactions which access the same accounts, in their execution order
+type txGroup struct {
+	txIndexes   []int
+	accounts    *groupAccounts
+	txProcessor process.TransactionProcessorCopy
+	failedIndex int
+	err         error
+}
+
+// txScheduler executes the transactions of a miniblock, running the independent move balance transactions
+// concurrently. The transactions are partitioned in groups which do not share any account, each group is executed
+// in order against its own copy of the accounts and journal and the modified accounts are then saved in the
+// accounts adapter, so the resulting state is the same as the one of the sequential execution
+type txScheduler struct {
+	accounts    state.AccountsAdapter
+	txProcessor process.ParallelTransactionProcessor
+	marshalizer marshal.Marshalizer
+	maxWorkers  int
+}
+
+func newTxScheduler(
+	accounts state.AccountsAdapter,
+	txProcessor process.ParallelTransactionProcessor,
+	marshalizer marshal.Marshalizer,
+	maxWorkers int,
+) (*txScheduler, error) {
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if txProcessor == nil {
+		return nil, process.ErrNilTxProcessor
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+
+	return &txScheduler{
+		accounts:    accounts,
+		txProcessor: txProcessor,
+		marshalizer: marshalizer,
+		maxWorkers:  maxWorkers,
+	}, nil
+}
+
+// executeTransactions executes the given transactions with the same result as executing them in order. The
+// transactions which can not be executed concurrently, as they might access other accounts than the sender and the
+// receiver, are executed alone, after all the previous ones. It returns the index of the first transaction which
+// failed, which is the one the sequential execution fails at, or -1 if the error is not caused by a transaction
+func (ts *txScheduler) executeTransactions(
+	txs []*transaction.Transaction,
+	round uint64,
+	haveTime func() bool,
+) (int, error) {
+	batch := make([]int, 0, len(txs))
+	for index, tx := range txs {
+		if ts.isParallelizable(tx) {
+			batch = append(batch, index)
+			continue
+		}
+
+		failedIndex, err := ts.executeBatch(txs, batch, round, haveTime)
+		if err != nil {
+			return failedIndex, err
+		}
+		batch = batch[:0]
+
+		if !haveTime() {
+			return -1, process.ErrTimeIsOut
+		}
+
+		err = ts.txProcessor.ProcessTransaction(tx, round)
+		if err != nil {
+			return index, err
+		}
+	}
+
+	return ts.executeBatch(txs, batch, round, haveTime)
+}
+
+// isParallelizable returns true for the move balance transactions between accounts which are not smart contracts.
+// They only access their sender and receiver accounts, as the fees and the receipts are kept by each group until merged
+func (ts *txScheduler) isParallelizable(tx *transaction.Transaction) bool {
+	if len(tx.Data) > 0 || len(tx.RcvAddr) == 0 || len(tx.SndAddr) == 0 {
+		return false
+	}
+	if bytes.Equal(tx.RcvAddr, process.StakingAddress) {
+		return false
+	}
+
+	account, err := ts.accounts.GetExistingAccount(state.NewAddress(tx.RcvAddr))
+	if err == state.ErrAccNotFound {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+
+	return len(account.GetCode()) == 0
+}
+
+func (ts *txScheduler) executeBatch(
+	txs []*transaction.Transaction,
+	batch []int,
+	round uint64,
+	haveTime func() bool,
+) (int, error) {
+	groups := partitionInGroups(txs, batch)
+	if len(groups) < 2 || ts.maxWorkers < 2 {
+		return ts.executeSequentially(txs, batch, round, haveTime)
+	}
+
+	loaded, err := ts.loadAccounts(txs, batch)
+	if err != nil {
+		return -1, err
+	}
+
+	chGroups := make(chan *txGroup, len(groups))
+	for _, group := range groups {
+		group.accounts = newGroupAccounts(ts.marshalizer, loaded)
+		group.failedIndex = -1
+		chGroups <- group
+	}
+	close(chGroups)
+
+	numWorkers := ts.maxWorkers
+	if numWorkers > len(groups) {
+		numWorkers = len(groups)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			for group := range chGroups {
+				ts.executeGroup(txs, group, round, haveTime)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	var firstFailed *txGroup
+	for _, group := range groups {
+		if group.err == nil {
+			continue
+		}
+		if firstFailed == nil || isFailedBefore(group, firstFailed) {
+			firstFailed = group
+		}
+	}
+	if firstFailed != nil {
+		// the groups are dropped together with their fees and receipts
+		return firstFailed.failedIndex, firstFailed.err
+	}
+
+	for _, group := range groups {
+		err = ts.mergeGroup(group)
+		if err != nil {
+			return -1, err
+		}
+	}
+	for _, group := range groups {
+		group.txProcessor.MergeFeesAndReceipts()
+	}
+
+	return -1, nil
+}
+
+// isFailedBefore compares the failures of two groups. The errors which are not caused by a transaction come first
+func isFailedBefore(group *txGroup, other *txGroup) bool {
+	if other.failedIndex < 0 {
+		return false
+	}
+
+	return group.failedIndex < other.failedIndex
+}
+
+func (ts *txScheduler) executeSequentially(
+	txs []*transaction.Transaction,
+	batch []int,
+	round uint64,
+	haveTime func() bool,
+) (int, error) {
+	for _, index := range batch {
+		if !haveTime() {
+			return -1, process.ErrTimeIsOut
+		}
+
+		err := ts.txProcessor.ProcessTransaction(txs[index], round)
+		if err != nil {
+			return index, err
+		}
+	}
+
+	return -1, nil
+}
+
+func (ts *txScheduler) executeGroup(txs []*transaction.Transaction, group *txGroup, round uint64, haveTime func() bool) {
+	txProcessor, err := ts.txProcessor.CopyWithAccounts(group.accounts)
+	if err != nil {
+		group.err = err
+		return
+	}
+	group.txProcessor = txProcessor
+
+	for _, index := range group.txIndexes {
+		if !haveTime() {
+			group.err = process.ErrTimeIsOut
+			return
+		}
+
+		err = txProcessor.ProcessTransaction(txs[index], round)
+		if err != nil {
+			group.failedIndex = index
+			group.err = err
+			return
+		}
+	}
+}
+
+// loadAccounts reads the sender and receiver accounts of the batch before the concurrent execution, so the groups
+// never access the accounts adapter while the other groups are executed. The transaction types are also computed
+// against the accounts of the group, so the missing receivers are created only in the group. A missing account is
+// loaded as nil
+func (ts *txScheduler) loadAccounts(txs []*transaction.Transaction, batch []int) (map[string][]byte, error) {
+	loaded := make(map[string][]byte)
+	for _, index := range batch {
+		for _, address := range [][]byte{txs[index].SndAddr, txs[index].RcvAddr} {
+			_, ok := loaded[string(address)]
+			if ok {
+				continue
+			}
+
+			account, err := ts.accounts.GetExistingAccount(state.NewAddress(address))
+			if err == state.ErrAccNotFound {
+				loaded[string(address)] = nil
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			buff, err := ts.marshalizer.Marshal(account)
+			if err != nil {
+				return nil, err
+			}
+
+			loaded[string(address)] = buff
+		}
+	}
+
+	return loaded, nil
+}
+
+// mergeGroup saves the accounts modified by a group in the accounts adapter. The accounts are changed with journal,
+// so the whole execution can be reverted as the sequential one
+func (ts *txScheduler) mergeGroup(group *txGroup) error {
+	for _, key := range group.accounts.updatedKeys {
+		val := group.accounts.updated[key]
+		if len(val) == 0 {
+			if len(group.accounts.loaded[key]) > 0 {
+				// move balance transactions never remove accounts
+				return process.ErrOperationNotSupported
+			}
+			continue
+		}
+
+		address, ok := group.accounts.addresses[key]
+		if !ok {
+			address = state.NewAddress([]byte(key))
+		}
+
+		err := ts.mergeAccount(group.accounts, address, val)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ts *txScheduler) mergeAccount(tracker state.AccountTracker, address state.AddressContainer, val []byte) error {
+	updated, err := state.NewAccount(address, tracker)
+	if err != nil {
+		return err
+	}
+
+	err = ts.marshalizer.Unmarshal(updated, val)
+	if err != nil {
+		return err
+	}
+
+	accountHandler, err := ts.accounts.GetAccountWithJournal(address)
+	if err != nil {
+		return err
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	if account.Nonce != updated.Nonce {
+		err = account.SetNonceWithJournal(updated.Nonce)
+		if err != nil {
+			return err
+		}
+	}
+	if account.Balance.Cmp(updated.Balance) != 0 {
+		err = account.SetBalanceWithJournal(updated.Balance)
+		if err != nil {
+			return err
+		}
+	}
+	if !bytes.Equal(account.CodeHash, updated.CodeHash) {
+		err = account.SetCodeHashWithJournal(updated.CodeHash)
+		if err != nil {
+			return err
+		}
+	}
+	if !bytes.Equal(account.RootHash, updated.RootHash) {
+		err = account.SetRootHashWithJournal(updated.RootHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// partitionInGroups splits the transactions of the batch in groups such that the transactions of different groups
+// do not share any sender or receiver account. The groups are sorted by their first transaction, and every group
+// keeps its transactions in the batch order
+func partitionInGroups(txs []*transaction.Transaction, batch []int) []*txGroup {
+	parents := make([]int, len(batch))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	union := func(i int, j int) {
+		rootI, rootJ := find(i), find(j)
+		if rootI == rootJ {
+			return
+		}
+		// the root is always the lowest position, which is the first transaction of the group
+		if rootI < rootJ {
+			parents[rootJ] = rootI
+		} else {
+			parents[rootI] = rootJ
+		}
+	}
+
+	lastAccess := make(map[string]int)
+	for position, index := range batch {
+		for _, address := range [][]byte{txs[index].SndAddr, txs[index].RcvAddr} {
+			previous, ok := lastAccess[string(address)]
+			if ok {
+				union(previous, position)
+			}
+			lastAccess[string(address)] = position
+		}
+	}
+
+	groups := make([]*txGroup, 0)
+	groupsByRoot := make(map[int]*txGroup)
+	for position, index := range batch {
+		root := find(position)
+		group, ok := groupsByRoot[root]
+		if !ok {
+			group = &txGroup{txIndexes: make([]int, 0)}
+			groupsByRoot[root] = group
+			groups = append(groups, group)
+		}
+
+		group.txIndexes = append(group.txIndexes, index)
+	}
+
+	return groups
+}
@@ -0,0 +1,492 @@
+package preprocess
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/smartContract"
+	txproc "github.com/ElrondNetwork/elrond-go/process/transaction"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+)
+
+const numSchedulerSenders = 10
+
+func createSchedulerAddress(prefix string, index int) []byte {
+	return []byte(fmt.Sprintf("%s%029d", prefix, index))
+}
+
+func createSchedulerAccounts(balance int64) *state.AccountsDB {
+	marshalizer := &mock.MarshalizerMock{}
+	db, _ := memorydb.New()
+	tr, _ := trie.NewTrie(db, marshalizer, mock.HasherMock{})
+	adb, _ := state.NewAccountsDB(tr, mock.HasherMock{}, marshalizer, factory.NewAccountCreator(), nil)
+
+	for i := 0; i < numSchedulerSenders; i++ {
+		account, _ := adb.GetAccountWithJournal(state.NewAddress(createSchedulerAddress("snd", i)))
+		_ = account.(*state.Account).SetBalanceWithJournal(big.NewInt(balance))
+	}
+	_, _ = adb.Commit()
+
+	return adb
+}
+
+func createSchedulerTxProcessor(accounts state.AccountsAdapter) (process.ParallelTransactionProcessor, *int64) {
+	numReceipts := int64(0)
+	txProc := createSchedulerTxProcessorWithHandlers(
+		accounts,
+		&mock.SCProcessorMock{},
+		createSchedulerFeeAccumulator(accounts),
+		&mock.ReceiptsHandlerStub{
+			AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+				atomic.AddInt64(&numReceipts, 1)
+			},
+		},
+	)
+
+	return txProc, &numReceipts
+}
+
+func createSchedulerFeeAccumulator(accounts state.AccountsAdapter) process.TransactionFeeHandler {
+	feeAccumulator, _ := economics.NewFeeAccumulator(accounts)
+	return feeAccumulator
+}
+
+func createSchedulerTxProcessorWithHandlers(
+	accounts state.AccountsAdapter,
+	scProcessor process.SmartContractProcessor,
+	txFeeHandler process.TransactionFeeHandler,
+	receiptsHandler process.ReceiptsHandler,
+) process.ParallelTransactionProcessor {
+	txProc, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		scProcessor,
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(0).SetUint64(tx.GasPrice * tx.GasLimit)
+			},
+		},
+		txFeeHandler,
+		&mock.StakingHandlerStub{},
+		receiptsHandler,
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	return txProc
+}
+
+// schedulerAccountsCounter counts the accounts requested with journal from the accounts of the node
+type schedulerAccountsCounter struct {
+	*state.AccountsDB
+	numGetAccountWithJournal int64
+}
+
+func (sac *schedulerAccountsCounter) GetAccountWithJournal(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+	atomic.AddInt64(&sac.numGetAccountWithJournal, 1)
+	return sac.AccountsDB.GetAccountWithJournal(addressContainer)
+}
+
+func createSchedulerScProcessor(accounts state.AccountsAdapter) process.SmartContractProcessor {
+	scProcessor, _ := smartContract.NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		accounts,
+		&mock.TemporaryAccountsHandlerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+	)
+
+	return scProcessor
+}
+
+// createSchedulerTxs creates transactions from the senders, paired so that every sender also pays its pair, towards
+// new accounts and, from time to time, with data, so they are executed alone. Every pair of senders forms a group
+func createSchedulerTxs(numTxs int) []*transaction.Transaction {
+	nonces := make(map[int]uint64)
+	txs := make([]*transaction.Transaction, 0, numTxs)
+	for i := 0; i < numTxs; i++ {
+		sender := i % numSchedulerSenders
+		receiver := createSchedulerAddress("rcv", sender*10+i%3)
+		if i%4 == 0 {
+			receiver = createSchedulerAddress("snd", sender^1)
+		}
+
+		tx := &transaction.Transaction{
+			Nonce:    nonces[sender],
+			Value:    big.NewInt(int64(i + 1)),
+			SndAddr:  createSchedulerAddress("snd", sender),
+			RcvAddr:  receiver,
+			GasPrice: 2,
+			GasLimit: 3,
+		}
+		if i%17 == 16 {
+			tx.Data = "data"
+		}
+
+		nonces[sender]++
+		txs = append(txs, tx)
+	}
+
+	return txs
+}
+
+func TestNewTxScheduler_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, _ := createSchedulerTxProcessor(&mock.AccountsStub{})
+	ts, err := newTxScheduler(nil, txProc, &mock.MarshalizerMock{}, 4)
+
+	assert.Nil(t, ts)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestNewTxScheduler_NilTxProcessorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ts, err := newTxScheduler(&mock.AccountsStub{}, nil, &mock.MarshalizerMock{}, 4)
+
+	assert.Nil(t, ts)
+	assert.Equal(t, process.ErrNilTxProcessor, err)
+}
+
+func TestNewTxScheduler_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, _ := createSchedulerTxProcessor(&mock.AccountsStub{})
+	ts, err := newTxScheduler(&mock.AccountsStub{}, txProc, nil, 4)
+
+	assert.Nil(t, ts)
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+}
+
+func TestNewTransactionPreprocessor_ParallelTxProcessorShouldCreateScheduler(t *testing.T) {
+	t.Parallel()
+
+	tdp := initDataPool()
+	txProc, _ := createSchedulerTxProcessor(&mock.AccountsStub{})
+	txs, _ := NewTransactionPreprocessor(
+		tdp.Transactions(),
+		&mock.ChainStorerMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		txProc,
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.AccountsStub{},
+		func(shardID uint32, txHashes [][]byte) {},
+	)
+
+	assert.NotNil(t, txs.scheduler)
+}
+
+func TestNewTransactionPreprocessor_SequentialTxProcessorShouldNotCreateScheduler(t *testing.T) {
+	t.Parallel()
+
+	tdp := initDataPool()
+	txs, _ := NewTransactionPreprocessor(
+		tdp.Transactions(),
+		&mock.ChainStorerMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.AccountsStub{},
+		func(shardID uint32, txHashes [][]byte) {},
+	)
+
+	assert.Nil(t, txs.scheduler)
+}
+
+func TestPartitionInGroups_ShouldGroupTheTransactionsSharingAccounts(t *testing.T) {
+	t.Parallel()
+
+	txs := []*transaction.Transaction{
+		{SndAddr: []byte("A"), RcvAddr: []byte("B")},
+		{SndAddr: []byte("C"), RcvAddr: []byte("D")},
+		{SndAddr: []byte("E"), RcvAddr: []byte("F")},
+		{SndAddr: []byte("D"), RcvAddr: []byte("G")},
+		{SndAddr: []byte("G"), RcvAddr: []byte("A")},
+		{SndAddr: []byte("H"), RcvAddr: []byte("H")},
+	}
+
+	groups := partitionInGroups(txs, []int{0, 1, 2, 3, 4, 5})
+
+	assert.Equal(t, 3, len(groups))
+	assert.Equal(t, []int{0, 1, 3, 4}, groups[0].txIndexes)
+	assert.Equal(t, []int{2}, groups[1].txIndexes)
+	assert.Equal(t, []int{5}, groups[2].txIndexes)
+}
+
+func TestTxScheduler_ExecuteTransactionsShouldComputeTheSameStateAsSequentialExecution(t *testing.T) {
+	t.Parallel()
+
+	txs := createSchedulerTxs(100)
+	batch := make([]int, len(txs))
+	for i := range batch {
+		batch[i] = i
+	}
+	assert.Equal(t, numSchedulerSenders/2, len(partitionInGroups(txs, batch)))
+
+	sequentialAccounts := createSchedulerAccounts(1000)
+	sequentialTxProc, sequentialReceipts := createSchedulerTxProcessor(sequentialAccounts)
+	for _, tx := range txs {
+		err := sequentialTxProc.ProcessTransaction(tx, 1)
+		assert.Nil(t, err)
+	}
+
+	parallelAccounts := createSchedulerAccounts(1000)
+	initialRootHash, _ := parallelAccounts.RootHash()
+	parallelTxProc, parallelReceipts := createSchedulerTxProcessor(parallelAccounts)
+	ts, _ := newTxScheduler(parallelAccounts, parallelTxProc, &mock.MarshalizerMock{}, 4)
+	failedIndex, err := ts.executeTransactions(txs, 1, haveTimeTrue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, -1, failedIndex)
+	sequentialRootHash, _ := sequentialAccounts.RootHash()
+	parallelRootHash, _ := parallelAccounts.RootHash()
+	assert.Equal(t, sequentialRootHash, parallelRootHash)
+	assert.NotEqual(t, initialRootHash, parallelRootHash)
+	assert.Equal(t, int64(len(txs)), *sequentialReceipts)
+	assert.Equal(t, int64(len(txs)), *parallelReceipts)
+
+	err = parallelAccounts.RevertToSnapshot(0)
+	assert.Nil(t, err)
+	revertedRootHash, _ := parallelAccounts.RootHash()
+	assert.Equal(t, initialRootHash, revertedRootHash)
+}
+
+func TestTxScheduler_ExecuteTransactionsWithScProcessorShouldComputeTheSameState(t *testing.T) {
+	t.Parallel()
+
+	txs := createSchedulerTxs(100)
+	for _, tx := range txs {
+		// the smart contract calls are not executed by the mocked virtual machine
+		tx.Data = ""
+	}
+
+	sequentialAccounts := createSchedulerAccounts(1000)
+	sequentialTxProc := createSchedulerTxProcessorWithHandlers(
+		sequentialAccounts,
+		createSchedulerScProcessor(sequentialAccounts),
+		createSchedulerFeeAccumulator(sequentialAccounts),
+		&mock.ReceiptsHandlerStub{},
+	)
+	for _, tx := range txs {
+		err := sequentialTxProc.ProcessTransaction(tx, 1)
+		assert.Nil(t, err)
+	}
+
+	parallelAccounts := &schedulerAccountsCounter{AccountsDB: createSchedulerAccounts(1000)}
+	initialRootHash, _ := parallelAccounts.RootHash()
+	parallelTxProc := createSchedulerTxProcessorWithHandlers(
+		parallelAccounts,
+		createSchedulerScProcessor(parallelAccounts),
+		createSchedulerFeeAccumulator(parallelAccounts),
+		&mock.ReceiptsHandlerStub{},
+	)
+	ts, _ := newTxScheduler(parallelAccounts, parallelTxProc, &mock.MarshalizerMock{}, 4)
+	failedIndex, err := ts.executeTransactions(txs, 1, haveTimeTrue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, -1, failedIndex)
+	sequentialRootHash, _ := sequentialAccounts.RootHash()
+	parallelRootHash, _ := parallelAccounts.RootHash()
+	assert.Equal(t, sequentialRootHash, parallelRootHash)
+
+	// the accounts of the node are only changed when the groups are merged, once for every modified account
+	modified := make(map[string]struct{})
+	for _, tx := range txs {
+		modified[string(tx.SndAddr)] = struct{}{}
+		modified[string(tx.RcvAddr)] = struct{}{}
+	}
+	assert.Equal(t, int64(len(modified)), atomic.LoadInt64(&parallelAccounts.numGetAccountWithJournal))
+
+	err = parallelAccounts.RevertToSnapshot(0)
+	assert.Nil(t, err)
+	revertedRootHash, _ := parallelAccounts.RootHash()
+	assert.Equal(t, initialRootHash, revertedRootHash)
+}
+
+func TestTxScheduler_ExecuteTransactionsShouldAddTheFeesAndReceiptsOfTheMergedGroups(t *testing.T) {
+	t.Parallel()
+
+	txs := createSchedulerTxs(16)
+	accounts := createSchedulerAccounts(1000)
+	feeAccumulator, _ := economics.NewFeeAccumulator(accounts)
+	numReceipts := int64(0)
+	txProc := createSchedulerTxProcessorWithHandlers(
+		accounts,
+		&mock.SCProcessorMock{},
+		feeAccumulator,
+		&mock.ReceiptsHandlerStub{
+			AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+				atomic.AddInt64(&numReceipts, 1)
+			},
+		},
+	)
+	ts, _ := newTxScheduler(accounts, txProc, &mock.MarshalizerMock{}, 4)
+
+	_, err := ts.executeTransactions(txs, 1, haveTimeTrue)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(len(txs)*6), feeAccumulator.AccumulatedFees().Uint64())
+	assert.Equal(t, int64(len(txs)), numReceipts)
+}
+
+func TestTxScheduler_ExecuteTransactionsFailedBatchShouldNotAddTheFeesAndReceiptsOfTheGroups(t *testing.T) {
+	t.Parallel()
+
+	txs := createSchedulerTxs(16)
+	txs[12].Value = big.NewInt(1000000)
+	accounts := createSchedulerAccounts(1000)
+	feeAccumulator, _ := economics.NewFeeAccumulator(accounts)
+	numReceipts := int64(0)
+	txProc := createSchedulerTxProcessorWithHandlers(
+		accounts,
+		&mock.SCProcessorMock{},
+		feeAccumulator,
+		&mock.ReceiptsHandlerStub{
+			AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+				atomic.AddInt64(&numReceipts, 1)
+			},
+		},
+	)
+	ts, _ := newTxScheduler(accounts, txProc, &mock.MarshalizerMock{}, 4)
+
+	failedIndex, err := ts.executeTransactions(txs, 1, haveTimeTrue)
+
+	assert.Equal(t, process.ErrInsufficientFunds, err)
+	assert.Equal(t, 12, failedIndex)
+	assert.Equal(t, uint64(0), feeAccumulator.AccumulatedFees().Uint64())
+	assert.Equal(t, int64(0), numReceipts)
+}
+
+func TestTxScheduler_ExecuteTransactionsWithOneWorkerShouldComputeTheSameState(t *testing.T) {
+	t.Parallel()
+
+	txs := createSchedulerTxs(50)
+
+	sequentialAccounts := createSchedulerAccounts(1000)
+	sequentialTxProc, _ := createSchedulerTxProcessor(sequentialAccounts)
+	sequentialTs, _ := newTxScheduler(sequentialAccounts, sequentialTxProc, &mock.MarshalizerMock{}, 1)
+	_, errSequential := sequentialTs.executeTransactions(txs, 1, haveTimeTrue)
+
+	parallelAccounts := createSchedulerAccounts(1000)
+	parallelTxProc, _ := createSchedulerTxProcessor(parallelAccounts)
+	parallelTs, _ := newTxScheduler(parallelAccounts, parallelTxProc, &mock.MarshalizerMock{}, 8)
+	_, errParallel := parallelTs.executeTransactions(txs, 1, haveTimeTrue)
+
+	assert.Nil(t, errSequential)
+	assert.Nil(t, errParallel)
+	sequentialRootHash, _ := sequentialAccounts.RootHash()
+	parallelRootHash, _ := parallelAccounts.RootHash()
+	assert.Equal(t, sequentialRootHash, parallelRootHash)
+}
+
+func TestTxScheduler_ExecuteTransactionsShouldReturnTheFirstFailingTransaction(t *testing.T) {
+	t.Parallel()
+
+	txs := createSchedulerTxs(40)
+	// the sender can not pay the value of this transaction, while a later one of another group has a wrong nonce
+	txs[12].Value = big.NewInt(1000000)
+	txs[14].Nonce = 100
+
+	sequentialAccounts := createSchedulerAccounts(1000)
+	sequentialTxProc, _ := createSchedulerTxProcessor(sequentialAccounts)
+	sequentialFailedIndex := -1
+	var errSequential error
+	for index, tx := range txs {
+		errSequential = sequentialTxProc.ProcessTransaction(tx, 1)
+		if errSequential != nil {
+			sequentialFailedIndex = index
+			break
+		}
+	}
+
+	parallelAccounts := createSchedulerAccounts(1000)
+	parallelTxProc, _ := createSchedulerTxProcessor(parallelAccounts)
+	ts, _ := newTxScheduler(parallelAccounts, parallelTxProc, &mock.MarshalizerMock{}, 4)
+	failedIndex, err := ts.executeTransactions(txs, 1, haveTimeTrue)
+
+	assert.Equal(t, process.ErrInsufficientFunds, errSequential)
+	assert.Equal(t, 12, sequentialFailedIndex)
+	assert.Equal(t, errSequential, err)
+	assert.Equal(t, sequentialFailedIndex, failedIndex)
+}
+
+func TestTxScheduler_ExecuteTransactionsTimeOutShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txs := createSchedulerTxs(20)
+	accounts := createSchedulerAccounts(1000)
+	txProc, _ := createSchedulerTxProcessor(accounts)
+	ts, _ := newTxScheduler(accounts, txProc, &mock.MarshalizerMock{}, 4)
+
+	failedIndex, err := ts.executeTransactions(txs, 1, func() bool {
+		return false
+	})
+
+	assert.Equal(t, process.ErrTimeIsOut, err)
+	assert.Equal(t, -1, failedIndex)
+}
+
+func TestGroupAccounts_AccountNotLoadedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ga := newGroupAccounts(&mock.MarshalizerMock{}, make(map[string][]byte))
+
+	account, err := ga.GetAccountWithJournal(state.NewAddress([]byte("address")))
+
+	assert.Nil(t, account)
+	assert.Equal(t, process.ErrAccountNotLoaded, err)
+}
+
+func TestGroupAccounts_RevertToSnapshotShouldRestoreTheLoadedAccounts(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	existingAddress := state.NewAddress([]byte("existing"))
+	missingAddress := state.NewAddress([]byte("missing"))
+	existing, _ := state.NewAccount(existingAddress, &mock.AccountTrackerStub{})
+	existing.Balance = big.NewInt(10)
+	existingBuff, _ := marshalizer.Marshal(existing)
+	ga := newGroupAccounts(marshalizer, map[string][]byte{
+		string(existingAddress.Bytes()): existingBuff,
+		string(missingAddress.Bytes()):  nil,
+	})
+
+	accountHandler, _ := ga.GetAccountWithJournal(existingAddress)
+	_ = accountHandler.(*state.Account).SetBalanceWithJournal(big.NewInt(20))
+	_, _ = ga.GetAccountWithJournal(missingAddress)
+	hasMissing, _ := ga.HasAccount(missingAddress)
+	assert.True(t, hasMissing)
+	assert.Equal(t, 2, ga.JournalLen())
+
+	err := ga.RevertToSnapshot(0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, ga.JournalLen())
+	hasMissing, _ = ga.HasAccount(missingAddress)
+	assert.False(t, hasMissing)
+	accountHandler, _ = ga.GetExistingAccount(existingAddress)
+	assert.Equal(t, big.NewInt(10), accountHandler.(*state.Account).Balance)
+}
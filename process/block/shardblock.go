@@ -1,7 +1,9 @@
 package block
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 	"sort"
 	"sync"
 	"time"
@@ -38,9 +40,20 @@ type shardProcessor struct {
 	currHighestMetaHdrNonce    uint64
 	allNeededMetaHdrsFound     bool
 
-	core          serviceContainer.Core
-	txCoordinator process.TransactionCoordinator
-	txCounter     *transactionCounter
+	core                  serviceContainer.Core
+	txCoordinator         process.TransactionCoordinator
+	txCounter             *transactionCounter
+	txFeeHandler          process.TransactionFeeHandler
+	specialAddressHandler process.SpecialAddressHandler
+	stakingHandler        process.StakingHandler
+	ratingsHandler        process.RatingsHandler
+	rewardsHandler        process.RewardsHandler
+	receiptsHandler       process.ReceiptsHandler
+
+	mutConsensusData sync.RWMutex
+	prevHeader       data.HeaderHandler
+
+	genesisHeader *block.Header
 
 	appStatusHandler core.AppStatusHandler
 }
@@ -70,6 +83,14 @@ func NewShardProcessor(
 	requestHandler process.RequestHandler,
 	txCoordinator process.TransactionCoordinator,
 	uint64Converter typeConverters.Uint64ByteSliceConverter,
+	txFeeHandler process.TransactionFeeHandler,
+	specialAddressHandler process.SpecialAddressHandler,
+	epochHandler process.EpochHandler,
+	stakingHandler process.StakingHandler,
+	ratingsHandler process.RatingsHandler,
+	rewardsHandler process.RewardsHandler,
+	receiptsHandler process.ReceiptsHandler,
+	commitJournal process.CommitJournal,
 ) (*shardProcessor, error) {
 
 	err := checkProcessorNilParameters(
@@ -79,7 +100,8 @@ func NewShardProcessor(
 		marshalizer,
 		store,
 		shardCoordinator,
-		uint64Converter)
+		uint64Converter,
+		epochHandler)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +118,27 @@ func NewShardProcessor(
 	if txCoordinator == nil {
 		return nil, process.ErrNilTransactionCoordinator
 	}
+	if txFeeHandler == nil {
+		return nil, process.ErrNilTxFeeHandler
+	}
+	if specialAddressHandler == nil {
+		return nil, process.ErrNilSpecialAddressHandler
+	}
+	if stakingHandler == nil {
+		return nil, process.ErrNilStakingHandler
+	}
+	if ratingsHandler == nil {
+		return nil, process.ErrNilRatingsHandler
+	}
+	if rewardsHandler == nil {
+		return nil, process.ErrNilRewardsHandler
+	}
+	if receiptsHandler == nil {
+		return nil, process.ErrNilReceiptsHandler
+	}
+	if commitJournal == nil {
+		return nil, process.ErrNilCommitJournal
+	}
 
 	blockSizeThrottler, err := throttle.NewBlockSizeThrottle()
 	if err != nil {
@@ -111,6 +154,8 @@ func NewShardProcessor(
 		store:                         store,
 		shardCoordinator:              shardCoordinator,
 		uint64Converter:               uint64Converter,
+		epochHandler:                  epochHandler,
+		commitJournal:                 commitJournal,
 		onRequestHeaderHandlerByNonce: requestHandler.RequestHeaderByNonce,
 	}
 	err = base.setLastNotarizedHeadersSlice(startHeaders)
@@ -119,13 +164,20 @@ func NewShardProcessor(
 	}
 
 	sp := shardProcessor{
-		core:             core,
-		baseProcessor:    base,
-		dataPool:         dataPool,
-		blocksTracker:    blocksTracker,
-		txCoordinator:    txCoordinator,
-		txCounter:        NewTransactionCounter(),
-		appStatusHandler: statusHandler.NewNilStatusHandler(),
+		core:                  core,
+		baseProcessor:         base,
+		dataPool:              dataPool,
+		blocksTracker:         blocksTracker,
+		txCoordinator:         txCoordinator,
+		txCounter:             NewTransactionCounter(),
+		appStatusHandler:      statusHandler.NewNilStatusHandler(),
+		txFeeHandler:          txFeeHandler,
+		specialAddressHandler: specialAddressHandler,
+		stakingHandler:        stakingHandler,
+		ratingsHandler:        ratingsHandler,
+		rewardsHandler:        rewardsHandler,
+		receiptsHandler:       receiptsHandler,
+		genesisHeader:         startHeaders[shardCoordinator.SelfId()].(*block.Header),
 	}
 
 	sp.chRcvAllMetaHdrs = make(chan bool)
@@ -194,6 +246,10 @@ func (sp *shardProcessor) ProcessBlock(
 	log.Info(fmt.Sprintf("Total txs in pool: %d\n", numTxWithDst))
 
 	sp.txCoordinator.CreateBlockStarted()
+	sp.txFeeHandler.CreateBlockStarted()
+	sp.stakingHandler.CreateBlockStarted()
+	sp.rewardsHandler.CreateBlockStarted()
+	sp.receiptsHandler.CreateBlockStarted()
 	sp.txCoordinator.RequestBlockTransactions(body)
 	requestedMetaHdrs, requestedFinalMetaHdrs := sp.requestMetaHeaders(header)
 
@@ -232,6 +288,11 @@ func (sp *shardProcessor) ProcessBlock(
 		return err
 	}
 
+	err = sp.checkEpochCorrectness(header)
+	if err != nil {
+		return err
+	}
+
 	err = sp.verifyCrossShardMiniBlockDstMe(header)
 	if err != nil {
 		return err
@@ -248,6 +309,31 @@ func (sp *shardProcessor) ProcessBlock(
 		return err
 	}
 
+	if !isPeerInfoEqual(sp.stakingHandler.PeerInfo(), header.PeerInfo) {
+		err = process.ErrPeerInfoDoesNotMatch
+		return err
+	}
+
+	err = sp.specialAddressHandler.SetConsensusData(header.PrevRandSeed, header.Round)
+	if err != nil {
+		return err
+	}
+
+	err = sp.ratingsHandler.SaveRatings()
+	if err != nil {
+		return err
+	}
+
+	err = sp.creditAccumulatedFees(header.Round)
+	if err != nil {
+		return err
+	}
+
+	err = sp.processRewards(body, header)
+	if err != nil {
+		return err
+	}
+
 	if !sp.verifyStateRoot(header.GetRootHash()) {
 		err = process.ErrRootStateMissmatch
 		return err
@@ -312,6 +398,269 @@ func (sp *shardProcessor) checkMetaHeadersValidityAndFinality(header *block.Head
 	return nil
 }
 
+// checkEpochCorrectness checks if the epoch of the header is the one given by the included metablocks
+func (sp *shardProcessor) checkEpochCorrectness(header *block.Header) error {
+	epoch, err := sp.computeEpoch(header.MetaBlockHashes)
+	if err != nil {
+		return err
+	}
+
+	if header.Epoch != epoch {
+		return process.ErrEpochDoesNotMatch
+	}
+
+	return nil
+}
+
+// computeEpoch returns the epoch of a shard block which includes the given metablocks. The epoch changes only when
+// one of the included metablocks starts a new epoch
+func (sp *shardProcessor) computeEpoch(metaBlockHashes [][]byte) (uint32, error) {
+	epoch := sp.epochHandler.Epoch()
+
+	epochStartMetaBlock, err := sp.getLastEpochStartMetaBlock(metaBlockHashes)
+	if err != nil {
+		return 0, err
+	}
+
+	if epochStartMetaBlock != nil && epochStartMetaBlock.Epoch > epoch {
+		epoch = epochStartMetaBlock.Epoch
+	}
+
+	return epoch, nil
+}
+
+// getLastEpochStartMetaBlock returns the epoch start metablock with the highest epoch from the given metablocks,
+// or nil if none of them starts a new epoch
+func (sp *shardProcessor) getLastEpochStartMetaBlock(metaBlockHashes [][]byte) (*block.MetaBlock, error) {
+	var lastEpochStartMetaBlock *block.MetaBlock
+
+	for _, metaBlockHash := range metaBlockHashes {
+		metaBlock, err := process.GetMetaHeaderFromPool(metaBlockHash, sp.dataPool.MetaBlocks())
+		if err != nil {
+			return nil, err
+		}
+
+		if !metaBlock.IsStartOfEpochBlock() {
+			continue
+		}
+
+		if lastEpochStartMetaBlock == nil || metaBlock.Epoch > lastEpochStartMetaBlock.Epoch {
+			lastEpochStartMetaBlock = metaBlock
+		}
+	}
+
+	return lastEpochStartMetaBlock, nil
+}
+
+// applyEpochStart switches the validators lists if the committed block is the first one of a new epoch
+func (sp *shardProcessor) applyEpochStart(header *block.Header) error {
+	if header.Epoch <= sp.epochHandler.Epoch() {
+		return nil
+	}
+
+	epochStartMetaBlock, err := sp.getLastEpochStartMetaBlock(header.MetaBlockHashes)
+	if err != nil {
+		return err
+	}
+
+	if epochStartMetaBlock == nil || epochStartMetaBlock.Epoch != header.Epoch {
+		return process.ErrMissingEpochStartMetaBlock
+	}
+
+	err = sp.epochHandler.SetEpochStart(epochStartMetaBlock.Epoch, epochStartMetaBlock.EpochStart)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("epoch %d has started with shardBlock with nonce %d\n", header.Epoch, header.Nonce))
+
+	return nil
+}
+
+// RestoreEpochState restores the epoch, the validators lists and the ratings of the given committed block. The
+// validators lists are restored from the epoch start metablock included in the first block of its epoch, with the
+// ratings saved in the state of that block, as the epoch started with them. The rating changes observed in the
+// given block are computed again, so the next block saves them as the other nodes do. The headers are searched in
+// storage too, as the pools are empty when the node restarts
+func (sp *shardProcessor) RestoreEpochState(headerHandler data.HeaderHandler) error {
+	if headerHandler == nil || headerHandler.IsInterfaceNil() {
+		return process.ErrNilBlockHeader
+	}
+
+	header, ok := headerHandler.(*block.Header)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	if header.Nonce == 0 {
+		err := sp.ratingsHandler.LoadRatings(header.RootHash)
+		if err != nil {
+			return err
+		}
+
+		return sp.epochHandler.RestoreEpochStart(0, nil, nil)
+	}
+
+	prevHeader, err := sp.getPrevHeader(header)
+	if err != nil {
+		return err
+	}
+
+	// the first block of an epoch was produced by the validators of the previous epoch, so the rating changes
+	// observed in it are computed with their lists
+	isEpochStart := prevHeader.Epoch < header.Epoch
+	epochHeader := header
+	if isEpochStart {
+		epochHeader = prevHeader
+	}
+
+	err = sp.restoreEpochStart(epochHeader)
+	if err != nil {
+		return err
+	}
+
+	err = sp.restoreRatingChanges(header, prevHeader)
+	if err != nil {
+		return err
+	}
+
+	if !isEpochStart {
+		return nil
+	}
+
+	// the ratings saved in the state of the given block were loaded together with its rating changes
+	epochStartMetaBlock, err := sp.getEpochStartMetaBlock(header)
+	if err != nil {
+		return err
+	}
+
+	return sp.epochHandler.RestoreEpochStart(epochStartMetaBlock.Epoch, epochStartMetaBlock.EpochStart, nil)
+}
+
+// restoreEpochStart restores the validators lists of the epoch of the given block, together with the ratings they
+// had when the epoch started
+func (sp *shardProcessor) restoreEpochStart(header *block.Header) error {
+	if header.Epoch == 0 {
+		return sp.epochHandler.RestoreEpochStart(0, nil, nil)
+	}
+
+	firstHeader, err := sp.getFirstHeaderOfEpoch(header)
+	if err != nil {
+		return err
+	}
+
+	err = sp.ratingsHandler.LoadRatings(firstHeader.RootHash)
+	if err != nil {
+		return err
+	}
+
+	epochStartMetaBlock, err := sp.getEpochStartMetaBlock(firstHeader)
+	if err != nil {
+		return err
+	}
+
+	return sp.epochHandler.RestoreEpochStart(epochStartMetaBlock.Epoch, epochStartMetaBlock.EpochStart, nil)
+}
+
+// restoreRatingChanges loads the ratings saved in the state of the given block and computes the rating changes
+// observed in it, including the ones of the validators slashed in the metablocks it finished processing
+func (sp *shardProcessor) restoreRatingChanges(header *block.Header, prevHeader *block.Header) error {
+	err := sp.ratingsHandler.ProcessCommittedHeader(header, prevHeader)
+	if err != nil {
+		return err
+	}
+
+	processedMetaBlocks, err := sp.getProcessedMetaBlocksFromStorage(header)
+	if err != nil {
+		return err
+	}
+
+	for _, metaBlock := range processedMetaBlocks {
+		sp.ratingsHandler.ProcessSlashedPeers(metaBlock.PeerInfo)
+	}
+
+	return nil
+}
+
+// getProcessedMetaBlocksFromStorage returns the metablocks included in the given block whose miniblocks for this
+// shard were all processed by it or by the previous blocks which included the same metablock. These are the
+// metablocks the block finished processing when it was committed
+func (sp *shardProcessor) getProcessedMetaBlocksFromStorage(header *block.Header) ([]*block.MetaBlock, error) {
+	processedMetaBlocks := make([]*block.MetaBlock, 0)
+	for _, metaBlockHash := range header.MetaBlockHashes {
+		metaBlock, err := process.GetMetaHeader(metaBlockHash, sp.dataPool.MetaBlocks(), sp.marshalizer, sp.store)
+		if err != nil {
+			return nil, err
+		}
+
+		crossMiniBlockHashes := metaBlock.GetMiniBlockHeadersWithDst(sp.shardCoordinator.SelfId())
+		currHeader := header
+		for len(crossMiniBlockHashes) > 0 && isHashInList(currHeader.MetaBlockHashes, metaBlockHash) {
+			for _, mbHeader := range currHeader.MiniBlockHeaders {
+				delete(crossMiniBlockHashes, string(mbHeader.Hash))
+			}
+			if currHeader.Nonce <= 1 {
+				break
+			}
+
+			currHeader, err = sp.getPrevHeader(currHeader)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(crossMiniBlockHashes) == 0 {
+			processedMetaBlocks = append(processedMetaBlocks, metaBlock)
+		}
+	}
+
+	return processedMetaBlocks, nil
+}
+
+// getEpochStartMetaBlock returns the epoch start metablock included in the given first block of an epoch
+func (sp *shardProcessor) getEpochStartMetaBlock(firstHeader *block.Header) (*block.MetaBlock, error) {
+	for _, metaBlockHash := range firstHeader.MetaBlockHashes {
+		metaBlock, err := process.GetMetaHeader(metaBlockHash, sp.dataPool.MetaBlocks(), sp.marshalizer, sp.store)
+		if err != nil {
+			return nil, err
+		}
+
+		if metaBlock.IsStartOfEpochBlock() && metaBlock.Epoch == firstHeader.Epoch {
+			return metaBlock, nil
+		}
+	}
+
+	return nil, process.ErrMissingEpochStartMetaBlock
+}
+
+// getPrevHeader returns the header the given one was built on, which is the genesis block for the first block
+func (sp *shardProcessor) getPrevHeader(header *block.Header) (*block.Header, error) {
+	if header.Nonce == 1 {
+		return sp.genesisHeader, nil
+	}
+
+	return process.GetShardHeader(header.PrevHash, sp.dataPool.Headers(), sp.marshalizer, sp.store)
+}
+
+// getFirstHeaderOfEpoch walks back from the given header until the previous header belongs to an older epoch
+func (sp *shardProcessor) getFirstHeaderOfEpoch(header *block.Header) (*block.Header, error) {
+	firstHeader := header
+	for firstHeader.Nonce > 1 {
+		prevHeader, err := sp.getPrevHeader(firstHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		if prevHeader.Epoch < header.Epoch {
+			break
+		}
+
+		firstHeader = prevHeader
+	}
+
+	return firstHeader, nil
+}
+
 // check if shard headers are final by checking if newer headers were constructed upon them
 func (sp *shardProcessor) checkMetaHdrFinality(header data.HeaderHandler, round uint64) error {
 	if header == nil {
@@ -433,6 +782,39 @@ func (sp *shardProcessor) indexBlockIfNeeded(
 	go sp.core.Indexer().SaveBlock(body, header, txPool)
 }
 
+func (sp *shardProcessor) notifyCommittedBlock(headerHash []byte, header data.HeaderHandler) {
+	if sp.core == nil || sp.core.EventsNotifier() == nil {
+		return
+	}
+
+	txPool := sp.txCoordinator.GetAllCurrentUsedTxs(block.TxBlock)
+	scPool := sp.txCoordinator.GetAllCurrentUsedTxs(block.SmartContractResultBlock)
+
+	for hash, tx := range scPool {
+		txPool[hash] = tx
+	}
+
+	sp.core.EventsNotifier().NotifyCommittedBlock(headerHash, header, txPool)
+}
+
+func (sp *shardProcessor) saveReceipts(headerHash []byte, header data.HeaderHandler, body block.Body) {
+	sp.receiptsHandler.SaveReceipts(headerHash, header, getTxHashes(body, block.TxBlock))
+}
+
+// getTxHashes returns the hashes of the transactions held by the miniblocks of the given type
+func getTxHashes(body block.Body, miniBlockType block.Type) [][]byte {
+	txHashes := make([][]byte, 0)
+	for _, miniBlock := range body {
+		if miniBlock.Type != miniBlockType {
+			continue
+		}
+
+		txHashes = append(txHashes, miniBlock.TxHashes...)
+	}
+
+	return txHashes
+}
+
 // RestoreBlockIntoPools restores the TxBlock and MetaBlock into associated pools
 func (sp *shardProcessor) RestoreBlockIntoPools(headerHandler data.HeaderHandler, bodyHandler data.BodyHandler) error {
 	if headerHandler == nil {
@@ -552,6 +934,10 @@ func (sp *shardProcessor) restoreMetaBlockIntoPool(miniBlockHashes map[int][][]b
 func (sp *shardProcessor) CreateBlockBody(round uint64, haveTime func() bool) (data.BodyHandler, error) {
 	log.Debug(fmt.Sprintf("started creating block body in round %d\n", round))
 	sp.txCoordinator.CreateBlockStarted()
+	sp.txFeeHandler.CreateBlockStarted()
+	sp.stakingHandler.CreateBlockStarted()
+	sp.rewardsHandler.CreateBlockStarted()
+	sp.receiptsHandler.CreateBlockStarted()
 	sp.blockSizeThrottler.ComputeMaxItems()
 
 	miniBlocks, err := sp.createMiniBlocks(sp.shardCoordinator.NumberOfShards(), sp.blockSizeThrottler.MaxItemsToAdd(), round, haveTime)
@@ -559,9 +945,194 @@ func (sp *shardProcessor) CreateBlockBody(round uint64, haveTime func() bool) (d
 		return nil, err
 	}
 
+	err = sp.ratingsHandler.SaveRatings()
+	if err != nil {
+		return nil, err
+	}
+
+	err = sp.creditAccumulatedFees(round)
+	if err != nil {
+		return nil, err
+	}
+
+	sp.mutConsensusData.RLock()
+	prevHeader := sp.prevHeader
+	sp.mutConsensusData.RUnlock()
+
+	rewardedHeader, err := sp.getRewardedHeader(prevHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	rewardsMiniBlock, err := sp.rewardsHandler.CreateRewardsMiniBlock(
+		round,
+		sp.specialAddressHandler.LeaderAddress(),
+		rewardedHeader,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rewardsMiniBlock != nil {
+		miniBlocks = append(miniBlocks, rewardsMiniBlock)
+	}
+
+	// the fees and the rewards credited in other shards are sent together with the other smart contract results
+	postProcessMiniBlocks := sp.txCoordinator.CreatePostProcessMiniBlocks()
+	if len(postProcessMiniBlocks) > 0 {
+		miniBlocks = append(miniBlocks, postProcessMiniBlocks...)
+	}
+
 	return miniBlocks, nil
 }
 
+// processRewards creates the rewards miniblock expected for the processed block, crediting the rewards, and checks
+// that the block holds the same miniblock and the same minted value
+func (sp *shardProcessor) processRewards(body block.Body, header *block.Header) error {
+	var prevHeader data.HeaderHandler
+	if header.Nonce > 1 {
+		prevShardHeader, err := process.GetShardHeader(header.PrevHash, sp.dataPool.Headers(), sp.marshalizer, sp.store)
+		if err != nil {
+			return err
+		}
+		prevHeader = prevShardHeader
+	}
+
+	rewardedHeader, err := sp.getRewardedHeader(prevHeader)
+	if err != nil {
+		return err
+	}
+
+	rewardsMiniBlock, err := sp.rewardsHandler.CreateRewardsMiniBlock(
+		header.Round,
+		sp.specialAddressHandler.LeaderAddress(),
+		rewardedHeader,
+	)
+	if err != nil {
+		return err
+	}
+
+	expectedMiniBlocks := make(block.Body, 0)
+	if rewardsMiniBlock != nil {
+		expectedMiniBlocks = append(expectedMiniBlocks, rewardsMiniBlock)
+	}
+
+	receivedMiniBlocks := make(block.Body, 0)
+	for _, miniBlock := range body {
+		if miniBlock.Type == block.RewardsBlock {
+			receivedMiniBlocks = append(receivedMiniBlocks, miniBlock)
+		}
+	}
+
+	if len(expectedMiniBlocks) != len(receivedMiniBlocks) {
+		return process.ErrRewardMiniBlockDoesNotMatch
+	}
+
+	for i := 0; i < len(expectedMiniBlocks); i++ {
+		expectedHash, err := core.CalculateHash(sp.marshalizer, sp.hasher, expectedMiniBlocks[i])
+		if err != nil {
+			return err
+		}
+
+		receivedHash, err := core.CalculateHash(sp.marshalizer, sp.hasher, receivedMiniBlocks[i])
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(expectedHash, receivedHash) {
+			return process.ErrRewardMiniBlockDoesNotMatch
+		}
+	}
+
+	headerRewards := big.NewInt(0)
+	if header.Rewards != nil {
+		headerRewards = header.Rewards
+	}
+	if headerRewards.Cmp(sp.rewardsHandler.AccumulatedRewards()) != 0 {
+		return process.ErrRewardsDoNotMatch
+	}
+
+	return nil
+}
+
+// getRewardedHeader returns the header whose signers are rewarded by the block built on top of the given previous
+// header. The genesis block has no signers and the first block of an epoch was signed by a consensus group computed
+// from the eligible list of the previous epoch, which is no longer loaded, so in both cases it returns nil and only
+// the leader is rewarded
+func (sp *shardProcessor) getRewardedHeader(prevHeader data.HeaderHandler) (data.HeaderHandler, error) {
+	if prevHeader == nil || prevHeader.IsInterfaceNil() || prevHeader.GetNonce() == 0 {
+		return nil, nil
+	}
+	if prevHeader.GetEpoch() == 0 {
+		return prevHeader, nil
+	}
+	if prevHeader.GetNonce() == 1 {
+		return nil, nil
+	}
+
+	beforePrevHeader, err := process.GetShardHeader(prevHeader.GetPrevHash(), sp.dataPool.Headers(), sp.marshalizer, sp.store)
+	if err != nil {
+		return nil, err
+	}
+	if beforePrevHeader.Epoch < prevHeader.GetEpoch() {
+		return nil, nil
+	}
+
+	return prevHeader, nil
+}
+
+// SetConsensusData sets the data of the consensus group which will produce the block in the given round on top of
+// the given previous header, so the leader's reward address and the rewarded signers are known when the block is
+// created
+func (sp *shardProcessor) SetConsensusData(prevHeader data.HeaderHandler, round uint64) {
+	if prevHeader == nil || prevHeader.IsInterfaceNil() {
+		log.Error(process.ErrNilBlockHeader.Error())
+		return
+	}
+
+	sp.mutConsensusData.Lock()
+	sp.prevHeader = prevHeader
+	sp.mutConsensusData.Unlock()
+
+	err := sp.specialAddressHandler.SetConsensusData(prevHeader.GetRandSeed(), round)
+	if err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// creditAccumulatedFees transfers the fees paid by the transactions of the current block to the reward address
+// of the leader. This is done before the state root hash is computed, so the credit is part of the block's state
+// and it is persisted when the block is committed. A reward address from another shard gets the fees through a
+// smart contract result sent to its shard.
+func (sp *shardProcessor) creditAccumulatedFees(round uint64) error {
+	accumulatedFees := sp.txFeeHandler.AccumulatedFees()
+	if accumulatedFees.Cmp(big.NewInt(0)) == 0 {
+		return nil
+	}
+
+	leaderAddress := sp.specialAddressHandler.LeaderAddress()
+	if len(leaderAddress) == 0 {
+		log.Debug(fmt.Sprintf("leader has no reward address, fees of %s are not credited\n", accumulatedFees.String()))
+		return nil
+	}
+
+	adrLeader := state.NewAddress(leaderAddress)
+	if sp.shardCoordinator.ComputeId(adrLeader) != sp.shardCoordinator.SelfId() {
+		return sp.rewardsHandler.ForwardFees(round, leaderAddress, accumulatedFees)
+	}
+
+	acntWrp, err := sp.accounts.GetAccountWithJournal(adrLeader)
+	if err != nil {
+		return err
+	}
+
+	acntLeader, ok := acntWrp.(*state.Account)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	return acntLeader.SetBalanceWithJournal(big.NewInt(0).Add(acntLeader.Balance, accumulatedFees))
+}
+
 // CommitBlock commits the block in the blockchain if everything was checked successfully
 func (sp *shardProcessor) CommitBlock(
 	chainHandler data.ChainHandler,
@@ -601,10 +1172,21 @@ func (sp *shardProcessor) CommitBlock(
 		return err
 	}
 
+	body, ok := bodyHandler.(block.Body)
+	if !ok {
+		err = process.ErrWrongTypeAssertion
+		return err
+	}
+
 	headerHash := sp.hasher.Compute(string(buff))
 	nonceToByteSlice := sp.uint64Converter.ToByteSlice(header.Nonce)
 	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(header.ShardId)
 
+	err = sp.recordCommitJournal(header, headerHash, body)
+	if err != nil {
+		return err
+	}
+
 	errNotCritical := sp.store.Put(hdrNonceHashDataUnit, nonceToByteSlice, headerHash)
 	log.LogIfError(errNotCritical)
 
@@ -622,12 +1204,6 @@ func (sp *shardProcessor) CommitBlock(
 	syncMap.Store(headerHandler.GetShardID(), headerHash)
 	headerNoncePool.Merge(headerHandler.GetNonce(), syncMap)
 
-	body, ok := bodyHandler.(block.Body)
-	if !ok {
-		err = process.ErrWrongTypeAssertion
-		return err
-	}
-
 	err = sp.txCoordinator.SaveBlockDataToStorage(body)
 	if err != nil {
 		return err
@@ -659,10 +1235,34 @@ func (sp *shardProcessor) CommitBlock(
 		return err
 	}
 
+	prevHeader := chainHandler.GetCurrentBlockHeader()
+	if prevHeader == nil {
+		prevHeader = chainHandler.GetGenesisHeader()
+	}
+
+	err = sp.ratingsHandler.ProcessCommittedHeader(header, prevHeader)
+	if err != nil {
+		return err
+	}
+	sp.processSlashedPeers(processedMetaHdrs)
+
+	err = sp.applyEpochStart(header)
+	if err != nil {
+		return err
+	}
+
 	log.Info(fmt.Sprintf("shardBlock with nonce %d and hash %s has been committed successfully\n",
 		header.Nonce,
 		core.ToB64(headerHash)))
 
+	log.Debug(fmt.Sprintf("fees of %s have been collected in shardBlock with nonce %d\n",
+		sp.txFeeHandler.AccumulatedFees().String(),
+		header.Nonce))
+
+	log.Debug(fmt.Sprintf("rewards of %s have been minted in shardBlock with nonce %d\n",
+		sp.rewardsHandler.AccumulatedRewards().String(),
+		header.Nonce))
+
 	sp.blocksTracker.AddBlock(header)
 
 	errNotCritical = sp.txCoordinator.RemoveBlockDataFromPool(body)
@@ -700,7 +1300,10 @@ func (sp *shardProcessor) CommitBlock(
 
 	chainHandler.SetCurrentBlockHeaderHash(headerHash)
 
+	sp.saveReceipts(headerHash, headerHandler, body)
+	sp.rewardsHandler.SaveRewardTxs(getTxHashes(body, block.RewardsBlock))
 	sp.indexBlockIfNeeded(bodyHandler, headerHandler)
+	sp.notifyCommittedBlock(headerHash, headerHandler)
 
 	// write data to log
 	go sp.txCounter.displayLogInfo(
@@ -717,6 +1320,41 @@ func (sp *shardProcessor) CommitBlock(
 	return nil
 }
 
+// recordCommitJournal records the keys which the commit of the block writes in the storage units, before any of
+// them is written
+func (sp *shardProcessor) recordCommitJournal(header *block.Header, headerHash []byte, body block.Body) error {
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(header.ShardId)
+	unitsKeys := map[dataRetriever.UnitType][][]byte{
+		hdrNonceHashDataUnit:          {sp.uint64Converter.ToByteSlice(header.Nonce)},
+		dataRetriever.BlockHeaderUnit: {headerHash},
+	}
+
+	for _, miniBlock := range body {
+		buff, err := sp.marshalizer.Marshal(miniBlock)
+		if err != nil {
+			return err
+		}
+
+		unitsKeys[dataRetriever.MiniBlockUnit] = append(unitsKeys[dataRetriever.MiniBlockUnit], sp.hasher.Compute(string(buff)))
+
+		switch {
+		case miniBlock.Type == block.TxBlock:
+			unitsKeys[dataRetriever.TransactionUnit] = append(unitsKeys[dataRetriever.TransactionUnit], miniBlock.TxHashes...)
+		case miniBlock.Type == block.SmartContractResultBlock && miniBlock.ReceiverShardID == sp.shardCoordinator.SelfId():
+			unitsKeys[dataRetriever.UnsignedTransactionUnit] = append(unitsKeys[dataRetriever.UnsignedTransactionUnit], miniBlock.TxHashes...)
+		case miniBlock.Type == block.RewardsBlock:
+			unitsKeys[dataRetriever.RewardTransactionUnit] = append(unitsKeys[dataRetriever.RewardTransactionUnit], miniBlock.TxHashes...)
+		}
+	}
+
+	receiptsTxHashes := sp.receiptsHandler.GetReceiptsTxHashes(getTxHashes(body, block.TxBlock))
+	if len(receiptsTxHashes) > 0 {
+		unitsKeys[dataRetriever.ReceiptsUnit] = receiptsTxHashes
+	}
+
+	return sp.commitJournal.Record(header.Nonce, header.RootHash, unitsKeys)
+}
+
 // getHighestHdrForOwnShardFromMetachain calculates the highest shard header notarized by metachain
 func (sp *shardProcessor) getHighestHdrForOwnShardFromMetachain(round uint64) (*block.Header, []byte, error) {
 	highestNonceOwnShIdHdr := &block.Header{}
@@ -862,6 +1500,19 @@ func (sp *shardProcessor) getProcessedMetaBlocksFromPool(body block.Body, header
 	return processedMetaHdrs, nil
 }
 
+// processSlashedPeers lowers the ratings of the validators slashed in the metachain blocks notarized by the
+// committed block
+func (sp *shardProcessor) processSlashedPeers(processedMetaHdrs []data.HeaderHandler) {
+	for _, hdr := range processedMetaHdrs {
+		metaBlock, ok := hdr.(*block.MetaBlock)
+		if !ok {
+			continue
+		}
+
+		sp.ratingsHandler.ProcessSlashedPeers(metaBlock.PeerInfo)
+	}
+}
+
 func (sp *shardProcessor) removeProcessedMetablocksFromPool(processedMetaHdrs []data.HeaderHandler) error {
 	lastNotarizedMetaHdr, err := sp.getLastNotarizedHdr(sharding.MetachainShardId)
 	if err != nil {
@@ -1405,6 +2056,7 @@ func (sp *shardProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round
 		MiniBlockHeaders: make([]block.MiniBlockHeader, 0),
 		RootHash:         sp.getRootHash(),
 		ShardId:          sp.shardCoordinator.SelfId(),
+		Epoch:            sp.epochHandler.Epoch(),
 		PrevRandSeed:     make([]byte, 0),
 		RandSeed:         make([]byte, 0),
 	}
@@ -1445,6 +2097,8 @@ func (sp *shardProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round
 
 	header.MiniBlockHeaders = miniBlockHeaders
 	header.TxCount = uint32(totalTxCount)
+	header.PeerInfo = sp.stakingHandler.PeerInfo()
+	header.Rewards = sp.rewardsHandler.AccumulatedRewards()
 
 	sp.mutUsedMetaHdrsHashes.Lock()
 
@@ -1455,6 +2109,13 @@ func (sp *shardProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round
 
 	sp.mutUsedMetaHdrsHashes.Unlock()
 
+	epoch, err := sp.computeEpoch(header.MetaBlockHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	header.Epoch = epoch
+
 	sp.blockSizeThrottler.Add(
 		round,
 		core.Max(header.ItemsInBody(), header.ItemsInHeader()))
@@ -1534,3 +2195,13 @@ func (sp *shardProcessor) DecodeBlockHeader(dta []byte) data.HeaderHandler {
 
 	return &header
 }
+
+func isHashInList(hashes [][]byte, hash []byte) bool {
+	for _, h := range hashes {
+		if bytes.Equal(h, hash) {
+			return true
+		}
+	}
+
+	return false
+}
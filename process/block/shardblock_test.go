@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -11,11 +12,13 @@ import (
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/events"
 	"github.com/ElrondNetwork/elrond-go/core/indexer"
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block"
 	"github.com/ElrondNetwork/elrond-go/data/blockchain"
 	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/hashing"
@@ -101,6 +104,14 @@ func TestNewBlockProcessor_NilDataPoolShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilDataPoolHolder, err)
 	assert.Nil(t, sp)
@@ -123,6 +134,14 @@ func TestNewShardProcessor_NilStoreShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilStorage, err)
 	assert.Nil(t, sp)
@@ -145,6 +164,14 @@ func TestNewShardProcessor_NilHasherShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilHasher, err)
 	assert.Nil(t, sp)
@@ -167,6 +194,14 @@ func TestNewShardProcessor_NilMarshalizerShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilMarshalizer, err)
 	assert.Nil(t, sp)
@@ -189,6 +224,14 @@ func TestNewShardProcessor_NilAccountsAdapterShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
 	assert.Nil(t, sp)
@@ -211,6 +254,14 @@ func TestNewShardProcessor_NilShardCoordinatorShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
 	assert.Nil(t, sp)
@@ -233,6 +284,14 @@ func TestNewShardProcessor_NilForkDetectorShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilForkDetector, err)
 	assert.Nil(t, sp)
@@ -255,6 +314,14 @@ func TestNewShardProcessor_NilBlocksTrackerShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilBlocksTracker, err)
 	assert.Nil(t, sp)
@@ -277,6 +344,14 @@ func TestNewShardProcessor_NilRequestTransactionHandlerShouldErr(t *testing.T) {
 		nil,
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilRequestHandler, err)
 	assert.Nil(t, sp)
@@ -302,6 +377,14 @@ func TestNewShardProcessor_NilTransactionPoolShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilTransactionPool, err)
 	assert.Nil(t, sp)
@@ -324,6 +407,14 @@ func TestNewShardProcessor_NilTxCoordinator(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		nil,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilTransactionCoordinator, err)
 	assert.Nil(t, sp)
@@ -346,11 +437,259 @@ func TestNewShardProcessor_NilUint64Converter(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		nil,
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Equal(t, process.ErrNilUint64Converter, err)
 	assert.Nil(t, sp)
 }
 
+func TestNewShardProcessor_NilTxFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		nil,
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilTxFeeHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilSpecialAddressHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		nil,
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilSpecialAddressHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilEpochHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		nil,
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilEpochHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilStakingHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		nil,
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilStakingHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilRatingsHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		nil,
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilRatingsHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilRewardsHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		nil,
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilRewardsHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilReceiptsHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		nil,
+		&mock.CommitJournalStub{},
+	)
+	assert.Equal(t, process.ErrNilReceiptsHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilCommitJournalShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		nil,
+	)
+	assert.Equal(t, process.ErrNilCommitJournal, err)
+	assert.Nil(t, sp)
+}
+
 func TestNewShardProcessor_OkValsShouldWork(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
@@ -368,6 +707,14 @@ func TestNewShardProcessor_OkValsShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.Nil(t, err)
 	assert.NotNil(t, sp)
@@ -392,6 +739,14 @@ func TestShardProcessor_ProcessBlockWithNilBlockchainShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blk := make(block.Body, 0)
 	err := sp.ProcessBlock(nil, &block.Header{}, blk, haveTime)
@@ -415,6 +770,14 @@ func TestShardProcessor_ProcessBlockWithNilHeaderShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	body := make(block.Body, 0)
 	err := sp.ProcessBlock(&blockchain.BlockChain{}, nil, body, haveTime)
@@ -438,6 +801,14 @@ func TestShardProcessor_ProcessBlockWithNilBlockBodyShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	err := sp.ProcessBlock(&blockchain.BlockChain{}, &block.Header{}, nil, haveTime)
 	assert.Equal(t, process.ErrNilBlockBody, err)
@@ -460,6 +831,14 @@ func TestShardProcessor_ProcessBlockWithNilHaveTimeFuncShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blk := make(block.Body, 0)
 	err := sp.ProcessBlock(&blockchain.BlockChain{}, &block.Header{}, blk, nil)
@@ -498,6 +877,14 @@ func TestShardProcessor_ProcessWithDirtyAccountShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	// should return err
 	err := sp.ProcessBlock(blkc, &hdr, body, haveTime)
@@ -558,6 +945,14 @@ func TestShardProcessor_ProcessBlockHeaderBodyMismatchShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -661,6 +1056,14 @@ func TestShardProcessor_ProcessBlockWithInvalidTransactionShouldErr(t *testing.T
 		&mock.RequestHandlerMock{},
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -685,6 +1088,14 @@ func TestShardProcessor_ProcessWithHeaderNotFirstShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr := &block.Header{
 		Nonce:         0,
@@ -717,6 +1128,14 @@ func TestShardProcessor_ProcessWithHeaderNotCorrectNonceShouldErr(t *testing.T)
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr := &block.Header{
 		Nonce:         0,
@@ -756,6 +1175,14 @@ func TestShardProcessor_ProcessWithHeaderNotCorrectPrevHashShouldErr(t *testing.
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr := &block.Header{
 		Nonce:         1,
@@ -887,6 +1314,14 @@ func TestShardProcessor_ProcessBlockWithErrOnProcessBlockTransactionsCallShouldR
 		&mock.RequestHandlerMock{},
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -973,6 +1408,14 @@ func TestShardProcessor_ProcessBlockWithErrOnVerifyStateRootCallShouldRevertStat
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -1052,6 +1495,14 @@ func TestShardProcessor_ProcessBlockOnlyIntraShardShouldPass(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -1135,6 +1586,14 @@ func TestShardProcessor_ProcessBlockCrossShardWithoutMetaShouldFail(t *testing.T
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -1219,6 +1678,14 @@ func TestShardProcessor_ProcessBlockCrossShardWithMetaShouldPass(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -1360,6 +1827,14 @@ func TestShardProcessor_ProcessBlockWithMissingMetaHdrShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -1425,6 +1900,14 @@ func TestShardProcessor_ProcessBlockWithWrongMiniBlockHeaderShouldErr(t *testing
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	// should return err
@@ -1515,6 +1998,14 @@ func TestShardProcessor_CheckAndRequestIfMetaHeadersMissingShouldErr(t *testing.
 		},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	err := sp.ProcessBlock(blkc, &hdr, body, haveTime)
@@ -1640,6 +2131,14 @@ func TestShardProcessor_RequestFinalMissingHeaders(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	sp.SetCurrHighestMetaHdrNonce(1)
@@ -1737,6 +2236,14 @@ func TestShardProcessor_CheckMetaHeadersValidityAndFinalityShouldPass(t *testing
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr.Round = 4
 
@@ -1790,6 +2297,14 @@ func TestShardProcessor_CommitBlockNilBlockchainShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blk := make(block.Body, 0)
 
@@ -1841,6 +2356,14 @@ func TestShardProcessor_CommitBlockMarshalizerFailForHeaderShouldErr(t *testing.
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blkc := createTestBlockchain()
 
@@ -1909,6 +2432,14 @@ func TestShardProcessor_CommitBlockStorageFailsForHeaderShouldErr(t *testing.T)
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	blkc, _ := blockchain.NewBlockChain(
@@ -1924,19 +2455,18 @@ func TestShardProcessor_CommitBlockStorageFailsForHeaderShouldErr(t *testing.T)
 	assert.Nil(t, err)
 }
 
-func TestShardProcessor_CommitBlockStorageFailsForBodyShouldWork(t *testing.T) {
+func TestShardProcessor_CommitBlockCommitJournalFailsShouldErrBeforeStoring(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
-	wasCalled := false
-	errPersister := errors.New("failure")
+	errJournal := errors.New("journal failure")
 	rootHash := []byte("root hash to be tested")
 	accounts := &mock.AccountsStub{
+		CommitCalled: func() ([]byte, error) {
+			return nil, nil
+		},
 		RootHashCalled: func() ([]byte, error) {
 			return rootHash, nil
 		},
-		CommitCalled: func() (i []byte, e error) {
-			return nil, nil
-		},
 		RevertToSnapshotCalled: func(snapshot int) error {
 			return nil
 		},
@@ -1948,20 +2478,27 @@ func TestShardProcessor_CommitBlockStorageFailsForBodyShouldWork(t *testing.T) {
 		Signature:     []byte("signature"),
 		RootHash:      rootHash,
 	}
-	mb := block.MiniBlock{}
 	body := make(block.Body, 0)
-	body = append(body, &mb)
-
-	miniBlockUnit := &mock.StorerStub{
+	hdrUnit := &mock.StorerStub{
 		PutCalled: func(key, data []byte) error {
-			wasCalled = true
-			return errPersister
+			assert.Fail(t, "should have not stored the header")
+			return nil
 		},
 	}
 	store := initStore()
-	store.AddStorer(dataRetriever.MiniBlockUnit, miniBlockUnit)
+	store.AddStorer(dataRetriever.BlockHeaderUnit, hdrUnit)
 
-	sp, err := blproc.NewShardProcessor(
+	var recordedKeys map[dataRetriever.UnitType][][]byte
+	commitJournal := &mock.CommitJournalStub{
+		RecordCalled: func(nonce uint64, rh []byte, unitsKeys map[dataRetriever.UnitType][][]byte) error {
+			assert.Equal(t, hdr.Nonce, nonce)
+			assert.Equal(t, rootHash, rh)
+			recordedKeys = unitsKeys
+			return errJournal
+		},
+	}
+
+	sp, _ := blproc.NewShardProcessor(
 		&mock.ServiceContainerMock{},
 		tdp,
 		store,
@@ -1969,47 +2506,38 @@ func TestShardProcessor_CommitBlockStorageFailsForBodyShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		accounts,
 		mock.NewMultiShardsCoordinatorMock(3),
-		&mock.ForkDetectorMock{
-			AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, finalHeader data.HeaderHandler, finalHeaderHash []byte) error {
-				return nil
-			},
-		},
-		&mock.BlocksTrackerMock{
-			AddBlockCalled: func(headerHandler data.HeaderHandler) {
-			},
-			UnnotarisedBlocksCalled: func() []data.HeaderHandler {
-				return make([]data.HeaderHandler, 0)
-			},
-		},
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		commitJournal,
 	)
 
-	assert.Nil(t, err)
-
 	blkc, _ := blockchain.NewBlockChain(
 		generateTestCache(),
 	)
 
-	_ = blkc.SetAppStatusHandler(&mock.AppStatusHandlerStub{
-		SetUInt64ValueHandler: func(key string, value uint64) {},
-	})
-
-	err = sp.CommitBlock(blkc, hdr, body)
-
-	assert.Nil(t, err)
-	assert.True(t, wasCalled)
+	err := sp.CommitBlock(blkc, hdr, body)
+	assert.Equal(t, errJournal, err)
+	assert.Equal(t, 1, len(recordedKeys[dataRetriever.BlockHeaderUnit]))
 }
 
-func TestShardProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
+func TestShardProcessor_CommitBlockShouldRecordTheReceiptsAndTheRewardTxs(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
-	rootHash := []byte("root hash to be tested")
+	errJournal := errors.New("journal failure")
 	accounts := &mock.AccountsStub{
 		RootHashCalled: func() ([]byte, error) {
-			return rootHash, nil
+			return []byte("root hash"), nil
 		},
 		RevertToSnapshotCalled: func(snapshot int) error {
 			return nil
@@ -2020,15 +2548,18 @@ func TestShardProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
 		Round:         1,
 		PubKeysBitmap: []byte("0100101"),
 		Signature:     []byte("signature"),
-		RootHash:      rootHash,
+		RootHash:      []byte("root hash"),
+	}
+	body := block.Body{
+		&block.MiniBlock{Type: block.TxBlock, TxHashes: [][]byte{[]byte("tx1"), []byte("tx2")}},
+		&block.MiniBlock{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward1"), []byte("reward2")}},
 	}
-	body := make(block.Body, 0)
-	store := initStore()
 
+	var recordedKeys map[dataRetriever.UnitType][][]byte
 	sp, _ := blproc.NewShardProcessor(
 		&mock.ServiceContainerMock{},
 		tdp,
-		store,
+		initStore(),
 		&mock.HasherStub{},
 		&mock.MarshalizerMock{},
 		accounts,
@@ -2039,25 +2570,187 @@ func TestShardProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{
+			GetReceiptsTxHashesCalled: func(txHashes [][]byte) [][]byte {
+				return txHashes[1:]
+			},
+		},
+		&mock.CommitJournalStub{
+			RecordCalled: func(nonce uint64, rh []byte, unitsKeys map[dataRetriever.UnitType][][]byte) error {
+				recordedKeys = unitsKeys
+				return errJournal
+			},
+		},
 	)
-	tdp.HeadersNoncesCalled = func() dataRetriever.Uint64SyncMapCacher {
-		return nil
-	}
-	blkc := createTestBlockchain()
-	err := sp.CommitBlock(blkc, hdr, body)
 
-	assert.Equal(t, process.ErrNilDataPoolHolder, err)
+	blkc, _ := blockchain.NewBlockChain(
+		generateTestCache(),
+	)
+
+	err := sp.CommitBlock(blkc, hdr, body)
+	assert.Equal(t, errJournal, err)
+	assert.Equal(t, [][]byte{[]byte("tx1"), []byte("tx2")}, recordedKeys[dataRetriever.TransactionUnit])
+	assert.Equal(t, [][]byte{[]byte("tx2")}, recordedKeys[dataRetriever.ReceiptsUnit])
+	assert.Equal(t, [][]byte{[]byte("reward1"), []byte("reward2")}, recordedKeys[dataRetriever.RewardTransactionUnit])
+	assert.Equal(t, 2, len(recordedKeys[dataRetriever.MiniBlockUnit]))
 }
 
-func TestShardProcessor_CommitBlockNoTxInPoolShouldErr(t *testing.T) {
+func TestShardProcessor_CommitBlockStorageFailsForBodyShouldWork(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
-
-	txCache := &mock.CacherStub{
-		PeekCalled: func(key []byte) (value interface{}, ok bool) {
-			return nil, false
+	wasCalled := false
+	errPersister := errors.New("failure")
+	rootHash := []byte("root hash to be tested")
+	accounts := &mock.AccountsStub{
+		RootHashCalled: func() ([]byte, error) {
+			return rootHash, nil
 		},
-		LenCalled: func() int {
+		CommitCalled: func() (i []byte, e error) {
+			return nil, nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	hdr := &block.Header{
+		Nonce:         1,
+		Round:         1,
+		PubKeysBitmap: []byte("0100101"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	mb := block.MiniBlock{}
+	body := make(block.Body, 0)
+	body = append(body, &mb)
+
+	miniBlockUnit := &mock.StorerStub{
+		PutCalled: func(key, data []byte) error {
+			wasCalled = true
+			return errPersister
+		},
+	}
+	store := initStore()
+	store.AddStorer(dataRetriever.MiniBlockUnit, miniBlockUnit)
+
+	sp, err := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		store,
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		accounts,
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{
+			AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, finalHeader data.HeaderHandler, finalHeaderHash []byte) error {
+				return nil
+			},
+		},
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+			UnnotarisedBlocksCalled: func() []data.HeaderHandler {
+				return make([]data.HeaderHandler, 0)
+			},
+		},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	assert.Nil(t, err)
+
+	blkc, _ := blockchain.NewBlockChain(
+		generateTestCache(),
+	)
+
+	_ = blkc.SetAppStatusHandler(&mock.AppStatusHandlerStub{
+		SetUInt64ValueHandler: func(key string, value uint64) {},
+	})
+
+	err = sp.CommitBlock(blkc, hdr, body)
+
+	assert.Nil(t, err)
+	assert.True(t, wasCalled)
+}
+
+func TestShardProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	rootHash := []byte("root hash to be tested")
+	accounts := &mock.AccountsStub{
+		RootHashCalled: func() ([]byte, error) {
+			return rootHash, nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	hdr := &block.Header{
+		Nonce:         1,
+		Round:         1,
+		PubKeysBitmap: []byte("0100101"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	body := make(block.Body, 0)
+	store := initStore()
+
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		store,
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		accounts,
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	tdp.HeadersNoncesCalled = func() dataRetriever.Uint64SyncMapCacher {
+		return nil
+	}
+	blkc := createTestBlockchain()
+	err := sp.CommitBlock(blkc, hdr, body)
+
+	assert.Equal(t, process.ErrNilDataPoolHolder, err)
+}
+
+func TestShardProcessor_CommitBlockNoTxInPoolShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+
+	txCache := &mock.CacherStub{
+		PeekCalled: func(key []byte) (value interface{}, ok bool) {
+			return nil, false
+		},
+		LenCalled: func() int {
 			return 0
 		},
 	}
@@ -2154,6 +2847,14 @@ func TestShardProcessor_CommitBlockNoTxInPoolShouldErr(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	blkc := createTestBlockchain()
@@ -2245,6 +2946,14 @@ func TestShardProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	blkc := createTestBlockchain()
@@ -2370,6 +3079,14 @@ func TestShardProcessor_CommitBlockCallsIndexerMethods(t *testing.T) {
 			},
 		},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	blkc := createTestBlockchain()
@@ -2394,52 +3111,322 @@ func TestShardProcessor_CommitBlockCallsIndexerMethods(t *testing.T) {
 	assert.Equal(t, 4, len(wasCalled))
 }
 
-func TestShardProcessor_CreateTxBlockBodyWithDirtyAccStateShouldErr(t *testing.T) {
+func TestShardProcessor_CommitBlockNotifiesCommittedBlock(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
-	journalLen := func() int { return 3 }
-	revToSnapshot := func(snapshot int) error { return nil }
+	txHash := []byte("tx_hash1")
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+
+	prevHdr := &block.Header{
+		Nonce:         0,
+		Round:         0,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+	}
+
+	hdr := &block.Header{
+		Nonce:         1,
+		Round:         1,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+	}
+	mb := block.MiniBlock{
+		TxHashes: [][]byte{txHash},
+	}
+	body := block.Body{&mb}
+
+	mbHdr := block.MiniBlockHeader{
+		TxCount: uint32(len(mb.TxHashes)),
+		Hash:    hdrHash,
+	}
+	mbHdrs := make([]block.MiniBlockHeader, 0)
+	mbHdrs = append(mbHdrs, mbHdr)
+	hdr.MiniBlockHeaders = mbHdrs
+
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() ([]byte, error) {
+			return rootHash, nil
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, finalHeader data.HeaderHandler, finalHeaderHash []byte) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	store := initStore()
+
+	var notifiedHeaderHash []byte
+	var notifiedTxs map[string]data.TransactionHandler
 	sp, _ := blproc.NewShardProcessor(
-		&mock.ServiceContainerMock{},
+		&mock.ServiceContainerMock{
+			EventsNotifierCalled: func() events.Notifier {
+				return &mock.EventsNotifierStub{
+					NotifyCommittedBlockCalled: func(headerHash []byte, header data.HeaderHandler, txs map[string]data.TransactionHandler) {
+						notifiedHeaderHash = headerHash
+						notifiedTxs = txs
+					},
+				}
+			},
+		},
 		tdp,
-		&mock.ChainStorerMock{},
-		&mock.HasherStub{},
+		store,
+		hasher,
 		&mock.MarshalizerMock{},
-		&mock.AccountsStub{
-			JournalLenCalled:       journalLen,
-			RevertToSnapshotCalled: revToSnapshot,
-		},
+		accounts,
 		mock.NewMultiShardsCoordinatorMock(3),
-		&mock.ForkDetectorMock{},
-		&mock.BlocksTrackerMock{},
+		fd,
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+			UnnotarisedBlocksCalled: func() []data.HeaderHandler {
+				return make([]data.HeaderHandler, 0)
+			},
+		},
 		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
 		&mock.RequestHandlerMock{},
-		&mock.TransactionCoordinatorMock{},
+		&mock.TransactionCoordinatorMock{
+			GetAllCurrentUsedTxsCalled: func(blockType block.Type) map[string]data.TransactionHandler {
+				switch blockType {
+				case block.TxBlock:
+					return map[string]data.TransactionHandler{
+						"tx_1": &transaction.Transaction{Nonce: 1},
+						"tx_2": &transaction.Transaction{Nonce: 2},
+					}
+				case block.SmartContractResultBlock:
+					return map[string]data.TransactionHandler{
+						"utx_1": &smartContractResult.SmartContractResult{Nonce: 1},
+						"utx_2": &smartContractResult.SmartContractResult{Nonce: 2},
+					}
+				default:
+					return nil
+				}
+			},
+		},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
-	bl, err := sp.CreateBlockBody(0, func() bool { return true })
-	// nil block
-	assert.Nil(t, bl)
-	// error
-	assert.Equal(t, process.ErrAccountStateDirty, err)
+
+	blkc := createTestBlockchain()
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return prevHdr
+	}
+	blkc.GetCurrentBlockHeaderHashCalled = func() []byte {
+		return hdrHash
+	}
+	err := sp.ProcessBlock(blkc, hdr, body, haveTime)
+	assert.Nil(t, err)
+	err = sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+	assert.Equal(t, hdrHash, notifiedHeaderHash)
+	assert.Equal(t, 4, len(notifiedTxs))
 }
 
-func TestShardProcessor_CreateTxBlockBodyWithNoTimeShouldEmptyBlock(t *testing.T) {
+func TestShardProcessor_CommitBlockSavesReceipts(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool([]byte("tx_hash1"))
-	journalLen := func() int { return 0 }
-	rootHashfunc := func() ([]byte, error) {
-		return []byte("roothash"), nil
-	}
-	revToSnapshot := func(snapshot int) error { return nil }
-	sp, _ := blproc.NewShardProcessor(
-		&mock.ServiceContainerMock{},
-		tdp,
-		&mock.ChainStorerMock{},
-		&mock.HasherStub{},
-		&mock.MarshalizerMock{},
-		&mock.AccountsStub{
-			JournalLenCalled:       journalLen,
+	txHash := []byte("tx_hash1")
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+
+	prevHdr := &block.Header{
+		Nonce:         0,
+		Round:         0,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+	}
+
+	hdr := &block.Header{
+		Nonce:         1,
+		Round:         1,
+		PubKeysBitmap: rootHash,
+		PrevHash:      hdrHash,
+		Signature:     rootHash,
+		RootHash:      rootHash,
+	}
+	mb := block.MiniBlock{
+		TxHashes: [][]byte{txHash},
+	}
+	body := block.Body{&mb}
+
+	mbHdr := block.MiniBlockHeader{
+		TxCount: uint32(len(mb.TxHashes)),
+		Hash:    hdrHash,
+	}
+	mbHdrs := make([]block.MiniBlockHeader, 0)
+	mbHdrs = append(mbHdrs, mbHdr)
+	hdr.MiniBlockHeaders = mbHdrs
+
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() ([]byte, error) {
+			return rootHash, nil
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, finalHeader data.HeaderHandler, finalHeaderHash []byte) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	store := initStore()
+
+	var savedHeaderHash []byte
+	var savedTxHashes [][]byte
+	var savedRewardTxHashes [][]byte
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		store,
+		hasher,
+		&mock.MarshalizerMock{},
+		accounts,
+		mock.NewMultiShardsCoordinatorMock(3),
+		fd,
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+			UnnotarisedBlocksCalled: func() []data.HeaderHandler {
+				return make([]data.HeaderHandler, 0)
+			},
+		},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{
+			GetAllCurrentUsedTxsCalled: func(blockType block.Type) map[string]data.TransactionHandler {
+				switch blockType {
+				case block.TxBlock:
+					return map[string]data.TransactionHandler{
+						"tx_1": &transaction.Transaction{Nonce: 1},
+						"tx_2": &transaction.Transaction{Nonce: 2},
+					}
+				case block.SmartContractResultBlock:
+					return map[string]data.TransactionHandler{
+						"utx_1": &smartContractResult.SmartContractResult{Nonce: 1},
+						"utx_2": &smartContractResult.SmartContractResult{Nonce: 2},
+					}
+				default:
+					return nil
+				}
+			},
+		},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{
+			SaveRewardTxsCalled: func(txHashes [][]byte) {
+				savedRewardTxHashes = txHashes
+			},
+		},
+		&mock.ReceiptsHandlerStub{
+			SaveReceiptsCalled: func(headerHash []byte, header data.HeaderHandler, txHashes [][]byte) {
+				savedHeaderHash = headerHash
+				savedTxHashes = txHashes
+			},
+		},
+		&mock.CommitJournalStub{},
+	)
+
+	blkc := createTestBlockchain()
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return prevHdr
+	}
+	blkc.GetCurrentBlockHeaderHashCalled = func() []byte {
+		return hdrHash
+	}
+	err := sp.ProcessBlock(blkc, hdr, body, haveTime)
+	assert.Nil(t, err)
+	err = sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+	assert.Equal(t, hdrHash, savedHeaderHash)
+	assert.Equal(t, [][]byte{txHash}, savedTxHashes)
+	assert.Equal(t, make([][]byte, 0), savedRewardTxHashes)
+}
+
+func TestShardProcessor_CreateTxBlockBodyWithDirtyAccStateShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	journalLen := func() int { return 3 }
+	revToSnapshot := func(snapshot int) error { return nil }
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{
+			JournalLenCalled:       journalLen,
+			RevertToSnapshotCalled: revToSnapshot,
+		},
+		mock.NewMultiShardsCoordinatorMock(3),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(mock.NewMultiShardsCoordinatorMock(3)),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+	bl, err := sp.CreateBlockBody(0, func() bool { return true })
+	// nil block
+	assert.Nil(t, bl)
+	// error
+	assert.Equal(t, process.ErrAccountStateDirty, err)
+}
+
+func TestShardProcessor_CreateTxBlockBodyWithNoTimeShouldEmptyBlock(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool([]byte("tx_hash1"))
+	journalLen := func() int { return 0 }
+	rootHashfunc := func() ([]byte, error) {
+		return []byte("roothash"), nil
+	}
+	revToSnapshot := func(snapshot int) error { return nil }
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{
+			JournalLenCalled:       journalLen,
 			RootHashCalled:         rootHashfunc,
 			RevertToSnapshotCalled: revToSnapshot,
 		},
@@ -2450,6 +3437,14 @@ func TestShardProcessor_CreateTxBlockBodyWithNoTimeShouldEmptyBlock(t *testing.T
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	haveTime := func() bool {
 		return false
@@ -2488,6 +3483,14 @@ func TestShardProcessor_CreateTxBlockBodyOK(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	blk, err := sp.CreateBlockBody(0, haveTime)
 	assert.NotNil(t, blk)
@@ -2515,6 +3518,14 @@ func TestNode_ComputeNewNoncePrevHashShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr, txBlock := createTestHdrTxBlockBody()
 	marshalizer.MarshalCalled = func(obj interface{}) (bytes []byte, e error) {
@@ -2607,6 +3618,14 @@ func TestShardProcessor_DisplayLogInfo(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	assert.NotNil(t, sp)
 	hdr.PrevHash = hasher.Compute("prev hash")
@@ -2629,6 +3648,14 @@ func TestBlockProcessor_CreateBlockHeaderShouldNotReturnNil(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	mbHeaders, err := bp.CreateBlockHeader(nil, 0, func() bool {
 		return true
@@ -2654,6 +3681,14 @@ func TestShardProcessor_CreateBlockHeaderShouldErrWhenMarshalizerErrors(t *testi
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	body := block.Body{
 		{
@@ -2695,6 +3730,14 @@ func TestShardProcessor_CreateBlockHeaderReturnsOK(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	body := block.Body{
 		{
@@ -2744,6 +3787,14 @@ func TestShardProcessor_CommitBlockShouldRevertAccountStateWhenErr(t *testing.T)
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	err := bp.CommitBlock(nil, nil, nil)
 	assert.NotNil(t, err)
@@ -2812,6 +3863,14 @@ func TestShardProcessor_MarshalizedDataToBroadcastShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	msh, mstx, err := sp.MarshalizedDataToBroadcast(&block.Header{}, body)
 	assert.Nil(t, err)
@@ -2848,6 +3907,14 @@ func TestShardProcessor_MarshalizedDataWrongType(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	wr := wrongBody{}
 	msh, mstx, err := sp.MarshalizedDataToBroadcast(&block.Header{}, wr)
@@ -2876,6 +3943,14 @@ func TestShardProcessor_MarshalizedDataNilInput(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	msh, mstx, err := sp.MarshalizedDataToBroadcast(nil, nil)
 	assert.Equal(t, process.ErrNilMiniBlocks, err)
@@ -2940,6 +4015,14 @@ func TestShardProcessor_MarshalizedDataMarshalWithoutSuccess(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	msh, mstx, err := sp.MarshalizedDataToBroadcast(&block.Header{}, body)
@@ -3021,6 +4104,14 @@ func TestShardProcessor_ReceivedMetaBlockShouldRequestMissingMiniBlocks(t *testi
 		requestHandler,
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	bp.ReceivedMetaBlock(metaBlockHash)
 
@@ -3089,6 +4180,14 @@ func TestShardProcessor_ReceivedMetaBlockNoMissingMiniBlocksShouldPass(t *testin
 		requestHandler,
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	sp.ReceivedMetaBlock(metaBlockHash)
 	assert.Equal(t, int32(0), atomic.LoadInt32(&noOfMissingMiniBlocks))
@@ -3166,6 +4265,14 @@ func TestShardProcessor_CreateAndProcessCrossMiniBlocksDstMe(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	miniBlockSlice, usedMetaHdrsHashes, noOfTxs, err := sp.CreateAndProcessCrossMiniBlocksDstMe(3, 2, 2, haveTimeTrue)
 	assert.Equal(t, err == nil, true)
@@ -3199,6 +4306,14 @@ func TestShardProcessor_NewShardProcessorWrongTypeOfStartHeaderShouldErrWrongTyp
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, sp)
@@ -3294,6 +4409,14 @@ func TestShardProcessor_CreateAndProcessCrossMiniBlocksDstMeProcessPartOfMiniBlo
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	miniBlocksReturned, usedMetaHdrsHashes, nrTxAdded, err := sp.CreateAndProcessCrossMiniBlocksDstMe(3, 2, 2, haveTimeTrue)
@@ -3410,6 +4533,14 @@ func TestShardProcessor_CreateMiniBlocksShouldWorkWithIntraShardTxs(t *testing.T
 		&mock.RequestHandlerMock{},
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	blockBody, err := bp.CreateMiniBlocks(1, 15000, 0, func() bool { return true })
@@ -3495,6 +4626,14 @@ func TestShardProcessor_GetProcessedMetaBlockFromPoolShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	//create block body with first 3 miniblocks from miniblocks var
@@ -3537,6 +4676,14 @@ func TestBlockProcessor_RestoreBlockIntoPoolsShouldErrNilBlockHeader(t *testing.
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	err := be.RestoreBlockIntoPools(nil, nil)
 	assert.NotNil(t, err)
@@ -3560,6 +4707,14 @@ func TestBlockProcessor_RestoreBlockIntoPoolsShouldErrNilTxBlockBody(t *testing.
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	err := sp.RestoreBlockIntoPools(&block.Header{}, nil)
@@ -3633,6 +4788,14 @@ func TestShardProcessor_RestoreBlockIntoPoolsShouldWork(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		tc,
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	txHashes := make([][]byte, 0)
@@ -3687,6 +4850,14 @@ func TestShardProcessor_DecodeBlockBody(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	body := make(block.Body, 0)
 	body = append(body, &block.MiniBlock{ReceiverShardID: 69})
@@ -3719,6 +4890,14 @@ func TestShardProcessor_DecodeBlockHeader(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 	hdr := &block.Header{}
 	hdr.Nonce = 1
@@ -3760,6 +4939,14 @@ func TestShardProcessor_IsHdrConstructionValid(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -3881,6 +5068,14 @@ func TestShardProcessor_RemoveAndSaveLastNotarizedMetaHdrNoDstMB(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -4044,6 +5239,14 @@ func TestShardProcessor_RemoveAndSaveLastNotarizedMetaHdrNotAllMBFinished(t *tes
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -4172,6 +5375,14 @@ func TestShardProcessor_RemoveAndSaveLastNotarizedMetaHdrAllMBFinished(t *testin
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	prevRandSeed := []byte("prevrand")
@@ -4322,6 +5533,14 @@ func TestShardProcessor_CheckHeaderBodyCorrelationReceiverMissmatch(t *testing.T
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	hdr.MiniBlockHeaders[0].ReceiverShardID = body[0].ReceiverShardID + 1
@@ -4347,6 +5566,14 @@ func TestShardProcessor_CheckHeaderBodyCorrelationSenderMissmatch(t *testing.T)
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	hdr.MiniBlockHeaders[0].SenderShardID = body[0].SenderShardID + 1
@@ -4372,6 +5599,14 @@ func TestShardProcessor_CheckHeaderBodyCorrelationTxCountMissmatch(t *testing.T)
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	hdr.MiniBlockHeaders[0].TxCount = uint32(len(body[0].TxHashes) + 1)
@@ -4397,6 +5632,14 @@ func TestShardProcessor_CheckHeaderBodyCorrelationHashMissmatch(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	hdr.MiniBlockHeaders[0].Hash = []byte("wrongHash")
@@ -4422,6 +5665,14 @@ func TestShardProcessor_CheckHeaderBodyCorrelationShouldPass(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	err := sp.CheckHeaderBodyCorrelation(hdr, body)
@@ -4465,6 +5716,14 @@ func TestShardProcessor_restoreMetaBlockIntoPoolShouldPass(t *testing.T) {
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	miniblockHashes := make(map[int][][]byte, 0)
@@ -4572,6 +5831,14 @@ func TestShardPreprocessor_getAllMiniBlockDstMeFromMetaShouldPass(t *testing.T)
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	meta := block.MetaBlock{
@@ -4613,6 +5880,14 @@ func TestShardProcessor_GetHighestHdrForOwnShardFromMetachainNothingToProcess(t
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	hdr, _, err := sp.GetHighestHdrForOwnShardFromMetachain(0)
@@ -4645,6 +5920,14 @@ func TestShardProcessor_GetHighestHdrForOwnShardFromMetachaiMetaHdrsWithoutOwnHd
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	shardInfo := make([]block.ShardData, 0)
@@ -4709,6 +5992,14 @@ func TestShardProcessor_GetHighestHdrForOwnShardFromMetachaiMetaHdrsWithOwnHdrBu
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	shardInfo := make([]block.ShardData, 0)
@@ -4772,6 +6063,14 @@ func TestShardProcessor_GetHighestHdrForOwnShardFromMetachaiMetaHdrsWithOwnHdrSt
 		&mock.RequestHandlerMock{},
 		&mock.TransactionCoordinatorMock{},
 		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
 	)
 
 	ownHdr := &block.Header{
@@ -4842,3 +6141,858 @@ func TestShardProcessor_GetHighestHdrForOwnShardFromMetachaiMetaHdrsWithOwnHdrSt
 	assert.NotNil(t, hdr)
 	assert.Equal(t, ownHdr.GetNonce(), hdr.GetNonce())
 }
+
+//------- creditAccumulatedFees
+
+func createShardProcessorForFees(
+	accounts *mock.AccountsStub,
+	fees *big.Int,
+	leaderAddress []byte,
+	rewardsHandler process.RewardsHandler,
+) *blproc.ShardProcessor {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		return uint32(address.Bytes()[0])
+	}
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		initDataPool([]byte("tx_hash1")),
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		accounts,
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(shardCoordinator),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{
+			AccumulatedFeesCalled: func() *big.Int {
+				return fees
+			},
+		},
+		&mock.SpecialAddressHandlerMock{
+			LeaderAddressCalled: func() []byte {
+				return leaderAddress
+			},
+		},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		rewardsHandler,
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	return sp
+}
+
+func TestShardProcessor_CreditAccumulatedFeesNoFeesShouldNotGetAccount(t *testing.T) {
+	t.Parallel()
+
+	getAccountCalled := false
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			getAccountCalled = true
+			return nil, nil
+		},
+	}
+	sp := createShardProcessorForFees(accounts, big.NewInt(0), []byte("leader address"), &mock.RewardsHandlerStub{})
+
+	err := sp.CreditAccumulatedFees(1)
+
+	assert.Nil(t, err)
+	assert.False(t, getAccountCalled)
+}
+
+func TestShardProcessor_CreditAccumulatedFeesNoLeaderAddressShouldNotGetAccount(t *testing.T) {
+	t.Parallel()
+
+	getAccountCalled := false
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			getAccountCalled = true
+			return nil, nil
+		},
+	}
+	sp := createShardProcessorForFees(accounts, big.NewInt(10), nil, &mock.RewardsHandlerStub{})
+
+	err := sp.CreditAccumulatedFees(1)
+
+	assert.Nil(t, err)
+	assert.False(t, getAccountCalled)
+}
+
+func TestShardProcessor_CreditAccumulatedFeesGetAccountErrorsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errExpected
+		},
+	}
+	sp := createShardProcessorForFees(accounts, big.NewInt(10), []byte{0}, &mock.RewardsHandlerStub{})
+
+	err := sp.CreditAccumulatedFees(1)
+
+	assert.Equal(t, errExpected, err)
+}
+
+func TestShardProcessor_CreditAccumulatedFeesShouldAddFeesToLeader(t *testing.T) {
+	t.Parallel()
+
+	leaderAddress := []byte{0}
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+	acntLeader, _ := state.NewAccount(mock.NewAddressMock(leaderAddress), tracker)
+	acntLeader.Balance = big.NewInt(5)
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return acntLeader, nil
+		},
+	}
+	sp := createShardProcessorForFees(accounts, big.NewInt(10), leaderAddress, &mock.RewardsHandlerStub{})
+
+	err := sp.CreditAccumulatedFees(1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(15), acntLeader.Balance)
+}
+
+func TestShardProcessor_CreditAccumulatedFeesLeaderInAnotherShardShouldForwardFees(t *testing.T) {
+	t.Parallel()
+
+	leaderAddress := []byte{1}
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			assert.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}
+	var forwardedFees *big.Int
+	rewardsHandler := &mock.RewardsHandlerStub{
+		ForwardFeesCalled: func(round uint64, address []byte, fees *big.Int) error {
+			assert.Equal(t, uint64(4), round)
+			assert.Equal(t, leaderAddress, address)
+			forwardedFees = fees
+			return nil
+		},
+	}
+	sp := createShardProcessorForFees(accounts, big.NewInt(10), leaderAddress, rewardsHandler)
+
+	err := sp.CreditAccumulatedFees(4)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), forwardedFees)
+}
+
+//------- epoch
+
+func createShardProcessorForEpoch(
+	metaBlocks map[string]*block.MetaBlock,
+	epochHandler process.EpochHandler,
+) *blproc.ShardProcessor {
+	tdp := initDataPool([]byte("tx_hash1"))
+	tdp.MetaBlocksCalled = func() storage.Cacher {
+		return &mock.CacherStub{
+			PeekCalled: func(key []byte) (value interface{}, ok bool) {
+				metaBlock, ok := metaBlocks[string(key)]
+				return metaBlock, ok
+			},
+			RegisterHandlerCalled: func(i func(key []byte)) {},
+		}
+	}
+
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(shardCoordinator),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		epochHandler,
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	return sp
+}
+
+func TestShardProcessor_CheckEpochCorrectnessWithoutEpochStartShouldKeepEpoch(t *testing.T) {
+	t.Parallel()
+
+	metaBlocks := map[string]*block.MetaBlock{
+		"meta1": {Nonce: 1, Epoch: 3},
+	}
+	epochHandler := &mock.EpochHandlerStub{
+		EpochCalled: func() uint32 {
+			return 3
+		},
+	}
+	sp := createShardProcessorForEpoch(metaBlocks, epochHandler)
+
+	err := sp.CheckEpochCorrectness(&block.Header{Epoch: 3, MetaBlockHashes: [][]byte{[]byte("meta1")}})
+	assert.Nil(t, err)
+
+	err = sp.CheckEpochCorrectness(&block.Header{Epoch: 4, MetaBlockHashes: [][]byte{[]byte("meta1")}})
+	assert.Equal(t, process.ErrEpochDoesNotMatch, err)
+}
+
+func TestShardProcessor_CheckEpochCorrectnessWithEpochStartShouldChangeEpoch(t *testing.T) {
+	t.Parallel()
+
+	metaBlocks := map[string]*block.MetaBlock{
+		"meta1": {Nonce: 1, Epoch: 3},
+		"meta2": {Nonce: 2, Epoch: 4, EpochStart: []block.EpochStartShardData{{ShardId: 0}}},
+	}
+	epochHandler := &mock.EpochHandlerStub{
+		EpochCalled: func() uint32 {
+			return 3
+		},
+	}
+	sp := createShardProcessorForEpoch(metaBlocks, epochHandler)
+	metaBlockHashes := [][]byte{[]byte("meta1"), []byte("meta2")}
+
+	err := sp.CheckEpochCorrectness(&block.Header{Epoch: 3, MetaBlockHashes: metaBlockHashes})
+	assert.Equal(t, process.ErrEpochDoesNotMatch, err)
+
+	err = sp.CheckEpochCorrectness(&block.Header{Epoch: 4, MetaBlockHashes: metaBlockHashes})
+	assert.Nil(t, err)
+}
+
+func TestShardProcessor_CheckEpochCorrectnessMissingMetaBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp := createShardProcessorForEpoch(make(map[string]*block.MetaBlock), &mock.EpochHandlerStub{})
+
+	err := sp.CheckEpochCorrectness(&block.Header{MetaBlockHashes: [][]byte{[]byte("meta1")}})
+
+	assert.Equal(t, process.ErrMissingHeader, err)
+}
+
+func TestShardProcessor_ApplyEpochStartSameEpochShouldNotSetEpochStart(t *testing.T) {
+	t.Parallel()
+
+	setEpochStartCalled := false
+	epochHandler := &mock.EpochHandlerStub{
+		EpochCalled: func() uint32 {
+			return 3
+		},
+		SetEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData) error {
+			setEpochStartCalled = true
+			return nil
+		},
+	}
+	sp := createShardProcessorForEpoch(make(map[string]*block.MetaBlock), epochHandler)
+
+	err := sp.ApplyEpochStart(&block.Header{Epoch: 3})
+
+	assert.Nil(t, err)
+	assert.False(t, setEpochStartCalled)
+}
+
+func TestShardProcessor_ApplyEpochStartMissingEpochStartMetaBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	metaBlocks := map[string]*block.MetaBlock{
+		"meta1": {Nonce: 1, Epoch: 4},
+	}
+	sp := createShardProcessorForEpoch(metaBlocks, &mock.EpochHandlerStub{})
+
+	err := sp.ApplyEpochStart(&block.Header{Epoch: 4, MetaBlockHashes: [][]byte{[]byte("meta1")}})
+
+	assert.Equal(t, process.ErrMissingEpochStartMetaBlock, err)
+}
+
+func TestShardProcessor_ApplyEpochStartShouldSetEpochStart(t *testing.T) {
+	t.Parallel()
+
+	epochStart := []block.EpochStartShardData{{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}}}
+	metaBlocks := map[string]*block.MetaBlock{
+		"meta1": {Nonce: 1, Epoch: 4, EpochStart: epochStart},
+	}
+	var setEpoch uint32
+	var setEpochStartData []block.EpochStartShardData
+	epochHandler := &mock.EpochHandlerStub{
+		SetEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData) error {
+			setEpoch = epoch
+			setEpochStartData = epochStart
+			return nil
+		},
+	}
+	sp := createShardProcessorForEpoch(metaBlocks, epochHandler)
+
+	err := sp.ApplyEpochStart(&block.Header{Epoch: 4, MetaBlockHashes: [][]byte{[]byte("meta1")}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(4), setEpoch)
+	assert.Equal(t, epochStart, setEpochStartData)
+}
+
+//------- rewards
+
+func createShardProcessorForRewards(rewardsHandler process.RewardsHandler) *blproc.ShardProcessor {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		initDataPool([]byte("tx_hash1")),
+		&mock.ChainStorerMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(shardCoordinator),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		rewardsHandler,
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	return sp
+}
+
+func createRewardsHandlerForMiniBlock(miniBlock *block.MiniBlock, rewards *big.Int) *mock.RewardsHandlerStub {
+	return &mock.RewardsHandlerStub{
+		CreateRewardsMiniBlockCalled: func(round uint64, leaderAddress []byte, signedHeader data.HeaderHandler) (*block.MiniBlock, error) {
+			return miniBlock, nil
+		},
+		AccumulatedRewardsCalled: func() *big.Int {
+			return rewards
+		},
+	}
+}
+
+func TestShardProcessor_ProcessRewardsWithoutRewardsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sp := createShardProcessorForRewards(createRewardsHandlerForMiniBlock(nil, big.NewInt(0)))
+	body := block.Body{{Type: block.TxBlock, TxHashes: [][]byte{[]byte("tx")}}}
+
+	err := sp.ProcessRewards(body, &block.Header{Round: 1})
+
+	assert.Nil(t, err)
+}
+
+func TestShardProcessor_ProcessRewardsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	rewardsMiniBlock := &block.MiniBlock{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward")}}
+	sp := createShardProcessorForRewards(createRewardsHandlerForMiniBlock(rewardsMiniBlock, big.NewInt(100)))
+	body := block.Body{
+		{Type: block.TxBlock, TxHashes: [][]byte{[]byte("tx")}},
+		{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward")}},
+	}
+
+	err := sp.ProcessRewards(body, &block.Header{Round: 1, Rewards: big.NewInt(100)})
+
+	assert.Nil(t, err)
+}
+
+func TestShardProcessor_ProcessRewardsMissingMiniBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rewardsMiniBlock := &block.MiniBlock{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward")}}
+	sp := createShardProcessorForRewards(createRewardsHandlerForMiniBlock(rewardsMiniBlock, big.NewInt(100)))
+	body := block.Body{{Type: block.TxBlock, TxHashes: [][]byte{[]byte("tx")}}}
+
+	err := sp.ProcessRewards(body, &block.Header{Round: 1, Rewards: big.NewInt(100)})
+
+	assert.Equal(t, process.ErrRewardMiniBlockDoesNotMatch, err)
+}
+
+func TestShardProcessor_ProcessRewardsDifferentMiniBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rewardsMiniBlock := &block.MiniBlock{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward")}}
+	sp := createShardProcessorForRewards(createRewardsHandlerForMiniBlock(rewardsMiniBlock, big.NewInt(100)))
+	body := block.Body{{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("other reward")}}}
+
+	err := sp.ProcessRewards(body, &block.Header{Round: 1, Rewards: big.NewInt(100)})
+
+	assert.Equal(t, process.ErrRewardMiniBlockDoesNotMatch, err)
+}
+
+func TestShardProcessor_ProcessRewardsDifferentHeaderRewardsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rewardsMiniBlock := &block.MiniBlock{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward")}}
+	sp := createShardProcessorForRewards(createRewardsHandlerForMiniBlock(rewardsMiniBlock, big.NewInt(100)))
+	body := block.Body{{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward")}}}
+
+	err := sp.ProcessRewards(body, &block.Header{Round: 1})
+
+	assert.Equal(t, process.ErrRewardsDoNotMatch, err)
+}
+
+func TestShardProcessor_CreateBlockBodyShouldAppendRewardsMiniBlock(t *testing.T) {
+	t.Parallel()
+
+	rewardsMiniBlock := &block.MiniBlock{Type: block.RewardsBlock, TxHashes: [][]byte{[]byte("reward")}}
+	sp := createShardProcessorForRewards(createRewardsHandlerForMiniBlock(rewardsMiniBlock, big.NewInt(100)))
+
+	bodyHandler, err := sp.CreateBlockBody(1, func() bool { return true })
+	assert.Nil(t, err)
+
+	body := bodyHandler.(block.Body)
+	assert.Equal(t, rewardsMiniBlock, body[len(body)-1])
+
+	header, err := sp.CreateBlockHeader(body, 1, func() bool { return true })
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), header.(*block.Header).Rewards)
+}
+
+func createShardProcessorForRewardsWithStore(
+	store dataRetriever.StorageService,
+	rewardsHandler process.RewardsHandler,
+) *blproc.ShardProcessor {
+	tdp := initDataPool([]byte("tx_hash1"))
+	tdp.HeadersCalled = func() storage.Cacher {
+		return &mock.CacherStub{
+			PeekCalled: func(key []byte) (value interface{}, ok bool) {
+				return nil, false
+			},
+			RegisterHandlerCalled: func(i func(key []byte)) {},
+		}
+	}
+
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		store,
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(shardCoordinator),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.RatingsHandlerStub{},
+		rewardsHandler,
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	return sp
+}
+
+func createRewardsHandlerForSignedHeader(signedHeaders *[]data.HeaderHandler) *mock.RewardsHandlerStub {
+	return &mock.RewardsHandlerStub{
+		CreateRewardsMiniBlockCalled: func(round uint64, leaderAddress []byte, signedHeader data.HeaderHandler) (*block.MiniBlock, error) {
+			*signedHeaders = append(*signedHeaders, signedHeader)
+			return nil, nil
+		},
+	}
+}
+
+func TestShardProcessor_ProcessRewardsShouldRewardTheSignersOfThePreviousHeaderFromStorage(t *testing.T) {
+	t.Parallel()
+
+	store := initStore()
+	prevHeader := &block.Header{Nonce: 2, Round: 4, PubKeysBitmap: []byte{5}, PrevHash: []byte("before prev")}
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "prev", prevHeader)
+	signedHeaders := make([]data.HeaderHandler, 0)
+	sp := createShardProcessorForRewardsWithStore(store, createRewardsHandlerForSignedHeader(&signedHeaders))
+
+	err := sp.ProcessRewards(block.Body{}, &block.Header{Nonce: 3, Round: 5, PrevHash: []byte("prev")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []data.HeaderHandler{prevHeader}, signedHeaders)
+}
+
+func TestShardProcessor_ProcessRewardsOnTopOfGenesisShouldRewardOnlyTheLeader(t *testing.T) {
+	t.Parallel()
+
+	signedHeaders := make([]data.HeaderHandler, 0)
+	sp := createShardProcessorForRewardsWithStore(initStore(), createRewardsHandlerForSignedHeader(&signedHeaders))
+
+	err := sp.ProcessRewards(block.Body{}, &block.Header{Nonce: 1, Round: 1, PrevHash: []byte("genesis")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []data.HeaderHandler{nil}, signedHeaders)
+}
+
+func TestShardProcessor_ProcessRewardsOnTopOfFirstBlockOfEpochShouldRewardOnlyTheLeader(t *testing.T) {
+	t.Parallel()
+
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "before prev", &block.Header{Nonce: 4, Epoch: 1})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "prev", &block.Header{Nonce: 5, Epoch: 2, PrevHash: []byte("before prev")})
+	signedHeaders := make([]data.HeaderHandler, 0)
+	sp := createShardProcessorForRewardsWithStore(store, createRewardsHandlerForSignedHeader(&signedHeaders))
+
+	err := sp.ProcessRewards(block.Body{}, &block.Header{Nonce: 6, Epoch: 2, PrevHash: []byte("prev")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []data.HeaderHandler{nil}, signedHeaders)
+}
+
+func TestShardProcessor_ProcessRewardsMissingPreviousHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	signedHeaders := make([]data.HeaderHandler, 0)
+	sp := createShardProcessorForRewardsWithStore(initStore(), createRewardsHandlerForSignedHeader(&signedHeaders))
+
+	err := sp.ProcessRewards(block.Body{}, &block.Header{Nonce: 3, PrevHash: []byte("missing")})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(signedHeaders))
+}
+
+func TestShardProcessor_CreateBlockBodyShouldRewardTheSignersOfTheConsensusPreviousHeader(t *testing.T) {
+	t.Parallel()
+
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "before prev", &block.Header{Nonce: 4, Epoch: 2})
+	prevHeader := &block.Header{Nonce: 5, Epoch: 2, PubKeysBitmap: []byte{3}, PrevHash: []byte("before prev")}
+	signedHeaders := make([]data.HeaderHandler, 0)
+	sp := createShardProcessorForRewardsWithStore(store, createRewardsHandlerForSignedHeader(&signedHeaders))
+
+	sp.SetConsensusData(prevHeader, 6)
+	_, err := sp.CreateBlockBody(6, func() bool { return true })
+
+	assert.Nil(t, err)
+	assert.Equal(t, []data.HeaderHandler{prevHeader}, signedHeaders)
+}
+
+//------- slashed peers
+
+func TestShardProcessor_ProcessSlashedPeersShouldSendPeerInfoOfMetaBlocks(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	processedPeerInfo := make([]block.PeerData, 0)
+	ratingsHandler := &mock.RatingsHandlerStub{
+		ProcessSlashedPeersCalled: func(peerInfo []block.PeerData) {
+			processedPeerInfo = append(processedPeerInfo, peerInfo...)
+		},
+	}
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		initDataPool([]byte("tx_hash1")),
+		&mock.ChainStorerMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(shardCoordinator),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		&mock.EpochHandlerStub{},
+		&mock.StakingHandlerStub{},
+		ratingsHandler,
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	slashed := block.PeerData{PublicKey: []byte("pk1"), Action: block.PeerSlashing}
+	registered := block.PeerData{PublicKey: []byte("pk2"), Action: block.PeerRegistrantion}
+	sp.ProcessSlashedPeers([]data.HeaderHandler{
+		&block.MetaBlock{Nonce: 1, PeerInfo: []block.PeerData{slashed}},
+		&block.Header{Nonce: 2},
+		&block.MetaBlock{Nonce: 3, PeerInfo: []block.PeerData{registered}},
+	})
+
+	assert.Equal(t, []block.PeerData{slashed, registered}, processedPeerInfo)
+}
+
+func createShardProcessorForEpochRestore(
+	store dataRetriever.StorageService,
+	epochHandler process.EpochHandler,
+	ratingsHandler process.RatingsHandler,
+) *blproc.ShardProcessor {
+	tdp := initDataPool([]byte("tx_hash1"))
+	emptyPool := &mock.CacherStub{
+		PeekCalled: func(key []byte) (value interface{}, ok bool) {
+			return nil, false
+		},
+		RegisterHandlerCalled: func(i func(key []byte)) {},
+	}
+	tdp.MetaBlocksCalled = func() storage.Cacher {
+		return emptyPool
+	}
+	tdp.HeadersCalled = func() storage.Cacher {
+		return emptyPool
+	}
+
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	sp, _ := blproc.NewShardProcessor(
+		&mock.ServiceContainerMock{},
+		tdp,
+		store,
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initAccountsMock(),
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		createGenesisBlocks(shardCoordinator),
+		&mock.RequestHandlerMock{},
+		&mock.TransactionCoordinatorMock{},
+		&mock.Uint64ByteSliceConverterMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SpecialAddressHandlerMock{},
+		epochHandler,
+		&mock.StakingHandlerStub{},
+		ratingsHandler,
+		&mock.RewardsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.CommitJournalStub{},
+	)
+
+	return sp
+}
+
+func putHeaderInStorage(store dataRetriever.StorageService, unit dataRetriever.UnitType, hash string, header data.HeaderHandler) {
+	buff, _ := (&mock.MarshalizerMock{}).Marshal(header)
+	_ = store.Put(unit, []byte(hash), buff)
+}
+
+func TestShardProcessor_RestoreEpochStateNilHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp := createShardProcessorForEpochRestore(initStore(), &mock.EpochHandlerStub{}, &mock.RatingsHandlerStub{})
+
+	err := sp.RestoreEpochState(nil)
+
+	assert.Equal(t, process.ErrNilBlockHeader, err)
+}
+
+func TestShardProcessor_RestoreEpochStateFirstEpochShouldRestoreTheInitialValidators(t *testing.T) {
+	t.Parallel()
+
+	restoredEpoch := uint32(5)
+	var restoredEpochStart []block.EpochStartShardData
+	epochHandler := &mock.EpochHandlerStub{
+		RestoreEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error {
+			restoredEpoch = epoch
+			restoredEpochStart = epochStart
+			return nil
+		},
+	}
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr2", &block.Header{Nonce: 2, Epoch: 0})
+	sp := createShardProcessorForEpochRestore(store, epochHandler, &mock.RatingsHandlerStub{})
+
+	err := sp.RestoreEpochState(&block.Header{Nonce: 3, Epoch: 0, PrevHash: []byte("hdr2")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), restoredEpoch)
+	assert.Nil(t, restoredEpochStart)
+}
+
+func TestShardProcessor_RestoreEpochStateShouldUseTheEpochStartMetaBlockFromStorage(t *testing.T) {
+	t.Parallel()
+
+	epochStart := []block.EpochStartShardData{{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}}}
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta_other", &block.MetaBlock{Nonce: 9, Epoch: 1})
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta_start", &block.MetaBlock{Nonce: 10, Epoch: 2, EpochStart: epochStart})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr3", &block.Header{Nonce: 3, Epoch: 1})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr4", &block.Header{
+		Nonce:           4,
+		Epoch:           2,
+		PrevHash:        []byte("hdr3"),
+		MetaBlockHashes: [][]byte{[]byte("meta_other"), []byte("meta_start")},
+	})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr5", &block.Header{Nonce: 5, Epoch: 2, PrevHash: []byte("hdr4")})
+
+	restoredEpoch := uint32(0)
+	var restoredEpochStart []block.EpochStartShardData
+	epochHandler := &mock.EpochHandlerStub{
+		RestoreEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error {
+			restoredEpoch = epoch
+			restoredEpochStart = epochStart
+			return nil
+		},
+	}
+	sp := createShardProcessorForEpochRestore(store, epochHandler, &mock.RatingsHandlerStub{})
+
+	err := sp.RestoreEpochState(&block.Header{Nonce: 6, Epoch: 2, PrevHash: []byte("hdr5")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), restoredEpoch)
+	assert.Equal(t, epochStart, restoredEpochStart)
+}
+
+func TestShardProcessor_RestoreEpochStateShouldRestoreTheRatingsOfTheEpochStartAndOfTheBlock(t *testing.T) {
+	t.Parallel()
+
+	epochStart := []block.EpochStartShardData{{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}}}
+	slashed := []block.PeerData{{PublicKey: []byte("pk"), Action: block.PeerSlashing}}
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta_start", &block.MetaBlock{Nonce: 10, Epoch: 2, EpochStart: epochStart})
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta_slashing", &block.MetaBlock{Nonce: 11, Epoch: 2, PeerInfo: slashed})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr3", &block.Header{Nonce: 3, Epoch: 1})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr4", &block.Header{
+		Nonce:           4,
+		Epoch:           2,
+		PrevHash:        []byte("hdr3"),
+		RootHash:        []byte("root4"),
+		MetaBlockHashes: [][]byte{[]byte("meta_start")},
+	})
+
+	calls := make([]string, 0)
+	epochHandler := &mock.EpochHandlerStub{
+		RestoreEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error {
+			calls = append(calls, fmt.Sprintf("restore epoch %d", epoch))
+			return nil
+		},
+	}
+	ratingsHandler := &mock.RatingsHandlerStub{
+		LoadRatingsCalled: func(rootHash []byte) error {
+			calls = append(calls, "load ratings "+string(rootHash))
+			return nil
+		},
+		ProcessCommittedHeaderCalled: func(header data.HeaderHandler, prevHeader data.HeaderHandler) error {
+			calls = append(calls, fmt.Sprintf("process header %d", header.GetNonce()))
+			return nil
+		},
+		ProcessSlashedPeersCalled: func(peerInfo []block.PeerData) {
+			calls = append(calls, fmt.Sprintf("slashed %d", len(peerInfo)))
+		},
+	}
+	sp := createShardProcessorForEpochRestore(store, epochHandler, ratingsHandler)
+
+	err := sp.RestoreEpochState(&block.Header{
+		Nonce:           5,
+		Epoch:           2,
+		PrevHash:        []byte("hdr4"),
+		MetaBlockHashes: [][]byte{[]byte("meta_slashing")},
+	})
+
+	assert.Nil(t, err)
+	expectedCalls := []string{"load ratings root4", "restore epoch 2", "process header 5", "slashed 1"}
+	assert.Equal(t, expectedCalls, calls)
+}
+
+func TestShardProcessor_RestoreEpochStateFirstBlockOfEpochShouldComputeRatingChangesWithThePreviousEpoch(t *testing.T) {
+	t.Parallel()
+
+	epochStart := []block.EpochStartShardData{{ShardId: 0, PublicKeys: [][]byte{[]byte("pk")}}}
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta_start", &block.MetaBlock{Nonce: 10, Epoch: 1, EpochStart: epochStart})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr3", &block.Header{Nonce: 3, Epoch: 0})
+
+	calls := make([]string, 0)
+	epochHandler := &mock.EpochHandlerStub{
+		RestoreEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error {
+			calls = append(calls, fmt.Sprintf("restore epoch %d", epoch))
+			return nil
+		},
+	}
+	ratingsHandler := &mock.RatingsHandlerStub{
+		LoadRatingsCalled: func(rootHash []byte) error {
+			calls = append(calls, "load ratings "+string(rootHash))
+			return nil
+		},
+		ProcessCommittedHeaderCalled: func(header data.HeaderHandler, prevHeader data.HeaderHandler) error {
+			calls = append(calls, fmt.Sprintf("process header %d", header.GetNonce()))
+			return nil
+		},
+	}
+	sp := createShardProcessorForEpochRestore(store, epochHandler, ratingsHandler)
+
+	err := sp.RestoreEpochState(&block.Header{
+		Nonce:           4,
+		Epoch:           1,
+		PrevHash:        []byte("hdr3"),
+		RootHash:        []byte("root4"),
+		MetaBlockHashes: [][]byte{[]byte("meta_start")},
+	})
+
+	assert.Nil(t, err)
+	expectedCalls := []string{"restore epoch 0", "process header 4", "restore epoch 1"}
+	assert.Equal(t, expectedCalls, calls)
+}
+
+func TestShardProcessor_RestoreEpochStateGenesisShouldLoadTheGenesisRatings(t *testing.T) {
+	t.Parallel()
+
+	var loadedRootHash []byte
+	ratingsHandler := &mock.RatingsHandlerStub{
+		LoadRatingsCalled: func(rootHash []byte) error {
+			loadedRootHash = rootHash
+			return nil
+		},
+		ProcessCommittedHeaderCalled: func(header data.HeaderHandler, prevHeader data.HeaderHandler) error {
+			assert.Fail(t, "should have not been called")
+			return nil
+		},
+	}
+	sp := createShardProcessorForEpochRestore(initStore(), &mock.EpochHandlerStub{}, ratingsHandler)
+
+	err := sp.RestoreEpochState(&block.Header{Nonce: 0, RootHash: []byte("genesis root")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("genesis root"), loadedRootHash)
+}
+
+func TestShardProcessor_RestoreEpochStateMissingEpochStartMetaBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	store := initStore()
+	putHeaderInStorage(store, dataRetriever.MetaBlockUnit, "meta_other", &block.MetaBlock{Nonce: 9, Epoch: 1})
+	putHeaderInStorage(store, dataRetriever.BlockHeaderUnit, "hdr1", &block.Header{
+		Nonce:           1,
+		Epoch:           2,
+		MetaBlockHashes: [][]byte{[]byte("meta_other")},
+	})
+
+	restoreCalled := false
+	epochHandler := &mock.EpochHandlerStub{
+		RestoreEpochStartCalled: func(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error {
+			restoreCalled = true
+			return nil
+		},
+	}
+	sp := createShardProcessorForEpochRestore(store, epochHandler, &mock.RatingsHandlerStub{})
+
+	err := sp.RestoreEpochState(&block.Header{Nonce: 2, Epoch: 2, PrevHash: []byte("hdr1")})
+
+	assert.Equal(t, process.ErrMissingEpochStartMetaBlock, err)
+	assert.False(t, restoreCalled)
+}
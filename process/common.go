@@ -18,6 +18,16 @@ func EmptyChannel(ch chan bool) {
 	}
 }
 
+// IsSignerInBitmap returns true if the validator found at the given index in the consensus group has signed the
+// block described by the given PubKeysBitmap
+func IsSignerInBitmap(bitmap []byte, index int) bool {
+	if index < 0 || index/8 >= len(bitmap) {
+		return false
+	}
+
+	return bitmap[index/8]&(1<<uint8(index%8)) != 0
+}
+
 // GetShardHeader gets the header, which is associated with the given hash, from pool or storage
 func GetShardHeader(
 	hash []byte,
@@ -27,6 +27,20 @@ func TestEmptyChannelShouldWork(t *testing.T) {
 	assert.Equal(t, 0, len(ch))
 }
 
+func TestIsSignerInBitmapShouldWork(t *testing.T) {
+	t.Parallel()
+
+	bitmap := []byte{5, 128}
+
+	assert.True(t, process.IsSignerInBitmap(bitmap, 0))
+	assert.False(t, process.IsSignerInBitmap(bitmap, 1))
+	assert.True(t, process.IsSignerInBitmap(bitmap, 2))
+	assert.True(t, process.IsSignerInBitmap(bitmap, 15))
+	assert.False(t, process.IsSignerInBitmap(bitmap, 16))
+	assert.False(t, process.IsSignerInBitmap(bitmap, -1))
+	assert.False(t, process.IsSignerInBitmap(nil, 0))
+}
+
 func TestGetShardHeaderShouldErrNilCacher(t *testing.T) {
 	hash := []byte("X")
 
@@ -24,10 +24,35 @@ const (
 	SCDeployment
 	// SCInvoking defines ID of a transaction of type smart contract call
 	SCInvoking
+	// Staking defines ID of a transaction which locks or releases the stake of a validator
+	Staking
+	// RelayedTx defines ID of a transaction which wraps the transaction of another sender, paying its gas
+	RelayedTx
 	// InvalidTransaction defines unknown transaction type
 	InvalidTransaction
 )
 
+// StakingAddress is the address of the system account which holds the stake locked by the validators. The
+// transactions sent to this address are staking transactions
+var StakingAddress = []byte("staking_system_account__________")
+
+// RelayedTxDataPrefix prefixes the data field of a relayed transaction, followed by the hex encoded inner transaction
+const RelayedTxDataPrefix = "relayedTx@"
+
+// RatingsAddress is the address of the system account which holds the ratings of the validators in its data trie
+var RatingsAddress = []byte("ratings_system_account__________")
+
+// FeesAddress is the sender of the smart contract results which credit the fees of a block to a leader whose reward
+// address is in another shard
+var FeesAddress = []byte("fees_system_account_____________")
+
+// RewardsAddress is the sender of the smart contract results which credit the rewards of a block to the validators
+// whose reward address is in another shard
+var RewardsAddress = []byte("rewards_system_account__________")
+
+// TotalSupplyAddress is the address of the metachain system account which holds the total supply in its data trie
+var TotalSupplyAddress = []byte("total_supply_system_account_____")
+
 const ShardBlockFinality = 1
 const MetaBlockFinality = 1
 const ForkBlockFinality = 1
@@ -37,3 +62,10 @@ const MaxItemsInBlock = 15000
 // TODO - calculate exactly in case of the VM, for every VM to have a similar constant, operations / seconds
 const MaxGasLimitPerMiniBlock = uint64(100000)
 const MaxRequestsWithTimeoutAllowed = 5
+
+// MaxHeaderRoundsInFuture defines the number of rounds after the current one for which the intercepted headers are
+// still accepted
+const MaxHeaderRoundsInFuture = 1
+
+// MinTransactionVersion is the lowest transaction version accepted, the first one which signs the chain ID
+const MinTransactionVersion = uint32(1)
@@ -484,15 +484,13 @@ func (tc *transactionCoordinator) CreateMbsAndProcessTransactionsFromMe(
 		}
 	}
 
-	interMBs := tc.processAddedInterimTransactions()
-	if len(interMBs) > 0 {
-		miniBlocks = append(miniBlocks, interMBs...)
-	}
-
 	return miniBlocks
 }
 
-func (tc *transactionCoordinator) processAddedInterimTransactions() block.MiniBlockSlice {
+// CreatePostProcessMiniBlocks returns the cross shard miniblocks holding the intermediate transactions added since
+// the block has been started. It is called after all the state changes of the created block were done, as crediting
+// the fees and the rewards can also add intermediate transactions
+func (tc *transactionCoordinator) CreatePostProcessMiniBlocks() block.MiniBlockSlice {
 	miniBlocks := make(block.MiniBlockSlice, 0)
 
 	// processing has to be done in order, as the order of different type of transactions over the same account is strict
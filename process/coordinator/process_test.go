@@ -939,6 +939,42 @@ func TestTransactionCoordinator_CreateMbsAndProcessTransactionsFromMeMultipleMin
 	assert.Equal(t, allTxs/numTxsToAdd, len(mbs))
 }
 
+func TestTransactionCoordinator_CreatePostProcessMiniBlocksShouldReturnTheInterimMiniBlocks(t *testing.T) {
+	t.Parallel()
+
+	scrMiniBlock := &block.MiniBlock{
+		TxHashes:        [][]byte{[]byte("scr_hash")},
+		SenderShardID:   0,
+		ReceiverShardID: 1,
+		Type:            block.SmartContractResultBlock,
+	}
+	tdp := initDataPool([]byte("tx_hash1"))
+	tc, err := NewTransactionCoordinator(
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.AccountsStub{},
+		tdp,
+		&mock.RequestHandlerMock{},
+		createPreProcessorContainerWithDataPool(tdp),
+		&mock.InterimProcessorContainerMock{
+			KeysCalled: func() []block.Type {
+				return []block.Type{block.SmartContractResultBlock}
+			},
+			GetCalled: func(key block.Type) (process.IntermediateTransactionHandler, error) {
+				return &mock.IntermediateTransactionHandlerMock{
+					CreateAllInterMiniBlocksCalled: func() map[uint32]*block.MiniBlock {
+						return map[uint32]*block.MiniBlock{1: scrMiniBlock}
+					},
+				}, nil
+			},
+		},
+	)
+	assert.Nil(t, err)
+
+	mbs := tc.CreatePostProcessMiniBlocks()
+
+	assert.Equal(t, block.MiniBlockSlice{scrMiniBlock}, mbs)
+}
+
 func TestTransactionCoordinator_GetAllCurrentUsedTxs(t *testing.T) {
 	t.Parallel()
 
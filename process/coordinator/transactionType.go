@@ -2,6 +2,8 @@ package coordinator
 
 import (
 	"bytes"
+	"strings"
+
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/process"
@@ -46,6 +48,14 @@ func (tc *txTypeHandler) ComputeTransactionType(tx data.TransactionHandler) (pro
 		return process.InvalidTransaction, err
 	}
 
+	if bytes.Equal(tx.GetRecvAddress(), process.StakingAddress) {
+		return process.Staking, nil
+	}
+
+	if strings.HasPrefix(tx.GetData(), process.RelayedTxDataPrefix) {
+		return process.RelayedTx, nil
+	}
+
 	isEmptyAddress := tc.isDestAddressEmpty(tx)
 	if isEmptyAddress {
 		if len(tx.GetData()) > 0 {
@@ -0,0 +1,128 @@
+package dataValidators
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/statusHandler"
+)
+
+// headerValidator drops the intercepted headers which are behind the final block of the node's own chain or which
+// belong to rounds that did not start yet, so they are not added in the headers pools
+type headerValidator struct {
+	forkDetector     process.ForkDetector
+	rounder          consensus.Rounder
+	shardCoordinator sharding.Coordinator
+
+	numStaleHeaders     uint64
+	numFutureHeaders    uint64
+	appStatusHandler    core.AppStatusHandler
+	mutAppStatusHandler sync.RWMutex
+}
+
+// NewHeaderValidator creates a new validator checking the headers against the final nonce and the current round
+func NewHeaderValidator(
+	forkDetector process.ForkDetector,
+	rounder consensus.Rounder,
+	shardCoordinator sharding.Coordinator,
+) (*headerValidator, error) {
+	if forkDetector == nil {
+		return nil, process.ErrNilForkDetector
+	}
+	if rounder == nil {
+		return nil, process.ErrNilRounder
+	}
+	if shardCoordinator == nil {
+		return nil, process.ErrNilShardCoordinator
+	}
+
+	return &headerValidator{
+		forkDetector:     forkDetector,
+		rounder:          rounder,
+		shardCoordinator: shardCoordinator,
+		appStatusHandler: statusHandler.NewNilStatusHandler(),
+	}, nil
+}
+
+// SetAppStatusHandler sets the status handler where the numbers of dropped headers are reported
+func (hv *headerValidator) SetAppStatusHandler(ash core.AppStatusHandler) error {
+	if ash == nil || ash.IsInterfaceNil() {
+		return process.ErrNilAppStatusHandler
+	}
+
+	hv.mutAppStatusHandler.Lock()
+	hv.appStatusHandler = ash
+	hv.mutAppStatusHandler.Unlock()
+
+	return nil
+}
+
+// IsHeaderValidForProcessing returns false if the header is behind the final block or if its round did not start yet
+func (hv *headerValidator) IsHeaderValidForProcessing(headerHandler data.HeaderHandler) bool {
+	if headerHandler == nil || headerHandler.IsInterfaceNil() {
+		return false
+	}
+
+	if hv.isFromFutureRound(headerHandler) {
+		numFutureHeaders := atomic.AddUint64(&hv.numFutureHeaders, 1)
+		hv.setMetric(core.MetricNumDroppedFutureHeaders, numFutureHeaders)
+
+		log.Debug(fmt.Sprintf("intercepted header with shard %d, nonce %d and round %d dropped: current round is %d",
+			headerHandler.GetShardID(), headerHandler.GetNonce(), headerHandler.GetRound(), hv.rounder.Index()))
+		return false
+	}
+
+	if hv.isStale(headerHandler) {
+		numStaleHeaders := atomic.AddUint64(&hv.numStaleHeaders, 1)
+		hv.setMetric(core.MetricNumDroppedStaleHeaders, numStaleHeaders)
+
+		log.Debug(fmt.Sprintf("intercepted header with shard %d and nonce %d dropped: final nonce is %d",
+			headerHandler.GetShardID(), headerHandler.GetNonce(), hv.forkDetector.GetHighestFinalBlockNonce()))
+		return false
+	}
+
+	return true
+}
+
+// NumStaleHeaders returns the number of headers dropped as they were behind the final block
+func (hv *headerValidator) NumStaleHeaders() uint64 {
+	return atomic.LoadUint64(&hv.numStaleHeaders)
+}
+
+// NumFutureHeaders returns the number of headers dropped as their round did not start yet
+func (hv *headerValidator) NumFutureHeaders() uint64 {
+	return atomic.LoadUint64(&hv.numFutureHeaders)
+}
+
+// isFromFutureRound returns true if the header round is after the next one. The header of the next round is
+// accepted, as the clocks of the nodes are not perfectly synchronized
+func (hv *headerValidator) isFromFutureRound(headerHandler data.HeaderHandler) bool {
+	currentRound := hv.rounder.Index()
+	if currentRound < 0 {
+		currentRound = 0
+	}
+
+	return headerHandler.GetRound() > uint64(currentRound)+process.MaxHeaderRoundsInFuture
+}
+
+// isStale returns true if the header is behind the final block. Only the headers of the node's own chain are
+// checked, as the fork detector follows only this chain
+func (hv *headerValidator) isStale(headerHandler data.HeaderHandler) bool {
+	if headerHandler.GetShardID() != hv.shardCoordinator.SelfId() {
+		return false
+	}
+
+	return headerHandler.GetNonce() < hv.forkDetector.GetHighestFinalBlockNonce()
+}
+
+func (hv *headerValidator) setMetric(key string, value uint64) {
+	hv.mutAppStatusHandler.RLock()
+	hv.appStatusHandler.SetUInt64Value(key, value)
+	hv.mutAppStatusHandler.RUnlock()
+}
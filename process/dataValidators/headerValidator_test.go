@@ -0,0 +1,186 @@
+package dataValidators_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/dataValidators"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createForkDetector(finalNonce uint64) *mock.ForkDetectorMock {
+	return &mock.ForkDetectorMock{
+		GetHighestFinalBlockNonceCalled: func() uint64 {
+			return finalNonce
+		},
+	}
+}
+
+//------- NewHeaderValidator
+
+func TestNewHeaderValidator_NilForkDetectorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, err := dataValidators.NewHeaderValidator(
+		nil,
+		&mock.RounderMock{},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.Nil(t, hdrValidator)
+	assert.Equal(t, process.ErrNilForkDetector, err)
+}
+
+func TestNewHeaderValidator_NilRounderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, err := dataValidators.NewHeaderValidator(
+		createForkDetector(0),
+		nil,
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.Nil(t, hdrValidator)
+	assert.Equal(t, process.ErrNilRounder, err)
+}
+
+func TestNewHeaderValidator_NilShardCoordinatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, err := dataValidators.NewHeaderValidator(
+		createForkDetector(0),
+		&mock.RounderMock{},
+		nil,
+	)
+
+	assert.Nil(t, hdrValidator)
+	assert.Equal(t, process.ErrNilShardCoordinator, err)
+}
+
+func TestNewHeaderValidator_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, err := dataValidators.NewHeaderValidator(
+		createForkDetector(0),
+		&mock.RounderMock{},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.NotNil(t, hdrValidator)
+	assert.Nil(t, err)
+}
+
+func TestHeaderValidator_SetAppStatusHandlerNilShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, _ := dataValidators.NewHeaderValidator(
+		createForkDetector(0),
+		&mock.RounderMock{},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	err := hdrValidator.SetAppStatusHandler(nil)
+
+	assert.Equal(t, process.ErrNilAppStatusHandler, err)
+}
+
+//------- IsHeaderValidForProcessing
+
+func TestHeaderValidator_IsHeaderValidForProcessingNilHeaderShouldReturnFalse(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, _ := dataValidators.NewHeaderValidator(
+		createForkDetector(0),
+		&mock.RounderMock{},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.False(t, hdrValidator.IsHeaderValidForProcessing(nil))
+}
+
+func TestHeaderValidator_IsHeaderValidForProcessingStaleHeaderShouldReturnFalse(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, _ := dataValidators.NewHeaderValidator(
+		createForkDetector(10),
+		&mock.RounderMock{RoundIndex: 20},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.False(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 9, Round: 15}))
+	assert.True(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 10, Round: 15}))
+	assert.True(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 11, Round: 15}))
+	assert.Equal(t, uint64(1), hdrValidator.NumStaleHeaders())
+	assert.Equal(t, uint64(0), hdrValidator.NumFutureHeaders())
+}
+
+func TestHeaderValidator_IsHeaderValidForProcessingOtherChainShouldNotCheckTheNonce(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, _ := dataValidators.NewHeaderValidator(
+		createForkDetector(10),
+		&mock.RounderMock{RoundIndex: 20},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.True(t, hdrValidator.IsHeaderValidForProcessing(&block.MetaBlock{Nonce: 1, Round: 15}))
+	assert.True(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{ShardId: 1, Nonce: 1, Round: 15}))
+	assert.Equal(t, uint64(0), hdrValidator.NumStaleHeaders())
+}
+
+func TestHeaderValidator_IsHeaderValidForProcessingFutureHeaderShouldReturnFalse(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, _ := dataValidators.NewHeaderValidator(
+		createForkDetector(0),
+		&mock.RounderMock{RoundIndex: 20},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.True(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 1, Round: 20}))
+	assert.True(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 1, Round: 21}))
+	assert.False(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 1, Round: 22}))
+	assert.False(t, hdrValidator.IsHeaderValidForProcessing(&block.MetaBlock{Nonce: 1, Round: 1000}))
+	assert.Equal(t, uint64(2), hdrValidator.NumFutureHeaders())
+	assert.Equal(t, uint64(0), hdrValidator.NumStaleHeaders())
+}
+
+func TestHeaderValidator_IsHeaderValidForProcessingBeforeGenesisShouldAcceptOnlyTheFirstRounds(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, _ := dataValidators.NewHeaderValidator(
+		createForkDetector(0),
+		&mock.RounderMock{RoundIndex: -5},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	assert.True(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 1, Round: 1}))
+	assert.False(t, hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 2, Round: 2}))
+}
+
+func TestHeaderValidator_IsHeaderValidForProcessingShouldReportTheDroppedHeaders(t *testing.T) {
+	t.Parallel()
+
+	hdrValidator, _ := dataValidators.NewHeaderValidator(
+		createForkDetector(10),
+		&mock.RounderMock{RoundIndex: 20},
+		mock.NewMultipleShardsCoordinatorMock(),
+	)
+
+	metrics := make(map[string]uint64)
+	_ = hdrValidator.SetAppStatusHandler(&mock.AppStatusHandlerStub{
+		SetUInt64ValueHandler: func(key string, value uint64) {
+			metrics[key] = value
+		},
+	})
+
+	_ = hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 5, Round: 15})
+	_ = hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 6, Round: 16})
+	_ = hdrValidator.IsHeaderValidForProcessing(&block.Header{Nonce: 50, Round: 50})
+
+	assert.Equal(t, uint64(2), metrics[core.MetricNumDroppedStaleHeaders])
+	assert.Equal(t, uint64(1), metrics[core.MetricNumDroppedFutureHeaders])
+}
@@ -0,0 +1,170 @@
+package dataValidators
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/statusHandler"
+)
+
+var log = logger.DefaultLogger()
+
+// txValidator checks the intercepted transactions against the state of the last committed block, so that the
+// transactions which can not be executed are not added in the pool
+type txValidator struct {
+	committedAccounts process.CommittedAccountsProvider
+	shardCoordinator  sharding.Coordinator
+	addrConverter     state.AddressConverter
+	economicsFee      process.FeeHandler
+	maxDataLength     int
+
+	numRejectedTxs      uint64
+	appStatusHandler    core.AppStatusHandler
+	mutAppStatusHandler sync.RWMutex
+}
+
+// NewTxValidator creates a new validator checking the transactions against the state of the last committed block,
+// read through the committed accounts provider shared with the transaction pool
+func NewTxValidator(
+	committedAccounts process.CommittedAccountsProvider,
+	shardCoordinator sharding.Coordinator,
+	addrConverter state.AddressConverter,
+	economicsFee process.FeeHandler,
+	maxDataLength int,
+) (*txValidator, error) {
+	if committedAccounts == nil {
+		return nil, process.ErrNilCommittedAccountsProvider
+	}
+	if shardCoordinator == nil {
+		return nil, process.ErrNilShardCoordinator
+	}
+	if addrConverter == nil {
+		return nil, process.ErrNilAddressConverter
+	}
+	if economicsFee == nil {
+		return nil, process.ErrNilEconomicsFeeHandler
+	}
+	if maxDataLength <= 0 {
+		return nil, process.ErrInvalidMaxDataLength
+	}
+
+	return &txValidator{
+		committedAccounts: committedAccounts,
+		shardCoordinator:  shardCoordinator,
+		addrConverter:     addrConverter,
+		economicsFee:      economicsFee,
+		maxDataLength:     maxDataLength,
+		appStatusHandler:  statusHandler.NewNilStatusHandler(),
+	}, nil
+}
+
+// SetAppStatusHandler sets the status handler where the number of rejected transactions is reported
+func (tv *txValidator) SetAppStatusHandler(ash core.AppStatusHandler) error {
+	if ash == nil || ash.IsInterfaceNil() {
+		return process.ErrNilAppStatusHandler
+	}
+
+	tv.mutAppStatusHandler.Lock()
+	tv.appStatusHandler = ash
+	tv.mutAppStatusHandler.Unlock()
+
+	return nil
+}
+
+// IsTxValidForProcessing returns true if the transaction can be executed on top of the last committed block. The
+// rejected transactions are counted
+func (tv *txValidator) IsTxValidForProcessing(txHandler data.TransactionHandler) bool {
+	err := tv.checkTransaction(txHandler)
+	if err == nil {
+		return true
+	}
+
+	numRejectedTxs := atomic.AddUint64(&tv.numRejectedTxs, 1)
+	tv.mutAppStatusHandler.RLock()
+	tv.appStatusHandler.SetUInt64Value(core.MetricNumRejectedTxs, numRejectedTxs)
+	tv.mutAppStatusHandler.RUnlock()
+
+	log.Debug(fmt.Sprintf("intercepted tx rejected: %s", err.Error()))
+	return false
+}
+
+// NumRejectedTxs returns the number of transactions rejected since the validator was created
+func (tv *txValidator) NumRejectedTxs() uint64 {
+	return atomic.LoadUint64(&tv.numRejectedTxs)
+}
+
+func (tv *txValidator) checkTransaction(txHandler data.TransactionHandler) error {
+	tx, ok := txHandler.(*transaction.Transaction)
+	if !ok || tx == nil {
+		return process.ErrWrongTypeAssertion
+	}
+
+	// the gas checks come first, as they do not access the state
+	err := tv.economicsFee.CheckValidityTxValues(tx)
+	if err != nil {
+		return err
+	}
+	if len(tx.Data) > tv.maxDataLength {
+		return process.ErrDataFieldTooLong
+	}
+
+	sndAddr, err := tv.addrConverter.CreateAddressFromPublicKeyBytes(tx.SndAddr)
+	if err != nil {
+		return err
+	}
+	if tv.shardCoordinator.ComputeId(sndAddr) != tv.shardCoordinator.SelfId() {
+		// the sender account is held by another shard, which checked the transaction
+		return nil
+	}
+
+	nonce, balance, err := tv.getSenderState(sndAddr)
+	if err == state.ErrNilBlockHeader {
+		// no block was committed yet, so there is no state to check against
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if tx.Nonce < nonce {
+		return process.ErrLowerNonceInTransaction
+	}
+
+	maxCost := big.NewInt(0).SetUint64(tx.GasPrice)
+	maxCost.Mul(maxCost, big.NewInt(0).SetUint64(tx.GasLimit))
+	if tx.Value != nil {
+		maxCost.Add(maxCost, tx.Value)
+	}
+	if balance.Cmp(maxCost) < 0 {
+		return process.ErrInsufficientFunds
+	}
+
+	return nil
+}
+
+// getSenderState returns the nonce and the balance of the sender at the last committed block
+func (tv *txValidator) getSenderState(sndAddr state.AddressContainer) (uint64, *big.Int, error) {
+	accountHandler, err := tv.committedAccounts.GetExistingAccount(sndAddr)
+	if err == state.ErrAccNotFound {
+		return 0, big.NewInt(0), nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if !ok {
+		return 0, nil, process.ErrWrongTypeAssertion
+	}
+
+	return account.Nonce, account.Balance, nil
+}
@@ -0,0 +1,326 @@
+package dataValidators_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/dataValidators"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const maxDataLength = 100
+
+func createCommittedAccounts(nonce uint64, balance int64) *mock.CommittedAccountsProviderStub {
+	return &mock.CommittedAccountsProviderStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			account, _ := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+			account.Nonce = nonce
+			account.Balance = big.NewInt(balance)
+			return account, nil
+		},
+	}
+}
+
+func createTxValidator(committedAccounts process.CommittedAccountsProvider) process.TxValidator {
+	txValidator, _ := dataValidators.NewTxValidator(
+		committedAccounts,
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	return txValidator
+}
+
+func createTx(nonce uint64, value int64) *transaction.Transaction {
+	return &transaction.Transaction{
+		Nonce:    nonce,
+		Value:    big.NewInt(value),
+		SndAddr:  []byte("sender"),
+		RcvAddr:  []byte("receiver"),
+		GasPrice: 2,
+		GasLimit: 10,
+	}
+}
+
+//------- NewTxValidator
+
+func TestNewTxValidator_NilCommittedAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txValidator, err := dataValidators.NewTxValidator(
+		nil,
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	assert.Nil(t, txValidator)
+	assert.Equal(t, process.ErrNilCommittedAccountsProvider, err)
+}
+
+func TestNewTxValidator_NilShardCoordinatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txValidator, err := dataValidators.NewTxValidator(
+		&mock.CommittedAccountsProviderStub{},
+		nil,
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	assert.Nil(t, txValidator)
+	assert.Equal(t, process.ErrNilShardCoordinator, err)
+}
+
+func TestNewTxValidator_NilAddressConverterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txValidator, err := dataValidators.NewTxValidator(
+		&mock.CommittedAccountsProviderStub{},
+		mock.NewMultipleShardsCoordinatorMock(),
+		nil,
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	assert.Nil(t, txValidator)
+	assert.Equal(t, process.ErrNilAddressConverter, err)
+}
+
+func TestNewTxValidator_NilFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txValidator, err := dataValidators.NewTxValidator(
+		&mock.CommittedAccountsProviderStub{},
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		nil,
+		maxDataLength,
+	)
+
+	assert.Nil(t, txValidator)
+	assert.Equal(t, process.ErrNilEconomicsFeeHandler, err)
+}
+
+func TestNewTxValidator_InvalidMaxDataLengthShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txValidator, err := dataValidators.NewTxValidator(
+		&mock.CommittedAccountsProviderStub{},
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		0,
+	)
+
+	assert.Nil(t, txValidator)
+	assert.Equal(t, process.ErrInvalidMaxDataLength, err)
+}
+
+func TestNewTxValidator_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	txValidator, err := dataValidators.NewTxValidator(
+		&mock.CommittedAccountsProviderStub{},
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	assert.NotNil(t, txValidator)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), txValidator.NumRejectedTxs())
+}
+
+func TestTxValidator_SetAppStatusHandlerNilShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txValidator, _ := dataValidators.NewTxValidator(
+		&mock.CommittedAccountsProviderStub{},
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	err := txValidator.SetAppStatusHandler(nil)
+
+	assert.Equal(t, process.ErrNilAppStatusHandler, err)
+}
+
+//------- IsTxValidForProcessing
+
+func TestTxValidator_IsTxValidForProcessingWrongTypeShouldReject(t *testing.T) {
+	t.Parallel()
+
+	txValidator := createTxValidator(&mock.CommittedAccountsProviderStub{})
+
+	assert.False(t, txValidator.IsTxValidForProcessing(nil))
+	assert.False(t, txValidator.IsTxValidForProcessing(&smartContractResult.SmartContractResult{}))
+}
+
+func TestTxValidator_IsTxValidForProcessingInvalidGasValuesShouldReject(t *testing.T) {
+	t.Parallel()
+
+	txValidator, _ := dataValidators.NewTxValidator(
+		createCommittedAccounts(0, 1000),
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{
+			CheckValidityTxValuesCalled: func(tx *transaction.Transaction) error {
+				return process.ErrInsufficientGasPriceInTx
+			},
+		},
+		maxDataLength,
+	)
+
+	assert.False(t, txValidator.IsTxValidForProcessing(createTx(0, 1)))
+	assert.Equal(t, uint64(1), txValidator.NumRejectedTxs())
+}
+
+func TestTxValidator_IsTxValidForProcessingDataTooLongShouldReject(t *testing.T) {
+	t.Parallel()
+
+	txValidator := createTxValidator(createCommittedAccounts(0, 1000))
+
+	tx := createTx(0, 1)
+	tx.Data = strings.Repeat("a", maxDataLength)
+	assert.True(t, txValidator.IsTxValidForProcessing(tx))
+
+	tx.Data = strings.Repeat("a", maxDataLength+1)
+	assert.False(t, txValidator.IsTxValidForProcessing(tx))
+}
+
+func TestTxValidator_IsTxValidForProcessingLowerNonceShouldReject(t *testing.T) {
+	t.Parallel()
+
+	txValidator := createTxValidator(createCommittedAccounts(5, 1000))
+
+	assert.False(t, txValidator.IsTxValidForProcessing(createTx(4, 1)))
+	assert.True(t, txValidator.IsTxValidForProcessing(createTx(5, 1)))
+	assert.True(t, txValidator.IsTxValidForProcessing(createTx(6, 1)))
+}
+
+func TestTxValidator_IsTxValidForProcessingInsufficientBalanceShouldReject(t *testing.T) {
+	t.Parallel()
+
+	txValidator := createTxValidator(createCommittedAccounts(0, 100))
+
+	// the maximum fee is gas price * gas limit = 20
+	assert.True(t, txValidator.IsTxValidForProcessing(createTx(0, 80)))
+	assert.False(t, txValidator.IsTxValidForProcessing(createTx(0, 81)))
+}
+
+func TestTxValidator_IsTxValidForProcessingMissingSenderShouldReject(t *testing.T) {
+	t.Parallel()
+
+	txValidator := createTxValidator(&mock.CommittedAccountsProviderStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, state.ErrAccNotFound
+		},
+	})
+
+	assert.False(t, txValidator.IsTxValidForProcessing(createTx(0, 0)))
+}
+
+func TestTxValidator_IsTxValidForProcessingCrossShardSenderShouldNotCheckTheState(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := mock.NewMultipleShardsCoordinatorMock()
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		return shardCoordinator.SelfId() + 1
+	}
+	committedAccounts := &mock.CommittedAccountsProviderStub{
+		GetExistingAccountCalled: func(address state.AddressContainer) (state.AccountHandler, error) {
+			assert.Fail(t, "should have not read the state")
+			return nil, nil
+		},
+	}
+	txValidator, _ := dataValidators.NewTxValidator(
+		committedAccounts,
+		shardCoordinator,
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	assert.True(t, txValidator.IsTxValidForProcessing(createTx(0, 1000)))
+}
+
+func TestTxValidator_IsTxValidForProcessingNoCommittedBlockShouldNotCheckTheState(t *testing.T) {
+	t.Parallel()
+
+	txValidator := createTxValidator(&mock.CommittedAccountsProviderStub{
+		GetExistingAccountCalled: func(address state.AddressContainer) (state.AccountHandler, error) {
+			return nil, state.ErrNilBlockHeader
+		},
+	})
+
+	assert.True(t, txValidator.IsTxValidForProcessing(createTx(0, 1000)))
+}
+
+func TestTxValidator_IsTxValidForProcessingStateErrorShouldReject(t *testing.T) {
+	t.Parallel()
+
+	txValidator := createTxValidator(&mock.CommittedAccountsProviderStub{
+		GetExistingAccountCalled: func(address state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errors.New("recreate error")
+		},
+	})
+
+	assert.False(t, txValidator.IsTxValidForProcessing(createTx(0, 1)))
+}
+
+func TestTxValidator_IsTxValidForProcessingShouldCountTheRejectedTxs(t *testing.T) {
+	t.Parallel()
+
+	txValidator, _ := dataValidators.NewTxValidator(
+		createCommittedAccounts(5, 1000),
+		mock.NewMultipleShardsCoordinatorMock(),
+		&mock.AddressConverterMock{},
+		&mock.FeeHandlerStub{},
+		maxDataLength,
+	)
+
+	mutMetric := sync.Mutex{}
+	metricValue := uint64(0)
+	_ = txValidator.SetAppStatusHandler(&mock.AppStatusHandlerStub{
+		SetUInt64ValueHandler: func(key string, value uint64) {
+			assert.Equal(t, core.MetricNumRejectedTxs, key)
+			mutMetric.Lock()
+			if value > metricValue {
+				metricValue = value
+			}
+			mutMetric.Unlock()
+		},
+	})
+
+	numTxs := 20
+	wg := sync.WaitGroup{}
+	wg.Add(numTxs)
+	for i := 0; i < numTxs; i++ {
+		go func(nonce uint64) {
+			_ = txValidator.IsTxValidForProcessing(createTx(nonce, 1))
+			wg.Done()
+		}(uint64(i))
+	}
+	wg.Wait()
+
+	// the transactions with nonces 0..4 are rejected
+	assert.Equal(t, uint64(5), txValidator.NumRejectedTxs())
+	assert.Equal(t, uint64(5), metricValue)
+}
@@ -0,0 +1,220 @@
+package economics
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// EconomicsData will store information about the economics of the network (fees, staking, ratings and rewards)
+type EconomicsData struct {
+	minGasPrice        uint64
+	minGasLimit        uint64
+	gasPerDataByte     uint64
+	minStakeValue      *big.Int
+	unBondPeriod       uint64
+	slashingPercentage float64
+	ratingSettings     config.RatingSettings
+
+	blockReward          *big.Int
+	leaderPercentage     float64
+	yearlyInflationRates []float64
+}
+
+// NewEconomicsData will create an object with information about the economics parameters
+func NewEconomicsData(economics *config.EconomicsConfig) (*EconomicsData, error) {
+	if economics == nil {
+		return nil, process.ErrNilEconomicsData
+	}
+
+	minStakeValue, ok := big.NewInt(0).SetString(economics.StakingSettings.MinStakeValue, 10)
+	if !ok || minStakeValue.Sign() < 0 {
+		return nil, process.ErrInvalidMinStakeValue
+	}
+
+	slashingPercentage := economics.StakingSettings.SlashingPercentage
+	if slashingPercentage < 0 || slashingPercentage > 1 {
+		return nil, process.ErrInvalidSlashingPercentage
+	}
+
+	err := checkRatingSettings(&economics.RatingSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	blockReward, ok := big.NewInt(0).SetString(economics.RewardsSettings.BlockReward, 10)
+	if !ok || blockReward.Sign() < 0 {
+		return nil, process.ErrInvalidBlockReward
+	}
+
+	err = checkRewardsSettings(&economics.RewardsSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	yearlyInflationRates := make([]float64, len(economics.RewardsSettings.YearlyInflationRates))
+	copy(yearlyInflationRates, economics.RewardsSettings.YearlyInflationRates)
+
+	return &EconomicsData{
+		minGasPrice:          economics.FeeSettings.MinGasPrice,
+		minGasLimit:          economics.FeeSettings.MinGasLimit,
+		gasPerDataByte:       economics.FeeSettings.GasPerDataByte,
+		minStakeValue:        minStakeValue,
+		unBondPeriod:         economics.StakingSettings.UnBondPeriod,
+		slashingPercentage:   slashingPercentage,
+		ratingSettings:       economics.RatingSettings,
+		blockReward:          blockReward,
+		leaderPercentage:     economics.RewardsSettings.LeaderPercentage,
+		yearlyInflationRates: yearlyInflationRates,
+	}, nil
+}
+
+func checkRewardsSettings(rewardsSettings *config.RewardsSettings) error {
+	if rewardsSettings.LeaderPercentage < 0 || rewardsSettings.LeaderPercentage > 1 {
+		return process.ErrInvalidLeaderPercentage
+	}
+
+	for _, rate := range rewardsSettings.YearlyInflationRates {
+		if rate < 0 || rate > 1 {
+			return process.ErrInvalidInflationRate
+		}
+	}
+
+	return nil
+}
+
+func checkRatingSettings(ratingSettings *config.RatingSettings) error {
+	if ratingSettings.MinRating > ratingSettings.MaxRating {
+		return process.ErrInvalidRatingSettings
+	}
+	if ratingSettings.StartRating < ratingSettings.MinRating || ratingSettings.StartRating > ratingSettings.MaxRating {
+		return process.ErrInvalidRatingSettings
+	}
+	if ratingSettings.ProposerIncreaseRatingStep < 0 ||
+		ratingSettings.SignerIncreaseRatingStep < 0 ||
+		ratingSettings.ProposerDecreaseRatingStep < 0 ||
+		ratingSettings.SlashingDecreaseRatingStep < 0 {
+		return process.ErrInvalidRatingSettings
+	}
+
+	return nil
+}
+
+// MinGasPrice will return the minimum gas price accepted for a transaction
+func (ed *EconomicsData) MinGasPrice() uint64 {
+	return ed.minGasPrice
+}
+
+// MinGasLimit will return the gas consumed by a transaction without data
+func (ed *EconomicsData) MinGasLimit() uint64 {
+	return ed.minGasLimit
+}
+
+// GasPerDataByte will return the gas consumed by each byte of the transaction's data field
+func (ed *EconomicsData) GasPerDataByte() uint64 {
+	return ed.gasPerDataByte
+}
+
+// MinStakeValue will return the minimum value a node has to stake in order to become a validator
+func (ed *EconomicsData) MinStakeValue() *big.Int {
+	return big.NewInt(0).Set(ed.minStakeValue)
+}
+
+// UnBondPeriod will return the number of rounds the stake stays locked after the node was unstaked
+func (ed *EconomicsData) UnBondPeriod() uint64 {
+	return ed.unBondPeriod
+}
+
+// SlashingPercentage will return the part of the stake burnt when a validator signs two different headers in the
+// same round
+func (ed *EconomicsData) SlashingPercentage() float64 {
+	return ed.slashingPercentage
+}
+
+// StartRating will return the rating of a validator which has not been rated yet
+func (ed *EconomicsData) StartRating() int32 {
+	return ed.ratingSettings.StartRating
+}
+
+// MinRating will return the lowest rating a validator can have
+func (ed *EconomicsData) MinRating() int32 {
+	return ed.ratingSettings.MinRating
+}
+
+// MaxRating will return the highest rating a validator can have
+func (ed *EconomicsData) MaxRating() int32 {
+	return ed.ratingSettings.MaxRating
+}
+
+// ProposerIncreaseRatingStep will return the rating gained by the leader of a committed block
+func (ed *EconomicsData) ProposerIncreaseRatingStep() int32 {
+	return ed.ratingSettings.ProposerIncreaseRatingStep
+}
+
+// SignerIncreaseRatingStep will return the rating gained by each signer of a committed block
+func (ed *EconomicsData) SignerIncreaseRatingStep() int32 {
+	return ed.ratingSettings.SignerIncreaseRatingStep
+}
+
+// ProposerDecreaseRatingStep will return the rating lost by a leader which did not propose a block in its round
+func (ed *EconomicsData) ProposerDecreaseRatingStep() int32 {
+	return ed.ratingSettings.ProposerDecreaseRatingStep
+}
+
+// SlashingDecreaseRatingStep will return the rating lost by a validator slashed for signing two different headers
+// in the same round
+func (ed *EconomicsData) SlashingDecreaseRatingStep() int32 {
+	return ed.ratingSettings.SlashingDecreaseRatingStep
+}
+
+// BlockReward will return the value minted for each shard block when no inflation schedule is set
+func (ed *EconomicsData) BlockReward() *big.Int {
+	return big.NewInt(0).Set(ed.blockReward)
+}
+
+// LeaderPercentage will return the part of the block reward given to the block's leader
+func (ed *EconomicsData) LeaderPercentage() float64 {
+	return ed.leaderPercentage
+}
+
+// YearlyInflationRates will return the parts of the genesis total supply minted in each year
+func (ed *EconomicsData) YearlyInflationRates() []float64 {
+	yearlyInflationRates := make([]float64, len(ed.yearlyInflationRates))
+	copy(yearlyInflationRates, ed.yearlyInflationRates)
+
+	return yearlyInflationRates
+}
+
+// ComputeGasLimit returns the gas needed by a transaction that only moves balance
+func (ed *EconomicsData) ComputeGasLimit(tx *transaction.Transaction) uint64 {
+	gasLimit := ed.minGasLimit
+
+	dataLen := uint64(len(tx.Data))
+	gasLimit += dataLen * ed.gasPerDataByte
+
+	return gasLimit
+}
+
+// ComputeFee computes the fee paid by a transaction that only moves balance
+func (ed *EconomicsData) ComputeFee(tx *transaction.Transaction) *big.Int {
+	gasPrice := big.NewInt(0).SetUint64(tx.GasPrice)
+	gasLimit := big.NewInt(0).SetUint64(ed.ComputeGasLimit(tx))
+
+	return gasPrice.Mul(gasPrice, gasLimit)
+}
+
+// CheckValidityTxValues checks if the gas price and gas limit provided in transaction are enough
+func (ed *EconomicsData) CheckValidityTxValues(tx *transaction.Transaction) error {
+	if ed.minGasPrice > tx.GasPrice {
+		return process.ErrInsufficientGasPriceInTx
+	}
+
+	requiredGasLimit := ed.ComputeGasLimit(tx)
+	if requiredGasLimit > tx.GasLimit {
+		return process.ErrInsufficientGasLimitInTx
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package economics_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/config"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
+	"github.com/stretchr/testify/assert"
+)
+
+func createDummyEconomicsConfig() *config.EconomicsConfig {
+	return &config.EconomicsConfig{
+		FeeSettings: config.FeeSettings{
+			MinGasPrice:    10,
+			MinGasLimit:    5,
+			GasPerDataByte: 2,
+		},
+		StakingSettings: config.StakingSettings{
+			MinStakeValue:      "1000",
+			UnBondPeriod:       10,
+			SlashingPercentage: 0.1,
+		},
+		RatingSettings: config.RatingSettings{
+			StartRating:                50,
+			MinRating:                  1,
+			MaxRating:                  100,
+			ProposerIncreaseRatingStep: 2,
+			SignerIncreaseRatingStep:   1,
+			ProposerDecreaseRatingStep: 4,
+			SlashingDecreaseRatingStep: 20,
+		},
+		RewardsSettings: config.RewardsSettings{
+			BlockReward:          "100",
+			LeaderPercentage:     0.4,
+			YearlyInflationRates: []float64{0.1, 0.05},
+		},
+	}
+}
+
+func TestNewEconomicsData_NilConfigShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, err := economics.NewEconomicsData(nil)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrNilEconomicsData, err)
+}
+
+func TestNewEconomicsData_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ed)
+	assert.Equal(t, economicsConfig.FeeSettings.MinGasPrice, ed.MinGasPrice())
+	assert.Equal(t, economicsConfig.FeeSettings.MinGasLimit, ed.MinGasLimit())
+	assert.Equal(t, economicsConfig.FeeSettings.GasPerDataByte, ed.GasPerDataByte())
+	assert.Equal(t, big.NewInt(1000), ed.MinStakeValue())
+	assert.Equal(t, economicsConfig.StakingSettings.UnBondPeriod, ed.UnBondPeriod())
+	assert.Equal(t, economicsConfig.StakingSettings.SlashingPercentage, ed.SlashingPercentage())
+	assert.Equal(t, economicsConfig.RatingSettings.StartRating, ed.StartRating())
+	assert.Equal(t, economicsConfig.RatingSettings.MinRating, ed.MinRating())
+	assert.Equal(t, economicsConfig.RatingSettings.MaxRating, ed.MaxRating())
+	assert.Equal(t, economicsConfig.RatingSettings.ProposerIncreaseRatingStep, ed.ProposerIncreaseRatingStep())
+	assert.Equal(t, economicsConfig.RatingSettings.SignerIncreaseRatingStep, ed.SignerIncreaseRatingStep())
+	assert.Equal(t, economicsConfig.RatingSettings.ProposerDecreaseRatingStep, ed.ProposerDecreaseRatingStep())
+	assert.Equal(t, economicsConfig.RatingSettings.SlashingDecreaseRatingStep, ed.SlashingDecreaseRatingStep())
+	assert.Equal(t, big.NewInt(100), ed.BlockReward())
+	assert.Equal(t, economicsConfig.RewardsSettings.LeaderPercentage, ed.LeaderPercentage())
+	assert.Equal(t, economicsConfig.RewardsSettings.YearlyInflationRates, ed.YearlyInflationRates())
+}
+
+func TestNewEconomicsData_InvalidMinStakeValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.StakingSettings.MinStakeValue = "not a number"
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidMinStakeValue, err)
+}
+
+func TestNewEconomicsData_NegativeMinStakeValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.StakingSettings.MinStakeValue = "-1"
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidMinStakeValue, err)
+}
+
+func TestNewEconomicsData_InvalidSlashingPercentageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.StakingSettings.SlashingPercentage = 1.5
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidSlashingPercentage, err)
+}
+
+func TestNewEconomicsData_MinRatingHigherThanMaxRatingShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.RatingSettings.MinRating = 101
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidRatingSettings, err)
+}
+
+func TestNewEconomicsData_StartRatingOutOfBoundsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.RatingSettings.StartRating = 0
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidRatingSettings, err)
+
+	economicsConfig.RatingSettings.StartRating = 101
+	ed, err = economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidRatingSettings, err)
+}
+
+func TestNewEconomicsData_NegativeRatingStepShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.RatingSettings.ProposerDecreaseRatingStep = -1
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidRatingSettings, err)
+}
+
+func TestNewEconomicsData_InvalidBlockRewardShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.RewardsSettings.BlockReward = "-1"
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidBlockReward, err)
+}
+
+func TestNewEconomicsData_InvalidLeaderPercentageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.RewardsSettings.LeaderPercentage = 1.1
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidLeaderPercentage, err)
+}
+
+func TestNewEconomicsData_InvalidInflationRateShouldErr(t *testing.T) {
+	t.Parallel()
+
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.RewardsSettings.YearlyInflationRates = []float64{0.1, -0.1}
+	ed, err := economics.NewEconomicsData(economicsConfig)
+
+	assert.Nil(t, ed)
+	assert.Equal(t, process.ErrInvalidInflationRate, err)
+}
+
+func TestEconomicsData_ComputeGasLimitShouldAddDataCost(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := economics.NewEconomicsData(createDummyEconomicsConfig())
+	tx := &transaction.Transaction{Data: "12345"}
+
+	assert.Equal(t, uint64(5+5*2), ed.ComputeGasLimit(tx))
+}
+
+func TestEconomicsData_ComputeFeeShouldWork(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := economics.NewEconomicsData(createDummyEconomicsConfig())
+	tx := &transaction.Transaction{
+		GasPrice: 20,
+		Data:     "12345",
+	}
+
+	assert.Equal(t, big.NewInt(20*(5+5*2)), ed.ComputeFee(tx))
+}
+
+func TestEconomicsData_CheckValidityTxValuesLowGasPriceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := economics.NewEconomicsData(createDummyEconomicsConfig())
+	tx := &transaction.Transaction{
+		GasPrice: 9,
+		GasLimit: 100,
+	}
+
+	err := ed.CheckValidityTxValues(tx)
+
+	assert.Equal(t, process.ErrInsufficientGasPriceInTx, err)
+}
+
+func TestEconomicsData_CheckValidityTxValuesLowGasLimitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := economics.NewEconomicsData(createDummyEconomicsConfig())
+	tx := &transaction.Transaction{
+		GasPrice: 10,
+		GasLimit: 14,
+		Data:     "12345",
+	}
+
+	err := ed.CheckValidityTxValues(tx)
+
+	assert.Equal(t, process.ErrInsufficientGasLimitInTx, err)
+}
+
+func TestEconomicsData_CheckValidityTxValuesShouldWork(t *testing.T) {
+	t.Parallel()
+
+	ed, _ := economics.NewEconomicsData(createDummyEconomicsConfig())
+	tx := &transaction.Transaction{
+		GasPrice: 10,
+		GasLimit: 15,
+		Data:     "12345",
+	}
+
+	err := ed.CheckValidityTxValues(tx)
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,75 @@
+package economics
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// feeAccumulator sums up the fees paid by the transactions executed in the current block. Every fee is added to the
+// journal of the accounts, so the fees of the reverted transactions are removed together with their state changes
+type feeAccumulator struct {
+	mutFees         sync.RWMutex
+	accumulatedFees *big.Int
+	accounts        state.AccountsAdapter
+}
+
+// NewFeeAccumulator creates a new fee accumulator
+func NewFeeAccumulator(accounts state.AccountsAdapter) (*feeAccumulator, error) {
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+
+	return &feeAccumulator{
+		accumulatedFees: big.NewInt(0),
+		accounts:        accounts,
+	}, nil
+}
+
+// CreateBlockStarted resets the fees accumulated for the previous block
+func (fa *feeAccumulator) CreateBlockStarted() {
+	fa.mutFees.Lock()
+	fa.accumulatedFees = big.NewInt(0)
+	fa.mutFees.Unlock()
+}
+
+// ProcessTransactionFee adds the fee paid by a transaction to the accumulated value
+func (fa *feeAccumulator) ProcessTransactionFee(cost *big.Int) {
+	if cost == nil || cost.Sign() <= 0 {
+		return
+	}
+
+	fee := big.NewInt(0).Set(cost)
+	fa.addFee(fee)
+	fa.accounts.Journalize(&journalEntryFee{feeAccumulator: fa, fee: fee})
+}
+
+func (fa *feeAccumulator) addFee(fee *big.Int) {
+	fa.mutFees.Lock()
+	fa.accumulatedFees = big.NewInt(0).Add(fa.accumulatedFees, fee)
+	fa.mutFees.Unlock()
+}
+
+// AccumulatedFees returns the fees accumulated since the current block has been started
+func (fa *feeAccumulator) AccumulatedFees() *big.Int {
+	fa.mutFees.RLock()
+	accumulatedFees := big.NewInt(0).Set(fa.accumulatedFees)
+	fa.mutFees.RUnlock()
+
+	return accumulatedFees
+}
+
+// journalEntryFee is used to remove a fee when the accounts are reverted
+type journalEntryFee struct {
+	feeAccumulator *feeAccumulator
+	fee            *big.Int
+}
+
+// Revert applies undo operation
+func (jef *journalEntryFee) Revert() (state.AccountHandler, error) {
+	jef.feeAccumulator.addFee(big.NewInt(0).Neg(jef.fee))
+
+	return nil, nil
+}
@@ -0,0 +1,120 @@
+package economics_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFeeAccumulator_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	fa, err := economics.NewFeeAccumulator(nil)
+
+	assert.Nil(t, fa)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestNewFeeAccumulator_ShouldStartFromZero(t *testing.T) {
+	t.Parallel()
+
+	fa, _ := economics.NewFeeAccumulator(&mock.AccountsStub{})
+
+	assert.NotNil(t, fa)
+	assert.Equal(t, big.NewInt(0), fa.AccumulatedFees())
+}
+
+func TestFeeAccumulator_ProcessTransactionFeeShouldAdd(t *testing.T) {
+	t.Parallel()
+
+	fa, _ := economics.NewFeeAccumulator(&mock.AccountsStub{})
+	fa.ProcessTransactionFee(big.NewInt(10))
+	fa.ProcessTransactionFee(big.NewInt(15))
+
+	assert.Equal(t, big.NewInt(25), fa.AccumulatedFees())
+}
+
+func TestFeeAccumulator_ProcessTransactionFeeNilOrNegativeShouldIgnore(t *testing.T) {
+	t.Parallel()
+
+	fa, _ := economics.NewFeeAccumulator(&mock.AccountsStub{})
+	fa.ProcessTransactionFee(big.NewInt(10))
+	fa.ProcessTransactionFee(nil)
+	fa.ProcessTransactionFee(big.NewInt(-5))
+
+	assert.Equal(t, big.NewInt(10), fa.AccumulatedFees())
+}
+
+func TestFeeAccumulator_CreateBlockStartedShouldReset(t *testing.T) {
+	t.Parallel()
+
+	fa, _ := economics.NewFeeAccumulator(&mock.AccountsStub{})
+	fa.ProcessTransactionFee(big.NewInt(10))
+	fa.CreateBlockStarted()
+
+	assert.Equal(t, big.NewInt(0), fa.AccumulatedFees())
+}
+
+func TestFeeAccumulator_AccumulatedFeesShouldReturnCopy(t *testing.T) {
+	t.Parallel()
+
+	fa, _ := economics.NewFeeAccumulator(&mock.AccountsStub{})
+	fa.ProcessTransactionFee(big.NewInt(10))
+	fees := fa.AccumulatedFees()
+	fees.SetInt64(100)
+
+	assert.Equal(t, big.NewInt(10), fa.AccumulatedFees())
+}
+
+func TestFeeAccumulator_RevertingTheAccountsShouldRemoveTheFees(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]state.JournalEntry, 0)
+	accounts := &mock.AccountsStub{
+		AddJournalEntryCalled: func(je state.JournalEntry) {
+			entries = append(entries, je)
+		},
+	}
+	fa, _ := economics.NewFeeAccumulator(accounts)
+	fa.ProcessTransactionFee(big.NewInt(10))
+	fa.ProcessTransactionFee(big.NewInt(15))
+	fa.ProcessTransactionFee(nil)
+
+	assert.Equal(t, 2, len(entries))
+
+	account, err := entries[1].Revert()
+
+	assert.Nil(t, account)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), fa.AccumulatedFees())
+}
+
+func TestFeeAccumulator_RevertToSnapshotShouldRemoveTheFeesAddedAfterTheSnapshot(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	db, _ := memorydb.New()
+	tr, _ := trie.NewTrie(db, marshalizer, mock.HasherMock{})
+	accounts, _ := state.NewAccountsDB(tr, mock.HasherMock{}, marshalizer, factory.NewAccountCreator(), nil)
+	fa, _ := economics.NewFeeAccumulator(accounts)
+
+	fa.ProcessTransactionFee(big.NewInt(10))
+	snapshot := accounts.JournalLen()
+	fa.ProcessTransactionFee(big.NewInt(15))
+	account, _ := accounts.GetAccountWithJournal(state.NewAddress([]byte("address")))
+	_ = account.(*state.Account).SetBalanceWithJournal(big.NewInt(100))
+	fa.ProcessTransactionFee(big.NewInt(20))
+
+	err := accounts.RevertToSnapshot(snapshot)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), fa.AccumulatedFees())
+}
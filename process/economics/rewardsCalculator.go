@@ -0,0 +1,100 @@
+package economics
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// rewardsPrecision is the number of parts a unit is split into when the reward percentages are applied, so the
+// rewards are computed in the same way by all the nodes, using only integer arithmetic
+const rewardsPrecision = 10000
+
+const millisecondsInYear = 365 * 24 * 60 * 60 * 1000
+
+// rewardsCalculator computes the value minted as reward for each shard block. Without an inflation schedule the
+// configured block reward is used, otherwise the part of the genesis total supply scheduled for the current year
+// is split between all the shard blocks which can be produced in that year
+type rewardsCalculator struct {
+	blockReward          *big.Int
+	leaderPercentage     uint64
+	yearlyInflationRates []uint64
+	genesisTotalSupply   *big.Int
+	roundsPerYear        uint64
+	numOfShards          uint32
+}
+
+// NewRewardsCalculator creates a new rewards calculator. The round duration is given in milliseconds
+func NewRewardsCalculator(
+	rewardSettings process.RewardSettingsHandler,
+	genesisTotalSupply *big.Int,
+	roundDuration uint64,
+	numOfShards uint32,
+) (*rewardsCalculator, error) {
+	if rewardSettings == nil {
+		return nil, process.ErrNilRewardSettings
+	}
+	if genesisTotalSupply == nil {
+		return nil, process.ErrNilGenesisTotalSupply
+	}
+	if roundDuration == 0 || roundDuration > millisecondsInYear {
+		return nil, process.ErrInvalidRoundDuration
+	}
+	if numOfShards == 0 {
+		return nil, process.ErrInvalidNumberOfShards
+	}
+
+	inflationRates := rewardSettings.YearlyInflationRates()
+	yearlyInflationRates := make([]uint64, len(inflationRates))
+	for i, rate := range inflationRates {
+		yearlyInflationRates[i] = toRewardsPrecision(rate)
+	}
+
+	return &rewardsCalculator{
+		blockReward:          rewardSettings.BlockReward(),
+		leaderPercentage:     toRewardsPrecision(rewardSettings.LeaderPercentage()),
+		yearlyInflationRates: yearlyInflationRates,
+		genesisTotalSupply:   big.NewInt(0).Set(genesisTotalSupply),
+		roundsPerYear:        millisecondsInYear / roundDuration,
+		numOfShards:          numOfShards,
+	}, nil
+}
+
+func toRewardsPrecision(value float64) uint64 {
+	return uint64(math.Round(value * rewardsPrecision))
+}
+
+// ComputeBlockReward returns the value minted as reward for a shard block produced in the given round
+func (rc *rewardsCalculator) ComputeBlockReward(round uint64) *big.Int {
+	if len(rc.yearlyInflationRates) == 0 {
+		return big.NewInt(0).Set(rc.blockReward)
+	}
+
+	year := round / rc.roundsPerYear
+	if year >= uint64(len(rc.yearlyInflationRates)) {
+		year = uint64(len(rc.yearlyInflationRates) - 1)
+	}
+
+	blocksPerYear := big.NewInt(0).SetUint64(rc.roundsPerYear * uint64(rc.numOfShards))
+
+	blockReward := big.NewInt(0).SetUint64(rc.yearlyInflationRates[year])
+	blockReward.Mul(blockReward, rc.genesisTotalSupply)
+	blockReward.Div(blockReward, big.NewInt(rewardsPrecision))
+	blockReward.Div(blockReward, blocksPerYear)
+
+	return blockReward
+}
+
+// ComputeLeaderReward returns the part of a block reward which is given to the block's leader
+func (rc *rewardsCalculator) ComputeLeaderReward(blockReward *big.Int) *big.Int {
+	if blockReward == nil {
+		return big.NewInt(0)
+	}
+
+	leaderReward := big.NewInt(0).SetUint64(rc.leaderPercentage)
+	leaderReward.Mul(leaderReward, blockReward)
+	leaderReward.Div(leaderReward, big.NewInt(rewardsPrecision))
+
+	return leaderReward
+}
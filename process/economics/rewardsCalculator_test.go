@@ -0,0 +1,90 @@
+package economics_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundDuration is chosen so a year has exactly 1000 rounds
+const roundDuration = uint64(365 * 24 * 60 * 60)
+
+func createEconomicsData(yearlyInflationRates []float64) *economics.EconomicsData {
+	economicsConfig := createDummyEconomicsConfig()
+	economicsConfig.RewardsSettings.YearlyInflationRates = yearlyInflationRates
+	ed, _ := economics.NewEconomicsData(economicsConfig)
+
+	return ed
+}
+
+func TestNewRewardsCalculator_NilRewardSettingsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rc, err := economics.NewRewardsCalculator(nil, big.NewInt(1000), roundDuration, 2)
+
+	assert.Nil(t, rc)
+	assert.Equal(t, process.ErrNilRewardSettings, err)
+}
+
+func TestNewRewardsCalculator_NilGenesisTotalSupplyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rc, err := economics.NewRewardsCalculator(createEconomicsData(nil), nil, roundDuration, 2)
+
+	assert.Nil(t, rc)
+	assert.Equal(t, process.ErrNilGenesisTotalSupply, err)
+}
+
+func TestNewRewardsCalculator_ZeroRoundDurationShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rc, err := economics.NewRewardsCalculator(createEconomicsData(nil), big.NewInt(1000), 0, 2)
+
+	assert.Nil(t, rc)
+	assert.Equal(t, process.ErrInvalidRoundDuration, err)
+}
+
+func TestNewRewardsCalculator_ZeroShardsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rc, err := economics.NewRewardsCalculator(createEconomicsData(nil), big.NewInt(1000), roundDuration, 0)
+
+	assert.Nil(t, rc)
+	assert.Equal(t, process.ErrInvalidNumberOfShards, err)
+}
+
+func TestRewardsCalculator_ComputeBlockRewardWithoutInflationShouldReturnBlockReward(t *testing.T) {
+	t.Parallel()
+
+	rc, err := economics.NewRewardsCalculator(createEconomicsData(nil), big.NewInt(1000), roundDuration, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), rc.ComputeBlockReward(0))
+	assert.Equal(t, big.NewInt(100), rc.ComputeBlockReward(5000))
+}
+
+func TestRewardsCalculator_ComputeBlockRewardShouldFollowInflationSchedule(t *testing.T) {
+	t.Parallel()
+
+	genesisTotalSupply := big.NewInt(10000000)
+	rc, _ := economics.NewRewardsCalculator(createEconomicsData([]float64{0.1, 0.05}), genesisTotalSupply, roundDuration, 2)
+
+	//10% of the genesis total supply is split between 1000 rounds in each of the 2 shards
+	assert.Equal(t, big.NewInt(500), rc.ComputeBlockReward(0))
+	assert.Equal(t, big.NewInt(500), rc.ComputeBlockReward(999))
+	assert.Equal(t, big.NewInt(250), rc.ComputeBlockReward(1000))
+	//the last rate is used after the schedule ends
+	assert.Equal(t, big.NewInt(250), rc.ComputeBlockReward(5000))
+}
+
+func TestRewardsCalculator_ComputeLeaderRewardShouldApplyLeaderPercentage(t *testing.T) {
+	t.Parallel()
+
+	rc, _ := economics.NewRewardsCalculator(createEconomicsData(nil), big.NewInt(1000), roundDuration, 2)
+
+	assert.Equal(t, big.NewInt(40), rc.ComputeLeaderReward(big.NewInt(100)))
+	assert.Equal(t, big.NewInt(0), rc.ComputeLeaderReward(nil))
+}
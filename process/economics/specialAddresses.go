@@ -0,0 +1,56 @@
+package economics
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+type specialAddresses struct {
+	mutAddresses  sync.RWMutex
+	leaderAddress []byte
+	groupSelector consensus.ValidatorGroupSelector
+}
+
+// NewSpecialAddressHolder creates a special address holder which computes the addresses involved in the
+// fee distribution of a block from the consensus group that produced it
+func NewSpecialAddressHolder(groupSelector consensus.ValidatorGroupSelector) (*specialAddresses, error) {
+	if groupSelector == nil {
+		return nil, process.ErrNilValidatorGroupSelector
+	}
+
+	return &specialAddresses{
+		groupSelector: groupSelector,
+	}, nil
+}
+
+// SetConsensusData computes the consensus group of a round, in the same way the consensus does, and
+// keeps the reward address of its leader
+func (sa *specialAddresses) SetConsensusData(prevRandSeed []byte, round uint64) error {
+	randomSource := fmt.Sprintf("%d-%s", round, core.ToB64(prevRandSeed))
+
+	consensusGroup, err := sa.groupSelector.ComputeValidatorsGroup([]byte(randomSource))
+	if err != nil {
+		return err
+	}
+	if len(consensusGroup) == 0 {
+		return process.ErrEmptyConsensusGroup
+	}
+
+	sa.mutAddresses.Lock()
+	sa.leaderAddress = consensusGroup[0].Address()
+	sa.mutAddresses.Unlock()
+
+	return nil
+}
+
+// LeaderAddress returns the reward address of the leader of the consensus group set last
+func (sa *specialAddresses) LeaderAddress() []byte {
+	sa.mutAddresses.RLock()
+	defer sa.mutAddresses.RUnlock()
+
+	return sa.leaderAddress
+}
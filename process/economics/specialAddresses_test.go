@@ -0,0 +1,82 @@
+package economics_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/economics"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpecialAddressHolder_NilGroupSelectorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sa, err := economics.NewSpecialAddressHolder(nil)
+
+	assert.Nil(t, sa)
+	assert.Equal(t, process.ErrNilValidatorGroupSelector, err)
+}
+
+func TestNewSpecialAddressHolder_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sa, err := economics.NewSpecialAddressHolder(&mock.ValidatorGroupSelectorStub{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sa)
+	assert.Nil(t, sa.LeaderAddress())
+}
+
+func TestSpecialAddresses_SetConsensusDataComputeErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	sa, _ := economics.NewSpecialAddressHolder(&mock.ValidatorGroupSelectorStub{
+		ComputeValidatorsGroupCalled: func(randomness []byte) ([]consensus.Validator, error) {
+			return nil, errExpected
+		},
+	})
+
+	err := sa.SetConsensusData([]byte("rand seed"), 1)
+
+	assert.Equal(t, errExpected, err)
+}
+
+func TestSpecialAddresses_SetConsensusDataEmptyGroupShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sa, _ := economics.NewSpecialAddressHolder(&mock.ValidatorGroupSelectorStub{
+		ComputeValidatorsGroupCalled: func(randomness []byte) ([]consensus.Validator, error) {
+			return make([]consensus.Validator, 0), nil
+		},
+	})
+
+	err := sa.SetConsensusData([]byte("rand seed"), 1)
+
+	assert.Equal(t, process.ErrEmptyConsensusGroup, err)
+}
+
+func TestSpecialAddresses_SetConsensusDataShouldKeepLeaderAddress(t *testing.T) {
+	t.Parallel()
+
+	randomSource := ""
+	sa, _ := economics.NewSpecialAddressHolder(&mock.ValidatorGroupSelectorStub{
+		ComputeValidatorsGroupCalled: func(randomness []byte) ([]consensus.Validator, error) {
+			randomSource = string(randomness)
+			return []consensus.Validator{
+				mock.NewValidatorMock(big.NewInt(0), 0, []byte("pk leader"), []byte("leader address")),
+				mock.NewValidatorMock(big.NewInt(0), 0, []byte("pk validator"), []byte("validator address")),
+			}, nil
+		},
+	})
+
+	err := sa.SetConsensusData([]byte("rand seed"), 7)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "7-cmFuZCBzZWVk", randomSource)
+	assert.Equal(t, []byte("leader address"), sa.LeaderAddress())
+}
@@ -178,6 +178,9 @@ var ErrInvalidSndAddr = errors.New("invalid sender address")
 // ErrNilKeyGen signals that an operation has been attempted to or with a nil single sign key generator
 var ErrNilKeyGen = errors.New("nil key generator")
 
+// ErrNilCommittedAccountsProvider signals that a nil committed accounts provider has been provided
+var ErrNilCommittedAccountsProvider = errors.New("nil committed accounts provider")
+
 // ErrNilSingleSigner signals that a nil single signer is used
 var ErrNilSingleSigner = errors.New("nil single signer")
 
@@ -229,6 +232,15 @@ var ErrNilMiniBlockPool = errors.New("nil mini block pool")
 // ErrNilMetaBlockPool signals that a nil meta blocks pool was used
 var ErrNilMetaBlockPool = errors.New("nil meta block pool")
 
+// ErrNilTrieNodesPool signals that a nil trie nodes pool was used
+var ErrNilTrieNodesPool = errors.New("nil trie nodes pool")
+
+// ErrNilTrieStorage signals that a nil trie storage has been provided
+var ErrNilTrieStorage = errors.New("nil trie storage")
+
+// ErrNilLeafReferencesExtractor signals that a nil leaf references extractor has been provided
+var ErrNilLeafReferencesExtractor = errors.New("nil leaf references extractor")
+
 // ErrNilTxProcessor signals that a nil transactions processor was used
 var ErrNilTxProcessor = errors.New("nil transactions processor")
 
@@ -417,3 +429,188 @@ var ErrNilHeaderHandlerValidator = errors.New("nil header handler validator prov
 
 // ErrNilAppStatusHandler defines the error for setting a nil AppStatusHandler
 var ErrNilAppStatusHandler = errors.New("nil AppStatusHandler")
+
+// ErrNilEconomicsFeeHandler signals that fee handler is nil
+var ErrNilEconomicsFeeHandler = errors.New("nil economics fee handler")
+
+// ErrNilTxFeeHandler signals that the transaction fee handler is nil
+var ErrNilTxFeeHandler = errors.New("nil transaction fee handler")
+
+// ErrNilReceiptsHandler signals that the receipts handler is nil
+var ErrNilReceiptsHandler = errors.New("nil receipts handler")
+
+// ErrNilSmartContractLogsHandler signals that the smart contract logs handler is nil
+var ErrNilSmartContractLogsHandler = errors.New("nil smart contract logs handler")
+
+// ErrNilSpecialAddressHandler signals that the special address handler is nil
+var ErrNilSpecialAddressHandler = errors.New("nil special address handler")
+
+// ErrNilValidatorGroupSelector signals that a nil validator group selector has been provided
+var ErrNilValidatorGroupSelector = errors.New("nil validator group selector")
+
+// ErrInsufficientGasPriceInTx signals that a lower gas price than required was provided
+var ErrInsufficientGasPriceInTx = errors.New("insufficient gas price in tx")
+
+// ErrInsufficientGasLimitInTx signals that a lower gas limit than required was provided
+var ErrInsufficientGasLimitInTx = errors.New("insufficient gas limit in tx")
+
+// ErrEmptyConsensusGroup signals that an empty consensus group was computed
+var ErrEmptyConsensusGroup = errors.New("empty consensus group")
+
+// ErrNilEconomicsData signals that nil economics data has been provided
+var ErrNilEconomicsData = errors.New("nil economics data")
+
+// ErrNilEpochHandler signals that a nil epoch handler has been provided
+var ErrNilEpochHandler = errors.New("nil epoch handler")
+
+// ErrEpochDoesNotMatch signals that the epoch of a block is not the expected one
+var ErrEpochDoesNotMatch = errors.New("epoch does not match")
+
+// ErrEpochStartDataDoesNotMatch signals that the epoch start data of a meta block is not the expected one
+var ErrEpochStartDataDoesNotMatch = errors.New("epoch start data does not match")
+
+// ErrMissingEpochStartMetaBlock signals that the meta block which started the epoch of a shard block is missing
+var ErrMissingEpochStartMetaBlock = errors.New("missing epoch start meta block")
+
+// ErrInvalidMinStakeValue signals that an invalid minimum stake value has been provided
+var ErrInvalidMinStakeValue = errors.New("invalid minimum stake value")
+
+// ErrNilStakingHandler signals that a nil staking handler has been provided
+var ErrNilStakingHandler = errors.New("nil staking handler")
+
+// ErrNilStakingSettings signals that nil staking settings have been provided
+var ErrNilStakingSettings = errors.New("nil staking settings")
+
+// ErrNilStakingAccount signals that the staking account is not available in the current shard
+var ErrNilStakingAccount = errors.New("nil staking account")
+
+// ErrInvalidStakingData signals that the data field or the value of a staking transaction is invalid
+var ErrInvalidStakingData = errors.New("invalid staking data")
+
+// ErrInsufficientStake signals that the value of a stake transaction is lower than the minimum stake value
+var ErrInsufficientStake = errors.New("insufficient stake")
+
+// ErrNodeAlreadyStaked signals that the node has already been staked
+var ErrNodeAlreadyStaked = errors.New("node already staked")
+
+// ErrInvalidOwnerSignature signals that the staking transaction does not prove that its sender holds the staked key
+var ErrInvalidOwnerSignature = errors.New("invalid owner signature of the staked key")
+
+// ErrNodeNotStaked signals that the node has not been staked
+var ErrNodeNotStaked = errors.New("node not staked")
+
+// ErrNodeAlreadyUnStaked signals that the node has already been unstaked
+var ErrNodeAlreadyUnStaked = errors.New("node already unstaked")
+
+// ErrNodeNotUnStaked signals that the stake of a node can not be released before the node is unstaked
+var ErrNodeNotUnStaked = errors.New("node not unstaked")
+
+// ErrNotStakeOwner signals that the sender of a staking transaction is not the owner of the stake
+var ErrNotStakeOwner = errors.New("sender is not the owner of the stake")
+
+// ErrUnBondPeriodNotPassed signals that the stake can not be released yet as the unbond period has not passed
+var ErrUnBondPeriodNotPassed = errors.New("unbond period has not passed")
+
+// ErrNilSlashingProofVerifier signals that a nil slashing proof verifier has been provided
+var ErrNilSlashingProofVerifier = errors.New("nil slashing proof verifier")
+
+// ErrNodeAlreadySlashed signals that the offence proven by a slashing proof has already been slashed
+var ErrNodeAlreadySlashed = errors.New("node already slashed for this offence")
+
+// ErrInvalidSlashingPercentage signals that an invalid slashing percentage has been provided
+var ErrInvalidSlashingPercentage = errors.New("invalid slashing percentage")
+
+// ErrPeerInfoDoesNotMatch signals that the peer info of a block is not the expected one
+var ErrPeerInfoDoesNotMatch = errors.New("peer info does not match")
+
+// ErrInvalidRatingSettings signals that the rating settings are not consistent
+var ErrInvalidRatingSettings = errors.New("invalid rating settings")
+
+// ErrNilRatingsHandler signals that a nil ratings handler has been provided
+var ErrNilRatingsHandler = errors.New("nil ratings handler")
+
+// ErrNilRatingSettings signals that nil rating settings have been provided
+var ErrNilRatingSettings = errors.New("nil rating settings")
+
+// ErrNilRatingsAccount signals that the account holding the ratings of the validators could not be loaded
+var ErrNilRatingsAccount = errors.New("nil ratings account")
+
+// ErrInvalidBlockReward signals that an invalid block reward value has been provided
+var ErrInvalidBlockReward = errors.New("invalid block reward")
+
+// ErrInvalidLeaderPercentage signals that the leader percentage is not between 0 and 1
+var ErrInvalidLeaderPercentage = errors.New("invalid leader percentage")
+
+// ErrInvalidInflationRate signals that an inflation rate is not between 0 and 1
+var ErrInvalidInflationRate = errors.New("invalid inflation rate")
+
+// ErrNilRewardSettings signals that nil reward settings have been provided
+var ErrNilRewardSettings = errors.New("nil reward settings")
+
+// ErrNilGenesisTotalSupply signals that a nil genesis total supply has been provided
+var ErrNilGenesisTotalSupply = errors.New("nil genesis total supply")
+
+// ErrInvalidRoundDuration signals that an invalid round duration has been provided
+var ErrInvalidRoundDuration = errors.New("invalid round duration")
+
+// ErrInvalidNumberOfShards signals that an invalid number of shards has been provided
+var ErrInvalidNumberOfShards = errors.New("invalid number of shards")
+
+// ErrNilRewardsCalculator signals that a nil rewards calculator has been provided
+var ErrNilRewardsCalculator = errors.New("nil rewards calculator")
+
+// ErrNilRewardsHandler signals that a nil rewards handler has been provided
+var ErrNilRewardsHandler = errors.New("nil rewards handler")
+
+// ErrRewardMiniBlockDoesNotMatch signals that the reward miniblock of a block is not the expected one
+var ErrRewardMiniBlockDoesNotMatch = errors.New("reward miniblock does not match")
+
+// ErrRewardsDoNotMatch signals that the rewards minted by a block are not the expected ones
+var ErrRewardsDoNotMatch = errors.New("rewards do not match")
+
+// ErrNilTotalSupplyHandler signals that a nil total supply handler has been provided
+var ErrNilTotalSupplyHandler = errors.New("nil total supply handler")
+
+// ErrNilTotalSupplyAccount signals that the account holding the total supply could not be loaded
+var ErrNilTotalSupplyAccount = errors.New("nil total supply account")
+
+// ErrNilCommitJournal signals that a nil commit journal has been provided
+var ErrNilCommitJournal = errors.New("nil commit journal")
+
+// ErrInvalidNumJournalEntries signals that the number of journal entries to keep is lower than one
+var ErrInvalidNumJournalEntries = errors.New("invalid number of journal entries to keep")
+
+// ErrAccountNotLoaded signals that a transaction executed in a group accessed an account which was not loaded
+// before the execution of the group
+var ErrAccountNotLoaded = errors.New("account not loaded before the execution of the group")
+
+// ErrOperationNotSupported signals that the operation is not supported by the accounts of a transactions group
+var ErrOperationNotSupported = errors.New("operation not supported by the accounts of a transactions group")
+
+// ErrInvalidMaxDataLength signals that an invalid maximum length of the transaction data field has been provided
+var ErrInvalidMaxDataLength = errors.New("invalid maximum data length")
+
+// ErrDataFieldTooLong signals that the data field of the transaction is longer than the allowed maximum
+var ErrDataFieldTooLong = errors.New("transaction data field too long")
+
+// ErrInvalidRelayedTxData signals that the data field of a relayed transaction does not hold an inner transaction
+var ErrInvalidRelayedTxData = errors.New("invalid data field of the relayed transaction")
+
+// ErrNestedRelayedTx signals that the inner transaction of a relayed transaction is also a relayed transaction
+var ErrNestedRelayedTx = errors.New("inner transaction can not be a relayed transaction")
+
+// ErrRelayedTxReceiverMismatch signals that the receiver of a relayed transaction is not the inner transaction sender
+var ErrRelayedTxReceiverMismatch = errors.New("relayed transaction receiver is not the inner transaction sender")
+
+// ErrRelayedTxValueMismatch signals that the value of a relayed transaction is not the value and the maximum fee of
+// its inner transaction
+var ErrRelayedTxValueMismatch = errors.New("relayed transaction value does not match the inner transaction cost")
+
+// ErrRelayedTxInnerCrossShard signals that the inner transaction of a relayed transaction is not intra shard
+var ErrRelayedTxInnerCrossShard = errors.New("inner transaction of a relayed transaction is not intra shard")
+
+// ErrInvalidChainID signals that the transaction was signed for another chain or that an invalid chain ID was provided
+var ErrInvalidChainID = errors.New("invalid chain ID")
+
+// ErrInvalidTransactionVersion signals that the transaction version is not supported
+var ErrInvalidTransactionVersion = errors.New("invalid transaction version")
@@ -15,6 +15,8 @@ const (
 	MetachainBlocksTopic = "metachainBlocks"
 	// ShardHeadersForMetachainTopic is used for sharing shards block headers to the metachain nodes
 	ShardHeadersForMetachainTopic = "shardHeadersForMetachain"
+	// TrieNodesTopic is used for sharing the state trie nodes with the nodes syncing the state
+	TrieNodesTopic = "trieNodes"
 )
 
 const (
@@ -8,7 +8,6 @@ import (
 	"github.com/ElrondNetwork/elrond-go/marshal"
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/block/interceptors"
-	"github.com/ElrondNetwork/elrond-go/process/dataValidators"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
 	"github.com/ElrondNetwork/elrond-go/process/factory/containers"
 	"github.com/ElrondNetwork/elrond-go/sharding"
@@ -23,6 +22,7 @@ type interceptorsContainerFactory struct {
 	messenger           process.TopicHandler
 	multiSigner         crypto.MultiSigner
 	chronologyValidator process.ChronologyValidator
+	headerValidator     process.HeaderValidator
 	tpsBenchmark        *statistics.TpsBenchmark
 }
 
@@ -36,6 +36,7 @@ func NewInterceptorsContainerFactory(
 	multiSigner crypto.MultiSigner,
 	dataPool dataRetriever.MetaPoolsHolder,
 	chronologyValidator process.ChronologyValidator,
+	headerValidator process.HeaderValidator,
 ) (*interceptorsContainerFactory, error) {
 
 	if shardCoordinator == nil {
@@ -62,6 +63,9 @@ func NewInterceptorsContainerFactory(
 	if chronologyValidator == nil {
 		return nil, process.ErrNilChronologyValidator
 	}
+	if headerValidator == nil {
+		return nil, process.ErrNilHeaderHandlerValidator
+	}
 
 	return &interceptorsContainerFactory{
 		shardCoordinator:    shardCoordinator,
@@ -72,6 +76,7 @@ func NewInterceptorsContainerFactory(
 		multiSigner:         multiSigner,
 		dataPool:            dataPool,
 		chronologyValidator: chronologyValidator,
+		headerValidator:     headerValidator,
 	}, nil
 }
 
@@ -119,18 +124,11 @@ func (icf *interceptorsContainerFactory) createTopicAndAssignHandler(
 func (icf *interceptorsContainerFactory) generateMetablockInterceptor() ([]string, []process.Interceptor, error) {
 	identifierHdr := factory.MetachainBlocksTopic
 
-	//TODO implement other HeaderHandlerProcessValidator that will check the header's nonce
-	// against blockchain's latest nonce - k finality
-	hdrValidator, err := dataValidators.NewNilHeaderValidator()
-	if err != nil {
-		return nil, nil, err
-	}
-
 	interceptor, err := interceptors.NewMetachainHeaderInterceptor(
 		icf.marshalizer,
 		icf.dataPool.MetaChainBlocks(),
 		icf.dataPool.HeadersNonces(),
-		hdrValidator,
+		icf.headerValidator,
 		icf.multiSigner,
 		icf.hasher,
 		icf.shardCoordinator,
@@ -172,18 +170,11 @@ func (icf *interceptorsContainerFactory) generateShardHeaderInterceptors() ([]st
 }
 
 func (icf *interceptorsContainerFactory) createOneShardHeaderInterceptor(identifier string) (process.Interceptor, error) {
-	//TODO implement other HeaderHandlerProcessValidator that will check the header's nonce
-	// against blockchain's latest nonce - k finality
-	hdrValidator, err := dataValidators.NewNilHeaderValidator()
-	if err != nil {
-		return nil, err
-	}
-
 	interceptor, err := interceptors.NewHeaderInterceptor(
 		icf.marshalizer,
 		icf.dataPool.ShardHeaders(),
 		icf.dataPool.HeadersNonces(),
-		hdrValidator,
+		icf.headerValidator,
 		icf.multiSigner,
 		icf.hasher,
 		icf.shardCoordinator,
@@ -85,6 +85,7 @@ func TestNewInterceptorsContainerFactory_NilShardCoordinatorShouldErr(t *testing
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.Nil(t, icf)
@@ -103,6 +104,7 @@ func TestNewInterceptorsContainerFactory_NilTopicHandlerShouldErr(t *testing.T)
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.Nil(t, icf)
@@ -121,6 +123,7 @@ func TestNewInterceptorsContainerFactory_NilBlockchainShouldErr(t *testing.T) {
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.Nil(t, icf)
@@ -139,6 +142,7 @@ func TestNewInterceptorsContainerFactory_NilMarshalizerShouldErr(t *testing.T) {
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.Nil(t, icf)
@@ -157,6 +161,7 @@ func TestNewInterceptorsContainerFactory_NilHasherShouldErr(t *testing.T) {
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.Nil(t, icf)
@@ -175,6 +180,7 @@ func TestNewInterceptorsContainerFactory_NilMultiSignerShouldErr(t *testing.T) {
 		nil,
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.Nil(t, icf)
@@ -193,12 +199,32 @@ func TestNewInterceptorsContainerFactory_NilDataPoolShouldErr(t *testing.T) {
 		mock.NewMultiSigner(),
 		nil,
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.Nil(t, icf)
 	assert.Equal(t, process.ErrNilDataPoolHolder, err)
 }
 
+func TestNewInterceptorsContainerFactory_NilHeaderValidatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, err := metachain.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		&mock.TopicHandlerStub{},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.ChronologyValidatorStub{},
+		nil,
+	)
+
+	assert.Nil(t, icf)
+	assert.Equal(t, process.ErrNilHeaderHandlerValidator, err)
+}
+
 func TestNewInterceptorsContainerFactory_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -211,6 +237,7 @@ func TestNewInterceptorsContainerFactory_ShouldWork(t *testing.T) {
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	assert.NotNil(t, icf)
@@ -231,6 +258,7 @@ func TestInterceptorsContainerFactory_CreateTopicMetablocksFailsShouldErr(t *tes
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	container, err := icf.Create()
@@ -251,6 +279,7 @@ func TestInterceptorsContainerFactory_CreateTopicShardHeadersForMetachainFailsSh
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	container, err := icf.Create()
@@ -271,6 +300,7 @@ func TestInterceptorsContainerFactory_CreateRegisterForMetablocksFailsShouldErr(
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	container, err := icf.Create()
@@ -291,6 +321,7 @@ func TestInterceptorsContainerFactory_CreateRegisterShardHeadersForMetachainFail
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	container, err := icf.Create()
@@ -318,6 +349,7 @@ func TestInterceptorsContainerFactory_CreateShouldWork(t *testing.T) {
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	container, err := icf.Create()
@@ -351,6 +383,7 @@ func TestInterceptorsContainerFactory_With4ShardsShouldWork(t *testing.T) {
 		mock.NewMultiSigner(),
 		createDataPools(),
 		&mock.ChronologyValidatorStub{},
+		&mock.HeaderValidatorStub{},
 	)
 
 	container, _ := icf.Create()
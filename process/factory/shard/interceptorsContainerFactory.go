@@ -8,7 +8,6 @@ import (
 	"github.com/ElrondNetwork/elrond-go/marshal"
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/block/interceptors"
-	"github.com/ElrondNetwork/elrond-go/process/dataValidators"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
 	"github.com/ElrondNetwork/elrond-go/process/factory/containers"
 	"github.com/ElrondNetwork/elrond-go/process/transaction"
@@ -28,6 +27,9 @@ type interceptorsContainerFactory struct {
 	dataPool            dataRetriever.PoolsHolder
 	addrConverter       state.AddressConverter
 	chronologyValidator process.ChronologyValidator
+	txValidator         process.TxValidator
+	headerValidator     process.HeaderValidator
+	chainID             []byte
 }
 
 // NewInterceptorsContainerFactory is responsible for creating a new interceptors factory object
@@ -43,6 +45,9 @@ func NewInterceptorsContainerFactory(
 	dataPool dataRetriever.PoolsHolder,
 	addrConverter state.AddressConverter,
 	chronologyValidator process.ChronologyValidator,
+	txValidator process.TxValidator,
+	headerValidator process.HeaderValidator,
+	chainID []byte,
 ) (*interceptorsContainerFactory, error) {
 
 	if shardCoordinator == nil {
@@ -78,6 +83,15 @@ func NewInterceptorsContainerFactory(
 	if chronologyValidator == nil {
 		return nil, process.ErrNilChronologyValidator
 	}
+	if txValidator == nil {
+		return nil, process.ErrNilTxHandlerValidator
+	}
+	if headerValidator == nil {
+		return nil, process.ErrNilHeaderHandlerValidator
+	}
+	if len(chainID) == 0 {
+		return nil, process.ErrInvalidChainID
+	}
 
 	return &interceptorsContainerFactory{
 		shardCoordinator:    shardCoordinator,
@@ -91,6 +105,9 @@ func NewInterceptorsContainerFactory(
 		dataPool:            dataPool,
 		addrConverter:       addrConverter,
 		chronologyValidator: chronologyValidator,
+		txValidator:         txValidator,
+		headerValidator:     headerValidator,
+		chainID:             chainID,
 	}, nil
 }
 
@@ -158,6 +175,16 @@ func (icf *interceptorsContainerFactory) Create() (process.InterceptorsContainer
 		return nil, err
 	}
 
+	keys, interceptorSlice, err = icf.generateTrieNodesInterceptor()
+	if err != nil {
+		return nil, err
+	}
+
+	err = container.AddMultiple(keys, interceptorSlice)
+	if err != nil {
+		return nil, err
+	}
+
 	return container, nil
 }
 
@@ -211,21 +238,16 @@ func (icf *interceptorsContainerFactory) generateTxInterceptors() ([]string, []p
 }
 
 func (icf *interceptorsContainerFactory) createOneTxInterceptor(identifier string) (process.Interceptor, error) {
-	//TODO implement other TxHandlerProcessValidator that will check the tx nonce against account's nonce
-	txValidator, err := dataValidators.NewNilTxValidator()
-	if err != nil {
-		return nil, err
-	}
-
 	interceptor, err := transaction.NewTxInterceptor(
 		icf.marshalizer,
 		icf.dataPool.Transactions(),
-		txValidator,
+		icf.txValidator,
 		icf.addrConverter,
 		icf.hasher,
 		icf.singleSigner,
 		icf.keyGen,
-		icf.shardCoordinator)
+		icf.shardCoordinator,
+		icf.chainID)
 
 	if err != nil {
 		return nil, err
@@ -290,12 +312,6 @@ func (icf *interceptorsContainerFactory) createOneUnsignedTxInterceptor(identifi
 
 func (icf *interceptorsContainerFactory) generateHdrInterceptor() ([]string, []process.Interceptor, error) {
 	shardC := icf.shardCoordinator
-	//TODO implement other HeaderHandlerProcessValidator that will check the header's nonce
-	// against blockchain's latest nonce - k finality
-	hdrValidator, err := dataValidators.NewNilHeaderValidator()
-	if err != nil {
-		return nil, nil, err
-	}
 
 	//only one intrashard header topic
 	identifierHdr := factory.HeadersTopic + shardC.CommunicationIdentifier(shardC.SelfId())
@@ -303,7 +319,7 @@ func (icf *interceptorsContainerFactory) generateHdrInterceptor() ([]string, []p
 		icf.marshalizer,
 		icf.dataPool.Headers(),
 		icf.dataPool.HeadersNonces(),
-		hdrValidator,
+		icf.headerValidator,
 		icf.multiSigner,
 		icf.hasher,
 		icf.shardCoordinator,
@@ -392,18 +408,11 @@ func (icf *interceptorsContainerFactory) generatePeerChBlockBodyInterceptor() ([
 
 func (icf *interceptorsContainerFactory) generateMetachainHeaderInterceptor() ([]string, []process.Interceptor, error) {
 	identifierHdr := factory.MetachainBlocksTopic
-	//TODO implement other HeaderHandlerProcessValidator that will check the header's nonce
-	// against blockchain's latest nonce - k finality
-	hdrValidator, err := dataValidators.NewNilHeaderValidator()
-	if err != nil {
-		return nil, nil, err
-	}
-
 	interceptor, err := interceptors.NewMetachainHeaderInterceptor(
 		icf.marshalizer,
 		icf.dataPool.MetaBlocks(),
 		icf.dataPool.HeadersNonces(),
-		hdrValidator,
+		icf.headerValidator,
 		icf.multiSigner,
 		icf.hasher,
 		icf.shardCoordinator,
@@ -419,3 +428,27 @@ func (icf *interceptorsContainerFactory) generateMetachainHeaderInterceptor() ([
 
 	return []string{identifierHdr}, []process.Interceptor{interceptor}, nil
 }
+
+//------- TrieNodes interceptor
+
+func (icf *interceptorsContainerFactory) generateTrieNodesInterceptor() ([]string, []process.Interceptor, error) {
+	shardC := icf.shardCoordinator
+
+	//only one intrashard trie nodes topic
+	identifierTrieNodes := factory.TrieNodesTopic + shardC.CommunicationIdentifier(shardC.SelfId())
+
+	interceptor, err := interceptors.NewTrieNodesInterceptor(
+		icf.marshalizer,
+		icf.hasher,
+		icf.dataPool.TrieNodes(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = icf.createTopicAndAssignHandler(identifierTrieNodes, interceptor, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []string{identifierTrieNodes}, []process.Interceptor{interceptor}, nil
+}
@@ -17,6 +17,8 @@ import (
 
 var errExpected = errors.New("expected error")
 
+var chainID = []byte("chain ID")
+
 func createStubTopicHandler(matchStrToErrOnCreate string, matchStrToErrOnRegister string) process.TopicHandler {
 	return &mock.TopicHandlerStub{
 		CreateTopicCalled: func(name string, createChannelForTopic bool) error {
@@ -62,6 +64,9 @@ func createDataPools() dataRetriever.PoolsHolder {
 	pools.MetaBlocksCalled = func() storage.Cacher {
 		return &mock.CacherStub{}
 	}
+	pools.TrieNodesCalled = func() storage.Cacher {
+		return &mock.CacherStub{}
+	}
 	pools.UnsignedTransactionsCalled = func() dataRetriever.ShardedDataCacherNotifier {
 		return &mock.ShardedDataStub{}
 	}
@@ -93,6 +98,9 @@ func TestNewInterceptorsContainerFactory_NilShardCoordinatorShouldErr(t *testing
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -114,6 +122,9 @@ func TestNewInterceptorsContainerFactory_NilTopicHandlerShouldErr(t *testing.T)
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -135,6 +146,9 @@ func TestNewInterceptorsContainerFactory_NilBlockchainShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -156,6 +170,9 @@ func TestNewInterceptorsContainerFactory_NilMarshalizerShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -177,6 +194,9 @@ func TestNewInterceptorsContainerFactory_NilHasherShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -198,6 +218,9 @@ func TestNewInterceptorsContainerFactory_NilKeyGenShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -219,6 +242,9 @@ func TestNewInterceptorsContainerFactory_NilSingleSignerShouldErr(t *testing.T)
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -240,6 +266,9 @@ func TestNewInterceptorsContainerFactory_NilMultiSignerShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -261,6 +290,9 @@ func TestNewInterceptorsContainerFactory_NilDataPoolShouldErr(t *testing.T) {
 		nil,
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
@@ -282,12 +314,87 @@ func TestNewInterceptorsContainerFactory_NilAddrConverterShouldErr(t *testing.T)
 		createDataPools(),
 		nil,
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.Nil(t, icf)
 	assert.Equal(t, process.ErrNilAddressConverter, err)
 }
 
+func TestNewInterceptorsContainerFactory_NilTxValidatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, err := shard.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		&mock.TopicHandlerStub{},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AddressConverterMock{},
+		&mock.ChronologyValidatorStub{},
+		nil,
+		&mock.HeaderValidatorStub{},
+		chainID,
+	)
+
+	assert.Nil(t, icf)
+	assert.Equal(t, process.ErrNilTxHandlerValidator, err)
+}
+
+func TestNewInterceptorsContainerFactory_NilHeaderValidatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, err := shard.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		&mock.TopicHandlerStub{},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AddressConverterMock{},
+		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		nil,
+		chainID,
+	)
+
+	assert.Nil(t, icf)
+	assert.Equal(t, process.ErrNilHeaderHandlerValidator, err)
+}
+
+func TestNewInterceptorsContainerFactory_EmptyChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, err := shard.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		&mock.TopicHandlerStub{},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AddressConverterMock{},
+		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		nil,
+	)
+
+	assert.Nil(t, icf)
+	assert.Equal(t, process.ErrInvalidChainID, err)
+}
+
 func TestNewInterceptorsContainerFactory_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -303,6 +410,9 @@ func TestNewInterceptorsContainerFactory_ShouldWork(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	assert.NotNil(t, icf)
@@ -326,6 +436,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationTxFailsShouldErr(t *tes
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -349,6 +462,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationHdrFailsShouldErr(t *te
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -372,6 +488,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationMiniBlocksFailsShouldEr
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -395,6 +514,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationPeerChBlocksFailsShould
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -418,6 +540,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationMetachainHeadersFailsSh
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -441,6 +566,9 @@ func TestInterceptorsContainerFactory_CreateRegisterTxFailsShouldErr(t *testing.
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -464,6 +592,9 @@ func TestInterceptorsContainerFactory_CreateRegisterHdrFailsShouldErr(t *testing
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -487,6 +618,9 @@ func TestInterceptorsContainerFactory_CreateRegisterMiniBlocksFailsShouldErr(t *
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -510,6 +644,35 @@ func TestInterceptorsContainerFactory_CreateRegisterPeerChBlocksFailsShouldErr(t
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
+	)
+
+	container, err := icf.Create()
+
+	assert.Nil(t, container)
+	assert.Equal(t, errExpected, err)
+}
+
+func TestInterceptorsContainerFactory_CreateRegisterTrieNodesFailsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, _ := shard.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		createStubTopicHandler("", factory.TrieNodesTopic),
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AddressConverterMock{},
+		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -533,6 +696,9 @@ func TestInterceptorsContainerFactory_CreateRegisterMetachainHeadersShouldErr(t
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -563,6 +729,9 @@ func TestInterceptorsContainerFactory_CreateShouldWork(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, err := icf.Create()
@@ -599,6 +768,9 @@ func TestInterceptorsContainerFactory_With4ShardsShouldWork(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.TxValidatorStub{},
+		&mock.HeaderValidatorStub{},
+		chainID,
 	)
 
 	container, _ := icf.Create()
@@ -608,8 +780,9 @@ func TestInterceptorsContainerFactory_With4ShardsShouldWork(t *testing.T) {
 	numInterceptorMiniBlocks := noOfShards
 	numInterceptorPeerChanges := 1
 	numInterceptorMetachainHeaders := 1
+	numInterceptorTrieNodes := 1
 	totalInterceptors := numInterceptorTxs + numInterceptorHeaders + numInterceptorMiniBlocks +
-		numInterceptorPeerChanges + numInterceptorMetachainHeaders + numInterceptorTxs
+		numInterceptorPeerChanges + numInterceptorMetachainHeaders + numInterceptorTxs + numInterceptorTrieNodes
 
 	assert.Equal(t, totalInterceptors, container.Len())
 }
@@ -6,9 +6,11 @@ import (
 
 	"github.com/ElrondNetwork/elrond-go/data"
 	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/p2p"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract/hooks"
 	"github.com/ElrondNetwork/elrond-go/sharding"
@@ -21,6 +23,20 @@ type TransactionProcessor interface {
 	ProcessTransaction(transaction *transaction.Transaction, round uint64) error
 }
 
+// ParallelTransactionProcessor is a transaction processor which can be copied to execute transactions against
+// another accounts adapter, so that independent transactions are executed concurrently
+type ParallelTransactionProcessor interface {
+	TransactionProcessor
+	CopyWithAccounts(accounts state.AccountsAdapter) (TransactionProcessorCopy, error)
+}
+
+// TransactionProcessorCopy is a transaction processor executing transactions against another accounts adapter. The
+// fees and the receipts of the executed transactions are added to the ones of the block only when merged
+type TransactionProcessorCopy interface {
+	TransactionProcessor
+	MergeFeesAndReceipts()
+}
+
 // SmartContractResultProcessor is the main interface for smart contract result execution engine
 type SmartContractResultProcessor interface {
 	ProcessSmartContractResult(scr *smartContractResult.SmartContractResult) error
@@ -36,6 +52,11 @@ type TxValidator interface {
 	IsTxValidForProcessing(txHandler data.TransactionHandler) bool
 }
 
+// CommittedAccountsProvider provides the accounts found in the state of the last committed block
+type CommittedAccountsProvider interface {
+	GetExistingAccount(address state.AddressContainer) (state.AccountHandler, error)
+}
+
 // HeaderValidator can determine if a provided header handler is valid or not from the process point of view
 type HeaderValidator interface {
 	IsHeaderValidForProcessing(headerHandler data.HeaderHandler) bool
@@ -56,6 +77,7 @@ type TransactionCoordinator interface {
 	CreateBlockStarted()
 	CreateMbsAndProcessCrossShardTransactionsDstMe(header data.HeaderHandler, maxTxSpaceRemained uint32, maxMbSpaceRemained uint32, round uint64, haveTime func() bool) (block.MiniBlockSlice, uint32, bool)
 	CreateMbsAndProcessTransactionsFromMe(maxTxSpaceRemained uint32, maxMbSpaceRemained uint32, round uint64, haveTime func() bool) block.MiniBlockSlice
+	CreatePostProcessMiniBlocks() block.MiniBlockSlice
 
 	CreateMarshalizedData(body block.Body) (map[uint32]block.MiniBlockSlice, map[string][][]byte)
 
@@ -108,12 +130,14 @@ type BlockProcessor interface {
 	CommitBlock(blockChain data.ChainHandler, header data.HeaderHandler, body data.BodyHandler) error
 	RevertAccountState()
 	CreateBlockBody(round uint64, haveTime func() bool) (data.BodyHandler, error)
+	SetConsensusData(prevHeader data.HeaderHandler, round uint64)
 	RestoreBlockIntoPools(header data.HeaderHandler, body data.BodyHandler) error
 	CreateBlockHeader(body data.BodyHandler, round uint64, haveTime func() bool) (data.HeaderHandler, error)
 	MarshalizedDataToBroadcast(header data.HeaderHandler, body data.BodyHandler) (map[uint32][]byte, map[string][][]byte, error)
 	DecodeBlockBody(dta []byte) data.BodyHandler
 	DecodeBlockHeader(dta []byte) data.HeaderHandler
 	AddLastNotarizedHdr(shardId uint32, processedHdr data.HeaderHandler)
+	RestoreEpochState(header data.HeaderHandler) error
 }
 
 // Checker provides functionality to checks the integrity and validity of a data structure
@@ -157,6 +181,12 @@ type Bootstrapper interface {
 	StartSync()
 }
 
+// StateSyncer is an interface that defines the behaviour of a struct that is able to synchronize the state of
+// the node without executing the blocks
+type StateSyncer interface {
+	SyncState() error
+}
+
 // ForkDetector is an interface that defines the behaviour of a struct that is able
 // to detect forks
 type ForkDetector interface {
@@ -315,3 +345,125 @@ type BlockSizeThrottler interface {
 	Succeed(round uint64)
 	ComputeMaxItems()
 }
+
+// FeeHandler is able to compute the gas and the fee needed by a transaction
+type FeeHandler interface {
+	MinGasPrice() uint64
+	ComputeGasLimit(tx *transaction.Transaction) uint64
+	ComputeFee(tx *transaction.Transaction) *big.Int
+	CheckValidityTxValues(tx *transaction.Transaction) error
+}
+
+// TransactionFeeHandler accumulates the fees paid by the transactions executed in the current block
+type TransactionFeeHandler interface {
+	CreateBlockStarted()
+	ProcessTransactionFee(cost *big.Int)
+	AccumulatedFees() *big.Int
+}
+
+// ReceiptsHandler keeps the receipts of the transactions executed in the current block and saves the receipts of
+// the transactions of the committed block
+type ReceiptsHandler interface {
+	CreateBlockStarted()
+	AddReceipt(txHash []byte, receipt *receipt.Receipt)
+	SaveReceipts(headerHash []byte, header data.HeaderHandler, txHashes [][]byte)
+	GetReceiptsTxHashes(txHashes [][]byte) [][]byte
+}
+
+// CommitJournal records the keys written in the storage units by each committed block, so that the blocks which were
+// only partially persisted before a crash are recognized and rolled back on startup
+type CommitJournal interface {
+	Record(nonce uint64, rootHash []byte, unitsKeys map[dataRetriever.UnitType][][]byte) error
+	RollbackIncompleteBlocks() (int, error)
+}
+
+// SmartContractLogsHandler receives the logs produced by the executed smart contract calls
+type SmartContractLogsHandler interface {
+	SaveLogs(txHash []byte, logs []*vmcommon.LogEntry)
+}
+
+// SpecialAddressHandler responds with the addresses involved in the fee distribution of a block
+type SpecialAddressHandler interface {
+	SetConsensusData(prevRandSeed []byte, round uint64) error
+	LeaderAddress() []byte
+}
+
+// EpochHandler keeps track of the epochs and of the validators lists that change when a new epoch starts
+type EpochHandler interface {
+	Epoch() uint32
+	EpochForRound(round uint64) uint32
+	IsEpochStart(round uint64) bool
+	CreateEpochStartData(randomness []byte) ([]block.EpochStartShardData, error)
+	SetEpochStart(epoch uint32, epochStart []block.EpochStartShardData) error
+	ProcessPeerInfo(peerInfo []block.PeerData) error
+	RestoreEpochStart(epoch uint32, epochStart []block.EpochStartShardData, peerInfo []block.PeerData) error
+}
+
+// StakingHandler executes the staking transactions and keeps the peer changes they produced in the current block
+type StakingHandler interface {
+	CreateBlockStarted()
+	ProcessStakingTransaction(tx *transaction.Transaction, acntSrc, acntStaking *state.Account, round uint64) error
+	PeerInfo() []block.PeerData
+}
+
+// StakingSettingsHandler provides the staking parameters of the network
+type StakingSettingsHandler interface {
+	MinStakeValue() *big.Int
+	UnBondPeriod() uint64
+	SlashingPercentage() float64
+}
+
+// SlashingProofVerifier checks a slashing proof and returns the public key of the offender and the round in which
+// the offence took place
+type SlashingProofVerifier interface {
+	VerifyProof(proof []byte) ([]byte, uint64, error)
+}
+
+// RatingsHandler computes the ratings of the validators from the committed headers and saves them in the state
+type RatingsHandler interface {
+	ProcessCommittedHeader(header data.HeaderHandler, prevHeader data.HeaderHandler) error
+	SaveRatings() error
+	ProcessSlashedPeers(peerInfo []block.PeerData)
+	LoadRatings(rootHash []byte) error
+}
+
+// RatingSettingsHandler provides the rating parameters of the network
+type RatingSettingsHandler interface {
+	StartRating() int32
+	MinRating() int32
+	MaxRating() int32
+	ProposerIncreaseRatingStep() int32
+	SignerIncreaseRatingStep() int32
+	ProposerDecreaseRatingStep() int32
+	SlashingDecreaseRatingStep() int32
+}
+
+// RewardSettingsHandler provides the reward parameters of the network
+type RewardSettingsHandler interface {
+	BlockReward() *big.Int
+	LeaderPercentage() float64
+	YearlyInflationRates() []float64
+}
+
+// RewardsCalculator computes the value minted as reward for a shard block and the part of it given to the leader
+type RewardsCalculator interface {
+	ComputeBlockReward(round uint64) *big.Int
+	ComputeLeaderReward(blockReward *big.Int) *big.Int
+}
+
+// RewardsHandler creates the reward transactions of a shard block, for its leader and for the signers of the
+// previous block, and applies them to the state
+type RewardsHandler interface {
+	CreateBlockStarted()
+	CreateRewardsMiniBlock(round uint64, leaderAddress []byte, signedHeader data.HeaderHandler) (*block.MiniBlock, error)
+	ForwardFees(round uint64, leaderAddress []byte, fees *big.Int) error
+	AccumulatedRewards() *big.Int
+	SaveRewardTxs(txHashes [][]byte)
+}
+
+// TotalSupplyHandler keeps the total supply of the network in the metachain state
+type TotalSupplyHandler interface {
+	AddRewards(rewards *big.Int) error
+	Burn(value *big.Int) error
+	TotalSupply() (*big.Int, error)
+}
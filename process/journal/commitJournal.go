@@ -0,0 +1,251 @@
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+var log = logger.DefaultLogger()
+
+var lastNonceKey = []byte("lastNonce")
+
+const entryKeyPrefix = "entry_"
+
+// journalEntry holds the keys written in each storage unit when a block was committed, together with the state
+// root hash of the block and the hashes of the trie nodes written by its commit
+type journalEntry struct {
+	Nonce     uint64
+	RootHash  []byte
+	UnitsKeys map[dataRetriever.UnitType][][]byte
+	TrieNodes [][]byte
+}
+
+// commitJournal records an entry before each block commit, in a storage unit which persists every write right
+// away. As the other storage units write in batches, the last blocks may be only partially persisted when the node
+// crashes, so on startup the recorded blocks are checked and the incomplete ones are removed from all the units
+type commitJournal struct {
+	store            dataRetriever.StorageService
+	accounts         state.AccountsAdapter
+	trieStorage      data.DBWriteCacher
+	marshalizer      marshal.Marshalizer
+	numEntriesToKeep uint64
+}
+
+// NewCommitJournal creates a commit journal which keeps the entries of the last numEntriesToKeep blocks. This number
+// should cover the blocks committed during the longest batch delay of the storage units, without exceeding the
+// number of roots kept by the trie pruning
+func NewCommitJournal(
+	store dataRetriever.StorageService,
+	accounts state.AccountsAdapter,
+	trieStorage data.DBWriteCacher,
+	marshalizer marshal.Marshalizer,
+	numEntriesToKeep uint32,
+) (*commitJournal, error) {
+	if store == nil {
+		return nil, process.ErrNilStorage
+	}
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if trieStorage == nil {
+		return nil, process.ErrNilTrieStorage
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if numEntriesToKeep == 0 {
+		return nil, process.ErrInvalidNumJournalEntries
+	}
+
+	return &commitJournal{
+		store:            store,
+		accounts:         accounts,
+		trieStorage:      trieStorage,
+		marshalizer:      marshalizer,
+		numEntriesToKeep: uint64(numEntriesToKeep),
+	}, nil
+}
+
+// Record writes the entry of a block which is about to be committed. It should be called before any of the keys is
+// written in its storage unit and before the accounts are committed, so that their dirty trie nodes are recorded
+func (cj *commitJournal) Record(nonce uint64, rootHash []byte, unitsKeys map[dataRetriever.UnitType][][]byte) error {
+	trieNodes, err := cj.accounts.GetDirtyTrieHashes()
+	if err != nil {
+		return err
+	}
+
+	entry := &journalEntry{
+		Nonce:     nonce,
+		RootHash:  rootHash,
+		UnitsKeys: unitsKeys,
+		TrieNodes: trieNodes,
+	}
+
+	buff, err := cj.marshalizer.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = cj.overwrite(entryKey(nonce), buff)
+	if err != nil {
+		return err
+	}
+
+	err = cj.overwrite(lastNonceKey, []byte(strconv.FormatUint(nonce, 10)))
+	if err != nil {
+		return err
+	}
+
+	if nonce >= cj.numEntriesToKeep {
+		errNotCritical := cj.removeKey(dataRetriever.CommitJournalUnit, entryKey(nonce-cj.numEntriesToKeep))
+		log.LogIfError(errNotCritical)
+	}
+
+	return nil
+}
+
+// RollbackIncompleteBlocks checks the recorded blocks from the oldest to the newest one and removes from the storage
+// units the first block which was not completely persisted, together with all the blocks above it. A block is
+// complete when all its keys and all the trie nodes written by its commit are found. It returns the number of rolled
+// back blocks
+func (cj *commitJournal) RollbackIncompleteBlocks() (int, error) {
+	entries, err := cj.loadEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	firstIncomplete := len(entries)
+	for i, entry := range entries {
+		if !cj.areKeysPersisted(entry) || !cj.areTrieNodesPersisted(entry) {
+			firstIncomplete = i
+			break
+		}
+	}
+
+	for i := len(entries) - 1; i >= firstIncomplete; i-- {
+		cj.rollback(entries[i])
+	}
+
+	if firstIncomplete == len(entries) {
+		return 0, nil
+	}
+	if firstIncomplete == 0 {
+		return len(entries), cj.removeKey(dataRetriever.CommitJournalUnit, lastNonceKey)
+	}
+
+	lastNonce := strconv.FormatUint(entries[firstIncomplete-1].Nonce, 10)
+	err = cj.overwrite(lastNonceKey, []byte(lastNonce))
+
+	return len(entries) - firstIncomplete, err
+}
+
+// loadEntries returns the recorded entries, ordered by nonce. An empty journal has no entries
+func (cj *commitJournal) loadEntries() ([]*journalEntry, error) {
+	buff, err := cj.store.Get(dataRetriever.CommitJournalUnit, lastNonceKey)
+	if err != nil {
+		return make([]*journalEntry, 0), nil
+	}
+
+	lastNonce, err := strconv.ParseUint(string(buff), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*journalEntry, 0)
+	for i := uint64(0); i < cj.numEntriesToKeep && i <= lastNonce; i++ {
+		buff, err = cj.store.Get(dataRetriever.CommitJournalUnit, entryKey(lastNonce-i))
+		if err != nil {
+			continue
+		}
+
+		entry := &journalEntry{}
+		err = cj.marshalizer.Unmarshal(entry, buff)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Nonce < entries[j].Nonce
+	})
+
+	return entries, nil
+}
+
+func (cj *commitJournal) areKeysPersisted(entry *journalEntry) bool {
+	for unitType, keys := range entry.UnitsKeys {
+		for _, key := range keys {
+			err := cj.store.Has(unitType, key)
+			if err != nil {
+				log.Info(fmt.Sprintf("block with nonce %d is incomplete: missing key %s in unit %d\n",
+					entry.Nonce,
+					core.ToB64(key),
+					unitType))
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (cj *commitJournal) areTrieNodesPersisted(entry *journalEntry) bool {
+	for _, hash := range entry.TrieNodes {
+		_, err := cj.trieStorage.Get(hash)
+		if err != nil {
+			log.Info(fmt.Sprintf("state of block with nonce %d is incomplete: missing trie node %s\n",
+				entry.Nonce,
+				core.ToB64(hash)))
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cj *commitJournal) rollback(entry *journalEntry) {
+	log.Info(fmt.Sprintf("rolling back the block with nonce %d\n", entry.Nonce))
+
+	for unitType, keys := range entry.UnitsKeys {
+		for _, key := range keys {
+			errNotCritical := cj.removeKey(unitType, key)
+			log.LogIfError(errNotCritical)
+		}
+	}
+
+	errNotCritical := cj.removeKey(dataRetriever.CommitJournalUnit, entryKey(entry.Nonce))
+	log.LogIfError(errNotCritical)
+}
+
+func (cj *commitJournal) overwrite(key []byte, value []byte) error {
+	storer := cj.store.GetStorer(dataRetriever.CommitJournalUnit)
+	if storer == nil {
+		return process.ErrNilStorage
+	}
+
+	return core.Overwrite(storer, key, value)
+}
+
+func (cj *commitJournal) removeKey(unitType dataRetriever.UnitType, key []byte) error {
+	storer := cj.store.GetStorer(unitType)
+	if storer == nil {
+		return process.ErrNilStorage
+	}
+
+	return storer.Remove(key)
+}
+
+func entryKey(nonce uint64) []byte {
+	return []byte(entryKeyPrefix + strconv.FormatUint(nonce, 10))
+}
@@ -0,0 +1,265 @@
+package journal_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/journal"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMemUnit() storage.Storer {
+	cache, _ := storageUnit.NewCache(storageUnit.LRUCache, 100, 1)
+	memDB, _ := memorydb.New()
+	unit, _ := storageUnit.NewStorageUnit(cache, memDB)
+
+	return unit
+}
+
+func createStore() dataRetriever.StorageService {
+	store := dataRetriever.NewChainStorer()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MiniBlockUnit, createMemUnit())
+	store.AddStorer(dataRetriever.CommitJournalUnit, createMemUnit())
+
+	return store
+}
+
+func trieNodeHash(nonce uint64) []byte {
+	return []byte(fmt.Sprintf("trie_node_%d", nonce))
+}
+
+// createAccounts returns accounts which have one dirty trie node on each record, as the tests commit the blocks in
+// order, starting with nonce 1
+func createAccounts() *mock.AccountsStub {
+	numRecords := uint64(0)
+	return &mock.AccountsStub{
+		GetDirtyTrieHashesCalled: func() ([][]byte, error) {
+			numRecords++
+			return [][]byte{trieNodeHash(numRecords)}, nil
+		},
+	}
+}
+
+// commitBlock records the block in the journal and then writes its keys and trie node, except for the missing ones
+func commitBlock(
+	cj process.CommitJournal,
+	store dataRetriever.StorageService,
+	trieStorage data.DBWriteCacher,
+	nonce uint64,
+	missingMiniBlock bool,
+	missingTrieNode bool,
+) {
+	headerHash := []byte(fmt.Sprintf("header_%d", nonce))
+	miniBlockHash := []byte(fmt.Sprintf("miniblock_%d", nonce))
+	unitsKeys := map[dataRetriever.UnitType][][]byte{
+		dataRetriever.BlockHeaderUnit: {headerHash},
+		dataRetriever.MiniBlockUnit:   {miniBlockHash},
+	}
+
+	_ = cj.Record(nonce, []byte(fmt.Sprintf("root_%d", nonce)), unitsKeys)
+	_ = store.Put(dataRetriever.BlockHeaderUnit, headerHash, []byte("header"))
+	if !missingMiniBlock {
+		_ = store.Put(dataRetriever.MiniBlockUnit, miniBlockHash, []byte("miniblock"))
+	}
+	if !missingTrieNode {
+		_ = trieStorage.Put(trieNodeHash(nonce), []byte("trie node"))
+	}
+}
+
+func hasHeader(store dataRetriever.StorageService, nonce uint64) bool {
+	return store.Has(dataRetriever.BlockHeaderUnit, []byte(fmt.Sprintf("header_%d", nonce))) == nil
+}
+
+//------- NewCommitJournal
+
+func TestNewCommitJournal_NilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cj, err := journal.NewCommitJournal(nil, &mock.AccountsStub{}, createMemUnit(), &mock.MarshalizerMock{}, 10)
+
+	assert.Nil(t, cj)
+	assert.Equal(t, process.ErrNilStorage, err)
+}
+
+func TestNewCommitJournal_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cj, err := journal.NewCommitJournal(createStore(), nil, createMemUnit(), &mock.MarshalizerMock{}, 10)
+
+	assert.Nil(t, cj)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestNewCommitJournal_NilTrieStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cj, err := journal.NewCommitJournal(createStore(), &mock.AccountsStub{}, nil, &mock.MarshalizerMock{}, 10)
+
+	assert.Nil(t, cj)
+	assert.Equal(t, process.ErrNilTrieStorage, err)
+}
+
+func TestNewCommitJournal_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cj, err := journal.NewCommitJournal(createStore(), &mock.AccountsStub{}, createMemUnit(), nil, 10)
+
+	assert.Nil(t, cj)
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+}
+
+func TestNewCommitJournal_ZeroEntriesToKeepShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cj, err := journal.NewCommitJournal(createStore(), &mock.AccountsStub{}, createMemUnit(), &mock.MarshalizerMock{}, 0)
+
+	assert.Nil(t, cj)
+	assert.Equal(t, process.ErrInvalidNumJournalEntries, err)
+}
+
+func TestNewCommitJournal_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	cj, err := journal.NewCommitJournal(createStore(), &mock.AccountsStub{}, createMemUnit(), &mock.MarshalizerMock{}, 10)
+
+	assert.NotNil(t, cj)
+	assert.Nil(t, err)
+}
+
+//------- RollbackIncompleteBlocks
+
+func TestCommitJournal_RollbackIncompleteBlocksEmptyJournalShouldNotRollback(t *testing.T) {
+	t.Parallel()
+
+	cj, _ := journal.NewCommitJournal(createStore(), &mock.AccountsStub{}, createMemUnit(), &mock.MarshalizerMock{}, 10)
+
+	numRolledBack, err := cj.RollbackIncompleteBlocks()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numRolledBack)
+}
+
+func TestCommitJournal_RollbackIncompleteBlocksCompleteBlocksShouldNotRollback(t *testing.T) {
+	t.Parallel()
+
+	store := createStore()
+	trieStorage := createMemUnit()
+	cj, _ := journal.NewCommitJournal(store, createAccounts(), trieStorage, &mock.MarshalizerMock{}, 10)
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		commitBlock(cj, store, trieStorage, nonce, false, false)
+	}
+
+	numRolledBack, err := cj.RollbackIncompleteBlocks()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numRolledBack)
+	assert.True(t, hasHeader(store, 3))
+}
+
+func TestCommitJournal_RollbackIncompleteBlocksShouldRemoveTheIncompleteBlockAndTheOnesAboveIt(t *testing.T) {
+	t.Parallel()
+
+	store := createStore()
+	trieStorage := createMemUnit()
+	cj, _ := journal.NewCommitJournal(store, createAccounts(), trieStorage, &mock.MarshalizerMock{}, 10)
+	commitBlock(cj, store, trieStorage, 1, false, false)
+	commitBlock(cj, store, trieStorage, 2, true, false)
+	commitBlock(cj, store, trieStorage, 3, false, false)
+
+	numRolledBack, err := cj.RollbackIncompleteBlocks()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, numRolledBack)
+	assert.True(t, hasHeader(store, 1))
+	assert.False(t, hasHeader(store, 2))
+	assert.False(t, hasHeader(store, 3))
+	assert.NotNil(t, store.Has(dataRetriever.MiniBlockUnit, []byte("miniblock_3")))
+
+	numRolledBack, err = cj.RollbackIncompleteBlocks()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numRolledBack)
+}
+
+func TestCommitJournal_RollbackIncompleteBlocksMissingTrieNodeShouldRollbackTheBlock(t *testing.T) {
+	t.Parallel()
+
+	store := createStore()
+	trieStorage := createMemUnit()
+	cj, _ := journal.NewCommitJournal(store, createAccounts(), trieStorage, &mock.MarshalizerMock{}, 10)
+	commitBlock(cj, store, trieStorage, 1, false, false)
+	commitBlock(cj, store, trieStorage, 2, false, false)
+	commitBlock(cj, store, trieStorage, 3, false, true)
+
+	numRolledBack, err := cj.RollbackIncompleteBlocks()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numRolledBack)
+	assert.True(t, hasHeader(store, 2))
+	assert.False(t, hasHeader(store, 3))
+}
+
+func TestCommitJournal_RollbackIncompleteBlocksShouldCheckOnlyTheRecordedTrieNodes(t *testing.T) {
+	t.Parallel()
+
+	store := createStore()
+	trieStorage := createMemUnit()
+	accounts := &mock.AccountsStub{
+		GetDirtyTrieHashesCalled: func() ([][]byte, error) {
+			return make([][]byte, 0), nil
+		},
+	}
+	cj, _ := journal.NewCommitJournal(store, accounts, trieStorage, &mock.MarshalizerMock{}, 10)
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		commitBlock(cj, store, trieStorage, nonce, false, true)
+	}
+
+	numRolledBack, err := cj.RollbackIncompleteBlocks()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numRolledBack)
+	assert.True(t, hasHeader(store, 3))
+}
+
+func TestCommitJournal_RecordDirtyTrieHashesErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	store := createStore()
+	accounts := &mock.AccountsStub{
+		GetDirtyTrieHashesCalled: func() ([][]byte, error) {
+			return nil, expectedErr
+		},
+	}
+	cj, _ := journal.NewCommitJournal(store, accounts, createMemUnit(), &mock.MarshalizerMock{}, 10)
+
+	err := cj.Record(1, []byte("root_1"), make(map[dataRetriever.UnitType][][]byte))
+
+	assert.Equal(t, expectedErr, err)
+	assert.NotNil(t, store.Has(dataRetriever.CommitJournalUnit, []byte("entry_1")))
+}
+
+func TestCommitJournal_RecordShouldKeepOnlyTheLastEntries(t *testing.T) {
+	t.Parallel()
+
+	store := createStore()
+	trieStorage := createMemUnit()
+	cj, _ := journal.NewCommitJournal(store, createAccounts(), trieStorage, &mock.MarshalizerMock{}, 2)
+	commitBlock(cj, store, trieStorage, 1, true, true)
+	commitBlock(cj, store, trieStorage, 2, false, false)
+	commitBlock(cj, store, trieStorage, 3, false, false)
+
+	numRolledBack, err := cj.RollbackIncompleteBlocks()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numRolledBack)
+	assert.True(t, hasHeader(store, 1))
+}
@@ -21,6 +21,10 @@ type AccountsStub struct {
 	SaveDataTrieCalled          func(acountWrapper state.AccountHandler) error
 	RootHashCalled              func() ([]byte, error)
 	RecreateTrieCalled          func(rootHash []byte) error
+	WalkAccountsCalled          func(handler func(accountHandler state.AccountHandler) error) error
+	ProveCalled                 func(rootHash []byte, key []byte) ([][]byte, error)
+	RecreateReadOnlyCalled      func(rootHash []byte) (state.AccountsAdapter, error)
+	GetDirtyTrieHashesCalled    func() ([][]byte, error)
 }
 
 var errNotImplemented = errors.New("not implemented")
@@ -35,6 +39,10 @@ func (aam *AccountsStub) AddJournalEntry(je state.JournalEntry) {
 	}
 }
 
+func (aam *AccountsStub) Journalize(entry state.JournalEntry) {
+	aam.AddJournalEntry(entry)
+}
+
 func (aam *AccountsStub) Commit() ([]byte, error) {
 	if aam.CommitCalled != nil {
 		return aam.CommitCalled()
@@ -138,3 +146,35 @@ func (aam *AccountsStub) RecreateTrie(rootHash []byte) error {
 
 	return errNotImplemented
 }
+
+func (aam *AccountsStub) WalkAccounts(handler func(accountHandler state.AccountHandler) error) error {
+	if aam.WalkAccountsCalled != nil {
+		return aam.WalkAccountsCalled(handler)
+	}
+
+	return errNotImplemented
+}
+
+func (aam *AccountsStub) Prove(rootHash []byte, key []byte) ([][]byte, error) {
+	if aam.ProveCalled != nil {
+		return aam.ProveCalled(rootHash, key)
+	}
+
+	return nil, errNotImplemented
+}
+
+func (aam *AccountsStub) RecreateReadOnly(rootHash []byte) (state.AccountsAdapter, error) {
+	if aam.RecreateReadOnlyCalled != nil {
+		return aam.RecreateReadOnlyCalled(rootHash)
+	}
+
+	return nil, errNotImplemented
+}
+
+func (aam *AccountsStub) GetDirtyTrieHashes() ([][]byte, error) {
+	if aam.GetDirtyTrieHashesCalled != nil {
+		return aam.GetDirtyTrieHashesCalled()
+	}
+
+	return nil, errNotImplemented
+}
@@ -13,6 +13,7 @@ type BlockProcessorMock struct {
 	RevertAccountStateCalled         func()
 	CreateGenesisBlockCalled         func(balances map[string]*big.Int) (data.HeaderHandler, error)
 	CreateBlockCalled                func(round uint64, haveTime func() bool) (data.BodyHandler, error)
+	SetConsensusDataCalled           func(prevHeader data.HeaderHandler, round uint64)
 	RestoreBlockIntoPoolsCalled      func(header data.HeaderHandler, body data.BodyHandler) error
 	noShards                         uint32
 	SetOnRequestTransactionCalled    func(f func(destShardID uint32, txHash []byte))
@@ -21,6 +22,7 @@ type BlockProcessorMock struct {
 	DecodeBlockBodyCalled            func(dta []byte) data.BodyHandler
 	DecodeBlockHeaderCalled          func(dta []byte) data.HeaderHandler
 	AddLastNotarizedHdrCalled        func(shardId uint32, processedHdr data.HeaderHandler)
+	RestoreEpochStateCalled          func(header data.HeaderHandler) error
 }
 
 func (bpm *BlockProcessorMock) ProcessBlock(blockChain data.ChainHandler, header data.HeaderHandler, body data.BodyHandler, haveTime func() time.Duration) error {
@@ -43,6 +45,12 @@ func (blProcMock BlockProcessorMock) CreateBlockBody(round uint64, haveTime func
 	return blProcMock.CreateBlockCalled(round, haveTime)
 }
 
+func (blProcMock BlockProcessorMock) SetConsensusData(prevHeader data.HeaderHandler, round uint64) {
+	if blProcMock.SetConsensusDataCalled != nil {
+		blProcMock.SetConsensusDataCalled(prevHeader, round)
+	}
+}
+
 func (blProcMock BlockProcessorMock) RestoreBlockIntoPools(header data.HeaderHandler, body data.BodyHandler) error {
 	return blProcMock.RestoreBlockIntoPoolsCalled(header, body)
 }
@@ -66,3 +74,11 @@ func (blProcMock BlockProcessorMock) DecodeBlockHeader(dta []byte) data.HeaderHa
 func (blProcMock BlockProcessorMock) AddLastNotarizedHdr(shardId uint32, processedHdr data.HeaderHandler) {
 	blProcMock.AddLastNotarizedHdrCalled(shardId, processedHdr)
 }
+
+func (blProcMock *BlockProcessorMock) RestoreEpochState(header data.HeaderHandler) error {
+	if blProcMock.RestoreEpochStateCalled == nil {
+		return nil
+	}
+
+	return blProcMock.RestoreEpochStateCalled(header)
+}
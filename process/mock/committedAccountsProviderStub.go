@@ -0,0 +1,13 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/state"
+)
+
+type CommittedAccountsProviderStub struct {
+	GetExistingAccountCalled func(address state.AddressContainer) (state.AccountHandler, error)
+}
+
+func (caps *CommittedAccountsProviderStub) GetExistingAccount(address state.AddressContainer) (state.AccountHandler, error) {
+	return caps.GetExistingAccountCalled(address)
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+)
+
+type EventsNotifierStub struct {
+	NotifyCommittedBlockCalled func(headerHash []byte, header data.HeaderHandler, txs map[string]data.TransactionHandler)
+}
+
+func (ens *EventsNotifierStub) NotifyCommittedBlock(
+	headerHash []byte,
+	header data.HeaderHandler,
+	txs map[string]data.TransactionHandler,
+) {
+	if ens.NotifyCommittedBlockCalled != nil {
+		ens.NotifyCommittedBlockCalled(headerHash, header, txs)
+	}
+}
@@ -0,0 +1,13 @@
+package mock
+
+type LeafReferencesExtractorStub struct {
+	ExtractReferencesCalled func(value []byte) [][]byte
+}
+
+func (lres *LeafReferencesExtractorStub) ExtractReferences(value []byte) [][]byte {
+	if lres.ExtractReferencesCalled != nil {
+		return lres.ExtractReferencesCalled(value)
+	}
+
+	return make([][]byte, 0)
+}
@@ -18,6 +18,7 @@ type PoolsHolderFake struct {
 	miniBlocks           storage.Cacher
 	peerChangesBlocks    storage.Cacher
 	metaHdrNonces        dataRetriever.Uint64SyncMapCacher
+	trieNodes            storage.Cacher
 }
 
 func NewPoolsHolderFake() *PoolsHolderFake {
@@ -38,6 +39,7 @@ func NewPoolsHolderFake() *PoolsHolderFake {
 	)
 	phf.miniBlocks, _ = storageUnit.NewCache(storageUnit.LRUCache, 10000, 1)
 	phf.peerChangesBlocks, _ = storageUnit.NewCache(storageUnit.LRUCache, 10000, 1)
+	phf.trieNodes, _ = storageUnit.NewCache(storageUnit.LRUCache, 10000, 1)
 	return phf
 }
 
@@ -69,6 +71,10 @@ func (phf *PoolsHolderFake) MetaBlocks() storage.Cacher {
 	return phf.metaBlocks
 }
 
+func (phf *PoolsHolderFake) TrieNodes() storage.Cacher {
+	return phf.trieNodes
+}
+
 func (phf *PoolsHolderFake) MetaHeadersNonces() dataRetriever.Uint64SyncMapCacher {
 	return phf.metaHdrNonces
 }
@@ -13,6 +13,7 @@ type PoolsHolderStub struct {
 	UnsignedTransactionsCalled func() dataRetriever.ShardedDataCacherNotifier
 	MiniBlocksCalled           func() storage.Cacher
 	MetaBlocksCalled           func() storage.Cacher
+	TrieNodesCalled            func() storage.Cacher
 }
 
 func (phs *PoolsHolderStub) Headers() storage.Cacher {
@@ -39,6 +40,10 @@ func (phs *PoolsHolderStub) MetaBlocks() storage.Cacher {
 	return phs.MetaBlocksCalled()
 }
 
+func (phs *PoolsHolderStub) TrieNodes() storage.Cacher {
+	return phs.TrieNodesCalled()
+}
+
 func (phs *PoolsHolderStub) UnsignedTransactions() dataRetriever.ShardedDataCacherNotifier {
 	return phs.UnsignedTransactionsCalled()
 }
@@ -0,0 +1,39 @@
+package mock
+
+type RatingSettingsMock struct {
+	StartRatingValue                int32
+	MinRatingValue                  int32
+	MaxRatingValue                  int32
+	ProposerIncreaseRatingStepValue int32
+	SignerIncreaseRatingStepValue   int32
+	ProposerDecreaseRatingStepValue int32
+	SlashingDecreaseRatingStepValue int32
+}
+
+func (rsm *RatingSettingsMock) StartRating() int32 {
+	return rsm.StartRatingValue
+}
+
+func (rsm *RatingSettingsMock) MinRating() int32 {
+	return rsm.MinRatingValue
+}
+
+func (rsm *RatingSettingsMock) MaxRating() int32 {
+	return rsm.MaxRatingValue
+}
+
+func (rsm *RatingSettingsMock) ProposerIncreaseRatingStep() int32 {
+	return rsm.ProposerIncreaseRatingStepValue
+}
+
+func (rsm *RatingSettingsMock) SignerIncreaseRatingStep() int32 {
+	return rsm.SignerIncreaseRatingStepValue
+}
+
+func (rsm *RatingSettingsMock) ProposerDecreaseRatingStep() int32 {
+	return rsm.ProposerDecreaseRatingStepValue
+}
+
+func (rsm *RatingSettingsMock) SlashingDecreaseRatingStep() int32 {
+	return rsm.SlashingDecreaseRatingStepValue
+}
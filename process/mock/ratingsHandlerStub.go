@@ -0,0 +1,40 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+)
+
+type RatingsHandlerStub struct {
+	ProcessCommittedHeaderCalled func(header data.HeaderHandler, prevHeader data.HeaderHandler) error
+	SaveRatingsCalled            func() error
+	ProcessSlashedPeersCalled    func(peerInfo []block.PeerData)
+	LoadRatingsCalled            func(rootHash []byte) error
+}
+
+func (rhs *RatingsHandlerStub) ProcessCommittedHeader(header data.HeaderHandler, prevHeader data.HeaderHandler) error {
+	if rhs.ProcessCommittedHeaderCalled == nil {
+		return nil
+	}
+	return rhs.ProcessCommittedHeaderCalled(header, prevHeader)
+}
+
+func (rhs *RatingsHandlerStub) SaveRatings() error {
+	if rhs.SaveRatingsCalled == nil {
+		return nil
+	}
+	return rhs.SaveRatingsCalled()
+}
+
+func (rhs *RatingsHandlerStub) ProcessSlashedPeers(peerInfo []block.PeerData) {
+	if rhs.ProcessSlashedPeersCalled != nil {
+		rhs.ProcessSlashedPeersCalled(peerInfo)
+	}
+}
+
+func (rhs *RatingsHandlerStub) LoadRatings(rootHash []byte) error {
+	if rhs.LoadRatingsCalled == nil {
+		return nil
+	}
+	return rhs.LoadRatingsCalled(rootHash)
+}
@@ -0,0 +1,39 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+)
+
+type ReceiptsHandlerStub struct {
+	CreateBlockStartedCalled  func()
+	AddReceiptCalled          func(txHash []byte, receipt *receipt.Receipt)
+	SaveReceiptsCalled        func(headerHash []byte, header data.HeaderHandler, txHashes [][]byte)
+	GetReceiptsTxHashesCalled func(txHashes [][]byte) [][]byte
+}
+
+func (rhs *ReceiptsHandlerStub) CreateBlockStarted() {
+	if rhs.CreateBlockStartedCalled != nil {
+		rhs.CreateBlockStartedCalled()
+	}
+}
+
+func (rhs *ReceiptsHandlerStub) AddReceipt(txHash []byte, receipt *receipt.Receipt) {
+	if rhs.AddReceiptCalled != nil {
+		rhs.AddReceiptCalled(txHash, receipt)
+	}
+}
+
+func (rhs *ReceiptsHandlerStub) SaveReceipts(headerHash []byte, header data.HeaderHandler, txHashes [][]byte) {
+	if rhs.SaveReceiptsCalled != nil {
+		rhs.SaveReceiptsCalled(headerHash, header, txHashes)
+	}
+}
+
+func (rhs *ReceiptsHandlerStub) GetReceiptsTxHashes(txHashes [][]byte) [][]byte {
+	if rhs.GetReceiptsTxHashesCalled != nil {
+		return rhs.GetReceiptsTxHashesCalled(txHashes)
+	}
+
+	return make([][]byte, 0)
+}
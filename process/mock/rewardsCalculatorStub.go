@@ -0,0 +1,18 @@
+package mock
+
+import (
+	"math/big"
+)
+
+type RewardsCalculatorStub struct {
+	ComputeBlockRewardCalled  func(round uint64) *big.Int
+	ComputeLeaderRewardCalled func(blockReward *big.Int) *big.Int
+}
+
+func (rcs *RewardsCalculatorStub) ComputeBlockReward(round uint64) *big.Int {
+	return rcs.ComputeBlockRewardCalled(round)
+}
+
+func (rcs *RewardsCalculatorStub) ComputeLeaderReward(blockReward *big.Int) *big.Int {
+	return rcs.ComputeLeaderRewardCalled(blockReward)
+}
@@ -0,0 +1,12 @@
+package mock
+
+type SlashingProofVerifierStub struct {
+	VerifyProofCalled func(proof []byte) ([]byte, uint64, error)
+}
+
+func (spvs *SlashingProofVerifierStub) VerifyProof(proof []byte) ([]byte, uint64, error) {
+	if spvs.VerifyProofCalled == nil {
+		return nil, 0, nil
+	}
+	return spvs.VerifyProofCalled(proof)
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-vm-common"
+)
+
+type SmartContractLogsHandlerStub struct {
+	SaveLogsCalled func(txHash []byte, logs []*vmcommon.LogEntry)
+}
+
+func (slhs *SmartContractLogsHandlerStub) SaveLogs(txHash []byte, logs []*vmcommon.LogEntry) {
+	if slhs.SaveLogsCalled != nil {
+		slhs.SaveLogsCalled(txHash, logs)
+	}
+}
@@ -0,0 +1,20 @@
+package mock
+
+type SpecialAddressHandlerMock struct {
+	SetConsensusDataCalled func(prevRandSeed []byte, round uint64) error
+	LeaderAddressCalled    func() []byte
+}
+
+func (sh *SpecialAddressHandlerMock) SetConsensusData(prevRandSeed []byte, round uint64) error {
+	if sh.SetConsensusDataCalled == nil {
+		return nil
+	}
+	return sh.SetConsensusDataCalled(prevRandSeed, round)
+}
+
+func (sh *SpecialAddressHandlerMock) LeaderAddress() []byte {
+	if sh.LeaderAddressCalled == nil {
+		return nil
+	}
+	return sh.LeaderAddressCalled()
+}
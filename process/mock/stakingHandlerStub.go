@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+)
+
+type StakingHandlerStub struct {
+	CreateBlockStartedCalled        func()
+	ProcessStakingTransactionCalled func(tx *transaction.Transaction, acntSrc, acntStaking *state.Account, round uint64) error
+	PeerInfoCalled                  func() []block.PeerData
+}
+
+func (shs *StakingHandlerStub) CreateBlockStarted() {
+	if shs.CreateBlockStartedCalled != nil {
+		shs.CreateBlockStartedCalled()
+	}
+}
+
+func (shs *StakingHandlerStub) ProcessStakingTransaction(
+	tx *transaction.Transaction,
+	acntSrc, acntStaking *state.Account,
+	round uint64,
+) error {
+	if shs.ProcessStakingTransactionCalled == nil {
+		return nil
+	}
+	return shs.ProcessStakingTransactionCalled(tx, acntSrc, acntStaking, round)
+}
+
+func (shs *StakingHandlerStub) PeerInfo() []block.PeerData {
+	if shs.PeerInfoCalled == nil {
+		return make([]block.PeerData, 0)
+	}
+	return shs.PeerInfoCalled()
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"math/big"
+)
+
+type StakingSettingsStub struct {
+	MinStakeValueCalled      func() *big.Int
+	UnBondPeriodCalled       func() uint64
+	SlashingPercentageCalled func() float64
+}
+
+func (sss *StakingSettingsStub) MinStakeValue() *big.Int {
+	if sss.MinStakeValueCalled == nil {
+		return big.NewInt(0)
+	}
+	return sss.MinStakeValueCalled()
+}
+
+func (sss *StakingSettingsStub) UnBondPeriod() uint64 {
+	if sss.UnBondPeriodCalled == nil {
+		return 0
+	}
+	return sss.UnBondPeriodCalled()
+}
+
+func (sss *StakingSettingsStub) SlashingPercentage() float64 {
+	if sss.SlashingPercentageCalled == nil {
+		return 0
+	}
+	return sss.SlashingPercentageCalled()
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"math/big"
+)
+
+type TotalSupplyHandlerStub struct {
+	AddRewardsCalled  func(rewards *big.Int) error
+	BurnCalled        func(value *big.Int) error
+	TotalSupplyCalled func() (*big.Int, error)
+}
+
+func (tshs *TotalSupplyHandlerStub) AddRewards(rewards *big.Int) error {
+	if tshs.AddRewardsCalled == nil {
+		return nil
+	}
+	return tshs.AddRewardsCalled(rewards)
+}
+
+func (tshs *TotalSupplyHandlerStub) Burn(value *big.Int) error {
+	if tshs.BurnCalled == nil {
+		return nil
+	}
+	return tshs.BurnCalled(value)
+}
+
+func (tshs *TotalSupplyHandlerStub) TotalSupply() (*big.Int, error) {
+	if tshs.TotalSupplyCalled == nil {
+		return big.NewInt(0), nil
+	}
+	return tshs.TotalSupplyCalled()
+}
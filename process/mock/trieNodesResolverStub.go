@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go/p2p"
+)
+
+type TrieNodesResolverStub struct {
+	RequestDataFromHashCalled      func(hash []byte) error
+	RequestDataFromHashArrayCalled func(hashes [][]byte) error
+	ProcessReceivedMessageCalled   func(message p2p.MessageP2P) error
+}
+
+func (tnrs *TrieNodesResolverStub) RequestDataFromHash(hash []byte) error {
+	return tnrs.RequestDataFromHashCalled(hash)
+}
+
+func (tnrs *TrieNodesResolverStub) RequestDataFromHashArray(hashes [][]byte) error {
+	return tnrs.RequestDataFromHashArrayCalled(hashes)
+}
+
+func (tnrs *TrieNodesResolverStub) ProcessReceivedMessage(message p2p.MessageP2P) error {
+	return tnrs.ProcessReceivedMessageCalled(message)
+}
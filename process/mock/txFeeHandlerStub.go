@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"math/big"
+)
+
+type TxFeeHandlerStub struct {
+	CreateBlockStartedCalled    func()
+	ProcessTransactionFeeCalled func(cost *big.Int)
+	AccumulatedFeesCalled       func() *big.Int
+}
+
+func (tfhs *TxFeeHandlerStub) CreateBlockStarted() {
+	if tfhs.CreateBlockStartedCalled != nil {
+		tfhs.CreateBlockStartedCalled()
+	}
+}
+
+func (tfhs *TxFeeHandlerStub) ProcessTransactionFee(cost *big.Int) {
+	if tfhs.ProcessTransactionFeeCalled != nil {
+		tfhs.ProcessTransactionFeeCalled(cost)
+	}
+}
+
+func (tfhs *TxFeeHandlerStub) AccumulatedFees() *big.Int {
+	if tfhs.AccumulatedFeesCalled == nil {
+		return big.NewInt(0)
+	}
+	return tfhs.AccumulatedFeesCalled()
+}
@@ -0,0 +1,294 @@
+package rating
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+var log = logger.DefaultLogger()
+
+// maxMissedRoundsRated limits the number of missed rounds penalized between two committed headers, so a long
+// interruption of the whole shard does not ruin the ratings of all validators
+const maxMissedRoundsRated = 100
+
+const ratingSizeInBytes = 4
+
+// ratingsProcessor computes the ratings of the validators of a shard. The leader and the signers of a committed
+// header, found in its PubKeysBitmap, gain rating, while the leaders of the rounds missed before it lose rating.
+// The changes observed in a committed header are saved in the state when the next block is created or processed,
+// and the ratings saved by a block become available to the epoch manager once that block is committed. The ratings
+// are always read from the state of the last committed block, so they are the same on all nodes, even after a restart
+// or a rollback
+type ratingsProcessor struct {
+	accounts       state.AccountsAdapter
+	groupSelector  consensus.ValidatorGroupSelector
+	ratingSettings process.RatingSettingsHandler
+
+	mutRatings  sync.RWMutex
+	acntRatings state.AccountHandler
+	changes     map[string]int32
+}
+
+// NewRatingsProcessor creates a new ratings processor
+func NewRatingsProcessor(
+	accounts state.AccountsAdapter,
+	groupSelector consensus.ValidatorGroupSelector,
+	ratingSettings process.RatingSettingsHandler,
+) (*ratingsProcessor, error) {
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if groupSelector == nil {
+		return nil, process.ErrNilValidatorGroupSelector
+	}
+	if ratingSettings == nil {
+		return nil, process.ErrNilRatingSettings
+	}
+
+	return &ratingsProcessor{
+		accounts:       accounts,
+		groupSelector:  groupSelector,
+		ratingSettings: ratingSettings,
+		changes:        make(map[string]int32),
+	}, nil
+}
+
+// GetRating returns the rating of a validator, as it was saved in the state of the last committed block
+func (rp *ratingsProcessor) GetRating(pubKey string) int32 {
+	rp.mutRatings.RLock()
+	defer rp.mutRatings.RUnlock()
+
+	if rp.acntRatings == nil {
+		return rp.ratingSettings.StartRating()
+	}
+
+	rating, err := rp.getSavedRating(rp.acntRatings, []byte(pubKey))
+	if err != nil {
+		log.Error(fmt.Sprintf("could not read the rating of %s: %s\n", core.ToHex([]byte(pubKey)), err.Error()))
+		return rp.ratingSettings.StartRating()
+	}
+
+	return rating
+}
+
+// LoadRatings makes available the ratings saved in the state with the given root hash and drops the rating changes
+// which were not saved yet. It is used when the node restarts or rolls back blocks
+func (rp *ratingsProcessor) LoadRatings(rootHash []byte) error {
+	acntRatings, err := rp.getCommittedRatingsAccount(rootHash)
+	if err != nil {
+		return err
+	}
+
+	rp.mutRatings.Lock()
+	rp.acntRatings = acntRatings
+	rp.changes = make(map[string]int32)
+	rp.mutRatings.Unlock()
+
+	return nil
+}
+
+// ProcessCommittedHeader makes the ratings saved by the committed header available and computes the rating changes
+// observed in it. It must be called before the eligible list is changed by the committed header, so the consensus
+// groups are computed in the same way they were computed when the header was produced
+func (rp *ratingsProcessor) ProcessCommittedHeader(header data.HeaderHandler, prevHeader data.HeaderHandler) error {
+	if header == nil || header.IsInterfaceNil() {
+		return process.ErrNilBlockHeader
+	}
+
+	changes, err := rp.computeRatingChanges(header, prevHeader)
+	if err != nil {
+		return err
+	}
+
+	acntRatings, err := rp.getCommittedRatingsAccount(header.GetRootHash())
+	if err != nil {
+		return err
+	}
+
+	rp.mutRatings.Lock()
+	rp.acntRatings = acntRatings
+	rp.changes = changes
+	rp.mutRatings.Unlock()
+
+	return nil
+}
+
+// ProcessSlashedPeers lowers the ratings of the validators slashed in the committed metachain blocks. The decrease
+// is added to the changes of the last committed header, so it is saved in the state together with them
+func (rp *ratingsProcessor) ProcessSlashedPeers(peerInfo []block.PeerData) {
+	rp.mutRatings.Lock()
+	for _, peerData := range peerInfo {
+		if peerData.Action != block.PeerSlashing {
+			continue
+		}
+
+		rp.changes[string(peerData.PublicKey)] -= rp.ratingSettings.SlashingDecreaseRatingStep()
+	}
+	rp.mutRatings.Unlock()
+}
+
+// SaveRatings applies the rating changes observed in the last committed header to the ratings found in the state
+// and saves the results in the data trie of the ratings account
+func (rp *ratingsProcessor) SaveRatings() error {
+	rp.mutRatings.RLock()
+	pubKeys := make([]string, 0, len(rp.changes))
+	for pubKey := range rp.changes {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	changes := rp.changes
+	rp.mutRatings.RUnlock()
+
+	if len(pubKeys) == 0 {
+		return nil
+	}
+
+	sort.Strings(pubKeys)
+
+	acntRatings, err := rp.getRatingsAccount()
+	if err != nil {
+		return err
+	}
+
+	for _, pubKey := range pubKeys {
+		rating, err := rp.getSavedRating(acntRatings, []byte(pubKey))
+		if err != nil {
+			return err
+		}
+
+		rating = rp.boundRating(int64(rating) + int64(changes[pubKey]))
+		acntRatings.DataTrieTracker().SaveKeyValue([]byte(pubKey), ratingToBytes(rating))
+	}
+
+	return rp.accounts.SaveDataTrie(acntRatings)
+}
+
+func (rp *ratingsProcessor) computeRatingChanges(
+	header data.HeaderHandler,
+	prevHeader data.HeaderHandler,
+) (map[string]int32, error) {
+	changes := make(map[string]int32)
+
+	consensusGroup, err := rp.computeConsensusGroup(header.GetPrevRandSeed(), header.GetRound())
+	if err != nil {
+		return nil, err
+	}
+
+	changes[string(consensusGroup[0].PubKey())] += rp.ratingSettings.ProposerIncreaseRatingStep()
+
+	bitmap := header.GetPubKeysBitmap()
+	for i, v := range consensusGroup {
+		if process.IsSignerInBitmap(bitmap, i) {
+			changes[string(v.PubKey())] += rp.ratingSettings.SignerIncreaseRatingStep()
+		}
+	}
+
+	if prevHeader == nil || prevHeader.IsInterfaceNil() {
+		return changes, nil
+	}
+
+	firstMissedRound := prevHeader.GetRound() + 1
+	if header.GetRound() > firstMissedRound+maxMissedRoundsRated {
+		firstMissedRound = header.GetRound() - maxMissedRoundsRated
+	}
+
+	for round := firstMissedRound; round < header.GetRound(); round++ {
+		missedGroup, err := rp.computeConsensusGroup(header.GetPrevRandSeed(), round)
+		if err != nil {
+			return nil, err
+		}
+
+		changes[string(missedGroup[0].PubKey())] -= rp.ratingSettings.ProposerDecreaseRatingStep()
+	}
+
+	return changes, nil
+}
+
+// computeConsensusGroup computes the consensus group of a round in the same way the consensus does
+func (rp *ratingsProcessor) computeConsensusGroup(prevRandSeed []byte, round uint64) ([]consensus.Validator, error) {
+	randomSource := fmt.Sprintf("%d-%s", round, core.ToB64(prevRandSeed))
+
+	consensusGroup, err := rp.groupSelector.ComputeValidatorsGroup([]byte(randomSource))
+	if err != nil {
+		return nil, err
+	}
+	if len(consensusGroup) == 0 {
+		return nil, process.ErrEmptyConsensusGroup
+	}
+
+	return consensusGroup, nil
+}
+
+func (rp *ratingsProcessor) getRatingsAccount() (state.AccountHandler, error) {
+	acntRatings, err := rp.accounts.GetAccountWithJournal(state.NewAddress(process.RatingsAddress))
+	if err != nil {
+		return nil, err
+	}
+	if acntRatings == nil || acntRatings.IsInterfaceNil() {
+		return nil, process.ErrNilRatingsAccount
+	}
+
+	return acntRatings, nil
+}
+
+// getCommittedRatingsAccount returns the ratings account found in the committed state with the given root hash, or
+// nil if no rating was saved yet
+func (rp *ratingsProcessor) getCommittedRatingsAccount(rootHash []byte) (state.AccountHandler, error) {
+	accounts, err := rp.accounts.RecreateReadOnly(rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	acntRatings, err := accounts.GetExistingAccount(state.NewAddress(process.RatingsAddress))
+	if err == state.ErrAccNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return acntRatings, nil
+}
+
+func (rp *ratingsProcessor) getSavedRating(acntRatings state.AccountHandler, pubKey []byte) (int32, error) {
+	if acntRatings.DataTrie() == nil {
+		return rp.ratingSettings.StartRating(), nil
+	}
+
+	buff, err := acntRatings.DataTrieTracker().RetrieveValue(pubKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(buff) != ratingSizeInBytes {
+		return rp.ratingSettings.StartRating(), nil
+	}
+
+	return int32(binary.BigEndian.Uint32(buff)), nil
+}
+
+func (rp *ratingsProcessor) boundRating(rating int64) int32 {
+	if rating < int64(rp.ratingSettings.MinRating()) {
+		return rp.ratingSettings.MinRating()
+	}
+	if rating > int64(rp.ratingSettings.MaxRating()) {
+		return rp.ratingSettings.MaxRating()
+	}
+
+	return int32(rating)
+}
+
+func ratingToBytes(rating int32) []byte {
+	buff := make([]byte, ratingSizeInBytes)
+	binary.BigEndian.PutUint32(buff, uint32(rating))
+
+	return buff
+}
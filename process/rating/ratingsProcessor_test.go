@@ -0,0 +1,522 @@
+package rating_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/consensus/epoch"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators"
+	"github.com/ElrondNetwork/elrond-go/consensus/validators/groupSelectors"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/state/factory"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/rating"
+	"github.com/ElrondNetwork/elrond-go/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+)
+
+const startRating = 50
+
+var prevRandSeed = []byte("prev rand seed")
+
+func createRatingSettings() *mock.RatingSettingsMock {
+	return &mock.RatingSettingsMock{
+		StartRatingValue:                startRating,
+		MinRatingValue:                  1,
+		MaxRatingValue:                  100,
+		ProposerIncreaseRatingStepValue: 2,
+		SignerIncreaseRatingStepValue:   1,
+		ProposerDecreaseRatingStepValue: 4,
+		SlashingDecreaseRatingStepValue: 20,
+	}
+}
+
+// createGroupSelector returns a selector which rotates the validators pk0, pk1 and pk2, so the leader of a round
+// is the validator with the index round % 3
+func createGroupSelector() *mock.ValidatorGroupSelectorStub {
+	validators := make([]consensus.Validator, 3)
+	for i := range validators {
+		validators[i] = mock.NewValidatorMock(big.NewInt(0), 0, []byte(fmt.Sprintf("pk%d", i)), nil)
+	}
+
+	return &mock.ValidatorGroupSelectorStub{
+		ComputeValidatorsGroupCalled: func(randomness []byte) ([]consensus.Validator, error) {
+			expectedSuffix := "-" + core.ToB64(prevRandSeed)
+			if !strings.HasSuffix(string(randomness), expectedSuffix) {
+				return nil, errors.New("unexpected randomness")
+			}
+
+			round, _ := strconv.Atoi(strings.TrimSuffix(string(randomness), expectedSuffix))
+			group := make([]consensus.Validator, 0, len(validators))
+			for i := 0; i < len(validators); i++ {
+				group = append(group, validators[(round+i)%len(validators)])
+			}
+
+			return group, nil
+		},
+	}
+}
+
+func createRatingsAccount(storage map[string][]byte) *mock.AccountsStub {
+	tracker := &mock.AccountTrackerStub{
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+	}
+	trie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return storage[string(key)], nil
+		},
+	}
+
+	return &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			acnt, _ := state.NewAccount(addressContainer, tracker)
+			acnt.SetDataTrie(trie)
+			return acnt, nil
+		},
+		SaveDataTrieCalled: func(acountWrapper state.AccountHandler) error {
+			for k, v := range acountWrapper.DataTrieTracker().DirtyData() {
+				storage[k] = v
+			}
+			acountWrapper.DataTrieTracker().ClearDataCaches()
+			return nil
+		},
+		RecreateReadOnlyCalled: func(rootHash []byte) (state.AccountsAdapter, error) {
+			return createCommittedRatingsAccount(storage), nil
+		},
+	}
+}
+
+// createCommittedRatingsAccount returns a read only view of the ratings saved until now, as if the block which
+// saved them was committed
+func createCommittedRatingsAccount(storage map[string][]byte) *mock.AccountsStub {
+	committed := make(map[string][]byte, len(storage))
+	for k, v := range storage {
+		committed[k] = v
+	}
+	trie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return committed[string(key)], nil
+		},
+	}
+
+	return &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			if len(committed) == 0 {
+				return nil, state.ErrAccNotFound
+			}
+
+			acnt, _ := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+			acnt.SetDataTrie(trie)
+			return acnt, nil
+		},
+	}
+}
+
+func createHeader(round uint64, bitmap []byte) *block.Header {
+	return &block.Header{
+		Round:         round,
+		PrevRandSeed:  prevRandSeed,
+		PubKeysBitmap: bitmap,
+	}
+}
+
+func savedRating(storage map[string][]byte, pubKey string) int32 {
+	return int32(binary.BigEndian.Uint32(storage[pubKey]))
+}
+
+//------- NewRatingsProcessor
+
+func TestNewRatingsProcessor_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rating.NewRatingsProcessor(nil, createGroupSelector(), createRatingSettings())
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestNewRatingsProcessor_NilGroupSelectorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rating.NewRatingsProcessor(&mock.AccountsStub{}, nil, createRatingSettings())
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilValidatorGroupSelector, err)
+}
+
+func TestNewRatingsProcessor_NilRatingSettingsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rating.NewRatingsProcessor(&mock.AccountsStub{}, createGroupSelector(), nil)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilRatingSettings, err)
+}
+
+func TestNewRatingsProcessor_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rating.NewRatingsProcessor(&mock.AccountsStub{}, createGroupSelector(), createRatingSettings())
+
+	assert.NotNil(t, rp)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(startRating), rp.GetRating("pk0"))
+}
+
+//------- ProcessCommittedHeader
+
+func TestRatingsProcessor_ProcessCommittedHeaderNilHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, _ := rating.NewRatingsProcessor(&mock.AccountsStub{}, createGroupSelector(), createRatingSettings())
+
+	err := rp.ProcessCommittedHeader(nil, nil)
+
+	assert.Equal(t, process.ErrNilBlockHeader, err)
+}
+
+func TestRatingsProcessor_ProcessCommittedHeaderGroupSelectionErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, _ := rating.NewRatingsProcessor(&mock.AccountsStub{}, createGroupSelector(), createRatingSettings())
+	header := createHeader(1, []byte{0})
+	header.PrevRandSeed = []byte("other rand seed")
+
+	err := rp.ProcessCommittedHeader(header, nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestRatingsProcessor_ProcessCommittedHeaderEmptyConsensusGroupShouldErr(t *testing.T) {
+	t.Parallel()
+
+	groupSelector := &mock.ValidatorGroupSelectorStub{
+		ComputeValidatorsGroupCalled: func(randomness []byte) ([]consensus.Validator, error) {
+			return make([]consensus.Validator, 0), nil
+		},
+	}
+	rp, _ := rating.NewRatingsProcessor(&mock.AccountsStub{}, groupSelector, createRatingSettings())
+
+	err := rp.ProcessCommittedHeader(createHeader(1, []byte{0}), nil)
+
+	assert.Equal(t, process.ErrEmptyConsensusGroup, err)
+}
+
+//------- SaveRatings
+
+func TestRatingsProcessor_SaveRatingsWithoutChangesShouldNotTouchState(t *testing.T) {
+	t.Parallel()
+
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			assert.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}
+	rp, _ := rating.NewRatingsProcessor(accounts, createGroupSelector(), createRatingSettings())
+
+	err := rp.SaveRatings()
+
+	assert.Nil(t, err)
+}
+
+func TestRatingsProcessor_SaveRatingsShouldRewardLeaderAndSigners(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	rp, _ := rating.NewRatingsProcessor(createRatingsAccount(storage), createGroupSelector(), createRatingSettings())
+
+	//round 1 has the group pk1, pk2, pk0 and only pk1 and pk2 signed
+	err := rp.ProcessCommittedHeader(createHeader(1, []byte{3}), createHeader(0, nil))
+	assert.Nil(t, err)
+
+	err = rp.SaveRatings()
+	assert.Nil(t, err)
+
+	assert.Equal(t, int32(startRating+2+1), savedRating(storage, "pk1"))
+	assert.Equal(t, int32(startRating+1), savedRating(storage, "pk2"))
+	_, found := storage["pk0"]
+	assert.False(t, found)
+}
+
+func TestRatingsProcessor_SaveRatingsShouldPenalizeMissedRounds(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	rp, _ := rating.NewRatingsProcessor(createRatingsAccount(storage), createGroupSelector(), createRatingSettings())
+
+	//rounds 5 and 6 were missed by pk2 and pk0, round 7 was produced by pk1 without any other signer
+	err := rp.ProcessCommittedHeader(createHeader(7, []byte{1}), createHeader(4, nil))
+	assert.Nil(t, err)
+
+	err = rp.SaveRatings()
+	assert.Nil(t, err)
+
+	assert.Equal(t, int32(startRating-4), savedRating(storage, "pk2"))
+	assert.Equal(t, int32(startRating-4), savedRating(storage, "pk0"))
+	assert.Equal(t, int32(startRating+2+1), savedRating(storage, "pk1"))
+}
+
+func TestRatingsProcessor_SaveRatingsShouldBoundRatings(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	storage["pk0"] = []byte{0, 0, 0, 99}
+	storage["pk1"] = []byte{0, 0, 0, 2}
+	rp, _ := rating.NewRatingsProcessor(createRatingsAccount(storage), createGroupSelector(), createRatingSettings())
+
+	//round 3 is produced by pk0 after pk1 missed round 1 and pk2 missed round 2
+	err := rp.ProcessCommittedHeader(createHeader(3, []byte{1}), createHeader(0, nil))
+	assert.Nil(t, err)
+
+	err = rp.SaveRatings()
+	assert.Nil(t, err)
+
+	assert.Equal(t, int32(100), savedRating(storage, "pk0"))
+	assert.Equal(t, int32(1), savedRating(storage, "pk1"))
+	assert.Equal(t, int32(startRating-4), savedRating(storage, "pk2"))
+}
+
+func TestRatingsProcessor_SaveRatingsCalledTwiceShouldSaveSameRatings(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	accounts := createRatingsAccount(storage)
+	rp, _ := rating.NewRatingsProcessor(accounts, createGroupSelector(), createRatingSettings())
+
+	_ = rp.ProcessCommittedHeader(createHeader(1, []byte{1}), createHeader(0, nil))
+
+	//the first block was not committed, so its changes are dropped from the state
+	_ = rp.SaveRatings()
+	for k := range storage {
+		delete(storage, k)
+	}
+	err := rp.SaveRatings()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(startRating+2+1), savedRating(storage, "pk1"))
+}
+
+func TestRatingsProcessor_GetRatingShouldReturnRatingsSavedByCommittedBlock(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	rp, _ := rating.NewRatingsProcessor(createRatingsAccount(storage), createGroupSelector(), createRatingSettings())
+
+	_ = rp.ProcessCommittedHeader(createHeader(1, []byte{1}), createHeader(0, nil))
+	_ = rp.SaveRatings()
+
+	//the ratings are not available until the block which saved them is committed
+	assert.Equal(t, int32(startRating), rp.GetRating("pk1"))
+
+	_ = rp.ProcessCommittedHeader(createHeader(2, []byte{0}), createHeader(1, nil))
+
+	assert.Equal(t, int32(startRating+2+1), rp.GetRating("pk1"))
+	assert.Equal(t, int32(startRating), rp.GetRating("pk2"))
+}
+
+func TestRatingsProcessor_GetRatingAfterRestartShouldReturnRatingsSavedByCommittedBlock(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	accounts := createRatingsAccount(storage)
+	rp, _ := rating.NewRatingsProcessor(accounts, createGroupSelector(), createRatingSettings())
+	_ = rp.ProcessCommittedHeader(createHeader(1, []byte{1}), createHeader(0, nil))
+	_ = rp.SaveRatings()
+	_ = rp.ProcessCommittedHeader(createHeader(2, []byte{0}), createHeader(1, nil))
+
+	restartedRp, _ := rating.NewRatingsProcessor(accounts, createGroupSelector(), createRatingSettings())
+	assert.Equal(t, int32(startRating), restartedRp.GetRating("pk1"))
+
+	err := restartedRp.LoadRatings([]byte("root hash"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, rp.GetRating("pk1"), restartedRp.GetRating("pk1"))
+	assert.Equal(t, int32(startRating+2+1), restartedRp.GetRating("pk1"))
+}
+
+func TestRatingsProcessor_LoadRatingsShouldDropTheChangesNotSaved(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	rp, _ := rating.NewRatingsProcessor(createRatingsAccount(storage), createGroupSelector(), createRatingSettings())
+	_ = rp.ProcessCommittedHeader(createHeader(1, []byte{1}), createHeader(0, nil))
+
+	err := rp.LoadRatings([]byte("root hash"))
+	assert.Nil(t, err)
+
+	err = rp.SaveRatings()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(storage))
+}
+
+func TestRatingsProcessor_LoadRatingsAccountsErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	accounts := &mock.AccountsStub{
+		RecreateReadOnlyCalled: func(rootHash []byte) (state.AccountsAdapter, error) {
+			return nil, errExpected
+		},
+	}
+	rp, _ := rating.NewRatingsProcessor(accounts, createGroupSelector(), createRatingSettings())
+
+	err := rp.LoadRatings([]byte("root hash"))
+
+	assert.Equal(t, errExpected, err)
+}
+
+func TestRatingsProcessor_SaveRatingsShouldPenalizeSlashedPeers(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	rp, _ := rating.NewRatingsProcessor(createRatingsAccount(storage), createGroupSelector(), createRatingSettings())
+
+	//round 1 is produced by pk1 without any other signer, while pk2 was slashed and pk0 only registered
+	_ = rp.ProcessCommittedHeader(createHeader(1, []byte{1}), createHeader(0, nil))
+	rp.ProcessSlashedPeers([]block.PeerData{
+		{PublicKey: []byte("pk2"), Action: block.PeerSlashing},
+		{PublicKey: []byte("pk0"), Action: block.PeerRegistrantion},
+	})
+	err := rp.SaveRatings()
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(startRating+2+1), savedRating(storage, "pk1"))
+	assert.Equal(t, int32(startRating-20), savedRating(storage, "pk2"))
+	_, found := storage["pk0"]
+	assert.False(t, found)
+}
+
+func TestRatingsProcessor_SaveRatingsAccountsErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errExpected
+		},
+		RecreateReadOnlyCalled: func(rootHash []byte) (state.AccountsAdapter, error) {
+			return createCommittedRatingsAccount(make(map[string][]byte)), nil
+		},
+	}
+	rp, _ := rating.NewRatingsProcessor(accounts, createGroupSelector(), createRatingSettings())
+
+	_ = rp.ProcessCommittedHeader(createHeader(1, []byte{1}), nil)
+	err := rp.SaveRatings()
+
+	assert.Equal(t, errExpected, err)
+}
+
+//------- restart
+
+// ratingsNode holds the components of a node which select the consensus groups with the committed ratings
+type ratingsNode struct {
+	groupSelector  consensus.ValidatorGroupSelector
+	ratingsHandler process.RatingsHandler
+	epochHandler   process.EpochHandler
+}
+
+func createRatingsNode(t *testing.T, accounts state.AccountsAdapter, pubKeys [][]byte) *ratingsNode {
+	initialValidators := make([]consensus.Validator, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		v, err := validators.NewValidator(big.NewInt(0), startRating, pubKey, pubKey)
+		assert.Nil(t, err)
+		initialValidators = append(initialValidators, v)
+	}
+
+	groupSelector, err := groupSelectors.NewIndexHashedGroupSelector(3, mock.HasherMock{})
+	assert.Nil(t, err)
+	err = groupSelector.LoadEligibleList(initialValidators)
+	assert.Nil(t, err)
+
+	rp, err := rating.NewRatingsProcessor(accounts, groupSelector, createRatingSettings())
+	assert.Nil(t, err)
+
+	em, err := epoch.NewEpochManager(
+		100,
+		0,
+		3,
+		mock.HasherMock{},
+		mock.NewOneShardCoordinatorMock(),
+		groupSelector,
+		rp,
+		map[uint32][]consensus.Validator{0: initialValidators},
+	)
+	assert.Nil(t, err)
+
+	return &ratingsNode{
+		groupSelector:  groupSelector,
+		ratingsHandler: rp,
+		epochHandler:   em,
+	}
+}
+
+func TestRatingsProcessor_RestartShouldComputeTheSameConsensusGroups(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	db, _ := memorydb.New()
+	tr, _ := trie.NewTrie(db, marshalizer, mock.HasherMock{})
+	accounts, _ := state.NewAccountsDB(tr, mock.HasherMock{}, marshalizer, factory.NewAccountCreator(), nil)
+
+	pubKeys := make([][]byte, 0)
+	for i := 0; i < 6; i++ {
+		pubKeys = append(pubKeys, []byte(fmt.Sprintf("pk%d", i)))
+	}
+	epochStart := []block.EpochStartShardData{{ShardId: 0, PublicKeys: pubKeys, Addresses: pubKeys}}
+
+	//block 1 is committed in a metachain round which slashed pk0 twice, so block 2 saves its lower rating
+	node := createRatingsNode(t, accounts, pubKeys)
+	header1 := &block.Header{Nonce: 1, Round: 1, PrevRandSeed: prevRandSeed, PubKeysBitmap: []byte{7}}
+	err := node.ratingsHandler.ProcessCommittedHeader(header1, &block.Header{})
+	assert.Nil(t, err)
+	slashed := []block.PeerData{{PublicKey: []byte("pk0"), Action: block.PeerSlashing}}
+	node.ratingsHandler.ProcessSlashedPeers(slashed)
+	node.ratingsHandler.ProcessSlashedPeers(slashed)
+
+	err = node.ratingsHandler.SaveRatings()
+	assert.Nil(t, err)
+	rootHash, err := accounts.Commit()
+	assert.Nil(t, err)
+
+	//block 2 starts epoch 1, so the new eligible list gets the ratings saved by it
+	header2 := &block.Header{Nonce: 2, Round: 2, Epoch: 1, PrevRandSeed: prevRandSeed, RootHash: rootHash}
+	err = node.ratingsHandler.ProcessCommittedHeader(header2, header1)
+	assert.Nil(t, err)
+	err = node.epochHandler.SetEpochStart(1, epochStart)
+	assert.Nil(t, err)
+
+	restartedNode := createRatingsNode(t, accounts, pubKeys)
+	err = restartedNode.ratingsHandler.LoadRatings(rootHash)
+	assert.Nil(t, err)
+	err = restartedNode.epochHandler.RestoreEpochStart(1, epochStart, nil)
+	assert.Nil(t, err)
+
+	for round := 3; round < 30; round++ {
+		randomness := []byte(fmt.Sprintf("%d-%s", round, core.ToB64(prevRandSeed)))
+		group, err := node.groupSelector.ComputeValidatorsGroup(randomness)
+		assert.Nil(t, err)
+		restartedGroup, err := restartedNode.groupSelector.ComputeValidatorsGroup(randomness)
+		assert.Nil(t, err)
+
+		assert.Equal(t, len(group), len(restartedGroup))
+		for i := range group {
+			assert.Equal(t, group[i].PubKey(), restartedGroup[i].PubKey())
+			assert.Equal(t, group[i].Rating(), restartedGroup[i].Rating())
+		}
+	}
+}
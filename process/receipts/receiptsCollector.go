@@ -0,0 +1,114 @@
+package receipts
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+var log = logger.DefaultLogger()
+
+// receiptsCollector keeps the receipts of the transactions executed in the current block. When the block is
+// committed, the receipts of its transactions are completed with the block data and saved in the receipts unit
+type receiptsCollector struct {
+	store       dataRetriever.StorageService
+	marshalizer marshal.Marshalizer
+
+	mutReceipts sync.Mutex
+	receipts    map[string]*receipt.Receipt
+}
+
+// NewReceiptsCollector creates a new receipts collector
+func NewReceiptsCollector(
+	store dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+) (*receiptsCollector, error) {
+	if store == nil {
+		return nil, process.ErrNilStorage
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+
+	return &receiptsCollector{
+		store:       store,
+		marshalizer: marshalizer,
+		receipts:    make(map[string]*receipt.Receipt),
+	}, nil
+}
+
+// CreateBlockStarted drops the receipts collected for the previous block
+func (rc *receiptsCollector) CreateBlockStarted() {
+	rc.mutReceipts.Lock()
+	rc.receipts = make(map[string]*receipt.Receipt)
+	rc.mutReceipts.Unlock()
+}
+
+// AddReceipt keeps the receipt of an executed transaction, replacing the one of a previous execution, if any
+func (rc *receiptsCollector) AddReceipt(txHash []byte, rcpt *receipt.Receipt) {
+	if rcpt == nil {
+		return
+	}
+
+	rcpt.TxHash = txHash
+
+	rc.mutReceipts.Lock()
+	rc.receipts[string(txHash)] = rcpt
+	rc.mutReceipts.Unlock()
+}
+
+// GetReceiptsTxHashes returns the given transactions which have a collected receipt, whose receipts are written by
+// SaveReceipts
+func (rc *receiptsCollector) GetReceiptsTxHashes(txHashes [][]byte) [][]byte {
+	rc.mutReceipts.Lock()
+	defer rc.mutReceipts.Unlock()
+
+	receiptsTxHashes := make([][]byte, 0)
+	for _, txHash := range txHashes {
+		if _, ok := rc.receipts[string(txHash)]; ok {
+			receiptsTxHashes = append(receiptsTxHashes, txHash)
+		}
+	}
+
+	return receiptsTxHashes
+}
+
+// SaveReceipts saves the receipts of the given transactions, executed by the committed block
+func (rc *receiptsCollector) SaveReceipts(headerHash []byte, header data.HeaderHandler, txHashes [][]byte) {
+	if header == nil || header.IsInterfaceNil() {
+		return
+	}
+
+	rc.mutReceipts.Lock()
+	defer rc.mutReceipts.Unlock()
+
+	for _, txHash := range txHashes {
+		rcpt, ok := rc.receipts[string(txHash)]
+		if !ok {
+			continue
+		}
+
+		rcpt.BlockHash = headerHash
+		rcpt.BlockNonce = header.GetNonce()
+
+		buff, err := rc.marshalizer.Marshal(rcpt)
+		if err != nil {
+			log.Error(fmt.Sprintf("could not marshal the receipt of tx %s: %s\n", core.ToHex(txHash), err.Error()))
+			continue
+		}
+
+		err = rc.store.Put(dataRetriever.ReceiptsUnit, txHash, buff)
+		if err != nil {
+			log.Error(fmt.Sprintf("could not save the receipt of tx %s: %s\n", core.ToHex(txHash), err.Error()))
+		}
+	}
+
+	rc.receipts = make(map[string]*receipt.Receipt)
+}
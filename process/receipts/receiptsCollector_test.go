@@ -0,0 +1,97 @@
+package receipts_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/receipts"
+	"github.com/stretchr/testify/assert"
+)
+
+func createStoreSavingReceipts(saved map[string][]byte) *mock.ChainStorerMock {
+	return &mock.ChainStorerMock{
+		PutCalled: func(unitType dataRetriever.UnitType, key []byte, value []byte) error {
+			if unitType == dataRetriever.ReceiptsUnit {
+				saved[string(key)] = value
+			}
+			return nil
+		},
+	}
+}
+
+func TestNewReceiptsCollector_NilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rc, err := receipts.NewReceiptsCollector(nil, &mock.MarshalizerMock{})
+
+	assert.Nil(t, rc)
+	assert.Equal(t, process.ErrNilStorage, err)
+}
+
+func TestNewReceiptsCollector_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rc, err := receipts.NewReceiptsCollector(&mock.ChainStorerMock{}, nil)
+
+	assert.Nil(t, rc)
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+}
+
+func TestNewReceiptsCollector_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	rc, err := receipts.NewReceiptsCollector(&mock.ChainStorerMock{}, &mock.MarshalizerMock{})
+
+	assert.NotNil(t, rc)
+	assert.Nil(t, err)
+}
+
+func TestReceiptsCollector_SaveReceiptsShouldSaveOnlyTheReceiptsOfTheGivenTxs(t *testing.T) {
+	t.Parallel()
+
+	saved := make(map[string][]byte)
+	marshalizer := &mock.MarshalizerMock{}
+	rc, _ := receipts.NewReceiptsCollector(createStoreSavingReceipts(saved), marshalizer)
+
+	rc.AddReceipt([]byte("tx1"), &receipt.Receipt{Status: receipt.Executed, GasUsed: 10})
+	rc.AddReceipt([]byte("tx2"), &receipt.Receipt{Status: receipt.Failed, GasUsed: 20})
+	rc.SaveReceipts([]byte("header hash"), &block.Header{Nonce: 7}, [][]byte{[]byte("tx1"), []byte("tx3")})
+
+	assert.Equal(t, 1, len(saved))
+	rcpt := &receipt.Receipt{}
+	_ = marshalizer.Unmarshal(rcpt, saved["tx1"])
+	assert.Equal(t, []byte("tx1"), rcpt.TxHash)
+	assert.Equal(t, receipt.Executed, rcpt.Status)
+	assert.Equal(t, uint64(10), rcpt.GasUsed)
+	assert.Equal(t, []byte("header hash"), rcpt.BlockHash)
+	assert.Equal(t, uint64(7), rcpt.BlockNonce)
+}
+
+func TestReceiptsCollector_CreateBlockStartedShouldDropTheReceipts(t *testing.T) {
+	t.Parallel()
+
+	saved := make(map[string][]byte)
+	rc, _ := receipts.NewReceiptsCollector(createStoreSavingReceipts(saved), &mock.MarshalizerMock{})
+
+	rc.AddReceipt([]byte("tx1"), &receipt.Receipt{})
+	rc.CreateBlockStarted()
+	rc.SaveReceipts([]byte("header hash"), &block.Header{}, [][]byte{[]byte("tx1")})
+
+	assert.Equal(t, 0, len(saved))
+}
+
+func TestReceiptsCollector_GetReceiptsTxHashesShouldReturnTheTxsWithReceipts(t *testing.T) {
+	t.Parallel()
+
+	rc, _ := receipts.NewReceiptsCollector(&mock.ChainStorerMock{}, &mock.MarshalizerMock{})
+
+	rc.AddReceipt([]byte("tx1"), &receipt.Receipt{})
+	rc.AddReceipt([]byte("tx2"), &receipt.Receipt{})
+	txHashes := rc.GetReceiptsTxHashes([][]byte{[]byte("tx1"), []byte("tx3")})
+
+	assert.Equal(t, [][]byte{[]byte("tx1")}, txHashes)
+}
@@ -0,0 +1,336 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+var log = logger.DefaultLogger()
+
+// rewardsProcessor creates the reward transactions of a shard block. The leader of the block gets its part of the
+// block reward, while the rest is split between the signers of the previous block, found in its PubKeysBitmap.
+// All the validators compute the reward transactions in the same way, so a block which does not hold the expected
+// rewards miniblock is rejected. The reward transactions of the committed block are saved in the reward transactions
+// unit
+type rewardsProcessor struct {
+	accounts          state.AccountsAdapter
+	store             dataRetriever.StorageService
+	shardCoordinator  sharding.Coordinator
+	groupSelector     consensus.ValidatorGroupSelector
+	hasher            hashing.Hasher
+	marshalizer       marshal.Marshalizer
+	rewardsCalculator process.RewardsCalculator
+	scForwarder       process.IntermediateTransactionHandler
+
+	mutRewards         sync.RWMutex
+	accumulatedRewards *big.Int
+	rewardTxs          map[string]*rewardTx.RewardTx
+}
+
+// NewRewardsProcessor creates a new rewards processor
+func NewRewardsProcessor(
+	accounts state.AccountsAdapter,
+	store dataRetriever.StorageService,
+	shardCoordinator sharding.Coordinator,
+	groupSelector consensus.ValidatorGroupSelector,
+	hasher hashing.Hasher,
+	marshalizer marshal.Marshalizer,
+	rewardsCalculator process.RewardsCalculator,
+	scForwarder process.IntermediateTransactionHandler,
+) (*rewardsProcessor, error) {
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if store == nil {
+		return nil, process.ErrNilStorage
+	}
+	if shardCoordinator == nil {
+		return nil, process.ErrNilShardCoordinator
+	}
+	if groupSelector == nil {
+		return nil, process.ErrNilValidatorGroupSelector
+	}
+	if hasher == nil {
+		return nil, process.ErrNilHasher
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if rewardsCalculator == nil {
+		return nil, process.ErrNilRewardsCalculator
+	}
+	if scForwarder == nil {
+		return nil, process.ErrNilIntermediateTransactionHandler
+	}
+
+	return &rewardsProcessor{
+		accounts:           accounts,
+		store:              store,
+		shardCoordinator:   shardCoordinator,
+		groupSelector:      groupSelector,
+		hasher:             hasher,
+		marshalizer:        marshalizer,
+		rewardsCalculator:  rewardsCalculator,
+		scForwarder:        scForwarder,
+		accumulatedRewards: big.NewInt(0),
+		rewardTxs:          make(map[string]*rewardTx.RewardTx),
+	}, nil
+}
+
+// CreateBlockStarted resets the rewards accumulated for the previous block
+func (rp *rewardsProcessor) CreateBlockStarted() {
+	rp.mutRewards.Lock()
+	rp.accumulatedRewards = big.NewInt(0)
+	rp.rewardTxs = make(map[string]*rewardTx.RewardTx)
+	rp.mutRewards.Unlock()
+}
+
+// CreateRewardsMiniBlock creates the reward transactions of the block produced in the given round, credits their
+// values to the receivers and returns the miniblock holding their hashes. The signers of the given signed header
+// are rewarded, while a nil signed header rewards only the leader. The rewards of the receivers from other shards
+// are sent to them as smart contract results. It returns a nil miniblock if the block has no self shard rewards
+func (rp *rewardsProcessor) CreateRewardsMiniBlock(
+	round uint64,
+	leaderAddress []byte,
+	signedHeader data.HeaderHandler,
+) (*block.MiniBlock, error) {
+	rp.CreateBlockStarted()
+
+	blockReward := rp.rewardsCalculator.ComputeBlockReward(round)
+	if blockReward.Sign() <= 0 {
+		return nil, nil
+	}
+
+	signersAddresses, err := rp.computeSignersAddresses(signedHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := rp.splitBlockReward(blockReward, leaderAddress, signersAddresses)
+
+	addresses := make([]string, 0, len(rewards))
+	for address := range rewards {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	accumulatedRewards := big.NewInt(0)
+	rewardTxs := make(map[string]*rewardTx.RewardTx)
+	txHashes := make([][]byte, 0, len(addresses))
+	for _, address := range addresses {
+		value := rewards[address]
+		if len(address) == 0 || value.Sign() <= 0 {
+			continue
+		}
+
+		adrReceiver := state.NewAddress([]byte(address))
+		if rp.shardCoordinator.ComputeId(adrReceiver) != rp.shardCoordinator.SelfId() {
+			err = rp.forwardToShard(round, process.RewardsAddress, []byte(address), value)
+			if err != nil {
+				return nil, err
+			}
+
+			accumulatedRewards.Add(accumulatedRewards, value)
+			continue
+		}
+
+		tx := &rewardTx.RewardTx{
+			Round:   round,
+			Value:   value,
+			RcvAddr: []byte(address),
+			ShardId: rp.shardCoordinator.SelfId(),
+		}
+
+		txHash, err := core.CalculateHash(rp.marshalizer, rp.hasher, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = rp.creditReward(adrReceiver, value)
+		if err != nil {
+			return nil, err
+		}
+
+		txHashes = append(txHashes, txHash)
+		rewardTxs[string(txHash)] = tx
+		accumulatedRewards.Add(accumulatedRewards, value)
+	}
+
+	rp.mutRewards.Lock()
+	rp.accumulatedRewards = accumulatedRewards
+	rp.rewardTxs = rewardTxs
+	rp.mutRewards.Unlock()
+
+	if len(txHashes) == 0 {
+		return nil, nil
+	}
+
+	miniBlock := &block.MiniBlock{
+		TxHashes:        txHashes,
+		ReceiverShardID: rp.shardCoordinator.SelfId(),
+		SenderShardID:   rp.shardCoordinator.SelfId(),
+		Type:            block.RewardsBlock,
+	}
+
+	return miniBlock, nil
+}
+
+// ForwardFees creates the smart contract result which credits the fees of the block produced in the given round to
+// a leader whose reward address is in another shard. The result is sent to that shard in a cross shard miniblock
+func (rp *rewardsProcessor) ForwardFees(round uint64, leaderAddress []byte, fees *big.Int) error {
+	if fees == nil || fees.Sign() <= 0 {
+		return nil
+	}
+
+	return rp.forwardToShard(round, process.FeesAddress, leaderAddress, fees)
+}
+
+// forwardToShard adds to the smart contract results of the block the transfer of the given value to a receiver from
+// another shard. The result references the hash of the reward transaction recording the transfer, which makes
+// the results of different rounds and receivers distinct
+func (rp *rewardsProcessor) forwardToShard(round uint64, sndAddr []byte, rcvAddr []byte, value *big.Int) error {
+	tx := &rewardTx.RewardTx{
+		Round:   round,
+		Value:   value,
+		RcvAddr: rcvAddr,
+		ShardId: rp.shardCoordinator.SelfId(),
+	}
+
+	txHash, err := core.CalculateHash(rp.marshalizer, rp.hasher, tx)
+	if err != nil {
+		return err
+	}
+
+	scr := &smartContractResult.SmartContractResult{
+		Nonce:   round,
+		Value:   big.NewInt(0).Set(value),
+		RcvAddr: rcvAddr,
+		SndAddr: sndAddr,
+		TxHash:  txHash,
+	}
+
+	return rp.scForwarder.AddIntermediateTransactions([]data.TransactionHandler{scr})
+}
+
+// AccumulatedRewards returns the value minted by the reward transactions of the current block
+func (rp *rewardsProcessor) AccumulatedRewards() *big.Int {
+	rp.mutRewards.RLock()
+	accumulatedRewards := big.NewInt(0).Set(rp.accumulatedRewards)
+	rp.mutRewards.RUnlock()
+
+	return accumulatedRewards
+}
+
+// SaveRewardTxs saves the given reward transactions, created for the committed block
+func (rp *rewardsProcessor) SaveRewardTxs(txHashes [][]byte) {
+	rp.mutRewards.RLock()
+	defer rp.mutRewards.RUnlock()
+
+	for _, txHash := range txHashes {
+		tx, ok := rp.rewardTxs[string(txHash)]
+		if !ok {
+			log.Error(fmt.Sprintf("missing reward tx %s\n", core.ToHex(txHash)))
+			continue
+		}
+
+		buff, err := rp.marshalizer.Marshal(tx)
+		if err != nil {
+			log.Error(fmt.Sprintf("could not marshal the reward tx %s: %s\n", core.ToHex(txHash), err.Error()))
+			continue
+		}
+
+		err = rp.store.Put(dataRetriever.RewardTransactionUnit, txHash, buff)
+		if err != nil {
+			log.Error(fmt.Sprintf("could not save the reward tx %s: %s\n", core.ToHex(txHash), err.Error()))
+		}
+	}
+}
+
+// computeSignersAddresses returns the reward addresses of the signers of the given header. The consensus group is
+// computed in the same way it was computed when the header was produced, so the eligible list must not have been
+// changed since then
+func (rp *rewardsProcessor) computeSignersAddresses(signedHeader data.HeaderHandler) ([][]byte, error) {
+	if signedHeader == nil || signedHeader.IsInterfaceNil() {
+		return make([][]byte, 0), nil
+	}
+
+	randomSource := fmt.Sprintf("%d-%s", signedHeader.GetRound(), core.ToB64(signedHeader.GetPrevRandSeed()))
+	consensusGroup, err := rp.groupSelector.ComputeValidatorsGroup([]byte(randomSource))
+	if err != nil {
+		return nil, err
+	}
+
+	signersAddresses := make([][]byte, 0, len(consensusGroup))
+	bitmap := signedHeader.GetPubKeysBitmap()
+	for i, v := range consensusGroup {
+		if process.IsSignerInBitmap(bitmap, i) {
+			signersAddresses = append(signersAddresses, v.Address())
+		}
+	}
+
+	return signersAddresses, nil
+}
+
+// splitBlockReward gives the leader its part of the block reward and splits the rest between the given signers.
+// The leader also gets what remains from the integer division and, if there are no signers, the whole block reward
+func (rp *rewardsProcessor) splitBlockReward(
+	blockReward *big.Int,
+	leaderAddress []byte,
+	signersAddresses [][]byte,
+) map[string]*big.Int {
+	rewards := make(map[string]*big.Int)
+	addReward := func(address []byte, value *big.Int) {
+		if _, ok := rewards[string(address)]; !ok {
+			rewards[string(address)] = big.NewInt(0)
+		}
+		rewards[string(address)].Add(rewards[string(address)], value)
+	}
+
+	if len(signersAddresses) == 0 {
+		addReward(leaderAddress, blockReward)
+		return rewards
+	}
+
+	leaderReward := rp.rewardsCalculator.ComputeLeaderReward(blockReward)
+	signersReward := big.NewInt(0).Sub(blockReward, leaderReward)
+
+	numSigners := big.NewInt(int64(len(signersAddresses)))
+	signerReward, remainder := big.NewInt(0).DivMod(signersReward, numSigners, big.NewInt(0))
+
+	addReward(leaderAddress, big.NewInt(0).Add(leaderReward, remainder))
+	for _, address := range signersAddresses {
+		addReward(address, signerReward)
+	}
+
+	return rewards
+}
+
+func (rp *rewardsProcessor) creditReward(adrReceiver state.AddressContainer, value *big.Int) error {
+	acntWrp, err := rp.accounts.GetAccountWithJournal(adrReceiver)
+	if err != nil {
+		return err
+	}
+
+	acntReceiver, ok := acntWrp.(*state.Account)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	return acntReceiver.SetBalanceWithJournal(big.NewInt(0).Add(acntReceiver.Balance, value))
+}
@@ -0,0 +1,596 @@
+package rewards_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/consensus"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/rewards"
+	"github.com/stretchr/testify/assert"
+)
+
+var leaderAddress = []byte("leader")
+
+// createGroupSelector returns a selector which always computes the group formed by the validators with the
+// reward addresses adr0, adr1 and adr2
+func createGroupSelector() *mock.ValidatorGroupSelectorStub {
+	validators := make([]consensus.Validator, 3)
+	for i := range validators {
+		validators[i] = mock.NewValidatorMock(big.NewInt(0), 0, []byte(fmt.Sprintf("pk%d", i)), []byte(fmt.Sprintf("adr%d", i)))
+	}
+
+	return &mock.ValidatorGroupSelectorStub{
+		ComputeValidatorsGroupCalled: func(randomness []byte) ([]consensus.Validator, error) {
+			return validators, nil
+		},
+	}
+}
+
+func createRewardsCalculator(blockReward int64) *mock.RewardsCalculatorStub {
+	return &mock.RewardsCalculatorStub{
+		ComputeBlockRewardCalled: func(round uint64) *big.Int {
+			return big.NewInt(blockReward)
+		},
+		ComputeLeaderRewardCalled: func(blockReward *big.Int) *big.Int {
+			return big.NewInt(0).Div(blockReward, big.NewInt(2))
+		},
+	}
+}
+
+func createBalancesAccounts(balances map[string]*big.Int) *mock.AccountsStub {
+	tracker := &mock.AccountTrackerStub{
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			balances[string(accountHandler.AddressContainer().Bytes())] = accountHandler.(*state.Account).Balance
+			return nil
+		},
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+	}
+
+	return &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			acnt, _ := state.NewAccount(addressContainer, tracker)
+			if balance, ok := balances[string(addressContainer.Bytes())]; ok {
+				acnt.Balance = balance
+			}
+			return acnt, nil
+		},
+	}
+}
+
+func createRewardsProcessor(accounts state.AccountsAdapter, blockReward int64) process.RewardsHandler {
+	return createRewardsProcessorWithForwarder(accounts, blockReward, &mock.IntermediateTransactionHandlerMock{})
+}
+
+func createRewardsProcessorWithForwarder(
+	accounts state.AccountsAdapter,
+	blockReward int64,
+	scForwarder process.IntermediateTransactionHandler,
+) process.RewardsHandler {
+	rp, _ := rewards.NewRewardsProcessor(
+		accounts,
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(blockReward),
+		scForwarder,
+	)
+
+	return rp
+}
+
+//------- NewRewardsProcessor
+
+func TestNewRewardsProcessor_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		nil,
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestNewRewardsProcessor_NilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		nil,
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilStorage, err)
+}
+
+func TestNewRewardsProcessor_NilShardCoordinatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		&mock.ChainStorerMock{},
+		nil,
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilShardCoordinator, err)
+}
+
+func TestNewRewardsProcessor_NilGroupSelectorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		nil,
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilValidatorGroupSelector, err)
+}
+
+func TestNewRewardsProcessor_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		nil,
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilHasher, err)
+}
+
+func TestNewRewardsProcessor_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		nil,
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+}
+
+func TestNewRewardsProcessor_NilRewardsCalculatorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		nil,
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilRewardsCalculator, err)
+}
+
+func TestNewRewardsProcessor_NilScForwarderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		nil,
+	)
+
+	assert.Nil(t, rp)
+	assert.Equal(t, process.ErrNilIntermediateTransactionHandler, err)
+}
+
+func TestNewRewardsProcessor_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	rp, err := rewards.NewRewardsProcessor(
+		&mock.AccountsStub{},
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.NotNil(t, rp)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), rp.AccumulatedRewards())
+}
+
+//------- CreateRewardsMiniBlock
+
+func TestRewardsProcessor_CreateRewardsMiniBlockNoRewardShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			assert.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}
+	rp := createRewardsProcessor(accounts, 0)
+
+	miniBlock, err := rp.CreateRewardsMiniBlock(1, leaderAddress, nil)
+
+	assert.Nil(t, miniBlock)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), rp.AccumulatedRewards())
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockWithoutSignersShouldRewardLeader(t *testing.T) {
+	t.Parallel()
+
+	balances := make(map[string]*big.Int)
+	rp := createRewardsProcessor(createBalancesAccounts(balances), 100)
+
+	miniBlock, err := rp.CreateRewardsMiniBlock(1, leaderAddress, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, block.RewardsBlock, miniBlock.Type)
+	assert.Equal(t, 1, len(miniBlock.TxHashes))
+	assert.Equal(t, big.NewInt(100), balances[string(leaderAddress)])
+	assert.Equal(t, big.NewInt(100), rp.AccumulatedRewards())
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockShouldSplitRewardBetweenLeaderAndSigners(t *testing.T) {
+	t.Parallel()
+
+	balances := make(map[string]*big.Int)
+	balances["adr0"] = big.NewInt(10)
+	rp := createRewardsProcessor(createBalancesAccounts(balances), 101)
+
+	//adr0 and adr2 signed the previous header
+	signedHeader := &block.Header{Round: 1, PubKeysBitmap: []byte{5}}
+
+	miniBlock, err := rp.CreateRewardsMiniBlock(2, leaderAddress, signedHeader)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(miniBlock.TxHashes))
+	assert.Equal(t, big.NewInt(50+1), balances[string(leaderAddress)])
+	assert.Equal(t, big.NewInt(10+25), balances["adr0"])
+	assert.Equal(t, big.NewInt(25), balances["adr2"])
+	_, found := balances["adr1"]
+	assert.False(t, found)
+	assert.Equal(t, big.NewInt(101), rp.AccumulatedRewards())
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockReceiverInAnotherShardShouldForwardReward(t *testing.T) {
+	t.Parallel()
+
+	balances := make(map[string]*big.Int)
+	var forwarded []data.TransactionHandler
+	scForwarder := &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			forwarded = append(forwarded, txs...)
+			return nil
+		},
+	}
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		if string(address.Bytes()) == "adr2" {
+			return 1
+		}
+		return 0
+	}
+	rp, _ := rewards.NewRewardsProcessor(
+		createBalancesAccounts(balances),
+		&mock.ChainStorerMock{},
+		shardCoordinator,
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		scForwarder,
+	)
+
+	//adr0 and adr2 signed the previous header
+	signedHeader := &block.Header{Round: 1, PubKeysBitmap: []byte{5}}
+
+	miniBlock, err := rp.CreateRewardsMiniBlock(2, leaderAddress, signedHeader)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(miniBlock.TxHashes))
+	assert.Equal(t, big.NewInt(50), balances[string(leaderAddress)])
+	assert.Equal(t, big.NewInt(25), balances["adr0"])
+	_, found := balances["adr2"]
+	assert.False(t, found)
+	assert.Equal(t, 1, len(forwarded))
+	scr := forwarded[0].(*smartContractResult.SmartContractResult)
+	assert.Equal(t, big.NewInt(25), scr.Value)
+	assert.Equal(t, []byte("adr2"), scr.RcvAddr)
+	assert.Equal(t, process.RewardsAddress, scr.SndAddr)
+	// the forwarded reward is minted as well
+	assert.Equal(t, big.NewInt(100), rp.AccumulatedRewards())
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockLeaderAlsoSignerShouldAggregateRewards(t *testing.T) {
+	t.Parallel()
+
+	balances := make(map[string]*big.Int)
+	rp := createRewardsProcessor(createBalancesAccounts(balances), 100)
+
+	signedHeader := &block.Header{Round: 1, PubKeysBitmap: []byte{3}}
+
+	miniBlock, err := rp.CreateRewardsMiniBlock(2, []byte("adr0"), signedHeader)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(miniBlock.TxHashes))
+	assert.Equal(t, big.NewInt(50+25), balances["adr0"])
+	assert.Equal(t, big.NewInt(25), balances["adr1"])
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockCalledTwiceShouldCreateSameMiniBlock(t *testing.T) {
+	t.Parallel()
+
+	rp := createRewardsProcessor(createBalancesAccounts(make(map[string]*big.Int)), 100)
+	signedHeader := &block.Header{Round: 1, PubKeysBitmap: []byte{7}}
+
+	miniBlock1, _ := rp.CreateRewardsMiniBlock(2, leaderAddress, signedHeader)
+	miniBlock2, _ := rp.CreateRewardsMiniBlock(2, leaderAddress, signedHeader)
+
+	assert.Equal(t, miniBlock1, miniBlock2)
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockAccountsErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errExpected
+		},
+	}
+	rp := createRewardsProcessor(accounts, 100)
+
+	miniBlock, err := rp.CreateRewardsMiniBlock(1, leaderAddress, nil)
+
+	assert.Nil(t, miniBlock)
+	assert.Equal(t, errExpected, err)
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockGroupSelectionErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	rp, _ := rewards.NewRewardsProcessor(
+		createBalancesAccounts(make(map[string]*big.Int)),
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ValidatorGroupSelectorStub{
+			ComputeValidatorsGroupCalled: func(randomness []byte) ([]consensus.Validator, error) {
+				return nil, errExpected
+			},
+		},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	miniBlock, err := rp.CreateRewardsMiniBlock(2, leaderAddress, &block.Header{Round: 1})
+
+	assert.Nil(t, miniBlock)
+	assert.Equal(t, errExpected, err)
+}
+
+func TestRewardsProcessor_CreateRewardsMiniBlockShouldComputeTheGroupOfTheSignedHeader(t *testing.T) {
+	t.Parallel()
+
+	signedHeader := &block.Header{Round: 7, PrevRandSeed: []byte("prev rand seed"), PubKeysBitmap: []byte{1}}
+	randomness := make([]byte, 0)
+	groupSelector := createGroupSelector()
+	computeValidatorsGroup := groupSelector.ComputeValidatorsGroupCalled
+	groupSelector.ComputeValidatorsGroupCalled = func(rand []byte) ([]consensus.Validator, error) {
+		randomness = rand
+		return computeValidatorsGroup(rand)
+	}
+	rp, _ := rewards.NewRewardsProcessor(
+		createBalancesAccounts(make(map[string]*big.Int)),
+		&mock.ChainStorerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		groupSelector,
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	_, err := rp.CreateRewardsMiniBlock(8, leaderAddress, signedHeader)
+
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("%d-%s", 7, core.ToB64([]byte("prev rand seed"))), string(randomness))
+}
+
+func TestRewardsProcessor_CreateBlockStartedShouldResetRewards(t *testing.T) {
+	t.Parallel()
+
+	rp := createRewardsProcessor(createBalancesAccounts(make(map[string]*big.Int)), 100)
+	_, _ = rp.CreateRewardsMiniBlock(1, leaderAddress, nil)
+
+	rp.CreateBlockStarted()
+
+	assert.Equal(t, big.NewInt(0), rp.AccumulatedRewards())
+}
+
+//------- SaveRewardTxs
+
+func TestRewardsProcessor_SaveRewardTxsShouldSaveTheCreatedRewardTxs(t *testing.T) {
+	t.Parallel()
+
+	saved := make(map[string][]byte)
+	marshalizer := &mock.MarshalizerMock{}
+	rp, _ := rewards.NewRewardsProcessor(
+		createBalancesAccounts(make(map[string]*big.Int)),
+		&mock.ChainStorerMock{
+			PutCalled: func(unitType dataRetriever.UnitType, key []byte, value []byte) error {
+				if unitType == dataRetriever.RewardTransactionUnit {
+					saved[string(key)] = value
+				}
+				return nil
+			},
+		},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		marshalizer,
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+	miniBlock, _ := rp.CreateRewardsMiniBlock(3, leaderAddress, nil)
+
+	rp.SaveRewardTxs(append(miniBlock.TxHashes, []byte("missing reward tx")))
+
+	assert.Equal(t, 1, len(saved))
+	tx := &rewardTx.RewardTx{}
+	_ = marshalizer.Unmarshal(tx, saved[string(miniBlock.TxHashes[0])])
+	assert.Equal(t, leaderAddress, tx.RcvAddr)
+	assert.Equal(t, big.NewInt(100), tx.Value)
+	assert.Equal(t, uint64(3), tx.Round)
+}
+
+func TestRewardsProcessor_SaveRewardTxsAfterCreateBlockStartedShouldNotSave(t *testing.T) {
+	t.Parallel()
+
+	numSaved := 0
+	rp, _ := rewards.NewRewardsProcessor(
+		createBalancesAccounts(make(map[string]*big.Int)),
+		&mock.ChainStorerMock{
+			PutCalled: func(unitType dataRetriever.UnitType, key []byte, value []byte) error {
+				numSaved++
+				return nil
+			},
+		},
+		mock.NewOneShardCoordinatorMock(),
+		createGroupSelector(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		createRewardsCalculator(100),
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+	miniBlock, _ := rp.CreateRewardsMiniBlock(3, leaderAddress, nil)
+
+	rp.CreateBlockStarted()
+	rp.SaveRewardTxs(miniBlock.TxHashes)
+
+	assert.Equal(t, 0, numSaved)
+}
+
+//------- ForwardFees
+
+func TestRewardsProcessor_ForwardFeesNoFeesShouldNotForward(t *testing.T) {
+	t.Parallel()
+
+	scForwarder := &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			assert.Fail(t, "should have not been called")
+			return nil
+		},
+	}
+	rp := createRewardsProcessorWithForwarder(&mock.AccountsStub{}, 100, scForwarder)
+
+	err := rp.ForwardFees(1, leaderAddress, big.NewInt(0))
+
+	assert.Nil(t, err)
+}
+
+func TestRewardsProcessor_ForwardFeesShouldAddSmartContractResultForTheLeader(t *testing.T) {
+	t.Parallel()
+
+	var forwarded []data.TransactionHandler
+	scForwarder := &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			forwarded = append(forwarded, txs...)
+			return nil
+		},
+	}
+	rp := createRewardsProcessorWithForwarder(&mock.AccountsStub{}, 100, scForwarder)
+
+	err := rp.ForwardFees(7, leaderAddress, big.NewInt(30))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(forwarded))
+	scr := forwarded[0].(*smartContractResult.SmartContractResult)
+	assert.Equal(t, big.NewInt(30), scr.Value)
+	assert.Equal(t, leaderAddress, scr.RcvAddr)
+	assert.Equal(t, process.FeesAddress, scr.SndAddr)
+	assert.Equal(t, uint64(7), scr.Nonce)
+	assert.NotEqual(t, 0, len(scr.TxHash))
+	// the fees are not minted
+	assert.Equal(t, big.NewInt(0), rp.AccumulatedRewards())
+}
+
+func TestRewardsProcessor_ForwardFeesForwarderErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("forwarder error")
+	scForwarder := &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			return errExpected
+		},
+	}
+	rp := createRewardsProcessorWithForwarder(&mock.AccountsStub{}, 100, scForwarder)
+
+	err := rp.ForwardFees(1, leaderAddress, big.NewInt(30))
+
+	assert.Equal(t, errExpected, err)
+}
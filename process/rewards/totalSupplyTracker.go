@@ -0,0 +1,107 @@
+package rewards
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+var totalSupplyKey = []byte("totalSupply")
+
+// totalSupplyTracker keeps the total supply of the network in the data trie of a metachain system account. The
+// total supply starts from the sum of the genesis balances, grows with the rewards minted by the shard blocks
+// notarized by the metachain and drops with the slashed stake, which is burnt, so it is part of the metachain state
+// root hash
+type totalSupplyTracker struct {
+	accounts           state.AccountsAdapter
+	genesisTotalSupply *big.Int
+}
+
+// NewTotalSupplyTracker creates a new total supply tracker
+func NewTotalSupplyTracker(accounts state.AccountsAdapter, genesisTotalSupply *big.Int) (*totalSupplyTracker, error) {
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if genesisTotalSupply == nil {
+		return nil, process.ErrNilGenesisTotalSupply
+	}
+
+	return &totalSupplyTracker{
+		accounts:           accounts,
+		genesisTotalSupply: big.NewInt(0).Set(genesisTotalSupply),
+	}, nil
+}
+
+// AddRewards adds the minted rewards to the total supply saved in the state
+func (tst *totalSupplyTracker) AddRewards(rewards *big.Int) error {
+	if rewards == nil || rewards.Sign() == 0 {
+		return nil
+	}
+
+	return tst.changeTotalSupply(rewards)
+}
+
+// Burn subtracts the burnt value from the total supply saved in the state
+func (tst *totalSupplyTracker) Burn(value *big.Int) error {
+	if value == nil || value.Sign() == 0 {
+		return nil
+	}
+
+	return tst.changeTotalSupply(big.NewInt(0).Neg(value))
+}
+
+func (tst *totalSupplyTracker) changeTotalSupply(delta *big.Int) error {
+	acntSupply, err := tst.getTotalSupplyAccount()
+	if err != nil {
+		return err
+	}
+
+	totalSupply, err := tst.getSavedTotalSupply(acntSupply)
+	if err != nil {
+		return err
+	}
+
+	totalSupply.Add(totalSupply, delta)
+	acntSupply.DataTrieTracker().SaveKeyValue(totalSupplyKey, totalSupply.Bytes())
+
+	return tst.accounts.SaveDataTrie(acntSupply)
+}
+
+// TotalSupply returns the total supply saved in the state
+func (tst *totalSupplyTracker) TotalSupply() (*big.Int, error) {
+	acntSupply, err := tst.getTotalSupplyAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	return tst.getSavedTotalSupply(acntSupply)
+}
+
+func (tst *totalSupplyTracker) getTotalSupplyAccount() (state.AccountHandler, error) {
+	acntSupply, err := tst.accounts.GetAccountWithJournal(state.NewAddress(process.TotalSupplyAddress))
+	if err != nil {
+		return nil, err
+	}
+	if acntSupply == nil || acntSupply.IsInterfaceNil() {
+		return nil, process.ErrNilTotalSupplyAccount
+	}
+
+	return acntSupply, nil
+}
+
+func (tst *totalSupplyTracker) getSavedTotalSupply(acntSupply state.AccountHandler) (*big.Int, error) {
+	if acntSupply.DataTrie() == nil {
+		return big.NewInt(0).Set(tst.genesisTotalSupply), nil
+	}
+
+	buff, err := acntSupply.DataTrieTracker().RetrieveValue(totalSupplyKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(buff) == 0 {
+		return big.NewInt(0).Set(tst.genesisTotalSupply), nil
+	}
+
+	return big.NewInt(0).SetBytes(buff), nil
+}
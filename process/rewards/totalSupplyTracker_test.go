@@ -0,0 +1,148 @@
+package rewards_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/rewards"
+	"github.com/stretchr/testify/assert"
+)
+
+func createSupplyAccounts(storage map[string][]byte) *mock.AccountsStub {
+	tracker := &mock.AccountTrackerStub{
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+	}
+	trie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return storage[string(key)], nil
+		},
+	}
+
+	return &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			acnt, _ := state.NewAccount(addressContainer, tracker)
+			acnt.SetDataTrie(trie)
+			return acnt, nil
+		},
+		SaveDataTrieCalled: func(acountWrapper state.AccountHandler) error {
+			for k, v := range acountWrapper.DataTrieTracker().DirtyData() {
+				storage[k] = v
+			}
+			acountWrapper.DataTrieTracker().ClearDataCaches()
+			return nil
+		},
+	}
+}
+
+func TestNewTotalSupplyTracker_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tst, err := rewards.NewTotalSupplyTracker(nil, big.NewInt(1000))
+
+	assert.Nil(t, tst)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestNewTotalSupplyTracker_NilGenesisTotalSupplyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tst, err := rewards.NewTotalSupplyTracker(&mock.AccountsStub{}, nil)
+
+	assert.Nil(t, tst)
+	assert.Equal(t, process.ErrNilGenesisTotalSupply, err)
+}
+
+func TestTotalSupplyTracker_TotalSupplyNotSavedShouldReturnGenesisTotalSupply(t *testing.T) {
+	t.Parallel()
+
+	tst, _ := rewards.NewTotalSupplyTracker(createSupplyAccounts(make(map[string][]byte)), big.NewInt(1000))
+
+	totalSupply, err := tst.TotalSupply()
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), totalSupply)
+}
+
+func TestTotalSupplyTracker_AddRewardsShouldIncreaseTotalSupply(t *testing.T) {
+	t.Parallel()
+
+	tst, _ := rewards.NewTotalSupplyTracker(createSupplyAccounts(make(map[string][]byte)), big.NewInt(1000))
+
+	err := tst.AddRewards(big.NewInt(15))
+	assert.Nil(t, err)
+	err = tst.AddRewards(big.NewInt(5))
+	assert.Nil(t, err)
+
+	totalSupply, _ := tst.TotalSupply()
+	assert.Equal(t, big.NewInt(1020), totalSupply)
+}
+
+func TestTotalSupplyTracker_BurnShouldDecreaseTotalSupply(t *testing.T) {
+	t.Parallel()
+
+	tst, _ := rewards.NewTotalSupplyTracker(createSupplyAccounts(make(map[string][]byte)), big.NewInt(1000))
+
+	err := tst.AddRewards(big.NewInt(20))
+	assert.Nil(t, err)
+	err = tst.Burn(big.NewInt(250))
+	assert.Nil(t, err)
+
+	totalSupply, _ := tst.TotalSupply()
+	assert.Equal(t, big.NewInt(770), totalSupply)
+}
+
+func TestTotalSupplyTracker_BurnZeroShouldNotTouchState(t *testing.T) {
+	t.Parallel()
+
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			assert.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}
+	tst, _ := rewards.NewTotalSupplyTracker(accounts, big.NewInt(1000))
+
+	err := tst.Burn(big.NewInt(0))
+
+	assert.Nil(t, err)
+}
+
+func TestTotalSupplyTracker_AddRewardsZeroShouldNotTouchState(t *testing.T) {
+	t.Parallel()
+
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			assert.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}
+	tst, _ := rewards.NewTotalSupplyTracker(accounts, big.NewInt(1000))
+
+	err := tst.AddRewards(big.NewInt(0))
+
+	assert.Nil(t, err)
+}
+
+func TestTotalSupplyTracker_AddRewardsAccountsErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errExpected
+		},
+	}
+	tst, _ := rewards.NewTotalSupplyTracker(accounts, big.NewInt(1000))
+
+	err := tst.AddRewards(big.NewInt(10))
+
+	assert.Equal(t, errExpected, err)
+}
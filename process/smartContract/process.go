@@ -6,10 +6,11 @@ import (
 	"fmt"
 	"github.com/ElrondNetwork/elrond-go/process/factory"
 	"math/big"
-	"sync"
+	"strings"
 
 	"github.com/ElrondNetwork/elrond-go/core/logger"
 	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
@@ -20,13 +21,6 @@ import (
 	"github.com/ElrondNetwork/elrond-vm-common"
 )
 
-type scExecutionState struct {
-	allLogs       map[string][]*vmcommon.LogEntry
-	allReturnData map[string][]*big.Int
-	returnCodes   map[string]vmcommon.ReturnCode
-	rootHash      []byte
-}
-
 type scProcessor struct {
 	accounts         state.AccountsAdapter
 	tempAccounts     process.TemporaryAccountsHandler
@@ -37,10 +31,10 @@ type scProcessor struct {
 	vmContainer      process.VirtualMachinesContainer
 	argsParser       process.ArgumentsParser
 
-	mutSCState   sync.Mutex
-	mapExecState map[uint64]scExecutionState
-
-	scrForwarder process.IntermediateTransactionHandler
+	scrForwarder    process.IntermediateTransactionHandler
+	txFeeHandler    process.TransactionFeeHandler
+	logsHandler     process.SmartContractLogsHandler
+	receiptsHandler process.ReceiptsHandler
 }
 
 var log = logger.DefaultLogger()
@@ -56,6 +50,9 @@ func NewSmartContractProcessor(
 	adrConv state.AddressConverter,
 	coordinator sharding.Coordinator,
 	scrForwarder process.IntermediateTransactionHandler,
+	txFeeHandler process.TransactionFeeHandler,
+	logsHandler process.SmartContractLogsHandler,
+	receiptsHandler process.ReceiptsHandler,
 ) (*scProcessor, error) {
 	if vmContainer == nil {
 		return nil, process.ErrNoVM
@@ -84,6 +81,15 @@ func NewSmartContractProcessor(
 	if scrForwarder == nil {
 		return nil, process.ErrNilIntermediateTransactionHandler
 	}
+	if txFeeHandler == nil {
+		return nil, process.ErrNilTxFeeHandler
+	}
+	if logsHandler == nil {
+		return nil, process.ErrNilSmartContractLogsHandler
+	}
+	if receiptsHandler == nil {
+		return nil, process.ErrNilReceiptsHandler
+	}
 
 	return &scProcessor{
 		vmContainer:      vmContainer,
@@ -95,7 +101,10 @@ func NewSmartContractProcessor(
 		adrConv:          adrConv,
 		shardCoordinator: coordinator,
 		scrForwarder:     scrForwarder,
-		mapExecState:     make(map[uint64]scExecutionState)}, nil
+		txFeeHandler:     txFeeHandler,
+		logsHandler:      logsHandler,
+		receiptsHandler:  receiptsHandler,
+	}, nil
 }
 
 // ComputeTransactionType calculates the type of the transaction
@@ -105,6 +114,14 @@ func (sc *scProcessor) ComputeTransactionType(tx *transaction.Transaction) (proc
 		return 0, err
 	}
 
+	if bytes.Equal(tx.RcvAddr, process.StakingAddress) {
+		return process.Staking, nil
+	}
+
+	if strings.HasPrefix(tx.Data, process.RelayedTxDataPrefix) {
+		return process.RelayedTx, nil
+	}
+
 	isEmptyAddress := sc.isDestAddressEmpty(tx)
 	if isEmptyAddress {
 		if len(tx.Data) > 0 {
@@ -186,7 +203,7 @@ func (sc *scProcessor) ExecuteSmartContractTransaction(
 	}
 
 	// VM is formally verified and the output is correct
-	crossTxs, err := sc.processVMOutput(vmOutput, tx, acntSnd, round)
+	crossTxs, consumedFee, err := sc.processVMOutput(vmOutput, tx, acntSnd, round)
 	if err != nil {
 		return err
 	}
@@ -196,6 +213,8 @@ func (sc *scProcessor) ExecuteSmartContractTransaction(
 		return err
 	}
 
+	sc.txFeeHandler.ProcessTransactionFee(consumedFee)
+
 	return nil
 }
 
@@ -269,7 +288,7 @@ func (sc *scProcessor) DeploySmartContract(
 	}
 
 	// VM is formally verified, the output is correct
-	crossTxs, err := sc.processVMOutput(vmOutput, tx, acntSnd, round)
+	crossTxs, consumedFee, err := sc.processVMOutput(vmOutput, tx, acntSnd, round)
 	if err != nil {
 		return err
 	}
@@ -279,6 +298,8 @@ func (sc *scProcessor) DeploySmartContract(
 		return err
 	}
 
+	sc.txFeeHandler.ProcessTransactionFee(consumedFee)
+
 	return nil
 }
 
@@ -386,17 +407,17 @@ func (sc *scProcessor) processVMOutput(
 	tx *transaction.Transaction,
 	acntSnd state.AccountHandler,
 	round uint64,
-) ([]data.TransactionHandler, error) {
+) ([]data.TransactionHandler, *big.Int, error) {
 	if vmOutput == nil {
-		return nil, process.ErrNilVMOutput
+		return nil, nil, process.ErrNilVMOutput
 	}
 	if tx == nil {
-		return nil, process.ErrNilTransaction
+		return nil, nil, process.ErrNilTransaction
 	}
 
 	txBytes, err := sc.marshalizer.Marshal(tx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	txHash := sc.hasher.Compute(string(txBytes))
 
@@ -408,48 +429,67 @@ func (sc *scProcessor) processVMOutput(
 		)
 	}
 
-	err = sc.saveSCOutputToCurrentState(vmOutput, round, txHash)
-	if err != nil {
-		return nil, err
-	}
-
 	crossOutAccs, err := sc.processSCOutputAccounts(vmOutput.OutputAccounts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	crossTxs, err := sc.createCrossShardTransactions(crossOutAccs, tx, txHash)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	acntSnd, err = sc.reloadLocalSndAccount(acntSnd)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	totalGasRefund := big.NewInt(0)
 	totalGasRefund = totalGasRefund.Add(vmOutput.GasRefund, vmOutput.GasRemaining)
 	scrIfCrossShard, err := sc.refundGasToSender(totalGasRefund, tx, txHash, acntSnd)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	consumedFee := sc.computeConsumedFee(totalGasRefund, tx, acntSnd)
+	sc.saveReceipt(vmOutput, tx, txHash, totalGasRefund)
+
 	if scrIfCrossShard != nil {
 		crossTxs = append(crossTxs, scrIfCrossShard)
 	}
 
 	err = sc.deleteAccounts(vmOutput.DeletedAccounts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	err = sc.processTouchedAccounts(vmOutput.TouchedAccounts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return crossTxs, consumedFee, nil
+}
+
+// computeConsumedFee returns the value of the gas used by the VM, only if the payment was taken in the current shard
+func (sc *scProcessor) computeConsumedFee(
+	gasRefund *big.Int,
+	tx *transaction.Transaction,
+	acntSnd state.AccountHandler,
+) *big.Int {
+	if acntSnd == nil || acntSnd.IsInterfaceNil() {
+		return big.NewInt(0)
+	}
+
+	gasUsed := big.NewInt(0).SetUint64(tx.GasLimit)
+	if gasRefund != nil {
+		gasUsed.Sub(gasUsed, gasRefund)
+	}
+	if gasUsed.Cmp(big.NewInt(0)) <= 0 {
+		return big.NewInt(0)
 	}
 
-	return crossTxs, nil
+	return gasUsed.Mul(gasUsed, big.NewInt(0).SetUint64(tx.GasPrice))
 }
 
 // reloadLocalSndAccount will reload from current account state the sender account
@@ -663,61 +703,56 @@ func (sc *scProcessor) GetAllSmartContractCallRootHash(round uint64) []byte {
 	return []byte("roothash")
 }
 
-// saves VM output into state
-func (sc *scProcessor) saveSCOutputToCurrentState(output *vmcommon.VMOutput, round uint64, txHash []byte) error {
-	var err error
-
-	sc.mutSCState.Lock()
-	defer sc.mutSCState.Unlock()
-
-	if _, ok := sc.mapExecState[round]; !ok {
-		sc.mapExecState[round] = scExecutionState{
-			allLogs:       make(map[string][]*vmcommon.LogEntry),
-			allReturnData: make(map[string][]*big.Int),
-			returnCodes:   make(map[string]vmcommon.ReturnCode)}
+// saveReceipt hands the receipt of the smart contract call, holding the outcome of its execution, and its logs
+func (sc *scProcessor) saveReceipt(
+	vmOutput *vmcommon.VMOutput,
+	tx *transaction.Transaction,
+	txHash []byte,
+	gasRefund *big.Int,
+) {
+	rcpt := &receipt.Receipt{
+		Status:        receipt.Executed,
+		RefundedValue: big.NewInt(0).Mul(gasRefund, big.NewInt(0).SetUint64(tx.GasPrice)),
+		ReturnCode:    vmOutput.ReturnCode.String(),
+		ReturnData:    make([][]byte, 0, len(vmOutput.ReturnData)),
+		Logs:          make([]*receipt.LogEntry, 0, len(vmOutput.Logs)),
 	}
-
-	tmpCurrScState := sc.mapExecState[round]
-	defer func() {
-		if err != nil {
-			sc.mapExecState[round] = tmpCurrScState
-		}
-	}()
-
-	err = sc.saveReturnData(output.ReturnData, round, txHash)
-	if err != nil {
-		return err
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		rcpt.Status = receipt.Failed
 	}
 
-	err = sc.saveReturnCode(output.ReturnCode, round, txHash)
-	if err != nil {
-		return err
+	gasUsed := big.NewInt(0).SetUint64(tx.GasLimit)
+	gasUsed.Sub(gasUsed, gasRefund)
+	if gasUsed.Sign() > 0 {
+		rcpt.GasUsed = gasUsed.Uint64()
 	}
 
-	err = sc.saveLogsIntoState(output.Logs, round, txHash)
-	if err != nil {
-		return err
+	for _, returnData := range vmOutput.ReturnData {
+		if returnData != nil {
+			rcpt.ReturnData = append(rcpt.ReturnData, returnData.Bytes())
+		}
 	}
 
-	return nil
-}
-
-// saves return data into account state
-func (sc *scProcessor) saveReturnData(returnData []*big.Int, round uint64, txHash []byte) error {
-	sc.mapExecState[round].allReturnData[string(txHash)] = returnData
-	return nil
-}
+	for _, logEntry := range vmOutput.Logs {
+		if logEntry == nil {
+			continue
+		}
 
-// saves smart contract return code into account state
-func (sc *scProcessor) saveReturnCode(returnCode vmcommon.ReturnCode, round uint64, txHash []byte) error {
-	sc.mapExecState[round].returnCodes[string(txHash)] = returnCode
-	return nil
-}
+		topics := make([][]byte, 0, len(logEntry.Topics))
+		for _, topic := range logEntry.Topics {
+			if topic != nil {
+				topics = append(topics, topic.Bytes())
+			}
+		}
+		rcpt.Logs = append(rcpt.Logs, &receipt.LogEntry{
+			Address: logEntry.Address,
+			Topics:  topics,
+			Data:    logEntry.Data,
+		})
+	}
 
-// save vm output logs into accounts
-func (sc *scProcessor) saveLogsIntoState(logs []*vmcommon.LogEntry, round uint64, txHash []byte) error {
-	sc.mapExecState[round].allLogs[string(txHash)] = logs
-	return nil
+	sc.receiptsHandler.AddReceipt(txHash, rcpt)
+	sc.logsHandler.SaveLogs(txHash, vmOutput.Logs)
 }
 
 // ProcessSmartContractResult updates the account state from the smart contract result
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
 	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
@@ -65,7 +66,10 @@ func TestNewSmartContractProcessorNilVM(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNoVM, err)
@@ -83,7 +87,10 @@ func TestNewSmartContractProcessorNilArgsParser(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilArgumentParser, err)
@@ -101,7 +108,10 @@ func TestNewSmartContractProcessorNilHasher(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilHasher, err)
@@ -119,7 +129,10 @@ func TestNewSmartContractProcessorNilMarshalizer(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilMarshalizer, err)
@@ -137,7 +150,10 @@ func TestNewSmartContractProcessorNilAccountsDB(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
@@ -155,7 +171,10 @@ func TestNewSmartContractProcessorNilAdrConv(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		nil,
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilAddressConverter, err)
@@ -173,7 +192,10 @@ func TestNewSmartContractProcessorNilShardCoordinator(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		nil,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
@@ -191,7 +213,10 @@ func TestNewSmartContractProcessorNilFakeAccountsHandler(t *testing.T) {
 		nil,
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilTemporaryAccountsHandler, err)
@@ -209,12 +234,78 @@ func TestNewSmartContractProcessor_NilIntermediateMock(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		nil)
+		nil,
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.Nil(t, sc)
 	assert.Equal(t, process.ErrNilIntermediateTransactionHandler, err)
 }
 
+func TestNewSmartContractProcessor_NilTxFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sc, err := NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{},
+		&mock.TemporaryAccountsHandlerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.IntermediateTransactionHandlerMock{},
+		nil,
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
+
+	assert.Nil(t, sc)
+	assert.Equal(t, process.ErrNilTxFeeHandler, err)
+}
+
+func TestNewSmartContractProcessor_NilLogsHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sc, err := NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{},
+		&mock.TemporaryAccountsHandlerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		nil,
+		&mock.ReceiptsHandlerStub{})
+
+	assert.Nil(t, sc)
+	assert.Equal(t, process.ErrNilSmartContractLogsHandler, err)
+}
+
+func TestNewSmartContractProcessor_NilReceiptsHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sc, err := NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{},
+		&mock.TemporaryAccountsHandlerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		nil)
+
+	assert.Nil(t, sc)
+	assert.Equal(t, process.ErrNilReceiptsHandler, err)
+}
+
 func TestNewSmartContractProcessor(t *testing.T) {
 	t.Parallel()
 
@@ -227,7 +318,10 @@ func TestNewSmartContractProcessor(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -245,7 +339,10 @@ func TestScProcessor_ComputeTransactionTypeNil(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -266,7 +363,10 @@ func TestScProcessor_ComputeTransactionTypeNilTx(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -294,7 +394,10 @@ func TestScProcessor_ComputeTransactionTypeErrWrongTransaction(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -322,7 +425,10 @@ func TestScProcessor_ComputeTransactionTypeScDeployment(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addressConverter,
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -364,7 +470,10 @@ func TestScProcessor_ComputeTransactionTypeScInvoking(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConverter,
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -398,7 +507,10 @@ func TestScProcessor_ComputeTransactionTypeMoveBalance(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConverter,
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -408,6 +520,72 @@ func TestScProcessor_ComputeTransactionTypeMoveBalance(t *testing.T) {
 	assert.Equal(t, process.MoveBalance, txType)
 }
 
+func TestScProcessor_ComputeTransactionTypeStaking(t *testing.T) {
+	t.Parallel()
+
+	tx := &transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = process.StakingAddress
+	tx.Data = "stake@aa"
+	tx.Value = big.NewInt(45)
+
+	sc, err := NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{},
+		&mock.TemporaryAccountsHandlerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
+
+	assert.NotNil(t, sc)
+	assert.Nil(t, err)
+
+	txType, err := sc.ComputeTransactionType(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, process.Staking, txType)
+}
+
+func TestScProcessor_ComputeTransactionTypeRelayedTx(t *testing.T) {
+	t.Parallel()
+
+	addrConverter := &mock.AddressConverterMock{}
+
+	tx := &transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = generateRandomByteSlice(addrConverter.AddressLen())
+	tx.Data = process.RelayedTxDataPrefix + "aa"
+	tx.Value = big.NewInt(45)
+
+	sc, err := NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{},
+		&mock.TemporaryAccountsHandlerMock{},
+		addrConverter,
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
+
+	assert.NotNil(t, sc)
+	assert.Nil(t, err)
+
+	txType, err := sc.ComputeTransactionType(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, process.RelayedTx, txType)
+}
+
 func TestScProcessor_DeploySmartContractBadParse(t *testing.T) {
 	t.Parallel()
 
@@ -423,7 +601,10 @@ func TestScProcessor_DeploySmartContractBadParse(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConverter,
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -458,7 +639,10 @@ func TestScProcessor_DeploySmartContractRunError(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConverter,
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -498,7 +682,10 @@ func TestScProcessor_DeploySmartContractWrongTx(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -530,7 +717,10 @@ func TestScProcessor_DeploySmartContract(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConverter,
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -564,7 +754,10 @@ func TestScProcessor_ExecuteSmartContractTransactionNilTx(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -594,7 +787,10 @@ func TestScProcessor_ExecuteSmartContractTransactionNilAccount(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -632,7 +828,10 @@ func TestScProcessor_ExecuteSmartContractTransactionBadParser(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -667,7 +866,10 @@ func TestScProcessor_ExecuteSmartContractTransactionVMRunError(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -708,7 +910,10 @@ func TestScProcessor_ExecuteSmartContractTransaction(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -743,7 +948,10 @@ func TestScProcessor_CreateVMCallInputWrongCode(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -777,7 +985,10 @@ func TestScProcessor_CreateVMCallInput(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -807,7 +1018,10 @@ func TestScProcessor_CreateVMDeployInputBadFunction(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -842,7 +1056,10 @@ func TestScProcessor_CreateVMDeployInput(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -872,7 +1089,10 @@ func TestScProcessor_CreateVMInputWrongArgument(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -906,7 +1126,10 @@ func TestScProcessor_CreateVMInput(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -949,13 +1172,16 @@ func TestScProcessor_processVMOutputNilVMOutput(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
 	acntSrc, _, tx := createAccountsAndTransaction()
 
-	_, err = sc.processVMOutput(nil, tx, acntSrc, 10)
+	_, _, err = sc.processVMOutput(nil, tx, acntSrc, 10)
 	assert.Equal(t, process.ErrNilVMOutput, err)
 }
 
@@ -973,14 +1199,17 @@ func TestScProcessor_processVMOutputNilTx(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
 	acntSrc, _, _ := createAccountsAndTransaction()
 
 	vmOutput := &vmcommon.VMOutput{}
-	_, err = sc.processVMOutput(vmOutput, nil, acntSrc, 10)
+	_, _, err = sc.processVMOutput(vmOutput, nil, acntSrc, 10)
 	assert.Equal(t, process.ErrNilTransaction, err)
 }
 
@@ -998,7 +1227,10 @@ func TestScProcessor_processVMOutputNilSndAcc(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1008,7 +1240,7 @@ func TestScProcessor_processVMOutputNilSndAcc(t *testing.T) {
 		GasRefund:    big.NewInt(0),
 		GasRemaining: big.NewInt(0),
 	}
-	_, err = sc.processVMOutput(vmOutput, tx, nil, 10)
+	_, _, err = sc.processVMOutput(vmOutput, tx, nil, 10)
 	assert.Nil(t, err)
 }
 
@@ -1027,7 +1259,10 @@ func TestScProcessor_processVMOutputNilDstAcc(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1042,7 +1277,7 @@ func TestScProcessor_processVMOutputNilDstAcc(t *testing.T) {
 		return acntSnd, nil
 	}
 
-	_, err = sc.processVMOutput(vmOutput, tx, acntSnd, 10)
+	_, _, err = sc.processVMOutput(vmOutput, tx, acntSnd, 10)
 	assert.Nil(t, err)
 }
 
@@ -1071,7 +1306,10 @@ func TestScProcessor_GetAccountFromAddressAccNotFound(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1109,7 +1347,10 @@ func TestScProcessor_GetAccountFromAddrFaildAddressConv(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1147,7 +1388,10 @@ func TestScProcessor_GetAccountFromAddrFailedGetExistingAccount(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1185,7 +1429,10 @@ func TestScProcessor_GetAccountFromAddrAccNotInShard(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1224,7 +1471,10 @@ func TestScProcessor_GetAccountFromAddr(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1264,7 +1514,10 @@ func TestScProcessor_DeleteAccountsFailedAtRemove(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1304,7 +1557,10 @@ func TestScProcessor_DeleteAccountsNotInShard(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1349,7 +1605,10 @@ func TestScProcessor_DeleteAccountsInShard(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConv,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1373,7 +1632,10 @@ func TestScProcessor_ProcessSCPaymentAccNotInShardShouldNotReturnError(t *testin
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -1403,7 +1665,10 @@ func TestScProcessor_ProcessSCPaymentWrongTypeAssertion(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -1437,7 +1702,10 @@ func TestScProcessor_ProcessSCPayment(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -1472,7 +1740,10 @@ func TestScProcessor_RefundGasToSenderNilAndZeroRefund(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -1512,7 +1783,10 @@ func TestScProcessor_RefundGasToSenderAccNotInShard(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -1555,7 +1829,10 @@ func TestScProcessor_RefundGasToSender(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
@@ -1595,11 +1872,14 @@ func TestScProcessor_processVMOutputNilOutput(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
-	_, err = sc.ProcessVMOutput(nil, tx, acntSrc, round)
+	_, _, err = sc.ProcessVMOutput(nil, tx, acntSrc, round)
 
 	assert.Equal(t, process.ErrNilVMOutput, err)
 }
@@ -1619,12 +1899,15 @@ func TestScProcessor_processVMOutputNilTransaction(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
 	vmOutput := &vmcommon.VMOutput{}
-	_, err = sc.ProcessVMOutput(vmOutput, nil, acntSrc, round)
+	_, _, err = sc.ProcessVMOutput(vmOutput, nil, acntSrc, round)
 
 	assert.Equal(t, process.ErrNilTransaction, err)
 }
@@ -1645,7 +1928,10 @@ func TestScProcessor_processVMOutput(t *testing.T) {
 		&mock.TemporaryAccountsHandlerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1658,7 +1944,7 @@ func TestScProcessor_processVMOutput(t *testing.T) {
 		return acntSrc, nil
 	}
 
-	_, err = sc.ProcessVMOutput(vmOutput, tx, acntSrc, round)
+	_, _, err = sc.ProcessVMOutput(vmOutput, tx, acntSrc, round)
 	assert.Nil(t, err)
 }
 
@@ -1678,7 +1964,10 @@ func TestScProcessor_processSCOutputAccounts(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		mock.NewMultiShardsCoordinatorMock(5),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1760,7 +2049,10 @@ func TestScProcessor_processSCOutputAccountsNotInShard(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1800,7 +2092,10 @@ func TestScProcessor_CreateCrossShardTransactions(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1843,7 +2138,10 @@ func TestScProcessor_ProcessSmartContractResultNilScr(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1869,7 +2167,10 @@ func TestScProcessor_ProcessSmartContractResultErrGetAccount(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1893,7 +2194,10 @@ func TestScProcessor_ProcessSmartContractResultAccNotInShard(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1922,7 +2226,10 @@ func TestScProcessor_ProcessSmartContractResultBadAccType(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1954,7 +2261,10 @@ func TestScProcessor_ProcessSmartContractResultOutputBalanceNil(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -1992,7 +2302,10 @@ func TestScProcessor_ProcessSmartContractResultWithCode(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -2034,7 +2347,10 @@ func TestScProcessor_ProcessSmartContractResultWithData(t *testing.T) {
 		fakeAccountsHandler,
 		&mock.AddressConverterMock{},
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	assert.NotNil(t, sc)
 	assert.Nil(t, err)
 
@@ -2062,3 +2378,82 @@ func TestScProcessor_ProcessSmartContractResultWithData(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 1, saveTrieCalled)
 }
+
+func TestScProcessor_SaveReceiptShouldAddReceiptAndSaveLogs(t *testing.T) {
+	t.Parallel()
+
+	var savedTxHash []byte
+	var savedLogs []*vmcommon.LogEntry
+	var addedReceipt *receipt.Receipt
+	sc, _ := NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{},
+		&mock.TemporaryAccountsHandlerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{
+			SaveLogsCalled: func(txHash []byte, logs []*vmcommon.LogEntry) {
+				savedTxHash = txHash
+				savedLogs = logs
+			},
+		},
+		&mock.ReceiptsHandlerStub{
+			AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+				addedReceipt = rcpt
+			},
+		})
+
+	logs := []*vmcommon.LogEntry{{Address: []byte("sc address"), Topics: []*big.Int{big.NewInt(1)}, Data: []byte("data")}}
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode: vmcommon.Ok,
+		ReturnData: []*big.Int{big.NewInt(7)},
+		Logs:       logs,
+	}
+	tx := &transaction.Transaction{GasLimit: 100, GasPrice: 2}
+	sc.saveReceipt(vmOutput, tx, []byte("tx hash"), big.NewInt(40))
+
+	assert.Equal(t, []byte("tx hash"), savedTxHash)
+	assert.Equal(t, logs, savedLogs)
+	assert.Equal(t, receipt.Executed, addedReceipt.Status)
+	assert.Equal(t, uint64(60), addedReceipt.GasUsed)
+	assert.Equal(t, big.NewInt(80), addedReceipt.RefundedValue)
+	assert.Equal(t, vmcommon.Ok.String(), addedReceipt.ReturnCode)
+	assert.Equal(t, [][]byte{{7}}, addedReceipt.ReturnData)
+	assert.Equal(t, []*receipt.LogEntry{{Address: []byte("sc address"), Topics: [][]byte{{1}}, Data: []byte("data")}},
+		addedReceipt.Logs)
+}
+
+func TestScProcessor_SaveReceiptFailedCallShouldAddFailedReceipt(t *testing.T) {
+	t.Parallel()
+
+	var addedReceipt *receipt.Receipt
+	sc, _ := NewSmartContractProcessor(
+		&mock.VMContainerMock{},
+		&mock.ArgumentParserMock{},
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.AccountsStub{},
+		&mock.TemporaryAccountsHandlerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewMultiShardsCoordinatorMock(5),
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{
+			AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+				addedReceipt = rcpt
+			},
+		})
+
+	tx := &transaction.Transaction{GasLimit: 100, GasPrice: 2}
+	sc.saveReceipt(&vmcommon.VMOutput{ReturnCode: vmcommon.OutOfGas}, tx, []byte("tx hash"), big.NewInt(0))
+
+	assert.Equal(t, receipt.Failed, addedReceipt.Status)
+	assert.Equal(t, uint64(100), addedReceipt.GasUsed)
+	assert.Equal(t, vmcommon.OutOfGas.String(), addedReceipt.ReturnCode)
+}
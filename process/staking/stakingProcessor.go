@@ -0,0 +1,534 @@
+package staking
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/crypto"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+var log = logger.DefaultLogger()
+
+const (
+	stakeAction   = "stake"
+	unStakeAction = "unstake"
+	unBondAction  = "unbond"
+	slashAction   = "slash"
+
+	dataSeparator = "@"
+
+	slashingPercentagePrecision = 10000
+)
+
+// StakedData holds the stake of a node, as it is saved in the data trie of the staking account
+type StakedData struct {
+	Owner         []byte   `json:"owner"`
+	Stake         *big.Int `json:"stake"`
+	UnStaked      bool     `json:"unStaked"`
+	UnStakedRound uint64   `json:"unStakedRound"`
+}
+
+// StakingTxData returns the data field of a transaction which stakes the node with the given public key. The
+// signature is made with the private key of the node over the address of the owner sending the transaction, proving
+// that the owner holds the staked key
+func StakingTxData(pubKey []byte, ownerSignature []byte) string {
+	return stakeAction + dataSeparator + hex.EncodeToString(pubKey) + dataSeparator + hex.EncodeToString(ownerSignature)
+}
+
+// SlashingTxData returns the data field of a transaction which slashes the validator proven to have signed two
+// different headers in the same round
+func SlashingTxData(proof []byte) string {
+	return slashAction + dataSeparator + hex.EncodeToString(proof)
+}
+
+// stakingProcessor executes the staking transactions. The stake of every node is locked in the staking account
+// and each node registration, deregistration or slashing is kept as peer data, so that the metachain can update
+// the validators lists when a new epoch starts
+type stakingProcessor struct {
+	accounts         state.AccountsAdapter
+	marshalizer      marshal.Marshalizer
+	hasher           hashing.Hasher
+	scrForwarder     process.IntermediateTransactionHandler
+	stakingSettings  process.StakingSettingsHandler
+	keyGen           crypto.KeyGenerator
+	singleSigner     crypto.SingleSigner
+	slashingVerifier process.SlashingProofVerifier
+
+	mutPeerInfo sync.RWMutex
+	peerInfo    []block.PeerData
+}
+
+// NewStakingProcessor creates a new staking processor
+func NewStakingProcessor(
+	accounts state.AccountsAdapter,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	scrForwarder process.IntermediateTransactionHandler,
+	stakingSettings process.StakingSettingsHandler,
+	keyGen crypto.KeyGenerator,
+	singleSigner crypto.SingleSigner,
+	slashingVerifier process.SlashingProofVerifier,
+) (*stakingProcessor, error) {
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, process.ErrNilHasher
+	}
+	if scrForwarder == nil {
+		return nil, process.ErrNilIntermediateTransactionHandler
+	}
+	if stakingSettings == nil {
+		return nil, process.ErrNilStakingSettings
+	}
+	if keyGen == nil {
+		return nil, process.ErrNilKeyGen
+	}
+	if singleSigner == nil {
+		return nil, process.ErrNilSingleSigner
+	}
+	if slashingVerifier == nil {
+		return nil, process.ErrNilSlashingProofVerifier
+	}
+
+	return &stakingProcessor{
+		accounts:         accounts,
+		marshalizer:      marshalizer,
+		hasher:           hasher,
+		scrForwarder:     scrForwarder,
+		stakingSettings:  stakingSettings,
+		keyGen:           keyGen,
+		singleSigner:     singleSigner,
+		slashingVerifier: slashingVerifier,
+		peerInfo:         make([]block.PeerData, 0),
+	}, nil
+}
+
+// CreateBlockStarted resets the peer data produced in the previous block
+func (sp *stakingProcessor) CreateBlockStarted() {
+	sp.mutPeerInfo.Lock()
+	sp.peerInfo = make([]block.PeerData, 0)
+	sp.mutPeerInfo.Unlock()
+}
+
+// PeerInfo returns the peer data produced since the current block has been started
+func (sp *stakingProcessor) PeerInfo() []block.PeerData {
+	sp.mutPeerInfo.RLock()
+	peerInfo := make([]block.PeerData, len(sp.peerInfo))
+	copy(peerInfo, sp.peerInfo)
+	sp.mutPeerInfo.RUnlock()
+
+	return peerInfo
+}
+
+// ProcessStakingTransaction executes the action found in the data field of a staking transaction. The value of the
+// transaction has already been moved to the staking account. If the action fails for a transaction sent from
+// another shard, the value is sent back to the sender, as the transaction can not be rejected anymore
+func (sp *stakingProcessor) ProcessStakingTransaction(
+	tx *transaction.Transaction,
+	acntSrc, acntStaking *state.Account,
+	round uint64,
+) error {
+	if tx == nil {
+		return process.ErrNilTransaction
+	}
+	if acntStaking == nil {
+		return process.ErrNilStakingAccount
+	}
+
+	peerData, err := sp.executeAction(tx, acntSrc, acntStaking, round)
+	if err != nil {
+		if acntSrc != nil {
+			return err
+		}
+
+		log.Debug(fmt.Sprintf("staking transaction from another shard failed: %s\n", err.Error()))
+		return sp.refund(tx, acntStaking)
+	}
+
+	if peerData != nil {
+		sp.mutPeerInfo.Lock()
+		sp.peerInfo = append(sp.peerInfo, *peerData)
+		sp.mutPeerInfo.Unlock()
+	}
+
+	return nil
+}
+
+func (sp *stakingProcessor) executeAction(
+	tx *transaction.Transaction,
+	acntSrc, acntStaking *state.Account,
+	round uint64,
+) (*block.PeerData, error) {
+	action, arguments, err := parseStakingData(tx.Data)
+	if err != nil {
+		return nil, err
+	}
+	if action == slashAction {
+		if len(arguments) != 1 {
+			return nil, process.ErrInvalidStakingData
+		}
+		return sp.slash(tx, acntStaking, arguments[0], round)
+	}
+
+	pubKey := arguments[0]
+	stakedData, err := sp.getStakedData(acntStaking, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if action == stakeAction {
+		if len(arguments) != 2 {
+			return nil, process.ErrInvalidStakingData
+		}
+		return sp.stake(tx, acntStaking, pubKey, arguments[1], stakedData, round)
+	}
+	if len(arguments) != 1 {
+		return nil, process.ErrInvalidStakingData
+	}
+
+	switch action {
+	case unStakeAction:
+		return sp.unStake(tx, acntStaking, pubKey, stakedData, round)
+	case unBondAction:
+		return nil, sp.unBond(tx, acntSrc, acntStaking, pubKey, stakedData, round)
+	}
+
+	return nil, process.ErrInvalidStakingData
+}
+
+// stake locks the value of the transaction as the stake of the node, only if the owner signature proves that the
+// sender holds the staked key, otherwise anyone could lock a public key out of staking by staking it first
+func (sp *stakingProcessor) stake(
+	tx *transaction.Transaction,
+	acntStaking *state.Account,
+	pubKey []byte,
+	ownerSignature []byte,
+	stakedData *StakedData,
+	round uint64,
+) (*block.PeerData, error) {
+	if tx.Value == nil || tx.Value.Cmp(sp.stakingSettings.MinStakeValue()) < 0 {
+		return nil, process.ErrInsufficientStake
+	}
+	if stakedData != nil {
+		return nil, process.ErrNodeAlreadyStaked
+	}
+
+	err := sp.checkOwnerSignature(pubKey, tx.SndAddr, ownerSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	stakedData = &StakedData{
+		Owner: tx.SndAddr,
+		Stake: big.NewInt(0).Set(tx.Value),
+	}
+	err = sp.saveStakedData(acntStaking, pubKey, stakedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.PeerData{
+		PublicKey: pubKey,
+		Action:    block.PeerRegistrantion,
+		TimeStamp: round,
+		Value:     big.NewInt(0).Set(stakedData.Stake),
+		Address:   stakedData.Owner,
+	}, nil
+}
+
+func (sp *stakingProcessor) unStake(
+	tx *transaction.Transaction,
+	acntStaking *state.Account,
+	pubKey []byte,
+	stakedData *StakedData,
+	round uint64,
+) (*block.PeerData, error) {
+	err := checkOwnerAction(tx, stakedData)
+	if err != nil {
+		return nil, err
+	}
+	if stakedData.UnStaked {
+		return nil, process.ErrNodeAlreadyUnStaked
+	}
+
+	stakedData.UnStaked = true
+	stakedData.UnStakedRound = round
+	err = sp.saveStakedData(acntStaking, pubKey, stakedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.PeerData{
+		PublicKey: pubKey,
+		Action:    block.PeerDeregistration,
+		TimeStamp: round,
+		Value:     big.NewInt(0).Set(stakedData.Stake),
+		Address:   stakedData.Owner,
+	}, nil
+}
+
+// unBond releases the stake of an unstaked node, after the unbond period has passed, and sends it back to the owner
+func (sp *stakingProcessor) unBond(
+	tx *transaction.Transaction,
+	acntSrc, acntStaking *state.Account,
+	pubKey []byte,
+	stakedData *StakedData,
+	round uint64,
+) error {
+	err := checkOwnerAction(tx, stakedData)
+	if err != nil {
+		return err
+	}
+	if !stakedData.UnStaked {
+		return process.ErrNodeNotUnStaked
+	}
+	if round < stakedData.UnStakedRound+sp.stakingSettings.UnBondPeriod() {
+		return process.ErrUnBondPeriodNotPassed
+	}
+
+	acntStaking.DataTrieTracker().SaveKeyValue(stakedDataKey(pubKey), nil)
+	err = sp.accounts.SaveDataTrie(acntStaking)
+	if err != nil {
+		return err
+	}
+
+	return sp.sendValue(tx, acntSrc, acntStaking, stakedData.Stake)
+}
+
+// slash burns a part of the stake of the validator proven to have signed two different headers in the same round.
+// Every offence is slashed only once, but the slashing is recorded even if the offender has no stake left, so its
+// rating is still lowered
+func (sp *stakingProcessor) slash(
+	tx *transaction.Transaction,
+	acntStaking *state.Account,
+	proof []byte,
+	round uint64,
+) (*block.PeerData, error) {
+	if tx.Value != nil && tx.Value.Sign() != 0 {
+		return nil, process.ErrInvalidStakingData
+	}
+
+	pubKey, offenceRound, err := sp.slashingVerifier.VerifyProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	offenceKey := slashingKey(pubKey, offenceRound)
+	if acntStaking.DataTrie() != nil {
+		buff, err := acntStaking.DataTrieTracker().RetrieveValue(offenceKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(buff) > 0 {
+			return nil, process.ErrNodeAlreadySlashed
+		}
+	}
+
+	stakedData, err := sp.getStakedData(acntStaking, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	slashed := big.NewInt(0)
+	var owner []byte
+	if stakedData != nil {
+		owner = stakedData.Owner
+		slashed = sp.computeSlashedValue(stakedData.Stake)
+		stakedData.Stake = big.NewInt(0).Sub(stakedData.Stake, slashed)
+
+		buff, err := sp.marshalizer.Marshal(stakedData)
+		if err != nil {
+			return nil, err
+		}
+		acntStaking.DataTrieTracker().SaveKeyValue(stakedDataKey(pubKey), buff)
+
+		//the slashed value is burnt
+		err = acntStaking.SetBalanceWithJournal(big.NewInt(0).Sub(acntStaking.Balance, slashed))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	acntStaking.DataTrieTracker().SaveKeyValue(offenceKey, []byte{1})
+	err = sp.accounts.SaveDataTrie(acntStaking)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(fmt.Sprintf("validator %s slashed for round %d, burnt stake: %s\n",
+		core.GetTrimmedPk(hex.EncodeToString(pubKey)),
+		offenceRound,
+		slashed.String(),
+	))
+
+	return &block.PeerData{
+		PublicKey: pubKey,
+		Action:    block.PeerSlashing,
+		TimeStamp: round,
+		Value:     slashed,
+		Address:   owner,
+	}, nil
+}
+
+func (sp *stakingProcessor) computeSlashedValue(stake *big.Int) *big.Int {
+	if stake == nil {
+		return big.NewInt(0)
+	}
+
+	percentage := int64(sp.stakingSettings.SlashingPercentage() * slashingPercentagePrecision)
+	slashed := big.NewInt(0).Mul(stake, big.NewInt(percentage))
+
+	return slashed.Div(slashed, big.NewInt(slashingPercentagePrecision))
+}
+
+// stakedDataKey is the key under which the stake of a node is saved in the data trie of the staking account. The
+// public keys are not checked, so the stake keys and the offence keys have different prefixes, otherwise a
+// staked public key could hide an offence from slashing
+func stakedDataKey(pubKey []byte) []byte {
+	return append([]byte(stakeAction+dataSeparator), pubKey...)
+}
+
+// slashingKey is the key under which a slashed offence is recorded in the data trie of the staking account
+func slashingKey(pubKey []byte, round uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s%s%s%d", slashAction, dataSeparator, hex.EncodeToString(pubKey), dataSeparator, round))
+}
+
+func (sp *stakingProcessor) refund(tx *transaction.Transaction, acntStaking *state.Account) error {
+	if tx.Value == nil || tx.Value.Sign() <= 0 {
+		return nil
+	}
+
+	return sp.sendValue(tx, nil, acntStaking, tx.Value)
+}
+
+// sendValue moves value from the staking account to the sender of the transaction. If the sender is in another
+// shard, the value is sent through a smart contract result
+func (sp *stakingProcessor) sendValue(
+	tx *transaction.Transaction,
+	acntSrc, acntStaking *state.Account,
+	value *big.Int,
+) error {
+	err := acntStaking.SetBalanceWithJournal(big.NewInt(0).Sub(acntStaking.Balance, value))
+	if err != nil {
+		return err
+	}
+
+	if acntSrc != nil {
+		return acntSrc.SetBalanceWithJournal(big.NewInt(0).Add(acntSrc.Balance, value))
+	}
+
+	txHash, err := core.CalculateHash(sp.marshalizer, sp.hasher, tx)
+	if err != nil {
+		return err
+	}
+
+	scr := &smartContractResult.SmartContractResult{
+		Nonce:   tx.Nonce,
+		Value:   big.NewInt(0).Set(value),
+		RcvAddr: tx.SndAddr,
+		SndAddr: tx.RcvAddr,
+		TxHash:  txHash,
+	}
+
+	return sp.scrForwarder.AddIntermediateTransactions([]data.TransactionHandler{scr})
+}
+
+func (sp *stakingProcessor) getStakedData(acntStaking *state.Account, pubKey []byte) (*StakedData, error) {
+	if acntStaking.DataTrie() == nil {
+		return nil, nil
+	}
+
+	buff, err := acntStaking.DataTrieTracker().RetrieveValue(stakedDataKey(pubKey))
+	if err != nil {
+		return nil, err
+	}
+	if len(buff) == 0 {
+		return nil, nil
+	}
+
+	stakedData := &StakedData{}
+	err = sp.marshalizer.Unmarshal(stakedData, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return stakedData, nil
+}
+
+func (sp *stakingProcessor) saveStakedData(acntStaking *state.Account, pubKey []byte, stakedData *StakedData) error {
+	buff, err := sp.marshalizer.Marshal(stakedData)
+	if err != nil {
+		return err
+	}
+
+	acntStaking.DataTrieTracker().SaveKeyValue(stakedDataKey(pubKey), buff)
+	return sp.accounts.SaveDataTrie(acntStaking)
+}
+
+// checkOwnerSignature verifies that the owner address has been signed with the private key of the staked node
+func (sp *stakingProcessor) checkOwnerSignature(pubKey []byte, owner []byte, signature []byte) error {
+	publicKey, err := sp.keyGen.PublicKeyFromByteArray(pubKey)
+	if err != nil {
+		return process.ErrInvalidOwnerSignature
+	}
+
+	err = sp.singleSigner.Verify(publicKey, owner, signature)
+	if err != nil {
+		return process.ErrInvalidOwnerSignature
+	}
+
+	return nil
+}
+
+func checkOwnerAction(tx *transaction.Transaction, stakedData *StakedData) error {
+	if tx.Value != nil && tx.Value.Sign() != 0 {
+		return process.ErrInvalidStakingData
+	}
+	if stakedData == nil {
+		return process.ErrNodeNotStaked
+	}
+	if !bytes.Equal(stakedData.Owner, tx.SndAddr) {
+		return process.ErrNotStakeOwner
+	}
+
+	return nil
+}
+
+// parseStakingData splits the data field of a staking transaction, which has the form
+// stake@hex(public key)@hex(owner signature), action@hex(public key) for the other node actions or, for slashing,
+// slash@hex(slashing proof)
+func parseStakingData(txData string) (string, [][]byte, error) {
+	tokens := strings.Split(txData, dataSeparator)
+	if len(tokens) < 2 {
+		return "", nil, process.ErrInvalidStakingData
+	}
+
+	arguments := make([][]byte, 0, len(tokens)-1)
+	for _, token := range tokens[1:] {
+		argument, err := hex.DecodeString(token)
+		if err != nil || len(argument) == 0 {
+			return "", nil, process.ErrInvalidStakingData
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return tokens[0], arguments, nil
+}
@@ -0,0 +1,743 @@
+package staking_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/crypto"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/staking"
+	"github.com/stretchr/testify/assert"
+)
+
+const minStake = 1000
+const unBondPeriod = 10
+const slashingPercentage = 0.25
+
+var nodePubKey = []byte("node public key")
+
+func createStakingSettings() *mock.StakingSettingsStub {
+	return &mock.StakingSettingsStub{
+		MinStakeValueCalled: func() *big.Int {
+			return big.NewInt(minStake)
+		},
+		UnBondPeriodCalled: func() uint64 {
+			return unBondPeriod
+		},
+		SlashingPercentageCalled: func() float64 {
+			return slashingPercentage
+		},
+	}
+}
+
+// createAccountsWithDataTrie returns an accounts adapter which moves the dirty data of the saved accounts into the
+// given storage, together with a trie which serves the values from the same storage
+func createAccountsWithDataTrie(storage map[string][]byte) (*mock.AccountsStub, *mock.TrieStub) {
+	accounts := &mock.AccountsStub{
+		SaveDataTrieCalled: func(acountWrapper state.AccountHandler) error {
+			for k, v := range acountWrapper.DataTrieTracker().DirtyData() {
+				if len(v) == 0 {
+					delete(storage, k)
+					continue
+				}
+				storage[k] = v
+			}
+			acountWrapper.DataTrieTracker().ClearDataCaches()
+			return nil
+		},
+	}
+	trie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return storage[string(key)], nil
+		},
+	}
+
+	return accounts, trie
+}
+
+func createAccount(address []byte, balance int64) *state.Account {
+	tracker := &mock.AccountTrackerStub{
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+	}
+	acnt, _ := state.NewAccount(mock.NewAddressMock(address), tracker)
+	acnt.Balance = big.NewInt(balance)
+
+	return acnt
+}
+
+// createOwnerSignature returns the signature of the owner address made with the node key, as checked by the signer
+// created by createSingleSigner
+func createOwnerSignature(owner []byte) []byte {
+	return append([]byte("signed by node: "), owner...)
+}
+
+func createSingleSigner() *mock.SignerMock {
+	return &mock.SignerMock{
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			if !bytes.Equal(sig, createOwnerSignature(msg)) {
+				return errors.New("invalid signature")
+			}
+			return nil
+		},
+	}
+}
+
+func createKeyGen() *mock.SingleSignKeyGenMock {
+	return &mock.SingleSignKeyGenMock{
+		PublicKeyFromByteArrayCalled: func(b []byte) (crypto.PublicKey, error) {
+			return &mock.SingleSignPublicKey{}, nil
+		},
+	}
+}
+
+func createStakingTx(sender []byte, action string, value int64) *transaction.Transaction {
+	data := action + "@" + hex.EncodeToString(nodePubKey)
+	if action == "stake" {
+		data = staking.StakingTxData(nodePubKey, createOwnerSignature(sender))
+	}
+
+	return &transaction.Transaction{
+		Nonce:   1,
+		SndAddr: sender,
+		RcvAddr: process.StakingAddress,
+		Value:   big.NewInt(value),
+		Data:    data,
+	}
+}
+
+func createStakingProcessorWithStorage(
+	storage map[string][]byte,
+	scrForwarder process.IntermediateTransactionHandler,
+) (process.StakingHandler, *state.Account) {
+	return createStakingProcessorWithVerifier(storage, scrForwarder, &mock.SlashingProofVerifierStub{})
+}
+
+func createStakingProcessorWithVerifier(
+	storage map[string][]byte,
+	scrForwarder process.IntermediateTransactionHandler,
+	slashingVerifier process.SlashingProofVerifier,
+) (process.StakingHandler, *state.Account) {
+	accounts, trie := createAccountsWithDataTrie(storage)
+	sp, _ := staking.NewStakingProcessor(
+		accounts,
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		scrForwarder,
+		createStakingSettings(),
+		createKeyGen(),
+		createSingleSigner(),
+		slashingVerifier,
+	)
+
+	acntStaking := createAccount(process.StakingAddress, 0)
+	acntStaking.SetDataTrie(trie)
+
+	return sp, acntStaking
+}
+
+//------- NewStakingProcessor
+
+func TestNewStakingProcessor_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		nil,
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		&mock.IntermediateTransactionHandlerMock{},
+		createStakingSettings(),
+		createKeyGen(),
+		createSingleSigner(),
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestNewStakingProcessor_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		nil,
+		mock.HasherMock{},
+		&mock.IntermediateTransactionHandlerMock{},
+		createStakingSettings(),
+		createKeyGen(),
+		createSingleSigner(),
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+}
+
+func TestNewStakingProcessor_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		&mock.MarshalizerMock{},
+		nil,
+		&mock.IntermediateTransactionHandlerMock{},
+		createStakingSettings(),
+		createKeyGen(),
+		createSingleSigner(),
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilHasher, err)
+}
+
+func TestNewStakingProcessor_NilScrForwarderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		nil,
+		createStakingSettings(),
+		createKeyGen(),
+		createSingleSigner(),
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilIntermediateTransactionHandler, err)
+}
+
+func TestNewStakingProcessor_NilStakingSettingsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		&mock.IntermediateTransactionHandlerMock{},
+		nil,
+		createKeyGen(),
+		createSingleSigner(),
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilStakingSettings, err)
+}
+
+func TestNewStakingProcessor_NilKeyGenShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		&mock.IntermediateTransactionHandlerMock{},
+		createStakingSettings(),
+		nil,
+		createSingleSigner(),
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilKeyGen, err)
+}
+
+func TestNewStakingProcessor_NilSingleSignerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		&mock.IntermediateTransactionHandlerMock{},
+		createStakingSettings(),
+		createKeyGen(),
+		nil,
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilSingleSigner, err)
+}
+
+func TestNewStakingProcessor_NilSlashingVerifierShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		&mock.IntermediateTransactionHandlerMock{},
+		createStakingSettings(),
+		createKeyGen(),
+		createSingleSigner(),
+		nil,
+	)
+
+	assert.Nil(t, sp)
+	assert.Equal(t, process.ErrNilSlashingProofVerifier, err)
+}
+
+func TestNewStakingProcessor_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(
+		&mock.AccountsStub{},
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		&mock.IntermediateTransactionHandlerMock{},
+		createStakingSettings(),
+		createKeyGen(),
+		createSingleSigner(),
+		&mock.SlashingProofVerifierStub{},
+	)
+
+	assert.NotNil(t, sp)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(sp.PeerInfo()))
+}
+
+//------- ProcessStakingTransaction
+
+func TestStakingProcessor_ProcessStakingTransactionNilTxShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+
+	err := sp.ProcessStakingTransaction(nil, nil, acntStaking, 0)
+
+	assert.Equal(t, process.ErrNilTransaction, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionNilStakingAccountShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	tx := createStakingTx([]byte("owner"), "stake", minStake)
+
+	err := sp.ProcessStakingTransaction(tx, nil, nil, 0)
+
+	assert.Equal(t, process.ErrNilStakingAccount, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionInvalidDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+
+	tx := createStakingTx([]byte("owner"), "stake", minStake)
+	tx.Data = "stake"
+	err := sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 0)
+	assert.Equal(t, process.ErrInvalidStakingData, err)
+
+	tx.Data = "stake@not hex"
+	err = sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 0)
+	assert.Equal(t, process.ErrInvalidStakingData, err)
+
+	tx = createStakingTx([]byte("owner"), "unknown", minStake)
+	err = sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 0)
+	assert.Equal(t, process.ErrInvalidStakingData, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionStakeInsufficientValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	tx := createStakingTx([]byte("owner"), "stake", minStake-1)
+
+	err := sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 0)
+
+	assert.Equal(t, process.ErrInsufficientStake, err)
+	assert.Equal(t, 0, len(sp.PeerInfo()))
+}
+
+func TestStakingProcessor_ProcessStakingTransactionStakeShouldWork(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	sp, acntStaking := createStakingProcessorWithStorage(storage, &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	tx := createStakingTx([]byte("owner"), "stake", minStake)
+
+	err := sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 5)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(storage))
+	expectedPeerData := block.PeerData{
+		PublicKey: nodePubKey,
+		Action:    block.PeerRegistrantion,
+		TimeStamp: 5,
+		Value:     big.NewInt(minStake),
+		Address:   []byte("owner"),
+	}
+	assert.Equal(t, []block.PeerData{expectedPeerData}, sp.PeerInfo())
+}
+
+func TestStakingProcessor_ProcessStakingTransactionStakeWithoutOwnerSignatureShouldErr(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	sp, acntStaking := createStakingProcessorWithStorage(storage, &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	tx := createStakingTx([]byte("owner"), "stake", minStake)
+	tx.Data = "stake@" + hex.EncodeToString(nodePubKey)
+
+	err := sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 5)
+
+	assert.Equal(t, process.ErrInvalidStakingData, err)
+	assert.Equal(t, 0, len(storage))
+}
+
+func TestStakingProcessor_ProcessStakingTransactionStakeSignedForAnotherOwnerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	sp, acntStaking := createStakingProcessorWithStorage(storage, &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("attacker"), 0)
+	// the attacker replays the staking data of the real owner
+	tx := createStakingTx([]byte("attacker"), "stake", minStake)
+	tx.Data = staking.StakingTxData(nodePubKey, createOwnerSignature([]byte("owner")))
+
+	err := sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 5)
+
+	assert.Equal(t, process.ErrInvalidOwnerSignature, err)
+	assert.Equal(t, 0, len(storage))
+	assert.Equal(t, 0, len(sp.PeerInfo()))
+}
+
+func TestStakingProcessor_ProcessStakingTransactionStakeTwiceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	tx := createStakingTx([]byte("owner"), "stake", minStake)
+
+	_ = sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 5)
+	err := sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 6)
+
+	assert.Equal(t, process.ErrNodeAlreadyStaked, err)
+	assert.Equal(t, 1, len(sp.PeerInfo()))
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnStakeNotStakedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	tx := createStakingTx([]byte("owner"), "unstake", 0)
+
+	err := sp.ProcessStakingTransaction(tx, acntSrc, acntStaking, 5)
+
+	assert.Equal(t, process.ErrNodeNotStaked, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnStakeNotOwnerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), acntSrc, acntStaking, 5)
+
+	acntOther := createAccount([]byte("other"), 0)
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("other"), "unstake", 0), acntOther, acntStaking, 6)
+
+	assert.Equal(t, process.ErrNotStakeOwner, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnStakeWithValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), acntSrc, acntStaking, 5)
+
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unstake", 1), acntSrc, acntStaking, 6)
+
+	assert.Equal(t, process.ErrInvalidStakingData, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnStakeShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), acntSrc, acntStaking, 5)
+	sp.CreateBlockStarted()
+
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unstake", 0), acntSrc, acntStaking, 6)
+
+	assert.Nil(t, err)
+	expectedPeerData := block.PeerData{
+		PublicKey: nodePubKey,
+		Action:    block.PeerDeregistration,
+		TimeStamp: 6,
+		Value:     big.NewInt(minStake),
+		Address:   []byte("owner"),
+	}
+	assert.Equal(t, []block.PeerData{expectedPeerData}, sp.PeerInfo())
+
+	err = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unstake", 0), acntSrc, acntStaking, 7)
+	assert.Equal(t, process.ErrNodeAlreadyUnStaked, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnBondNotUnStakedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), acntSrc, acntStaking, 5)
+
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unbond", 0), acntSrc, acntStaking, 100)
+
+	assert.Equal(t, process.ErrNodeNotUnStaked, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnBondBeforePeriodShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), acntSrc, acntStaking, 5)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unstake", 0), acntSrc, acntStaking, 6)
+
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unbond", 0), acntSrc, acntStaking, 6+unBondPeriod-1)
+
+	assert.Equal(t, process.ErrUnBondPeriodNotPassed, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnBondSameShardShouldReturnStake(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	sp, acntStaking := createStakingProcessorWithStorage(storage, &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			assert.Fail(t, "stake should have been returned directly")
+			return nil
+		},
+	})
+	acntStaking.Balance = big.NewInt(minStake)
+	acntSrc := createAccount([]byte("owner"), 0)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), acntSrc, acntStaking, 5)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unstake", 0), acntSrc, acntStaking, 6)
+	sp.CreateBlockStarted()
+
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unbond", 0), acntSrc, acntStaking, 6+unBondPeriod)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(storage))
+	assert.Equal(t, uint64(0), acntStaking.Balance.Uint64())
+	assert.Equal(t, big.NewInt(minStake), acntSrc.Balance)
+	assert.Equal(t, 0, len(sp.PeerInfo()))
+}
+
+func TestStakingProcessor_ProcessStakingTransactionUnBondCrossShardShouldCreateScr(t *testing.T) {
+	t.Parallel()
+
+	var scrs []data.TransactionHandler
+	storage := make(map[string][]byte)
+	sp, acntStaking := createStakingProcessorWithStorage(storage, &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			scrs = append(scrs, txs...)
+			return nil
+		},
+	})
+	acntStaking.Balance = big.NewInt(minStake)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), nil, acntStaking, 5)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unstake", 0), nil, acntStaking, 6)
+
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "unbond", 0), nil, acntStaking, 6+unBondPeriod)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(storage))
+	assert.Equal(t, uint64(0), acntStaking.Balance.Uint64())
+	assert.Equal(t, 1, len(scrs))
+	scr := scrs[0].(*smartContractResult.SmartContractResult)
+	assert.Equal(t, []byte("owner"), scr.RcvAddr)
+	assert.Equal(t, process.StakingAddress, scr.SndAddr)
+	assert.Equal(t, big.NewInt(minStake), scr.Value)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionCrossShardFailureShouldRefund(t *testing.T) {
+	t.Parallel()
+
+	var scrs []data.TransactionHandler
+	storage := make(map[string][]byte)
+	sp, acntStaking := createStakingProcessorWithStorage(storage, &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			scrs = append(scrs, txs...)
+			return nil
+		},
+	})
+	acntStaking.Balance = big.NewInt(minStake - 1)
+
+	err := sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake-1), nil, acntStaking, 5)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(storage))
+	assert.Equal(t, 0, len(sp.PeerInfo()))
+	assert.Equal(t, uint64(0), acntStaking.Balance.Uint64())
+	assert.Equal(t, 1, len(scrs))
+	scr := scrs[0].(*smartContractResult.SmartContractResult)
+	assert.Equal(t, []byte("owner"), scr.RcvAddr)
+	assert.Equal(t, big.NewInt(minStake-1), scr.Value)
+}
+
+func createOffenceVerifier(offender []byte, round uint64) *mock.SlashingProofVerifierStub {
+	return &mock.SlashingProofVerifierStub{
+		VerifyProofCalled: func(proof []byte) ([]byte, uint64, error) {
+			if string(proof) != "proof" {
+				return nil, 0, errors.New("invalid proof")
+			}
+			return offender, round, nil
+		},
+	}
+}
+
+func createSlashingTx(value int64) *transaction.Transaction {
+	return &transaction.Transaction{
+		Nonce:   1,
+		SndAddr: []byte("reporter"),
+		RcvAddr: process.StakingAddress,
+		Value:   big.NewInt(value),
+		Data:    staking.SlashingTxData([]byte("proof")),
+	}
+}
+
+func TestStakingProcessor_ProcessStakingTransactionSlashInvalidProofShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithVerifier(
+		make(map[string][]byte),
+		&mock.IntermediateTransactionHandlerMock{},
+		createOffenceVerifier(nodePubKey, 3),
+	)
+	tx := createSlashingTx(0)
+	tx.Data = staking.SlashingTxData([]byte("other proof"))
+
+	err := sp.ProcessStakingTransaction(tx, createAccount([]byte("reporter"), 0), acntStaking, 5)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(sp.PeerInfo()))
+}
+
+func TestStakingProcessor_ProcessStakingTransactionSlashWithValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithVerifier(
+		make(map[string][]byte),
+		&mock.IntermediateTransactionHandlerMock{},
+		createOffenceVerifier(nodePubKey, 3),
+	)
+
+	err := sp.ProcessStakingTransaction(createSlashingTx(10), createAccount([]byte("reporter"), 0), acntStaking, 5)
+
+	assert.Equal(t, process.ErrInvalidStakingData, err)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionSlashShouldBurnStake(t *testing.T) {
+	t.Parallel()
+
+	storage := make(map[string][]byte)
+	sp, acntStaking := createStakingProcessorWithVerifier(
+		storage,
+		&mock.IntermediateTransactionHandlerMock{},
+		createOffenceVerifier(nodePubKey, 3),
+	)
+	_ = sp.ProcessStakingTransaction(
+		createStakingTx([]byte("owner"), "stake", minStake),
+		createAccount([]byte("owner"), 0),
+		acntStaking,
+		1,
+	)
+	acntStaking.Balance = big.NewInt(minStake)
+	sp.CreateBlockStarted()
+
+	err := sp.ProcessStakingTransaction(createSlashingTx(0), createAccount([]byte("reporter"), 0), acntStaking, 5)
+
+	assert.Nil(t, err)
+	slashed := int64(minStake * slashingPercentage)
+	assert.Equal(t, big.NewInt(minStake-slashed), acntStaking.Balance)
+	expectedPeerData := block.PeerData{
+		PublicKey: nodePubKey,
+		Action:    block.PeerSlashing,
+		TimeStamp: 5,
+		Value:     big.NewInt(slashed),
+		Address:   []byte("owner"),
+	}
+	assert.Equal(t, []block.PeerData{expectedPeerData}, sp.PeerInfo())
+
+	//the stake left can still be unstaked and released by the owner
+	stakedData := &staking.StakedData{}
+	_ = (&mock.MarshalizerMock{}).Unmarshal(stakedData, storage["stake@"+string(nodePubKey)])
+	assert.Equal(t, big.NewInt(minStake-slashed), stakedData.Stake)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionSlashTwiceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithVerifier(
+		make(map[string][]byte),
+		&mock.IntermediateTransactionHandlerMock{},
+		createOffenceVerifier(nodePubKey, 3),
+	)
+	acntReporter := createAccount([]byte("reporter"), 0)
+
+	err := sp.ProcessStakingTransaction(createSlashingTx(0), acntReporter, acntStaking, 5)
+	assert.Nil(t, err)
+
+	err = sp.ProcessStakingTransaction(createSlashingTx(0), acntReporter, acntStaking, 6)
+	assert.Equal(t, process.ErrNodeAlreadySlashed, err)
+	assert.Equal(t, 1, len(sp.PeerInfo()))
+	assert.Equal(t, big.NewInt(0), sp.PeerInfo()[0].Value)
+}
+
+func TestStakingProcessor_ProcessStakingTransactionSlashAfterStakingTheOffenceKeyShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithVerifier(
+		make(map[string][]byte),
+		&mock.IntermediateTransactionHandlerMock{},
+		createOffenceVerifier(nodePubKey, 3),
+	)
+	offenceKey := "slash@" + hex.EncodeToString(nodePubKey) + "@3"
+	stakeTx := createStakingTx([]byte("owner"), "stake", minStake)
+	stakeTx.Data = staking.StakingTxData([]byte(offenceKey), createOwnerSignature([]byte("owner")))
+
+	err := sp.ProcessStakingTransaction(stakeTx, createAccount([]byte("owner"), 0), acntStaking, 1)
+	assert.Nil(t, err)
+
+	err = sp.ProcessStakingTransaction(createSlashingTx(0), createAccount([]byte("reporter"), 0), acntStaking, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, block.PeerSlashing, sp.PeerInfo()[1].Action)
+}
+
+func TestStakingProcessor_CreateBlockStartedShouldResetPeerInfo(t *testing.T) {
+	t.Parallel()
+
+	sp, acntStaking := createStakingProcessorWithStorage(make(map[string][]byte), &mock.IntermediateTransactionHandlerMock{})
+	acntSrc := createAccount([]byte("owner"), 0)
+	_ = sp.ProcessStakingTransaction(createStakingTx([]byte("owner"), "stake", minStake), acntSrc, acntStaking, 5)
+	assert.Equal(t, 1, len(sp.PeerInfo()))
+
+	sp.CreateBlockStarted()
+
+	assert.Equal(t, 0, len(sp.PeerInfo()))
+}
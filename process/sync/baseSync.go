@@ -60,6 +60,7 @@ type baseBootstrap struct {
 	shardCoordinator    sharding.Coordinator
 	accounts            state.AccountsAdapter
 	storageBootstrapper storageBootstrapper
+	commitJournal       process.CommitJournal
 
 	mutHeader     sync.RWMutex
 	headerNonce   *uint64
@@ -96,6 +97,16 @@ func (boot *baseBootstrap) loadBlocks(
 	var err error
 	var validNonce uint64
 
+	// the blocks which were only partially persisted before the node stopped are removed first, so that the
+	// highest nonce in storer belongs to a complete block
+	numRolledBack, errNotCritical := boot.commitJournal.RollbackIncompleteBlocks()
+	if errNotCritical != nil {
+		log.Info(fmt.Sprintf("check commit journal: %s\n", errNotCritical.Error()))
+	}
+	if numRolledBack > 0 {
+		log.Info(fmt.Sprintf("%d incomplete blocks have been rolled back from storer\n", numRolledBack))
+	}
+
 	highestNonceInStorer := boot.computeHighestNonce(hdrNonceHashDataUnit)
 
 	log.Info(fmt.Sprintf("the highest header nonce committed in storer is %d\n", highestNonceInStorer))
@@ -135,6 +146,16 @@ func (boot *baseBootstrap) loadBlocks(
 				continue
 			}
 
+			err = boot.restoreEpochState(boot.blkc.GetCurrentBlockHeader())
+			if err != nil {
+				log.Info(fmt.Sprintf("restore epoch state for block with nonce %d in shard %d: %s\n",
+					boot.blkc.GetCurrentBlockHeader().GetNonce(),
+					boot.blkc.GetCurrentBlockHeader().GetShardID(),
+					err.Error()))
+				currentNonce--
+				continue
+			}
+
 			break
 		}
 
@@ -172,6 +193,16 @@ func (boot *baseBootstrap) loadBlocks(
 	return nil
 }
 
+// restoreEpochState restores the epoch and the validators lists of the current block, or of the genesis block when
+// there is no current block, as they are kept only in memory
+func (boot *baseBootstrap) restoreEpochState(currentHeader data.HeaderHandler) error {
+	if currentHeader == nil || currentHeader.IsInterfaceNil() {
+		currentHeader = boot.blkc.GetGenesisHeader()
+	}
+
+	return boot.blkExecutor.RestoreEpochState(currentHeader)
+}
+
 func (boot *baseBootstrap) computeHighestNonce(hdrNonceHashDataUnit dataRetriever.UnitType) uint64 {
 	highestNonceInStorer := uint64(0)
 
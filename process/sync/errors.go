@@ -41,3 +41,6 @@ var ErrRandomSeedNotValid = errors.New("random seed is not valid")
 
 // ErrInvalidShardId signals that an invalid shard id has been provided
 var ErrInvalidShardId = errors.New("invalid shard id")
+
+// ErrNoNotarizedHeader signals that no header of the current shard, notarized by the metachain, has been received
+var ErrNoNotarizedHeader = errors.New("no notarized header received")
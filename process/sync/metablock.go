@@ -43,6 +43,7 @@ func NewMetaBootstrap(
 	shardCoordinator sharding.Coordinator,
 	accounts state.AccountsAdapter,
 	bootstrapRoundIndex uint64,
+	commitJournal process.CommitJournal,
 ) (*MetaBootstrap, error) {
 
 	if poolsHolder == nil {
@@ -70,6 +71,9 @@ func NewMetaBootstrap(
 	if err != nil {
 		return nil, err
 	}
+	if commitJournal == nil {
+		return nil, process.ErrNilCommitJournal
+	}
 
 	base := &baseBootstrap{
 		blkc:                blkc,
@@ -85,6 +89,7 @@ func NewMetaBootstrap(
 		shardCoordinator:    shardCoordinator,
 		accounts:            accounts,
 		bootstrapRoundIndex: bootstrapRoundIndex,
+		commitJournal:       commitJournal,
 	}
 
 	boot := MetaBootstrap{
@@ -621,6 +626,11 @@ func (boot *MetaBootstrap) rollback(header *block.MetaBlock) error {
 		return err
 	}
 
+	err = boot.restoreEpochState(newHeader)
+	if err != nil {
+		return err
+	}
+
 	boot.cleanCachesOnRollback(header, headerStore, headerNonceHashStore)
 	errNotCritical := boot.blkExecutor.RestoreBlockIntoPools(header, nil)
 	if errNotCritical != nil {
@@ -128,6 +128,7 @@ func TestNewMetaBootstrap_NilPoolsHolderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -165,6 +166,7 @@ func TestNewMetaBootstrap_PoolsHolderRetNilOnHeadersShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -197,6 +199,7 @@ func TestNewMetaBootstrap_NilStoreShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -229,6 +232,7 @@ func TestNewMetaBootstrap_NilBlockchainShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -261,6 +265,7 @@ func TestNewMetaBootstrap_NilRounderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -293,6 +298,7 @@ func TestNewMetaBootstrap_NilBlockProcessorShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -325,6 +331,7 @@ func TestNewMetaBootstrap_NilHasherShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -357,6 +364,7 @@ func TestNewMetaBootstrap_NilMarshalizerShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -389,6 +397,7 @@ func TestNewMetaBootstrap_NilForkDetectorShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -422,6 +431,7 @@ func TestNewMetaBootstrap_NilResolversContainerShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -454,6 +464,7 @@ func TestNewMetaBootstrap_NilShardCoordinatorShouldErr(t *testing.T) {
 		nil,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -486,12 +497,46 @@ func TestNewMetaBootstrap_NilAccountsAdapterShouldErr(t *testing.T) {
 		shardCoordinator,
 		nil,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
 }
 
+func TestNewMetaBootstrap_NilCommitJournalShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pools := createMockMetaPools()
+	blkc := initBlockchain()
+	rnd := &mock.RounderMock{}
+	blkExec := &mock.BlockProcessorMock{}
+	forkDetector := &mock.ForkDetectorMock{}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+
+	bs, err := sync.NewMetaBootstrap(
+		pools,
+		createStore(),
+		blkc,
+		rnd,
+		blkExec,
+		waitTime,
+		hasher,
+		marshalizer,
+		forkDetector,
+		&mock.ResolversFinderStub{},
+		shardCoordinator,
+		&mock.AccountsStub{},
+		math.MaxUint32,
+		nil,
+	)
+
+	assert.Nil(t, bs)
+	assert.Equal(t, process.ErrNilCommitJournal, err)
+}
+
 func TestNewMetaBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -529,6 +574,7 @@ func TestNewMetaBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -572,6 +618,7 @@ func TestNewMetaBootstrap_NilTxBlockBodyResolverShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -629,6 +676,7 @@ func TestNewMetaBootstrap_OkValsShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.NotNil(t, bs)
@@ -691,6 +739,7 @@ func TestMetaBootstrap_SyncBlockShouldCallForkChoice(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	r := bs.SyncBlock()
@@ -745,6 +794,7 @@ func TestMetaBootstrap_ShouldReturnTimeIsOutWhenMissingHeader(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	r := bs.SyncBlock()
@@ -797,6 +847,7 @@ func TestMetaBootstrap_ShouldNotNeedToSync(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.StartSync()
@@ -896,6 +947,7 @@ func TestMetaBootstrap_SyncShouldSyncOneBlock(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.StartSync()
@@ -991,6 +1043,7 @@ func TestMetaBootstrap_ShouldReturnNilErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	r := bs.SyncBlock()
@@ -1089,6 +1142,7 @@ func TestMetaBootstrap_SyncBlockShouldReturnErrorWhenProcessBlockFailed(t *testi
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.SyncBlock()
@@ -1129,6 +1183,7 @@ func TestMetaBootstrap_ShouldSyncShouldReturnFalseWhenCurrentBlockIsNilAndRoundI
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, err)
@@ -1169,6 +1224,7 @@ func TestMetaBootstrap_ShouldReturnTrueWhenCurrentBlockIsNilAndRoundIndexIsGreat
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1213,6 +1269,7 @@ func TestMetaBootstrap_ShouldReturnFalseWhenNodeIsSynced(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.False(t, bs.ShouldSync())
@@ -1257,6 +1314,7 @@ func TestMetaBootstrap_ShouldReturnTrueWhenNodeIsNotSynced(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1303,6 +1361,7 @@ func TestMetaBootstrap_ShouldSyncShouldReturnTrueWhenForkIsDetectedAndItReceives
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	_ = forkDetector.AddHeader(&hdr1, hash1, process.BHProcessed, nil, nil)
@@ -1364,6 +1423,7 @@ func TestMetaBootstrap_ShouldSyncShouldReturnFalseWhenForkIsDetectedAndItReceive
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	_ = forkDetector.AddHeader(&hdr1, hash1, process.BHProcessed, nil, nil)
@@ -1414,6 +1474,7 @@ func TestMetaBootstrap_GetHeaderFromPoolShouldReturnNil(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	hdr, _, _ := process.GetMetaHeaderFromPoolWithNonce(0, pools.MetaChainBlocks(), pools.HeadersNonces())
@@ -1482,6 +1543,7 @@ func TestMetaBootstrap_GetHeaderFromPoolShouldReturnHeader(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	hdr2, _, _ := process.GetMetaHeaderFromPoolWithNonce(0, pools.MetaChainBlocks(), pools.HeadersNonces())
@@ -1552,6 +1614,7 @@ func TestMetaBootstrap_ReceivedHeadersFoundInPoolShouldAddToForkDetector(t *test
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -1625,6 +1688,7 @@ func TestMetaBootstrap_ReceivedHeadersNotFoundInPoolShouldNotAddToForkDetector(t
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -1661,6 +1725,7 @@ func TestMetaBootstrap_ForkChoiceNilBlockchainHeaderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.ForkChoice()
@@ -1694,6 +1759,7 @@ func TestMetaBootstrap_ForkChoiceNilParamHeaderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -1749,6 +1815,7 @@ func TestMetaBootstrap_ForkChoiceIsNotEmptyShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -1822,10 +1889,15 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.
 	}
 
 	rnd := &mock.RounderMock{}
+	var restoredHeader data.HeaderHandler
 	blkExec := &mock.BlockProcessorMock{
 		RestoreBlockIntoPoolsCalled: func(header data.HeaderHandler, body data.BodyHandler) error {
 			return nil
 		},
+		RestoreEpochStateCalled: func(header data.HeaderHandler) error {
+			restoredHeader = header
+			return nil
+		},
 	}
 
 	hasher := &mock.HasherStub{
@@ -1880,6 +1952,7 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -1923,6 +1996,7 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.
 	assert.Equal(t, blkc.GetCurrentBlockHeader(), prevHdr)
 	assert.Equal(t, blkc.GetCurrentBlockBody(), prevTxBlockBody)
 	assert.Equal(t, blkc.GetCurrentBlockHeaderHash(), prevHdrHash)
+	assert.Equal(t, prevHdr.RootHash, restoredHeader.GetRootHash())
 }
 
 func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T) {
@@ -1987,10 +2061,15 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testi
 		},
 	}
 	rnd := &mock.RounderMock{}
+	var restoredHeader data.HeaderHandler
 	blkExec := &mock.BlockProcessorMock{
 		RestoreBlockIntoPoolsCalled: func(header data.HeaderHandler, body data.BodyHandler) error {
 			return nil
 		},
+		RestoreEpochStateCalled: func(header data.HeaderHandler) error {
+			restoredHeader = header
+			return nil
+		},
 	}
 
 	hasher := &mock.HasherStub{
@@ -2045,6 +2124,7 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testi
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -2078,6 +2158,7 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testi
 	assert.True(t, remFlags.flagHdrRemovedFromForkDetector)
 	assert.Nil(t, blkc.GetCurrentBlockHeader())
 	assert.Nil(t, blkc.GetCurrentBlockHeaderHash())
+	assert.Equal(t, prevHdr.RootHash, restoredHeader.GetRootHash())
 }
 
 func TestMetaBootstrap_AddSyncStateListenerShouldAppendAnotherListener(t *testing.T) {
@@ -2107,6 +2188,7 @@ func TestMetaBootstrap_AddSyncStateListenerShouldAppendAnotherListener(t *testin
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	f1 := func(bool) {}
@@ -2148,6 +2230,7 @@ func TestMetaBootstrap_NotifySyncStateListenersShouldNotify(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	mutex.RLock()
@@ -2221,6 +2304,7 @@ func TestMetaBootstrap_SyncFromStorerShouldErrWhenLoadBlocksFails(t *testing.T)
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.SyncFromStorer(process.MetaBlockFinality,
@@ -2308,6 +2392,7 @@ func TestMetaBootstrap_SyncFromStorerShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.SyncFromStorer(process.MetaBlockFinality,
@@ -2380,6 +2465,7 @@ func TestMetaBootstrap_ApplyNotarizedBlockShouldErrWhenGetFinalNotarizedShardHea
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	lastNotarized := make(map[uint32]uint64, 0)
@@ -2460,6 +2546,7 @@ func TestMetaBootstrap_ApplyNotarizedBlockShouldErrWhenGetLastNotarizedShardHead
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	lastNotarized := make(map[uint32]uint64, 0)
@@ -2540,6 +2627,7 @@ func TestMetaBootstrap_ApplyNotarizedBlockShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		&mock.CommitJournalStub{},
 	)
 
 	lastNotarized := make(map[uint32]uint64, 0)
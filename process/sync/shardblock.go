@@ -32,9 +32,12 @@ type ShardBootstrap struct {
 	resolversFinder   dataRetriever.ResolversFinder
 	hdrRes            dataRetriever.HeaderResolver
 	miniBlockResolver dataRetriever.MiniBlocksResolver
+
+	stateSyncer process.StateSyncer
 }
 
-// NewShardBootstrap creates a new Bootstrap object
+// NewShardBootstrap creates a new Bootstrap object. The state syncer is optional, when provided it is used to sync
+// the state of the latest notarized header if the node can not bootstrap from its storage
 func NewShardBootstrap(
 	poolsHolder dataRetriever.PoolsHolder,
 	store dataRetriever.StorageService,
@@ -49,6 +52,8 @@ func NewShardBootstrap(
 	shardCoordinator sharding.Coordinator,
 	accounts state.AccountsAdapter,
 	bootstrapRoundIndex uint64,
+	stateSyncer process.StateSyncer,
+	commitJournal process.CommitJournal,
 ) (*ShardBootstrap, error) {
 
 	if poolsHolder == nil {
@@ -79,6 +84,9 @@ func NewShardBootstrap(
 	if err != nil {
 		return nil, err
 	}
+	if commitJournal == nil {
+		return nil, process.ErrNilCommitJournal
+	}
 
 	base := &baseBootstrap{
 		blkc:                blkc,
@@ -94,11 +102,13 @@ func NewShardBootstrap(
 		shardCoordinator:    shardCoordinator,
 		accounts:            accounts,
 		bootstrapRoundIndex: bootstrapRoundIndex,
+		commitJournal:       commitJournal,
 	}
 
 	boot := ShardBootstrap{
 		baseBootstrap: base,
 		miniBlocks:    poolsHolder.MiniBlocks(),
+		stateSyncer:   stateSyncer,
 	}
 
 	base.storageBootstrapper = &boot
@@ -446,11 +456,36 @@ func (boot *ShardBootstrap) StartSync() {
 		process.MetaBlockFinality)
 	if errNotCritical != nil {
 		log.Info(errNotCritical.Error())
+		boot.syncState()
 	}
 
 	go boot.syncBlocks()
 }
 
+// syncState downloads the state of the latest notarized header, if a state syncer was provided, so that the node
+// does not have to execute all the blocks since genesis. On failure, the node falls back to syncing block by block
+func (boot *ShardBootstrap) syncState() {
+	if boot.stateSyncer == nil {
+		return
+	}
+
+	err := boot.stateSyncer.SyncState()
+	if err != nil {
+		log.Info(fmt.Sprintf("state sync failed, the blocks will be synced one by one: %s\n", err.Error()))
+		return
+	}
+
+	errNotCritical := boot.forkDetector.AddHeader(
+		boot.blkc.GetCurrentBlockHeader(),
+		boot.blkc.GetCurrentBlockHeaderHash(),
+		process.BHProcessed,
+		nil,
+		nil)
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+}
+
 // StopSync method will stop SyncBlocks
 func (boot *ShardBootstrap) StopSync() {
 	boot.chStopSync <- true
@@ -816,6 +851,11 @@ func (boot *ShardBootstrap) rollback(header *block.Header) error {
 		return err
 	}
 
+	err = boot.restoreEpochState(newHeader)
+	if err != nil {
+		return err
+	}
+
 	body, err := boot.getTxBlockBody(header)
 	if err != nil {
 		return err
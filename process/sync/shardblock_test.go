@@ -300,6 +300,8 @@ func TestNewShardBootstrap_NilPoolsHolderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -337,6 +339,8 @@ func TestNewShardBootstrap_PoolsHolderRetNilOnHeadersShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -373,6 +377,8 @@ func TestNewShardBootstrap_PoolsHolderRetNilOnHeadersNoncesShouldErr(t *testing.
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -409,6 +415,8 @@ func TestNewShardBootstrap_PoolsHolderRetNilOnTxBlockBodyShouldErr(t *testing.T)
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -441,6 +449,8 @@ func TestNewShardBootstrap_NilStoreShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -473,6 +483,8 @@ func TestNewShardBootstrap_NilBlockchainShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -505,6 +517,8 @@ func TestNewShardBootstrap_NilRounderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -537,6 +551,8 @@ func TestNewShardBootstrap_NilBlockProcessorShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -569,6 +585,8 @@ func TestNewShardBootstrap_NilHasherShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -601,6 +619,8 @@ func TestNewShardBootstrap_NilMarshalizerShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -633,6 +653,8 @@ func TestNewShardBootstrap_NilForkDetectorShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -666,6 +688,8 @@ func TestNewShardBootstrap_NilResolversContainerShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -698,6 +722,8 @@ func TestNewShardBootstrap_NilShardCoordinatorShouldErr(t *testing.T) {
 		nil,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -730,12 +756,48 @@ func TestNewShardBootstrap_NilAccountsAdapterShouldErr(t *testing.T) {
 		shardCoordinator,
 		nil,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
 }
 
+func TestNewShardBootstrap_NilCommitJournalShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pools := createMockPools()
+	blkc := initBlockchain()
+	rnd := &mock.RounderMock{}
+	blkExec := &mock.BlockProcessorMock{}
+	forkDetector := &mock.ForkDetectorMock{}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+
+	bs, err := sync.NewShardBootstrap(
+		pools,
+		createStore(),
+		blkc,
+		rnd,
+		blkExec,
+		waitTime,
+		hasher,
+		marshalizer,
+		forkDetector,
+		&mock.ResolversFinderStub{},
+		shardCoordinator,
+		&mock.AccountsStub{},
+		math.MaxUint32,
+		nil,
+		nil,
+	)
+
+	assert.Nil(t, bs)
+	assert.Equal(t, process.ErrNilCommitJournal, err)
+}
+
 func TestNewShardBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -778,6 +840,8 @@ func TestNewShardBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -826,6 +890,8 @@ func TestNewShardBootstrap_NilTxBlockBodyResolverShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.Nil(t, bs)
@@ -891,6 +957,8 @@ func TestNewShardBootstrap_OkValsShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.NotNil(t, bs)
@@ -963,6 +1031,8 @@ func TestBootstrap_SyncBlockShouldCallForkChoice(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	r := bs.SyncBlock()
@@ -1017,6 +1087,8 @@ func TestBootstrap_ShouldReturnTimeIsOutWhenMissingHeader(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	r := bs.SyncBlock()
@@ -1107,6 +1179,8 @@ func TestBootstrap_ShouldReturnTimeIsOutWhenMissingBody(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.RequestHeaderWithNonce(2)
@@ -1159,6 +1233,8 @@ func TestBootstrap_ShouldNotNeedToSync(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.StartSync()
@@ -1277,6 +1353,8 @@ func TestBootstrap_SyncShouldSyncOneBlock(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.StartSync()
@@ -1389,6 +1467,8 @@ func TestBootstrap_ShouldReturnNilErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	r := bs.SyncBlock()
@@ -1501,6 +1581,8 @@ func TestBootstrap_SyncBlockShouldReturnErrorWhenProcessBlockFailed(t *testing.T
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.SyncBlock()
@@ -1542,6 +1624,8 @@ func TestBootstrap_ShouldSyncShouldReturnFalseWhenCurrentBlockIsNilAndRoundIndex
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.False(t, bs.ShouldSync())
@@ -1581,6 +1665,8 @@ func TestBootstrap_ShouldReturnTrueWhenCurrentBlockIsNilAndRoundIndexIsGreaterTh
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1625,6 +1711,8 @@ func TestBootstrap_ShouldReturnFalseWhenNodeIsSynced(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.False(t, bs.ShouldSync())
@@ -1669,6 +1757,8 @@ func TestBootstrap_ShouldReturnTrueWhenNodeIsNotSynced(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1715,6 +1805,8 @@ func TestBootstrap_ShouldSyncShouldReturnTrueWhenForkIsDetectedAndItReceivesTheS
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	_ = forkDetector.AddHeader(&hdr1, hash1, process.BHProcessed, nil, nil)
@@ -1776,6 +1868,8 @@ func TestBootstrap_ShouldSyncShouldReturnFalseWhenForkIsDetectedAndItReceivesThe
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	_ = forkDetector.AddHeader(&hdr1, hash1, process.BHProcessed, nil, nil)
@@ -1826,6 +1920,8 @@ func TestBootstrap_GetHeaderFromPoolShouldReturnNil(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	hdr, _, _ := process.GetShardHeaderFromPoolWithNonce(0, 0, pools.Headers(), pools.HeadersNonces())
@@ -1896,6 +1992,8 @@ func TestBootstrap_GetHeaderFromPoolShouldReturnHeader(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	hdr2, _, _ := process.GetShardHeaderFromPoolWithNonce(0, 0, pools.Headers(), pools.HeadersNonces())
@@ -1944,6 +2042,8 @@ func TestShardGetBlockFromPoolShouldReturnBlock(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	mbHashes := make([][]byte, 0)
@@ -2017,6 +2117,8 @@ func TestBootstrap_ReceivedHeadersFoundInPoolShouldAddToForkDetector(t *testing.
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -2094,6 +2196,8 @@ func TestBootstrap_ReceivedHeadersNotFoundInPoolShouldNotAddToForkDetector(t *te
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -2130,6 +2234,8 @@ func TestBootstrap_ForkChoiceNilBlockchainHeaderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.ForkChoice()
@@ -2163,6 +2269,8 @@ func TestBootstrap_ForkChoiceNilParamHeaderShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -2218,6 +2326,8 @@ func TestBootstrap_ForkChoiceIsNotEmptyShouldErr(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -2291,10 +2401,15 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.T) {
 	}
 
 	rnd := &mock.RounderMock{}
+	var restoredHeader data.HeaderHandler
 	blkExec := &mock.BlockProcessorMock{
 		RestoreBlockIntoPoolsCalled: func(header data.HeaderHandler, body data.BodyHandler) error {
 			return nil
 		},
+		RestoreEpochStateCalled: func(header data.HeaderHandler) error {
+			restoredHeader = header
+			return nil
+		},
 	}
 
 	hasher := &mock.HasherStub{
@@ -2349,6 +2464,8 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -2392,6 +2509,7 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.T) {
 	assert.Equal(t, blkc.GetCurrentBlockHeader(), prevHdr)
 	assert.Equal(t, blkc.GetCurrentBlockBody(), prevTxBlockBody)
 	assert.Equal(t, blkc.GetCurrentBlockHeaderHash(), prevHdrHash)
+	assert.Equal(t, prevHdr.RootHash, restoredHeader.GetRootHash())
 }
 
 func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T) {
@@ -2456,10 +2574,15 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T
 		},
 	}
 	rnd := &mock.RounderMock{}
+	var restoredHeader data.HeaderHandler
 	blkExec := &mock.BlockProcessorMock{
 		RestoreBlockIntoPoolsCalled: func(header data.HeaderHandler, body data.BodyHandler) error {
 			return nil
 		},
+		RestoreEpochStateCalled: func(header data.HeaderHandler) error {
+			restoredHeader = header
+			return nil
+		},
 	}
 
 	hasher := &mock.HasherStub{
@@ -2514,6 +2637,8 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -2557,6 +2682,7 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T
 	assert.Nil(t, blkc.GetCurrentBlockHeader())
 	assert.Nil(t, blkc.GetCurrentBlockBody())
 	assert.Nil(t, blkc.GetCurrentBlockHeaderHash())
+	assert.Equal(t, prevHdr.RootHash, restoredHeader.GetRootHash())
 }
 
 //------- GetTxBodyHavingHash
@@ -2610,6 +2736,8 @@ func TestBootstrap_GetTxBodyHavingHashReturnsFromCacherShouldWork(t *testing.T)
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 	txBlockRecovered := bs.GetMiniBlocks(requestedHash)
 
@@ -2664,6 +2792,8 @@ func TestBootstrap_GetTxBodyHavingHashNotFoundInCacherOrStorageShouldRetNil(t *t
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 	txBlockRecovered := bs.GetMiniBlocks(requestedHash)
 
@@ -2724,6 +2854,8 @@ func TestBootstrap_GetTxBodyHavingHashFoundInStorageShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 	txBlockRecovered := bs.GetMiniBlocks(requestedHash)
 
@@ -2757,6 +2889,8 @@ func TestBootstrap_AddSyncStateListenerShouldAppendAnotherListener(t *testing.T)
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	f1 := func(bool) {}
@@ -2798,6 +2932,8 @@ func TestBootstrap_NotifySyncStateListenersShouldNotify(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	mutex.RLock()
@@ -2893,6 +3029,8 @@ func TestBootstrap_LoadBlocksShouldErrBootstrapFromStorage(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -2970,6 +3108,8 @@ func TestBootstrap_LoadBlocksShouldErrBootstrapFromStorageWhenBlocksAreNotValid(
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3057,6 +3197,102 @@ func TestBootstrap_LoadBlocksShouldErrWhenRecreateTrieFail(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
+	)
+
+	bs.SetStorageBootstrapper(storageBootstrapper)
+
+	err := bs.LoadBlocks(
+		process.ShardBlockFinality,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.ShardHdrNonceHashDataUnit+dataRetriever.UnitType(shardCoordinator.SelfId()),
+	)
+
+	assert.True(t, wasCalled)
+	assert.Equal(t, process.ErrNotEnoughValidBlocksInStorage, err)
+}
+
+func TestBootstrap_LoadBlocksShouldErrWhenRestoreEpochStateFail(t *testing.T) {
+	t.Parallel()
+
+	wasCalled := false
+	errExpected := errors.New("error to restore epoch state")
+	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
+	pools := &mock.PoolsHolderStub{}
+	pools.HeadersCalled = func() storage.Cacher {
+		sds := &mock.CacherStub{}
+		sds.RegisterHandlerCalled = func(func(key []byte)) {
+		}
+
+		return sds
+	}
+	pools.HeadersNoncesCalled = func() dataRetriever.Uint64SyncMapCacher {
+		hnc := &mock.Uint64SyncMapCacherStub{}
+		hnc.RegisterHandlerCalled = func(handler func(nonce uint64, shardId uint32, hash []byte)) {}
+
+		return hnc
+	}
+	pools.MiniBlocksCalled = func() storage.Cacher {
+		cs := &mock.CacherStub{}
+		cs.RegisterHandlerCalled = func(i func(key []byte)) {
+		}
+
+		return cs
+	}
+
+	blkc := initBlockchain()
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return &block.Header{}
+	}
+	rnd := &mock.RounderMock{}
+	blkExec := &mock.BlockProcessorMock{
+		RestoreEpochStateCalled: func(header data.HeaderHandler) error {
+			wasCalled = true
+			return errExpected
+		},
+	}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+	forkDetector := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState, finalHeader data.HeaderHandler, finalHeaderHash []byte) error {
+			return nil
+		},
+	}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	account := &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			return nil
+		},
+	}
+	storageBootstrapper := NewStorageBootstrapperMock()
+
+	store := createStore()
+	store.HasCalled = func(unitType dataRetriever.UnitType, key []byte) error {
+		if bytes.Equal(key, uint64Converter.ToByteSlice(1)) ||
+			bytes.Equal(key, uint64Converter.ToByteSlice(2)) {
+			return nil
+		}
+
+		return errors.New("key not found")
+	}
+
+	bs, _ := sync.NewShardBootstrap(
+		pools,
+		store,
+		blkc,
+		rnd,
+		blkExec,
+		waitTime,
+		hasher,
+		marshalizer,
+		forkDetector,
+		createMockResolversFinder(),
+		shardCoordinator,
+		account,
+		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3098,13 +3334,20 @@ func TestBootstrap_LoadBlocksShouldWorkAfterRemoveInvalidBlocks(t *testing.T) {
 		return cs
 	}
 
+	currentHeader := &block.Header{}
 	blkc := initBlockchain()
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
-		return &block.Header{}
+		return currentHeader
 	}
 
 	rnd := &mock.RounderMock{}
-	blkExec := &mock.BlockProcessorMock{}
+	var restoredHeader data.HeaderHandler
+	blkExec := &mock.BlockProcessorMock{
+		RestoreEpochStateCalled: func(header data.HeaderHandler) error {
+			restoredHeader = header
+			return nil
+		},
+	}
 	hasher := &mock.HasherMock{}
 	marshalizer := &mock.MarshalizerMock{}
 	forkDetector := &mock.ForkDetectorMock{
@@ -3151,6 +3394,8 @@ func TestBootstrap_LoadBlocksShouldWorkAfterRemoveInvalidBlocks(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3162,6 +3407,7 @@ func TestBootstrap_LoadBlocksShouldWorkAfterRemoveInvalidBlocks(t *testing.T) {
 	)
 
 	assert.Nil(t, err)
+	assert.True(t, restoredHeader == currentHeader)
 }
 
 func TestBootstrap_ApplyBlockShouldErrWhenHeaderIsNotFound(t *testing.T) {
@@ -3220,6 +3466,8 @@ func TestBootstrap_ApplyBlockShouldErrWhenHeaderIsNotFound(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3291,6 +3539,8 @@ func TestBootstrap_ApplyBlockShouldErrWhenBodyIsNotFound(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3361,6 +3611,8 @@ func TestBootstrap_ApplyBlockShouldErrWhenSetCurrentBlockBodyFails(t *testing.T)
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3432,6 +3684,8 @@ func TestBootstrap_ApplyBlockShouldErrWhenSetCurrentBlockHeaderFails(t *testing.
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3498,6 +3752,8 @@ func TestBootstrap_ApplyBlockShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	bs.SetStorageBootstrapper(storageBootstrapper)
@@ -3569,6 +3825,8 @@ func TestBootstrap_RemoveBlockHeaderShouldErrNilHeadersStorage(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(1,
@@ -3638,6 +3896,8 @@ func TestBootstrap_RemoveBlockHeaderShouldErrNilHeadersNonceHashStorage(t *testi
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(1,
@@ -3701,6 +3961,8 @@ func TestBootstrap_RemoveBlockHeaderShouldErrWhenHeaderIsNotFound(t *testing.T)
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(1,
@@ -3779,6 +4041,8 @@ func TestBootstrap_RemoveBlockHeaderShouldErrWhenRemoveHeaderHashFails(t *testin
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(1,
@@ -3857,6 +4121,8 @@ func TestBootstrap_RemoveBlockHeaderShouldErrWhenRemoveHeaderNonceFails(t *testi
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(1,
@@ -3916,6 +4182,8 @@ func TestBootstrap_RemoveBlockHeaderShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(1,
@@ -3978,6 +4246,8 @@ func TestBootstrap_SyncFromStorerShouldErrWhenLoadBlocksFails(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.SyncFromStorer(process.ShardBlockFinality,
@@ -4159,6 +4429,8 @@ func TestBootstrap_SyncFromStorerShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.SyncFromStorer(process.ShardBlockFinality,
@@ -4252,6 +4524,8 @@ func TestBootstrap_ApplyNotarizedBlockShouldErrWhenGetFinalNotarizedMetaHeaderFr
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	lastNotarized := make(map[uint32]uint64, 0)
@@ -4354,6 +4628,8 @@ func TestBootstrap_ApplyNotarizedBlockShouldErrWhenGetLastNotarizedMetaHeaderFro
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	lastNotarized := make(map[uint32]uint64, 0)
@@ -4456,6 +4732,8 @@ func TestBootstrap_ApplyNotarizedBlockShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	lastNotarized := make(map[uint32]uint64, 0)
@@ -4522,6 +4800,8 @@ func TestBootstrap_RemoveNotarizedBlockShouldErrNilHeadersStorage(t *testing.T)
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(
@@ -4589,6 +4869,8 @@ func TestBootstrap_RemoveNotarizedBlockShouldErrNilHeadersNonceHashStorage(t *te
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(
@@ -4669,6 +4951,8 @@ func TestBootstrap_RemoveNotarizedBlockShouldErrWhenRemoveHeaderHashFails(t *tes
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(
@@ -4749,6 +5033,8 @@ func TestBootstrap_RemoveNotarizedBlockShouldErrWhenRemoveHeaderNonceFails(t *te
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(
@@ -4809,6 +5095,8 @@ func TestBootstrap_RemoveNotarizedBlockShouldWork(t *testing.T) {
 		shardCoordinator,
 		account,
 		math.MaxUint32,
+		nil,
+		&mock.CommitJournalStub{},
 	)
 
 	err := bs.RemoveBlockHeader(
@@ -0,0 +1,567 @@
+package sync
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/factory"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// maxTrieNodesRequestsPerRound defines the maximum number of trie nodes requests sent before waiting for the answers
+const maxTrieNodesRequestsPerRound = 10
+
+// pendingTrieNode is a received trie node which is kept in memory until all the nodes it references are committed,
+// so that a stored node always has its whole sub-trie stored
+type pendingTrieNode struct {
+	encNode         []byte
+	missingChildren int
+}
+
+// trieNodesSync holds the progress of a trie download
+type trieNodesSync struct {
+	missing map[string]bool
+	pending map[string]*pendingTrieNode
+	parents map[string][]string
+}
+
+// StateSync downloads from the other peers the state of the latest header notarized by the metachain, so that
+// a new node can start bootstrapping from that header instead of executing all the blocks since genesis
+type StateSync struct {
+	headers             storage.Cacher
+	metaBlocks          storage.Cacher
+	trieNodes           storage.Cacher
+	hdrRes              dataRetriever.Resolver
+	metaBlockRes        dataRetriever.Resolver
+	trieNodesRes        dataRetriever.TrieNodesResolver
+	trieStorage         data.DBWriteCacher
+	triePruner          data.TriePruner
+	referencesExtractor data.LeafReferencesExtractor
+	blkc                data.ChainHandler
+	blkExecutor         process.BlockProcessor
+	accounts            state.AccountsAdapter
+	hasher              hashing.Hasher
+	marshalizer         marshal.Marshalizer
+	shardCoordinator    sharding.Coordinator
+	waitTime            time.Duration
+	chRcvData           chan bool
+}
+
+// NewStateSync creates a new StateSync object. The trie pruner is optional, when provided the synced root is
+// registered to it
+func NewStateSync(
+	poolsHolder dataRetriever.PoolsHolder,
+	resolversFinder dataRetriever.ResolversFinder,
+	trieStorage data.DBWriteCacher,
+	triePruner data.TriePruner,
+	referencesExtractor data.LeafReferencesExtractor,
+	blkc data.ChainHandler,
+	blkExecutor process.BlockProcessor,
+	accounts state.AccountsAdapter,
+	hasher hashing.Hasher,
+	marshalizer marshal.Marshalizer,
+	shardCoordinator sharding.Coordinator,
+	waitTime time.Duration,
+) (*StateSync, error) {
+
+	if poolsHolder == nil {
+		return nil, process.ErrNilPoolsHolder
+	}
+	if poolsHolder.Headers() == nil {
+		return nil, process.ErrNilHeadersDataPool
+	}
+	if poolsHolder.MetaBlocks() == nil {
+		return nil, process.ErrNilMetaBlockPool
+	}
+	if poolsHolder.TrieNodes() == nil {
+		return nil, process.ErrNilTrieNodesPool
+	}
+	if resolversFinder == nil {
+		return nil, process.ErrNilResolverContainer
+	}
+	if trieStorage == nil {
+		return nil, process.ErrNilTrieStorage
+	}
+	if referencesExtractor == nil {
+		return nil, process.ErrNilLeafReferencesExtractor
+	}
+	if blkc == nil {
+		return nil, process.ErrNilBlockChain
+	}
+	if blkExecutor == nil {
+		return nil, process.ErrNilBlockExecutor
+	}
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if hasher == nil {
+		return nil, process.ErrNilHasher
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if shardCoordinator == nil {
+		return nil, process.ErrNilShardCoordinator
+	}
+
+	hdrRes, err := resolversFinder.IntraShardResolver(factory.HeadersTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	metaBlockRes, err := resolversFinder.MetaChainResolver(factory.MetachainBlocksTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := resolversFinder.IntraShardResolver(factory.TrieNodesTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	trieNodesRes, ok := resolver.(dataRetriever.TrieNodesResolver)
+	if !ok {
+		return nil, process.ErrWrongTypeAssertion
+	}
+
+	ss := &StateSync{
+		headers:             poolsHolder.Headers(),
+		metaBlocks:          poolsHolder.MetaBlocks(),
+		trieNodes:           poolsHolder.TrieNodes(),
+		hdrRes:              hdrRes,
+		metaBlockRes:        metaBlockRes,
+		trieNodesRes:        trieNodesRes,
+		trieStorage:         trieStorage,
+		triePruner:          triePruner,
+		referencesExtractor: referencesExtractor,
+		blkc:                blkc,
+		blkExecutor:         blkExecutor,
+		accounts:            accounts,
+		hasher:              hasher,
+		marshalizer:         marshalizer,
+		shardCoordinator:    shardCoordinator,
+		waitTime:            waitTime,
+		chRcvData:           make(chan bool, 1),
+	}
+
+	ss.headers.RegisterHandler(ss.receivedData)
+	ss.metaBlocks.RegisterHandler(ss.receivedData)
+	ss.trieNodes.RegisterHandler(ss.receivedData)
+
+	return ss, nil
+}
+
+// SyncState fetches the latest header notarized by the metachain, downloads its state trie node by node and sets
+// the header as the current block of the chain, so that the bootstrapping continues from it
+func (ss *StateSync) SyncState() error {
+	header, headerHash, err := ss.getLatestNotarizedHeader()
+	if err != nil {
+		return err
+	}
+
+	lastNotarizedMetaBlock, err := ss.getLastNotarizedMetaBlock(header)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("syncing the state of the block with nonce %d and root hash %s\n",
+		header.Nonce,
+		core.ToB64(header.RootHash)))
+
+	err = ss.syncTrie(header.RootHash)
+	if err != nil {
+		return err
+	}
+
+	if ss.triePruner != nil {
+		err = ss.triePruner.AddRoot(header.RootHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ss.accounts.RecreateTrie(header.RootHash)
+	if err != nil {
+		return err
+	}
+
+	err = ss.blkc.SetCurrentBlockHeader(header)
+	if err != nil {
+		return err
+	}
+	ss.blkc.SetCurrentBlockHeaderHash(headerHash)
+
+	if lastNotarizedMetaBlock != nil {
+		ss.blkExecutor.AddLastNotarizedHdr(sharding.MetachainShardId, lastNotarizedMetaBlock)
+	}
+
+	log.Info(fmt.Sprintf("the state of the block with nonce %d has been synced successfully\n", header.Nonce))
+
+	return nil
+}
+
+// getLatestNotarizedHeader returns the latest header notarized by the metachain or, if that header does not hold
+// any meta block, the closest previous header which does, as it is needed to know the last notarized meta block
+func (ss *StateSync) getLatestNotarizedHeader() (*block.Header, []byte, error) {
+	ss.waitUntil(func() bool {
+		return ss.getLatestNotarizedHeaderHash() != nil
+	})
+
+	headerHash := ss.getLatestNotarizedHeaderHash()
+	if headerHash == nil {
+		return nil, nil, ErrNoNotarizedHeader
+	}
+
+	header, err := ss.getHeaderRequestingIfMissing(headerHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for len(header.MetaBlockHashes) == 0 && header.Nonce > 1 {
+		headerHash = header.PrevHash
+		header, err = ss.getHeaderRequestingIfMissing(headerHash)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return header, headerHash, nil
+}
+
+// getLatestNotarizedHeaderHash returns the self shard header notarized by the highest final meta block from the pool.
+// A meta block is final when it is followed by MetaBlockFinality meta blocks which chain by their previous hash,
+// so a single meta block received from the network is not enough to choose the state to sync
+func (ss *StateSync) getLatestNotarizedHeaderHash() []byte {
+	metaBlocks := make(map[string]*block.MetaBlock)
+	successors := make(map[string][]string)
+	for _, key := range ss.metaBlocks.Keys() {
+		metaBlock, err := process.GetMetaHeaderFromPool(key, ss.metaBlocks)
+		if err != nil {
+			continue
+		}
+
+		metaBlocks[string(key)] = metaBlock
+		successors[string(metaBlock.PrevHash)] = append(successors[string(metaBlock.PrevHash)], string(key))
+	}
+
+	var latestMetaBlock *block.MetaBlock
+	var headerHash []byte
+
+	for hash, metaBlock := range metaBlocks {
+		if latestMetaBlock != nil && metaBlock.Nonce <= latestMetaBlock.Nonce {
+			continue
+		}
+		if !isMetaBlockFinal(hash, metaBlocks, successors, process.MetaBlockFinality) {
+			continue
+		}
+
+		for _, shardData := range metaBlock.ShardInfo {
+			if shardData.ShardId == ss.shardCoordinator.SelfId() {
+				latestMetaBlock = metaBlock
+				headerHash = shardData.HeaderHash
+			}
+		}
+	}
+
+	return headerHash
+}
+
+// isMetaBlockFinal checks if the meta block with the given hash has a chain of at least finality successors
+func isMetaBlockFinal(
+	hash string,
+	metaBlocks map[string]*block.MetaBlock,
+	successors map[string][]string,
+	finality uint32,
+) bool {
+	if finality == 0 {
+		return true
+	}
+
+	for _, nextHash := range successors[hash] {
+		if metaBlocks[nextHash].Nonce != metaBlocks[hash].Nonce+1 {
+			continue
+		}
+		if isMetaBlockFinal(nextHash, metaBlocks, successors, finality-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ss *StateSync) getLastNotarizedMetaBlock(header *block.Header) (*block.MetaBlock, error) {
+	var lastNotarizedMetaBlock *block.MetaBlock
+	for _, metaBlockHash := range header.MetaBlockHashes {
+		metaBlock, err := ss.getMetaBlockRequestingIfMissing(metaBlockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastNotarizedMetaBlock == nil || metaBlock.Nonce > lastNotarizedMetaBlock.Nonce {
+			lastNotarizedMetaBlock = metaBlock
+		}
+	}
+
+	return lastNotarizedMetaBlock, nil
+}
+
+func (ss *StateSync) getHeaderRequestingIfMissing(hash []byte) (*block.Header, error) {
+	hdr, err := process.GetShardHeaderFromPool(hash, ss.headers)
+	if err == nil {
+		return hdr, nil
+	}
+
+	err = ss.hdrRes.RequestDataFromHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	ss.waitUntil(func() bool {
+		_, ok := ss.headers.Peek(hash)
+		return ok
+	})
+
+	return process.GetShardHeaderFromPool(hash, ss.headers)
+}
+
+func (ss *StateSync) getMetaBlockRequestingIfMissing(hash []byte) (*block.MetaBlock, error) {
+	metaBlock, err := process.GetMetaHeaderFromPool(hash, ss.metaBlocks)
+	if err == nil {
+		return metaBlock, nil
+	}
+
+	err = ss.metaBlockRes.RequestDataFromHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	ss.waitUntil(func() bool {
+		_, ok := ss.metaBlocks.Peek(hash)
+		return ok
+	})
+
+	return process.GetMetaHeaderFromPool(hash, ss.metaBlocks)
+}
+
+// syncTrie downloads the account trie with the given root and all the data tries referenced by its leaves.
+// A node is committed only after all the nodes it references were committed, so an interrupted sync never
+// leaves an incomplete sub-trie in the storage
+func (ss *StateSync) syncTrie(rootHash []byte) error {
+	if len(rootHash) == 0 || ss.isTrieNodeStored(rootHash) {
+		return nil
+	}
+
+	tns := &trieNodesSync{
+		missing: map[string]bool{string(rootHash): false},
+		pending: make(map[string]*pendingTrieNode),
+		parents: make(map[string][]string),
+	}
+
+	requestsWithTimeout := uint32(0)
+	for len(tns.missing) > 0 {
+		requested := ss.requestMissingTrieNodes(tns)
+		ss.waitUntil(func() bool {
+			return ss.areTrieNodesReceived(requested)
+		})
+
+		numReceived, err := ss.processReceivedTrieNodes(tns)
+		if err != nil {
+			return err
+		}
+
+		if numReceived > 0 {
+			requestsWithTimeout = 0
+			continue
+		}
+
+		requestsWithTimeout++
+		if requestsWithTimeout >= process.MaxRequestsWithTimeoutAllowed {
+			return process.ErrTimeIsOut
+		}
+	}
+
+	return nil
+}
+
+func (ss *StateSync) requestMissingTrieNodes(tns *trieNodesSync) [][]byte {
+	maxRequested := dataRetriever.MaxTrieNodesPerRequest * maxTrieNodesRequestsPerRound
+	requested := make([][]byte, 0, maxRequested)
+	for hash := range tns.missing {
+		if len(requested) == maxRequested {
+			break
+		}
+		requested = append(requested, []byte(hash))
+	}
+
+	for start := 0; start < len(requested); start += dataRetriever.MaxTrieNodesPerRequest {
+		end := start + dataRetriever.MaxTrieNodesPerRequest
+		if end > len(requested) {
+			end = len(requested)
+		}
+
+		err := ss.trieNodesRes.RequestDataFromHashArray(requested[start:end])
+		if err != nil {
+			log.Error(err.Error())
+		}
+	}
+
+	log.Info(fmt.Sprintf("requested %d trie nodes from network, %d nodes waiting for their children\n",
+		len(requested),
+		len(tns.pending)))
+
+	return requested
+}
+
+func (ss *StateSync) areTrieNodesReceived(hashes [][]byte) bool {
+	for _, hash := range hashes {
+		_, ok := ss.trieNodes.Peek(hash)
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (ss *StateSync) processReceivedTrieNodes(tns *trieNodesSync) (int, error) {
+	received := make(map[string][]byte)
+	for hash := range tns.missing {
+		val, ok := ss.trieNodes.Peek([]byte(hash))
+		if !ok {
+			continue
+		}
+		ss.trieNodes.Remove([]byte(hash))
+
+		encNode, ok := val.([]byte)
+		if !ok || !bytes.Equal(ss.hasher.Compute(string(encNode)), []byte(hash)) {
+			continue
+		}
+
+		received[hash] = encNode
+	}
+
+	for hash, encNode := range received {
+		isDataTrieNode := tns.missing[hash]
+		delete(tns.missing, hash)
+
+		err := ss.processTrieNode(tns, hash, encNode, isDataTrieNode)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(received), nil
+}
+
+func (ss *StateSync) processTrieNode(tns *trieNodesSync, hash string, encNode []byte, isDataTrieNode bool) error {
+	childrenHashes, leafValue, err := trie.DecodeNodeReferences(encNode, ss.marshalizer)
+	if err != nil {
+		return err
+	}
+
+	references := make([]string, 0, len(childrenHashes))
+	referencesInDataTrie := make([]bool, 0, len(childrenHashes))
+	for _, childHash := range childrenHashes {
+		references = append(references, string(childHash))
+		referencesInDataTrie = append(referencesInDataTrie, isDataTrieNode)
+	}
+
+	// only the leaves of the account trie hold references to other tries
+	if leafValue != nil && !isDataTrieNode {
+		for _, dataTrieRoot := range ss.referencesExtractor.ExtractReferences(leafValue) {
+			references = append(references, string(dataTrieRoot))
+			referencesInDataTrie = append(referencesInDataTrie, true)
+		}
+	}
+
+	missingChildren := 0
+	for i, reference := range references {
+		if ss.isTrieNodeStored([]byte(reference)) {
+			continue
+		}
+
+		missingChildren++
+		tns.parents[reference] = append(tns.parents[reference], hash)
+
+		_, isPending := tns.pending[reference]
+		if !isPending {
+			tns.missing[reference] = referencesInDataTrie[i]
+		}
+	}
+
+	if missingChildren > 0 {
+		tns.pending[hash] = &pendingTrieNode{
+			encNode:         encNode,
+			missingChildren: missingChildren,
+		}
+		return nil
+	}
+
+	return ss.commitTrieNode(tns, hash, encNode)
+}
+
+// commitTrieNode stores the given node and then all its pending ancestors which have no other missing children
+func (ss *StateSync) commitTrieNode(tns *trieNodesSync, hash string, encNode []byte) error {
+	hashes := []string{hash}
+	encNodes := [][]byte{encNode}
+
+	for len(hashes) > 0 {
+		hash = hashes[len(hashes)-1]
+		encNode = encNodes[len(encNodes)-1]
+		hashes = hashes[:len(hashes)-1]
+		encNodes = encNodes[:len(encNodes)-1]
+
+		err := ss.trieStorage.Put([]byte(hash), encNode)
+		if err != nil {
+			return err
+		}
+		delete(tns.pending, hash)
+
+		for _, parentHash := range tns.parents[hash] {
+			parent := tns.pending[parentHash]
+			parent.missingChildren--
+			if parent.missingChildren == 0 {
+				hashes = append(hashes, parentHash)
+				encNodes = append(encNodes, parent.encNode)
+			}
+		}
+		delete(tns.parents, hash)
+	}
+
+	return nil
+}
+
+func (ss *StateSync) isTrieNodeStored(hash []byte) bool {
+	_, err := ss.trieStorage.Get(hash)
+	return err == nil
+}
+
+func (ss *StateSync) receivedData(key []byte) {
+	select {
+	case ss.chRcvData <- true:
+	default:
+	}
+}
+
+// waitUntil blocks until the given condition is met or the wait time has passed
+func (ss *StateSync) waitUntil(isDone func() bool) {
+	timeout := time.After(ss.waitTime)
+	for !isDone() {
+		select {
+		case <-ss.chRcvData:
+		case <-timeout:
+			return
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package sync_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/blockchain"
+	"github.com/ElrondNetwork/elrond-go/data/trie"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/process/factory"
+	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/sync"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+const stateSyncWaitTime = 10 * time.Millisecond
+
+func createStateSyncPools() *mock.PoolsHolderStub {
+	headers := generateTestCache()
+	metaBlocks := generateTestCache()
+	trieNodes := generateTestCache()
+
+	return &mock.PoolsHolderStub{
+		HeadersCalled: func() storage.Cacher {
+			return headers
+		},
+		MetaBlocksCalled: func() storage.Cacher {
+			return metaBlocks
+		},
+		TrieNodesCalled: func() storage.Cacher {
+			return trieNodes
+		},
+	}
+}
+
+func createStateSyncResolversFinder(trieNodesRes dataRetriever.Resolver) *mock.ResolversFinderStub {
+	return &mock.ResolversFinderStub{
+		IntraShardResolverCalled: func(baseTopic string) (dataRetriever.Resolver, error) {
+			if strings.Contains(baseTopic, factory.TrieNodesTopic) {
+				return trieNodesRes, nil
+			}
+
+			return &mock.HeaderResolverMock{
+				RequestDataFromHashCalled: func(hash []byte) error {
+					return nil
+				},
+			}, nil
+		},
+		MetaChainResolverCalled: func(baseTopic string) (dataRetriever.Resolver, error) {
+			return &mock.ResolverStub{
+				RequestDataFromHashCalled: func(hash []byte) error {
+					return nil
+				},
+			}, nil
+		},
+	}
+}
+
+func createStateSync(
+	pools dataRetriever.PoolsHolder,
+	trieNodesRes dataRetriever.Resolver,
+	trieStorage data.DBWriteCacher,
+	blkc data.ChainHandler,
+	blkExec process.BlockProcessor,
+	accounts *mock.AccountsStub,
+) (*sync.StateSync, error) {
+	return sync.NewStateSync(
+		pools,
+		createStateSyncResolversFinder(trieNodesRes),
+		trieStorage,
+		nil,
+		&mock.LeafReferencesExtractorStub{},
+		blkc,
+		blkExec,
+		accounts,
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		stateSyncWaitTime,
+	)
+}
+
+//------- NewStateSync
+
+func TestNewStateSync_NilPoolsHolderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ss, err := createStateSync(nil, &mock.TrieNodesResolverStub{}, generateTestUnit(), initBlockchain(),
+		&mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	assert.Nil(t, ss)
+	assert.Equal(t, process.ErrNilPoolsHolder, err)
+}
+
+func TestNewStateSync_NilTrieNodesPoolShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pools := createStateSyncPools()
+	pools.TrieNodesCalled = func() storage.Cacher {
+		return nil
+	}
+
+	ss, err := createStateSync(pools, &mock.TrieNodesResolverStub{}, generateTestUnit(), initBlockchain(),
+		&mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	assert.Nil(t, ss)
+	assert.Equal(t, process.ErrNilTrieNodesPool, err)
+}
+
+func TestNewStateSync_NilTrieStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ss, err := createStateSync(createStateSyncPools(), &mock.TrieNodesResolverStub{}, nil, initBlockchain(),
+		&mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	assert.Nil(t, ss)
+	assert.Equal(t, process.ErrNilTrieStorage, err)
+}
+
+func TestNewStateSync_NilBlockchainShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ss, err := createStateSync(createStateSyncPools(), &mock.TrieNodesResolverStub{}, generateTestUnit(), nil,
+		&mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	assert.Nil(t, ss)
+	assert.Equal(t, process.ErrNilBlockChain, err)
+}
+
+func TestNewStateSync_WrongTrieNodesResolverTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ss, err := createStateSync(createStateSyncPools(), &mock.ResolverStub{}, generateTestUnit(), initBlockchain(),
+		&mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	assert.Nil(t, ss)
+	assert.Equal(t, process.ErrWrongTypeAssertion, err)
+}
+
+func TestNewStateSync_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	ss, err := createStateSync(createStateSyncPools(), &mock.TrieNodesResolverStub{}, generateTestUnit(),
+		initBlockchain(), &mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	assert.NotNil(t, ss)
+	assert.Nil(t, err)
+}
+
+//------- SyncState
+
+func TestStateSync_SyncStateNoNotarizedHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ss, _ := createStateSync(createStateSyncPools(), &mock.TrieNodesResolverStub{}, generateTestUnit(),
+		initBlockchain(), &mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	err := ss.SyncState()
+
+	assert.Equal(t, sync.ErrNoNotarizedHeader, err)
+}
+
+func TestStateSync_SyncStateShouldDownloadTheTrieAndSetTheHeader(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	hasher := mock.HasherMock{}
+
+	sourceStorage := generateTestUnit()
+	sourceTrie, _ := trie.NewTrie(sourceStorage, marshalizer, hasher)
+	_ = sourceTrie.Update([]byte("doe"), []byte("reindeer"))
+	_ = sourceTrie.Update([]byte("dog"), []byte("puppy"))
+	_ = sourceTrie.Update([]byte("dogglesworth"), []byte("cat"))
+	_ = sourceTrie.Commit()
+	rootHash, _ := sourceTrie.Root()
+
+	pools := createStateSyncPools()
+
+	metaBlockHash := []byte("meta block hash")
+	metaBlock := &block.MetaBlock{Nonce: 5}
+	pools.MetaBlocks().Put(metaBlockHash, metaBlock)
+
+	headerHash := []byte("header hash")
+	header := &block.Header{Nonce: 10, RootHash: rootHash, MetaBlockHashes: [][]byte{metaBlockHash}}
+	pools.Headers().Put(headerHash, header)
+
+	notarizingMetaBlock := &block.MetaBlock{
+		Nonce:     6,
+		ShardInfo: []block.ShardData{{ShardId: 0, HeaderHash: headerHash}},
+	}
+	pools.MetaBlocks().Put([]byte("notarizing meta block hash"), notarizingMetaBlock)
+	pools.MetaBlocks().Put([]byte("final meta block hash"), &block.MetaBlock{
+		Nonce:    7,
+		PrevHash: []byte("notarizing meta block hash"),
+	})
+
+	trieNodesRes := &mock.TrieNodesResolverStub{
+		RequestDataFromHashArrayCalled: func(hashes [][]byte) error {
+			for _, hash := range hashes {
+				encNode, err := sourceStorage.Get(hash)
+				if err == nil {
+					pools.TrieNodes().Put(hash, encNode)
+				}
+			}
+			return nil
+		},
+	}
+
+	var lastNotarized data.HeaderHandler
+	blkExec := &mock.BlockProcessorMock{
+		AddLastNotarizedHdrCalled: func(shardId uint32, processedHdr data.HeaderHandler) {
+			assert.Equal(t, sharding.MetachainShardId, shardId)
+			lastNotarized = processedHdr
+		},
+	}
+
+	var recreatedRoot []byte
+	accounts := &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			recreatedRoot = rootHash
+			return nil
+		},
+	}
+
+	trieStorage := generateTestUnit()
+	blkc, _ := blockchain.NewBlockChain(&mock.CacherStub{})
+	ss, _ := createStateSync(pools, trieNodesRes, trieStorage, blkc, blkExec, accounts)
+
+	err := ss.SyncState()
+	assert.Nil(t, err)
+
+	emptyTrie, _ := trie.NewTrie(trieStorage, marshalizer, hasher)
+	syncedTrie, err := emptyTrie.Recreate(rootHash)
+	assert.Nil(t, err)
+	val, err := syncedTrie.Get([]byte("dogglesworth"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("cat"), val)
+
+	assert.Equal(t, rootHash, recreatedRoot)
+	assert.Equal(t, header, blkc.GetCurrentBlockHeader())
+	assert.Equal(t, headerHash, blkc.GetCurrentBlockHeaderHash())
+	assert.Equal(t, metaBlock, lastNotarized)
+}
+
+func TestStateSync_SyncStateMissingTrieNodesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pools := createStateSyncPools()
+	headerHash := []byte("header hash")
+	pools.Headers().Put(headerHash, &block.Header{Nonce: 1, RootHash: []byte("missing root hash")})
+	pools.MetaBlocks().Put([]byte("meta block hash"), &block.MetaBlock{
+		Nonce:     2,
+		ShardInfo: []block.ShardData{{ShardId: 0, HeaderHash: headerHash}},
+	})
+	pools.MetaBlocks().Put([]byte("final meta block hash"), &block.MetaBlock{
+		Nonce:    3,
+		PrevHash: []byte("meta block hash"),
+	})
+
+	trieNodesRes := &mock.TrieNodesResolverStub{
+		RequestDataFromHashArrayCalled: func(hashes [][]byte) error {
+			return nil
+		},
+	}
+
+	ss, _ := createStateSync(pools, trieNodesRes, generateTestUnit(), initBlockchain(),
+		&mock.BlockProcessorMock{}, &mock.AccountsStub{})
+
+	err := ss.SyncState()
+
+	assert.Equal(t, process.ErrTimeIsOut, err)
+}
+
+func TestStateSync_SyncStateShouldSkipTheMetaBlocksWhichAreNotFinal(t *testing.T) {
+	t.Parallel()
+
+	pools := createStateSyncPools()
+	headerHash := []byte("header hash")
+	pools.Headers().Put(headerHash, &block.Header{Nonce: 1})
+	pools.MetaBlocks().Put([]byte("meta block hash"), &block.MetaBlock{
+		Nonce:     2,
+		ShardInfo: []block.ShardData{{ShardId: 0, HeaderHash: headerHash}},
+	})
+	pools.MetaBlocks().Put([]byte("final meta block hash"), &block.MetaBlock{
+		Nonce:    3,
+		PrevHash: []byte("meta block hash"),
+	})
+
+	// neither the highest meta block nor the one which does not chain with it is final
+	forgedHeaderHash := []byte("forged header hash")
+	pools.MetaBlocks().Put([]byte("forged meta block hash"), &block.MetaBlock{
+		Nonce:     10,
+		ShardInfo: []block.ShardData{{ShardId: 0, HeaderHash: forgedHeaderHash}},
+	})
+	pools.MetaBlocks().Put([]byte("unchained meta block hash"), &block.MetaBlock{
+		Nonce:     9,
+		PrevHash:  []byte("other meta block hash"),
+		ShardInfo: []block.ShardData{{ShardId: 0, HeaderHash: forgedHeaderHash}},
+	})
+	pools.MetaBlocks().Put([]byte("wrong nonce meta block hash"), &block.MetaBlock{
+		Nonce:    12,
+		PrevHash: []byte("unchained meta block hash"),
+	})
+
+	accounts := &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			return nil
+		},
+	}
+	blkc, _ := blockchain.NewBlockChain(&mock.CacherStub{})
+	ss, _ := createStateSync(pools, &mock.TrieNodesResolverStub{}, generateTestUnit(), blkc,
+		&mock.BlockProcessorMock{}, accounts)
+
+	err := ss.SyncState()
+
+	assert.Nil(t, err)
+	assert.Equal(t, headerHash, blkc.GetCurrentBlockHeaderHash())
+}
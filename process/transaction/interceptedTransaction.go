@@ -22,6 +22,7 @@ type InterceptedTransaction struct {
 	singleSigner             crypto.SingleSigner
 	addrConv                 state.AddressConverter
 	coordinator              sharding.Coordinator
+	chainID                  []byte
 	hash                     []byte
 	rcvShard                 uint32
 	sndShard                 uint32
@@ -37,6 +38,7 @@ func NewInterceptedTransaction(
 	signer crypto.SingleSigner,
 	addrConv state.AddressConverter,
 	coordinator sharding.Coordinator,
+	chainID []byte,
 ) (*InterceptedTransaction, error) {
 
 	if txBuff == nil {
@@ -60,6 +62,9 @@ func NewInterceptedTransaction(
 	if coordinator == nil {
 		return nil, process.ErrNilShardCoordinator
 	}
+	if len(chainID) == 0 {
+		return nil, process.ErrInvalidChainID
+	}
 
 	tx := &transaction.Transaction{}
 	err := marshalizer.Unmarshal(tx, txBuff)
@@ -75,6 +80,7 @@ func NewInterceptedTransaction(
 		addrConv:     addrConv,
 		keyGen:       keyGen,
 		coordinator:  coordinator,
+		chainID:      chainID,
 	}
 
 	txBuffWithoutSig, err := inTx.processFields(txBuff)
@@ -92,6 +98,13 @@ func NewInterceptedTransaction(
 		return nil, err
 	}
 
+	if isRelayedTx(tx) {
+		err = inTx.verifyInnerTx()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return inTx, nil
 }
 
@@ -149,17 +162,33 @@ func (inTx *InterceptedTransaction) integrity() error {
 		return process.ErrNegativeValue
 	}
 
+	return inTx.checkChainIDAndVersion(inTx.tx)
+}
+
+// checkChainIDAndVersion rejects the transactions signed for other chains, so they can not be replayed on this one
+func (inTx *InterceptedTransaction) checkChainIDAndVersion(tx *transaction.Transaction) error {
+	if !bytes.Equal(tx.ChainID, inTx.chainID) {
+		return process.ErrInvalidChainID
+	}
+	if tx.Version < process.MinTransactionVersion {
+		return process.ErrInvalidTransactionVersion
+	}
+
 	return nil
 }
 
 // verifySig checks if the tx is correctly signed
 func (inTx *InterceptedTransaction) verifySig(txBuffWithoutSig []byte) error {
-	senderPubKey, err := inTx.keyGen.PublicKeyFromByteArray(inTx.tx.SndAddr)
+	return inTx.verifyTxSig(inTx.tx, txBuffWithoutSig)
+}
+
+func (inTx *InterceptedTransaction) verifyTxSig(tx *transaction.Transaction, txBuffWithoutSig []byte) error {
+	senderPubKey, err := inTx.keyGen.PublicKeyFromByteArray(tx.SndAddr)
 	if err != nil {
 		return err
 	}
 
-	err = inTx.singleSigner.Verify(senderPubKey, txBuffWithoutSig, inTx.tx.Signature)
+	err = inTx.singleSigner.Verify(senderPubKey, txBuffWithoutSig, tx.Signature)
 	if err != nil {
 		return err
 	}
@@ -167,6 +196,43 @@ func (inTx *InterceptedTransaction) verifySig(txBuffWithoutSig []byte) error {
 	return nil
 }
 
+// verifyInnerTx checks the inner transaction of a relayed transaction, which has to be signed by its sender
+func (inTx *InterceptedTransaction) verifyInnerTx() error {
+	innerTx, err := getInnerTransaction(inTx.marshalizer, inTx.tx)
+	if err != nil {
+		return err
+	}
+
+	if innerTx.Signature == nil {
+		return process.ErrNilSignature
+	}
+	if innerTx.RcvAddr == nil {
+		return process.ErrNilRcvAddr
+	}
+	if innerTx.SndAddr == nil {
+		return process.ErrNilSndAddr
+	}
+
+	err = inTx.checkChainIDAndVersion(innerTx)
+	if err != nil {
+		return err
+	}
+
+	err = checkRelayedTx(inTx.tx, innerTx, inTx.addrConv, inTx.coordinator)
+	if err != nil {
+		return err
+	}
+
+	copiedInnerTx := *innerTx
+	copiedInnerTx.Signature = nil
+	innerTxBuffWithoutSig, err := inTx.marshalizer.Marshal(&copiedInnerTx)
+	if err != nil {
+		return err
+	}
+
+	return inTx.verifyTxSig(innerTx, innerTxBuffWithoutSig)
+}
+
 // RcvShard returns the receiver shard
 func (inTx *InterceptedTransaction) RcvShard() uint32 {
 	return inTx.rcvShard
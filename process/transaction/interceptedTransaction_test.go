@@ -23,6 +23,7 @@ var recvShard = uint32(3)
 var senderAddress = []byte("sender")
 var recvAddress = []byte("receiver")
 var sigOk = []byte("signature")
+var chainID = []byte("chain ID")
 
 func createDummySigner() crypto.SingleSigner {
 	return &mock.SignerMock{
@@ -76,6 +77,7 @@ func createInterceptedTxFromPlainTx(tx *dataTransaction.Transaction) (*transacti
 			},
 		},
 		shardCoordinator,
+		chainID,
 	)
 }
 
@@ -90,6 +92,7 @@ func TestNewInterceptedTransaction_NilBufferShouldErr(t *testing.T) {
 		&mock.SignerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -107,6 +110,7 @@ func TestNewInterceptedTransaction_NilMarshalizerShouldErr(t *testing.T) {
 		&mock.SignerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -124,6 +128,7 @@ func TestNewInterceptedTransaction_NilHasherShouldErr(t *testing.T) {
 		&mock.SignerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -141,6 +146,7 @@ func TestNewInterceptedTransaction_NilKeyGenShouldErr(t *testing.T) {
 		&mock.SignerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -158,6 +164,7 @@ func TestNewInterceptedTransaction_NilSignerShouldErr(t *testing.T) {
 		nil,
 		&mock.AddressConverterMock{},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -175,6 +182,7 @@ func TestNewInterceptedTransaction_NilAddressConverterShouldErr(t *testing.T) {
 		&mock.SignerMock{},
 		nil,
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -192,12 +200,31 @@ func TestNewInterceptedTransaction_NilCoordinatorShouldErr(t *testing.T) {
 		&mock.SignerMock{},
 		&mock.AddressConverterMock{},
 		nil,
+		chainID,
 	)
 
 	assert.Nil(t, txi)
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
 }
 
+func TestNewInterceptedTransaction_EmptyChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txi, err := transaction.NewInterceptedTransaction(
+		make([]byte, 0),
+		&mock.MarshalizerMock{},
+		mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		&mock.AddressConverterMock{},
+		mock.NewOneShardCoordinatorMock(),
+		nil,
+	)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrInvalidChainID, err)
+}
+
 func TestNewInterceptedTransaction_UnmarshalingTxFailsShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -215,6 +242,7 @@ func TestNewInterceptedTransaction_UnmarshalingTxFailsShouldErr(t *testing.T) {
 		&mock.SignerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -241,6 +269,7 @@ func TestNewInterceptedTransaction_MarshalingCopiedTxFailsShouldErr(t *testing.T
 		&mock.SignerMock{},
 		&mock.AddressConverterMock{},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -262,6 +291,7 @@ func TestNewInterceptedTransaction_AddrConvFailsShouldErr(t *testing.T) {
 			},
 		},
 		mock.NewOneShardCoordinatorMock(),
+		chainID,
 	)
 
 	assert.Nil(t, txi)
@@ -280,6 +310,8 @@ func TestNewInterceptedTransaction_NilSignatureShouldErr(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: nil,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -300,6 +332,8 @@ func TestNewInterceptedTransaction_NilSenderAddressShouldErr(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   nil,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -320,6 +354,8 @@ func TestNewInterceptedTransaction_NilRecvAddressShouldErr(t *testing.T) {
 		RcvAddr:   nil,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -340,6 +376,8 @@ func TestNewInterceptedTransaction_NilValueShouldErr(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -360,6 +398,8 @@ func TestNewInterceptedTransaction_NilNegativeValueShouldErr(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -380,6 +420,8 @@ func TestNewInterceptedTransaction_InvalidSenderShouldErr(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   []byte(""),
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -400,6 +442,8 @@ func TestNewInterceptedTransaction_VerifyFailsShouldErr(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: []byte("wrong sig"),
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -408,6 +452,71 @@ func TestNewInterceptedTransaction_VerifyFailsShouldErr(t *testing.T) {
 	assert.Equal(t, errSignerMockVerifySigFails, err)
 }
 
+func TestNewInterceptedTransaction_OtherChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := &dataTransaction.Transaction{
+		Nonce:     1,
+		Value:     big.NewInt(2),
+		Data:      "data",
+		GasLimit:  3,
+		GasPrice:  4,
+		RcvAddr:   recvAddress,
+		SndAddr:   senderAddress,
+		Signature: sigOk,
+		ChainID:   []byte("other chain ID"),
+		Version:   process.MinTransactionVersion,
+	}
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrInvalidChainID, err)
+}
+
+func TestNewInterceptedTransaction_NilChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := &dataTransaction.Transaction{
+		Nonce:     1,
+		Value:     big.NewInt(2),
+		Data:      "data",
+		GasLimit:  3,
+		GasPrice:  4,
+		RcvAddr:   recvAddress,
+		SndAddr:   senderAddress,
+		Signature: sigOk,
+		Version:   process.MinTransactionVersion,
+	}
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrInvalidChainID, err)
+}
+
+func TestNewInterceptedTransaction_InvalidVersionShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := &dataTransaction.Transaction{
+		Nonce:     1,
+		Value:     big.NewInt(2),
+		Data:      "data",
+		GasLimit:  3,
+		GasPrice:  4,
+		RcvAddr:   recvAddress,
+		SndAddr:   senderAddress,
+		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion - 1,
+	}
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrInvalidTransactionVersion, err)
+}
+
 func TestNewInterceptedTransaction_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -420,6 +529,8 @@ func TestNewInterceptedTransaction_ShouldWork(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, err := createInterceptedTxFromPlainTx(tx)
@@ -441,6 +552,8 @@ func TestNewInterceptedTransaction_OkValsGettersShouldWork(t *testing.T) {
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 
 	txi, _ := createInterceptedTxFromPlainTx(tx)
@@ -468,6 +581,8 @@ func TestNewInterceptedTransaction_ScTxDeployRecvShardIdShouldBeSendersShardId(t
 		RcvAddr:   recvAddressDeploy,
 		SndAddr:   senderAddressInShard1,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	marshalizer := &mock.MarshalizerMock{}
 	txBuff, _ := marshalizer.Marshal(tx)
@@ -497,9 +612,120 @@ func TestNewInterceptedTransaction_ScTxDeployRecvShardIdShouldBeSendersShardId(t
 			},
 		},
 		shardCoordinator,
+		chainID,
 	)
 
 	assert.Nil(t, err)
 	assert.Equal(t, uint32(1), txIntercepted.RcvShard())
 	assert.Equal(t, uint32(1), txIntercepted.SndShard())
 }
+
+//------- relayed transactions
+
+func createRelayedTx(innerTx *dataTransaction.Transaction) *dataTransaction.Transaction {
+	data, _ := transaction.CreateRelayedTxData(&mock.MarshalizerMock{}, innerTx)
+
+	return &dataTransaction.Transaction{
+		Nonce:     1,
+		Value:     transaction.ComputeRelayedTxValue(innerTx),
+		Data:      data,
+		GasLimit:  300,
+		GasPrice:  4,
+		RcvAddr:   innerTx.SndAddr,
+		SndAddr:   senderAddress,
+		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
+	}
+}
+
+func createInnerTx() *dataTransaction.Transaction {
+	return &dataTransaction.Transaction{
+		Nonce:     5,
+		Value:     big.NewInt(2),
+		Data:      "data",
+		GasLimit:  3,
+		GasPrice:  4,
+		RcvAddr:   recvAddress,
+		SndAddr:   recvAddress,
+		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
+	}
+}
+
+func TestNewInterceptedTransaction_RelayedTxShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tx := createRelayedTx(createInnerTx())
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.NotNil(t, txi)
+	assert.Nil(t, err)
+	assert.Equal(t, senderShard, txi.SndShard())
+	assert.Equal(t, recvShard, txi.RcvShard())
+}
+
+func TestNewInterceptedTransaction_RelayedTxInnerVerifyFailsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	innerTx := createInnerTx()
+	innerTx.Signature = []byte("wrong sig")
+	tx := createRelayedTx(innerTx)
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, errSignerMockVerifySigFails, err)
+}
+
+func TestNewInterceptedTransaction_RelayedTxInnerNilSignatureShouldErr(t *testing.T) {
+	t.Parallel()
+
+	innerTx := createInnerTx()
+	innerTx.Signature = nil
+	tx := createRelayedTx(innerTx)
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrNilSignature, err)
+}
+
+func TestNewInterceptedTransaction_RelayedTxValueMismatchShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := createRelayedTx(createInnerTx())
+	tx.Value = big.NewInt(2)
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrRelayedTxValueMismatch, err)
+}
+
+func TestNewInterceptedTransaction_RelayedTxInvalidDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := createRelayedTx(createInnerTx())
+	tx.Data = process.RelayedTxDataPrefix + "zz"
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrInvalidRelayedTxData, err)
+}
+
+func TestNewInterceptedTransaction_RelayedTxInnerOtherChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	innerTx := createInnerTx()
+	innerTx.ChainID = []byte("other chain ID")
+	tx := createRelayedTx(innerTx)
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrInvalidChainID, err)
+}
@@ -24,6 +24,7 @@ type TxInterceptor struct {
 	singleSigner             crypto.SingleSigner
 	keyGen                   crypto.KeyGenerator
 	shardCoordinator         sharding.Coordinator
+	chainID                  []byte
 	broadcastCallbackHandler func(buffToSend []byte)
 }
 
@@ -37,6 +38,7 @@ func NewTxInterceptor(
 	singleSigner crypto.SingleSigner,
 	keyGen crypto.KeyGenerator,
 	shardCoordinator sharding.Coordinator,
+	chainID []byte,
 ) (*TxInterceptor, error) {
 
 	if marshalizer == nil {
@@ -63,6 +65,9 @@ func NewTxInterceptor(
 	if shardCoordinator == nil {
 		return nil, process.ErrNilShardCoordinator
 	}
+	if len(chainID) == 0 {
+		return nil, process.ErrInvalidChainID
+	}
 
 	txIntercept := &TxInterceptor{
 		marshalizer:      marshalizer,
@@ -73,6 +78,7 @@ func NewTxInterceptor(
 		singleSigner:     singleSigner,
 		keyGen:           keyGen,
 		shardCoordinator: shardCoordinator,
+		chainID:          chainID,
 	}
 
 	return txIntercept, nil
@@ -108,7 +114,8 @@ func (txi *TxInterceptor) ProcessReceivedMessage(message p2p.MessageP2P) error {
 			txi.keyGen,
 			txi.singleSigner,
 			txi.addrConverter,
-			txi.shardCoordinator)
+			txi.shardCoordinator,
+			txi.chainID)
 
 		if err != nil {
 			lastErrEncountered = err
@@ -39,7 +39,8 @@ func TestNewTxInterceptor_NilMarshalizerShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Equal(t, process.ErrNilMarshalizer, err)
 	assert.Nil(t, txi)
@@ -62,7 +63,8 @@ func TestNewTxInterceptor_NilTransactionPoolShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Equal(t, process.ErrNilTxDataPool, err)
 	assert.Nil(t, txi)
@@ -85,7 +87,8 @@ func TestNewTxInterceptor_NilTxHandlerValidatorShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Equal(t, process.ErrNilTxHandlerValidator, err)
 	assert.Nil(t, txi)
@@ -108,7 +111,8 @@ func TestNewTxInterceptor_NilAddressConverterShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Equal(t, process.ErrNilAddressConverter, err)
 	assert.Nil(t, txi)
@@ -132,7 +136,8 @@ func TestNewTxInterceptor_NilHasherShouldErr(t *testing.T) {
 		nil,
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Equal(t, process.ErrNilHasher, err)
 	assert.Nil(t, txi)
@@ -155,7 +160,8 @@ func TestNewTxInterceptor_NilSignerShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		nil,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Equal(t, process.ErrNilSingleSigner, err)
 	assert.Nil(t, txi)
@@ -178,7 +184,8 @@ func TestNewTxInterceptor_NilKeyGenShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		nil,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Equal(t, process.ErrNilKeyGen, err)
 	assert.Nil(t, txi)
@@ -201,12 +208,38 @@ func TestNewTxInterceptor_NilShardCoordinatorShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		nil)
+		nil,
+		chainID)
 
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
 	assert.Nil(t, txi)
 }
 
+func TestNewTxInterceptor_EmptyChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+	keyGen := &mock.SingleSignKeyGenMock{}
+	txValidator := &mock.TxValidatorStub{}
+	signer := &mock.SignerMock{}
+	oneSharder := mock.NewOneShardCoordinatorMock()
+
+	txi, err := transaction.NewTxInterceptor(
+		&mock.MarshalizerMock{},
+		txPool,
+		txValidator,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		oneSharder,
+		nil)
+
+	assert.Equal(t, process.ErrInvalidChainID, err)
+	assert.Nil(t, txi)
+}
+
 func TestNewTxInterceptor_OkValsShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -225,7 +258,8 @@ func TestNewTxInterceptor_OkValsShouldWork(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, txi)
@@ -251,7 +285,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageNilMesssageShouldErr(t *te
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	err := txi.ProcessReceivedMessage(nil)
 
@@ -276,7 +311,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageMilMessageDataShouldErr(t
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	msg := &mock.P2PMessageMock{}
 
@@ -309,7 +345,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageMarshalizerFailsAtUnmarsha
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	msg := &mock.P2PMessageMock{
 		DataField: make([]byte, 0),
@@ -345,7 +382,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageNoTransactionInMessageShou
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	msg := &mock.P2PMessageMock{
 		DataField: make([]byte, 0),
@@ -376,7 +414,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedShouldErr(t
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -387,6 +426,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedShouldErr(t
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: nil,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
 
@@ -434,7 +475,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedWithTwoTxsS
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	tx1 := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -445,6 +487,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedWithTwoTxsS
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: nil,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	tx1Buff, _ := marshalizer.Marshal(tx1)
 
@@ -457,6 +501,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedWithTwoTxsS
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	tx2Buff, _ := marshalizer.Marshal(tx2)
 
@@ -511,7 +557,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageVerifySigFailsShouldErr(t
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -522,6 +569,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageVerifySigFailsShouldErr(t
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
 
@@ -569,7 +618,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsSameShardShouldWork(
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -580,6 +630,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsSameShardShouldWork(
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
 
@@ -638,7 +690,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsOtherShardsShouldWor
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		multiSharder)
+		multiSharder,
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -649,6 +702,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsOtherShardsShouldWor
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
 
@@ -715,7 +770,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageTxNotValidShouldNotAdd(t *
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		multiSharder)
+		multiSharder,
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -726,6 +782,8 @@ func TestTransactionInterceptor_ProcessReceivedMessageTxNotValidShouldNotAdd(t *
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
 		Signature: sigOk,
+		ChainID:   chainID,
+		Version:   process.MinTransactionVersion,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
 
@@ -2,9 +2,14 @@ package transaction
 
 import (
 	"bytes"
+	"fmt"
 	"math/big"
 
+	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
 	"github.com/ElrondNetwork/elrond-go/hashing"
@@ -23,6 +28,11 @@ type txProcessor struct {
 	scProcessor      process.SmartContractProcessor
 	marshalizer      marshal.Marshalizer
 	shardCoordinator sharding.Coordinator
+	economicsFee     process.FeeHandler
+	txFeeHandler     process.TransactionFeeHandler
+	stakingHandler   process.StakingHandler
+	receiptsHandler  process.ReceiptsHandler
+	scrForwarder     process.IntermediateTransactionHandler
 }
 
 // NewTxProcessor creates a new txProcessor engine
@@ -33,6 +43,11 @@ func NewTxProcessor(
 	marshalizer marshal.Marshalizer,
 	shardCoordinator sharding.Coordinator,
 	scProcessor process.SmartContractProcessor,
+	economicsFee process.FeeHandler,
+	txFeeHandler process.TransactionFeeHandler,
+	stakingHandler process.StakingHandler,
+	receiptsHandler process.ReceiptsHandler,
+	scrForwarder process.IntermediateTransactionHandler,
 ) (*txProcessor, error) {
 
 	if accounts == nil {
@@ -53,6 +68,21 @@ func NewTxProcessor(
 	if scProcessor == nil {
 		return nil, process.ErrNilSmartContractProcessor
 	}
+	if economicsFee == nil {
+		return nil, process.ErrNilEconomicsFeeHandler
+	}
+	if txFeeHandler == nil {
+		return nil, process.ErrNilTxFeeHandler
+	}
+	if stakingHandler == nil {
+		return nil, process.ErrNilStakingHandler
+	}
+	if receiptsHandler == nil {
+		return nil, process.ErrNilReceiptsHandler
+	}
+	if scrForwarder == nil {
+		return nil, process.ErrNilIntermediateTransactionHandler
+	}
 
 	return &txProcessor{
 		accounts:         accounts,
@@ -61,6 +91,41 @@ func NewTxProcessor(
 		marshalizer:      marshalizer,
 		shardCoordinator: shardCoordinator,
 		scProcessor:      scProcessor,
+		economicsFee:     economicsFee,
+		txFeeHandler:     txFeeHandler,
+		stakingHandler:   stakingHandler,
+		receiptsHandler:  receiptsHandler,
+		scrForwarder:     scrForwarder,
+	}, nil
+}
+
+// CopyWithAccounts returns a copy of the transaction processor which reads and modifies only the given accounts
+// adapter. The copy keeps the fees and the receipts of its transactions until they are merged and shares all the
+// other components, which should be concurrent safe
+func (txProc *txProcessor) CopyWithAccounts(accounts state.AccountsAdapter) (process.TransactionProcessorCopy, error) {
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+
+	fees := &feesBuffer{accumulatedFees: big.NewInt(0)}
+	receipts := &receiptsBuffer{}
+
+	txProcCopy := *txProc
+	txProcCopy.accounts = accounts
+	txProcCopy.scProcessor = &groupScProcessor{
+		accounts:         accounts,
+		adrConv:          txProc.adrConv,
+		shardCoordinator: txProc.shardCoordinator,
+	}
+	txProcCopy.txFeeHandler = fees
+	txProcCopy.receiptsHandler = receipts
+
+	return &txProcessorCopy{
+		txProcessor:             &txProcCopy,
+		fees:                    fees,
+		receipts:                receipts,
+		originalTxFeeHandler:    txProc.txFeeHandler,
+		originalReceiptsHandler: txProc.receiptsHandler,
 	}, nil
 }
 
@@ -97,6 +162,10 @@ func (txProc *txProcessor) ProcessTransaction(tx *transaction.Transaction, round
 		return txProc.processSCDeployment(tx, adrSrc, roundIndex)
 	case process.SCInvoking:
 		return txProc.processSCInvoking(tx, adrSrc, adrDst, roundIndex)
+	case process.Staking:
+		return txProc.processStaking(tx, adrSrc, adrDst, roundIndex)
+	case process.RelayedTx:
+		return txProc.processRelayedTx(tx, adrSrc, adrDst, roundIndex)
 	}
 
 	return process.ErrWrongTransaction
@@ -114,6 +183,11 @@ func (txProc *txProcessor) processMoveBalance(
 		return err
 	}
 
+	txFee, err := txProc.processTxFee(tx, acntSrc)
+	if err != nil {
+		return err
+	}
+
 	value := tx.Value
 
 	err = txProc.moveBalances(acntSrc, acntDst, value)
@@ -129,9 +203,284 @@ func (txProc *txProcessor) processMoveBalance(
 		}
 	}
 
+	txProc.txFeeHandler.ProcessTransactionFee(txFee)
+
+	return txProc.addReceipt(tx)
+}
+
+// processStaking moves the value of the transaction to the staking account, as a move balance transaction does, and
+// then executes the staking action, only if the staking account is in the node shard
+func (txProc *txProcessor) processStaking(
+	tx *transaction.Transaction,
+	adrSrc, adrDst state.AddressContainer,
+	roundIndex uint64,
+) error {
+	acntSrc, acntDst, err := txProc.getAccounts(adrSrc, adrDst)
+	if err != nil {
+		return err
+	}
+
+	txFee, err := txProc.processTxFee(tx, acntSrc)
+	if err != nil {
+		return err
+	}
+
+	err = txProc.moveBalances(acntSrc, acntDst, tx.Value)
+	if err != nil {
+		return err
+	}
+
+	// is sender address in node shard
+	if acntSrc != nil {
+		err = txProc.increaseNonce(acntSrc)
+		if err != nil {
+			return err
+		}
+	}
+
+	// is staking account in node shard
+	if acntDst != nil {
+		err = txProc.stakingHandler.ProcessStakingTransaction(tx, acntSrc, acntDst, roundIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	txProc.txFeeHandler.ProcessTransactionFee(txFee)
+
+	return txProc.addReceipt(tx)
+}
+
+// processRelayedTx executes the relayer part of a relayed transaction as a move balance transaction: the relayer pays
+// the fee and sends the value which covers the inner transaction to its sender. The inner transaction is then
+// executed in the shard of its sender, which pays its fee from the received value and gives back to the relayer
+// what the inner transaction did not use
+func (txProc *txProcessor) processRelayedTx(
+	tx *transaction.Transaction,
+	adrSrc, adrDst state.AddressContainer,
+	roundIndex uint64,
+) error {
+	innerTx, err := getInnerTransaction(txProc.marshalizer, tx)
+	if err != nil {
+		return err
+	}
+
+	err = checkRelayedTx(tx, innerTx, txProc.adrConv, txProc.shardCoordinator)
+	if err != nil {
+		return err
+	}
+
+	acntSrc, acntDst, err := txProc.getAccounts(adrSrc, adrDst)
+	if err != nil {
+		return err
+	}
+
+	txFee, err := txProc.processTxFee(tx, acntSrc)
+	if err != nil {
+		return err
+	}
+
+	err = txProc.moveBalances(acntSrc, acntDst, tx.Value)
+	if err != nil {
+		return err
+	}
+
+	// is relayer address in node shard
+	if acntSrc != nil {
+		err = txProc.increaseNonce(acntSrc)
+		if err != nil {
+			return err
+		}
+	}
+
+	txProc.txFeeHandler.ProcessTransactionFee(txFee)
+
+	// is inner transaction sender in node shard
+	if acntDst == nil {
+		return txProc.addReceipt(tx)
+	}
+
+	return txProc.processInnerTx(tx, innerTx, adrSrc, adrDst, roundIndex)
+}
+
+// processInnerTx executes the inner transaction of a relayed transaction. A failed inner transaction is reverted, but
+// for the nonce of its sender, and its error is kept in the receipt of the relayed transaction, as the relayer part might have been already executed
+// in another shard. The relayer gets back the whole relayed value if the inner transaction failed, or the part of it
+// which was neither sent nor consumed as fee by the inner transaction
+func (txProc *txProcessor) processInnerTx(
+	tx *transaction.Transaction,
+	innerTx *transaction.Transaction,
+	adrRelayer, adrUser state.AddressContainer,
+	roundIndex uint64,
+) error {
+	snapshot := txProc.accounts.JournalLen()
+	feesBefore := txProc.txFeeHandler.AccumulatedFees()
+
+	errInner := txProc.ProcessTransaction(innerTx, roundIndex)
+	if errInner == nil {
+		consumedFee := big.NewInt(0).Sub(txProc.txFeeHandler.AccumulatedFees(), feesBefore)
+		unusedValue := big.NewInt(0).Sub(tx.Value, innerTx.Value)
+		unusedValue.Sub(unusedValue, consumedFee)
+
+		err := txProc.refundRelayer(tx, adrRelayer, adrUser, unusedValue)
+		if err != nil {
+			return err
+		}
+
+		return txProc.addReceipt(tx)
+	}
+
+	log.Debug(fmt.Sprintf("inner transaction of relayed transaction failed: %s", errInner.Error()))
+
+	err := txProc.accounts.RevertToSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = txProc.increaseInnerTxNonce(innerTx, adrUser)
+	if err != nil {
+		return err
+	}
+
+	err = txProc.refundRelayer(tx, adrRelayer, adrUser, tx.Value)
+	if err != nil {
+		return err
+	}
+
+	return txProc.addFailedRelayedTxReceipt(tx, errInner)
+}
+
+// increaseInnerTxNonce uses up the nonce of a failed inner transaction, which the revert gave back to its sender, so
+// the signed inner transaction can not be relayed again. A failed inner transaction which did not hold the sender
+// nonce leaves it unchanged
+func (txProc *txProcessor) increaseInnerTxNonce(innerTx *transaction.Transaction, adrUser state.AddressContainer) error {
+	acntUser, err := txProc.getStateAccount(adrUser)
+	if err != nil {
+		return err
+	}
+	if acntUser.Nonce != innerTx.Nonce {
+		return nil
+	}
+
+	return txProc.increaseNonce(acntUser)
+}
+
+// refundRelayer moves the given value from the inner transaction sender back to the relayer. A relayer from another
+// shard gets it through a smart contract result. The accounts are loaded again, as the inner transaction might
+// have changed them
+func (txProc *txProcessor) refundRelayer(
+	tx *transaction.Transaction,
+	adrRelayer, adrUser state.AddressContainer,
+	value *big.Int,
+) error {
+	if value.Sign() <= 0 {
+		return nil
+	}
+
+	acntUser, err := txProc.getStateAccount(adrUser)
+	if err != nil {
+		return err
+	}
+
+	err = acntUser.SetBalanceWithJournal(big.NewInt(0).Sub(acntUser.Balance, value))
+	if err != nil {
+		return err
+	}
+
+	if txProc.shardCoordinator.ComputeId(adrRelayer) != txProc.shardCoordinator.SelfId() {
+		txHash, err := core.CalculateHash(txProc.marshalizer, txProc.hasher, tx)
+		if err != nil {
+			return err
+		}
+
+		scr := &smartContractResult.SmartContractResult{
+			Nonce:   tx.Nonce,
+			Value:   big.NewInt(0).Set(value),
+			RcvAddr: tx.SndAddr,
+			SndAddr: tx.RcvAddr,
+			TxHash:  txHash,
+		}
+
+		return txProc.scrForwarder.AddIntermediateTransactions([]data.TransactionHandler{scr})
+	}
+
+	acntRelayer, err := txProc.getStateAccount(adrRelayer)
+	if err != nil {
+		return err
+	}
+
+	return acntRelayer.SetBalanceWithJournal(big.NewInt(0).Add(acntRelayer.Balance, value))
+}
+
+func (txProc *txProcessor) getStateAccount(adr state.AddressContainer) (*state.Account, error) {
+	acntWrp, err := txProc.accounts.GetAccountWithJournal(adr)
+	if err != nil {
+		return nil, err
+	}
+
+	account, ok := acntWrp.(*state.Account)
+	if !ok {
+		return nil, process.ErrWrongTypeAssertion
+	}
+
+	return account, nil
+}
+
+// addFailedRelayedTxReceipt hands a failed receipt holding the error of the inner transaction under the relayed
+// transaction hash, as only the receipts of the block transactions are saved
+func (txProc *txProcessor) addFailedRelayedTxReceipt(tx *transaction.Transaction, errInner error) error {
+	txHash, err := core.CalculateHash(txProc.marshalizer, txProc.hasher, tx)
+	if err != nil {
+		return err
+	}
+
+	txProc.receiptsHandler.AddReceipt(txHash, &receipt.Receipt{
+		Status:        receipt.Failed,
+		GasUsed:       txProc.economicsFee.ComputeGasLimit(tx),
+		RefundedValue: big.NewInt(0),
+		ReturnCode:    errInner.Error(),
+	})
+
 	return nil
 }
 
+// addReceipt hands the receipt of a transaction which does not call a smart contract, so it consumes only the gas
+// computed from its data and nothing is refunded, as the unused gas is not paid
+func (txProc *txProcessor) addReceipt(tx *transaction.Transaction) error {
+	txHash, err := core.CalculateHash(txProc.marshalizer, txProc.hasher, tx)
+	if err != nil {
+		return err
+	}
+
+	txProc.receiptsHandler.AddReceipt(txHash, &receipt.Receipt{
+		Status:        receipt.Executed,
+		GasUsed:       txProc.economicsFee.ComputeGasLimit(tx),
+		RefundedValue: big.NewInt(0),
+	})
+
+	return nil
+}
+
+// processTxFee takes the fee from the sender, only if the sender address is in the node shard
+func (txProc *txProcessor) processTxFee(tx *transaction.Transaction, acntSnd *state.Account) (*big.Int, error) {
+	if acntSnd == nil {
+		return big.NewInt(0), nil
+	}
+
+	cost := txProc.economicsFee.ComputeFee(tx)
+	if cost.Cmp(big.NewInt(0)) == 0 {
+		return cost, nil
+	}
+
+	operation := big.NewInt(0)
+	err := acntSnd.SetBalanceWithJournal(operation.Sub(acntSnd.Balance, cost))
+	if err != nil {
+		return nil, err
+	}
+
+	return cost, nil
+}
+
 func (txProc *txProcessor) processSCDeployment(
 	tx *transaction.Transaction,
 	adrSrc state.AddressContainer,
@@ -272,6 +621,11 @@ func (txProc *txProcessor) checkTxValues(tx *transaction.Transaction, acntSnd st
 		return process.ErrLowerNonceInTransaction
 	}
 
+	err := txProc.economicsFee.CheckValidityTxValues(tx)
+	if err != nil {
+		return err
+	}
+
 	cost := big.NewInt(0)
 	cost = cost.Mul(big.NewInt(0).SetUint64(tx.GasPrice), big.NewInt(0).SetUint64(tx.GasLimit))
 	cost = cost.Add(cost, tx.Value)
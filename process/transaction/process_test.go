@@ -5,14 +5,23 @@ import (
 	"crypto/rand"
 	"errors"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/block"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/smartContractResult"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/dataRetriever"
 	"github.com/ElrondNetwork/elrond-go/process"
 	"github.com/ElrondNetwork/elrond-go/process/mock"
+	"github.com/ElrondNetwork/elrond-go/process/receipts"
 	"github.com/ElrondNetwork/elrond-go/process/smartContract"
 	txproc "github.com/ElrondNetwork/elrond-go/process/transaction"
+	"github.com/ElrondNetwork/elrond-go/sharding"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,6 +60,11 @@ func createTxProcessor() txproc.TxProcessor {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	return txProc
@@ -68,6 +82,11 @@ func TestNewTxProcessor_NilAccountsShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
@@ -84,6 +103,11 @@ func TestNewTxProcessor_NilHasherShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	assert.Equal(t, process.ErrNilHasher, err)
@@ -100,6 +124,11 @@ func TestNewTxProcessor_NilAddressConverterMockShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	assert.Equal(t, process.ErrNilAddressConverter, err)
@@ -116,6 +145,11 @@ func TestNewTxProcessor_NilMarshalizerMockShouldErr(t *testing.T) {
 		nil,
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	assert.Equal(t, process.ErrNilMarshalizer, err)
@@ -132,6 +166,11 @@ func TestNewTxProcessor_NilShardCoordinatorMockShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		nil,
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
@@ -148,12 +187,122 @@ func TestNewTxProcessor_NilSCProcessorShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		nil,
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	assert.Equal(t, process.ErrNilSmartContractProcessor, err)
 	assert.Nil(t, txProc)
 }
 
+func TestNewTxProcessor_NilEconomicsFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, err := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		nil,
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Equal(t, process.ErrNilEconomicsFeeHandler, err)
+	assert.Nil(t, txProc)
+}
+
+func TestNewTxProcessor_NilTxFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, err := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		nil,
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Equal(t, process.ErrNilTxFeeHandler, err)
+	assert.Nil(t, txProc)
+}
+
+func TestNewTxProcessor_NilStakingHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, err := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		nil,
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Equal(t, process.ErrNilStakingHandler, err)
+	assert.Nil(t, txProc)
+}
+
+func TestNewTxProcessor_NilReceiptsHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, err := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		nil,
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	assert.Equal(t, process.ErrNilReceiptsHandler, err)
+	assert.Nil(t, txProc)
+}
+
+func TestNewTxProcessor_NilScrForwarderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, err := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		nil,
+	)
+
+	assert.Equal(t, process.ErrNilIntermediateTransactionHandler, err)
+	assert.Nil(t, txProc)
+}
+
 func TestNewTxProcessor_OkValsShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -164,12 +313,150 @@ func TestNewTxProcessor_OkValsShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, txProc)
 }
 
+//------- CopyWithAccounts
+
+func TestTxProcessor_CopyWithAccountsNilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, _ := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	txProcCopy, err := txProc.CopyWithAccounts(nil)
+
+	assert.Nil(t, txProcCopy)
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+}
+
+func TestTxProcessor_CopyWithAccountsShouldUseTheGivenAccounts(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	originalAccountsCalled := false
+	originalAccounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			originalAccountsCalled = true
+			return nil, nil
+		},
+	}
+	txProc, _ := txproc.NewTxProcessor(
+		originalAccounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+	copiedAccounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, errExpected
+		},
+	}
+
+	txProcCopy, err := txProc.CopyWithAccounts(copiedAccounts)
+	assert.Nil(t, err)
+
+	err = txProcCopy.ProcessTransaction(&transaction.Transaction{SndAddr: []byte("SRC"), RcvAddr: []byte("DST")}, 4)
+
+	assert.Equal(t, errExpected, err)
+	assert.False(t, originalAccountsCalled)
+}
+
+func TestTxProcessor_CopyWithAccountsShouldKeepFeesAndReceiptsUntilMerged(t *testing.T) {
+	t.Parallel()
+
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.SndAddr = generateRandomByteSlice(32)
+	tx.RcvAddr = generateRandomByteSlice(32)
+	tx.Value = big.NewInt(10)
+
+	acntSrc, _ := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	acntSrc.Balance = big.NewInt(100)
+	acntDst, _ := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+
+	txFee := big.NewInt(5)
+	accumulatedFee := big.NewInt(0)
+	var receiptTxHash []byte
+	txProc, _ := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{
+			ComputeTransactionTypeCalled: func(tx *transaction.Transaction) (process.TransactionType, error) {
+				assert.Fail(t, "the copy should not compute the transaction type on the original accounts")
+				return process.MoveBalance, nil
+			},
+		},
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return txFee
+			},
+		},
+		&mock.TxFeeHandlerStub{
+			ProcessTransactionFeeCalled: func(cost *big.Int) {
+				accumulatedFee.Add(accumulatedFee, cost)
+			},
+		},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{
+			AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+				receiptTxHash = txHash
+			},
+		},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	txProcCopy, _ := txProc.CopyWithAccounts(createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst))
+	err := txProcCopy.ProcessTransaction(&tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(85), acntSrc.Balance)
+	assert.Equal(t, big.NewInt(10), acntDst.Balance)
+	assert.Equal(t, big.NewInt(0), accumulatedFee)
+	assert.Nil(t, receiptTxHash)
+
+	txProcCopy.MergeFeesAndReceipts()
+
+	txHash, _ := core.CalculateHash(&mock.MarshalizerMock{}, mock.HasherMock{}, &tx)
+	assert.Equal(t, txFee, accumulatedFee)
+	assert.Equal(t, txHash, receiptTxHash)
+}
+
 //------- getAddresses
 
 func TestTxProcessor_GetAddressErrAddressConvShouldErr(t *testing.T) {
@@ -184,6 +471,11 @@ func TestTxProcessor_GetAddressErrAddressConvShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	addressConv.Fail = true
@@ -223,6 +515,11 @@ func TestTxProcessor_GetAccountsShouldErrNilAddressContainer(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	adr1 := mock.NewAddressMock([]byte{65})
@@ -247,6 +544,11 @@ func TestTxProcessor_GetAccountsMalfunctionAccountsShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	adr1 := mock.NewAddressMock([]byte{65})
@@ -288,6 +590,11 @@ func TestTxProcessor_GetAccountsOkValsSrcShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		shardCoordinator,
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	shardCoordinator.ComputeIdCalled = func(container state.AddressContainer) uint32 {
@@ -338,6 +645,11 @@ func TestTxProcessor_GetAccountsOkValsDsthouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		shardCoordinator,
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	shardCoordinator.ComputeIdCalled = func(container state.AddressContainer) uint32 {
@@ -373,6 +685,11 @@ func TestTxProcessor_GetAccountsOkValsShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	a1, a2, err := execTx.GetAccounts(adr1, adr2)
@@ -399,6 +716,11 @@ func TestTxProcessor_GetSameAccountShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	a1, a2, err := execTx.GetAccounts(adr1, adr1)
@@ -469,6 +791,37 @@ func TestTxProcessor_CheckTxValuesOkValsShouldErr(t *testing.T) {
 }
 
 //------- moveBalances
+func TestTxProcessor_CheckTxValuesInvalidGasValuesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adr1 := mock.NewAddressMock([]byte{65})
+	acnt1, err := state.NewAccount(adr1, &mock.AccountTrackerStub{})
+	assert.Nil(t, err)
+
+	execTx, _ := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{
+			CheckValidityTxValuesCalled: func(tx *transaction.Transaction) error {
+				return process.ErrInsufficientGasPriceInTx
+			},
+		},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	acnt1.Balance = big.NewInt(67)
+
+	err = execTx.CheckTxValues(&transaction.Transaction{Value: big.NewInt(1)}, acnt1)
+	assert.Equal(t, process.ErrInsufficientGasPriceInTx, err)
+}
+
 func TestTxProcessor_MoveBalancesShouldNotFailWhenAcntSrcIsNotInNodeShard(t *testing.T) {
 	t.Parallel()
 
@@ -639,6 +992,11 @@ func TestTxProcessor_ProcessTransactionErrAddressConvShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	addressConv.Fail = true
@@ -659,6 +1017,11 @@ func TestTxProcessor_ProcessTransactionMalfunctionAccountsShouldErr(t *testing.T
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	tx := transaction.Transaction{}
@@ -695,6 +1058,11 @@ func TestTxProcessor_ProcessCheckNotPassShouldErr(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -746,6 +1114,11 @@ func TestTxProcessor_ProcessCheckShouldPassWhenAdrSrcIsNotInNodeShard(t *testing
 		&mock.MarshalizerMock{},
 		shardCoordinator,
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -789,6 +1162,11 @@ func TestTxProcessor_ProcessMoveBalancesShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -797,6 +1175,122 @@ func TestTxProcessor_ProcessMoveBalancesShouldWork(t *testing.T) {
 	assert.Equal(t, 3, saveAccountCalled)
 }
 
+func TestTxProcessor_ProcessMoveBalancesShouldTakeAndAccumulateFee(t *testing.T) {
+	t.Parallel()
+
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(10)
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntSrc.Balance = big.NewInt(100)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+
+	txFee := big.NewInt(5)
+	accumulatedFee := big.NewInt(0)
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return txFee
+			},
+		},
+		&mock.TxFeeHandlerStub{
+			ProcessTransactionFeeCalled: func(cost *big.Int) {
+				accumulatedFee.Add(accumulatedFee, cost)
+			},
+		},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(85), acntSrc.Balance)
+	assert.Equal(t, big.NewInt(10), acntDst.Balance)
+	assert.Equal(t, txFee, accumulatedFee)
+}
+
+func TestTxProcessor_ProcessMoveBalancesShouldAddReceipt(t *testing.T) {
+	t.Parallel()
+
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(10)
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntSrc.Balance = big.NewInt(100)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+
+	var receiptTxHash []byte
+	var addedReceipt *receipt.Receipt
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{
+			ComputeGasLimitCalled: func(tx *transaction.Transaction) uint64 {
+				return 7
+			},
+		},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{
+			AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+				receiptTxHash = txHash
+				addedReceipt = rcpt
+			},
+		},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Nil(t, err)
+
+	txHash, _ := core.CalculateHash(&mock.MarshalizerMock{}, mock.HasherMock{}, &tx)
+	assert.Equal(t, txHash, receiptTxHash)
+	assert.Equal(t, receipt.Executed, addedReceipt.Status)
+	assert.Equal(t, uint64(7), addedReceipt.GasUsed)
+	assert.Equal(t, big.NewInt(0), addedReceipt.RefundedValue)
+}
+
 func TestTxProcessor_ProcessMoveBalancesShouldPassWhenAdrSrcIsNotInNodeShard(t *testing.T) {
 	t.Parallel()
 
@@ -842,6 +1336,11 @@ func TestTxProcessor_ProcessMoveBalancesShouldPassWhenAdrSrcIsNotInNodeShard(t *
 		&mock.MarshalizerMock{},
 		shardCoordinator,
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -895,6 +1394,11 @@ func TestTxProcessor_ProcessIncreaseNonceShouldPassWhenAdrSrcIsNotInNodeShard(t
 		&mock.MarshalizerMock{},
 		shardCoordinator,
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -942,6 +1446,11 @@ func TestTxProcessor_ProcessOkValsShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.SCProcessorMock{},
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -997,6 +1506,9 @@ func TestTxProcessor_ProcessTransactionScTxShouldWork(t *testing.T) {
 		addrConverter,
 		mock.NewOneShardCoordinatorMock(),
 		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
 	)
 
 	scProcessorMock := &mock.SCProcessorMock{}
@@ -1014,6 +1526,11 @@ func TestTxProcessor_ProcessTransactionScTxShouldWork(t *testing.T) {
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		scProcessorMock,
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -1064,7 +1581,10 @@ func TestTxProcessor_ProcessTransactionScTxShouldReturnErrWhenExecutionFails(t *
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConverter,
 		mock.NewOneShardCoordinatorMock(),
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	scProcessorMock := &mock.SCProcessorMock{}
 
 	scProcessorMock.ComputeTransactionTypeCalled = scProcessor.ComputeTransactionType
@@ -1081,6 +1601,11 @@ func TestTxProcessor_ProcessTransactionScTxShouldReturnErrWhenExecutionFails(t *
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
 		scProcessorMock,
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -1141,7 +1666,10 @@ func TestTxProcessor_ProcessTransactionScTxShouldNotBeCalledWhenAdrDstIsNotInNod
 		&mock.TemporaryAccountsHandlerMock{},
 		addrConverter,
 		shardCoordinator,
-		&mock.IntermediateTransactionHandlerMock{})
+		&mock.IntermediateTransactionHandlerMock{},
+		&mock.TxFeeHandlerStub{},
+		&mock.SmartContractLogsHandlerStub{},
+		&mock.ReceiptsHandlerStub{})
 	scProcessorMock := &mock.SCProcessorMock{}
 	scProcessorMock.ComputeTransactionTypeCalled = scProcessor.ComputeTransactionType
 	wasCalled := false
@@ -1157,6 +1685,11 @@ func TestTxProcessor_ProcessTransactionScTxShouldNotBeCalledWhenAdrDstIsNotInNod
 		&mock.MarshalizerMock{},
 		shardCoordinator,
 		scProcessorMock,
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		&mock.StakingHandlerStub{},
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -1165,3 +1698,532 @@ func TestTxProcessor_ProcessTransactionScTxShouldNotBeCalledWhenAdrDstIsNotInNod
 	assert.Equal(t, 2, journalizeCalled)
 	assert.Equal(t, 2, saveAccountCalled)
 }
+
+func createStakingTxProcessor(
+	tx *transaction.Transaction,
+	acntSrc, acntDst *state.Account,
+	stakingHandler process.StakingHandler,
+) process.TransactionProcessor {
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+	scProcessorMock := &mock.SCProcessorMock{
+		ComputeTransactionTypeCalled: func(tx *transaction.Transaction) (process.TransactionType, error) {
+			return process.Staking, nil
+		},
+	}
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		scProcessorMock,
+		&mock.FeeHandlerStub{},
+		&mock.TxFeeHandlerStub{},
+		stakingHandler,
+		&mock.ReceiptsHandlerStub{},
+		&mock.IntermediateTransactionHandlerMock{},
+	)
+
+	return execTx
+}
+
+func TestTxProcessor_ProcessTransactionStakingShouldMoveValueAndCallStakingHandler(t *testing.T) {
+	t.Parallel()
+
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = process.StakingAddress
+	tx.Value = big.NewInt(45)
+	tx.Data = "stake@aa"
+
+	acntSrc, _ := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	acntSrc.Balance = big.NewInt(45)
+	acntDst, _ := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+
+	wasCalled := false
+	stakingHandler := &mock.StakingHandlerStub{
+		ProcessStakingTransactionCalled: func(stakingTx *transaction.Transaction, src, staking *state.Account, round uint64) error {
+			wasCalled = true
+			assert.Equal(t, &tx, stakingTx)
+			assert.Equal(t, acntSrc, src)
+			assert.Equal(t, acntDst, staking)
+			assert.Equal(t, uint64(4), round)
+			return nil
+		},
+	}
+	execTx := createStakingTxProcessor(&tx, acntSrc, acntDst, stakingHandler)
+
+	err := execTx.ProcessTransaction(&tx, 4)
+	assert.Nil(t, err)
+	assert.True(t, wasCalled)
+	assert.Equal(t, uint64(0), acntSrc.Balance.Uint64())
+	assert.Equal(t, uint64(1), acntSrc.Nonce)
+	assert.Equal(t, big.NewInt(45), acntDst.Balance)
+}
+
+func TestTxProcessor_ProcessTransactionStakingShouldReturnErrWhenStakingFails(t *testing.T) {
+	t.Parallel()
+
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = process.StakingAddress
+	tx.Value = big.NewInt(45)
+
+	acntSrc, _ := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	acntSrc.Balance = big.NewInt(45)
+	acntDst, _ := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+
+	stakingHandler := &mock.StakingHandlerStub{
+		ProcessStakingTransactionCalled: func(stakingTx *transaction.Transaction, src, staking *state.Account, round uint64) error {
+			return process.ErrInvalidStakingData
+		},
+	}
+	execTx := createStakingTxProcessor(&tx, acntSrc, acntDst, stakingHandler)
+
+	err := execTx.ProcessTransaction(&tx, 4)
+	assert.Equal(t, process.ErrInvalidStakingData, err)
+}
+
+//------- relayed transactions
+
+type relayedTxTestData struct {
+	tx        *transaction.Transaction
+	innerTx   *transaction.Transaction
+	relayer   *state.Account
+	user      *state.Account
+	receiver  *state.Account
+	receipts  map[string]*receipt.Receipt
+	snapshots []int
+	fees      *big.Int
+	scrs      []*smartContractResult.SmartContractResult
+	innerFee  *big.Int
+}
+
+func createRelayedTxTestData(innerNonce uint64) *relayedTxTestData {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	innerTx := &transaction.Transaction{
+		Nonce:     innerNonce,
+		Value:     big.NewInt(20),
+		SndAddr:   []byte("USER"),
+		RcvAddr:   []byte("RECEIVER"),
+		GasPrice:  2,
+		GasLimit:  5,
+		Signature: []byte("user signature"),
+	}
+	relayedTxData, _ := txproc.CreateRelayedTxData(&mock.MarshalizerMock{}, innerTx)
+
+	td := &relayedTxTestData{
+		tx: &transaction.Transaction{
+			Nonce:    3,
+			Value:    txproc.ComputeRelayedTxValue(innerTx),
+			SndAddr:  []byte("RELAYER"),
+			RcvAddr:  innerTx.SndAddr,
+			GasPrice: 2,
+			GasLimit: 100,
+			Data:     relayedTxData,
+		},
+		innerTx:   innerTx,
+		receipts:  make(map[string]*receipt.Receipt),
+		snapshots: make([]int, 0),
+		fees:      big.NewInt(0),
+		scrs:      make([]*smartContractResult.SmartContractResult, 0),
+		innerFee:  big.NewInt(0).SetUint64(innerTx.GasPrice * innerTx.GasLimit),
+	}
+
+	td.relayer, _ = state.NewAccount(mock.NewAddressMock(td.tx.SndAddr), tracker)
+	td.relayer.Nonce = 3
+	td.relayer.Balance = big.NewInt(1000)
+	td.user, _ = state.NewAccount(mock.NewAddressMock(innerTx.SndAddr), tracker)
+	td.receiver, _ = state.NewAccount(mock.NewAddressMock(innerTx.RcvAddr), tracker)
+
+	return td
+}
+
+func createRelayedTxProcessor(td *relayedTxTestData, shardCoordinator sharding.Coordinator) process.TransactionProcessor {
+	receiptsHandler := &mock.ReceiptsHandlerStub{
+		AddReceiptCalled: func(txHash []byte, rcpt *receipt.Receipt) {
+			td.receipts[string(txHash)] = rcpt
+		},
+	}
+
+	return createRelayedTxProcessorWithReceiptsHandler(td, shardCoordinator, receiptsHandler)
+}
+
+func createRelayedTxProcessorWithReceiptsHandler(
+	td *relayedTxTestData,
+	shardCoordinator sharding.Coordinator,
+	receiptsHandler process.ReceiptsHandler,
+) process.TransactionProcessor {
+	journalLen := 7
+	accounts := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			for _, account := range []*state.Account{td.relayer, td.user, td.receiver} {
+				if bytes.Equal(addressContainer.Bytes(), account.AddressContainer().Bytes()) {
+					return account, nil
+				}
+			}
+			return nil, errors.New("unknown account")
+		},
+		JournalLenCalled: func() int {
+			return journalLen
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			td.snapshots = append(td.snapshots, snapshot)
+			return nil
+		},
+	}
+	scProcessorMock := &mock.SCProcessorMock{
+		ComputeTransactionTypeCalled: func(tx *transaction.Transaction) (process.TransactionType, error) {
+			if strings.HasPrefix(tx.Data, process.RelayedTxDataPrefix) {
+				return process.RelayedTx, nil
+			}
+			return process.MoveBalance, nil
+		},
+	}
+	feeHandler := &mock.FeeHandlerStub{
+		ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+			if bytes.Equal(tx.SndAddr, td.innerTx.SndAddr) {
+				return td.innerFee
+			}
+			return big.NewInt(0).SetUint64(tx.GasPrice * tx.GasLimit)
+		},
+	}
+	txFeeHandler := &mock.TxFeeHandlerStub{
+		ProcessTransactionFeeCalled: func(cost *big.Int) {
+			td.fees.Add(td.fees, cost)
+		},
+		AccumulatedFeesCalled: func() *big.Int {
+			return big.NewInt(0).Set(td.fees)
+		},
+	}
+	scrForwarder := &mock.IntermediateTransactionHandlerMock{
+		AddIntermediateTransactionsCalled: func(txs []data.TransactionHandler) error {
+			for _, tx := range txs {
+				td.scrs = append(td.scrs, tx.(*smartContractResult.SmartContractResult))
+			}
+			return nil
+		},
+	}
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		shardCoordinator,
+		scProcessorMock,
+		feeHandler,
+		txFeeHandler,
+		&mock.StakingHandlerStub{},
+		receiptsHandler,
+		scrForwarder,
+	)
+
+	return execTx
+}
+
+// createRelayedTxShardCoordinator puts the relayer in shard 0 and the user and the receiver in shard 1
+func createRelayedTxShardCoordinator(td *relayedTxTestData, selfId uint32) sharding.Coordinator {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	shardCoordinator.CurrentShard = selfId
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		if bytes.Equal(address.Bytes(), td.tx.SndAddr) {
+			return 0
+		}
+		return 1
+	}
+
+	return shardCoordinator
+}
+
+func TestTxProcessor_ProcessRelayedTxIntraShardShouldExecuteBothTransactions(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+
+	// the relayer paid the relayed transaction fee (200) and the value with the inner transaction gas (30)
+	assert.Equal(t, big.NewInt(770), td.relayer.Balance)
+	assert.Equal(t, uint64(4), td.relayer.Nonce)
+	// the user paid the inner transaction fee (10) and value (20) from the received value
+	assert.Equal(t, uint64(0), td.user.Balance.Uint64())
+	assert.Equal(t, uint64(1), td.user.Nonce)
+	assert.Equal(t, big.NewInt(20), td.receiver.Balance)
+	assert.Equal(t, 2, len(td.receipts))
+	assert.Equal(t, 0, len(td.snapshots))
+	assert.Equal(t, 0, len(td.scrs))
+}
+
+func TestTxProcessor_ProcessRelayedTxIntraShardShouldRefundTheUnusedGasToTheRelayer(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	td.innerFee = big.NewInt(4)
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+
+	// the relayer got back the inner transaction gas which was not consumed (6)
+	assert.Equal(t, big.NewInt(776), td.relayer.Balance)
+	assert.Equal(t, uint64(0), td.user.Balance.Uint64())
+	assert.Equal(t, big.NewInt(20), td.receiver.Balance)
+	assert.Equal(t, big.NewInt(204), td.fees)
+	assert.Equal(t, 0, len(td.scrs))
+}
+
+func TestTxProcessor_ProcessRelayedTxInRelayerShardShouldOnlyChargeTheRelayer(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	execTx := createRelayedTxProcessor(td, createRelayedTxShardCoordinator(td, 0))
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(770), td.relayer.Balance)
+	assert.Equal(t, uint64(4), td.relayer.Nonce)
+	assert.Equal(t, uint64(0), td.user.Balance.Uint64())
+	assert.Equal(t, uint64(0), td.user.Nonce)
+	assert.Equal(t, uint64(0), td.receiver.Balance.Uint64())
+	assert.Equal(t, 1, len(td.receipts))
+}
+
+func TestTxProcessor_ProcessRelayedTxInUserShardShouldExecuteTheInnerTransaction(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	execTx := createRelayedTxProcessor(td, createRelayedTxShardCoordinator(td, 1))
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), td.relayer.Balance)
+	assert.Equal(t, uint64(3), td.relayer.Nonce)
+	assert.Equal(t, uint64(0), td.user.Balance.Uint64())
+	assert.Equal(t, uint64(1), td.user.Nonce)
+	assert.Equal(t, big.NewInt(20), td.receiver.Balance)
+	assert.Equal(t, 2, len(td.receipts))
+	assert.Equal(t, 0, len(td.scrs))
+}
+
+func TestTxProcessor_ProcessRelayedTxInUserShardShouldSendTheUnusedGasToTheRelayerShard(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	td.innerFee = big.NewInt(4)
+	execTx := createRelayedTxProcessor(td, createRelayedTxShardCoordinator(td, 1))
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), td.relayer.Balance)
+	assert.Equal(t, uint64(0), td.user.Balance.Uint64())
+	assert.Equal(t, big.NewInt(20), td.receiver.Balance)
+
+	txHash, _ := core.CalculateHash(&mock.MarshalizerMock{}, mock.HasherMock{}, td.tx)
+	assert.Equal(t, 1, len(td.scrs))
+	assert.Equal(t, big.NewInt(6), td.scrs[0].Value)
+	assert.Equal(t, td.tx.SndAddr, td.scrs[0].RcvAddr)
+	assert.Equal(t, td.tx.RcvAddr, td.scrs[0].SndAddr)
+	assert.Equal(t, txHash, td.scrs[0].TxHash)
+}
+
+func TestTxProcessor_ProcessRelayedTxFailedInnerTransactionShouldRevertItAndAddFailedReceipt(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(5)
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{7}, td.snapshots)
+	assert.Equal(t, uint64(4), td.relayer.Nonce)
+	// the relayer paid only the relayed transaction fee (200) as the relayed value went back to it
+	assert.Equal(t, big.NewInt(800), td.relayer.Balance)
+	assert.Equal(t, uint64(0), td.user.Balance.Uint64())
+	assert.Equal(t, uint64(0), td.user.Nonce)
+	assert.Equal(t, uint64(0), td.receiver.Balance.Uint64())
+	assert.Equal(t, 0, len(td.scrs))
+
+	assert.Equal(t, 1, len(td.receipts))
+	txHash, _ := core.CalculateHash(&mock.MarshalizerMock{}, mock.HasherMock{}, td.tx)
+	rcpt := td.receipts[string(txHash)]
+	assert.NotNil(t, rcpt)
+	assert.Equal(t, receipt.Failed, rcpt.Status)
+	assert.Equal(t, process.ErrHigherNonceInTransaction.Error(), rcpt.ReturnCode)
+}
+
+func TestTxProcessor_ProcessRelayedTxFailedInnerTransactionShouldIncreaseTheUserNonce(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	// the inner transaction passes the nonce check and then fails on loading its receiver
+	td.receiver, _ = state.NewAccount(mock.NewAddressMock([]byte("OTHER")), &mock.AccountTrackerStub{})
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{7}, td.snapshots)
+	assert.Equal(t, big.NewInt(800), td.relayer.Balance)
+	// the inner transaction can not be relayed again
+	assert.Equal(t, uint64(1), td.user.Nonce)
+
+	txHash, _ := core.CalculateHash(&mock.MarshalizerMock{}, mock.HasherMock{}, td.tx)
+	rcpt := td.receipts[string(txHash)]
+	assert.NotNil(t, rcpt)
+	assert.Equal(t, receipt.Failed, rcpt.Status)
+}
+
+func TestTxProcessor_ProcessRelayedTxFailedInnerTransactionInUserShardShouldSendTheValueToTheRelayerShard(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(5)
+	execTx := createRelayedTxProcessor(td, createRelayedTxShardCoordinator(td, 1))
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), td.relayer.Balance)
+	assert.Equal(t, uint64(0), td.user.Balance.Uint64())
+	assert.Equal(t, uint64(0), td.receiver.Balance.Uint64())
+
+	assert.Equal(t, 1, len(td.scrs))
+	assert.Equal(t, td.tx.Value, td.scrs[0].Value)
+	assert.Equal(t, td.tx.SndAddr, td.scrs[0].RcvAddr)
+	assert.Equal(t, td.tx.RcvAddr, td.scrs[0].SndAddr)
+}
+
+func TestTxProcessor_ProcessRelayedTxFailedInnerTransactionShouldSaveTheFailedReceiptWithTheBlock(t *testing.T) {
+	t.Parallel()
+
+	saved := make(map[string][]byte)
+	store := &mock.ChainStorerMock{
+		PutCalled: func(unitType dataRetriever.UnitType, key []byte, value []byte) error {
+			if unitType == dataRetriever.ReceiptsUnit {
+				saved[string(key)] = value
+			}
+			return nil
+		},
+	}
+	marshalizer := &mock.MarshalizerMock{}
+	receiptsCollector, _ := receipts.NewReceiptsCollector(store, marshalizer)
+
+	td := createRelayedTxTestData(5)
+	execTx := createRelayedTxProcessorWithReceiptsHandler(td, createRelayedTxShardCoordinator(td, 1), receiptsCollector)
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Nil(t, err)
+
+	txHash, _ := core.CalculateHash(marshalizer, mock.HasherMock{}, td.tx)
+	receiptsCollector.SaveReceipts([]byte("header hash"), &block.Header{Nonce: 4}, [][]byte{txHash})
+
+	assert.Equal(t, 1, len(saved))
+	rcpt := &receipt.Receipt{}
+	err = marshalizer.Unmarshal(rcpt, saved[string(txHash)])
+	assert.Nil(t, err)
+	assert.Equal(t, txHash, rcpt.TxHash)
+	assert.Equal(t, receipt.Failed, rcpt.Status)
+	assert.Equal(t, process.ErrHigherNonceInTransaction.Error(), rcpt.ReturnCode)
+	assert.Equal(t, []byte("header hash"), rcpt.BlockHash)
+}
+
+func TestTxProcessor_ProcessRelayedTxValueMismatchShouldErr(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	td.tx.Value = big.NewInt(0).Add(td.tx.Value, big.NewInt(1))
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Equal(t, process.ErrRelayedTxValueMismatch, err)
+	assert.Equal(t, uint64(3), td.relayer.Nonce)
+}
+
+func TestTxProcessor_ProcessRelayedTxReceiverMismatchShouldErr(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	td.tx.RcvAddr = td.innerTx.RcvAddr
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Equal(t, process.ErrRelayedTxReceiverMismatch, err)
+}
+
+func TestTxProcessor_ProcessRelayedTxInvalidDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	td.tx.Data = process.RelayedTxDataPrefix + "not hex"
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Equal(t, process.ErrInvalidRelayedTxData, err)
+}
+
+func TestTxProcessor_ProcessRelayedTxNestedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	td.innerTx.Data = process.RelayedTxDataPrefix
+	td.tx.Data, _ = txproc.CreateRelayedTxData(&mock.MarshalizerMock{}, td.innerTx)
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Equal(t, process.ErrNestedRelayedTx, err)
+}
+
+func TestTxProcessor_ProcessRelayedTxInnerOtherChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	td.innerTx.ChainID = []byte("other chain ID")
+	td.tx.Data, _ = txproc.CreateRelayedTxData(&mock.MarshalizerMock{}, td.innerTx)
+	execTx := createRelayedTxProcessor(td, mock.NewOneShardCoordinatorMock())
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Equal(t, process.ErrInvalidChainID, err)
+}
+
+func TestTxProcessor_ProcessRelayedTxCrossShardInnerTransactionShouldErr(t *testing.T) {
+	t.Parallel()
+
+	td := createRelayedTxTestData(0)
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		if bytes.Equal(address.Bytes(), td.innerTx.RcvAddr) {
+			return 1
+		}
+		return 0
+	}
+	execTx := createRelayedTxProcessor(td, shardCoordinator)
+
+	err := execTx.ProcessTransaction(td.tx, 4)
+	assert.Equal(t, process.ErrRelayedTxInnerCrossShard, err)
+}
@@ -0,0 +1,139 @@
+package transaction
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/data"
+	"github.com/ElrondNetwork/elrond-go/data/receipt"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// txProcessorCopy is a transaction processor executing the transactions of a group against the accounts of the
+// group. The fees and the receipts of its transactions are kept aside until the group is merged, so nothing is left
+// behind by the groups which are dropped
+type txProcessorCopy struct {
+	*txProcessor
+	fees     *feesBuffer
+	receipts *receiptsBuffer
+
+	originalTxFeeHandler    process.TransactionFeeHandler
+	originalReceiptsHandler process.ReceiptsHandler
+}
+
+// MergeFeesAndReceipts adds the fees and the receipts of the executed transactions to the ones of the block
+func (txpc *txProcessorCopy) MergeFeesAndReceipts() {
+	txpc.originalTxFeeHandler.ProcessTransactionFee(txpc.fees.AccumulatedFees())
+	for i, txHash := range txpc.receipts.txHashes {
+		txpc.originalReceiptsHandler.AddReceipt(txHash, txpc.receipts.receipts[i])
+	}
+}
+
+// groupScProcessor computes the type of the transactions against the accounts of the group. The groups only hold
+// move balance transactions, so the smart contracts are never executed against them
+type groupScProcessor struct {
+	accounts         state.AccountsAdapter
+	adrConv          state.AddressConverter
+	shardCoordinator sharding.Coordinator
+}
+
+// ComputeTransactionType computes the transaction type reading the accounts of the group
+func (gsp *groupScProcessor) ComputeTransactionType(tx *transaction.Transaction) (process.TransactionType, error) {
+	if gsp.adrConv.AddressLen() != len(tx.RcvAddr) {
+		return 0, process.ErrWrongTransaction
+	}
+	if bytes.Equal(tx.RcvAddr, process.StakingAddress) {
+		return process.Staking, nil
+	}
+	if strings.HasPrefix(tx.Data, process.RelayedTxDataPrefix) {
+		return process.RelayedTx, nil
+	}
+
+	isEmptyAddress := bytes.Equal(tx.RcvAddr, make([]byte, gsp.adrConv.AddressLen()))
+	if isEmptyAddress {
+		if len(tx.Data) > 0 {
+			return process.SCDeployment, nil
+		}
+		return 0, process.ErrWrongTransaction
+	}
+
+	adrDst, err := gsp.adrConv.CreateAddressFromPublicKeyBytes(tx.RcvAddr)
+	if err != nil {
+		return 0, err
+	}
+	if gsp.shardCoordinator.ComputeId(adrDst) != gsp.shardCoordinator.SelfId() {
+		return process.MoveBalance, nil
+	}
+
+	acntDst, err := gsp.accounts.GetAccountWithJournal(adrDst)
+	if err != nil {
+		return 0, err
+	}
+	if len(acntDst.GetCode()) > 0 {
+		return process.SCInvoking, nil
+	}
+
+	return process.MoveBalance, nil
+}
+
+// ExecuteSmartContractTransaction is not supported by the groups
+func (gsp *groupScProcessor) ExecuteSmartContractTransaction(_ *transaction.Transaction, _, _ state.AccountHandler, _ uint64) error {
+	return process.ErrOperationNotSupported
+}
+
+// DeploySmartContract is not supported by the groups
+func (gsp *groupScProcessor) DeploySmartContract(_ *transaction.Transaction, _ state.AccountHandler, _ uint64) error {
+	return process.ErrOperationNotSupported
+}
+
+// feesBuffer sums up the fees of the transactions of a group. A group is executed by a single go routine
+type feesBuffer struct {
+	accumulatedFees *big.Int
+}
+
+// CreateBlockStarted does nothing, as a group is executed inside a block
+func (fb *feesBuffer) CreateBlockStarted() {
+}
+
+// ProcessTransactionFee adds the fee paid by a transaction of the group
+func (fb *feesBuffer) ProcessTransactionFee(cost *big.Int) {
+	if cost == nil || cost.Sign() <= 0 {
+		return
+	}
+
+	fb.accumulatedFees = big.NewInt(0).Add(fb.accumulatedFees, cost)
+}
+
+// AccumulatedFees returns the fees paid by the transactions of the group
+func (fb *feesBuffer) AccumulatedFees() *big.Int {
+	return big.NewInt(0).Set(fb.accumulatedFees)
+}
+
+// receiptsBuffer keeps the receipts of the transactions of a group, in their execution order
+type receiptsBuffer struct {
+	txHashes [][]byte
+	receipts []*receipt.Receipt
+}
+
+// CreateBlockStarted does nothing, as a group is executed inside a block
+func (rb *receiptsBuffer) CreateBlockStarted() {
+}
+
+// AddReceipt keeps the receipt of a transaction of the group
+func (rb *receiptsBuffer) AddReceipt(txHash []byte, rcpt *receipt.Receipt) {
+	rb.txHashes = append(rb.txHashes, txHash)
+	rb.receipts = append(rb.receipts, rcpt)
+}
+
+// SaveReceipts does nothing, as the receipts are saved by the block after they are merged
+func (rb *receiptsBuffer) SaveReceipts(_ []byte, _ data.HeaderHandler, _ [][]byte) {
+}
+
+// GetReceiptsTxHashes returns no transactions, as the group does not save the receipts
+func (rb *receiptsBuffer) GetReceiptsTxHashes(_ [][]byte) [][]byte {
+	return make([][]byte, 0)
+}
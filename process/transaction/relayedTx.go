@@ -0,0 +1,114 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/ElrondNetwork/elrond-go/marshal"
+	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/ElrondNetwork/elrond-go/sharding"
+)
+
+// CreateRelayedTxData returns the data field of a relayed transaction wrapping the given signed inner transaction.
+// The relayed transaction has to be sent to the inner transaction sender, with the value and the maximum fee of the
+// inner transaction as value
+func CreateRelayedTxData(marshalizer marshal.Marshalizer, innerTx *transaction.Transaction) (string, error) {
+	if marshalizer == nil {
+		return "", process.ErrNilMarshalizer
+	}
+	if innerTx == nil {
+		return "", process.ErrNilTransaction
+	}
+
+	buff, err := marshalizer.Marshal(innerTx)
+	if err != nil {
+		return "", err
+	}
+
+	return process.RelayedTxDataPrefix + hex.EncodeToString(buff), nil
+}
+
+// ComputeRelayedTxValue returns the value of the relayed transaction wrapping the given inner transaction, which
+// covers its value and its maximum fee
+func ComputeRelayedTxValue(innerTx *transaction.Transaction) *big.Int {
+	value := big.NewInt(0).SetUint64(innerTx.GasPrice)
+	value.Mul(value, big.NewInt(0).SetUint64(innerTx.GasLimit))
+	if innerTx.Value != nil {
+		value.Add(value, innerTx.Value)
+	}
+
+	return value
+}
+
+func isRelayedTx(tx *transaction.Transaction) bool {
+	return strings.HasPrefix(tx.Data, process.RelayedTxDataPrefix)
+}
+
+// getInnerTransaction decodes the inner transaction from the data field of a relayed transaction
+func getInnerTransaction(marshalizer marshal.Marshalizer, tx *transaction.Transaction) (*transaction.Transaction, error) {
+	if !isRelayedTx(tx) {
+		return nil, process.ErrInvalidRelayedTxData
+	}
+
+	buff, err := hex.DecodeString(tx.Data[len(process.RelayedTxDataPrefix):])
+	if err != nil {
+		return nil, process.ErrInvalidRelayedTxData
+	}
+
+	innerTx := &transaction.Transaction{}
+	err = marshalizer.Unmarshal(innerTx, buff)
+	if err != nil {
+		return nil, process.ErrInvalidRelayedTxData
+	}
+
+	return innerTx, nil
+}
+
+// checkRelayedTx checks the relayed transaction against its inner transaction. The shards of the relayer and of the
+// inner transaction sender run the same checks, so both of them either execute or reject the relayed transaction
+func checkRelayedTx(
+	tx *transaction.Transaction,
+	innerTx *transaction.Transaction,
+	addrConv state.AddressConverter,
+	shardCoordinator sharding.Coordinator,
+) error {
+	if isRelayedTx(innerTx) {
+		return process.ErrNestedRelayedTx
+	}
+	if innerTx.Value == nil {
+		return process.ErrNilValue
+	}
+	if innerTx.Value.Cmp(big.NewInt(0)) < 0 {
+		return process.ErrNegativeValue
+	}
+	if !bytes.Equal(tx.RcvAddr, innerTx.SndAddr) {
+		return process.ErrRelayedTxReceiverMismatch
+	}
+	if !bytes.Equal(tx.ChainID, innerTx.ChainID) {
+		return process.ErrInvalidChainID
+	}
+	if tx.Value == nil || tx.Value.Cmp(ComputeRelayedTxValue(innerTx)) != 0 {
+		return process.ErrRelayedTxValueMismatch
+	}
+
+	sndAddr, err := addrConv.CreateAddressFromPublicKeyBytes(innerTx.SndAddr)
+	if err != nil {
+		return process.ErrInvalidSndAddr
+	}
+	rcvAddr, err := addrConv.CreateAddressFromPublicKeyBytes(innerTx.RcvAddr)
+	if err != nil {
+		return process.ErrInvalidRcvAddr
+	}
+
+	// the inner transaction is executed only in the shard of its sender
+	isDeployment := bytes.Equal(rcvAddr.Bytes(), make([]byte, len(rcvAddr.Bytes())))
+	if !isDeployment && shardCoordinator.ComputeId(sndAddr) != shardCoordinator.ComputeId(rcvAddr) {
+		return process.ErrRelayedTxInnerCrossShard
+	}
+
+	return nil
+}
@@ -28,6 +28,9 @@ var ErrNilAddressConverter = errors.New("trying to set nil address converter")
 // ErrCouldNotParsePubKey signals that a given public key could not be parsed
 var ErrCouldNotParsePubKey = errors.New("could not parse node's public key")
 
+// ErrCouldNotParseAddress signals that a given node's reward address could not be parsed
+var ErrCouldNotParseAddress = errors.New("could not parse node's reward address")
+
 // ErrNegativeOrZeroConsensusGroupSize signals that an invalid consensus group size has been provided
 var ErrNegativeOrZeroConsensusGroupSize = errors.New("negative or zero consensus group size")
 
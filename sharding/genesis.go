@@ -89,3 +89,17 @@ func (g *Genesis) InitialNodesBalances(shardCoordinator Coordinator, adrConv sta
 
 	return balances, nil
 }
+
+// TotalSupply returns the sum of all the initial balances
+func (g *Genesis) TotalSupply() *big.Int {
+	totalSupply := big.NewInt(0)
+	for _, in := range g.InitialBalances {
+		if in.balance == nil {
+			continue
+		}
+
+		totalSupply.Add(totalSupply, in.balance)
+	}
+
+	return totalSupply
+}
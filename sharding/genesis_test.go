@@ -192,3 +192,9 @@ func TestGenesis_Initial5NodesBalancesGood(t *testing.T) {
 	assert.Equal(t, 3, len(inBalance))
 	assert.Nil(t, err)
 }
+
+func TestGenesis_TotalSupplyShouldSumAllBalances(t *testing.T) {
+	genesis := createGenesisTwoShardTwoNodes()
+
+	assert.Equal(t, big.NewInt(4*999), genesis.TotalSupply())
+}
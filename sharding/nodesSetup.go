@@ -10,8 +10,10 @@ import (
 // InitialNode holds data from json
 type InitialNode struct {
 	PubKey        string `json:"pubkey"`
+	Address       string `json:"address"`
 	assignedShard uint32
 	pubKey        []byte
+	address       []byte
 }
 
 // NodesSetup hold data for decoded data from json file
@@ -31,6 +33,7 @@ type NodesSetup struct {
 	nrOfNodes          uint32
 	nrOfMetaChainNodes uint32
 	allNodesPubKeys    map[uint32][]string
+	allNodesAddresses  map[uint32][]string
 }
 
 // NewNodesSetup creates a new decoded nodes structure from json config file
@@ -75,6 +78,12 @@ func (ns *NodesSetup) processConfig() error {
 			return ErrCouldNotParsePubKey
 		}
 
+		ns.InitialNodes[i].address, err = hex.DecodeString(ns.InitialNodes[i].Address)
+		if err != nil {
+			ns.InitialNodes[i].address = nil
+			return ErrCouldNotParseAddress
+		}
+
 		ns.nrOfNodes++
 	}
 
@@ -146,9 +155,11 @@ func (ns *NodesSetup) createInitialNodesPubKeys() {
 	}
 
 	ns.allNodesPubKeys = make(map[uint32][]string, nrOfShardAndMeta)
+	ns.allNodesAddresses = make(map[uint32][]string, nrOfShardAndMeta)
 	for _, in := range ns.InitialNodes {
 		if in.pubKey != nil {
 			ns.allNodesPubKeys[in.assignedShard] = append(ns.allNodesPubKeys[in.assignedShard], string(in.pubKey))
+			ns.allNodesAddresses[in.assignedShard] = append(ns.allNodesAddresses[in.assignedShard], string(in.address))
 		}
 	}
 }
@@ -170,6 +181,23 @@ func (ns *NodesSetup) InitialNodesPubKeysForShard(shardId uint32) ([]string, err
 	return ns.allNodesPubKeys[shardId], nil
 }
 
+// InitialNodesAddresses - gets initial nodes reward addresses, in the same order as the public keys
+func (ns *NodesSetup) InitialNodesAddresses() map[uint32][]string {
+	return ns.allNodesAddresses
+}
+
+// InitialNodesAddressesForShard - gets initial nodes reward addresses for a shard
+func (ns *NodesSetup) InitialNodesAddressesForShard(shardId uint32) ([]string, error) {
+	if ns.allNodesAddresses[shardId] == nil {
+		return nil, ErrShardIdOutOfRange
+	}
+	if len(ns.allNodesAddresses[shardId]) == 0 {
+		return nil, ErrNoPubKeys
+	}
+
+	return ns.allNodesAddresses[shardId], nil
+}
+
 // NumberOfShards returns the calculated number of shards
 func (ns *NodesSetup) NumberOfShards() uint32 {
 	return ns.nrOfShards
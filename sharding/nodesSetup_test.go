@@ -183,6 +183,21 @@ func TestNodesSetup_ProcessConfigNodesWithIncompleteDataShouldErr(t *testing.T)
 	assert.Equal(t, sharding.ErrCouldNotParsePubKey, err)
 }
 
+func TestNodesSetup_ProcessConfigInvalidAddressShouldErr(t *testing.T) {
+	ns := sharding.NodesSetup{}
+
+	ns.InitialNodes = make([]*sharding.InitialNode, 1)
+	ns.InitialNodes[0] = &sharding.InitialNode{}
+
+	ns.InitialNodes[0].PubKey = "5126b6505a73e59a994caa8f556f8c335d4399229de42102bb4814ca261c7419"
+	ns.InitialNodes[0].Address = "not a hex address"
+
+	err := ns.ProcessConfig()
+
+	assert.NotNil(t, ns)
+	assert.Equal(t, sharding.ErrCouldNotParseAddress, err)
+}
+
 func TestNodesSetup_ProcessConfigInvalidConsensusGroupSizeShouldErr(t *testing.T) {
 	ns := sharding.NodesSetup{
 		ConsensusGroupSize: 0,
@@ -396,6 +411,39 @@ func TestNodesSetup_InitialNodesPubKeysForShardGoodMeta(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestNodesSetup_InitialNodesAddressesForShardWrongShard(t *testing.T) {
+	ns := createNodesSetupOneShardOneNode()
+	addresses, err := ns.InitialNodesAddressesForShard(1)
+
+	assert.Nil(t, addresses)
+	assert.Equal(t, sharding.ErrShardIdOutOfRange, err)
+}
+
+func TestNodesSetup_InitialNodesAddressesForShardGood(t *testing.T) {
+	ns := &sharding.NodesSetup{}
+	ns.ConsensusGroupSize = 1
+	ns.MinNodesPerShard = 2
+	ns.InitialNodes = make([]*sharding.InitialNode, 2)
+	ns.InitialNodes[0] = &sharding.InitialNode{}
+	ns.InitialNodes[1] = &sharding.InitialNode{}
+
+	ns.InitialNodes[0].PubKey = "5126b6505a73e59a994caa8f556f8c335d4399229de42102bb4814ca261c7419"
+	ns.InitialNodes[0].Address = "d4105de8e44aee9d4be670401cec546e5df381028e805012386a05acf76518d9"
+	ns.InitialNodes[1].PubKey = "5126b6505a73e59a994caa8f556f8c335d4399229de42102bb4814ca261c7418"
+
+	_ = ns.ProcessConfig()
+	ns.ProcessShardAssignment()
+	ns.CreateInitialNodesPubKeys()
+
+	addresses, err := ns.InitialNodesAddressesForShard(0)
+	expectedAddress, _ := hex.DecodeString(ns.InitialNodes[0].Address)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(addresses))
+	assert.Equal(t, string(expectedAddress), addresses[0])
+	assert.Equal(t, "", addresses[1])
+}
+
 func TestNodesSetup_PublicKeyNotGood(t *testing.T) {
 	ns := createNodesSetupTwoShard6NodesMeta()
 
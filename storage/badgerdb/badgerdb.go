@@ -143,6 +143,9 @@ func (s *DB) Get(key []byte) ([]byte, error) {
 		return nil
 	})
 
+	if err == badger.ErrKeyNotFound {
+		return nil, storage.ErrKeyNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -161,9 +164,47 @@ func (s *DB) Has(key []byte) error {
 		return nil
 	})
 
+	if err == badger.ErrKeyNotFound {
+		return storage.ErrKeyNotFound
+	}
+
 	return err
 }
 
+// RangeKeys calls the handler for every stored entry, in the ascending order of the keys. The pending batch is
+// written first. The iteration stops when the handler returns false
+func (s *DB) RangeKeys(handler func(key []byte, val []byte) bool) error {
+	s.mutBatch.Lock()
+	err := s.putBatch(s.batch)
+	if err == nil {
+		s.batch.Reset()
+		s.sizeBatch = 0
+	}
+	s.mutBatch.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return s.db.View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+
+		for iterator.Rewind(); iterator.Valid(); iterator.Next() {
+			item := iterator.Item()
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			if !handler(item.KeyCopy(nil), val) {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ElrondNetwork/elrond-go/storage"
 	"github.com/ElrondNetwork/elrond-go/storage/badgerdb"
 	"github.com/stretchr/testify/assert"
 )
@@ -42,8 +43,7 @@ func TestDB_GetErrorAfterPutBeforeTimeout(t *testing.T) {
 	assert.Nil(t, err)
 	v, err := ldb.Get(key)
 	assert.Nil(t, v)
-	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "Key not found")
+	assert.Equal(t, storage.ErrKeyNotFound, err)
 }
 
 func TestDB_GetOKAfterPutWithTimeout(t *testing.T) {
@@ -71,8 +71,7 @@ func TestDB_RemoveBeforeTimeoutOK(t *testing.T) {
 
 	v, err := ldb.Get(key)
 	assert.Nil(t, v)
-	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "Key not found")
+	assert.Equal(t, storage.ErrKeyNotFound, err)
 }
 
 func TestDB_RemoveAfterTimeoutOK(t *testing.T) {
@@ -87,8 +86,7 @@ func TestDB_RemoveAfterTimeoutOK(t *testing.T) {
 
 	v, err := ldb.Get(key)
 	assert.Nil(t, v)
-	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "Key not found")
+	assert.Equal(t, storage.ErrKeyNotFound, err)
 }
 
 func TestDB_GetPresent(t *testing.T) {
@@ -111,7 +109,7 @@ func TestDB_GetNotPresent(t *testing.T) {
 
 	v, err := ldb.Get(key)
 
-	assert.NotNil(t, err, "error expected but got nil, value %s", v)
+	assert.Equal(t, storage.ErrKeyNotFound, err, "error expected but got nil, value %s", v)
 }
 
 func TestDB_HasPresent(t *testing.T) {
@@ -133,8 +131,7 @@ func TestDB_HasNotPresent(t *testing.T) {
 
 	err := ldb.Has(key)
 
-	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "Key not found")
+	assert.Equal(t, storage.ErrKeyNotFound, err)
 }
 
 func TestDB_RemovePresent(t *testing.T) {
@@ -151,8 +148,7 @@ func TestDB_RemovePresent(t *testing.T) {
 
 	err = ldb.Has(key)
 
-	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "Key not found")
+	assert.Equal(t, storage.ErrKeyNotFound, err)
 }
 
 func TestDB_RemoveNotPresent(t *testing.T) {
@@ -179,3 +175,39 @@ func TestDB_Destroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestDB_RangeKeysShouldIterateAllEntriesInKeyOrder(t *testing.T) {
+	ldb := createBadgerDb(t, 10, 100)
+
+	_ = ldb.Put([]byte("key2"), []byte("value2"))
+	_ = ldb.Put([]byte("key1"), []byte("value1"))
+	_ = ldb.Put([]byte("key3"), []byte("value3"))
+
+	keys := make([][]byte, 0)
+	values := make([][]byte, 0)
+	err := ldb.RangeKeys(func(key []byte, val []byte) bool {
+		keys = append(keys, key)
+		values = append(values, val)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}, keys)
+	assert.Equal(t, [][]byte{[]byte("value1"), []byte("value2"), []byte("value3")}, values)
+}
+
+func TestDB_RangeKeysShouldStopWhenHandlerReturnsFalse(t *testing.T) {
+	ldb := createBadgerDb(t, 10, 1)
+
+	_ = ldb.Put([]byte("key1"), []byte("value1"))
+	_ = ldb.Put([]byte("key2"), []byte("value2"))
+
+	numCalls := 0
+	err := ldb.RangeKeys(func(key []byte, val []byte) bool {
+		numCalls++
+		return false
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numCalls)
+}
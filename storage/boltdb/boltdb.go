@@ -44,7 +44,7 @@ func NewDB(path string, batchDelaySeconds int, maxBatchSize int) (s *DB, err err
 	fmt.Println("Parent Folder: ", parentFolder)
 
 	err = db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucket([]byte(parentFolder))
+		_, err := tx.CreateBucketIfNotExists([]byte(parentFolder))
 		if err != nil {
 			return errors.New(fmt.Sprintf("create bucket: %s", err))
 		}
@@ -110,6 +110,22 @@ func (s *DB) Has(key []byte) error {
 	})
 }
 
+// RangeKeys calls the handler for every stored entry, in the ascending order of the keys. The iteration stops
+// when the handler returns false
+func (s *DB) RangeKeys(handler func(key []byte, val []byte) bool) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket([]byte(s.parentFolder)).Cursor()
+		for key, val := cursor.First(); key != nil; key, val = cursor.Next() {
+			// the returned slices are only valid during the transaction, so they are copied
+			if !handler(append([]byte{}, key...), append([]byte{}, val...)) {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
@@ -27,3 +27,30 @@ var ErrKeyNotFound = errors.New("key not found")
 
 // ErrInvalidBatch is raised when the used batch is invalid
 var ErrInvalidBatch = errors.New("batch is invalid")
+
+// ErrNilPersisterFactory is raised when a nil persister factory is provided
+var ErrNilPersisterFactory = errors.New("expected not nil persister factory")
+
+// ErrInvalidNumActivePersisters is raised when the number of active persisters is lower than one
+var ErrInvalidNumActivePersisters = errors.New("number of active persisters should be at least one")
+
+// ErrInvalidPathTemplate is raised when the path template does not contain the epoch placeholder
+var ErrInvalidPathTemplate = errors.New("path template should contain the epoch placeholder")
+
+// ErrNilHasher is raised when a nil hasher is provided
+var ErrNilHasher = errors.New("expected not nil hasher")
+
+// ErrPersisterNotRangeable is raised when a persister can not walk its stored entries
+var ErrPersisterNotRangeable = errors.New("persister can not walk its stored entries")
+
+// ErrMigrationDestinationExists is raised when the destination path of a migration already exists
+var ErrMigrationDestinationExists = errors.New("migration destination path already exists")
+
+// ErrMigrationNumKeysMismatch is raised when the migrated persister holds a different number of keys than the source
+var ErrMigrationNumKeysMismatch = errors.New("migrated persister holds a different number of keys")
+
+// ErrMigrationContentHashMismatch is raised when the migrated persister holds different entries than the source
+var ErrMigrationContentHashMismatch = errors.New("migrated persister content hash differs")
+
+// ErrReadOnlyPersister is raised when a read-only persister is asked to change its stored data
+var ErrReadOnlyPersister = errors.New("the persister is read-only")
@@ -18,6 +18,18 @@ type Persister interface {
 	Destroy() error
 }
 
+// RangeablePersister is a persister which can walk all its stored entries
+type RangeablePersister interface {
+	Persister
+	// RangeKeys calls the handler for every stored entry. The iteration stops when the handler returns false
+	RangeKeys(handler func(key []byte, val []byte) bool) error
+}
+
+// PersisterFactory creates the persisters opened by a storer at the given paths
+type PersisterFactory interface {
+	Create(path string) (Persister, error)
+}
+
 // Batcher allows to batch the data first then write the batch to the persister in one go
 type Batcher interface {
 	// Put inserts one entry - key, value pair - into the batch
@@ -137,6 +137,23 @@ func (s *DB) Has(key []byte) error {
 	return storage.ErrKeyNotFound
 }
 
+// RangeKeys calls the handler for every stored entry, in the ascending order of the keys. The pending batch is
+// written first. The iteration stops when the handler returns false
+func (s *DB) RangeKeys(handler func(key []byte, val []byte) bool) error {
+	s.mutBatch.Lock()
+	err := s.putBatch(s.batch)
+	if err == nil {
+		s.batch.Reset()
+		s.sizeBatch = 0
+	}
+	s.mutBatch.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return rangeKeys(s.db, handler)
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
@@ -162,6 +179,21 @@ func (s *DB) putBatch(b storage.Batcher) error {
 	return s.db.Write(batch.batch, wopt)
 }
 
+func rangeKeys(db *leveldb.DB, handler func(key []byte, val []byte) bool) error {
+	iterator := db.NewIterator(nil, nil)
+	for iterator.Next() {
+		// the iterator reuses its buffers, so the key and the value are copied
+		key := append([]byte{}, iterator.Key()...)
+		val := append([]byte{}, iterator.Value()...)
+		if !handler(key, val) {
+			break
+		}
+	}
+	iterator.Release()
+
+	return iterator.Error()
+}
+
 // Close closes the files/resources associated to the storage medium
 func (s *DB) Close() error {
 	s.mutBatch.Lock()
@@ -0,0 +1,94 @@
+package leveldb
+
+import (
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// ReadOnlyDB opens an existing leveldb database without being able to change it. It can read the databases written
+// by both the DB and the SerialDB persisters
+type ReadOnlyDB struct {
+	db   *leveldb.DB
+	path string
+}
+
+// NewReadOnlyDB opens the database found in the location given as parameter. It returns an error if the database
+// does not exist, as it never creates the files
+func NewReadOnlyDB(path string) (*ReadOnlyDB, error) {
+	options := &opt.Options{
+		// disable internal cache
+		BlockCacheCapacity: -1,
+		ReadOnly:           true,
+		ErrorIfMissing:     true,
+	}
+
+	db, err := leveldb.OpenFile(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReadOnlyDB{
+		db:   db,
+		path: path,
+	}, nil
+}
+
+// Put returns ErrReadOnlyPersister as the database can not be changed
+func (s *ReadOnlyDB) Put(key, val []byte) error {
+	return storage.ErrReadOnlyPersister
+}
+
+// Get returns the value associated to the key
+func (s *ReadOnlyDB) Get(key []byte) ([]byte, error) {
+	data, err := s.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, storage.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Has returns true if the given key is present in the persistance medium
+func (s *ReadOnlyDB) Has(key []byte) error {
+	has, err := s.db.Has(key, nil)
+	if err != nil {
+		return err
+	}
+
+	if has {
+		return nil
+	}
+
+	return storage.ErrKeyNotFound
+}
+
+// RangeKeys calls the handler for every stored entry, in the ascending order of the keys. The iteration stops when
+// the handler returns false
+func (s *ReadOnlyDB) RangeKeys(handler func(key []byte, val []byte) bool) error {
+	return rangeKeys(s.db, handler)
+}
+
+// Init initializes the storage medium and prepares it for usage
+func (s *ReadOnlyDB) Init() error {
+	// no special initialization needed
+	return nil
+}
+
+// Close closes the files/resources associated to the storage medium
+func (s *ReadOnlyDB) Close() error {
+	return s.db.Close()
+}
+
+// Remove returns ErrReadOnlyPersister as the database can not be changed
+func (s *ReadOnlyDB) Remove(key []byte) error {
+	return storage.ErrReadOnlyPersister
+}
+
+// Destroy returns ErrReadOnlyPersister as the database can not be changed
+func (s *ReadOnlyDB) Destroy() error {
+	return storage.ErrReadOnlyPersister
+}
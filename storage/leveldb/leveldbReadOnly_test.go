@@ -0,0 +1,130 @@
+package leveldb_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/leveldb"
+	"github.com/stretchr/testify/assert"
+)
+
+func createReadOnlyLevelDb(t *testing.T, entries map[string][]byte) *leveldb.ReadOnlyDB {
+	dir, err := ioutil.TempDir("", "leveldb_temp")
+	assert.Nil(t, err)
+
+	ldb, err := leveldb.NewSerialDB(dir, 10, 1)
+	assert.Nil(t, err)
+	for key, val := range entries {
+		err = ldb.Put([]byte(key), val)
+		assert.Nil(t, err)
+	}
+	err = ldb.Close()
+	assert.Nil(t, err)
+
+	rodb, err := leveldb.NewReadOnlyDB(dir)
+	assert.Nil(t, err, "Failed opening leveldb database file")
+	return rodb
+}
+
+func TestNewReadOnlyDB_MissingDatabaseShouldErr(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+
+	rodb, err := leveldb.NewReadOnlyDB(filepath.Join(dir, "missing"))
+
+	assert.Nil(t, rodb)
+	assert.NotNil(t, err)
+}
+
+func TestReadOnlyDB_GetPresent(t *testing.T) {
+	key, val := []byte("key"), []byte("value")
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{string(key): val})
+
+	v, err := rodb.Get(key)
+
+	assert.Nil(t, err)
+	assert.Equal(t, val, v)
+}
+
+func TestReadOnlyDB_GetNotPresent(t *testing.T) {
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{})
+
+	v, err := rodb.Get([]byte("key"))
+
+	assert.Nil(t, v)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
+
+func TestReadOnlyDB_HasPresent(t *testing.T) {
+	key := []byte("key")
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{string(key): []byte("value")})
+
+	err := rodb.Has(key)
+
+	assert.Nil(t, err)
+}
+
+func TestReadOnlyDB_HasNotPresent(t *testing.T) {
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{})
+
+	err := rodb.Has([]byte("key"))
+
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
+
+func TestReadOnlyDB_PutShouldErr(t *testing.T) {
+	key := []byte("key")
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{})
+
+	err := rodb.Put(key, []byte("value"))
+
+	assert.Equal(t, storage.ErrReadOnlyPersister, err)
+	assert.Equal(t, storage.ErrKeyNotFound, rodb.Has(key))
+}
+
+func TestReadOnlyDB_RemoveShouldErr(t *testing.T) {
+	key := []byte("key")
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{string(key): []byte("value")})
+
+	err := rodb.Remove(key)
+
+	assert.Equal(t, storage.ErrReadOnlyPersister, err)
+	assert.Nil(t, rodb.Has(key))
+}
+
+func TestReadOnlyDB_DestroyShouldErr(t *testing.T) {
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{})
+
+	err := rodb.Destroy()
+
+	assert.Equal(t, storage.ErrReadOnlyPersister, err)
+}
+
+func TestReadOnlyDB_RangeKeysShouldIterateAllEntriesInKeyOrder(t *testing.T) {
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{
+		"key2": []byte("value2"),
+		"key1": []byte("value1"),
+		"key3": []byte("value3"),
+	})
+
+	keys := make([][]byte, 0)
+	values := make([][]byte, 0)
+	err := rodb.RangeKeys(func(key []byte, val []byte) bool {
+		keys = append(keys, key)
+		values = append(values, val)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}, keys)
+	assert.Equal(t, [][]byte{[]byte("value1"), []byte("value2"), []byte("value3")}, values)
+}
+
+func TestReadOnlyDB_Close(t *testing.T) {
+	rodb := createReadOnlyLevelDb(t, map[string][]byte{})
+
+	err := rodb.Close()
+
+	assert.Nil(t, err, "no error expected but got %s", err)
+}
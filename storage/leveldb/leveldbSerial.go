@@ -137,6 +137,18 @@ func (s *SerialDB) Has(key []byte) error {
 	return result
 }
 
+// RangeKeys calls the handler for every stored entry, in the ascending order of the keys. The pending batch is
+// written first. The iteration reads a snapshot of the database, outside the serial process loop, so it does
+// not block the other accesses. The iteration stops when the handler returns false
+func (s *SerialDB) RangeKeys(handler func(key []byte, val []byte) bool) error {
+	err := s.putBatch()
+	if err != nil {
+		return err
+	}
+
+	return rangeKeys(s.db, handler)
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *SerialDB) Init() error {
 	// no special initialization needed
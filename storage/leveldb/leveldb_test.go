@@ -186,3 +186,39 @@ func TestDB_Destroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestDB_RangeKeysShouldIterateAllEntriesInKeyOrder(t *testing.T) {
+	ldb := createLevelDb(t, 10, 100)
+
+	_ = ldb.Put([]byte("key2"), []byte("value2"))
+	_ = ldb.Put([]byte("key1"), []byte("value1"))
+	_ = ldb.Put([]byte("key3"), []byte("value3"))
+
+	keys := make([][]byte, 0)
+	values := make([][]byte, 0)
+	err := ldb.RangeKeys(func(key []byte, val []byte) bool {
+		keys = append(keys, key)
+		values = append(values, val)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}, keys)
+	assert.Equal(t, [][]byte{[]byte("value1"), []byte("value2"), []byte("value3")}, values)
+}
+
+func TestDB_RangeKeysShouldStopWhenHandlerReturnsFalse(t *testing.T) {
+	ldb := createLevelDb(t, 10, 1)
+
+	_ = ldb.Put([]byte("key1"), []byte("value1"))
+	_ = ldb.Put([]byte("key2"), []byte("value2"))
+
+	numCalls := 0
+	err := ldb.RangeKeys(func(key []byte, val []byte) bool {
+		numCalls++
+		return false
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numCalls)
+}
@@ -1,10 +1,10 @@
 package memorydb
 
 import (
-	"encoding/base64"
 	"errors"
-	"fmt"
 	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
 )
 
 // DB represents the memory database storage. It holds a map of key value pairs
@@ -40,7 +40,7 @@ func (s *DB) Get(key []byte) ([]byte, error) {
 	val, ok := s.db[string(key)]
 
 	if !ok {
-		return nil, errors.New(fmt.Sprintf("key: %s not found", base64.StdEncoding.EncodeToString(key)))
+		return nil, storage.ErrKeyNotFound
 	}
 
 	return val, nil
@@ -59,6 +59,27 @@ func (s *DB) Has(key []byte) error {
 	return nil
 }
 
+// RangeKeys calls the handler for every stored entry, in no particular order. The iteration stops when the
+// handler returns false
+func (s *DB) RangeKeys(handler func(key []byte, val []byte) bool) error {
+	s.mutx.RLock()
+	keys := make([]string, 0, len(s.db))
+	values := make([][]byte, 0, len(s.db))
+	for key, val := range s.db {
+		keys = append(keys, key)
+		values = append(values, val)
+	}
+	s.mutx.RUnlock()
+
+	for i := range keys {
+		if !handler([]byte(keys[i]), values[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
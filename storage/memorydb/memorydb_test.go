@@ -131,3 +131,18 @@ func TestDestroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestRangeKeysShouldIterateAllEntries(t *testing.T) {
+	mdb, _ := memorydb.New()
+	_ = mdb.Put([]byte("key1"), []byte("value1"))
+	_ = mdb.Put([]byte("key2"), []byte("value2"))
+
+	entries := make(map[string]string)
+	err := mdb.RangeKeys(func(key []byte, val []byte) bool {
+		entries[string(key)] = string(val)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, entries)
+}
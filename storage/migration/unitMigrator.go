@@ -0,0 +1,204 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+
+	"github.com/ElrondNetwork/elrond-go/hashing"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// UnitMigrationResult holds the number of keys and the content hash of a migrated persister
+type UnitMigrationResult struct {
+	NumKeys     uint64
+	ContentHash []byte
+}
+
+// unitMigrator copies the entries of a persister into a new persister, usually of another database type
+type unitMigrator struct {
+	sourceFactory      storage.PersisterFactory
+	destinationFactory storage.PersisterFactory
+	hasher             hashing.Hasher
+}
+
+// NewUnitMigrator creates a migrator reading the persisters created by the source factory and writing in the
+// persisters created by the destination factory
+func NewUnitMigrator(
+	sourceFactory storage.PersisterFactory,
+	destinationFactory storage.PersisterFactory,
+	hasher hashing.Hasher,
+) (*unitMigrator, error) {
+	if sourceFactory == nil || destinationFactory == nil {
+		return nil, storage.ErrNilPersisterFactory
+	}
+	if hasher == nil {
+		return nil, storage.ErrNilHasher
+	}
+
+	return &unitMigrator{
+		sourceFactory:      sourceFactory,
+		destinationFactory: destinationFactory,
+		hasher:             hasher,
+	}, nil
+}
+
+// Migrate copies every entry of the persister at the source path in a new persister created at the destination
+// path. Both persisters are then reopened and their number of keys and content hashes are compared
+func (um *unitMigrator) Migrate(sourcePath string, destinationPath string) (*UnitMigrationResult, error) {
+	_, err := os.Stat(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat(destinationPath)
+	if err == nil {
+		return nil, storage.ErrMigrationDestinationExists
+	}
+
+	err = um.copyEntries(sourcePath, destinationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceResult, err := um.computeResult(um.sourceFactory, sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	destinationResult, err := um.computeResult(um.destinationFactory, destinationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceResult.NumKeys != destinationResult.NumKeys {
+		return nil, storage.ErrMigrationNumKeysMismatch
+	}
+	if !bytes.Equal(sourceResult.ContentHash, destinationResult.ContentHash) {
+		return nil, storage.ErrMigrationContentHashMismatch
+	}
+
+	return destinationResult, nil
+}
+
+func (um *unitMigrator) copyEntries(sourcePath string, destinationPath string) error {
+	source, err := openRangeable(um.sourceFactory, sourcePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = source.Close()
+	}()
+
+	destination, err := um.destinationFactory.Create(destinationPath)
+	if err != nil {
+		return err
+	}
+
+	var errPut error
+	err = source.RangeKeys(func(key []byte, val []byte) bool {
+		errPut = destination.Put(key, val)
+		return errPut == nil
+	})
+	if err == nil {
+		err = errPut
+	}
+	if err != nil {
+		_ = destination.Destroy()
+		return err
+	}
+
+	// closing the destination writes its pending batch
+	return destination.Close()
+}
+
+// computeResult walks the persister at the given path and computes its content hash, which does not depend on
+// the order in which the entries are walked
+func (um *unitMigrator) computeResult(factory storage.PersisterFactory, path string) (*UnitMigrationResult, error) {
+	persister, err := openRangeable(factory, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = persister.Close()
+	}()
+
+	result := &UnitMigrationResult{
+		ContentHash: make([]byte, um.hasher.Size()),
+	}
+	err = persister.RangeKeys(func(key []byte, val []byte) bool {
+		entryHash := um.hasher.Compute(string(encodeEntry(key, val)))
+		for i := range result.ContentHash {
+			result.ContentHash[i] ^= entryHash[i]
+		}
+		result.NumKeys++
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// encodeEntry prefixes the key with its length, so that the key and the value boundary can not be shifted
+func encodeEntry(key []byte, val []byte) []byte {
+	buff := make([]byte, 8, 8+len(key)+len(val))
+	binary.BigEndian.PutUint64(buff, uint64(len(key)))
+	buff = append(buff, key...)
+
+	return append(buff, val...)
+}
+
+func openRangeable(factory storage.PersisterFactory, path string) (storage.RangeablePersister, error) {
+	persister, err := factory.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeable, ok := persister.(storage.RangeablePersister)
+	if !ok {
+		_ = persister.Close()
+		return nil, storage.ErrPersisterNotRangeable
+	}
+
+	return rangeable, nil
+}
+
+// SwapDirectories moves the directory at the given path to the backup path and the migrated directory in its
+// place. Each move is an atomic rename, and the first move is reverted if the second one fails
+func SwapDirectories(path string, migratedPath string, backupPath string) error {
+	_, err := os.Stat(backupPath)
+	if err == nil {
+		return os.ErrExist
+	}
+
+	err = os.Rename(path, backupPath)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(migratedPath, path)
+	if err != nil {
+		_ = os.Rename(backupPath, path)
+		return err
+	}
+
+	return nil
+}
+
+// RestoreDirectories reverts SwapDirectories, moving the migrated directory back to the migrated path and the
+// original directory back from the backup path
+func RestoreDirectories(path string, migratedPath string, backupPath string) error {
+	err := os.Rename(path, migratedPath)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(backupPath, path)
+	if err != nil {
+		_ = os.Rename(migratedPath, path)
+		return err
+	}
+
+	return nil
+}
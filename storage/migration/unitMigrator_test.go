@@ -0,0 +1,239 @@
+package migration_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/hashing/sha256"
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/migration"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+)
+
+const numEntries = 100
+
+func createSourceUnit(t *testing.T, dbType storageUnit.DBType) string {
+	dir, _ := ioutil.TempDir("", "migration_test")
+	path := filepath.Join(dir, "Unit")
+
+	persister, err := storageUnit.NewPersisterFactory(dbType, 10, 1).Create(path)
+	assert.Nil(t, err)
+	for i := 0; i < numEntries; i++ {
+		_ = persister.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	_ = persister.Close()
+
+	return path
+}
+
+func TestNewUnitMigrator_NilSourceFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	um, err := migration.NewUnitMigrator(nil, storageUnit.NewPersisterFactory(storageUnit.LvlDB, 10, 1), sha256.Sha256{})
+
+	assert.Nil(t, um)
+	assert.Equal(t, storage.ErrNilPersisterFactory, err)
+}
+
+func TestNewUnitMigrator_NilDestinationFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	um, err := migration.NewUnitMigrator(storageUnit.NewPersisterFactory(storageUnit.LvlDB, 10, 1), nil, sha256.Sha256{})
+
+	assert.Nil(t, um)
+	assert.Equal(t, storage.ErrNilPersisterFactory, err)
+}
+
+func TestNewUnitMigrator_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	um, err := migration.NewUnitMigrator(
+		storageUnit.NewPersisterFactory(storageUnit.LvlDB, 10, 1),
+		storageUnit.NewPersisterFactory(storageUnit.BoltDB, 10, 1),
+		nil,
+	)
+
+	assert.Nil(t, um)
+	assert.Equal(t, storage.ErrNilHasher, err)
+}
+
+func TestUnitMigrator_MigrateMissingSourceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := ioutil.TempDir("", "migration_test")
+	um, _ := migration.NewUnitMigrator(
+		storageUnit.NewPersisterFactory(storageUnit.LvlDB, 10, 1),
+		storageUnit.NewPersisterFactory(storageUnit.BoltDB, 10, 1),
+		sha256.Sha256{},
+	)
+
+	result, err := um.Migrate(filepath.Join(dir, "missing"), filepath.Join(dir, "destination"))
+
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+	_, err = os.Stat(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUnitMigrator_MigrateExistingDestinationShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sourcePath := createSourceUnit(t, storageUnit.LvlDB)
+	destinationPath := filepath.Join(filepath.Dir(sourcePath), "destination")
+	_ = os.MkdirAll(destinationPath, os.ModePerm)
+	um, _ := migration.NewUnitMigrator(
+		storageUnit.NewPersisterFactory(storageUnit.LvlDB, 10, 1),
+		storageUnit.NewPersisterFactory(storageUnit.BoltDB, 10, 1),
+		sha256.Sha256{},
+	)
+
+	result, err := um.Migrate(sourcePath, destinationPath)
+
+	assert.Nil(t, result)
+	assert.Equal(t, storage.ErrMigrationDestinationExists, err)
+}
+
+func TestUnitMigrator_MigrateShouldCopyAllEntries(t *testing.T) {
+	t.Parallel()
+
+	sourcePath := createSourceUnit(t, storageUnit.LvlDbSerial)
+	destinationPath := filepath.Join(filepath.Dir(sourcePath), "destination")
+	destinationFactory := storageUnit.NewPersisterFactory(storageUnit.BoltDB, 10, 1)
+	um, _ := migration.NewUnitMigrator(
+		storageUnit.NewPersisterFactory(storageUnit.LvlDbSerial, 10, 1),
+		destinationFactory,
+		sha256.Sha256{},
+	)
+
+	result, err := um.Migrate(sourcePath, destinationPath)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(numEntries), result.NumKeys)
+	assert.Equal(t, sha256.Sha256{}.Size(), len(result.ContentHash))
+
+	destination, _ := destinationFactory.Create(destinationPath)
+	for i := 0; i < numEntries; i++ {
+		val, err := destination.Get([]byte(fmt.Sprintf("key%d", i)))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("value%d", i)), val)
+	}
+	_ = destination.Close()
+}
+
+func TestUnitMigrator_MigrateBackShouldComputeTheSameContentHash(t *testing.T) {
+	t.Parallel()
+
+	sourcePath := createSourceUnit(t, storageUnit.BadgerDB)
+	leveldbPath := filepath.Join(filepath.Dir(sourcePath), "leveldb")
+	badgerPath := filepath.Join(filepath.Dir(sourcePath), "badger")
+	badgerFactory := storageUnit.NewPersisterFactory(storageUnit.BadgerDB, 10, 1)
+	leveldbFactory := storageUnit.NewPersisterFactory(storageUnit.LvlDB, 10, 1)
+	toLeveldb, _ := migration.NewUnitMigrator(badgerFactory, leveldbFactory, sha256.Sha256{})
+	toBadger, _ := migration.NewUnitMigrator(leveldbFactory, badgerFactory, sha256.Sha256{})
+
+	leveldbResult, err := toLeveldb.Migrate(sourcePath, leveldbPath)
+	assert.Nil(t, err)
+	badgerResult, err := toBadger.Migrate(leveldbPath, badgerPath)
+	assert.Nil(t, err)
+
+	assert.Equal(t, leveldbResult, badgerResult)
+}
+
+func TestSwapDirectories_ShouldMoveTheDirectories(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := ioutil.TempDir("", "migration_test")
+	path := filepath.Join(dir, "Unit")
+	migratedPath := filepath.Join(dir, "migrated", "Unit")
+	backupPath := filepath.Join(dir, "backup")
+	_ = os.MkdirAll(path, os.ModePerm)
+	_ = ioutil.WriteFile(filepath.Join(path, "original"), []byte("original"), os.ModePerm)
+	_ = os.MkdirAll(migratedPath, os.ModePerm)
+	_ = ioutil.WriteFile(filepath.Join(migratedPath, "migrated"), []byte("migrated"), os.ModePerm)
+
+	err := migration.SwapDirectories(path, migratedPath, backupPath)
+
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(path, "migrated"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(backupPath, "original"))
+	assert.Nil(t, err)
+	_, err = os.Stat(migratedPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSwapDirectories_MissingMigratedDirectoryShouldRestoreTheOriginal(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := ioutil.TempDir("", "migration_test")
+	path := filepath.Join(dir, "Unit")
+	backupPath := filepath.Join(dir, "backup")
+	_ = os.MkdirAll(path, os.ModePerm)
+
+	err := migration.SwapDirectories(path, filepath.Join(dir, "missing"), backupPath)
+
+	assert.NotNil(t, err)
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+	_, err = os.Stat(backupPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSwapDirectories_ExistingBackupShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := ioutil.TempDir("", "migration_test")
+	path := filepath.Join(dir, "Unit")
+	backupPath := filepath.Join(dir, "backup")
+	_ = os.MkdirAll(path, os.ModePerm)
+	_ = os.MkdirAll(backupPath, os.ModePerm)
+
+	err := migration.SwapDirectories(path, filepath.Join(dir, "migrated"), backupPath)
+
+	assert.Equal(t, os.ErrExist, err)
+}
+
+func TestRestoreDirectories_ShouldRevertTheSwap(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := ioutil.TempDir("", "migration_test")
+	path := filepath.Join(dir, "Unit")
+	migratedPath := filepath.Join(dir, "migrated", "Unit")
+	backupPath := filepath.Join(dir, "backup")
+	_ = os.MkdirAll(path, os.ModePerm)
+	_ = ioutil.WriteFile(filepath.Join(path, "original"), []byte("original"), os.ModePerm)
+	_ = os.MkdirAll(migratedPath, os.ModePerm)
+	_ = ioutil.WriteFile(filepath.Join(migratedPath, "migrated"), []byte("migrated"), os.ModePerm)
+	_ = migration.SwapDirectories(path, migratedPath, backupPath)
+
+	err := migration.RestoreDirectories(path, migratedPath, backupPath)
+
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(path, "original"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(migratedPath, "migrated"))
+	assert.Nil(t, err)
+	_, err = os.Stat(backupPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRestoreDirectories_MissingBackupShouldKeepTheMigratedDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := ioutil.TempDir("", "migration_test")
+	path := filepath.Join(dir, "Unit")
+	migratedPath := filepath.Join(dir, "migrated")
+	_ = os.MkdirAll(path, os.ModePerm)
+
+	err := migration.RestoreDirectories(path, migratedPath, filepath.Join(dir, "missing"))
+
+	assert.NotNil(t, err)
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+	_, err = os.Stat(migratedPath)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,429 @@
+package pruning
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go/core/logger"
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// EpochPlaceholder is replaced with the epoch number in the path template of the persisters
+const EpochPlaceholder = "[E]"
+
+var log = logger.DefaultLogger()
+
+// persisterData holds an opened persister together with the epoch it stores
+type persisterData struct {
+	epoch     uint32
+	path      string
+	persister storage.Persister
+}
+
+// PruningStorer is a storer which writes the data of each epoch in a new persister. Only the persisters of the
+// last numActivePersisters epochs are kept opened. The older ones are destroyed, unless the storer is a full
+// archive, in which case they are only closed and searched again when a key is not found in the active ones
+type PruningStorer struct {
+	lock                sync.Mutex
+	cacher              storage.Cacher
+	persisterFactory    storage.PersisterFactory
+	pathTemplate        string
+	numActivePersisters uint32
+	fullArchive         bool
+	activePersisters    []*persisterData
+}
+
+// NewPruningStorer creates a pruning storer which starts writing in the persister of the given epoch or, if the
+// node is restarted, in the persister of the newest epoch found on disk. The persisters of the previous epochs
+// which are still in the active window are reopened, while the older ones are destroyed, unless the storer is a
+// full archive. The path template should contain the epoch placeholder, as each epoch is stored in a different
+// directory
+func NewPruningStorer(
+	cacher storage.Cacher,
+	persisterFactory storage.PersisterFactory,
+	pathTemplate string,
+	numActivePersisters uint32,
+	fullArchive bool,
+	epoch uint32,
+) (*PruningStorer, error) {
+	if cacher == nil {
+		return nil, storage.ErrNilCacher
+	}
+	if persisterFactory == nil {
+		return nil, storage.ErrNilPersisterFactory
+	}
+	if !strings.Contains(pathTemplate, EpochPlaceholder) {
+		return nil, storage.ErrInvalidPathTemplate
+	}
+	if numActivePersisters == 0 {
+		return nil, storage.ErrInvalidNumActivePersisters
+	}
+
+	ps := &PruningStorer{
+		cacher:              cacher,
+		persisterFactory:    persisterFactory,
+		pathTemplate:        pathTemplate,
+		numActivePersisters: numActivePersisters,
+		fullArchive:         fullArchive,
+	}
+
+	err := ps.openPersisters(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+// openPersisters opens the persister of the start epoch and the ones of the existing epochs which are still active
+func (ps *PruningStorer) openPersisters(epoch uint32) error {
+	existingEpochs, err := ps.existingEpochs()
+	if err != nil {
+		return err
+	}
+	if len(existingEpochs) > 0 && existingEpochs[0] > epoch {
+		epoch = existingEpochs[0]
+	}
+
+	pd, err := ps.createPersister(epoch)
+	if err != nil {
+		return err
+	}
+
+	ps.activePersisters = []*persisterData{pd}
+	for _, existingEpoch := range existingEpochs {
+		isActive := epoch-existingEpoch < ps.numActivePersisters
+		if existingEpoch >= epoch || (!isActive && ps.fullArchive) {
+			continue
+		}
+
+		pd, err = ps.createPersister(existingEpoch)
+		if err != nil {
+			log.LogIfError(ps.closePersisters())
+			return err
+		}
+
+		if isActive {
+			ps.activePersisters = append(ps.activePersisters, pd)
+			continue
+		}
+
+		ps.removePersister(pd)
+	}
+
+	return nil
+}
+
+// existingEpochs returns the epochs which have a persister of this storer on disk, from the newest to the oldest one
+func (ps *PruningStorer) existingEpochs() ([]uint32, error) {
+	placeholderDir := ps.epochPlaceholderDirectory()
+	dirName := filepath.Base(placeholderDir)
+	idx := strings.Index(dirName, EpochPlaceholder)
+	prefix := dirName[:idx]
+	suffix := dirName[idx+len(EpochPlaceholder):]
+
+	files, err := ioutil.ReadDir(filepath.Dir(placeholderDir))
+	if os.IsNotExist(err) {
+		return make([]uint32, 0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	epochs := make([]uint32, 0)
+	for _, file := range files {
+		name := file.Name()
+		if !file.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+
+		epoch, errParse := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix), 10, 32)
+		if errParse != nil {
+			continue
+		}
+
+		_, errStat := os.Stat(ps.pathForEpoch(uint32(epoch)))
+		if errStat != nil {
+			continue
+		}
+
+		epochs = append(epochs, uint32(epoch))
+	}
+
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] > epochs[j]
+	})
+
+	return epochs, nil
+}
+
+// Put adds the data to the cache and to the persister of the current epoch
+func (ps *PruningStorer) Put(key, data []byte) error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	ps.cacher.Put(key, data)
+
+	err := ps.activePersisters[0].persister.Put(key, data)
+	if err != nil {
+		ps.cacher.Remove(key)
+		return err
+	}
+
+	return nil
+}
+
+// Get searches the key in the cache and then in the active persisters, from the newest to the oldest one. A full
+// archive also searches the persisters of the older epochs. The found value is added to the cache
+func (ps *PruningStorer) Get(key []byte) ([]byte, error) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	v, ok := ps.cacher.Get(key)
+	if ok {
+		return v.([]byte), nil
+	}
+
+	val, ok := ps.searchPersisters(key)
+	if !ok {
+		return nil, storage.ErrKeyNotFound
+	}
+
+	ps.cacher.Put(key, val)
+
+	return val, nil
+}
+
+// Has checks if the key is in the cache or in any of the searched persisters
+func (ps *PruningStorer) Has(key []byte) error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	if ps.cacher.Has(key) {
+		return nil
+	}
+
+	_, ok := ps.searchPersisters(key)
+	if !ok {
+		return storage.ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// HasOrAdd checks if the key is present in the storer and if not, adds it to the persister of the current epoch
+func (ps *PruningStorer) HasOrAdd(key []byte, value []byte) error {
+	err := ps.Has(key)
+	if err == nil {
+		return nil
+	}
+
+	return ps.Put(key, value)
+}
+
+// Remove removes the data associated to the given key from the cache and from all the active persisters
+func (ps *PruningStorer) Remove(key []byte) error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	ps.cacher.Remove(key)
+
+	var err error
+	for _, pd := range ps.activePersisters {
+		errRemove := pd.persister.Remove(key)
+		if errRemove != nil && err == nil {
+			err = errRemove
+		}
+	}
+
+	return err
+}
+
+// ClearCache cleans up the entire cache
+func (ps *PruningStorer) ClearCache() {
+	ps.cacher.Clear()
+}
+
+// DestroyUnit cleans up the cache and destroys all the active persisters
+func (ps *PruningStorer) DestroyUnit() error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	ps.cacher.Clear()
+
+	var err error
+	for _, pd := range ps.activePersisters {
+		errDestroy := pd.persister.Destroy()
+		if errDestroy != nil && err == nil {
+			err = errDestroy
+		}
+	}
+
+	return err
+}
+
+// ChangeEpoch opens the persister of the new epoch, where all the next puts are written, and removes the
+// persisters which fall out of the active window. An epoch which is not newer than the current one is ignored
+func (ps *PruningStorer) ChangeEpoch(epoch uint32) error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	if epoch <= ps.activePersisters[0].epoch {
+		return nil
+	}
+
+	pd, err := ps.createPersister(epoch)
+	if err != nil {
+		return err
+	}
+
+	ps.activePersisters = append([]*persisterData{pd}, ps.activePersisters...)
+	for uint32(len(ps.activePersisters)) > ps.numActivePersisters {
+		oldest := ps.activePersisters[len(ps.activePersisters)-1]
+		ps.activePersisters = ps.activePersisters[:len(ps.activePersisters)-1]
+		ps.removePersister(oldest)
+	}
+
+	return nil
+}
+
+// Close closes all the active persisters, so they can be reopened by another storer
+func (ps *PruningStorer) Close() error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	return ps.closePersisters()
+}
+
+// Epoch returns the epoch of the persister where the data is currently written
+func (ps *PruningStorer) Epoch() uint32 {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	return ps.activePersisters[0].epoch
+}
+
+func (ps *PruningStorer) closePersisters() error {
+	var err error
+	for _, pd := range ps.activePersisters {
+		errClose := pd.persister.Close()
+		if errClose != nil && err == nil {
+			err = errClose
+		}
+	}
+
+	return err
+}
+
+func (ps *PruningStorer) searchPersisters(key []byte) ([]byte, bool) {
+	for _, pd := range ps.activePersisters {
+		val, err := pd.persister.Get(key)
+		if err == nil {
+			return val, true
+		}
+	}
+
+	if !ps.fullArchive {
+		return nil, false
+	}
+
+	oldestActiveEpoch := ps.activePersisters[len(ps.activePersisters)-1].epoch
+	for epoch := int64(oldestActiveEpoch) - 1; epoch >= 0; epoch-- {
+		val, ok := ps.searchClosedPersister(uint32(epoch), key)
+		if ok {
+			return val, true
+		}
+	}
+
+	return nil, false
+}
+
+// searchClosedPersister opens the persister of an epoch out of the active window only for the time of the search
+func (ps *PruningStorer) searchClosedPersister(epoch uint32, key []byte) ([]byte, bool) {
+	path := ps.pathForEpoch(epoch)
+	_, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+
+	persister, err := ps.persisterFactory.Create(path)
+	if err != nil {
+		log.Debug(fmt.Sprintf("could not open the persister of epoch %d: %s", epoch, err.Error()))
+		return nil, false
+	}
+	defer func() {
+		log.LogIfError(persister.Close())
+	}()
+
+	val, err := persister.Get(key)
+	if err != nil {
+		return nil, false
+	}
+
+	return val, true
+}
+
+func (ps *PruningStorer) createPersister(epoch uint32) (*persisterData, error) {
+	path := ps.pathForEpoch(epoch)
+	persister, err := ps.persisterFactory.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = persister.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return &persisterData{
+		epoch:     epoch,
+		path:      path,
+		persister: persister,
+	}, nil
+}
+
+// removePersister closes the persister of a full archive. Otherwise the persister is destroyed together with its
+// parent directories up to the epoch directory, which are removed only when left empty by all the storers
+func (ps *PruningStorer) removePersister(pd *persisterData) {
+	if ps.fullArchive {
+		log.LogIfError(pd.persister.Close())
+		return
+	}
+
+	log.LogIfError(pd.persister.Destroy())
+	ps.cacher.Clear()
+
+	epochDir := ps.epochDirectory(pd.epoch)
+	for dir := filepath.Dir(pd.path); strings.HasPrefix(dir, epochDir); dir = filepath.Dir(dir) {
+		err := os.Remove(dir)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (ps *PruningStorer) pathForEpoch(epoch uint32) string {
+	return strings.Replace(ps.pathTemplate, EpochPlaceholder, strconv.Itoa(int(epoch)), 1)
+}
+
+// epochDirectory returns the path up to the directory whose name holds the epoch placeholder
+func (ps *PruningStorer) epochDirectory(epoch uint32) string {
+	return strings.Replace(ps.epochPlaceholderDirectory(), EpochPlaceholder, strconv.Itoa(int(epoch)), 1)
+}
+
+// epochPlaceholderDirectory returns the path template up to the directory whose name holds the epoch placeholder
+func (ps *PruningStorer) epochPlaceholderDirectory() string {
+	idx := strings.Index(ps.pathTemplate, EpochPlaceholder) + len(EpochPlaceholder)
+	end := strings.IndexRune(ps.pathTemplate[idx:], filepath.Separator)
+	if end < 0 {
+		return ps.pathTemplate
+	}
+
+	return ps.pathTemplate[:idx+end]
+}
@@ -0,0 +1,336 @@
+package pruning_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/lrucache"
+	"github.com/ElrondNetwork/elrond-go/storage/pruning"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+)
+
+func createPathTemplate() (string, string) {
+	dir, _ := ioutil.TempDir("", "pruning_storer")
+	return dir, filepath.Join(dir, "Epoch_"+pruning.EpochPlaceholder, "Shard_0", "Transactions")
+}
+
+func createPruningStorer(pathTemplate string, numActivePersisters uint32, fullArchive bool) *pruning.PruningStorer {
+	cacher, _ := lrucache.NewCache(10)
+	persisterFactory := storageUnit.NewPersisterFactory(storageUnit.LvlDbSerial, 1, 1)
+	ps, _ := pruning.NewPruningStorer(cacher, persisterFactory, pathTemplate, numActivePersisters, fullArchive, 0)
+
+	return ps
+}
+
+func directoryExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestNewPruningStorer_NilCacherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ps, err := pruning.NewPruningStorer(nil, storageUnit.NewPersisterFactory(storageUnit.LvlDbSerial, 1, 1),
+		"Epoch_"+pruning.EpochPlaceholder, 2, false, 0)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, storage.ErrNilCacher, err)
+}
+
+func TestNewPruningStorer_NilPersisterFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := lrucache.NewCache(10)
+	ps, err := pruning.NewPruningStorer(cacher, nil, "Epoch_"+pruning.EpochPlaceholder, 2, false, 0)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, storage.ErrNilPersisterFactory, err)
+}
+
+func TestNewPruningStorer_PathTemplateWithoutPlaceholderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := lrucache.NewCache(10)
+	ps, err := pruning.NewPruningStorer(cacher, storageUnit.NewPersisterFactory(storageUnit.LvlDbSerial, 1, 1),
+		"Epoch_0", 2, false, 0)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, storage.ErrInvalidPathTemplate, err)
+}
+
+func TestNewPruningStorer_ZeroActivePersistersShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := lrucache.NewCache(10)
+	ps, err := pruning.NewPruningStorer(cacher, storageUnit.NewPersisterFactory(storageUnit.LvlDbSerial, 1, 1),
+		"Epoch_"+pruning.EpochPlaceholder, 0, false, 0)
+
+	assert.Nil(t, ps)
+	assert.Equal(t, storage.ErrInvalidNumActivePersisters, err)
+}
+
+func TestNewPruningStorer_ShouldOpenThePersisterOfTheStartEpoch(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	cacher, _ := lrucache.NewCache(10)
+	persisterFactory := storageUnit.NewPersisterFactory(storageUnit.LvlDbSerial, 1, 1)
+	ps, err := pruning.NewPruningStorer(cacher, persisterFactory, pathTemplate, 2, false, 3)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3), ps.Epoch())
+	assert.True(t, directoryExists(filepath.Join(dir, "Epoch_3", "Shard_0", "Transactions")))
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_PutGetHasShouldWork(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ps := createPruningStorer(pathTemplate, 2, false)
+	key, value := []byte("key"), []byte("value")
+
+	err := ps.Put(key, value)
+	assert.Nil(t, err)
+
+	ps.ClearCache()
+	val, err := ps.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, value, val)
+	assert.Nil(t, ps.Has(key))
+
+	_, err = ps.Get([]byte("missing key"))
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	assert.Equal(t, storage.ErrKeyNotFound, ps.Has([]byte("missing key")))
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_HasOrAddShouldAddOnlyMissingKeys(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ps := createPruningStorer(pathTemplate, 2, false)
+	key := []byte("key")
+
+	err := ps.HasOrAdd(key, []byte("value"))
+	assert.Nil(t, err)
+	err = ps.HasOrAdd(key, []byte("other value"))
+	assert.Nil(t, err)
+
+	ps.ClearCache()
+	val, _ := ps.Get(key)
+	assert.Equal(t, []byte("value"), val)
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_RemoveShouldRemoveFromAllActivePersisters(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ps := createPruningStorer(pathTemplate, 2, false)
+	key := []byte("key")
+	_ = ps.Put(key, []byte("value"))
+	_ = ps.ChangeEpoch(1)
+
+	err := ps.Remove(key)
+	assert.Nil(t, err)
+	assert.NotNil(t, ps.Has(key))
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_ChangeEpochShouldKeepTheDataOfTheActiveEpochs(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ps := createPruningStorer(pathTemplate, 2, false)
+	_ = ps.Put([]byte("key0"), []byte("value0"))
+
+	err := ps.ChangeEpoch(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), ps.Epoch())
+	_ = ps.Put([]byte("key1"), []byte("value1"))
+
+	ps.ClearCache()
+	val, err := ps.Get([]byte("key0"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value0"), val)
+	val, err = ps.Get([]byte("key1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_ChangeEpochShouldDestroyThePersistersOutOfTheActiveWindow(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ps := createPruningStorer(pathTemplate, 2, false)
+	_ = ps.Put([]byte("key0"), []byte("value0"))
+	_ = ps.ChangeEpoch(1)
+	_ = ps.Put([]byte("key1"), []byte("value1"))
+
+	err := ps.ChangeEpoch(2)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, ps.Has([]byte("key0")))
+	assert.Nil(t, ps.Has([]byte("key1")))
+	assert.False(t, directoryExists(filepath.Join(dir, "Epoch_0")))
+	assert.True(t, directoryExists(filepath.Join(dir, "Epoch_1", "Shard_0", "Transactions")))
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_ChangeEpochFullArchiveShouldSearchTheClosedPersisters(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ps := createPruningStorer(pathTemplate, 1, true)
+	_ = ps.Put([]byte("key0"), []byte("value0"))
+	_ = ps.ChangeEpoch(1)
+	_ = ps.ChangeEpoch(2)
+
+	assert.True(t, directoryExists(filepath.Join(dir, "Epoch_0", "Shard_0", "Transactions")))
+
+	ps.ClearCache()
+	val, err := ps.Get([]byte("key0"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value0"), val)
+	_ = ps.DestroyUnit()
+}
+
+func TestPruningStorer_ChangeEpochToAnOlderEpochShouldBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	ps := createPruningStorer(pathTemplate, 2, false)
+	_ = ps.ChangeEpoch(2)
+
+	err := ps.ChangeEpoch(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), ps.Epoch())
+	assert.False(t, directoryExists(filepath.Join(dir, "Epoch_1")))
+	_ = ps.DestroyUnit()
+}
+
+func createPruningStorerWithEpochs(pathTemplate string, numActivePersisters uint32, lastEpoch uint32) {
+	ps := createPruningStorer(pathTemplate, numActivePersisters, false)
+	for epoch := uint32(0); epoch <= lastEpoch; epoch++ {
+		_ = ps.ChangeEpoch(epoch)
+		_ = ps.Put([]byte(fmt.Sprintf("key%d", epoch)), []byte(fmt.Sprintf("value%d", epoch)))
+	}
+	_ = ps.Close()
+}
+
+func TestNewPruningStorer_ExistingEpochsShouldContinueFromTheNewestOne(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	createPruningStorerWithEpochs(pathTemplate, 3, 2)
+
+	ps := createPruningStorer(pathTemplate, 3, false)
+
+	assert.Equal(t, uint32(2), ps.Epoch())
+	for epoch := 0; epoch <= 2; epoch++ {
+		val, err := ps.Get([]byte(fmt.Sprintf("key%d", epoch)))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("value%d", epoch)), val)
+	}
+	_ = ps.DestroyUnit()
+}
+
+func TestNewPruningStorer_ExistingEpochsShouldDestroyThePersistersOutOfTheActiveWindow(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	createPruningStorerWithEpochs(pathTemplate, 3, 2)
+
+	ps := createPruningStorer(pathTemplate, 2, false)
+
+	assert.Equal(t, uint32(2), ps.Epoch())
+	assert.NotNil(t, ps.Has([]byte("key0")))
+	assert.Nil(t, ps.Has([]byte("key1")))
+	assert.False(t, directoryExists(filepath.Join(dir, "Epoch_0")))
+	assert.True(t, directoryExists(filepath.Join(dir, "Epoch_1", "Shard_0", "Transactions")))
+	_ = ps.DestroyUnit()
+}
+
+func TestNewPruningStorer_ExistingEpochsFullArchiveShouldKeepThePersistersOutOfTheActiveWindow(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	createPruningStorerWithEpochs(pathTemplate, 3, 2)
+
+	ps := createPruningStorer(pathTemplate, 1, true)
+
+	assert.Equal(t, uint32(2), ps.Epoch())
+	assert.True(t, directoryExists(filepath.Join(dir, "Epoch_0", "Shard_0", "Transactions")))
+	val, err := ps.Get([]byte("key0"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value0"), val)
+	_ = ps.DestroyUnit()
+}
+
+func TestNewPruningStorer_ExistingEpochsOlderThanTheStartEpochShouldStartFromTheGivenEpoch(t *testing.T) {
+	t.Parallel()
+
+	dir, pathTemplate := createPathTemplate()
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	createPruningStorerWithEpochs(pathTemplate, 2, 1)
+
+	cacher, _ := lrucache.NewCache(10)
+	persisterFactory := storageUnit.NewPersisterFactory(storageUnit.LvlDbSerial, 1, 1)
+	ps, err := pruning.NewPruningStorer(cacher, persisterFactory, pathTemplate, 2, false, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), ps.Epoch())
+	assert.Nil(t, ps.Has([]byte("key1")))
+	assert.False(t, directoryExists(filepath.Join(dir, "Epoch_0")))
+	_ = ps.DestroyUnit()
+}
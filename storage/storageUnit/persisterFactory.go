@@ -0,0 +1,26 @@
+package storageUnit
+
+import (
+	"github.com/ElrondNetwork/elrond-go/storage"
+)
+
+// persisterFactory creates persisters of the same database type at different paths
+type persisterFactory struct {
+	dbType            DBType
+	batchDelaySeconds int
+	maxBatchSize      int
+}
+
+// NewPersisterFactory creates a factory for the persisters of the given database type
+func NewPersisterFactory(dbType DBType, batchDelaySeconds int, maxBatchSize int) *persisterFactory {
+	return &persisterFactory{
+		dbType:            dbType,
+		batchDelaySeconds: batchDelaySeconds,
+		maxBatchSize:      maxBatchSize,
+	}
+}
+
+// Create opens a new persister at the given path
+func (pf *persisterFactory) Create(path string) (storage.Persister, error) {
+	return NewDB(pf.dbType, path, pf.batchDelaySeconds, pf.maxBatchSize)
+}
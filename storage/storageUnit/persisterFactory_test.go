@@ -0,0 +1,37 @@
+package storageUnit_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/storage"
+	"github.com/ElrondNetwork/elrond-go/storage/storageUnit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersisterFactory_CreateWrongTypeShouldErr(t *testing.T) {
+	pf := storageUnit.NewPersisterFactory("NotLvlDB", 10, 10)
+
+	persister, err := pf.Create("test")
+
+	assert.Equal(t, storage.ErrNotSupportedDBType, err)
+	assert.Nil(t, persister)
+}
+
+func TestPersisterFactory_CreateShouldOpenPersisterAtPath(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+	pf := storageUnit.NewPersisterFactory(storageUnit.LvlDB, 10, 1)
+
+	persister, err := pf.Create(dir)
+	assert.Nil(t, err)
+	assert.NotNil(t, persister)
+
+	err = persister.Put([]byte("key"), []byte("value"))
+	assert.Nil(t, err)
+	val, err := persister.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), val)
+
+	err = persister.Destroy()
+	assert.Nil(t, err)
+}
@@ -1,8 +1,6 @@
 package storageUnit
 
 import (
-	"encoding/base64"
-	"errors"
 	"fmt"
 	"reflect"
 	"sync"
@@ -141,7 +139,7 @@ func (s *Unit) Get(key []byte) ([]byte, error) {
 			// if found in persistance unit, add it in cache
 			s.cacher.Put(key, v)
 		} else {
-			return nil, errors.New(fmt.Sprintf("key: %s not found", base64.StdEncoding.EncodeToString(key)))
+			return nil, storage.ErrKeyNotFound
 		}
 	}
 
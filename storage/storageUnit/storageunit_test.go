@@ -190,7 +190,7 @@ func TestGetNotPresent(t *testing.T) {
 	s := initStorageUnitWithBloomFilter(t, 10)
 	v, err := s.Get(key)
 
-	assert.NotNil(t, err, "expected to find no value, but found %s", v)
+	assert.Equal(t, storage.ErrKeyNotFound, err, "expected to find no value, but found %s", v)
 }
 
 func TestGetNotPresentWithNilBloomFilter(t *testing.T) {